@@ -0,0 +1,140 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/controller/provider/compound"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	"github.com/gardener/external-dns-management/pkg/dns/zonefile"
+)
+
+const cmdExportZone = "export-zone"
+
+// exportZone dumps the record sets of a hosted zone of a DNS provider in zone file format.
+func exportZone(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet(cmdExportZone, flag.ContinueOnError)
+	kubeconfig := fs.String("kubeconfig", os.Getenv("KUBECONFIG"), "path to the kubeconfig of the cluster containing the DNS provider")
+	providerName := fs.String("provider", "", "DNS provider given as <namespace>/<name>")
+	zoneID := fs.String("zone", "", "ID of the hosted zone to export")
+	timeout := fs.Duration("timeout", 5*time.Minute, "timeout for reading the zone")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	namespace, name, found := strings.Cut(*providerName, "/")
+	if !found || namespace == "" || name == "" {
+		return fmt.Errorf("option --provider must be given as <namespace>/<name>")
+	}
+	if *zoneID == "" {
+		return fmt.Errorf("option --zone is required")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	c, err := newExportClient(*kubeconfig)
+	if err != nil {
+		return err
+	}
+	return readZoneState(ctx, c, namespace, name, *zoneID, func(_ *v1alpha1.DNSProvider, zone provider.DNSHostedZone, state provider.DNSZoneState) error {
+		return zonefile.Write(out, zone.Domain(), state.GetDNSSets())
+	})
+}
+
+// readZoneState reads the state of a hosted zone of a DNS provider and calls the given function with it.
+func readZoneState(ctx context.Context, c client.Client, namespace, name, zoneID string,
+	f func(dnsProvider *v1alpha1.DNSProvider, zone provider.DNSHostedZone, state provider.DNSZoneState) error) error {
+	providerName := namespace + "/" + name
+	dnsProvider := &v1alpha1.DNSProvider{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, dnsProvider); err != nil {
+		return fmt.Errorf("cannot get DNS provider %s: %w", providerName, err)
+	}
+	props, err := readProviderSecret(ctx, c, dnsProvider)
+	if err != nil {
+		return err
+	}
+
+	log := logger.NewContext("provider", providerName)
+	cfg := &provider.DNSHandlerConfig{
+		Context:          ctx,
+		Logger:           log,
+		Properties:       props,
+		Config:           dnsProvider.Spec.ProviderConfig,
+		ZoneCacheFactory: *provider.NewZonesOnlyCacheFactory(ctx, log, time.Minute),
+		Options:          provider.GetFactoryOptions(provider.CreateFactoryOptionSource(compound.Factory, "")),
+		Metrics:          &provider.NullMetrics{},
+	}
+	handler, err := compound.Factory.Create(dnsProvider.Spec.Type, cfg)
+	if err != nil {
+		return fmt.Errorf("cannot create handler for provider type %s: %w", dnsProvider.Spec.Type, err)
+	}
+	defer handler.Release()
+
+	zones, err := handler.GetZones()
+	if err != nil {
+		return fmt.Errorf("cannot get zones: %w", err)
+	}
+	var ids []string
+	for _, zone := range zones {
+		if zone.Id().ID == zoneID {
+			state, err := handler.GetZoneState(zone)
+			if err != nil {
+				return fmt.Errorf("cannot get state of zone %s: %w", zoneID, err)
+			}
+			return f(dnsProvider, zone, state)
+		}
+		ids = append(ids, zone.Id().ID)
+	}
+	return fmt.Errorf("zone %s not found for provider %s (available: %s)", zoneID, providerName, strings.Join(ids, ", "))
+}
+
+func newExportClient(kubeconfig string) (client.Client, error) {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.ExplicitPath = kubeconfig
+	restConfig, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, &clientcmd.ConfigOverrides{}).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("cannot load kubeconfig: %w", err)
+	}
+	scheme := runtime.NewScheme()
+	utils.Must(corev1.AddToScheme(scheme))
+	utils.Must(v1alpha1.AddToScheme(scheme))
+	return client.New(restConfig, client.Options{Scheme: scheme})
+}
+
+func readProviderSecret(ctx context.Context, c client.Client, dnsProvider *v1alpha1.DNSProvider) (utils.Properties, error) {
+	ref := dnsProvider.Spec.SecretRef
+	if ref == nil {
+		return nil, fmt.Errorf("DNS provider %s/%s has no secret reference", dnsProvider.Namespace, dnsProvider.Name)
+	}
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = dnsProvider.Namespace
+	}
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, secret); err != nil {
+		return nil, fmt.Errorf("cannot get secret %s/%s: %w", namespace, ref.Name, err)
+	}
+	props := utils.Properties{}
+	for k, v := range secret.Data {
+		props[k] = string(v)
+	}
+	return props, nil
+}
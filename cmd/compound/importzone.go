@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/yaml"
+
+	"github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	"github.com/gardener/external-dns-management/pkg/dns/recordimport"
+)
+
+const cmdImportZone = "import-zone"
+
+// importZone creates DNS entries for the record sets existing in a hosted zone of a DNS provider.
+func importZone(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet(cmdImportZone, flag.ContinueOnError)
+	kubeconfig := fs.String("kubeconfig", os.Getenv("KUBECONFIG"), "path to the kubeconfig of the cluster containing the DNS provider")
+	providerName := fs.String("provider", "", "DNS provider given as <namespace>/<name>")
+	zoneID := fs.String("zone", "", "ID of the hosted zone to import")
+	entryNamespace := fs.String("namespace", "", "namespace of the created DNS entries (defaults to the namespace of the DNS provider)")
+	namePrefix := fs.String("name-prefix", "", "prefix for the names of the created DNS entries")
+	class := fs.String("class", "", "DNS class of the created DNS entries")
+	dryRun := fs.Bool("dry-run", false, "print the DNS entries as YAML manifests instead of creating them")
+	timeout := fs.Duration("timeout", 5*time.Minute, "timeout for reading the zone and creating the DNS entries")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	namespace, name, found := strings.Cut(*providerName, "/")
+	if !found || namespace == "" || name == "" {
+		return fmt.Errorf("option --provider must be given as <namespace>/<name>")
+	}
+	if *zoneID == "" {
+		return fmt.Errorf("option --zone is required")
+	}
+	if *entryNamespace == "" {
+		*entryNamespace = namespace
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	c, err := newExportClient(*kubeconfig)
+	if err != nil {
+		return err
+	}
+	var (
+		entries []*v1alpha1.DNSEntry
+		skipped []recordimport.Skipped
+	)
+	err = readZoneState(ctx, c, namespace, name, *zoneID, func(_ *v1alpha1.DNSProvider, zone provider.DNSHostedZone, state provider.DNSZoneState) error {
+		entries, skipped = recordimport.Entries(zone.Domain(), state.GetDNSSets(), recordimport.Options{
+			Namespace:  *entryNamespace,
+			NamePrefix: *namePrefix,
+			Class:      *class,
+		})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, s := range skipped {
+		fmt.Fprintf(out, "# skipped %s\n", s)
+	}
+	for _, entry := range entries {
+		if *dryRun {
+			data, err := yaml.Marshal(entry)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "---\n%s", data)
+			continue
+		}
+		if err := c.Create(ctx, entry); err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				fmt.Fprintf(out, "# skipped existing DNS entry %s/%s\n", entry.Namespace, entry.Name)
+				continue
+			}
+			return fmt.Errorf("cannot create DNS entry %s/%s: %w", entry.Namespace, entry.Name, err)
+		}
+		fmt.Fprintf(out, "created DNS entry %s/%s for %s\n", entry.Namespace, entry.Name, entry.Spec.DNSName)
+	}
+	return nil
+}
@@ -20,6 +20,7 @@ import (
 	istionetworkingv1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
 	istionetworkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	coordinationv1 "k8s.io/api/coordination/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc"
@@ -28,6 +29,7 @@ import (
 	gatewayapisv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	_ "github.com/gardener/external-dns-management/pkg/controller/admission"
 	_ "github.com/gardener/external-dns-management/pkg/controller/annotation/annotations"
 	_ "github.com/gardener/external-dns-management/pkg/controller/provider/alicloud"
 	_ "github.com/gardener/external-dns-management/pkg/controller/provider/aws"
@@ -35,13 +37,16 @@ import (
 	_ "github.com/gardener/external-dns-management/pkg/controller/provider/azure-private"
 	_ "github.com/gardener/external-dns-management/pkg/controller/provider/cloudflare"
 	_ "github.com/gardener/external-dns-management/pkg/controller/provider/compound/controller"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/desec"
 	_ "github.com/gardener/external-dns-management/pkg/controller/provider/google"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/hetzner"
 	_ "github.com/gardener/external-dns-management/pkg/controller/provider/infoblox"
 	_ "github.com/gardener/external-dns-management/pkg/controller/provider/netlify"
 	_ "github.com/gardener/external-dns-management/pkg/controller/provider/openstack"
 	_ "github.com/gardener/external-dns-management/pkg/controller/provider/powerdns"
 	_ "github.com/gardener/external-dns-management/pkg/controller/provider/remote"
 	_ "github.com/gardener/external-dns-management/pkg/controller/provider/rfc2136"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/vultr"
 	_ "github.com/gardener/external-dns-management/pkg/controller/replication/dnsprovider"
 	_ "github.com/gardener/external-dns-management/pkg/controller/source/dnsentry"
 	_ "github.com/gardener/external-dns-management/pkg/controller/source/gateways/crdwatch"
@@ -70,6 +75,7 @@ func init() {
 
 	utils.Must(resources.Register(v1alpha1.SchemeBuilder))
 	utils.Must(resources.Register(coordinationv1.SchemeBuilder))
+	utils.Must(resources.Register(discoveryv1.SchemeBuilder))
 	utils.Must(resources.Register(networkingv1.SchemeBuilder))
 	utils.Must(resources.Register(istionetworkingv1alpha3.SchemeBuilder))
 	utils.Must(resources.Register(istionetworkingv1beta1.SchemeBuilder))
@@ -87,5 +93,26 @@ func main() {
 		fmt.Println(Version)
 		os.Exit(0)
 	}
+	if len(os.Args) >= 2 && os.Args[1] == cmdExportZone {
+		if err := exportZone(os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "%s failed: %s\n", cmdExportZone, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	if len(os.Args) >= 2 && os.Args[1] == cmdImportZone {
+		if err := importZone(os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "%s failed: %s\n", cmdImportZone, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	if len(os.Args) >= 2 && os.Args[1] == cmdValidateProvider {
+		if err := validateProvider(os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "%s failed: %s\n", cmdValidateProvider, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
 	controllermanager.Start("dns-controller-manager", "dns controller manager", "nothing")
 }
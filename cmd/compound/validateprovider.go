@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	"github.com/gardener/external-dns-management/pkg/controller/provider/compound"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+)
+
+const cmdValidateProvider = "validate-provider"
+
+// validateProvider validates the secret and provider config of a DNS provider without accessing a cluster.
+// It creates the handler of the provider type and lists the hosted zones of the account.
+func validateProvider(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet(cmdValidateProvider, flag.ContinueOnError)
+	ptype := fs.String("type", "", "provider type of the DNS provider, e.g. aws-route53")
+	secretFile := fs.String("secret-file", "", "path to the manifest of the secret of the DNS provider")
+	configFile := fs.String("config-file", "", "path to the provider config (YAML or JSON) of the DNS provider (optional)")
+	timeout := fs.Duration("timeout", 2*time.Minute, "timeout for listing the hosted zones")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *ptype == "" {
+		return fmt.Errorf("option --type is required")
+	}
+	if types := compound.Factory.TypeCodes(); !types.Contains(*ptype) {
+		supported := types.AsArray()
+		sort.Strings(supported)
+		return fmt.Errorf("unknown provider type %q (supported: %s)", *ptype, strings.Join(supported, ", "))
+	}
+	if *secretFile == "" {
+		return fmt.Errorf("option --secret-file is required")
+	}
+
+	props, err := readSecretFile(*secretFile)
+	if err != nil {
+		return err
+	}
+	keys, err := compound.Factory.SecretKeys(*ptype)
+	if err != nil {
+		return err
+	}
+	if err := keys.Validate(*ptype, props); err != nil {
+		return err
+	}
+	if unknown := keys.Unknown(props); len(keys) > 0 && len(unknown) > 0 {
+		fmt.Fprintf(out, "warning: secret keys not used by provider type %s: %s\n", *ptype, strings.Join(unknown, ", "))
+	}
+	var providerConfig *runtime.RawExtension
+	if *configFile != "" {
+		providerConfig, err = readProviderConfigFile(*configFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	log := logger.NewContext("provider", *ptype)
+	cfg := &provider.DNSHandlerConfig{
+		Context:          ctx,
+		Logger:           log,
+		Properties:       props,
+		Config:           providerConfig,
+		ZoneCacheFactory: *provider.NewZonesOnlyCacheFactory(ctx, log, time.Minute),
+		Options:          provider.GetFactoryOptions(provider.CreateFactoryOptionSource(compound.Factory, "")),
+		Metrics:          &provider.NullMetrics{},
+	}
+	handler, err := compound.Factory.Create(*ptype, cfg)
+	if err != nil {
+		return fmt.Errorf("cannot create handler for provider type %s: %w", *ptype, err)
+	}
+	defer handler.Release()
+
+	zones, err := handler.GetZones()
+	if err != nil {
+		return fmt.Errorf("cannot get zones: %w", err)
+	}
+	sort.Slice(zones, func(i, j int) bool { return zones[i].Id().ID < zones[j].Id().ID })
+	fmt.Fprintf(out, "provider configuration is valid, %d hosted zone(s) found\n", len(zones))
+	for _, zone := range zones {
+		private := ""
+		if zone.IsPrivate() {
+			private = " (private)"
+		}
+		fmt.Fprintf(out, "%s\t%s%s\n", zone.Id().ID, zone.Domain(), private)
+	}
+	return nil
+}
+
+// readSecretFile reads the properties of a secret manifest, merging its fields data and stringData.
+func readSecretFile(filename string) (utils.Properties, error) {
+	content, err := os.ReadFile(filename) // #nosec G304 -- file given by the user
+	if err != nil {
+		return nil, fmt.Errorf("cannot read secret file: %w", err)
+	}
+	secret := &corev1.Secret{}
+	if err := yaml.UnmarshalStrict(content, secret); err != nil {
+		return nil, fmt.Errorf("invalid secret file %s: %w", filename, err)
+	}
+	if secret.Kind != "" && secret.Kind != "Secret" {
+		return nil, fmt.Errorf("invalid secret file %s: unexpected kind %s", filename, secret.Kind)
+	}
+	props := utils.Properties{}
+	for k, v := range secret.Data {
+		props[k] = string(v)
+	}
+	for k, v := range secret.StringData {
+		props[k] = v
+	}
+	if len(props) == 0 {
+		return nil, fmt.Errorf("secret file %s contains no data", filename)
+	}
+	return props, nil
+}
+
+// readProviderConfigFile reads the provider config given as YAML or JSON.
+func readProviderConfigFile(filename string) (*runtime.RawExtension, error) {
+	content, err := os.ReadFile(filename) // #nosec G304 -- file given by the user
+	if err != nil {
+		return nil, fmt.Errorf("cannot read config file: %w", err)
+	}
+	data, err := yaml.YAMLToJSON(content)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", filename, err)
+	}
+	return &runtime.RawExtension{Raw: data}, nil
+}
@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gardener/controller-manager-library/pkg/utils"
+
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/mock"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	filename := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(filename, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return filename
+}
+
+const mockSecret = `
+apiVersion: v1
+kind: Secret
+metadata:
+  name: mock
+data:
+  a: dmFsdWU=
+stringData:
+  b: other
+`
+
+func TestValidateProviderMock(t *testing.T) {
+	secretFile := writeFile(t, "secret.yaml", mockSecret)
+	configFile := writeFile(t, "config.yaml", `
+name: validate-provider-test
+zones:
+- zonePrefix: "z1:"
+  dnsName: b.example.com
+- zonePrefix: "z2:"
+  dnsName: a.example.com
+`)
+	out := &bytes.Buffer{}
+	err := validateProvider([]string{"--type", "mock-inmemory", "--secret-file", secretFile, "--config-file", configFile}, out)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := "provider configuration is valid, 2 hosted zone(s) found\n" +
+		"z1:b.example.com\tb.example.com\n" +
+		"z2:a.example.com\ta.example.com\n"
+	if out.String() != expected {
+		t.Errorf("unexpected output:\n%s\nexpected:\n%s", out.String(), expected)
+	}
+}
+
+func TestValidateProviderFailingZones(t *testing.T) {
+	secretFile := writeFile(t, "secret.yaml", mockSecret)
+	configFile := writeFile(t, "config.yaml", `{"name": "validate-provider-fail", "failGetZones": true}`)
+	err := validateProvider([]string{"--type", "mock-inmemory", "--secret-file", secretFile, "--config-file", configFile}, &bytes.Buffer{})
+	if err == nil || !strings.HasPrefix(err.Error(), "cannot get zones: ") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestValidateProviderOptions(t *testing.T) {
+	secretFile := writeFile(t, "secret.yaml", mockSecret)
+	tests := []struct {
+		args     []string
+		expected string
+	}{
+		{nil, "option --type is required"},
+		{[]string{"--type", "unknown"}, "unknown provider type \"unknown\""},
+		{[]string{"--type", "mock-inmemory"}, "option --secret-file is required"},
+		{[]string{"--type", "mock-inmemory", "--secret-file", secretFile, "--config-file", "/nonexisting"}, "cannot read config file: "},
+	}
+	for _, tt := range tests {
+		err := validateProvider(tt.args, &bytes.Buffer{})
+		if err == nil || !strings.HasPrefix(err.Error(), tt.expected) {
+			t.Errorf("args %v: expected error %q, got %v", tt.args, tt.expected, err)
+		}
+	}
+}
+
+func TestReadSecretFile(t *testing.T) {
+	props, err := readSecretFile(writeFile(t, "secret.yaml", mockSecret))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !props.Equals(utils.Properties{"a": "value", "b": "other"}) {
+		t.Errorf("unexpected properties: %v", props)
+	}
+
+	tests := []struct {
+		content  string
+		expected string
+	}{
+		{"kind: ConfigMap\ndata:\n  a: dmFsdWU=\n", "unexpected kind ConfigMap"},
+		{"kind: Secret\n", "contains no data"},
+		{"kind: Secret\nunknown: x\n", "invalid secret file"},
+	}
+	for _, tt := range tests {
+		filename := writeFile(t, "secret.yaml", tt.content)
+		if _, err := readSecretFile(filename); err == nil || !strings.Contains(err.Error(), tt.expected) {
+			t.Errorf("content %q: expected error containing %q, got %v", tt.content, tt.expected, err)
+		}
+	}
+	if _, err := readSecretFile(filepath.Join(t.TempDir(), "missing.yaml")); err == nil || !strings.HasPrefix(err.Error(), "cannot read secret file: ") {
+		t.Errorf("unexpected error for missing file: %v", err)
+	}
+}
+
+func TestReadProviderConfigFile(t *testing.T) {
+	for _, content := range []string{"name: test\nzones: []\n", `{"name": "test", "zones": []}`} {
+		config, err := readProviderConfigFile(writeFile(t, "config", content))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if string(config.Raw) != `{"name":"test","zones":[]}` {
+			t.Errorf("unexpected config: %s", config.Raw)
+		}
+	}
+	if _, err := readProviderConfigFile(writeFile(t, "config", "a: [")); err == nil || !strings.HasPrefix(err.Error(), "invalid config file ") {
+		t.Errorf("unexpected error for invalid config: %v", err)
+	}
+}
@@ -231,12 +231,60 @@ spec:
                   Only used if ` + "`" + `resolveTargetsToAddresses` + "`" + ` is set to true or targets consists of multiple domain names.
                 format: int64
                 type: integer
+              comment:
+                description: |-
+                  optional comment for the records, e.g. to tag them with the owning team.
+                  Only supported by some provider types (currently ` + "`" + `cloudflare-dns` + "`" + ` and ` + "`" + `infoblox-dns` + "`" + `), it is ignored by the other ones.
+                type: string
               dnsName:
                 description: full qualified domain name
                 type: string
+              dnsNames:
+                description: |-
+                  additional full qualified domain names (aliases) served with the same targets, TTL, and routing policy.
+                  For each alias, a DNS entry referencing this entry is maintained. All names must be served by the same provider.
+                items:
+                  type: string
+                type: array
               ownerId:
                 description: owner id used to tag entries in external DNS system
                 type: string
+              preserveOnDelete:
+                description: |-
+                  if set to true, the records are preserved in the DNS backend on deletion of the entry.
+                  Only the ownership of the records is released, so that they can be taken over by another system.
+                  It cannot be combined with ` + "`" + `dnsNames` + "`" + `.
+                type: boolean
+              providerRef:
+                description: |-
+                  reference to the DNSProvider to be used for the entry, overriding the selection of the best matching provider.
+                  If the referenced provider does not exist or its domain selection does not include the DNS name,
+                  the entry goes into error state.
+                properties:
+                  name:
+                    description: name of the referenced DNSProvider object
+                    type: string
+                  namespace:
+                    description: namespace of the referenced DNSProvider object, defaults
+                      to the namespace of the DNSEntry
+                    type: string
+                required:
+                - name
+                type: object
+              recordType:
+                description: record type of the values given in ` + "`" + `records` + "`" + `. Currently
+                  only ` + "`" + `SRV` + "`" + `, ` + "`" + `CAA` + "`" + `, ` + "`" + `MX` + "`" + `, ` + "`" + `NS` + "`" + `, ` + "`" + `DS` + "`" + `, and ` + "`" + `PTR` + "`" + ` are supported.
+                type: string
+              records:
+                description: |-
+                  records of the type given by ` + "`" + `recordType` + "`" + `, either text, targets or records must be specified.
+                  SRV records are specified in the format ` + "`" + `priority weight port target` + "`" + `,
+                  CAA records in the format ` + "`" + `flags tag value` + "`" + `, MX records in the format ` + "`" + `priority hostname` + "`" + `, NS records as name server domain names,
+                  DS records in the format ` + "`" + `keyTag algorithm digestType digest` + "`" + `,
+                  PTR records as hostnames for a reverse DNS name (e.g. ` + "`" + `4.3.2.1.in-addr.arpa` + "`" + `).
+                items:
+                  type: string
+                type: array
               reference:
                 description: reference to base entry used to inherit attributes from
                 properties:
@@ -249,6 +297,14 @@ spec:
                 required:
                 - name
                 type: object
+              resolveTargetsExclusions:
+                description: |-
+                  domain names of targets which are never resolved to addresses, but passed through as CNAME.
+                  A value ` + "`" + `example.com` + "`" + ` matches the domain itself and all subdomains, ` + "`" + `*.example.com` + "`" + ` matches only subdomains.
+                  Only used if ` + "`" + `resolveTargetsToAddresses` + "`" + ` is set to true. A target excluded from resolution must be the only target.
+                items:
+                  type: string
+                type: array
               resolveTargetsToAddresses:
                 description: |-
                   enables translation of a target domain name in the resolved IPv4 and IPv6 addresses.
@@ -275,6 +331,48 @@ spec:
                 - setIdentifier
                 - type
                 type: object
+              splitProviders:
+                description: |-
+                  opts in to the split mode, where the DNS name is served by all listed DNSProviders simultaneously,
+                  e.g. for the zone apex of a domain hosted by multiple DNS services (multi-cloud active-active DNS).
+                  At least two providers must be given. The entry is pinned to the first one, for each further provider
+                  a DNS entry referencing this entry is maintained. It cannot be combined with ` + "`" + `providerRef` + "`" + `, ` + "`" + `dnsNames` + "`" + `,
+                  or ` + "`" + `preserveOnDelete` + "`" + `.
+                items:
+                  properties:
+                    name:
+                      description: name of the referenced DNSProvider object
+                      type: string
+                    namespace:
+                      description: namespace of the referenced DNSProvider object,
+                        defaults to the namespace of the DNSEntry
+                      type: string
+                  required:
+                  - name
+                  type: object
+                type: array
+              targetRef:
+                description: |-
+                  reference to a Service of type LoadBalancer or an Ingress whose load balancer addresses are used as targets.
+                  Neither targets, text, nor records must be specified together with a target reference.
+                properties:
+                  kind:
+                    description: kind of the referenced object
+                    enum:
+                    - Service
+                    - Ingress
+                    type: string
+                  name:
+                    description: name of the referenced object
+                    type: string
+                  namespace:
+                    description: namespace of the referenced object, defaults to the
+                      namespace of the DNSEntry
+                    type: string
+                required:
+                - kind
+                - name
+                type: object
               targets:
                 description: target records (CNAME or A records), either text or targets
                   must be specified
@@ -287,7 +385,8 @@ spec:
                   type: string
                 type: array
               ttl:
-                description: time to live for records in external DNS system
+                description: time to live for records in external DNS system (0 for
+                  the default TTL of the provider)
                 format: int64
                 type: integer
             required:
@@ -295,11 +394,44 @@ spec:
             type: object
           status:
             properties:
+              aliases:
+                description: aliases contains the states of the additional DNS names
+                  given in ` + "`" + `spec.dnsNames` + "`" + `.
+                items:
+                  description: DNSEntryAliasStatus is the state of an additional DNS
+                    name of a DNS entry.
+                  properties:
+                    dnsName:
+                      description: DNS name of the alias
+                      type: string
+                    entry:
+                      description: name of the DNS entry maintained for the alias
+                      type: string
+                    message:
+                      description: message describing the reason for the state
+                      type: string
+                    state:
+                      description: state of the alias
+                      type: string
+                  required:
+                  - dnsName
+                  type: object
+                type: array
+              apexAlias:
+                description: |-
+                  apexAlias is the strategy used for a CNAME target of a DNS name at the zone apex, where CNAME records are not allowed:
+                  ` + "`" + `native` + "`" + ` if the alias records or CNAME flattening of the provider are used, ` + "`" + `resolved` + "`" + ` if the target is resolved to addresses.
+                type: string
               cnameLookupInterval:
                 description: effective lookup interval for CNAMEs that must be resolved
                   to IP addresses
                 format: int64
                 type: integer
+              lastBackendSyncTime:
+                description: lastBackendSyncTime contains the timestamp of the last
+                  change of the entry's records written to the DNS backend
+                format: date-time
+                type: string
               lastUpdateTime:
                 description: lastUpdateTime contains the timestamp of the last status
                   update
@@ -308,9 +440,34 @@ spec:
               message:
                 description: message describing the reason for the state
                 type: string
+              mirror:
+                description: mirror contains the state of the copy of the records
+                  in the mirror provider of the assigned provider.
+                properties:
+                  entry:
+                    description: name of the DNS entry maintained for the mirror provider
+                    type: string
+                  message:
+                    description: message describing the reason for the state
+                    type: string
+                  provider:
+                    description: mirror provider (namespace/name)
+                    type: string
+                  state:
+                    description: state of the DNS name in the mirror provider
+                    type: string
+                required:
+                - provider
+                type: object
               observedGeneration:
                 format: int64
                 type: integer
+              propagated:
+                description: |-
+                  propagated indicates if the records of the entry have been verified to be resolvable
+                  at the authoritative name servers of the zone after the last change.
+                  It is only set if propagation verification is enabled.
+                type: boolean
               provider:
                 description: assigned provider
                 type: string
@@ -337,6 +494,29 @@ spec:
                 - setIdentifier
                 - type
                 type: object
+              splitProviders:
+                description: splitProviders contains the states of the DNS name for
+                  each provider given in ` + "`" + `spec.splitProviders` + "`" + `.
+                items:
+                  description: DNSEntrySplitProviderStatus is the state of a DNS entry
+                    for one of the providers of the split mode.
+                  properties:
+                    entry:
+                      description: name of the DNS entry maintained for the provider
+                      type: string
+                    message:
+                      description: message describing the reason for the state
+                      type: string
+                    provider:
+                      description: provider serving the DNS name (namespace/name)
+                      type: string
+                    state:
+                      description: state of the DNS name in the provider
+                      type: string
+                  required:
+                  - provider
+                  type: object
+                type: array
               state:
                 description: entry state
                 type: string
@@ -414,6 +594,35 @@ spec:
               policy:
                 description: ZonePolicy specifies zone specific policy
                 properties:
+                  paused:
+                    description: |-
+                      Paused stops the reconciliation of the zone. No changes are applied to the DNS records of the zone
+                      and the DNS entries keep their current state until the zone is unpaused.
+                    type: boolean
+                  rateLimit:
+                    description: |-
+                      RateLimit specifies the rate limit for create/update operations on DNS entries in the zone.
+                      It overwrites the rate limit of the providers serving the zone.
+                    properties:
+                      burst:
+                        description: |-
+                          Burst allows bursts of up to 'burst' to exceed the rate defined by 'RequestsPerDay', while still maintaining a
+                          smoothed rate of 'RequestsPerDay'
+                        type: integer
+                      requestsPerDay:
+                        description: RequestsPerDay is create/update request rate
+                          per DNS entry given by requests per day
+                        type: integer
+                    required:
+                    - burst
+                    - requestsPerDay
+                    type: object
+                  recordSetLimit:
+                    description: |-
+                      RecordSetLimit specifies the maximum number of record sets in the zone.
+                      New DNS entries are refused if the limit would be exceeded. It overwrites the limit configured for the controller.
+                    minimum: 0
+                    type: integer
                   zoneStateCacheTTL:
                     description: ZoneStateCacheTTL specifies the TTL for the zone
                       state cache
@@ -459,6 +668,23 @@ spec:
                 description: In case of a configuration problem this field describes
                   the reason
                 type: string
+              rateLimit:
+                description: RateLimit is the effective rate limit applied to the
+                  DNS entries of the selected zones
+                properties:
+                  burst:
+                    description: |-
+                      Burst allows bursts of up to 'burst' to exceed the rate defined by 'RequestsPerDay', while still maintaining a
+                      smoothed rate of 'RequestsPerDay'
+                    type: integer
+                  requestsPerDay:
+                    description: RequestsPerDay is create/update request rate per
+                      DNS entry given by requests per day
+                    type: integer
+                required:
+                - burst
+                - requestsPerDay
+                type: object
               zones:
                 description: Indicates that annotation is observed by a DNS sorce
                   controller
@@ -555,6 +781,21 @@ spec:
                   state of the ownerid for the DNS controller observing entry using this owner id
                   (default:true)
                 type: boolean
+              namespaceSelector:
+                description: |-
+                  NamespaceSelector restricts the owner id to entries in the selected namespaces.
+                  If not set, the owner id is valid for entries in all namespaces.
+                properties:
+                  matchNames:
+                    description: MatchNames is the list of namespaces of entries the
+                      owner id is valid for.
+                    items:
+                      type: string
+                    minItems: 1
+                    type: array
+                required:
+                - matchNames
+                type: object
               ownerId:
                 description: owner id used to tag entries in external DNS system
                 type: string
@@ -656,6 +897,12 @@ spec:
             type: object
           spec:
             properties:
+              credentialsPath:
+                description: |-
+                  path of the access credentials mounted into the controller as alternative to the secretRef,
+                  relative to the credentials directory of the controller. It is either a directory with one file per key
+                  (like a mounted secret) or a YAML file with the keys and values.
+                type: string
               defaultTTL:
                 description: default TTL used for DNS entries if not specified explicitly
                 format: int64
@@ -676,6 +923,41 @@ spec:
                       type: string
                     type: array
                 type: object
+              maxConcurrentRequests:
+                description: |-
+                  maximum number of concurrent requests to the DNS backend of the provider account
+                  (by default the number of concurrent requests is not limited)
+                minimum: 1
+                type: integer
+              migration:
+                description: migration of the DNS entries assigned to this provider
+                  to another provider without downtime
+                properties:
+                  targetProvider:
+                    description: name of the DNSProvider in the same namespace taking
+                      over the DNS entries of this provider
+                    type: string
+                required:
+                - targetProvider
+                type: object
+              mirror:
+                description: |-
+                  mirror of the records of the DNS entries assigned to this provider in a secondary provider, e.g. for disaster recovery.
+                  Failures of the mirror provider do not block this provider.
+                properties:
+                  targetProvider:
+                    description: name of the DNSProvider in the same namespace keeping
+                      a copy of the records of this provider
+                    type: string
+                required:
+                - targetProvider
+                type: object
+              priority:
+                description: |-
+                  priority of the provider if several providers match a DNS entry equally well.
+                  The provider with the highest priority is preferred (default 0).
+                format: int32
+                type: integer
               providerConfig:
                 description: optional additional provider specific configuration values
                 type: object
@@ -697,6 +979,22 @@ spec:
                 - burst
                 - requestsPerDay
                 type: object
+              recordTypeSelection:
+                description: |-
+                  desired selection of record types managed by this provider
+                  (by default all supported record types are managed)
+                properties:
+                  exclude:
+                    description: values that should be ignored (domains or zones)
+                    items:
+                      type: string
+                    type: array
+                  include:
+                    description: values that should be observed (domains or zones)
+                    items:
+                      type: string
+                    type: array
+                type: object
               secretRef:
                 description: access credential for the external DNS system of the
                   given type
@@ -715,6 +1013,54 @@ spec:
                 description: type of the provider (selecting the responsible type
                   of DNS controller)
                 type: string
+              zoneTagSelector:
+                description: |-
+                  selection of zones by their tags (AWS Route53) or labels (Google CloudDNS)
+                  zones matching the selector are served in addition to explicitly included zones
+                properties:
+                  matchExpressions:
+                    description: matchExpressions is a list of label selector requirements.
+                      The requirements are ANDed.
+                    items:
+                      description: |-
+                        A label selector requirement is a selector that contains values, a key, and an operator that
+                        relates the key and values.
+                      properties:
+                        key:
+                          description: key is the label key that the selector applies
+                            to.
+                          type: string
+                        operator:
+                          description: |-
+                            operator represents a key's relationship to a set of values.
+                            Valid operators are In, NotIn, Exists and DoesNotExist.
+                          type: string
+                        values:
+                          description: |-
+                            values is an array of string values. If the operator is In or NotIn,
+                            the values array must be non-empty. If the operator is Exists or DoesNotExist,
+                            the values array must be empty. This array is replaced during a strategic
+                            merge patch.
+                          items:
+                            type: string
+                          type: array
+                          x-kubernetes-list-type: atomic
+                      required:
+                      - key
+                      - operator
+                      type: object
+                    type: array
+                    x-kubernetes-list-type: atomic
+                  matchLabels:
+                    additionalProperties:
+                      type: string
+                    description: |-
+                      matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels
+                      map is equivalent to an element of matchExpressions, whose key field is "key", the
+                      operator is "In", and the values array contains only "value". The requirements are ANDed.
+                    type: object
+                type: object
+                x-kubernetes-map-type: atomic
               zones:
                 description: |-
                   desired selection of usable domains
@@ -735,6 +1081,64 @@ spec:
             type: object
           status:
             properties:
+              conditions:
+                description: conditions of the provider, e.g. the result of the periodic
+                  credentials probe
+                items:
+                  description: Condition contains details for one aspect of the current
+                    state of this API Resource.
+                  properties:
+                    lastTransitionTime:
+                      description: |-
+                        lastTransitionTime is the last time the condition transitioned from one status to another.
+                        This should be when the underlying condition changed.  If that is not known, then using the time when the API field changed is acceptable.
+                      format: date-time
+                      type: string
+                    message:
+                      description: |-
+                        message is a human readable message indicating details about the transition.
+                        This may be an empty string.
+                      maxLength: 32768
+                      type: string
+                    observedGeneration:
+                      description: |-
+                        observedGeneration represents the .metadata.generation that the condition was set based upon.
+                        For instance, if .metadata.generation is currently 12, but the .status.conditions[x].observedGeneration is 9, the condition is out of date
+                        with respect to the current state of the instance.
+                      format: int64
+                      minimum: 0
+                      type: integer
+                    reason:
+                      description: |-
+                        reason contains a programmatic identifier indicating the reason for the condition's last transition.
+                        Producers of specific condition types may define expected values and meanings for this field,
+                        and whether the values are considered a guaranteed API.
+                        The value should be a CamelCase string.
+                        This field may not be empty.
+                      maxLength: 1024
+                      minLength: 1
+                      pattern: ^[A-Za-z]([A-Za-z0-9_,:]*[A-Za-z0-9_])?$
+                      type: string
+                    status:
+                      description: status of the condition, one of True, False, Unknown.
+                      enum:
+                      - "True"
+                      - "False"
+                      - Unknown
+                      type: string
+                    type:
+                      description: type of condition in CamelCase or in foo.example.com/CamelCase.
+                      maxLength: 316
+                      pattern: ^([a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/)?(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])$
+                      type: string
+                  required:
+                  - lastTransitionTime
+                  - message
+                  - reason
+                  - status
+                  - type
+                  type: object
+                type: array
               defaultTTL:
                 description: actually used default TTL for DNS entries
                 format: int64
@@ -762,9 +1166,72 @@ spec:
                 description: message describing the reason for the actual state of
                   the provider
                 type: string
+              migration:
+                description: status of the migration of the DNS entries to the target
+                  provider
+                properties:
+                  message:
+                    description: message describing the progress of the migration
+                    type: string
+                  pendingEntries:
+                    description: number of DNS entries still assigned to this provider
+                    type: integer
+                  phase:
+                    description: phase of the migration (Preparing, Switching, or
+                      Completed)
+                    type: string
+                  preparedEntries:
+                    description: number of DNS entries assigned to this provider whose
+                      records are in place in the target provider
+                    type: integer
+                required:
+                - pendingEntries
+                - phase
+                - preparedEntries
+                type: object
+              mirror:
+                description: status of the mirror of the DNS entries in the target
+                  provider
+                properties:
+                  entries:
+                    description: number of DNS entries assigned to this provider
+                    type: integer
+                  message:
+                    description: message describing the state of the mirror
+                    type: string
+                  mirroredEntries:
+                    description: number of DNS entries assigned to this provider whose
+                      records are in place in the mirror provider
+                    type: integer
+                required:
+                - entries
+                - mirroredEntries
+                type: object
               observedGeneration:
                 format: int64
                 type: integer
+              problematicZones:
+                description: expected zones not served together with the reason
+                items:
+                  description: |-
+                    ProblematicZone is a zone expected to be served by a provider, which is not served.
+                    A zone is expected if it is included explicitly, selected by tags, or contains an included domain.
+                  properties:
+                    id:
+                      description: id of the zone
+                      type: string
+                    message:
+                      description: message with details
+                      type: string
+                    reason:
+                      description: reason why the zone is not served (excluded-by-selector,
+                        not-found, or access-denied)
+                      type: string
+                  required:
+                  - id
+                  - reason
+                  type: object
+                type: array
               rateLimit:
                 description: actually used rate limit for create/update operations
                   on DNSEntries assigned to this provider
@@ -785,6 +1252,26 @@ spec:
               state:
                 description: state of the provider
                 type: string
+              zoneRecordSets:
+                description: number of record sets of the served zones
+                items:
+                  properties:
+                    count:
+                      description: number of record sets in the zone found on the
+                        last zone reconciliation
+                      type: integer
+                    limit:
+                      description: maximum number of record sets in the zone, new
+                        DNS entries are refused if exceeded
+                      type: integer
+                    zoneID:
+                      description: ID of the zone
+                      type: string
+                  required:
+                  - count
+                  - zoneID
+                  type: object
+                type: array
               zones:
                 description: actually served zones
                 properties:
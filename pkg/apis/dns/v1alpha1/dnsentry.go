@@ -49,13 +49,33 @@ type DNSEntry struct {
 type DNSEntrySpec struct {
 	// full qualified domain name
 	DNSName string `json:"dnsName"`
+	// additional full qualified domain names (aliases) served with the same targets, TTL, and routing policy.
+	// For each alias, a DNS entry referencing this entry is maintained. All names must be served by the same provider.
+	// +optional
+	DNSNames []string `json:"dnsNames,omitempty"`
 	// reference to base entry used to inherit attributes from
 	// +optional
 	Reference *EntryReference `json:"reference,omitempty"`
+	// reference to a Service of type LoadBalancer or an Ingress whose load balancer addresses are used as targets.
+	// Neither targets, text, nor records must be specified together with a target reference.
+	// +optional
+	TargetRef *TargetReference `json:"targetRef,omitempty"`
+	// reference to the DNSProvider to be used for the entry, overriding the selection of the best matching provider.
+	// If the referenced provider does not exist or its domain selection does not include the DNS name,
+	// the entry goes into error state.
+	// +optional
+	ProviderRef *ProviderReference `json:"providerRef,omitempty"`
+	// opts in to the split mode, where the DNS name is served by all listed DNSProviders simultaneously,
+	// e.g. for the zone apex of a domain hosted by multiple DNS services (multi-cloud active-active DNS).
+	// At least two providers must be given. The entry is pinned to the first one, for each further provider
+	// a DNS entry referencing this entry is maintained. It cannot be combined with `providerRef`, `dnsNames`,
+	// or `preserveOnDelete`.
+	// +optional
+	SplitProviders []ProviderReference `json:"splitProviders,omitempty"`
 	// owner id used to tag entries in external DNS system
 	// +optional
 	OwnerId *string `json:"ownerId,omitempty"`
-	// time to live for records in external DNS system
+	// time to live for records in external DNS system (0 for the default TTL of the provider)
 	// +optional
 	TTL *int64 `json:"ttl,omitempty"`
 	// lookup interval for CNAMEs that must be resolved to IP addresses.
@@ -67,15 +87,39 @@ type DNSEntrySpec struct {
 	// If the target list contains multiple targets, it is enabled implicitly.
 	// +optional
 	ResolveTargetsToAddresses *bool `json:"resolveTargetsToAddresses,omitempty"`
+	// domain names of targets which are never resolved to addresses, but passed through as CNAME.
+	// A value `example.com` matches the domain itself and all subdomains, `*.example.com` matches only subdomains.
+	// Only used if `resolveTargetsToAddresses` is set to true. A target excluded from resolution must be the only target.
+	// +optional
+	ResolveTargetsExclusions []string `json:"resolveTargetsExclusions,omitempty"`
 	// text records, either text or targets must be specified
 	// +optional
 	Text []string `json:"text,omitempty"`
 	// target records (CNAME or A records), either text or targets must be specified
 	// +optional
 	Targets []string `json:"targets,omitempty"`
+	// record type of the values given in `records`. Currently only `SRV`, `CAA`, `MX`, `NS`, `DS`, and `PTR` are supported.
+	// +optional
+	RecordType string `json:"recordType,omitempty"`
+	// records of the type given by `recordType`, either text, targets or records must be specified.
+	// SRV records are specified in the format `priority weight port target`,
+	// CAA records in the format `flags tag value`, MX records in the format `priority hostname`, NS records as name server domain names,
+	// DS records in the format `keyTag algorithm digestType digest`,
+	// PTR records as hostnames for a reverse DNS name (e.g. `4.3.2.1.in-addr.arpa`).
+	// +optional
+	Records []string `json:"records,omitempty"`
 	// optional routing policy
 	// +optional
 	RoutingPolicy *RoutingPolicy `json:"routingPolicy,omitempty"`
+	// optional comment for the records, e.g. to tag them with the owning team.
+	// Only supported by some provider types (currently `cloudflare-dns` and `infoblox-dns`), it is ignored by the other ones.
+	// +optional
+	Comment *string `json:"comment,omitempty"`
+	// if set to true, the records are preserved in the DNS backend on deletion of the entry.
+	// Only the ownership of the records is released, so that they can be taken over by another system.
+	// It cannot be combined with `dnsNames`.
+	// +optional
+	PreserveOnDelete *bool `json:"preserveOnDelete,omitempty"`
 }
 
 type DNSEntryStatus struct {
@@ -90,6 +134,9 @@ type DNSEntryStatus struct {
 	// lastUpdateTime contains the timestamp of the last status update
 	// +optional
 	LastUptimeTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+	// lastBackendSyncTime contains the timestamp of the last change of the entry's records written to the DNS backend
+	// +optional
+	LastBackendSyncTime *metav1.Time `json:"lastBackendSyncTime,omitempty"`
 	// provider type used for the entry
 	// +optional
 	ProviderType *string `json:"providerType,omitempty"`
@@ -111,6 +158,69 @@ type DNSEntryStatus struct {
 	// effective lookup interval for CNAMEs that must be resolved to IP addresses
 	// +optional
 	CNameLookupInterval *int64 `json:"cnameLookupInterval,omitempty"`
+	// apexAlias is the strategy used for a CNAME target of a DNS name at the zone apex, where CNAME records are not allowed:
+	// `native` if the alias records or CNAME flattening of the provider are used, `resolved` if the target is resolved to addresses.
+	// +optional
+	ApexAlias *string `json:"apexAlias,omitempty"`
+	// propagated indicates if the records of the entry have been verified to be resolvable
+	// at the authoritative name servers of the zone after the last change.
+	// It is only set if propagation verification is enabled.
+	// +optional
+	Propagated *bool `json:"propagated,omitempty"`
+	// aliases contains the states of the additional DNS names given in `spec.dnsNames`.
+	// +optional
+	Aliases []DNSEntryAliasStatus `json:"aliases,omitempty"`
+	// splitProviders contains the states of the DNS name for each provider given in `spec.splitProviders`.
+	// +optional
+	SplitProviders []DNSEntrySplitProviderStatus `json:"splitProviders,omitempty"`
+	// mirror contains the state of the copy of the records in the mirror provider of the assigned provider.
+	// +optional
+	Mirror *DNSEntryMirrorStatus `json:"mirror,omitempty"`
+}
+
+// DNSEntryMirrorStatus is the state of the copy of the records of a DNS entry in a mirror provider.
+type DNSEntryMirrorStatus struct {
+	// mirror provider (namespace/name)
+	Provider string `json:"provider"`
+	// name of the DNS entry maintained for the mirror provider
+	// +optional
+	Entry string `json:"entry,omitempty"`
+	// state of the DNS name in the mirror provider
+	// +optional
+	State string `json:"state,omitempty"`
+	// message describing the reason for the state
+	// +optional
+	Message *string `json:"message,omitempty"`
+}
+
+// DNSEntryAliasStatus is the state of an additional DNS name of a DNS entry.
+type DNSEntryAliasStatus struct {
+	// DNS name of the alias
+	DNSName string `json:"dnsName"`
+	// name of the DNS entry maintained for the alias
+	// +optional
+	Entry string `json:"entry,omitempty"`
+	// state of the alias
+	// +optional
+	State string `json:"state,omitempty"`
+	// message describing the reason for the state
+	// +optional
+	Message *string `json:"message,omitempty"`
+}
+
+// DNSEntrySplitProviderStatus is the state of a DNS entry for one of the providers of the split mode.
+type DNSEntrySplitProviderStatus struct {
+	// provider serving the DNS name (namespace/name)
+	Provider string `json:"provider"`
+	// name of the DNS entry maintained for the provider
+	// +optional
+	Entry string `json:"entry,omitempty"`
+	// state of the DNS name in the provider
+	// +optional
+	State string `json:"state,omitempty"`
+	// message describing the reason for the state
+	// +optional
+	Message *string `json:"message,omitempty"`
 }
 
 type EntryReference struct {
@@ -121,6 +231,25 @@ type EntryReference struct {
 	Namespace string `json:"namespace,omitempty"`
 }
 
+type ProviderReference struct {
+	// name of the referenced DNSProvider object
+	Name string `json:"name"`
+	// namespace of the referenced DNSProvider object, defaults to the namespace of the DNSEntry
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+type TargetReference struct {
+	// kind of the referenced object
+	// +kubebuilder:validation:Enum=Service;Ingress
+	Kind string `json:"kind"`
+	// name of the referenced object
+	Name string `json:"name"`
+	// namespace of the referenced object, defaults to the namespace of the DNSEntry
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
 type RoutingPolicy struct {
 	// Policy is the policy type. Allowed values are provider dependent, e.g. `weighted`
 	Type string `json:"type"`
@@ -60,6 +60,19 @@ type ZonePolicy struct {
 	// ZoneStateCacheTTL specifies the TTL for the zone state cache
 	// +optional
 	ZoneStateCacheTTL *metav1.Duration `json:"zoneStateCacheTTL,omitempty"`
+	// Paused stops the reconciliation of the zone. No changes are applied to the DNS records of the zone
+	// and the DNS entries keep their current state until the zone is unpaused.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+	// RecordSetLimit specifies the maximum number of record sets in the zone.
+	// New DNS entries are refused if the limit would be exceeded. It overwrites the limit configured for the controller.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RecordSetLimit *int `json:"recordSetLimit,omitempty"`
+	// RateLimit specifies the rate limit for create/update operations on DNS entries in the zone.
+	// It overwrites the rate limit of the providers serving the zone.
+	// +optional
+	RateLimit *RateLimit `json:"rateLimit,omitempty"`
 }
 
 type DNSHostedZonePolicyStatus struct {
@@ -69,6 +82,9 @@ type DNSHostedZonePolicyStatus struct {
 	// Indicates that annotation is observed by a DNS sorce controller
 	// +optional
 	Zones []ZoneInfo `json:"zones,omitempty"`
+	// RateLimit is the effective rate limit applied to the DNS entries of the selected zones
+	// +optional
+	RateLimit *RateLimit `json:"rateLimit,omitempty"`
 	// LastStatusUpdateTime contains the timestamp of the last status update
 	// +optional
 	LastStatusUpdateTime *metav1.Time `json:"lastStatusUpdateTime,omitempty"`
@@ -45,6 +45,16 @@ type DNSOwnerSpec struct {
 	// (default:true)
 	// +optional
 	Active *bool `json:"active,omitempty"`
+	// NamespaceSelector restricts the owner id to entries in the selected namespaces.
+	// If not set, the owner id is valid for entries in all namespaces.
+	// +optional
+	NamespaceSelector *DNSOwnerNamespaceSelector `json:"namespaceSelector,omitempty"`
+}
+
+type DNSOwnerNamespaceSelector struct {
+	// MatchNames is the list of namespaces of entries the owner id is valid for.
+	// +kubebuilder:validation:MinItems=1
+	MatchNames []string `json:"matchNames"`
 }
 
 type DNSOwnerStatus struct {
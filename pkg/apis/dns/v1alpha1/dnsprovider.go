@@ -51,6 +51,11 @@ type DNSProviderSpec struct {
 	ProviderConfig *runtime.RawExtension `json:"providerConfig,omitempty"`
 	// access credential for the external DNS system of the given type
 	SecretRef *corev1.SecretReference `json:"secretRef,omitempty"`
+	// path of the access credentials mounted into the controller as alternative to the secretRef,
+	// relative to the credentials directory of the controller. It is either a directory with one file per key
+	// (like a mounted secret) or a YAML file with the keys and values.
+	// +optional
+	CredentialsPath *string `json:"credentialsPath,omitempty"`
 	// desired selection of usable domains
 	// (by default all zones and domains in those zones will be served)
 	// +optional
@@ -60,12 +65,46 @@ type DNSProviderSpec struct {
 	// (by default all zones will be served)
 	// +optional
 	Zones *DNSSelection `json:"zones,omitempty"`
+	// selection of zones by their tags (AWS Route53) or labels (Google CloudDNS)
+	// zones matching the selector are served in addition to explicitly included zones
+	// +optional
+	ZoneTagSelector *metav1.LabelSelector `json:"zoneTagSelector,omitempty"`
+	// desired selection of record types managed by this provider
+	// (by default all supported record types are managed)
+	// +optional
+	RecordTypeSelection *DNSSelection `json:"recordTypeSelection,omitempty"`
 	// default TTL used for DNS entries if not specified explicitly
 	// +optional
 	DefaultTTL *int64 `json:"defaultTTL,omitempty"`
 	// rate limit for create/update operations on DNSEntries assigned to this provider
 	// +optional
 	RateLimit *RateLimit `json:"rateLimit,omitempty"`
+	// maximum number of concurrent requests to the DNS backend of the provider account
+	// (by default the number of concurrent requests is not limited)
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxConcurrentRequests *int `json:"maxConcurrentRequests,omitempty"`
+	// migration of the DNS entries assigned to this provider to another provider without downtime
+	// +optional
+	Migration *DNSProviderMigration `json:"migration,omitempty"`
+	// priority of the provider if several providers match a DNS entry equally well.
+	// The provider with the highest priority is preferred (default 0).
+	// +optional
+	Priority *int32 `json:"priority,omitempty"`
+	// mirror of the records of the DNS entries assigned to this provider in a secondary provider, e.g. for disaster recovery.
+	// Failures of the mirror provider do not block this provider.
+	// +optional
+	Mirror *DNSProviderMirror `json:"mirror,omitempty"`
+}
+
+type DNSProviderMirror struct {
+	// name of the DNSProvider in the same namespace keeping a copy of the records of this provider
+	TargetProvider string `json:"targetProvider"`
+}
+
+type DNSProviderMigration struct {
+	// name of the DNSProvider in the same namespace taking over the DNS entries of this provider
+	TargetProvider string `json:"targetProvider"`
 }
 
 type RateLimit struct {
@@ -102,12 +141,59 @@ type DNSProviderStatus struct {
 	// actually served zones
 	// +optional
 	Zones DNSSelectionStatus `json:"zones"`
+	// expected zones not served together with the reason
+	// +optional
+	ProblematicZones []ProblematicZone `json:"problematicZones,omitempty"`
 	// actually used default TTL for DNS entries
 	// +optional
 	DefaultTTL *int64 `json:"defaultTTL,omitempty"`
 	// actually used rate limit for create/update operations on DNSEntries assigned to this provider
 	// +optional
 	RateLimit *RateLimit `json:"rateLimit,omitempty"`
+	// conditions of the provider, e.g. the result of the periodic credentials probe
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// number of record sets of the served zones
+	// +optional
+	ZoneRecordSets []ZoneRecordSetCount `json:"zoneRecordSets,omitempty"`
+	// status of the migration of the DNS entries to the target provider
+	// +optional
+	Migration *DNSProviderMigrationStatus `json:"migration,omitempty"`
+	// status of the mirror of the DNS entries in the target provider
+	// +optional
+	Mirror *DNSProviderMirrorStatus `json:"mirror,omitempty"`
+}
+
+type DNSProviderMirrorStatus struct {
+	// number of DNS entries assigned to this provider
+	Entries int `json:"entries"`
+	// number of DNS entries assigned to this provider whose records are in place in the mirror provider
+	MirroredEntries int `json:"mirroredEntries"`
+	// message describing the state of the mirror
+	// +optional
+	Message *string `json:"message,omitempty"`
+}
+
+type DNSProviderMigrationStatus struct {
+	// phase of the migration (Preparing, Switching, or Completed)
+	Phase string `json:"phase"`
+	// number of DNS entries still assigned to this provider
+	PendingEntries int `json:"pendingEntries"`
+	// number of DNS entries assigned to this provider whose records are in place in the target provider
+	PreparedEntries int `json:"preparedEntries"`
+	// message describing the progress of the migration
+	// +optional
+	Message *string `json:"message,omitempty"`
+}
+
+type ZoneRecordSetCount struct {
+	// ID of the zone
+	ZoneID string `json:"zoneID"`
+	// number of record sets in the zone found on the last zone reconciliation
+	Count int `json:"count"`
+	// maximum number of record sets in the zone, new DNS entries are refused if exceeded
+	// +optional
+	Limit *int `json:"limit,omitempty"`
 }
 
 type DNSSelectionStatus struct {
@@ -118,3 +204,15 @@ type DNSSelectionStatus struct {
 	// + optional
 	Excluded []string `json:"excluded,omitempty"`
 }
+
+// ProblematicZone is a zone expected to be served by a provider, which is not served.
+// A zone is expected if it is included explicitly, selected by tags, or contains an included domain.
+type ProblematicZone struct {
+	// id of the zone
+	ID string `json:"id"`
+	// reason why the zone is not served (excluded-by-selector, not-found, or access-denied)
+	Reason string `json:"reason"`
+	// message with details
+	// +optional
+	Message string `json:"message,omitempty"`
+}
@@ -13,3 +13,34 @@ const (
 	STATE_DELETING = "Deleting"
 	STATE_IGNORED  = "Ignored"
 )
+
+const (
+	// APEX_ALIAS_NATIVE is the apex alias strategy using the alias records or CNAME flattening of the provider.
+	APEX_ALIAS_NATIVE = "native"
+	// APEX_ALIAS_RESOLVED is the apex alias strategy resolving the CNAME target to addresses.
+	APEX_ALIAS_RESOLVED = "resolved"
+)
+
+const (
+	// CONDITION_CREDENTIALS_VALID is the condition type of a DNSProvider reporting the result of the credentials probe.
+	CONDITION_CREDENTIALS_VALID = "CredentialsValid"
+)
+
+const (
+	// MIGRATION_PHASE_PREPARING is the migration phase creating the records of the DNS entries in the target provider.
+	// The source provider is read-only in this phase.
+	MIGRATION_PHASE_PREPARING = "Preparing"
+	// MIGRATION_PHASE_SWITCHING is the migration phase assigning the DNS entries to the target provider.
+	MIGRATION_PHASE_SWITCHING = "Switching"
+	// MIGRATION_PHASE_COMPLETED is the migration phase after all DNS entries have been assigned to the target provider.
+	MIGRATION_PHASE_COMPLETED = "Completed"
+)
+
+const (
+	// ZONE_PROBLEM_EXCLUDED_BY_SELECTOR is the reason of a zone not served as excluded by the zone or domain selection.
+	ZONE_PROBLEM_EXCLUDED_BY_SELECTOR = "excluded-by-selector"
+	// ZONE_PROBLEM_ACCESS_DENIED is the reason of a zone not served as the credentials grant no access to it.
+	ZONE_PROBLEM_ACCESS_DENIED = "access-denied"
+	// ZONE_PROBLEM_NOT_FOUND is the reason of a zone not served as it is not found in the account.
+	ZONE_PROBLEM_NOT_FOUND = "not-found"
+)
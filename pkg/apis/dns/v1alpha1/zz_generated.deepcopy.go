@@ -143,6 +143,27 @@ func (in *DNSEntry) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSEntryAliasStatus) DeepCopyInto(out *DNSEntryAliasStatus) {
+	*out = *in
+	if in.Message != nil {
+		in, out := &in.Message, &out.Message
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSEntryAliasStatus.
+func (in *DNSEntryAliasStatus) DeepCopy() *DNSEntryAliasStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSEntryAliasStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DNSEntryList) DeepCopyInto(out *DNSEntryList) {
 	*out = *in
@@ -176,14 +197,55 @@ func (in *DNSEntryList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSEntryMirrorStatus) DeepCopyInto(out *DNSEntryMirrorStatus) {
+	*out = *in
+	if in.Message != nil {
+		in, out := &in.Message, &out.Message
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSEntryMirrorStatus.
+func (in *DNSEntryMirrorStatus) DeepCopy() *DNSEntryMirrorStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSEntryMirrorStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DNSEntrySpec) DeepCopyInto(out *DNSEntrySpec) {
 	*out = *in
+	if in.DNSNames != nil {
+		in, out := &in.DNSNames, &out.DNSNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Reference != nil {
 		in, out := &in.Reference, &out.Reference
 		*out = new(EntryReference)
 		**out = **in
 	}
+	if in.TargetRef != nil {
+		in, out := &in.TargetRef, &out.TargetRef
+		*out = new(TargetReference)
+		**out = **in
+	}
+	if in.ProviderRef != nil {
+		in, out := &in.ProviderRef, &out.ProviderRef
+		*out = new(ProviderReference)
+		**out = **in
+	}
+	if in.SplitProviders != nil {
+		in, out := &in.SplitProviders, &out.SplitProviders
+		*out = make([]ProviderReference, len(*in))
+		copy(*out, *in)
+	}
 	if in.OwnerId != nil {
 		in, out := &in.OwnerId, &out.OwnerId
 		*out = new(string)
@@ -204,6 +266,11 @@ func (in *DNSEntrySpec) DeepCopyInto(out *DNSEntrySpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.ResolveTargetsExclusions != nil {
+		in, out := &in.ResolveTargetsExclusions, &out.ResolveTargetsExclusions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Text != nil {
 		in, out := &in.Text, &out.Text
 		*out = make([]string, len(*in))
@@ -214,11 +281,26 @@ func (in *DNSEntrySpec) DeepCopyInto(out *DNSEntrySpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Records != nil {
+		in, out := &in.Records, &out.Records
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.RoutingPolicy != nil {
 		in, out := &in.RoutingPolicy, &out.RoutingPolicy
 		*out = new(RoutingPolicy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Comment != nil {
+		in, out := &in.Comment, &out.Comment
+		*out = new(string)
+		**out = **in
+	}
+	if in.PreserveOnDelete != nil {
+		in, out := &in.PreserveOnDelete, &out.PreserveOnDelete
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -232,6 +314,27 @@ func (in *DNSEntrySpec) DeepCopy() *DNSEntrySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSEntrySplitProviderStatus) DeepCopyInto(out *DNSEntrySplitProviderStatus) {
+	*out = *in
+	if in.Message != nil {
+		in, out := &in.Message, &out.Message
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSEntrySplitProviderStatus.
+func (in *DNSEntrySplitProviderStatus) DeepCopy() *DNSEntrySplitProviderStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSEntrySplitProviderStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DNSEntryStatus) DeepCopyInto(out *DNSEntryStatus) {
 	*out = *in
@@ -244,6 +347,10 @@ func (in *DNSEntryStatus) DeepCopyInto(out *DNSEntryStatus) {
 		in, out := &in.LastUptimeTime, &out.LastUptimeTime
 		*out = (*in).DeepCopy()
 	}
+	if in.LastBackendSyncTime != nil {
+		in, out := &in.LastBackendSyncTime, &out.LastBackendSyncTime
+		*out = (*in).DeepCopy()
+	}
 	if in.ProviderType != nil {
 		in, out := &in.ProviderType, &out.ProviderType
 		*out = new(string)
@@ -279,6 +386,35 @@ func (in *DNSEntryStatus) DeepCopyInto(out *DNSEntryStatus) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.ApexAlias != nil {
+		in, out := &in.ApexAlias, &out.ApexAlias
+		*out = new(string)
+		**out = **in
+	}
+	if in.Propagated != nil {
+		in, out := &in.Propagated, &out.Propagated
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Aliases != nil {
+		in, out := &in.Aliases, &out.Aliases
+		*out = make([]DNSEntryAliasStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SplitProviders != nil {
+		in, out := &in.SplitProviders, &out.SplitProviders
+		*out = make([]DNSEntrySplitProviderStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Mirror != nil {
+		in, out := &in.Mirror, &out.Mirror
+		*out = new(DNSEntryMirrorStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -384,6 +520,11 @@ func (in *DNSHostedZonePolicyStatus) DeepCopyInto(out *DNSHostedZonePolicyStatus
 		*out = make([]ZoneInfo, len(*in))
 		copy(*out, *in)
 	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(RateLimit)
+		**out = **in
+	}
 	if in.LastStatusUpdateTime != nil {
 		in, out := &in.LastStatusUpdateTime, &out.LastStatusUpdateTime
 		*out = (*in).DeepCopy()
@@ -467,6 +608,27 @@ func (in *DNSOwnerList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSOwnerNamespaceSelector) DeepCopyInto(out *DNSOwnerNamespaceSelector) {
+	*out = *in
+	if in.MatchNames != nil {
+		in, out := &in.MatchNames, &out.MatchNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSOwnerNamespaceSelector.
+func (in *DNSOwnerNamespaceSelector) DeepCopy() *DNSOwnerNamespaceSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSOwnerNamespaceSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DNSOwnerSpec) DeepCopyInto(out *DNSOwnerSpec) {
 	*out = *in
@@ -475,6 +637,11 @@ func (in *DNSOwnerSpec) DeepCopyInto(out *DNSOwnerSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(DNSOwnerNamespaceSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -594,6 +761,80 @@ func (in *DNSProviderList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSProviderMigration) DeepCopyInto(out *DNSProviderMigration) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSProviderMigration.
+func (in *DNSProviderMigration) DeepCopy() *DNSProviderMigration {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSProviderMigration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSProviderMigrationStatus) DeepCopyInto(out *DNSProviderMigrationStatus) {
+	*out = *in
+	if in.Message != nil {
+		in, out := &in.Message, &out.Message
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSProviderMigrationStatus.
+func (in *DNSProviderMigrationStatus) DeepCopy() *DNSProviderMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSProviderMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSProviderMirror) DeepCopyInto(out *DNSProviderMirror) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSProviderMirror.
+func (in *DNSProviderMirror) DeepCopy() *DNSProviderMirror {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSProviderMirror)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSProviderMirrorStatus) DeepCopyInto(out *DNSProviderMirrorStatus) {
+	*out = *in
+	if in.Message != nil {
+		in, out := &in.Message, &out.Message
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSProviderMirrorStatus.
+func (in *DNSProviderMirrorStatus) DeepCopy() *DNSProviderMirrorStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSProviderMirrorStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DNSProviderSpec) DeepCopyInto(out *DNSProviderSpec) {
 	*out = *in
@@ -607,6 +848,11 @@ func (in *DNSProviderSpec) DeepCopyInto(out *DNSProviderSpec) {
 		*out = new(v1.SecretReference)
 		**out = **in
 	}
+	if in.CredentialsPath != nil {
+		in, out := &in.CredentialsPath, &out.CredentialsPath
+		*out = new(string)
+		**out = **in
+	}
 	if in.Domains != nil {
 		in, out := &in.Domains, &out.Domains
 		*out = new(DNSSelection)
@@ -617,6 +863,16 @@ func (in *DNSProviderSpec) DeepCopyInto(out *DNSProviderSpec) {
 		*out = new(DNSSelection)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ZoneTagSelector != nil {
+		in, out := &in.ZoneTagSelector, &out.ZoneTagSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RecordTypeSelection != nil {
+		in, out := &in.RecordTypeSelection, &out.RecordTypeSelection
+		*out = new(DNSSelection)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.DefaultTTL != nil {
 		in, out := &in.DefaultTTL, &out.DefaultTTL
 		*out = new(int64)
@@ -627,6 +883,26 @@ func (in *DNSProviderSpec) DeepCopyInto(out *DNSProviderSpec) {
 		*out = new(RateLimit)
 		**out = **in
 	}
+	if in.MaxConcurrentRequests != nil {
+		in, out := &in.MaxConcurrentRequests, &out.MaxConcurrentRequests
+		*out = new(int)
+		**out = **in
+	}
+	if in.Migration != nil {
+		in, out := &in.Migration, &out.Migration
+		*out = new(DNSProviderMigration)
+		**out = **in
+	}
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Mirror != nil {
+		in, out := &in.Mirror, &out.Mirror
+		*out = new(DNSProviderMirror)
+		**out = **in
+	}
 	return
 }
 
@@ -654,6 +930,11 @@ func (in *DNSProviderStatus) DeepCopyInto(out *DNSProviderStatus) {
 	}
 	in.Domains.DeepCopyInto(&out.Domains)
 	in.Zones.DeepCopyInto(&out.Zones)
+	if in.ProblematicZones != nil {
+		in, out := &in.ProblematicZones, &out.ProblematicZones
+		*out = make([]ProblematicZone, len(*in))
+		copy(*out, *in)
+	}
 	if in.DefaultTTL != nil {
 		in, out := &in.DefaultTTL, &out.DefaultTTL
 		*out = new(int64)
@@ -664,6 +945,30 @@ func (in *DNSProviderStatus) DeepCopyInto(out *DNSProviderStatus) {
 		*out = new(RateLimit)
 		**out = **in
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ZoneRecordSets != nil {
+		in, out := &in.ZoneRecordSets, &out.ZoneRecordSets
+		*out = make([]ZoneRecordSetCount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Migration != nil {
+		in, out := &in.Migration, &out.Migration
+		*out = new(DNSProviderMigrationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Mirror != nil {
+		in, out := &in.Mirror, &out.Mirror
+		*out = new(DNSProviderMirrorStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -745,6 +1050,38 @@ func (in *EntryReference) DeepCopy() *EntryReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProblematicZone) DeepCopyInto(out *ProblematicZone) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProblematicZone.
+func (in *ProblematicZone) DeepCopy() *ProblematicZone {
+	if in == nil {
+		return nil
+	}
+	out := new(ProblematicZone)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderReference) DeepCopyInto(out *ProviderReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderReference.
+func (in *ProviderReference) DeepCopy() *ProviderReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RateLimit) DeepCopyInto(out *RateLimit) {
 	*out = *in
@@ -800,6 +1137,22 @@ func (in *RoutingPolicy) DeepCopy() *RoutingPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetReference) DeepCopyInto(out *TargetReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetReference.
+func (in *TargetReference) DeepCopy() *TargetReference {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ZoneInfo) DeepCopyInto(out *ZoneInfo) {
 	*out = *in
@@ -824,6 +1177,16 @@ func (in *ZonePolicy) DeepCopyInto(out *ZonePolicy) {
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.RecordSetLimit != nil {
+		in, out := &in.RecordSetLimit, &out.RecordSetLimit
+		*out = new(int)
+		**out = **in
+	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(RateLimit)
+		**out = **in
+	}
 	return
 }
 
@@ -837,6 +1200,27 @@ func (in *ZonePolicy) DeepCopy() *ZonePolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneRecordSetCount) DeepCopyInto(out *ZoneRecordSetCount) {
+	*out = *in
+	if in.Limit != nil {
+		in, out := &in.Limit, &out.Limit
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneRecordSetCount.
+func (in *ZoneRecordSetCount) DeepCopy() *ZoneRecordSetCount {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneRecordSetCount)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ZoneSelector) DeepCopyInto(out *ZoneSelector) {
 	*out = *in
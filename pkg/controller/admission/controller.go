@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package admission
+
+import (
+	"fmt"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/reconcile"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/resources/apiextensions"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/gardener/external-dns-management/pkg/apis/dns/crds"
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	"github.com/gardener/external-dns-management/pkg/dns/source"
+	webhook "github.com/gardener/external-dns-management/pkg/server/admission"
+)
+
+// CONTROLLER is the name of the controller serving the validating admission webhook for DNS entries.
+// It does not require the lease, so that the webhook is served by all replicas and not only by the leader.
+const CONTROLLER = "dnsentry-admission"
+
+const (
+	OPT_ADMISSION_WEBHOOK_PORT     = "admission-webhook-port"
+	OPT_ADMISSION_WEBHOOK_CERT_DIR = "admission-webhook-cert-dir"
+)
+
+func init() {
+	crds.AddToRegistry(apiextensions.DefaultRegistry())
+
+	controller.Configure(CONTROLLER).
+		DefaultedStringOption(provider.OPT_CLASS, dns.DEFAULT_CLASS, "Class identifier used to differentiate responsible controllers for entry resources").
+		DefaultedIntOption(OPT_ADMISSION_WEBHOOK_PORT, 0, "port of the validating admission webhook server for DNS entries (disabled if 0)").
+		DefaultedStringOption(OPT_ADMISSION_WEBHOOK_CERT_DIR, "", "directory containing the server certificate (tls.crt and tls.key) of the admission webhook server").
+		Reconciler(Create).
+		Cluster(provider.TARGET_CLUSTER).
+		DefaultWorkerPool(1, 0).
+		MainResource(api.GroupName, api.DNSEntryKind).
+		ActivateExplicitly().
+		MustRegister(provider.CONTROLLER_GROUP_DNS_CONTROLLERS)
+}
+
+type reconciler struct {
+	reconcile.DefaultReconciler
+	controller controller.Interface
+	port       int
+	certDir    string
+	classes    *controller.Classes
+}
+
+var _ reconcile.Interface = &reconciler{}
+
+func Create(c controller.Interface) (reconcile.Interface, error) {
+	port, _ := c.GetIntOption(OPT_ADMISSION_WEBHOOK_PORT)
+	certDir, _ := c.GetStringOption(OPT_ADMISSION_WEBHOOK_CERT_DIR)
+	if port != 0 && certDir == "" {
+		return nil, fmt.Errorf("missing %s for activated admission webhook server", OPT_ADMISSION_WEBHOOK_CERT_DIR)
+	}
+	return &reconciler{
+		controller: c,
+		port:       port,
+		certDir:    certDir,
+		classes:    controller.NewClassesByOption(c, provider.OPT_CLASS, source.CLASS_ANNOTATION, dns.DEFAULT_CLASS),
+	}, nil
+}
+
+// Start starts the admission webhook server. The DNS entries are only watched to fill the cache used for the validation.
+func (this *reconciler) Start() error {
+	if this.port == 0 {
+		this.controller.Infof("admission webhook server disabled")
+		return nil
+	}
+	resc, err := this.controller.GetMainCluster().Resources().GetByExample(&api.DNSEntry{})
+	if err != nil {
+		return err
+	}
+	lister := func(namespace string) ([]*api.DNSEntry, error) {
+		objs, err := resc.Namespace(namespace).ListCached(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		entries := make([]*api.DNSEntry, 0, len(objs))
+		for _, obj := range objs {
+			if entry, ok := obj.Data().(*api.DNSEntry); ok {
+				entries = append(entries, entry)
+			}
+		}
+		return entries, nil
+	}
+	validator := webhook.NewEntryValidator(this.controller.NewContext("sub", "admission"), lister, this.classes.Contains)
+	return webhook.StartServer(this.controller.GetContext(), this.controller, this.port, this.certDir, validator)
+}
+
+func (this *reconciler) Reconcile(logger logger.LogContext, _ resources.Object) reconcile.Status {
+	return reconcile.Succeeded(logger)
+}
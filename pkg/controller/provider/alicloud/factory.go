@@ -18,7 +18,11 @@ var rateLimiterDefaults = provider.RateLimiterOptions{
 }
 
 var Factory = provider.NewDNSHandlerFactory(TYPE_CODE, NewHandler, true).
-	SetGenericFactoryOptionDefaults(provider.GenericFactoryOptionDefaults.SetRateLimiterOptions(rateLimiterDefaults))
+	SetGenericFactoryOptionDefaults(provider.GenericFactoryOptionDefaults.SetRateLimiterOptions(rateLimiterDefaults)).
+	SetSecretKeys(
+		provider.RequiredSecretKey("ACCESS_KEY_ID", "accessKeyID"),
+		provider.RequiredSecretKey("ACCESS_KEY_SECRET", "accessKeySecret"),
+	)
 
 func init() {
 	compound.MustRegister(Factory)
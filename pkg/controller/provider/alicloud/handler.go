@@ -5,6 +5,8 @@
 package alicloud
 
 import (
+	"context"
+
 	"github.com/aliyun/alibaba-cloud-sdk-go/services/alidns"
 	"github.com/gardener/controller-manager-library/pkg/logger"
 	"github.com/gardener/external-dns-management/pkg/dns/provider"
@@ -61,7 +63,7 @@ func (h *Handler) GetZones() (provider.DNSHostedZones, error) {
 	return h.cache.GetZones()
 }
 
-func (h *Handler) getZones(_ provider.ZoneCache) (provider.DNSHostedZones, error) {
+func (h *Handler) getZones(_ context.Context, _ provider.ZoneCache) (provider.DNSHostedZones, error) {
 	blockedZones := h.config.Options.AdvancedOptions.GetBlockedZones()
 	raw := []alidns.Domain{}
 	{
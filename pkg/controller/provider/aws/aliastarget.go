@@ -32,7 +32,12 @@ func buildRecordSetFromAliasTarget(r route53types.ResourceRecordSet) *dns.Record
 	}
 	rs := dns.NewRecordSet(rtype, 0, nil)
 	rs.IgnoreTTL = true // alias target has no settable TTL
-	rs.Add(&dns.Record{Value: dns.NormalizeHostname(aws.ToString(r.AliasTarget.DNSName))})
+	target := dns.NormalizeHostname(aws.ToString(r.AliasTarget.DNSName))
+	hostedZone := aws.ToString(r.AliasTarget.HostedZoneId)
+	if hostedZone == mapping.CanonicalHostedZone(target) {
+		hostedZone = ""
+	}
+	rs.Add(&dns.Record{Value: dns.AliasTargetValue(target, hostedZone)})
 	return rs
 }
 
@@ -49,15 +54,19 @@ func buildResourceRecordSetForAliasTarget(ctx context.Context, name dns.DNSSetNa
 		return nil, nil
 	}
 
-	target := dns.NormalizeHostname(rset.Records[0].Value)
-	hostedZone := mapping.CanonicalHostedZone(target)
+	target, hostedZone := dns.SplitAliasTargetValue(rset.Records[0].Value)
+	target = dns.NormalizeHostname(target)
+	if hostedZone == "" {
+		hostedZone = mapping.CanonicalHostedZone(target)
+	}
 	if hostedZone == "" {
 		return nil, fmt.Errorf("Corrupted alias record set")
 	}
 	aliasTarget := &route53types.AliasTarget{
-		DNSName:              aws.String(target),
-		HostedZoneId:         aws.String(hostedZone),
-		EvaluateTargetHealth: true,
+		DNSName:      aws.String(target),
+		HostedZoneId: aws.String(hostedZone),
+		// target health cannot be evaluated for CloudFront distributions
+		EvaluateTargetHealth: hostedZone != mapping.CloudFrontHostedZone,
 	}
 
 	rrset := &route53types.ResourceRecordSet{
@@ -0,0 +1,23 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/smithy-go"
+)
+
+func TestIsAccessDenied(t *testing.T) {
+	for _, code := range []string{"AccessDenied", "AccessDeniedException"} {
+		if !isAccessDenied(fmt.Errorf("wrapped: %w", &smithy.GenericAPIError{Code: code})) {
+			t.Errorf("Failed: access denied not detected for %s", code)
+		}
+	}
+	if isAccessDenied(&smithy.GenericAPIError{Code: "Throttling"}) || isAccessDenied(fmt.Errorf("other")) {
+		t.Errorf("Failed: unexpected access denied")
+	}
+}
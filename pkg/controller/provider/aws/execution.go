@@ -61,8 +61,12 @@ func buildResourceRecordSet(ctx context.Context, name dns.DNSSetName, policy *dn
 	rrs.TTL = aws.Int64(rset.TTL)
 	rrs.ResourceRecords = make([]route53types.ResourceRecord, len(rset.Records))
 	for i, r := range rset.Records {
+		value := r.Value
+		if rset.Type == dns.RS_SRV {
+			value = dns.AlignSRVValue(value)
+		}
 		rrs.ResourceRecords[i] = route53types.ResourceRecord{
-			Value: aws.String(r.Value),
+			Value: aws.String(value),
 		}
 	}
 	if err := policyContext.addRoutingPolicy(ctx, rrs, name, policy); err != nil {
@@ -73,7 +77,7 @@ func buildResourceRecordSet(ctx context.Context, name dns.DNSSetName, policy *dn
 
 func (this *Execution) addChange(ctx context.Context, action route53types.ChangeAction, req *provider.ChangeRequest, dnsset *dns.DNSSet) error {
 	name, rset := dns.MapToProvider(req.Type, dnsset, this.zone.Domain())
-	name = name.Align()
+	name = nameForm.SetName(name)
 	if len(rset.Records) == 0 {
 		return nil
 	}
@@ -108,6 +112,8 @@ func (this *Execution) submitChanges(ctx context.Context, metrics provider.Metri
 
 	failed := 0
 	throttlingErrCount := 0
+	batchTooLargeCount := 0
+	noSuchHostedZoneCount := 0
 	limitedChanges := limitChangeSet(this.changes, this.batchSize)
 	this.Infof("require %d batches for %d dns names", len(limitedChanges), len(this.changes))
 	for i, changes := range limitedChanges {
@@ -137,7 +143,15 @@ func (this *Execution) submitChanges(ctx context.Context, metrics provider.Metri
 			if errors.As(err, &apiError) {
 				switch v := apiError.(type) {
 				case *route53types.InvalidChangeBatch:
-					succeededChanges, failedChanges, err = this.tryFixChanges(ctx, v.ErrorMessage(), changes)
+					if patternBatchTooLarge.MatchString(v.ErrorMessage()) {
+						batchTooLargeCount++
+						err = dnserrors.NewBatchTooLargeError(err)
+					} else {
+						succeededChanges, failedChanges, err = this.tryFixChanges(ctx, v.ErrorMessage(), changes)
+					}
+				case *route53types.NoSuchHostedZone:
+					noSuchHostedZoneCount++
+					err = &dnserrors.NoSuchHostedZone{ZoneId: this.zone.Id().ID, Err: err}
 				default:
 					if v.ErrorCode() == "Throttling" {
 						throttlingErrCount++
@@ -167,8 +181,12 @@ func (this *Execution) submitChanges(ctx context.Context, metrics provider.Metri
 	}
 	if failed > 0 {
 		err := fmt.Errorf("%d changes failed", failed)
-		if throttlingErrCount == len(limitedChanges) {
+		if noSuchHostedZoneCount > 0 {
+			err = &dnserrors.NoSuchHostedZone{ZoneId: this.zone.Id().ID, Err: err}
+		} else if throttlingErrCount == len(limitedChanges) {
 			err = dnserrors.NewThrottlingError(err)
+		} else if batchTooLargeCount > 0 {
+			err = dnserrors.NewBatchTooLargeError(err)
 		}
 		return err
 	}
@@ -178,6 +196,8 @@ func (this *Execution) submitChanges(ctx context.Context, metrics provider.Metri
 var (
 	patternNotFound = regexp.MustCompile(`Tried to delete resource record set \[name='([^']+)', type='([^']+)'] but it was not found`)
 	patternExists   = regexp.MustCompile(`Tried to create resource record set \[name='([^']+)', type='([^']+)'] but it already exists`)
+	// patternBatchTooLarge matches errors like `Number of records limit of 1000 exceeded.` or `RDATA character limit of 32000 exceeded.`
+	patternBatchTooLarge = regexp.MustCompile(`limit of \d+ exceeded`)
 )
 
 func (this *Execution) tryFixChanges(ctx context.Context, message string, changes []*Change) (succeeded []*Change, failed []*Change, err error) {
@@ -24,7 +24,15 @@ var advancedDefaults = provider.AdvancedOptions{
 
 var Factory = provider.NewDNSHandlerFactory(TYPE_CODE, NewHandler).
 	SetGenericFactoryOptionDefaults(provider.GenericFactoryOptionDefaults.
-		SetRateLimiterOptions(rateLimiterDefaults).SetAdvancedOptions(advancedDefaults))
+		SetRateLimiterOptions(rateLimiterDefaults).SetAdvancedOptions(advancedDefaults)).
+	SetSecretKeys(
+		provider.OptionalSecretKey("AWS_REGION", "region"),
+		provider.OptionalSecretKey("AWS_USE_CREDENTIALS_CHAIN"),
+		provider.RequiredSecretKey("AWS_ACCESS_KEY_ID", "accessKeyID").If(provider.IsNotTrue("AWS_USE_CREDENTIALS_CHAIN")),
+		provider.RequiredSecretKey("AWS_SECRET_ACCESS_KEY", "secretAccessKey").If(provider.IsNotTrue("AWS_USE_CREDENTIALS_CHAIN")),
+		provider.OptionalSecretKey("AWS_SESSION_TOKEN"),
+		provider.ProxySecretKey,
+	)
 
 func init() {
 	compound.MustRegister(Factory)
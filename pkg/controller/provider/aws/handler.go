@@ -16,6 +16,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/route53"
 	route53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/aws/smithy-go"
 
 	"github.com/gardener/controller-manager-library/pkg/logger"
 	"github.com/gardener/external-dns-management/pkg/controller/provider/aws/mapping"
@@ -26,6 +27,7 @@ import (
 
 type Handler struct {
 	provider.DefaultDNSHandler
+	provider.DeniedZonesTracker
 	config        provider.DNSHandlerConfig
 	awsConfig     AWSConfig
 	cache         provider.ZoneCache
@@ -34,10 +36,33 @@ type Handler struct {
 }
 
 type AWSConfig struct {
-	BatchSize int `json:"batchSize"`
+	BatchSize                  int `json:"batchSize"`
+	provider.ZoneListingConfig `json:",inline"`
 }
 
-var _ provider.DNSHandler = &Handler{}
+var (
+	_ provider.DNSHandler              = &Handler{}
+	_ provider.RoutingPolicyNormalizer = &Handler{}
+	_ provider.TXTRecordLimiter        = &Handler{}
+	_ provider.WeightScaler            = &Handler{}
+	_ provider.ApexAliasSupporter      = &Handler{}
+	_ provider.NameFormer              = &Handler{}
+	_ provider.CapabilitiesReporter    = &Handler{}
+	_ provider.DeniedZonesReporter     = &Handler{}
+)
+
+// nameForm is the canonical form of DNS names in AWS Route53: lower case with trailing dot.
+var nameForm = dns.NameForm{TrailingDot: true, LowerCase: true}
+
+// maxTXTTextLength is the maximum length of a text supported by Route53.
+// A record value is limited to 4000 characters, i.e. 16 quoted character strings separated by blanks.
+const maxTXTTextLength = 3953
+
+// maxWeight is the maximum weight of a weighted record set supported by Route53.
+const maxWeight = 255
+
+// maxZoneListPageSize is the maximum number of hosted zones per page supported by Route53.
+const maxZoneListPageSize = 100
 
 func NewHandler(c *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
 	advancedConfig := c.Options.AdvancedOptions.GetAdvancedConfig()
@@ -49,6 +74,9 @@ func NewHandler(c *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
 		if err != nil {
 			return nil, fmt.Errorf("unmarshal aws-route providerConfig failed with: %s", err)
 		}
+		if err := awsConfig.ZoneListingConfig.Validate(maxZoneListPageSize); err != nil {
+			return nil, fmt.Errorf("invalid aws-route providerConfig: %w", err)
+		}
 	}
 
 	h := &Handler{
@@ -95,6 +123,8 @@ func NewHandler(c *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
 		c.Logger.Infof("creating aws-route53 handler using the chain of credential providers")
 	}
 
+	awscfg.HTTPClient = c.NewHTTPClient()
+
 	// TODO check if this is correct
 	//if strings.HasPrefix(region, "us-gov-") {
 	//	awscfg.BaseEndpoint = aws.String("route53.us-gov.amazonaws.com")
@@ -122,24 +152,40 @@ func (h *Handler) GetZones() (provider.DNSHostedZones, error) {
 	return h.cache.GetZones()
 }
 
-func (h *Handler) getZones(_ provider.ZoneCache) (provider.DNSHostedZones, error) {
+func (h *Handler) getZones(ctx context.Context, _ provider.ZoneCache) (provider.DNSHostedZones, error) {
 	blockedZones := h.config.Options.AdvancedOptions.GetBlockedZones()
 
 	rt := provider.M_LISTZONES
-	var zones provider.DNSHostedZones
+	var hostedZones []route53types.HostedZone
+	var ids []string
 
 	h.config.RateLimiter.Accept()
-	ctx := context.Background()
-	paginator := route53.NewListHostedZonesPaginator(&h.r53, &route53.ListHostedZonesInput{})
+	listing := h.awsConfig.ZoneListingConfig
+	listCtx, cancel := listing.Context(ctx)
+	defer cancel()
+	input := &route53.ListHostedZonesInput{}
+	if listing.ZoneListPageSize > 0 {
+		input.MaxItems = aws.Int32(listing.ZoneListPageSize)
+	}
+	paginator := route53.NewListHostedZonesPaginator(&h.r53, input)
+pages:
 	for paginator.HasMorePages() {
 		h.config.Metrics.AddGenericRequests(rt, 1)
 		rt = provider.M_PLISTZONES
 
-		output, err := paginator.NextPage(ctx)
+		output, err := paginator.NextPage(listCtx)
 		if err != nil {
+			if listing.IsPartialResult(listCtx, err, len(hostedZones)) {
+				h.config.Logger.Warnf("zone listing timed out after %s, serving %d zones listed so far", listing.ZoneListTimeout.Duration, len(hostedZones))
+				break
+			}
 			return nil, err
 		}
 		for _, zone := range output.HostedZones {
+			if listing.MaxZonesReached(len(hostedZones)) {
+				h.config.Logger.Warnf("zone listing stopped after maximum number of %d zones", listing.ZoneListMaxZones)
+				break pages
+			}
 			comp := strings.Split(aws.ToString(zone.Id), "/")
 			id := comp[len(comp)-1]
 			if blockedZones.Contains(id) {
@@ -147,15 +193,58 @@ func (h *Handler) getZones(_ provider.ZoneCache) (provider.DNSHostedZones, error
 				continue
 			}
 
-			domain := dns.NormalizeHostname(aws.ToString(zone.Name))
-			hostedZone := provider.NewDNSHostedZone(h.ProviderType(), id, domain, aws.ToString(zone.Id), zone.Config.PrivateZone)
-			zones = append(zones, hostedZone)
+			hostedZones = append(hostedZones, zone)
+			ids = append(ids, id)
+		}
+	}
+
+	var tags map[string]map[string]string
+	if h.config.ZoneTags {
+		var err error
+		tags, err = h.listZoneTags(ctx, ids)
+		if err != nil {
+			h.config.Logger.Warnf("cannot list tags of hosted zones, zone selection by tags not possible: %s", err)
 		}
 	}
 
+	var zones provider.DNSHostedZones
+	for i, zone := range hostedZones {
+		domain := dns.NormalizeHostname(aws.ToString(zone.Name))
+		hostedZone := provider.NewDNSHostedZoneWithTags(h.ProviderType(), ids[i], domain, aws.ToString(zone.Id), zone.Config.PrivateZone, tags[ids[i]])
+		zones = append(zones, hostedZone)
+	}
+
 	return zones, nil
 }
 
+// maxTagResources is the maximum number of resources per ListTagsForResources request.
+const maxTagResources = 10
+
+// listZoneTags returns the tags of the hosted zones by zone id.
+func (h *Handler) listZoneTags(ctx context.Context, ids []string) (map[string]map[string]string, error) {
+	tags := map[string]map[string]string{}
+	for start := 0; start < len(ids); start += maxTagResources {
+		end := min(start+maxTagResources, len(ids))
+		h.config.RateLimiter.Accept()
+		h.config.Metrics.AddGenericRequests(provider.M_LISTZONETAGS, 1)
+		output, err := h.r53.ListTagsForResources(ctx, &route53.ListTagsForResourcesInput{
+			ResourceIds:  ids[start:end],
+			ResourceType: route53types.TagResourceTypeHostedzone,
+		})
+		if err != nil {
+			return tags, err
+		}
+		for _, set := range output.ResourceTagSets {
+			zoneTags := map[string]string{}
+			for _, tag := range set.Tags {
+				zoneTags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+			}
+			tags[aws.ToString(set.ResourceId)] = zoneTags
+		}
+	}
+	return tags, nil
+}
+
 func buildRecordSet(r route53types.ResourceRecordSet) *dns.RecordSet {
 	if rs := buildRecordSetFromAliasTarget(r); rs != nil {
 		return rs
@@ -185,6 +274,7 @@ func (h *Handler) getZoneState(zone provider.DNSHostedZone, _ provider.ZoneCache
 
 		output, err := paginator.NextPage(ctx)
 		if err != nil {
+			h.UpdateDeniedZone(zone.Id().ID, isAccessDenied(err))
 			var target *route53types.NoSuchHostedZone
 			if errors.As(err, &target) {
 				err = &dnserrors.NoSuchHostedZone{ZoneId: zone.Id().ID, Err: err}
@@ -201,9 +291,23 @@ func (h *Handler) getZoneState(zone provider.DNSHostedZone, _ provider.ZoneCache
 		}
 	}
 
+	h.UpdateDeniedZone(zone.Id().ID, false)
 	return provider.NewDNSZoneState(dnssets), nil
 }
 
+// isAccessDenied returns true if the request was rejected as the credentials grant no access to the resource.
+func isAccessDenied(err error) bool {
+	var apiError smithy.APIError
+	if !errors.As(err, &apiError) {
+		return false
+	}
+	switch apiError.ErrorCode() {
+	case "AccessDenied", "AccessDeniedException":
+		return true
+	}
+	return false
+}
+
 func (h *Handler) ReportZoneStateConflict(zone provider.DNSHostedZone, err error) bool {
 	return h.cache.ReportZoneStateConflict(zone, err)
 }
@@ -245,6 +349,42 @@ func (h *Handler) MapTargets(_ string, targets []provider.Target) []provider.Tar
 	return mapping.MapTargets(targets)
 }
 
+// SupportsApexAlias returns true for targets which can be mapped to alias targets, i.e. AWS load balancers,
+// CloudFront distributions, or targets with explicit alias hosted zone.
+func (h *Handler) SupportsApexAlias(target provider.Target) bool {
+	return mapping.CanonicalHostedZone(target.GetHostName()) != "" || target.GetAliasHostedZone() != ""
+}
+
+// NormalizeRoutingPolicy maps alternative location names of geolocation routing policies and failover record types
+// to the values read back from Route53.
+func (h *Handler) NormalizeRoutingPolicy(policy *dns.RoutingPolicy) *dns.RoutingPolicy {
+	return h.policyContext.normalizeRoutingPolicy(context.Background(), policy)
+}
+
+// TXTRecordLimits returns the limits of Route53 for TXT record values.
+// Texts longer than 255 characters must be split into multiple character strings.
+func (h *Handler) TXTRecordLimits() dns.TXTRecordLimits {
+	return dns.TXTRecordLimits{
+		ChunkSize: dns.MaxTXTCharacterStringLength,
+		MaxLength: maxTXTTextLength,
+	}
+}
+
+// MaxWeight returns the maximum weight of weighted record sets supported by Route53.
+func (h *Handler) MaxWeight() int64 {
+	return maxWeight
+}
+
+// NameForm returns the form of DNS names written to Route53.
+func (h *Handler) NameForm() dns.NameForm {
+	return nameForm
+}
+
+// Capabilities returns the optional record types supported by Route53.
+func (h *Handler) Capabilities() provider.Capabilities {
+	return provider.Capabilities{RecordTypes: []string{dns.RS_NS, dns.RS_DS}}
+}
+
 // AssociateVPCWithHostedZone associates a VPC with a private hosted zone
 // in use by external controller
 func (h *Handler) AssociateVPCWithHostedZone(ctx context.Context, vpcId string, vpcRegion route53types.VPCRegion, hostedZoneId string) (*route53.AssociateVPCWithHostedZoneOutput, error) {
@@ -328,7 +468,7 @@ func (h *Handler) DeleteVPCAssociationAuthorization(ctx context.Context, hostedZ
 }
 
 func (h *Handler) GetRecordSet(ctx context.Context, zone provider.DNSHostedZone, setName dns.DNSSetName, recordType route53types.RRType) (provider.DedicatedRecordSet, error) {
-	name := setName.Align()
+	name := nameForm.SetName(setName)
 	var recordIdentifier *string
 	if setName.SetIdentifier != "" {
 		recordIdentifier = &setName.SetIdentifier
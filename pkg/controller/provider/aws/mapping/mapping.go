@@ -13,6 +13,9 @@ import (
 
 var canonicalHostedZones = data.CanonicalHostedZones()
 
+// CloudFrontHostedZone is the hosted zone of all CloudFront distributions.
+const CloudFrontHostedZone = "Z2FDTNDATAQYW2"
+
 // CanonicalHostedZone returns the matching canonical zone for a given hostname.
 func CanonicalHostedZone(hostname string) string {
 	for suffix, zone := range canonicalHostedZones {
@@ -24,21 +27,28 @@ func CanonicalHostedZone(hostname string) string {
 }
 
 // MapTargets maps CNAME records to A/AAAA records for hosted zones used for AWS load balancers.
+// If an alias hosted zone is requested explicitly for a target, it is mapped to an alias target for this hosted zone.
 func MapTargets(targets []dnsutils.Target) []dnsutils.Target {
 	mapped := make([]dnsutils.Target, 0, len(targets)+1)
 	for _, t := range targets {
 		switch t.GetRecordType() {
 		case dns.RS_CNAME:
-			hostedZone := CanonicalHostedZone(t.GetHostName())
-			if hostedZone != "" {
+			canonicalZone := CanonicalHostedZone(t.GetHostName())
+			hostedZone := t.GetAliasHostedZone()
+			if hostedZone == canonicalZone {
+				// no need to store the hosted zone in the record value
+				hostedZone = ""
+			}
+			if canonicalZone != "" || hostedZone != "" {
+				value := dns.AliasTargetValue(t.GetHostName(), hostedZone)
 				switch strings.ToLower(t.GetIPStack()) {
 				case dns.AnnotationValueIPStackIPDualStack:
-					mapped = append(mapped, dnsutils.NewTarget(dns.RS_ALIAS_A, t.GetHostName(), t.GetTTL()))
-					mapped = append(mapped, dnsutils.NewTarget(dns.RS_ALIAS_AAAA, t.GetHostName(), t.GetTTL()))
+					mapped = append(mapped, dnsutils.NewTarget(dns.RS_ALIAS_A, value, t.GetTTL()))
+					mapped = append(mapped, dnsutils.NewTarget(dns.RS_ALIAS_AAAA, value, t.GetTTL()))
 				case dns.AnnotationValueIPStackIPv6:
-					mapped = append(mapped, dnsutils.NewTarget(dns.RS_ALIAS_AAAA, t.GetHostName(), t.GetTTL()))
+					mapped = append(mapped, dnsutils.NewTarget(dns.RS_ALIAS_AAAA, value, t.GetTTL()))
 				default:
-					mapped = append(mapped, dnsutils.NewTarget(dns.RS_ALIAS_A, t.GetHostName(), t.GetTTL()))
+					mapped = append(mapped, dnsutils.NewTarget(dns.RS_ALIAS_A, value, t.GetTTL()))
 				}
 			} else {
 				mapped = append(mapped, t)
@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mapping
+
+import (
+	"testing"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+func TestMapTargets(t *testing.T) {
+	table := []struct {
+		name     string
+		target   dnsutils.Target
+		expected []string
+	}{
+		{"plain CNAME", dnsutils.NewTarget(dns.RS_CNAME, "www.example.com", 300), []string{"CNAME:www.example.com"}},
+		{"A record", dnsutils.NewTarget(dns.RS_A, "1.1.1.1", 300), []string{"A:1.1.1.1"}},
+		{"load balancer", dnsutils.NewTarget(dns.RS_CNAME, "my-lb.eu-west-1.elb.amazonaws.com", 300),
+			[]string{"ALIAS:my-lb.eu-west-1.elb.amazonaws.com"}},
+		{"load balancer dual-stack", dnsutils.NewTargetWithIPStack(dns.RS_CNAME, "my-lb.eu-west-1.elb.amazonaws.com", 300, dns.AnnotationValueIPStackIPDualStack),
+			[]string{"ALIAS:my-lb.eu-west-1.elb.amazonaws.com", "ALIAS_AAAA:my-lb.eu-west-1.elb.amazonaws.com"}},
+		{"cloudfront", dnsutils.NewTarget(dns.RS_CNAME, "d111111abcdef8.cloudfront.net", 300),
+			[]string{"ALIAS:d111111abcdef8.cloudfront.net"}},
+		{"s3 website", dnsutils.NewTargetWithIPStack(dns.RS_CNAME, "bucket.s3-website.eu-central-1.amazonaws.com", 300, dns.AnnotationValueIPStackIPv6),
+			[]string{"ALIAS_AAAA:bucket.s3-website.eu-central-1.amazonaws.com"}},
+		{"explicit canonical hosted zone", dnsutils.NewTargetWithAliasHostedZone(dns.RS_CNAME, "d111111abcdef8.cloudfront.net", 300, "", CloudFrontHostedZone),
+			[]string{"ALIAS:d111111abcdef8.cloudfront.net"}},
+		{"explicit hosted zone", dnsutils.NewTargetWithAliasHostedZone(dns.RS_CNAME, "www.example.com", 300, "", "Z123456"),
+			[]string{"ALIAS:www.example.com@Z123456"}},
+	}
+
+	for _, entry := range table {
+		mapped := MapTargets([]dnsutils.Target{entry.target})
+		var result []string
+		for _, t := range mapped {
+			result = append(result, t.GetRecordType()+":"+t.GetHostName())
+		}
+		if len(result) != len(entry.expected) {
+			t.Errorf("%s: expected %v, got %v", entry.name, entry.expected, result)
+			continue
+		}
+		for i := range result {
+			if result[i] != entry.expected[i] {
+				t.Errorf("%s: expected %v, got %v", entry.name, entry.expected, result)
+				break
+			}
+		}
+	}
+}
@@ -8,7 +8,6 @@ import (
 	"context"
 	"fmt"
 	"strconv"
-	"strings"
 	"sync"
 	"time"
 
@@ -24,9 +23,13 @@ const (
 	keyRegion                      = "region"
 	keyLocation                    = "location"
 	keyCollection                  = "collection"
-	keyFailoverRecordType          = "failoverRecordType"
+	keyFailoverRecordType          = dns.RoutingPolicyParameterFailoverRecordType
 	keyDisableEvaluateTargetHealth = "disableEvaluateTargetHealth"
-	keyHealthCheckID               = "healthCheckID"
+	keyHealthCheckID               = dns.RoutingPolicyParameterHealthCheckID
+
+	// defaultGeoLocationCountryCode is the country code of the default geolocation covering all locations without
+	// own record set. It can also be used as location name.
+	defaultGeoLocationCountryCode = "*"
 
 	// refreshGeoLocationPeriod is the interval to reload the geolocation names and codes
 	refreshGeoLocationPeriod = 24 * time.Hour
@@ -123,17 +126,30 @@ func (r *routingPolicyContext) refreshGeoLocationList(ctx context.Context, perio
 		return nil
 	}
 
-	output, err := r.r53.ListGeoLocations(ctx, &route53.ListGeoLocationsInput{})
-	if err != nil {
-		r.lastGeoLocationListUpdate = time.Now().Add(-period / 2)
-		return fmt.Errorf("listing geo-locations failed: %s", err)
+	var list []route53types.GeoLocationDetails
+	input := &route53.ListGeoLocationsInput{}
+	for {
+		output, err := r.r53.ListGeoLocations(ctx, input)
+		if err != nil {
+			r.lastGeoLocationListUpdate = time.Now().Add(-period / 2)
+			return fmt.Errorf("listing geo-locations failed: %s", err)
+		}
+		list = append(list, output.GeoLocationDetailsList...)
+		if !output.IsTruncated {
+			break
+		}
+		input = &route53.ListGeoLocationsInput{
+			StartContinentCode:   output.NextContinentCode,
+			StartCountryCode:     output.NextCountryCode,
+			StartSubdivisionCode: output.NextSubdivisionCode,
+		}
 	}
 
 	r.lastGeoLocationListUpdate = time.Now()
 	r.cachedGeoLocationCodeToName = map[string]string{}
 	r.cachedGeoLocationNameToLocation = map[string]*route53types.GeoLocation{}
 
-	for _, details := range output.GeoLocationDetailsList {
+	for _, details := range list {
 		var name, altName string
 		if details.SubdivisionName != nil {
 			name = aws.ToString(details.SubdivisionName)
@@ -156,12 +172,46 @@ func (r *routingPolicyContext) refreshGeoLocationList(ctx context.Context, perio
 		}
 		r.cachedGeoLocationNameToLocation[name] = location
 		r.cachedGeoLocationNameToLocation[altName] = location
+		if aws.ToString(details.CountryCode) == defaultGeoLocationCountryCode {
+			r.cachedGeoLocationNameToLocation[defaultGeoLocationCountryCode] = location
+		}
 		code := codeFromGeoLocation(location)
 		r.cachedGeoLocationCodeToName[code] = name
 	}
 	return nil
 }
 
+// normalizeRoutingPolicy replaces the location of a geolocation routing policy by the name used for record sets read
+// from Route53, so that alternative names (e.g. `continent=EU` for `Europe`) do not result in repeated updates.
+// The record type of a failover routing policy is mapped to upper case for the same reason.
+// The routing policy is returned unchanged if it cannot be normalized.
+func (r *routingPolicyContext) normalizeRoutingPolicy(ctx context.Context, policy *dns.RoutingPolicy) *dns.RoutingPolicy {
+	if policy == nil {
+		return nil
+	}
+	if policy.Type == dns.RoutingPolicyFailover {
+		return policy.NormalizeFailoverRecordType()
+	}
+	if policy.Type != dns.RoutingPolicyGeoLocation {
+		return policy
+	}
+	location, ok := policy.Parameters[keyLocation]
+	if !ok {
+		return policy
+	}
+	geoLocation, err := r.lookupGeoLocation(ctx, location)
+	if err != nil {
+		return policy
+	}
+	name, err := r.geoLocationName(ctx, geoLocation)
+	if err != nil || name == location {
+		return policy
+	}
+	normalized := policy.Clone()
+	normalized.Parameters[keyLocation] = name
+	return normalized
+}
+
 func (r *routingPolicyContext) lookupCIDRRoutingConfig(ctx context.Context, collectionName, locationName string) (*route53types.CidrRoutingConfig, error) {
 	r.Lock()
 	defer r.Unlock()
@@ -359,18 +409,11 @@ func (r *routingPolicyContext) addRoutingPolicy(ctx context.Context, rrset *rout
 				rrset.CidrRoutingConfig = cidrRoutingConfig
 			}
 		case keyFailoverRecordType:
-			upperValue := strings.ToUpper(value)
-			valid := false
-			for _, validValue := range route53types.ResourceRecordSetFailoverPrimary.Values() {
-				if string(validValue) == upperValue {
-					valid = true
-					rrset.Failover = validValue
-					break
-				}
-			}
-			if !valid {
-				return fmt.Errorf("invalid %s value: %s", keyFailoverRecordType, value)
+			typ, _, err := routingPolicy.FailoverRecordType()
+			if err != nil {
+				return err
 			}
+			rrset.Failover = route53types.ResourceRecordSetFailover(typ)
 		case keyDisableEvaluateTargetHealth:
 			if rrset.AliasTarget == nil {
 				return fmt.Errorf("%s only allowed for alias targets", keyDisableEvaluateTargetHealth)
@@ -383,6 +426,9 @@ func (r *routingPolicyContext) addRoutingPolicy(ctx context.Context, rrset *rout
 				rrset.AliasTarget.EvaluateTargetHealth = false
 			}
 		case keyHealthCheckID:
+			if err := dns.ValidateHealthCheckID(value); err != nil {
+				return err
+			}
 			rrset.HealthCheckId = aws.String(value)
 		}
 	}
@@ -0,0 +1,126 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package aws
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	route53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+func newCachedRoutingPolicyContext() *routingPolicyContext {
+	europe := &route53types.GeoLocation{ContinentCode: aws.String("EU")}
+	def := &route53types.GeoLocation{CountryCode: aws.String("*")}
+	return &routingPolicyContext{
+		cachedGeoLocationNameToLocation: map[string]*route53types.GeoLocation{
+			"Europe":       europe,
+			"continent=EU": europe,
+			"Default":      def,
+			"country=*":    def,
+			"*":            def,
+		},
+		cachedGeoLocationCodeToName: map[string]string{
+			codeFromGeoLocation(europe): "Europe",
+			codeFromGeoLocation(def):    "Default",
+		},
+		lastGeoLocationListUpdate: time.Now(),
+	}
+}
+
+func TestNormalizeRoutingPolicy(t *testing.T) {
+	ctx := context.Background()
+	r := newCachedRoutingPolicyContext()
+
+	tests := []struct {
+		policy   *dns.RoutingPolicy
+		expected *dns.RoutingPolicy
+	}{
+		{nil, nil},
+		{
+			dns.NewRoutingPolicy(dns.RoutingPolicyWeighted, keyWeight, "10"),
+			dns.NewRoutingPolicy(dns.RoutingPolicyWeighted, keyWeight, "10"),
+		},
+		{
+			dns.NewRoutingPolicy(dns.RoutingPolicyGeoLocation, keyLocation, "Europe"),
+			dns.NewRoutingPolicy(dns.RoutingPolicyGeoLocation, keyLocation, "Europe"),
+		},
+		{
+			dns.NewRoutingPolicy(dns.RoutingPolicyGeoLocation, keyLocation, "continent=EU", keyHealthCheckID, "id"),
+			dns.NewRoutingPolicy(dns.RoutingPolicyGeoLocation, keyLocation, "Europe", keyHealthCheckID, "id"),
+		},
+		{
+			dns.NewRoutingPolicy(dns.RoutingPolicyGeoLocation, keyLocation, "*"),
+			dns.NewRoutingPolicy(dns.RoutingPolicyGeoLocation, keyLocation, "Default"),
+		},
+		{
+			dns.NewRoutingPolicy(dns.RoutingPolicyFailover, keyFailoverRecordType, "primary"),
+			dns.NewRoutingPolicy(dns.RoutingPolicyFailover, keyFailoverRecordType, "PRIMARY"),
+		},
+	}
+	for _, tt := range tests {
+		if got := r.normalizeRoutingPolicy(ctx, tt.policy); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("normalizeRoutingPolicy(%v) = %v, expected %v", tt.policy, got, tt.expected)
+		}
+	}
+}
+
+func TestAddGeoLocationRoutingPolicy(t *testing.T) {
+	ctx := context.Background()
+	r := newCachedRoutingPolicyContext()
+	name := dns.DNSSetName{DNSName: "www.example.com", SetIdentifier: "default"}
+
+	rrset := &route53types.ResourceRecordSet{}
+	if err := r.addRoutingPolicy(ctx, rrset, name, dns.NewRoutingPolicy(dns.RoutingPolicyGeoLocation, keyLocation, "*")); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if aws.ToString(rrset.GeoLocation.CountryCode) != "*" {
+		t.Errorf("unexpected geolocation %v", rrset.GeoLocation)
+	}
+
+	rrset = &route53types.ResourceRecordSet{}
+	if err := r.addRoutingPolicy(ctx, rrset, name, dns.NewRoutingPolicy(dns.RoutingPolicyGeoLocation, keyLocation, "Atlantis")); err == nil {
+		t.Errorf("expected error for unknown location")
+	}
+}
+
+func TestAddFailoverRoutingPolicy(t *testing.T) {
+	ctx := context.Background()
+	r := newCachedRoutingPolicyContext()
+	name := dns.DNSSetName{DNSName: "www.example.com", SetIdentifier: "primary"}
+	healthCheckID := "abcdef11-2222-3333-4444-555555fedcba"
+
+	rrset := &route53types.ResourceRecordSet{}
+	policy := dns.NewRoutingPolicy(dns.RoutingPolicyFailover, keyFailoverRecordType, "primary", keyHealthCheckID, healthCheckID)
+	if err := r.addRoutingPolicy(ctx, rrset, name, policy); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if rrset.Failover != route53types.ResourceRecordSetFailoverPrimary {
+		t.Errorf("unexpected failover record type %q", rrset.Failover)
+	}
+	if aws.ToString(rrset.HealthCheckId) != healthCheckID {
+		t.Errorf("unexpected health check ID %q", aws.ToString(rrset.HealthCheckId))
+	}
+	if got := r.extractRoutingPolicy(ctx, rrset); !reflect.DeepEqual(got, dns.NewRoutingPolicy(dns.RoutingPolicyFailover, keyFailoverRecordType, "PRIMARY", keyHealthCheckID, healthCheckID)) {
+		t.Errorf("unexpected extracted routing policy %v", got)
+	}
+
+	rrset = &route53types.ResourceRecordSet{}
+	policy = dns.NewRoutingPolicy(dns.RoutingPolicyFailover, keyFailoverRecordType, "primary", keyHealthCheckID, "my-check")
+	if err := r.addRoutingPolicy(ctx, rrset, name, policy); err == nil {
+		t.Errorf("expected error for invalid health check ID")
+	}
+
+	rrset = &route53types.ResourceRecordSet{}
+	policy = dns.NewRoutingPolicy(dns.RoutingPolicyFailover, keyFailoverRecordType, "backup")
+	if err := r.addRoutingPolicy(ctx, rrset, name, policy); err == nil {
+		t.Errorf("expected error for invalid failover record type")
+	}
+}
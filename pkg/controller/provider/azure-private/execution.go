@@ -13,6 +13,7 @@ import (
 	"github.com/gardener/external-dns-management/pkg/controller/provider/azure/utils"
 	"github.com/gardener/external-dns-management/pkg/dns"
 	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
 )
 
 type Change struct {
@@ -22,14 +23,16 @@ type Change struct {
 type Execution struct {
 	logger.LogContext
 	handler       *Handler
+	recordsClient *armprivatedns.RecordSetsClient
+	zoneID        string
 	resourceGroup string
 	zoneName      string
 
 	changes map[string][]*Change
 }
 
-func NewExecution(logger logger.LogContext, h *Handler, resourceGroup string, zoneName string) *Execution {
-	return &Execution{LogContext: logger, handler: h, resourceGroup: resourceGroup, zoneName: zoneName, changes: map[string][]*Change{}}
+func NewExecution(logger logger.LogContext, h *Handler, recordsClient *armprivatedns.RecordSetsClient, zoneID, resourceGroup, zoneName string) *Execution {
+	return &Execution{LogContext: logger, handler: h, recordsClient: recordsClient, zoneID: zoneID, resourceGroup: resourceGroup, zoneName: zoneName, changes: map[string][]*Change{}}
 }
 
 type buildStatus int
@@ -112,30 +115,48 @@ func (exec *Execution) buildMappedRecordSet(name string, rset *dns.RecordSet) (b
 	return bs_ok, recordType, &armprivatedns.RecordSet{Name: &name, Properties: &properties}
 }
 
-func (exec *Execution) apply(action string, recordType armprivatedns.RecordType, rset *armprivatedns.RecordSet, metrics provider.Metrics) error {
+// apply executes the change of a record set and returns the new etag of a created or updated record set.
+// If the etag of the record set read from the provider is given, the change is only applied if the record set
+// has not been changed in the meantime. A record set missing in the zone state is created unconditionally,
+// so that an already existing record set with the same name is adopted.
+func (exec *Execution) apply(action string, recordType armprivatedns.RecordType, rset *armprivatedns.RecordSet, etag string, metrics provider.Metrics) (string, error) {
+	var newETag string
 	var err error
 	switch action {
-	case provider.R_CREATE, provider.R_UPDATE:
-		err = exec.update(recordType, rset, metrics)
+	case provider.R_CREATE:
+		newETag, err = exec.update(recordType, rset, nil, metrics)
+	case provider.R_UPDATE:
+		newETag, err = exec.update(recordType, rset, &armprivatedns.RecordSetsClientCreateOrUpdateOptions{IfMatch: ifMatch(etag)}, metrics)
 	case provider.R_DELETE:
-		err = exec.delete(recordType, rset, metrics)
+		err = exec.delete(recordType, rset, &armprivatedns.RecordSetsClientDeleteOptions{IfMatch: ifMatch(etag)}, metrics)
 	}
-	return err
+	if utils.IsPreconditionFailed(err) {
+		err = perrs.NewConditionalUpdateConflictError(err)
+	}
+	return newETag, err
+}
+
+func ifMatch(etag string) *string {
+	if etag == "" {
+		return nil
+	}
+	return &etag
 }
 
-func (exec *Execution) update(recordType armprivatedns.RecordType, rset *armprivatedns.RecordSet, metrics provider.Metrics) error {
+func (exec *Execution) update(recordType armprivatedns.RecordType, rset *armprivatedns.RecordSet, options *armprivatedns.RecordSetsClientCreateOrUpdateOptions, metrics provider.Metrics) (string, error) {
 	exec.handler.config.RateLimiter.Accept()
-	_, err := exec.handler.recordsClient.CreateOrUpdate(exec.handler.ctx, exec.resourceGroup, exec.zoneName,
-		recordType, *rset.Name, *rset, nil)
-	zoneID := utils.MakeZoneID(exec.resourceGroup, exec.zoneName)
-	metrics.AddZoneRequests(zoneID, provider.M_UPDATERECORDS, 1)
-	return err
+	resp, err := exec.recordsClient.CreateOrUpdate(exec.handler.ctx, exec.resourceGroup, exec.zoneName,
+		recordType, *rset.Name, *rset, options)
+	metrics.AddZoneRequests(exec.zoneID, provider.M_UPDATERECORDS, 1)
+	if err != nil {
+		return "", err
+	}
+	return utils.ETag(resp.Etag), nil
 }
 
-func (exec *Execution) delete(recordType armprivatedns.RecordType, rset *armprivatedns.RecordSet, metrics provider.Metrics) error {
+func (exec *Execution) delete(recordType armprivatedns.RecordType, rset *armprivatedns.RecordSet, options *armprivatedns.RecordSetsClientDeleteOptions, metrics provider.Metrics) error {
 	exec.handler.config.RateLimiter.Accept()
-	_, err := exec.handler.recordsClient.Delete(exec.handler.ctx, exec.resourceGroup, exec.zoneName, recordType, *rset.Name, nil)
-	zoneID := utils.MakeZoneID(exec.resourceGroup, exec.zoneName)
-	metrics.AddZoneRequests(zoneID, provider.M_DELETERECORDS, 1)
+	_, err := exec.recordsClient.Delete(exec.handler.ctx, exec.resourceGroup, exec.zoneName, recordType, *rset.Name, options)
+	metrics.AddZoneRequests(exec.zoneID, provider.M_DELETERECORDS, 1)
 	return err
 }
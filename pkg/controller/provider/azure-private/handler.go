@@ -6,6 +6,7 @@ package azureprivate
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -25,11 +26,22 @@ type Handler struct {
 	config        provider.DNSHandlerConfig
 	cache         provider.ZoneCache
 	ctx           context.Context
+	subscriptions []*subscription
+	// multiple is set if more than one subscription is configured, independent of the authenticated ones
+	multiple bool
+}
+
+// subscription contains the clients for a single Azure subscription.
+type subscription struct {
+	id            string
 	zonesClient   *armprivatedns.PrivateZonesClient
 	recordsClient *armprivatedns.RecordSetsClient
 }
 
-var _ provider.DNSHandler = &Handler{}
+var (
+	_ provider.DNSHandler           = &Handler{}
+	_ provider.CapabilitiesReporter = &Handler{}
+)
 
 func NewHandler(c *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
 	h := &Handler{
@@ -39,33 +51,48 @@ func NewHandler(c *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
 
 	h.ctx = c.Context
 
-	subscriptionID, tc, err := utils.GetSubscriptionIDAndCredentials(c)
+	subscriptionIDs, tc, err := utils.GetSubscriptionIDsAndCredentials(c)
 	if err != nil {
 		return nil, err
 	}
+	h.multiple = len(subscriptionIDs) > 1
 	opts, err := utils.GetDefaultAzureClientOpts(c)
 	if err != nil {
 		return nil, err
 	}
 
-	zonesClient, err := armprivatedns.NewPrivateZonesClient(subscriptionID, tc, opts)
-	if err != nil {
-		return nil, err
-	}
-	recordsClient, err := armprivatedns.NewRecordSetsClient(subscriptionID, tc, opts)
-	if err != nil {
-		return nil, err
-	}
+	var authErrs []error
+	for _, subscriptionID := range subscriptionIDs {
+		zonesClient, err := armprivatedns.NewPrivateZonesClient(subscriptionID, tc, opts)
+		if err != nil {
+			return nil, err
+		}
+		recordsClient, err := armprivatedns.NewRecordSetsClient(subscriptionID, tc, opts)
+		if err != nil {
+			return nil, err
+		}
 
-	// dummy call to check authentication
-	h.config.RateLimiter.Accept()
-	_, err = zonesClient.NewListPager(&armprivatedns.PrivateZonesClientListOptions{Top: ptr.To[int32](1)}).NextPage(h.ctx)
-	if err != nil {
-		return nil, perrs.WrapAsHandlerError(err, "Authentication test to Azure with client credentials failed. Please check secret for DNSProvider.")
-	}
+		// dummy call to check authentication
+		h.config.RateLimiter.Accept()
+		_, err = zonesClient.NewListPager(&armprivatedns.PrivateZonesClientListOptions{Top: ptr.To[int32](1)}).NextPage(h.ctx)
+		if err != nil {
+			if len(subscriptionIDs) == 1 {
+				return nil, perrs.WrapAsHandlerError(err, "Authentication test to Azure with client credentials failed. Please check secret for DNSProvider.")
+			}
+			c.Logger.Warnf("skipping subscription %s: authentication test failed: %s", subscriptionID, err)
+			authErrs = append(authErrs, fmt.Errorf("subscription %s: %w", subscriptionID, err))
+			continue
+		}
 
-	h.zonesClient = zonesClient
-	h.recordsClient = recordsClient
+		h.subscriptions = append(h.subscriptions, &subscription{
+			id:            subscriptionID,
+			zonesClient:   zonesClient,
+			recordsClient: recordsClient,
+		})
+	}
+	if len(h.subscriptions) == 0 {
+		return nil, perrs.WrapAsHandlerError(errors.Join(authErrs...), "Authentication test to Azure with client credentials failed for all subscriptions. Please check secret for DNSProvider.")
+	}
 
 	h.cache, err = c.ZoneCacheFactory.CreateZoneCache(provider.CacheZoneState, c.Metrics, h.getZones, h.getZoneState)
 	if err != nil {
@@ -79,23 +106,70 @@ func (h *Handler) Release() {
 	h.cache.Release()
 }
 
+// Capabilities returns that Azure Private DNS requires the apex prefix '@.' for DNS names at the zone apex.
+func (h *Handler) Capabilities() provider.Capabilities {
+	return provider.Capabilities{ApexPrefix: true}
+}
+
 func (h *Handler) GetZones() (provider.DNSHostedZones, error) {
 	return h.cache.GetZones()
 }
 
-func (h *Handler) getZones(_ provider.ZoneCache) (provider.DNSHostedZones, error) {
+// multipleSubscriptions returns true if more than one subscription is configured.
+// In this case the zone IDs are namespaced by the subscription ID, even if some subscriptions cannot be accessed,
+// so that the zone IDs are stable.
+func (h *Handler) multipleSubscriptions() bool {
+	return h.multiple
+}
+
+// lookupSubscription returns the subscription and the resource group and zone name for a zone ID.
+func (h *Handler) lookupSubscription(zoneID string) (*subscription, string, string, error) {
+	if !h.multipleSubscriptions() {
+		resourceGroup, zoneName := utils.SplitZoneID(zoneID)
+		return h.subscriptions[0], resourceGroup, zoneName, nil
+	}
+	subscriptionID, resourceGroup, zoneName := utils.SplitSubscriptionZoneID(zoneID)
+	for _, s := range h.subscriptions {
+		if s.id == subscriptionID {
+			return s, resourceGroup, zoneName, nil
+		}
+	}
+	return nil, resourceGroup, zoneName, fmt.Errorf("unknown subscription for zone %s", zoneID)
+}
+
+func (h *Handler) getZones(ctx context.Context, _ provider.ZoneCache) (provider.DNSHostedZones, error) {
+	zones := provider.DNSHostedZones{}
+	var errs []error
+	for _, s := range h.subscriptions {
+		subscriptionZones, err := h.getSubscriptionZones(ctx, s)
+		if err != nil {
+			if !h.multipleSubscriptions() {
+				return nil, err
+			}
+			h.config.Logger.Warnf("skipping zones of subscription %s: %s", s.id, err)
+			errs = append(errs, fmt.Errorf("subscription %s: %w", s.id, err))
+			continue
+		}
+		zones = append(zones, subscriptionZones...)
+	}
+	if len(errs) == len(h.subscriptions) {
+		// zones of all subscriptions failed, an empty zone list would drop all zones of the provider
+		return nil, errors.Join(errs...)
+	}
+	return zones, nil
+}
+
+func (h *Handler) getSubscriptionZones(ctx context.Context, s *subscription) (provider.DNSHostedZones, error) {
 	zones := provider.DNSHostedZones{}
 	h.config.RateLimiter.Accept()
 
 	blockedZones := h.config.Options.AdvancedOptions.GetBlockedZones()
-	pager := h.zonesClient.NewListPager(nil)
+	pager := s.zonesClient.NewListPager(nil)
 	for pager.More() {
 		h.config.Metrics.AddGenericRequests(provider.M_LISTZONES, 1)
-		page, err := pager.NextPage(h.ctx)
+		page, err := pager.NextPage(ctx)
 		if err != nil {
-			if err != nil {
-				return nil, perrs.WrapAsHandlerError(err, "Listing DNS zones failed")
-			}
+			return nil, perrs.WrapAsHandlerError(err, "Listing DNS zones failed")
 		}
 
 		for _, item := range page.Value {
@@ -105,6 +179,9 @@ func (h *Handler) getZones(_ provider.ZoneCache) (provider.DNSHostedZones, error
 				logger.Warnf("skipping zone: %s", err)
 			} else {
 				zoneID = utils.MakeZoneID(resourceGroup, *item.Name)
+				if h.multipleSubscriptions() {
+					zoneID = utils.MakeSubscriptionZoneID(s.id, resourceGroup, *item.Name)
+				}
 				if blockedZones.Contains(zoneID) {
 					h.config.Logger.Infof("ignoring blocked zone id: %s", zoneID)
 					zoneID = ""
@@ -130,11 +207,14 @@ func (h *Handler) GetZoneState(zone provider.DNSHostedZone) (provider.DNSZoneSta
 func (h *Handler) getZoneState(zone provider.DNSHostedZone, _ provider.ZoneCache) (provider.DNSZoneState, error) {
 	dnssets := dns.DNSSets{}
 
-	resourceGroup, zoneName := utils.SplitZoneID(zone.Id().ID)
+	s, resourceGroup, zoneName, err := h.lookupSubscription(zone.Id().ID)
+	if err != nil {
+		return nil, err
+	}
 	h.config.RateLimiter.Accept()
 
 	h.config.Metrics.AddZoneRequests(zone.Id().ID, provider.M_LISTRECORDS, 1)
-	pager := h.recordsClient.NewListPager(resourceGroup, zoneName, nil)
+	pager := s.recordsClient.NewListPager(resourceGroup, zoneName, nil)
 	for pager.More() {
 		page, err := pager.NextPage(h.ctx)
 		h.config.Metrics.AddZoneRequests(zone.Id().ID, provider.M_PLISTRECORDS, 1)
@@ -151,16 +231,19 @@ func (h *Handler) getZoneState(zone provider.DNSHostedZone, _ provider.ZoneCache
 				for _, record := range item.Properties.ARecords {
 					rs.Add(&dns.Record{Value: *record.IPv4Address})
 				}
+				rs.ETag = utils.ETag(item.Etag)
 				dnssets.AddRecordSetFromProvider(fullName, rs)
 			case item.Properties.AaaaRecords != nil:
 				rs := dns.NewRecordSet(dns.RS_AAAA, *item.Properties.TTL, nil)
 				for _, record := range item.Properties.AaaaRecords {
 					rs.Add(&dns.Record{Value: *record.IPv6Address})
 				}
+				rs.ETag = utils.ETag(item.Etag)
 				dnssets.AddRecordSetFromProvider(fullName, rs)
 			case item.Properties.CnameRecord != nil:
 				rs := dns.NewRecordSet(dns.RS_CNAME, *item.Properties.TTL, nil)
 				rs.Add(&dns.Record{Value: *item.Properties.CnameRecord.Cname})
+				rs.ETag = utils.ETag(item.Etag)
 				dnssets.AddRecordSetFromProvider(fullName, rs)
 			case item.Properties.TxtRecords != nil:
 				rs := dns.NewRecordSet(dns.RS_TXT, *item.Properties.TTL, nil)
@@ -176,6 +259,7 @@ func (h *Handler) getZoneState(zone provider.DNSHostedZone, _ provider.ZoneCache
 					}
 					rs.Add(&dns.Record{Value: quoted})
 				}
+				rs.ETag = utils.ETag(item.Etag)
 				dnssets.AddRecordSetFromProvider(fullName, rs)
 			}
 		}
@@ -195,10 +279,13 @@ func (h *Handler) ExecuteRequests(logger logger.LogContext, zone provider.DNSHos
 }
 
 func (h *Handler) executeRequests(logger logger.LogContext, zone provider.DNSHostedZone, _ provider.DNSZoneState, reqs []*provider.ChangeRequest) error {
-	resourceGroup, zoneName := utils.SplitZoneID(zone.Id().ID)
-	exec := NewExecution(logger, h, resourceGroup, zoneName)
+	s, resourceGroup, zoneName, err := h.lookupSubscription(zone.Id().ID)
+	if err != nil {
+		return err
+	}
+	exec := NewExecution(logger, h, s.recordsClient, zone.Id().ID, resourceGroup, zoneName)
 
-	var succeeded, failed int
+	var succeeded, failed, conflicts int
 	for _, r := range reqs {
 		status, recordType, rset := exec.buildRecordSet(r)
 		switch status {
@@ -226,15 +313,19 @@ func (h *Handler) executeRequests(logger logger.LogContext, zone provider.DNSHos
 			continue
 		}
 
-		err := exec.apply(r.Action, recordType, rset, h.config.Metrics)
+		etag, err := exec.apply(r.Action, recordType, rset, r.ETag(), h.config.Metrics)
 		if err != nil {
 			failed++
+			if perrs.IsConditionalUpdateConflictError(err) {
+				conflicts++
+			}
 			logger.Infof("Apply failed with %s", err.Error())
 			if r.Done != nil {
 				r.Done.Failed(err)
 			}
 		} else {
 			succeeded++
+			r.SetETag(etag)
 			if r.Done != nil {
 				r.Done.Succeeded()
 			}
@@ -251,6 +342,9 @@ func (h *Handler) executeRequests(logger logger.LogContext, zone provider.DNSHos
 	}
 	if failed > 0 {
 		logger.Infof("Failed updates for records in zone %s: %d", zoneName, failed)
+		if conflicts > 0 {
+			return perrs.NewConditionalUpdateConflictError(fmt.Errorf("%d changes failed (%d conflicts)", failed, conflicts))
+		}
 		return fmt.Errorf("%d changes failed", failed)
 	}
 
@@ -13,6 +13,7 @@ import (
 
 	"github.com/gardener/external-dns-management/pkg/dns"
 	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
 )
 
 type Change struct {
@@ -112,29 +113,49 @@ func (exec *Execution) buildMappedRecordSet(name string, rset *dns.RecordSet) (b
 	return bs_ok, recordType, &armdns.RecordSet{Name: &name, Properties: &properties}
 }
 
-func (exec *Execution) apply(action string, recordType armdns.RecordType, rset *armdns.RecordSet, metrics provider.Metrics) error {
+// apply executes the change of a record set and returns the new etag of a created or updated record set.
+// If the etag of the record set read from the provider is given, the change is only applied if the record set
+// has not been changed in the meantime. A record set missing in the zone state is created unconditionally,
+// so that an already existing record set with the same name is adopted.
+func (exec *Execution) apply(action string, recordType armdns.RecordType, rset *armdns.RecordSet, etag string, metrics provider.Metrics) (string, error) {
+	var newETag string
 	var err error
 	switch action {
-	case provider.R_CREATE, provider.R_UPDATE:
-		err = exec.update(recordType, rset, metrics)
+	case provider.R_CREATE:
+		newETag, err = exec.update(recordType, rset, nil, metrics)
+	case provider.R_UPDATE:
+		newETag, err = exec.update(recordType, rset, &armdns.RecordSetsClientCreateOrUpdateOptions{IfMatch: ifMatch(etag)}, metrics)
 	case provider.R_DELETE:
-		err = exec.delete(recordType, rset, metrics)
+		err = exec.delete(recordType, rset, &armdns.RecordSetsClientDeleteOptions{IfMatch: ifMatch(etag)}, metrics)
 	}
-	return err
+	if utils.IsPreconditionFailed(err) {
+		err = perrs.NewConditionalUpdateConflictError(err)
+	}
+	return newETag, err
+}
+
+func ifMatch(etag string) *string {
+	if etag == "" {
+		return nil
+	}
+	return &etag
 }
 
-func (exec *Execution) update(recordType armdns.RecordType, rset *armdns.RecordSet, metrics provider.Metrics) error {
+func (exec *Execution) update(recordType armdns.RecordType, rset *armdns.RecordSet, options *armdns.RecordSetsClientCreateOrUpdateOptions, metrics provider.Metrics) (string, error) {
 	exec.handler.config.RateLimiter.Accept()
-	_, err := exec.handler.recordsClient.CreateOrUpdate(exec.handler.ctx, exec.resourceGroup, exec.zoneName, *rset.Name,
-		recordType, *rset, nil)
+	resp, err := exec.handler.recordsClient.CreateOrUpdate(exec.handler.ctx, exec.resourceGroup, exec.zoneName, *rset.Name,
+		recordType, *rset, options)
 	zoneID := utils.MakeZoneID(exec.resourceGroup, exec.zoneName)
 	metrics.AddZoneRequests(zoneID, provider.M_UPDATERECORDS, 1)
-	return err
+	if err != nil {
+		return "", err
+	}
+	return utils.ETag(resp.Etag), nil
 }
 
-func (exec *Execution) delete(recordType armdns.RecordType, rset *armdns.RecordSet, metrics provider.Metrics) error {
+func (exec *Execution) delete(recordType armdns.RecordType, rset *armdns.RecordSet, options *armdns.RecordSetsClientDeleteOptions, metrics provider.Metrics) error {
 	exec.handler.config.RateLimiter.Accept()
-	_, err := exec.handler.recordsClient.Delete(exec.handler.ctx, exec.resourceGroup, exec.zoneName, *rset.Name, recordType, nil)
+	_, err := exec.handler.recordsClient.Delete(exec.handler.ctx, exec.resourceGroup, exec.zoneName, *rset.Name, recordType, options)
 	zoneID := utils.MakeZoneID(exec.resourceGroup, exec.zoneName)
 	metrics.AddZoneRequests(zoneID, provider.M_DELETERECORDS, 1)
 	return err
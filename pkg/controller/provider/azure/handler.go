@@ -22,6 +22,7 @@ import (
 
 type Handler struct {
 	provider.DefaultDNSHandler
+	provider.DeniedZonesTracker
 	config        provider.DNSHandlerConfig
 	cache         provider.ZoneCache
 	ctx           context.Context
@@ -29,7 +30,11 @@ type Handler struct {
 	recordsClient *armdns.RecordSetsClient
 }
 
-var _ provider.DNSHandler = &Handler{}
+var (
+	_ provider.DNSHandler           = &Handler{}
+	_ provider.CapabilitiesReporter = &Handler{}
+	_ provider.DeniedZonesReporter  = &Handler{}
+)
 
 func NewHandler(c *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
 	h := &Handler{
@@ -79,11 +84,16 @@ func (h *Handler) Release() {
 	h.cache.Release()
 }
 
+// Capabilities returns that Azure DNS requires the apex prefix '@.' for DNS names at the zone apex.
+func (h *Handler) Capabilities() provider.Capabilities {
+	return provider.Capabilities{ApexPrefix: true}
+}
+
 func (h *Handler) GetZones() (provider.DNSHostedZones, error) {
 	return h.cache.GetZones()
 }
 
-func (h *Handler) getZones(_ provider.ZoneCache) (provider.DNSHostedZones, error) {
+func (h *Handler) getZones(ctx context.Context, _ provider.ZoneCache) (provider.DNSHostedZones, error) {
 	zones := provider.DNSHostedZones{}
 	h.config.RateLimiter.Accept()
 
@@ -91,7 +101,7 @@ func (h *Handler) getZones(_ provider.ZoneCache) (provider.DNSHostedZones, error
 	pager := h.zonesClient.NewListPager(nil)
 	for pager.More() {
 		h.config.Metrics.AddGenericRequests(provider.M_LISTZONES, 1)
-		page, err := pager.NextPage(h.ctx)
+		page, err := pager.NextPage(ctx)
 		if err != nil {
 			if err != nil {
 				return nil, perrs.WrapAsHandlerError(err, "Listing DNS zones failed")
@@ -139,6 +149,7 @@ func (h *Handler) getZoneState(zone provider.DNSHostedZone, _ provider.ZoneCache
 		page, err := pager.NextPage(h.ctx)
 		h.config.Metrics.AddZoneRequests(zone.Id().ID, provider.M_PLISTRECORDS, 1)
 		if err != nil {
+			h.UpdateDeniedZone(zone.Id().ID, utils.IsAccessDenied(err))
 			return nil, perrs.WrapfAsHandlerError(err, "Listing DNS zone state for zone %s failed", zoneName)
 		}
 		for _, item := range page.Value {
@@ -151,16 +162,19 @@ func (h *Handler) getZoneState(zone provider.DNSHostedZone, _ provider.ZoneCache
 				for _, record := range item.Properties.ARecords {
 					rs.Add(&dns.Record{Value: *record.IPv4Address})
 				}
+				rs.ETag = utils.ETag(item.Etag)
 				dnssets.AddRecordSetFromProvider(fullName, rs)
 			case item.Properties.AaaaRecords != nil:
 				rs := dns.NewRecordSet(dns.RS_AAAA, *item.Properties.TTL, nil)
 				for _, record := range item.Properties.AaaaRecords {
 					rs.Add(&dns.Record{Value: *record.IPv6Address})
 				}
+				rs.ETag = utils.ETag(item.Etag)
 				dnssets.AddRecordSetFromProvider(fullName, rs)
 			case item.Properties.CnameRecord != nil:
 				rs := dns.NewRecordSet(dns.RS_CNAME, *item.Properties.TTL, nil)
 				rs.Add(&dns.Record{Value: *item.Properties.CnameRecord.Cname})
+				rs.ETag = utils.ETag(item.Etag)
 				dnssets.AddRecordSetFromProvider(fullName, rs)
 			case item.Properties.TxtRecords != nil:
 				rs := dns.NewRecordSet(dns.RS_TXT, *item.Properties.TTL, nil)
@@ -176,11 +190,13 @@ func (h *Handler) getZoneState(zone provider.DNSHostedZone, _ provider.ZoneCache
 					}
 					rs.Add(&dns.Record{Value: quoted})
 				}
+				rs.ETag = utils.ETag(item.Etag)
 				dnssets.AddRecordSetFromProvider(fullName, rs)
 			}
 		}
 	}
 
+	h.UpdateDeniedZone(zone.Id().ID, false)
 	return provider.NewDNSZoneState(dnssets), nil
 }
 
@@ -198,7 +214,7 @@ func (h *Handler) executeRequests(logger logger.LogContext, zone provider.DNSHos
 	resourceGroup, zoneName := utils.SplitZoneID(zone.Id().ID)
 	exec := NewExecution(logger, h, resourceGroup, zoneName)
 
-	var succeeded, failed int
+	var succeeded, failed, conflicts int
 	for _, r := range reqs {
 		status, recordType, rset := exec.buildRecordSet(r)
 		switch status {
@@ -226,15 +242,19 @@ func (h *Handler) executeRequests(logger logger.LogContext, zone provider.DNSHos
 			continue
 		}
 
-		err := exec.apply(r.Action, recordType, rset, h.config.Metrics)
+		etag, err := exec.apply(r.Action, recordType, rset, r.ETag(), h.config.Metrics)
 		if err != nil {
 			failed++
+			if perrs.IsConditionalUpdateConflictError(err) {
+				conflicts++
+			}
 			logger.Infof("Apply failed with %s", err.Error())
 			if r.Done != nil {
 				r.Done.Failed(err)
 			}
 		} else {
 			succeeded++
+			r.SetETag(etag)
 			if r.Done != nil {
 				r.Done.Succeeded()
 			}
@@ -251,6 +271,9 @@ func (h *Handler) executeRequests(logger logger.LogContext, zone provider.DNSHos
 	}
 	if failed > 0 {
 		logger.Infof("Failed updates for records in zone %s: %d", zoneName, failed)
+		if conflicts > 0 {
+			return perrs.NewConditionalUpdateConflictError(fmt.Errorf("%d changes failed (%d conflicts)", failed, conflicts))
+		}
 		return fmt.Errorf("%d changes failed", failed)
 	}
 
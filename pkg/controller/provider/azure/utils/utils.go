@@ -6,11 +6,14 @@ package utils
 
 import (
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"math"
 	"net"
 	"net/http"
+	"net/url"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
 
@@ -65,13 +68,79 @@ func SplitZoneID(zoneid string) (string, string) {
 	return parts[0], parts[1]
 }
 
+// MakeSubscriptionZoneID creates zone ID from subscription ID, resource group and name
+func MakeSubscriptionZoneID(subscriptionID, resourceGroup, zoneName string) string {
+	return subscriptionID + "/" + MakeZoneID(resourceGroup, zoneName)
+}
+
+// SplitSubscriptionZoneID returns subscription ID, resource group and name for a zoneid.
+// The subscription ID is empty if the zone ID is not namespaced by a subscription.
+func SplitSubscriptionZoneID(zoneid string) (string, string, string) {
+	parts := strings.SplitN(zoneid, "/", 3)
+	if len(parts) != 3 {
+		resourceGroup, zoneName := SplitZoneID(zoneid)
+		return "", resourceGroup, zoneName
+	}
+	return parts[0], parts[1], parts[2]
+}
+
+// SecretKeys are the keys of the secret of an Azure DNS provider.
+var SecretKeys = []provider.SecretKey{
+	provider.RequiredSecretKey("AZURE_SUBSCRIPTION_ID", "subscriptionID"),
+	provider.RequiredSecretKey("AZURE_CLIENT_ID", "clientID"),
+	provider.RequiredSecretKey("AZURE_CLIENT_SECRET", "clientSecret"),
+	provider.RequiredSecretKey("AZURE_TENANT_ID", "tenantID"),
+	provider.OptionalSecretKey("AZURE_CLOUD"),
+	provider.ProxySecretKey,
+}
+
+// PrivateSecretKeys are the keys of the secret of an Azure Private DNS provider.
+// The single subscription ID is only required if no list of subscription IDs is given.
+var PrivateSecretKeys = []provider.SecretKey{
+	provider.RequiredSecretKey("AZURE_SUBSCRIPTION_ID", "subscriptionID").If(provider.IsNotGiven("AZURE_SUBSCRIPTION_IDS", "subscriptionIDs")),
+	provider.OptionalSecretKey("AZURE_SUBSCRIPTION_IDS", "subscriptionIDs"),
+	provider.RequiredSecretKey("AZURE_CLIENT_ID", "clientID"),
+	provider.RequiredSecretKey("AZURE_CLIENT_SECRET", "clientSecret"),
+	provider.RequiredSecretKey("AZURE_TENANT_ID", "tenantID"),
+	provider.OptionalSecretKey("AZURE_CLOUD"),
+	provider.ProxySecretKey,
+}
+
 // GetSubscriptionIDAndCredentials extracts credentials from config
 func GetSubscriptionIDAndCredentials(c *provider.DNSHandlerConfig) (subscriptionID string, tc azcore.TokenCredential, err error) {
 	subscriptionID, err = c.GetRequiredProperty("AZURE_SUBSCRIPTION_ID", "subscriptionID")
 	if err != nil {
 		return
 	}
+	tc, err = getCredentials(c)
+	return
+}
 
+// GetSubscriptionIDsAndCredentials extracts credentials and a list of subscription IDs from config.
+// The subscription IDs are taken from the comma separated list `AZURE_SUBSCRIPTION_IDS` if specified,
+// otherwise from the single subscription ID `AZURE_SUBSCRIPTION_ID`.
+func GetSubscriptionIDsAndCredentials(c *provider.DNSHandlerConfig) (subscriptionIDs []string, tc azcore.TokenCredential, err error) {
+	if list := c.GetProperty("AZURE_SUBSCRIPTION_IDS", "subscriptionIDs"); list != "" {
+		for _, id := range strings.Split(list, ",") {
+			id = strings.TrimSpace(id)
+			if id != "" && !slices.Contains(subscriptionIDs, id) {
+				subscriptionIDs = append(subscriptionIDs, id)
+			}
+		}
+	}
+	if len(subscriptionIDs) == 0 {
+		var subscriptionID string
+		subscriptionID, err = c.GetRequiredProperty("AZURE_SUBSCRIPTION_ID", "subscriptionID")
+		if err != nil {
+			return
+		}
+		subscriptionIDs = []string{subscriptionID}
+	}
+	tc, err = getCredentials(c)
+	return
+}
+
+func getCredentials(c *provider.DNSHandlerConfig) (tc azcore.TokenCredential, err error) {
 	// see https://docs.microsoft.com/en-us/go/azure/azure-sdk-go-authorization
 	clientID, err := c.GetRequiredProperty("AZURE_CLIENT_ID", "clientID")
 	if err != nil {
@@ -86,7 +155,10 @@ func GetSubscriptionIDAndCredentials(c *provider.DNSHandlerConfig) (subscription
 		return
 	}
 
-	tc, err = azidentity.NewClientSecretCredential(tenantID, clientID, clientSecret, nil)
+	opts := &azidentity.ClientSecretCredentialOptions{
+		ClientOptions: policy.ClientOptions{Transport: newHTTPClient(c)},
+	}
+	tc, err = azidentity.NewClientSecretCredential(tenantID, clientID, clientSecret, opts)
 	if err != nil {
 		err = perrs.WrapAsHandlerError(err, "Creating Azure authorizer with client credentials failed")
 	}
@@ -114,17 +186,20 @@ func GetDefaultAzureClientOpts(c *provider.DNSHandlerConfig) (*arm.ClientOptions
 				MaxRetries:    DefaultMaxRetries,
 				StatusCodes:   getRetriableStatusCode(),
 			},
-			Transport: &http.Client{
-				Transport: getTransport(),
-			},
-			Cloud: cloudConf,
+			Transport: newHTTPClient(c),
+			Cloud:     cloudConf,
 		},
 	}, nil
 }
 
-func getTransport() *http.Transport {
+// newHTTPClient returns the HTTP client for Azure requests using the proxy of the handler.
+func newHTTPClient(c *provider.DNSHandlerConfig) *http.Client {
+	return &http.Client{Transport: c.WrapProxyErrors(getTransport(c.Proxy))}
+}
+
+func getTransport(proxy func(*http.Request) (*url.URL, error)) *http.Transport {
 	return &http.Transport{
-		Proxy: http.ProxyFromEnvironment,
+		Proxy: proxy,
 		DialContext: (&net.Dialer{
 			Timeout:   30 * time.Second,
 			KeepAlive: 30 * time.Second,
@@ -140,6 +215,26 @@ func getTransport() *http.Transport {
 	}
 }
 
+// IsPreconditionFailed returns true if a conditional request was rejected because the etag of the record set did not match.
+func IsPreconditionFailed(err error) bool {
+	var rerr *azcore.ResponseError
+	return errors.As(err, &rerr) && rerr.StatusCode == http.StatusPreconditionFailed
+}
+
+// IsAccessDenied returns true if a request was rejected as the credentials grant no access to the resource.
+func IsAccessDenied(err error) bool {
+	var rerr *azcore.ResponseError
+	return errors.As(err, &rerr) && rerr.StatusCode == http.StatusForbidden
+}
+
+// ETag returns the etag of a record set read from Azure or an empty string if not set.
+func ETag(etag *string) string {
+	if etag == nil {
+		return ""
+	}
+	return *etag
+}
+
 func getRetriableStatusCode() []int {
 	return []int{
 		http.StatusRequestTimeout,      // 408
@@ -4,7 +4,14 @@
 
 package utils
 
-import "testing"
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"k8s.io/utils/ptr"
+)
 
 func TestDropZoneName(t *testing.T) {
 	table := []struct {
@@ -29,3 +36,46 @@ func TestDropZoneName(t *testing.T) {
 		}
 	}
 }
+
+func TestSplitSubscriptionZoneID(t *testing.T) {
+	table := []struct {
+		zoneID                string
+		expectedSubscription  string
+		expectedResourceGroup string
+		expectedZoneName      string
+	}{
+		{"sub1/rg1/test.com", "sub1", "rg1", "test.com"},
+		{"rg1/test.com", "", "rg1", "test.com"},
+		{"test.com", "", "", "test.com"},
+	}
+	for _, entry := range table {
+		subscriptionID, resourceGroup, zoneName := SplitSubscriptionZoneID(entry.zoneID)
+		if subscriptionID != entry.expectedSubscription || resourceGroup != entry.expectedResourceGroup || zoneName != entry.expectedZoneName {
+			t.Errorf("Failed: unexpected result: %s, %s, %s for %v", subscriptionID, resourceGroup, zoneName, entry)
+		}
+	}
+	if zoneID := MakeSubscriptionZoneID("sub1", "rg1", "test.com"); zoneID != "sub1/rg1/test.com" {
+		t.Errorf("Failed: unexpected zone ID: %s", zoneID)
+	}
+}
+
+func TestIsPreconditionFailed(t *testing.T) {
+	if !IsPreconditionFailed(fmt.Errorf("wrapped: %w", &azcore.ResponseError{StatusCode: http.StatusPreconditionFailed})) {
+		t.Errorf("Failed: precondition failed not detected")
+	}
+	if IsPreconditionFailed(&azcore.ResponseError{StatusCode: http.StatusNotFound}) || IsPreconditionFailed(fmt.Errorf("other")) {
+		t.Errorf("Failed: unexpected precondition failed")
+	}
+	if ETag(nil) != "" || ETag(ptr.To("etag")) != "etag" {
+		t.Errorf("Failed: unexpected etag")
+	}
+}
+
+func TestIsAccessDenied(t *testing.T) {
+	if !IsAccessDenied(fmt.Errorf("wrapped: %w", &azcore.ResponseError{StatusCode: http.StatusForbidden})) {
+		t.Errorf("Failed: access denied not detected")
+	}
+	if IsAccessDenied(&azcore.ResponseError{StatusCode: http.StatusNotFound}) || IsAccessDenied(fmt.Errorf("other")) {
+		t.Errorf("Failed: unexpected access denied")
+	}
+}
@@ -5,16 +5,33 @@
 package cloudflare
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
 	"github.com/cloudflare/cloudflare-go"
 	"k8s.io/client-go/util/flowcontrol"
 
+	"github.com/gardener/external-dns-management/pkg/dns"
 	"github.com/gardener/external-dns-management/pkg/dns/provider"
 	"github.com/gardener/external-dns-management/pkg/dns/provider/raw"
 )
 
+// recordsPerPage is the maximum page size for listing DNS records.
+const recordsPerPage = 100
+
 type Access interface {
 	ListZones(consume func(zone cloudflare.Zone) (bool, error)) error
-	ListRecords(zoneId string, consume func(record cloudflare.DNSRecord) (bool, error)) error
+	ListRecords(zoneId string, consume func(record Record) (bool, error)) error
+
+	ListWeightedLoadBalancers(zoneId string, consume func(lb cloudflare.LoadBalancer, pool cloudflare.LoadBalancerPool) (bool, error)) error
+	GetWeightedLoadBalancer(zoneId, dnsName string) (*cloudflare.LoadBalancer, *cloudflare.LoadBalancerPool, error)
+	ApplyWeightedLoadBalancer(zoneId, dnsName string, ttl int64, origins []cloudflare.LoadBalancerOrigin,
+		lb *cloudflare.LoadBalancer, pool *cloudflare.LoadBalancerPool) error
+	DeleteWeightedLoadBalancer(zoneId string, lb *cloudflare.LoadBalancer, pool *cloudflare.LoadBalancerPool) error
 
 	raw.Executor
 }
@@ -48,16 +65,16 @@ func (this *access) ListZones(consume func(zone cloudflare.Zone) (bool, error))
 	return nil
 }
 
-func (this *access) ListRecords(zoneId string, consume func(record cloudflare.DNSRecord) (bool, error)) error {
+func (this *access) ListRecords(zoneId string, consume func(record Record) (bool, error)) error {
 	return this.listRecords(zoneId, consume, cloudflare.DNSRecord{})
 }
 
-func (this *access) listRecords(zoneId string, consume func(record cloudflare.DNSRecord) (bool, error),
+func (this *access) listRecords(zoneId string, consume func(record Record) (bool, error),
 	record cloudflare.DNSRecord,
 ) error {
 	this.metrics.AddZoneRequests(zoneId, provider.M_LISTRECORDS, 1)
 	this.rateLimiter.Accept()
-	results, err := this.DNSRecords(zoneId, record)
+	results, err := this.dnsRecords(zoneId, record)
 	if err != nil {
 		return err
 	}
@@ -69,20 +86,160 @@ func (this *access) listRecords(zoneId string, consume func(record cloudflare.DN
 	return nil
 }
 
+// dnsRecords lists the DNS records of the zone matching the name and type of the given record.
+// In contrast to the Cloudflare client, the comments of the records are read, too.
+func (this *access) dnsRecords(zoneId string, filter cloudflare.DNSRecord) ([]Record, error) {
+	v := url.Values{}
+	v.Set("per_page", strconv.Itoa(recordsPerPage))
+	if filter.Name != "" {
+		v.Set("name", filter.Name)
+	}
+	if filter.Type != "" {
+		v.Set("type", filter.Type)
+	}
+	var records []Record
+	for page := 1; ; page++ {
+		v.Set("page", strconv.Itoa(page))
+		res, err := this.Raw(http.MethodGet, "/zones/"+zoneId+"/dns_records?"+v.Encode(), nil)
+		if err != nil {
+			return nil, err
+		}
+		var result []Record
+		if err := json.Unmarshal(res, &result); err != nil {
+			return nil, fmt.Errorf("cannot unmarshal DNS records: %w", err)
+		}
+		records = append(records, result...)
+		if len(result) < recordsPerPage {
+			return records, nil
+		}
+	}
+}
+
+// ListWeightedLoadBalancers lists all load balancers of the zone managed for weighted routing policies together with their pool.
+func (this *access) ListWeightedLoadBalancers(zoneId string,
+	consume func(lb cloudflare.LoadBalancer, pool cloudflare.LoadBalancerPool) (bool, error),
+) error {
+	this.metrics.AddZoneRequests(zoneId, provider.M_LISTRECORDS, 1)
+	this.rateLimiter.Accept()
+	results, err := this.API.ListLoadBalancers(zoneId)
+	if err != nil {
+		return err
+	}
+	for _, lb := range results {
+		if !isManagedLoadBalancer(lb.Description) || len(lb.DefaultPools) == 0 {
+			continue
+		}
+		this.metrics.AddZoneRequests(zoneId, provider.M_LISTRECORDS, 1)
+		this.rateLimiter.Accept()
+		pool, err := this.LoadBalancerPoolDetails(lb.DefaultPools[0])
+		if err != nil {
+			return err
+		}
+		if cont, err := consume(lb, pool); !cont || err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetWeightedLoadBalancer returns the load balancer and pool managed for the DNS name or nil if not existing.
+func (this *access) GetWeightedLoadBalancer(zoneId, dnsName string) (*cloudflare.LoadBalancer, *cloudflare.LoadBalancerPool, error) {
+	var resultLB *cloudflare.LoadBalancer
+	var resultPool *cloudflare.LoadBalancerPool
+	consume := func(lb cloudflare.LoadBalancer, pool cloudflare.LoadBalancerPool) (bool, error) {
+		if lb.Name == dnsName {
+			resultLB = &lb
+			resultPool = &pool
+			return false, nil
+		}
+		return true, nil
+	}
+	if err := this.ListWeightedLoadBalancers(zoneId, consume); err != nil {
+		return nil, nil, err
+	}
+	return resultLB, resultPool, nil
+}
+
+// ApplyWeightedLoadBalancer creates or updates the load balancer and pool for the DNS name with the given origins.
+func (this *access) ApplyWeightedLoadBalancer(zoneId, dnsName string, ttl int64, origins []cloudflare.LoadBalancerOrigin,
+	lb *cloudflare.LoadBalancer, pool *cloudflare.LoadBalancerPool,
+) error {
+	testTTL(&ttl)
+	var err error
+	if pool == nil {
+		newPool := cloudflare.LoadBalancerPool{
+			Name:        poolName(dnsName),
+			Description: managedDescription(dnsName),
+			Enabled:     true,
+			Origins:     origins,
+		}
+		this.metrics.AddZoneRequests(zoneId, provider.M_CREATERECORDS, 1)
+		this.rateLimiter.Accept()
+		newPool, err = this.CreateLoadBalancerPool(newPool)
+		if err != nil {
+			return err
+		}
+		pool = &newPool
+	} else {
+		pool.Origins = origins
+		this.metrics.AddZoneRequests(zoneId, provider.M_UPDATERECORDS, 1)
+		this.rateLimiter.Accept()
+		if _, err = this.ModifyLoadBalancerPool(*pool); err != nil {
+			return err
+		}
+	}
+
+	if lb == nil {
+		newLB := cloudflare.LoadBalancer{
+			Name:           dnsName,
+			Description:    managedDescription(dnsName),
+			TTL:            int(ttl),
+			FallbackPool:   pool.ID,
+			DefaultPools:   []string{pool.ID},
+			SteeringPolicy: "off",
+		}
+		this.metrics.AddZoneRequests(zoneId, provider.M_CREATERECORDS, 1)
+		this.rateLimiter.Accept()
+		_, err = this.CreateLoadBalancer(zoneId, newLB)
+		return err
+	}
+	if lb.TTL != int(ttl) {
+		lb.TTL = int(ttl)
+		this.metrics.AddZoneRequests(zoneId, provider.M_UPDATERECORDS, 1)
+		this.rateLimiter.Accept()
+		_, err = this.ModifyLoadBalancer(zoneId, *lb)
+	}
+	return err
+}
+
+// DeleteWeightedLoadBalancer deletes the load balancer and its pool.
+func (this *access) DeleteWeightedLoadBalancer(zoneId string, lb *cloudflare.LoadBalancer, pool *cloudflare.LoadBalancerPool) error {
+	if lb != nil {
+		this.metrics.AddZoneRequests(zoneId, provider.M_DELETERECORDS, 1)
+		this.rateLimiter.Accept()
+		if err := this.API.DeleteLoadBalancer(zoneId, lb.ID); err != nil {
+			return err
+		}
+	}
+	if pool != nil {
+		this.metrics.AddZoneRequests(zoneId, provider.M_DELETERECORDS, 1)
+		this.rateLimiter.Accept()
+		return this.DeleteLoadBalancerPool(pool.ID)
+	}
+	return nil
+}
+
 func (this *access) CreateRecord(r raw.Record, zone provider.DNSHostedZone) error {
 	a := r.(*Record)
 	ttl := r.GetTTL()
 	testTTL(&ttl)
-	dnsRecord := cloudflare.DNSRecord{
-		Type:    r.GetType(),
-		Name:    r.GetDNSName(),
-		Content: r.GetValue(),
-		TTL:     int(ttl),
-		ZoneID:  a.ZoneID,
+	dnsRecord, err := newDNSRecord(r, a.ZoneID, ttl)
+	if err != nil {
+		return err
 	}
 	this.metrics.AddZoneRequests(zone.Id().ID, provider.M_CREATERECORDS, 1)
 	this.rateLimiter.Accept()
-	_, err := this.CreateDNSRecord(a.ZoneID, dnsRecord)
+	_, err = this.Raw(http.MethodPost, "/zones/"+a.ZoneID+"/dns_records", dnsRecord)
 	return err
 }
 
@@ -90,16 +247,13 @@ func (this *access) UpdateRecord(r raw.Record, zone provider.DNSHostedZone) erro
 	a := r.(*Record)
 	ttl := r.GetTTL()
 	testTTL(&ttl)
-	dnsRecord := cloudflare.DNSRecord{
-		Type:    r.GetType(),
-		Name:    r.GetDNSName(),
-		Content: r.GetValue(),
-		TTL:     int(ttl),
-		ZoneID:  a.ZoneID,
+	dnsRecord, err := newDNSRecord(r, a.ZoneID, ttl)
+	if err != nil {
+		return err
 	}
 	this.metrics.AddZoneRequests(zone.Id().ID, provider.M_UPDATERECORDS, 1)
 	this.rateLimiter.Accept()
-	err := this.UpdateDNSRecord(a.ZoneID, r.GetId(), dnsRecord)
+	_, err = this.Raw(http.MethodPatch, "/zones/"+a.ZoneID+"/dns_records/"+r.GetId(), dnsRecord)
 	return err
 }
 
@@ -112,20 +266,19 @@ func (this *access) DeleteRecord(r raw.Record, zone provider.DNSHostedZone) erro
 }
 
 func (this *access) NewRecord(fqdn, rtype, value string, zone provider.DNSHostedZone, ttl int64) raw.Record {
-	return (*Record)(&cloudflare.DNSRecord{
+	return &Record{DNSRecord: cloudflare.DNSRecord{
 		Type:    rtype,
 		Name:    fqdn,
 		Content: value,
 		TTL:     int(ttl),
 		ZoneID:  zone.Id().ID,
-	})
+	}}
 }
 
 func (this *access) GetRecordSet(dnsName, rtype string, zone provider.DNSHostedZone) (raw.RecordSet, error) {
 	rs := raw.RecordSet{}
-	consume := func(record cloudflare.DNSRecord) (bool, error) {
-		a := (*Record)(&record)
-		rs = append(rs, a)
+	consume := func(record Record) (bool, error) {
+		rs = append(rs, &record)
 		return true, nil
 	}
 
@@ -136,8 +289,47 @@ func (this *access) GetRecordSet(dnsName, rtype string, zone provider.DNSHostedZ
 	return rs, nil
 }
 
+// newDNSRecord creates the cloudflare DNS record for creation or update.
+// SRV records must be provided as structured data instead of content.
+func newDNSRecord(r raw.Record, zoneID string, ttl int64) (Record, error) {
+	dnsRecord := Record{DNSRecord: cloudflare.DNSRecord{
+		Type:    r.GetType(),
+		Name:    r.GetDNSName(),
+		Content: r.GetValue(),
+		TTL:     int(ttl),
+		ZoneID:  zoneID,
+	}}
+	if a, ok := r.(*Record); ok {
+		if isProxiableType(a.Type) {
+			dnsRecord.Proxied = a.Proxied
+		}
+		dnsRecord.Comment = a.Comment
+	}
+	if r.GetType() == dns.RS_SRV {
+		srv, err := dns.ParseSRVRecord(r.GetValue())
+		if err != nil {
+			return dnsRecord, err
+		}
+		labels := strings.SplitN(r.GetDNSName(), ".", 3)
+		if len(labels) != 3 {
+			return dnsRecord, fmt.Errorf("invalid SRV record name %q: expected format '_service._proto.name'", r.GetDNSName())
+		}
+		dnsRecord.Content = ""
+		dnsRecord.Data = map[string]interface{}{
+			"service":  labels[0],
+			"proto":    labels[1],
+			"name":     labels[2],
+			"priority": srv.Priority,
+			"weight":   srv.Weight,
+			"port":     srv.Port,
+			"target":   srv.Target,
+		}
+	}
+	return dnsRecord, nil
+}
+
 func testTTL(ttl *int64) {
-	if *ttl < 120 {
+	if *ttl < minTTL {
 		*ttl = 1
 	}
 }
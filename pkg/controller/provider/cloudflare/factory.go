@@ -18,7 +18,11 @@ var rateLimiterDefaults = provider.RateLimiterOptions{
 }
 
 var Factory = provider.NewDNSHandlerFactory(TYPE_CODE, NewHandler).
-	SetGenericFactoryOptionDefaults(provider.GenericFactoryOptionDefaults.SetRateLimiterOptions(rateLimiterDefaults))
+	SetGenericFactoryOptionDefaults(provider.GenericFactoryOptionDefaults.SetRateLimiterOptions(rateLimiterDefaults)).
+	SetSecretKeys(
+		provider.RequiredSecretKey("CLOUDFLARE_API_TOKEN", "apiToken"),
+	).
+	SetProviderAttributes(attrProxied)
 
 func init() {
 	compound.MustRegister(Factory)
@@ -5,8 +5,13 @@
 package cloudflare
 
 import (
+	"context"
+
+	"strings"
+
 	"github.com/cloudflare/cloudflare-go"
 	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/external-dns-management/pkg/dns"
 	"github.com/gardener/external-dns-management/pkg/dns/provider"
 	"github.com/gardener/external-dns-management/pkg/dns/provider/raw"
 )
@@ -18,7 +23,11 @@ type Handler struct {
 	access Access
 }
 
-var _ provider.DNSHandler = &Handler{}
+var (
+	_ provider.DNSHandler         = &Handler{}
+	_ provider.TTLLimiter         = &Handler{}
+	_ provider.ApexAliasSupporter = &Handler{}
+)
 
 func NewHandler(c *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
 	var err error
@@ -57,11 +66,21 @@ func (h *Handler) Release() {
 	h.cache.Release()
 }
 
+// MinTTL returns the minimum TTL accepted by Cloudflare for non-proxied records.
+func (h *Handler) MinTTL() int64 {
+	return minTTL
+}
+
+// SupportsApexAlias returns true, as Cloudflare flattens CNAME records at the zone apex.
+func (h *Handler) SupportsApexAlias(_ provider.Target) bool {
+	return true
+}
+
 func (h *Handler) GetZones() (provider.DNSHostedZones, error) {
 	return h.cache.GetZones()
 }
 
-func (h *Handler) getZones(_ provider.ZoneCache) (provider.DNSHostedZones, error) {
+func (h *Handler) getZones(_ context.Context, _ provider.ZoneCache) (provider.DNSHostedZones, error) {
 	blockedZones := h.config.Options.AdvancedOptions.GetBlockedZones()
 	rawZones := []cloudflare.Zone{}
 	{
@@ -96,9 +115,8 @@ func (h *Handler) GetZoneState(zone provider.DNSHostedZone) (provider.DNSZoneSta
 func (h *Handler) getZoneState(zone provider.DNSHostedZone, _ provider.ZoneCache) (provider.DNSZoneState, error) {
 	state := raw.NewState()
 
-	f := func(r cloudflare.DNSRecord) (bool, error) {
-		a := (*Record)(&r)
-		state.AddRecord(a)
+	f := func(r Record) (bool, error) {
+		state.AddRecord(&r)
 		return true, nil
 	}
 	err := h.access.ListRecords(zone.Id().ID, f)
@@ -106,19 +124,70 @@ func (h *Handler) getZoneState(zone provider.DNSHostedZone, _ provider.ZoneCache
 		return nil, err
 	}
 	state.CalculateDNSSets()
+	for _, set := range state.GetDNSSets() {
+		for _, rs := range set.Sets {
+			if rs.ProviderAttributes[attrProxied] == "true" {
+				// Cloudflare manages the TTL of proxied records
+				rs.IgnoreTTL = true
+			}
+		}
+	}
+
+	weighted := func(lb cloudflare.LoadBalancer, pool cloudflare.LoadBalancerPool) (bool, error) {
+		addWeightedRecordSets(state.GetDNSSets(), lb, pool)
+		return true, nil
+	}
+	if err := h.access.ListWeightedLoadBalancers(zone.Id().ID, weighted); err != nil {
+		if !isLoadBalancingUnavailable(err) {
+			return nil, err
+		}
+		// accounts without the Load Balancing add-on or tokens without permission have no weighted record sets
+		logger.Debugf("load balancers of zone %s not available: %s", zone.Id().ID, err)
+	}
 	return state, nil
 }
 
+// isLoadBalancingUnavailable checks whether the error indicates that the load balancers cannot be used with the
+// credentials, as the permission is missing or the account is not entitled to Load Balancing.
+func isLoadBalancingUnavailable(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "http status 403") || strings.Contains(msg, "not entitled")
+}
+
 func (h *Handler) ReportZoneStateConflict(zone provider.DNSHostedZone, err error) bool {
 	return h.cache.ReportZoneStateConflict(zone, err)
 }
 
 func (h *Handler) ExecuteRequests(logger logger.LogContext, zone provider.DNSHostedZone, state provider.DNSZoneState, reqs []*provider.ChangeRequest) error {
-	err := raw.ExecuteRequests(logger, &h.config, h.access, zone, state, reqs)
+	err := h.executeRequests(logger, zone, state, reqs)
 	h.cache.ApplyRequests(logger, err, zone, reqs)
 	return err
 }
 
+func (h *Handler) executeRequests(logger logger.LogContext, zone provider.DNSHostedZone, state provider.DNSZoneState, reqs []*provider.ChangeRequest) error {
+	weighted := newWeightedExecution(logger, h.access, zone)
+	var plain []*provider.ChangeRequest
+	for _, r := range reqs {
+		if !checkProxied(logger, zone, r) {
+			continue
+		}
+		if !weighted.addChange(r) {
+			plain = append(plain, r)
+		}
+	}
+	var err error
+	if h.config.DryRun {
+		logger.Infof("no changes of weighted record sets in dryrun mode")
+	} else {
+		// weighted record sets are processed first, as a removed load balancer may be replaced by a plain record
+		err = weighted.submitChanges()
+	}
+	if perr := raw.ExecuteRequests(logger, &h.config, h.access, zone, state, plain); perr != nil {
+		return perr
+	}
+	return err
+}
+
 func (h *Handler) GetRecordSet(zone provider.DNSHostedZone, dnsName, recordType string) (provider.DedicatedRecordSet, error) {
 	rs, err := h.access.GetRecordSet(dnsName, recordType, zone)
 	if err != nil {
@@ -157,3 +226,23 @@ func (h *Handler) DeleteRecordSet(_ logger.LogContext, zone provider.DNSHostedZo
 	}
 	return nil
 }
+
+// checkProxied validates the proxied attribute of an added record set. Invalid requests are
+// marked as invalid and must not be executed.
+func checkProxied(logger logger.LogContext, zone provider.DNSHostedZone, req *provider.ChangeRequest) bool {
+	if req.Addition == nil {
+		return true
+	}
+	setName, rs := dns.MapToProvider(req.Type, req.Addition, zone.Domain())
+	if rs == nil {
+		return true
+	}
+	if err := validateProxied(rs); err != nil {
+		logger.Warnf("record set %s[%s]: %s", setName, zone.Id(), err)
+		if req.Done != nil {
+			req.Done.SetInvalid(err)
+		}
+		return false
+	}
+	return true
+}
@@ -0,0 +1,252 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflare
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+)
+
+const (
+	keyWeight = "weight"
+	// maxWeight is the maximum weight of a record set. Cloudflare origin weights are in the range [0, 1],
+	// the weight of a record set is mapped to an origin weight of `weight / maxWeight`.
+	maxWeight = 100
+
+	// managedDescriptionPrefix marks load balancers and pools managed by the dns-controller-manager
+	managedDescriptionPrefix = "managed by dns-controller-manager for "
+)
+
+// weightedTarget is the desired target of a weighted record set.
+type weightedTarget struct {
+	value  string
+	ttl    int64
+	weight int64
+}
+
+// weightedExecution collects the changes of weighted record sets which are realized
+// by a load balancer with a single pool per DNS name. Each set identifier is mapped to an origin of the pool.
+type weightedExecution struct {
+	logger.LogContext
+	access Access
+	zone   provider.DNSHostedZone
+
+	// changes contains the changed targets per DNS name and set identifier. A nil target marks a deletion.
+	changes map[string]map[string]*weightedTarget
+	done    map[string][]provider.DoneHandler
+}
+
+func newWeightedExecution(logger logger.LogContext, access Access, zone provider.DNSHostedZone) *weightedExecution {
+	return &weightedExecution{
+		LogContext: logger,
+		access:     access,
+		zone:       zone,
+		changes:    map[string]map[string]*weightedTarget{},
+		done:       map[string][]provider.DoneHandler{},
+	}
+}
+
+// addChange adds the change request if it is a change of a record set with routing policy.
+// It returns false if the request has to be executed as plain record change.
+func (this *weightedExecution) addChange(req *provider.ChangeRequest) bool {
+	set := req.Addition
+	if set == nil {
+		set = req.Deletion
+	}
+	if set == nil || (set.Name.SetIdentifier == "" && set.RoutingPolicy == nil) {
+		return false
+	}
+
+	setName, rs := dns.MapToProvider(req.Type, set, this.zone.Domain())
+	target, err := extractWeightedTarget(set, rs)
+	if err != nil {
+		this.Warnf("record set %s[%s]: %s", setName, this.zone.Id(), err)
+		if req.Done != nil {
+			req.Done.SetInvalid(err)
+		}
+		return true
+	}
+
+	this.Infof("%s %s record set %s[%s] (weighted routing policy)", req.Action, req.Type, setName, this.zone.Id())
+	if req.Action == provider.R_DELETE {
+		target = nil
+	}
+	perName := this.changes[setName.DNSName]
+	if perName == nil {
+		perName = map[string]*weightedTarget{}
+		this.changes[setName.DNSName] = perName
+	}
+	perName[setName.SetIdentifier] = target
+	if req.Done != nil {
+		this.done[setName.DNSName] = append(this.done[setName.DNSName], req.Done)
+	}
+	return true
+}
+
+func extractWeightedTarget(set *dns.DNSSet, rs *dns.RecordSet) (*weightedTarget, error) {
+	if set.Name.SetIdentifier == "" {
+		return nil, fmt.Errorf("missing set identifier")
+	}
+	if set.RoutingPolicy == nil {
+		return nil, fmt.Errorf("missing routing policy")
+	}
+	if set.RoutingPolicy.Type != dns.RoutingPolicyWeighted {
+		return nil, fmt.Errorf("unsupported routing policy for %s: %s", TYPE_CODE, set.RoutingPolicy.Type)
+	}
+	if err := set.RoutingPolicy.CheckParameterKeys([]string{keyWeight}, nil); err != nil {
+		return nil, err
+	}
+	value := set.RoutingPolicy.Parameters[keyWeight]
+	weight, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || weight < 0 || weight > maxWeight {
+		return nil, fmt.Errorf("invalid value for spec.routingPolicy.parameters.weight: %s (only integers between 0 and %d are allowed for %s)", value, maxWeight, TYPE_CODE)
+	}
+	switch rs.Type {
+	case dns.RS_A, dns.RS_AAAA, dns.RS_CNAME:
+	default:
+		return nil, fmt.Errorf("weighted routing policy for %s is only supported for record types A, AAAA, and CNAME, but got %s", TYPE_CODE, rs.Type)
+	}
+	if len(rs.Records) != 1 {
+		return nil, fmt.Errorf("weighted routing policy for %s requires exactly one target per set identifier, but got %d", TYPE_CODE, len(rs.Records))
+	}
+	return &weightedTarget{
+		value:  strings.TrimSuffix(rs.Records[0].Value, "."),
+		ttl:    rs.TTL,
+		weight: weight,
+	}, nil
+}
+
+// submitChanges merges the changes with the existing load balancers and pools and applies them.
+// If the last set identifier of a DNS name is removed, the load balancer and its pool are deleted,
+// so that a plain record can be used for the DNS name again.
+func (this *weightedExecution) submitChanges() error {
+	var names []string
+	for name := range this.changes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var lastErr error
+	for _, name := range names {
+		err := this.submitChangesForName(name, this.changes[name])
+		for _, d := range this.done[name] {
+			if err != nil {
+				d.Failed(err)
+			} else {
+				d.Succeeded()
+			}
+		}
+		if err != nil {
+			this.Infof("weighted record sets for %s in zone %s failed: %s", name, this.zone.Id(), err)
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+func (this *weightedExecution) submitChangesForName(name string, changes map[string]*weightedTarget) error {
+	zoneID := this.zone.Id().ID
+	lb, pool, err := this.access.GetWeightedLoadBalancer(zoneID, name)
+	if err != nil {
+		return err
+	}
+
+	origins := map[string]cloudflare.LoadBalancerOrigin{}
+	var ttl int64
+	if pool != nil {
+		for _, o := range pool.Origins {
+			origins[o.Name] = o
+		}
+	}
+	if lb != nil {
+		ttl = int64(lb.TTL)
+	}
+	for setIdentifier, target := range changes {
+		if target == nil {
+			delete(origins, setIdentifier)
+			continue
+		}
+		origins[setIdentifier] = cloudflare.LoadBalancerOrigin{
+			Name:    setIdentifier,
+			Address: target.value,
+			Enabled: true,
+			Weight:  float64(target.weight) / maxWeight,
+		}
+		ttl = target.ttl
+	}
+
+	if len(origins) == 0 {
+		if lb == nil && pool == nil {
+			return nil
+		}
+		this.Infof("desired change: Deletion of load balancer %s", name)
+		return this.access.DeleteWeightedLoadBalancer(zoneID, lb, pool)
+	}
+
+	list := make([]cloudflare.LoadBalancerOrigin, 0, len(origins))
+	for _, o := range origins {
+		list = append(list, o)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	this.Infof("desired change: Update of load balancer %s (%s)", name, describeOrigins(list))
+	return this.access.ApplyWeightedLoadBalancer(zoneID, name, ttl, list, lb, pool)
+}
+
+func describeOrigins(origins []cloudflare.LoadBalancerOrigin) string {
+	parts := make([]string, len(origins))
+	for i, o := range origins {
+		parts[i] = fmt.Sprintf("%s: %s weight=%d", o.Name, o.Address, weightFromOrigin(o))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// addWeightedRecordSets adds a record set with weighted routing policy for each origin of the pool.
+func addWeightedRecordSets(dnssets dns.DNSSets, lb cloudflare.LoadBalancer, pool cloudflare.LoadBalancerPool) {
+	for _, o := range pool.Origins {
+		rs := dns.NewRecordSet(addressRecordType(o.Address), int64(lb.TTL), []*dns.Record{{Value: o.Address}})
+		policy := dns.NewRoutingPolicy(dns.RoutingPolicyWeighted, keyWeight, strconv.FormatInt(weightFromOrigin(o), 10))
+		dnssets.AddRecordSetFromProviderEx(dns.DNSSetName{DNSName: lb.Name, SetIdentifier: o.Name}, policy, rs)
+	}
+}
+
+func weightFromOrigin(o cloudflare.LoadBalancerOrigin) int64 {
+	return int64(math.Round(o.Weight * maxWeight))
+}
+
+func addressRecordType(address string) string {
+	ip := net.ParseIP(address)
+	switch {
+	case ip == nil:
+		return dns.RS_CNAME
+	case ip.To4() != nil:
+		return dns.RS_A
+	default:
+		return dns.RS_AAAA
+	}
+}
+
+func isManagedLoadBalancer(description string) bool {
+	return strings.HasPrefix(description, managedDescriptionPrefix)
+}
+
+func managedDescription(dnsName string) string {
+	return managedDescriptionPrefix + dnsName
+}
+
+// poolName returns the name of the pool for the DNS name. Pool names may only contain
+// alphanumeric characters, hyphens, and underscores.
+func poolName(dnsName string) string {
+	return strings.NewReplacer(".", "-", "*", "_").Replace(dnsName)
+}
@@ -0,0 +1,219 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflare
+
+import (
+	"fmt"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+)
+
+type testLoadBalancer struct {
+	lb   cloudflare.LoadBalancer
+	pool cloudflare.LoadBalancerPool
+}
+
+type testAccess struct {
+	Access
+	loadBalancers map[string]*testLoadBalancer
+	records       []Record
+	listLBErr     error
+}
+
+func (a *testAccess) ListRecords(_ string, consume func(record Record) (bool, error)) error {
+	for _, r := range a.records {
+		if cont, err := consume(r); !cont || err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *testAccess) ListWeightedLoadBalancers(_ string, consume func(lb cloudflare.LoadBalancer, pool cloudflare.LoadBalancerPool) (bool, error)) error {
+	if a.listLBErr != nil {
+		return a.listLBErr
+	}
+	for _, tlb := range a.loadBalancers {
+		if cont, err := consume(tlb.lb, tlb.pool); !cont || err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *testAccess) GetWeightedLoadBalancer(_, dnsName string) (*cloudflare.LoadBalancer, *cloudflare.LoadBalancerPool, error) {
+	tlb := a.loadBalancers[dnsName]
+	if tlb == nil {
+		return nil, nil, nil
+	}
+	lb, pool := tlb.lb, tlb.pool
+	return &lb, &pool, nil
+}
+
+func (a *testAccess) ApplyWeightedLoadBalancer(_, dnsName string, ttl int64, origins []cloudflare.LoadBalancerOrigin,
+	_ *cloudflare.LoadBalancer, _ *cloudflare.LoadBalancerPool,
+) error {
+	a.loadBalancers[dnsName] = &testLoadBalancer{
+		lb:   cloudflare.LoadBalancer{Name: dnsName, Description: managedDescription(dnsName), TTL: int(ttl)},
+		pool: cloudflare.LoadBalancerPool{Name: poolName(dnsName), Origins: origins},
+	}
+	return nil
+}
+
+func (a *testAccess) DeleteWeightedLoadBalancer(_ string, lb *cloudflare.LoadBalancer, _ *cloudflare.LoadBalancerPool) error {
+	delete(a.loadBalancers, lb.Name)
+	return nil
+}
+
+type testDoneHandler struct {
+	invalid   error
+	failed    error
+	succeeded bool
+}
+
+func (h *testDoneHandler) SetInvalid(err error) { h.invalid = err }
+func (h *testDoneHandler) Failed(err error)     { h.failed = err }
+func (h *testDoneHandler) Throttled()           {}
+func (h *testDoneHandler) Succeeded()           { h.succeeded = true }
+
+func newWeightedSet(setIdentifier, rtype, value, weight string) *dns.DNSSet {
+	set := dns.NewDNSSet(dns.DNSSetName{DNSName: "www.example.com", SetIdentifier: setIdentifier},
+		dns.NewRoutingPolicy(dns.RoutingPolicyWeighted, keyWeight, weight))
+	set.SetRecordSet(rtype, 300, value)
+	return set
+}
+
+var _ = Describe("Weighted routing policy", func() {
+	var (
+		access *testAccess
+		zone   provider.DNSHostedZone
+	)
+
+	BeforeEach(func() {
+		access = &testAccess{loadBalancers: map[string]*testLoadBalancer{}}
+		zone = provider.NewDNSHostedZone(TYPE_CODE, "z1", "example.com", "", false)
+	})
+
+	execute := func(reqs ...*provider.ChangeRequest) []*provider.ChangeRequest {
+		exec := newWeightedExecution(logger.New(), access, zone)
+		var plain []*provider.ChangeRequest
+		for _, r := range reqs {
+			if !exec.addChange(r) {
+				plain = append(plain, r)
+			}
+		}
+		Expect(exec.submitChanges()).To(Succeed())
+		return plain
+	}
+
+	It("creates a load balancer with weighted origins", func() {
+		done := &testDoneHandler{}
+		plain := execute(
+			provider.NewChangeRequest(provider.R_CREATE, dns.RS_A, nil, newWeightedSet("a", dns.RS_A, "1.1.1.1", "10"), done),
+			provider.NewChangeRequest(provider.R_CREATE, dns.RS_CNAME, nil, newWeightedSet("b", dns.RS_CNAME, "other.example.org", "90"), done),
+		)
+		Expect(plain).To(BeEmpty())
+		Expect(done.succeeded).To(BeTrue())
+		Expect(access.loadBalancers).To(HaveKey("www.example.com"))
+		Expect(access.loadBalancers["www.example.com"].pool.Origins).To(Equal([]cloudflare.LoadBalancerOrigin{
+			{Name: "a", Address: "1.1.1.1", Enabled: true, Weight: 0.1},
+			{Name: "b", Address: "other.example.org", Enabled: true, Weight: 0.9},
+		}))
+	})
+
+	It("merges changes with existing origins and reads back the weights", func() {
+		execute(
+			provider.NewChangeRequest(provider.R_CREATE, dns.RS_A, nil, newWeightedSet("a", dns.RS_A, "1.1.1.1", "10"), nil),
+			provider.NewChangeRequest(provider.R_CREATE, dns.RS_A, nil, newWeightedSet("b", dns.RS_A, "1.1.1.2", "20"), nil),
+		)
+		execute(provider.NewChangeRequest(provider.R_UPDATE, dns.RS_A,
+			newWeightedSet("b", dns.RS_A, "1.1.1.2", "20"), newWeightedSet("b", dns.RS_A, "1.1.1.2", "30"), nil))
+
+		dnssets := dns.DNSSets{}
+		Expect(access.ListWeightedLoadBalancers("z1", func(lb cloudflare.LoadBalancer, pool cloudflare.LoadBalancerPool) (bool, error) {
+			addWeightedRecordSets(dnssets, lb, pool)
+			return true, nil
+		})).To(Succeed())
+		Expect(dnssets).To(HaveLen(2))
+		a := dnssets[dns.DNSSetName{DNSName: "www.example.com", SetIdentifier: "a"}]
+		Expect(a.RoutingPolicy).To(Equal(dns.NewRoutingPolicy(dns.RoutingPolicyWeighted, keyWeight, "10")))
+		Expect(a.Sets[dns.RS_A].Records[0].Value).To(Equal("1.1.1.1"))
+		b := dnssets[dns.DNSSetName{DNSName: "www.example.com", SetIdentifier: "b"}]
+		Expect(b.RoutingPolicy).To(Equal(dns.NewRoutingPolicy(dns.RoutingPolicyWeighted, keyWeight, "30")))
+	})
+
+	It("deletes the load balancer if the last set identifier is removed", func() {
+		set := newWeightedSet("a", dns.RS_A, "1.1.1.1", "10")
+		execute(provider.NewChangeRequest(provider.R_CREATE, dns.RS_A, nil, set, nil))
+		Expect(access.loadBalancers).To(HaveLen(1))
+
+		plainSet := dns.NewDNSSet(dns.DNSSetName{DNSName: "www.example.com"}, nil)
+		plainSet.SetRecordSet(dns.RS_A, 300, "1.1.1.1")
+		plain := execute(
+			provider.NewChangeRequest(provider.R_DELETE, dns.RS_A, set, nil, nil),
+			provider.NewChangeRequest(provider.R_CREATE, dns.RS_A, nil, plainSet, nil),
+		)
+		Expect(access.loadBalancers).To(BeEmpty())
+		Expect(plain).To(HaveLen(1))
+		Expect(plain[0].Addition).To(Equal(plainSet))
+	})
+
+	It("rejects invalid weighted record sets", func() {
+		for _, set := range []*dns.DNSSet{
+			newWeightedSet("a", dns.RS_A, "1.1.1.1", "101"),
+			newWeightedSet("a", dns.RS_TXT, "\"foo\"", "10"),
+			dns.NewDNSSet(dns.DNSSetName{DNSName: "www.example.com", SetIdentifier: "a"}, dns.NewRoutingPolicy(dns.RoutingPolicyGeoLocation, "location", "a")),
+		} {
+			done := &testDoneHandler{}
+			rtype := dns.RS_A
+			for t := range set.Sets {
+				rtype = t
+			}
+			execute(provider.NewChangeRequest(provider.R_CREATE, rtype, nil, set, done))
+			Expect(done.invalid).To(HaveOccurred())
+		}
+		Expect(access.loadBalancers).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Zone state without load balancing", func() {
+	var (
+		access *testAccess
+		zone   provider.DNSHostedZone
+	)
+
+	BeforeEach(func() {
+		access = &testAccess{
+			loadBalancers: map[string]*testLoadBalancer{},
+			records:       []Record{{DNSRecord: cloudflare.DNSRecord{Type: "A", Name: "www.example.com", Content: "1.2.3.4", TTL: 300}}},
+		}
+		zone = provider.NewDNSHostedZone(TYPE_CODE, "z1", "example.com", "", false)
+	})
+
+	DescribeTable("ignores load balancers not available for the account",
+		func(err error) {
+			access.listLBErr = err
+			h := &Handler{access: access}
+			state, err := h.getZoneState(zone, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(state.GetDNSSets()).To(HaveKey(dns.DNSSetName{DNSName: "www.example.com"}))
+		},
+		Entry("missing permission", fmt.Errorf("HTTP status 403: insufficient permissions")),
+		Entry("not entitled", fmt.Errorf(`HTTP status 400: content "zone is not entitled to use load balancing"`)),
+	)
+
+	It("fails on other errors", func() {
+		access.listLBErr = fmt.Errorf("HTTP status 503: service failure")
+		h := &Handler{access: access}
+		_, err := h.getZoneState(zone, nil)
+		Expect(err).To(MatchError("HTTP status 503: service failure"))
+	})
+})
@@ -5,26 +5,94 @@
 package cloudflare
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+
 	"github.com/cloudflare/cloudflare-go"
 
 	"github.com/gardener/external-dns-management/pkg/dns"
 	"github.com/gardener/external-dns-management/pkg/dns/provider/raw"
 )
 
-type Record cloudflare.DNSRecord
+// attrProxied is the provider attribute to enable the Cloudflare proxy for a record.
+const attrProxied = "proxied"
+
+// minTTL is the minimum TTL of non-enterprise Cloudflare zones, lower TTLs are raised to this value.
+const minTTL = 120
 
-var _ raw.Record = &Record{}
+// Record is a Cloudflare DNS record including its comment, which is not supported by the DNS record
+// of the Cloudflare client.
+type Record struct {
+	cloudflare.DNSRecord
+	Comment string `json:"comment"`
+}
+
+var (
+	_ raw.Record           = &Record{}
+	_ raw.AttributesRecord = &Record{}
+	_ raw.CommentRecord    = &Record{}
+)
 
 func (r *Record) GetType() string          { return r.Type }
 func (r *Record) GetId() string            { return r.ID }
 func (r *Record) GetDNSName() string       { return r.Name }
 func (r *Record) GetSetIdentifier() string { return "" }
 func (r *Record) GetValue() string {
-	if r.Type == dns.RS_TXT {
+	switch r.Type {
+	case dns.RS_TXT:
 		return raw.EnsureQuotedText(r.Content)
+	case dns.RS_SRV:
+		// content of SRV records is returned as `weight port target`, the priority is provided separately
+		if len(strings.Fields(r.Content)) == 3 {
+			return dns.NormalizeSRVValue(fmt.Sprintf("%d %s", r.Priority, r.Content))
+		}
+		return dns.NormalizeSRVValue(r.Content)
 	}
 	return r.Content
 }
-func (r *Record) GetTTL() int64    { return int64(r.TTL) }
-func (r *Record) SetTTL(ttl int64) { r.TTL = int(ttl) }
-func (r *Record) Copy() raw.Record { n := *r; return &n }
+func (r *Record) GetTTL() int64             { return int64(r.TTL) }
+func (r *Record) SetTTL(ttl int64)          { r.TTL = int(ttl) }
+func (r *Record) Copy() raw.Record          { n := *r; return &n }
+func (r *Record) GetComment() string        { return r.Comment }
+func (r *Record) SetComment(comment string) { r.Comment = comment }
+
+// GetProviderAttributes returns the proxied state of records with a record type supporting the Cloudflare proxy.
+func (r *Record) GetProviderAttributes() map[string]string {
+	if !isProxiableType(r.Type) {
+		return nil
+	}
+	return map[string]string{attrProxied: strconv.FormatBool(r.Proxied)}
+}
+
+// SetProviderAttributes sets the proxied state of the record. Invalid values are rejected before by validateProxied.
+func (r *Record) SetProviderAttributes(attrs map[string]string) {
+	if v, ok := attrs[attrProxied]; ok && isProxiableType(r.Type) {
+		r.Proxied, _ = strconv.ParseBool(v)
+	}
+}
+
+// isProxiableType returns true if records of the record type can be proxied by Cloudflare.
+func isProxiableType(rtype string) bool {
+	switch rtype {
+	case dns.RS_A, dns.RS_AAAA, dns.RS_CNAME:
+		return true
+	}
+	return false
+}
+
+// validateProxied checks the value of the proxied attribute of the record set.
+func validateProxied(rs *dns.RecordSet) error {
+	v, ok := rs.ProviderAttributes[attrProxied]
+	if !ok {
+		return nil
+	}
+	proxied, err := strconv.ParseBool(v)
+	if err != nil {
+		return fmt.Errorf("invalid value %q for provider attribute %s: must be 'true' or 'false'", v, attrProxied)
+	}
+	if proxied && !isProxiableType(rs.Type) {
+		return fmt.Errorf("provider attribute %s is only supported for record types A, AAAA, and CNAME, but got %s", attrProxied, rs.Type)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudflare
+
+import (
+	"encoding/json"
+
+	"github.com/cloudflare/cloudflare-go"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+var _ = Describe("Proxied records", func() {
+	It("reads and sets the proxied state of proxiable records", func() {
+		r := &Record{DNSRecord: cloudflare.DNSRecord{Type: dns.RS_A, Name: "www.example.com", Content: "1.2.3.4"}}
+		Expect(r.GetProviderAttributes()).To(Equal(map[string]string{attrProxied: "false"}))
+		r.SetProviderAttributes(map[string]string{attrProxied: "true"})
+		Expect(r.Proxied).To(BeTrue())
+		Expect(r.GetProviderAttributes()).To(Equal(map[string]string{attrProxied: "true"}))
+
+		dnsRecord, err := newDNSRecord(r, "zone", 1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dnsRecord.Proxied).To(BeTrue())
+	})
+
+	It("ignores the proxied state for other record types", func() {
+		r := &Record{DNSRecord: cloudflare.DNSRecord{Type: dns.RS_TXT, Name: "www.example.com", Content: "\"foo\""}}
+		r.SetProviderAttributes(map[string]string{attrProxied: "true"})
+		Expect(r.Proxied).To(BeFalse())
+		Expect(r.GetProviderAttributes()).To(BeNil())
+	})
+
+	DescribeTable("validates the proxied attribute",
+		func(rtype, value string, valid bool) {
+			rs := dns.NewRecordSet(rtype, 300, nil)
+			rs.ProviderAttributes = map[string]string{attrProxied: value}
+			if valid {
+				Expect(validateProxied(rs)).To(Succeed())
+			} else {
+				Expect(validateProxied(rs)).NotTo(Succeed())
+			}
+		},
+		Entry("A proxied", dns.RS_A, "true", true),
+		Entry("AAAA proxied", dns.RS_AAAA, "true", true),
+		Entry("CNAME proxied", dns.RS_CNAME, "true", true),
+		Entry("TXT proxied", dns.RS_TXT, "true", false),
+		Entry("TXT not proxied", dns.RS_TXT, "false", true),
+		Entry("invalid value", dns.RS_A, "yes please", false),
+	)
+})
+
+var _ = Describe("Record comments", func() {
+	It("reads the comment of a listed record", func() {
+		var records []Record
+		Expect(json.Unmarshal([]byte(`[{"id":"1","type":"A","name":"www.example.com","content":"1.2.3.4","ttl":300,"comment":"managed"}]`), &records)).To(Succeed())
+		Expect(records).To(HaveLen(1))
+		Expect(records[0].GetComment()).To(Equal("managed"))
+		Expect(records[0].GetValue()).To(Equal("1.2.3.4"))
+	})
+
+	It("sends the comment on creation and update", func() {
+		r := &Record{DNSRecord: cloudflare.DNSRecord{Type: dns.RS_A, Name: "www.example.com", Content: "1.2.3.4"}}
+		r.SetComment("managed")
+		dnsRecord, err := newDNSRecord(r, "zone", 300)
+		Expect(err).NotTo(HaveOccurred())
+		data, err := json.Marshal(dnsRecord)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring(`"comment":"managed"`))
+
+		// an empty comment is sent to remove an existing comment
+		r.SetComment("")
+		dnsRecord, err = newDNSRecord(r, "zone", 300)
+		Expect(err).NotTo(HaveOccurred())
+		data, err = json.Marshal(dnsRecord)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring(`"comment":""`))
+	})
+})
@@ -0,0 +1,179 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package desec
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"k8s.io/client-go/util/flowcontrol"
+
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+)
+
+const defaultAPIURL = "https://desec.io/api/v1"
+
+// Domain is a domain of a deSEC account.
+type Domain struct {
+	Name       string `json:"name"`
+	MinimumTTL int64  `json:"minimum_ttl"`
+}
+
+// RRSet is a resource record set of a deSEC domain.
+// An empty record list deletes the record set.
+type RRSet struct {
+	Subname string   `json:"subname"`
+	Name    string   `json:"name,omitempty"`
+	Type    string   `json:"type"`
+	TTL     int64    `json:"ttl,omitempty"`
+	Records []string `json:"records"`
+}
+
+// APIError is an error response of the deSEC API.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("deSEC API request failed with status %d: %s", e.StatusCode, e.Message)
+}
+
+type client struct {
+	ctx         context.Context
+	baseURL     string
+	token       string
+	httpClient  *http.Client
+	metrics     provider.Metrics
+	rateLimiter flowcontrol.RateLimiter
+}
+
+func newClient(ctx context.Context, httpClient *http.Client, baseURL, token string, metrics provider.Metrics, rateLimiter flowcontrol.RateLimiter) *client {
+	return &client{
+		ctx:         ctx,
+		baseURL:     strings.TrimSuffix(baseURL, "/"),
+		token:       token,
+		httpClient:  httpClient,
+		metrics:     metrics,
+		rateLimiter: rateLimiter,
+	}
+}
+
+// ListDomains returns all domains of the account.
+func (c *client) ListDomains() ([]Domain, error) {
+	var result []Domain
+	err := c.list(c.baseURL+"/domains/", func() { c.metrics.AddGenericRequests(provider.M_LISTZONES, 1) }, func(data []byte) error {
+		var page []Domain
+		if err := json.Unmarshal(data, &page); err != nil {
+			return err
+		}
+		result = append(result, page...)
+		return nil
+	})
+	return result, err
+}
+
+// ListRRSets returns all record sets of a domain.
+func (c *client) ListRRSets(zoneID, domain string) ([]RRSet, error) {
+	var result []RRSet
+	// an empty cursor enables pagination for domains with many record sets
+	err := c.list(c.domainURL(domain)+"?cursor=", func() { c.metrics.AddZoneRequests(zoneID, provider.M_LISTRECORDS, 1) }, func(data []byte) error {
+		var page []RRSet
+		if err := json.Unmarshal(data, &page); err != nil {
+			return err
+		}
+		result = append(result, page...)
+		return nil
+	})
+	return result, err
+}
+
+// UpdateRRSets creates, updates, or deletes the given record sets of a domain in a single atomic request.
+func (c *client) UpdateRRSets(zoneID, domain string, rrsets []RRSet) error {
+	body, err := json.Marshal(rrsets)
+	if err != nil {
+		return err
+	}
+	c.metrics.AddZoneRequests(zoneID, provider.M_UPDATERECORDS, 1)
+	_, _, err = c.do(http.MethodPatch, c.domainURL(domain), body)
+	return err
+}
+
+func (c *client) domainURL(domain string) string {
+	return fmt.Sprintf("%s/domains/%s/rrsets/", c.baseURL, url.PathEscape(domain))
+}
+
+// list reads all pages of a list request following the links to the next pages.
+func (c *client) list(u string, count func(), consume func(data []byte) error) error {
+	for u != "" {
+		count()
+		data, header, err := c.do(http.MethodGet, u, nil)
+		if err != nil {
+			return err
+		}
+		if err := consume(data); err != nil {
+			return err
+		}
+		u = nextLink(header.Get("Link"))
+	}
+	return nil
+}
+
+func (c *client) do(method, u string, body []byte) ([]byte, http.Header, error) {
+	c.rateLimiter.Accept()
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(c.ctx, method, u, reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Authorization", "Token "+c.token)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.StatusCode >= 300 {
+		apiErr := &APIError{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(data))}
+		if resp.StatusCode == http.StatusTooManyRequests {
+			if retry := resp.Header.Get("Retry-After"); retry != "" {
+				apiErr.Message = fmt.Sprintf("%s (retry after %s seconds)", apiErr.Message, retry)
+			}
+			return nil, nil, perrs.NewThrottlingError(apiErr)
+		}
+		return nil, nil, apiErr
+	}
+	return data, resp.Header, nil
+}
+
+var linkNextPattern = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+// nextLink returns the URL of the next page from the Link header or an empty string.
+func nextLink(header string) string {
+	for _, link := range strings.Split(header, ",") {
+		if m := linkNextPattern.FindStringSubmatch(strings.TrimSpace(link)); m != nil {
+			return m[1]
+		}
+	}
+	return ""
+}
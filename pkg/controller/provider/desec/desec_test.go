@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package desec
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+
+	"github.com/gardener/external-dns-management/pkg/controller/provider/testutils"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+)
+
+func newTestClient(url string) *client {
+	return newClient(testutils.ClientArgs(url))
+}
+
+func TestListRRSetsPaginated(t *testing.T) {
+	var serverURL string
+	serverURL = testutils.NewServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Token "+testutils.Token {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			w.Header().Set("Link", fmt.Sprintf(`<%s/domains/example.com/rrsets/?cursor=>; rel="first", <%s/domains/example.com/rrsets/?cursor=abc>; rel="next"`, serverURL, serverURL))
+			_, _ = io.WriteString(w, `[{"subname":"a","name":"a.example.com.","type":"A","ttl":3600,"records":["1.1.1.1"]}]`)
+		case "abc":
+			_, _ = io.WriteString(w, `[{"subname":"","name":"example.com.","type":"TXT","ttl":3600,"records":["\"foo\""]}]`)
+		}
+	})
+
+	rrsets, err := newTestClient(serverURL).ListRRSets("example.com", "example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(rrsets) != 2 || rrsets[0].Type != dns.RS_A || rrsets[1].Type != dns.RS_TXT {
+		t.Errorf("unexpected record sets: %v", rrsets)
+	}
+	if name := rrsetDNSName(RRSet{Subname: "a"}, "example.com"); name != "a.example.com" {
+		t.Errorf("unexpected DNS name %s", name)
+	}
+}
+
+func TestUpdateRRSetsThrottled(t *testing.T) {
+	serverURL := testutils.NewServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Retry-After", "5")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	err := newTestClient(serverURL).UpdateRRSets("example.com", "example.com", []RRSet{{Subname: "a", Type: dns.RS_A, Records: []string{}}})
+	if !perrs.IsThrottlingError(err) {
+		t.Errorf("expected throttling error, but got %v", err)
+	}
+}
+
+func TestUpdateRRSetsBody(t *testing.T) {
+	var body []RRSet
+	serverURL := testutils.NewServer(t, func(_ http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/domains/example.com/rrsets/" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+	})
+
+	rrsets := []RRSet{{Subname: "a", Type: dns.RS_A, TTL: 3600, Records: []string{"1.1.1.1"}}, {Subname: "b", Type: dns.RS_A, Records: []string{}}}
+	if err := newTestClient(serverURL).UpdateRRSets("example.com", "example.com", rrsets); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(body, rrsets) {
+		t.Errorf("unexpected body %v", body)
+	}
+}
+
+func TestBuildRRSet(t *testing.T) {
+	zone := provider.NewDNSHostedZone(TYPE_CODE, "example.com", "example.com", "example.com", false)
+	exec := newExecution(logger.New(), zone, 3600)
+
+	table := []struct {
+		req      *provider.ChangeRequest
+		expected *RRSet
+	}{
+		{
+			provider.NewChangeRequest(provider.R_CREATE, dns.RS_A, nil, testutils.NewDNSSet("a.example.com", dns.RS_A, 300, "1.1.1.1"), nil),
+			&RRSet{Subname: "a", Type: dns.RS_A, TTL: 3600, Records: []string{"1.1.1.1"}},
+		},
+		{
+			provider.NewChangeRequest(provider.R_UPDATE, dns.RS_CNAME, nil, testutils.NewDNSSet("*.b.example.com", dns.RS_CNAME, 100000, "target.example.org"), nil),
+			&RRSet{Subname: "*.b", Type: dns.RS_CNAME, TTL: maximumTTL, Records: []string{"target.example.org."}},
+		},
+		{
+			provider.NewChangeRequest(provider.R_UPDATE, dns.RS_TXT, nil, testutils.NewDNSSet("example.com", dns.RS_TXT, 7200, `"foo"`), nil),
+			&RRSet{Subname: "", Type: dns.RS_TXT, TTL: 7200, Records: []string{`"foo"`}},
+		},
+		{
+			provider.NewChangeRequest(provider.R_DELETE, dns.RS_A, testutils.NewDNSSet("a.example.com", dns.RS_A, 3600, "1.1.1.1"), nil, nil),
+			&RRSet{Subname: "a", Type: dns.RS_A, Records: []string{}},
+		},
+	}
+	for _, entry := range table {
+		rrset, err := exec.buildRRSet(entry.req)
+		if err != nil {
+			t.Errorf("%s %s: unexpected error: %s", entry.req.Action, entry.req.Type, err)
+			continue
+		}
+		if !reflect.DeepEqual(rrset, entry.expected) {
+			t.Errorf("%s %s: expected %v, but got %v", entry.req.Action, entry.req.Type, entry.expected, rrset)
+		}
+	}
+
+	_, err := exec.buildRRSet(provider.NewChangeRequest(provider.R_CREATE, dns.RS_A, nil, testutils.NewDNSSet("a.example.org", dns.RS_A, 300, "1.1.1.1"), nil))
+	if err == nil {
+		t.Errorf("expected error for foreign domain")
+	}
+}
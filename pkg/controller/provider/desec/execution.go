@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package desec
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+)
+
+const (
+	// defaultMinimumTTL is the minimum TTL of deSEC domains if not known from the domain list.
+	defaultMinimumTTL = 3600
+	// maximumTTL is the maximum TTL accepted by deSEC.
+	maximumTTL = 86400
+)
+
+type execution struct {
+	logger.LogContext
+	zone       provider.DNSHostedZone
+	minimumTTL int64
+}
+
+func newExecution(logger logger.LogContext, zone provider.DNSHostedZone, minimumTTL int64) *execution {
+	return &execution{LogContext: logger, zone: zone, minimumTTL: minimumTTL}
+}
+
+// buildRRSet maps a change request to the deSEC record set to patch.
+func (exec *execution) buildRRSet(req *provider.ChangeRequest) (*RRSet, error) {
+	var dnsset *dns.DNSSet
+	switch req.Action {
+	case provider.R_CREATE, provider.R_UPDATE:
+		dnsset = req.Addition
+	case provider.R_DELETE:
+		dnsset = req.Deletion
+	}
+	if dnsset == nil {
+		return nil, nil
+	}
+
+	name, rset := dns.MapToProvider(req.Type, dnsset, exec.zone.Domain())
+	if name.SetIdentifier != "" || dnsset.RoutingPolicy != nil {
+		return nil, fmt.Errorf("routing policies not supported for %s", TYPE_CODE)
+	}
+	if name.DNSName == "" || rset == nil || len(rset.Records) == 0 {
+		return nil, nil
+	}
+
+	subname, err := exec.subname(name.DNSName)
+	if err != nil {
+		return nil, err
+	}
+
+	exec.Infof("Desired %s: %s record set %s[%s] with TTL %d: %s", req.Action, rset.Type, name.DNSName, exec.zone.Id(), rset.TTL, rset.RecordString())
+
+	rrset := &RRSet{Subname: subname, Type: rset.Type, Records: []string{}}
+	if req.Action != provider.R_DELETE {
+		rrset.TTL = exec.clampTTL(rset.TTL)
+		for _, r := range rset.Records {
+			rrset.Records = append(rrset.Records, recordValue(rset.Type, r.Value))
+		}
+	}
+	return rrset, nil
+}
+
+// subname returns the name of the record set relative to the domain of the zone.
+func (exec *execution) subname(dnsName string) (string, error) {
+	domain := exec.zone.Domain()
+	if dnsName == domain {
+		return "", nil
+	}
+	if !strings.HasSuffix(dnsName, "."+domain) {
+		return "", fmt.Errorf("%s is not part of domain %s", dnsName, domain)
+	}
+	return strings.TrimSuffix(dnsName, "."+domain), nil
+}
+
+// clampTTL adjusts the TTL to the range accepted for the domain.
+func (exec *execution) clampTTL(ttl int64) int64 {
+	if ttl < exec.minimumTTL {
+		return exec.minimumTTL
+	}
+	if ttl > maximumTTL {
+		return maximumTTL
+	}
+	return ttl
+}
+
+// recordValue returns the record value in the presentation format expected by deSEC.
+func recordValue(rtype, value string) string {
+	switch rtype {
+	case dns.RS_CNAME, dns.RS_NS:
+		return dns.AlignHostname(value)
+	case dns.RS_SRV:
+		return dns.AlignSRVValue(value)
+	}
+	return value
+}
+
+// rrsetDNSName returns the DNS name of a record set read from deSEC.
+func rrsetDNSName(rrset RRSet, domain string) string {
+	if rrset.Name != "" {
+		return rrset.Name
+	}
+	if rrset.Subname == "" {
+		return domain
+	}
+	return rrset.Subname + "." + domain
+}
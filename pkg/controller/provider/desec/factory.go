@@ -0,0 +1,31 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package desec
+
+import (
+	"github.com/gardener/external-dns-management/pkg/controller/provider/compound"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+)
+
+const TYPE_CODE = "desec"
+
+// rateLimiterDefaults respects the rate limits of the deSEC API for record set changes.
+var rateLimiterDefaults = provider.RateLimiterOptions{
+	Enabled: true,
+	QPS:     1,
+	Burst:   5,
+}
+
+var Factory = provider.NewDNSHandlerFactory(TYPE_CODE, NewHandler).
+	SetGenericFactoryOptionDefaults(provider.GenericFactoryOptionDefaults.SetRateLimiterOptions(rateLimiterDefaults)).
+	SetSecretKeys(
+		provider.RequiredSecretKey("DESEC_TOKEN", "token"),
+		provider.OptionalSecretKey("DESEC_API_URL", "apiURL"),
+		provider.ProxySecretKey,
+	)
+
+func init() {
+	compound.MustRegister(Factory)
+}
@@ -0,0 +1,209 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package desec
+
+import (
+	"context"
+
+	"fmt"
+	"sync"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+)
+
+type Handler struct {
+	provider.DefaultDNSHandler
+	config provider.DNSHandlerConfig
+	cache  provider.ZoneCache
+	client *client
+
+	lock        sync.Mutex
+	minimumTTLs map[string]int64
+}
+
+var (
+	_ provider.DNSHandler       = &Handler{}
+	_ provider.TXTRecordLimiter = &Handler{}
+)
+
+func NewHandler(c *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
+	h := &Handler{
+		DefaultDNSHandler: provider.NewDefaultDNSHandler(TYPE_CODE),
+		config:            *c,
+		minimumTTLs:       map[string]int64{},
+	}
+
+	token, err := c.GetRequiredProperty("DESEC_TOKEN", "token")
+	if err != nil {
+		return nil, err
+	}
+	apiURL := c.GetDefaultedProperty("DESEC_API_URL", defaultAPIURL, "apiURL")
+
+	h.client = newClient(c.Context, c.NewHTTPClient(), apiURL, token, c.Metrics, c.RateLimiter)
+
+	h.cache, err = c.ZoneCacheFactory.CreateZoneCache(provider.CacheZoneState, c.Metrics, h.getZones, h.getZoneState)
+	if err != nil {
+		return nil, err
+	}
+
+	return h, nil
+}
+
+func (h *Handler) Release() {
+	h.cache.Release()
+}
+
+// TXTRecordLimits returns the limits of deSEC for TXT record values.
+// Texts longer than 255 characters must be split into multiple character strings.
+func (h *Handler) TXTRecordLimits() dns.TXTRecordLimits {
+	return dns.TXTRecordLimits{ChunkSize: dns.MaxTXTCharacterStringLength}
+}
+
+func (h *Handler) GetZones() (provider.DNSHostedZones, error) {
+	return h.cache.GetZones()
+}
+
+func (h *Handler) getZones(_ context.Context, _ provider.ZoneCache) (provider.DNSHostedZones, error) {
+	blockedZones := h.config.Options.AdvancedOptions.GetBlockedZones()
+	domains, err := h.client.ListDomains()
+	if err != nil {
+		return nil, err
+	}
+
+	minimumTTLs := map[string]int64{}
+	zones := provider.DNSHostedZones{}
+	for _, d := range domains {
+		if blockedZones.Contains(d.Name) {
+			h.config.Logger.Infof("ignoring blocked zone id: %s", d.Name)
+			continue
+		}
+		minimumTTLs[d.Name] = d.MinimumTTL
+		zones = append(zones, provider.NewDNSHostedZone(h.ProviderType(), d.Name, d.Name, d.Name, false))
+	}
+
+	h.lock.Lock()
+	h.minimumTTLs = minimumTTLs
+	h.lock.Unlock()
+	return zones, nil
+}
+
+func (h *Handler) GetZoneState(zone provider.DNSHostedZone) (provider.DNSZoneState, error) {
+	return h.cache.GetZoneState(zone)
+}
+
+func (h *Handler) getZoneState(zone provider.DNSHostedZone, _ provider.ZoneCache) (provider.DNSZoneState, error) {
+	rrsets, err := h.client.ListRRSets(zone.Id().ID, zone.Key())
+	if err != nil {
+		return nil, err
+	}
+
+	minimumTTL := h.minimumTTL(zone.Key())
+	dnssets := dns.DNSSets{}
+	for _, rrset := range rrsets {
+		rs := dns.NewRecordSet(rrset.Type, rrset.TTL, nil)
+		// the desired TTL may have been adjusted to the range accepted by deSEC
+		rs.IgnoreTTL = rrset.TTL <= minimumTTL || rrset.TTL >= maximumTTL
+		for _, r := range rrset.Records {
+			rs.Add(&dns.Record{Value: r})
+		}
+		dnssets.AddRecordSetFromProvider(rrsetDNSName(rrset, zone.Domain()), rs)
+	}
+	return provider.NewDNSZoneState(dnssets), nil
+}
+
+func (h *Handler) ReportZoneStateConflict(zone provider.DNSHostedZone, err error) bool {
+	return h.cache.ReportZoneStateConflict(zone, err)
+}
+
+func (h *Handler) ExecuteRequests(logger logger.LogContext, zone provider.DNSHostedZone, state provider.DNSZoneState, reqs []*provider.ChangeRequest) error {
+	err := h.executeRequests(logger, zone, state, reqs)
+	h.cache.ApplyRequests(logger, err, zone, reqs)
+	return err
+}
+
+func (h *Handler) executeRequests(logger logger.LogContext, zone provider.DNSHostedZone, _ provider.DNSZoneState, reqs []*provider.ChangeRequest) error {
+	exec := newExecution(logger, zone, h.minimumTTL(zone.Key()))
+
+	var rrsets []RRSet
+	var valid []*provider.ChangeRequest
+	for _, req := range reqs {
+		rrset, err := exec.buildRRSet(req)
+		if err != nil {
+			if req.Done != nil {
+				req.Done.SetInvalid(err)
+			}
+			continue
+		}
+		if rrset == nil {
+			if req.Done != nil {
+				req.Done.Succeeded()
+			}
+			continue
+		}
+		rrsets = append(rrsets, *rrset)
+		valid = append(valid, req)
+	}
+	if len(rrsets) == 0 {
+		return nil
+	}
+
+	errs := make([]error, len(rrsets))
+	if err := h.client.UpdateRRSets(zone.Id().ID, zone.Key(), rrsets); err != nil {
+		if apiErr, ok := err.(*APIError); ok && apiErr.StatusCode == 400 && len(rrsets) > 1 {
+			// bulk changes are atomic, apply them one by one to identify the invalid ones
+			logger.Infof("bulk update of %d record sets rejected, applying them separately: %s", len(rrsets), err)
+			for i := range rrsets {
+				errs[i] = h.client.UpdateRRSets(zone.Id().ID, zone.Key(), rrsets[i:i+1])
+			}
+		} else {
+			for i := range errs {
+				errs[i] = err
+			}
+		}
+	}
+
+	var succeeded, failed int
+	var lastErr error
+	for i, req := range valid {
+		if errs[i] != nil {
+			failed++
+			lastErr = errs[i]
+			logger.Infof("Apply failed with %s", errs[i])
+			if req.Done != nil {
+				req.Done.Failed(errs[i])
+			}
+		} else {
+			succeeded++
+			if req.Done != nil {
+				req.Done.Succeeded()
+			}
+		}
+	}
+
+	if succeeded > 0 {
+		logger.Infof("Succeeded updates for records in zone %s: %d", zone.Id(), succeeded)
+	}
+	if failed > 0 {
+		logger.Infof("Failed updates for records in zone %s: %d", zone.Id(), failed)
+		if failed == len(valid) {
+			return lastErr
+		}
+		return fmt.Errorf("%d changes failed", failed)
+	}
+	return nil
+}
+
+// minimumTTL returns the minimum TTL of the domain as read from the domain list.
+func (h *Handler) minimumTTL(domain string) int64 {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if ttl := h.minimumTTLs[domain]; ttl > 0 {
+		return ttl
+	}
+	return defaultMinimumTTL
+}
@@ -0,0 +1,132 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package google
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+
+	googledns "google.golang.org/api/dns/v1"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	dnserrors "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+)
+
+const changeStatusDone = "done"
+
+var errMarkerReached = errors.New("marker reached")
+
+// getZoneStateChanges returns the record sets changed since the change with the given id (the marker).
+// The changes are listed in descending order of their change sequence until the marker is reached.
+// Changes which are still pending are ignored together with all later changes, so that they are
+// returned on the next call.
+func (h *Handler) getZoneStateChanges(zone provider.DNSHostedZone, marker string) (*provider.ZoneStateChanges, error) {
+	var (
+		markerSeq int64 = -1
+		err       error
+	)
+	if marker != "" {
+		markerSeq, err = strconv.ParseInt(marker, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid change marker %q: %w", marker, err)
+		}
+	}
+
+	var changes []*googledns.Change
+	rt := provider.M_LISTCHANGES
+	aggr := func(resp *googledns.ChangesListResponse) error {
+		h.config.Metrics.AddZoneRequests(zone.Id().ID, rt, 1)
+		rt = provider.M_PLISTCHANGES
+		for _, c := range resp.Changes {
+			seq, err := strconv.ParseInt(c.Id, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid change id %q: %w", c.Id, err)
+			}
+			if seq <= markerSeq {
+				return errMarkerReached
+			}
+			changes = append(changes, c)
+			if marker == "" {
+				return errMarkerReached
+			}
+		}
+		return nil
+	}
+
+	h.config.RateLimiter.Accept()
+	projectID, zoneName := SplitZoneID(zone.Id().ID)
+	call := h.service.Changes.List(projectID, zoneName).SortBy("changeSequence").SortOrder("descending")
+	if marker == "" {
+		call = call.MaxResults(1)
+	}
+	if err := call.Pages(h.ctx, aggr); err != nil && !errors.Is(err, errMarkerReached) {
+		if isManagedZoneNotFound(err) {
+			err = &dnserrors.NoSuchHostedZone{ZoneId: zone.Id().ID, Err: err}
+		}
+		return nil, err
+	}
+
+	if marker == "" {
+		if len(changes) == 0 {
+			return nil, fmt.Errorf("no changes found for zone %s", zone.Id())
+		}
+		if changes[0].Status != changeStatusDone {
+			return nil, fmt.Errorf("latest change %s of zone %s is still pending", changes[0].Id, zone.Id())
+		}
+		return &provider.ZoneStateChanges{Marker: changes[0].Id}, nil
+	}
+
+	slices.Reverse(changes)
+
+	result := &provider.ZoneStateChanges{Marker: marker}
+	for _, c := range changes {
+		if c.Status != changeStatusDone {
+			break
+		}
+		for _, r := range c.Deletions {
+			result.Changes = append(result.Changes, toZoneStateChanges(r, false)...)
+		}
+		for _, r := range c.Additions {
+			result.Changes = append(result.Changes, toZoneStateChanges(r, true)...)
+		}
+		result.Marker = c.Id
+	}
+	return result, nil
+}
+
+// toZoneStateChanges converts a deleted or added resource record set into zone state changes.
+// A deleted resource record set results in changes without DNS sets for the affected DNS names and record types.
+func toZoneStateChanges(r *googledns.ResourceRecordSet, added bool) []provider.ZoneStateChange {
+	dnssets := dns.DNSSets{}
+	addResourceRecordSet(dnssets, r)
+	if len(dnssets) == 0 {
+		return []provider.ZoneStateChange{{DNSName: dns.NormalizeHostname(r.Name), Type: r.Type}}
+	}
+
+	type changeKey struct {
+		dnsName string
+		rtype   string
+	}
+	keys := map[changeKey]dns.DNSSets{}
+	for name, set := range dnssets {
+		for rtype := range set.Sets {
+			key := changeKey{dnsName: name.DNSName, rtype: rtype}
+			if keys[key] == nil {
+				keys[key] = dns.DNSSets{}
+			}
+			if added {
+				keys[key][name] = set
+			}
+		}
+	}
+	var result []provider.ZoneStateChange
+	for key, sets := range keys {
+		result = append(result, provider.ZoneStateChange{DNSName: key.dnsName, Type: key.rtype, DNSSets: sets})
+	}
+	return result
+}
@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package google
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	googledns "google.golang.org/api/dns/v1"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+var _ = Describe("Zone state changes", func() {
+	It("converts an added resource record set", func() {
+		changes := toZoneStateChanges(&googledns.ResourceRecordSet{
+			Name:    "a.example.org.",
+			Type:    dns.RS_A,
+			Ttl:     300,
+			Rrdatas: []string{"1.1.1.1"},
+		}, true)
+		Expect(changes).To(HaveLen(1))
+		Expect(changes[0].DNSName).To(Equal("a.example.org"))
+		Expect(changes[0].Type).To(Equal(dns.RS_A))
+		Expect(changes[0].DNSSets).To(HaveKey(dns.DNSSetName{DNSName: "a.example.org"}))
+	})
+
+	It("converts a deleted weighted resource record set", func() {
+		changes := toZoneStateChanges(&googledns.ResourceRecordSet{
+			Name: "w.example.org.",
+			Type: dns.RS_CNAME,
+			Ttl:  300,
+			RoutingPolicy: &googledns.RRSetRoutingPolicy{
+				Wrr: &googledns.RRSetRoutingPolicyWrrPolicy{
+					Items: []*googledns.RRSetRoutingPolicyWrrPolicyWrrPolicyItem{
+						{Rrdatas: []string{"x.example.org."}, Weight: 1},
+						{Rrdatas: []string{"y.example.org."}, Weight: 2},
+					},
+				},
+			},
+		}, false)
+		Expect(changes).To(HaveLen(1))
+		Expect(changes[0].DNSName).To(Equal("w.example.org"))
+		Expect(changes[0].Type).To(Equal(dns.RS_CNAME))
+		Expect(changes[0].DNSSets).To(BeEmpty())
+	})
+
+	It("converts an unsupported resource record set", func() {
+		changes := toZoneStateChanges(&googledns.ResourceRecordSet{
+			Name:    "example.org.",
+			Type:    "SOA",
+			Rrdatas: []string{"ns.example.org. admin.example.org. 1 21600 3600 259200 300"},
+		}, true)
+		Expect(changes).To(HaveLen(1))
+		Expect(changes[0].DNSName).To(Equal("example.org"))
+		Expect(changes[0].DNSSets).To(BeEmpty())
+	})
+})
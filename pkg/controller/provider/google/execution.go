@@ -5,6 +5,9 @@
 package google
 
 import (
+	"errors"
+	"strings"
+
 	"github.com/gardener/controller-manager-library/pkg/logger"
 	"github.com/gardener/controller-manager-library/pkg/utils"
 	googledns "google.golang.org/api/dns/v1"
@@ -12,6 +15,7 @@ import (
 
 	"github.com/gardener/external-dns-management/pkg/dns"
 	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	dnserrors "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
 )
 
 const (
@@ -70,7 +74,7 @@ func (this *Execution) addChange(req *provider.ChangeRequest) {
 	if setName.DNSName == "" || (newset.Length() == 0 && oldset.Length() == 0) {
 		return
 	}
-	setName = setName.Align()
+	setName = nameForm.SetName(setName)
 	switch req.Action {
 	case provider.R_CREATE:
 		this.Infof("%s %s record set %s[%s]: %s(%d)", req.Action, req.Type, setName, this.zone.Id(), newset.RecordString(), newset.TTL)
@@ -156,6 +160,13 @@ func (this *Execution) submitChanges(metrics provider.Metrics) error {
 	metrics.AddZoneRequests(this.zone.Id().ID, provider.M_UPDATERECORDS, 1)
 	this.handler.config.RateLimiter.Accept()
 	if _, err := this.handler.service.Changes.Create(projectID, zoneName, this.change).Do(); err != nil {
+		if isPerChangeQuotaExceeded(err) {
+			err = dnserrors.NewBatchTooLargeError(err)
+		} else if isManagedZoneNotFound(err) {
+			err = &dnserrors.NoSuchHostedZone{ZoneId: this.zone.Id().ID, Err: err}
+		} else if isConditionNotMet(err) {
+			err = dnserrors.NewConditionalUpdateConflictError(err)
+		}
 		this.Error(err)
 		for _, d := range this.done {
 			if d != nil {
@@ -174,6 +185,53 @@ func (this *Execution) submitChanges(metrics provider.Metrics) error {
 	return nil
 }
 
+// isConditionNotMet returns true if the change was rejected because a deleted record set does not match
+// the current record set anymore or because an added record set already exists.
+// Cloud DNS applies deletions only if the record set is unchanged, so updates are always conditional.
+func isConditionNotMet(err error) bool {
+	var ge *googleapi.Error
+	if !errors.As(err, &ge) {
+		return false
+	}
+	return ge.Code == 412 || ge.Code == 409
+}
+
+// isPerChangeQuotaExceeded returns true if the change exceeds a quota per change
+// like `rrsetAdditionsPerChange` or `rrsetDeletionsPerChange`.
+func isPerChangeQuotaExceeded(err error) bool {
+	var ge *googleapi.Error
+	if !errors.As(err, &ge) {
+		return false
+	}
+	for _, item := range ge.Errors {
+		if item.Reason == "quotaExceeded" && strings.Contains(item.Message, "PerChange") {
+			return true
+		}
+	}
+	return false
+}
+
+// isManagedZoneNotFound returns true if the error reports that the managed zone itself does not exist.
+// Other not found errors, e.g. for a record set to be deleted, are not considered.
+func isManagedZoneNotFound(err error) bool {
+	var ge *googleapi.Error
+	if !errors.As(err, &ge) || ge.Code != 404 {
+		return false
+	}
+	for _, item := range ge.Errors {
+		if strings.Contains(item.Message, "managedZone") {
+			return true
+		}
+	}
+	return strings.Contains(ge.Message, "managedZone")
+}
+
+// isAccessDenied returns true if the request was rejected as the credentials grant no access to the resource.
+func isAccessDenied(err error) bool {
+	var ge *googleapi.Error
+	return errors.As(err, &ge) && ge.Code == 403
+}
+
 func isNotFound(err error) bool {
 	if ge, ok := err.(*googleapi.Error); ok {
 		return ge.Code == 404
@@ -184,9 +242,14 @@ func isNotFound(err error) bool {
 func mapRecordSet(name dns.DNSSetName, rs *dns.RecordSet, policy *googleRoutingPolicyData) *googledns.ResourceRecordSet {
 	targets := make([]string, len(rs.Records))
 	for i, r := range rs.Records {
-		if rs.Type == dns.RS_CNAME {
-			targets[i] = dns.AlignHostname(r.Value)
-		} else {
+		switch rs.Type {
+		case dns.RS_CNAME, dns.RS_NS:
+			targets[i] = nameForm.Hostname(r.Value)
+		case dns.RS_SRV:
+			targets[i] = dns.AlignSRVValue(r.Value)
+		case dns.RS_MX:
+			targets[i] = dns.AlignMXValue(r.Value)
+		default:
 			targets[i] = r.Value
 		}
 	}
@@ -362,6 +362,54 @@ var _ = Describe("Execution", func() {
 				}),
 			}),
 		),
+		Entry("prepares SRV change requests with aligned targets",
+			[]*provider.ChangeRequest{
+				{Action: provider.R_CREATE, Type: dns.RS_SRV, Addition: makeDNSSet("_sip._tcp.example.org", dns.RS_SRV, 300, "10 5 5060 sip.example.org")},
+			},
+			nil,
+			MatchFields(IgnoreExtras, Fields{
+				"Deletions": BeEmpty(),
+				"Additions": MatchAllElements(nameFunc, Elements{
+					"_sip._tcp.example.org.": matchSimpleResourceRecordSet(dns.RS_SRV, 300, "10 5 5060 sip.example.org."),
+				}),
+			}),
+		),
+		Entry("prepares CAA change requests",
+			[]*provider.ChangeRequest{
+				{Action: provider.R_CREATE, Type: dns.RS_CAA, Addition: makeDNSSet("example.org", dns.RS_CAA, 300, `0 issue "letsencrypt.org"`)},
+			},
+			nil,
+			MatchFields(IgnoreExtras, Fields{
+				"Deletions": BeEmpty(),
+				"Additions": MatchAllElements(nameFunc, Elements{
+					"example.org.": matchSimpleResourceRecordSet(dns.RS_CAA, 300, `0 issue "letsencrypt.org"`),
+				}),
+			}),
+		),
+		Entry("prepares MX change requests",
+			[]*provider.ChangeRequest{
+				{Action: provider.R_CREATE, Type: dns.RS_MX, Addition: makeDNSSet("example.org", dns.RS_MX, 300, "10 mx1.example.org", "20 mx2.example.org")},
+			},
+			nil,
+			MatchFields(IgnoreExtras, Fields{
+				"Deletions": BeEmpty(),
+				"Additions": MatchAllElements(nameFunc, Elements{
+					"example.org.": matchSimpleResourceRecordSet(dns.RS_MX, 300, "10 mx1.example.org.", "20 mx2.example.org."),
+				}),
+			}),
+		),
+		Entry("prepares DS change requests",
+			[]*provider.ChangeRequest{
+				{Action: provider.R_CREATE, Type: dns.RS_DS, Addition: makeDNSSet("child.example.org", dns.RS_DS, 300, "60485 5 1 2BB183AF5F22588179A53B0A98631FAD1A292118")},
+			},
+			nil,
+			MatchFields(IgnoreExtras, Fields{
+				"Deletions": BeEmpty(),
+				"Additions": MatchAllElements(nameFunc, Elements{
+					"child.example.org.": matchSimpleResourceRecordSet(dns.RS_DS, 300, "60485 5 1 2BB183AF5F22588179A53B0A98631FAD1A292118"),
+				}),
+			}),
+		),
 		Entry("prepares weighted policy-routing change requests",
 			[]*provider.ChangeRequest{
 				{Action: provider.R_CREATE, Type: dns.RS_A, Addition: dnssetwrr1_0},
@@ -590,6 +638,34 @@ func prepareSubmission(reqs []*provider.ChangeRequest, rrsetGetter rrsetGetterFu
 	return exec.change, nil
 }
 
+var _ = Describe("Error classification", func() {
+	It("detects a missing managed zone", func() {
+		err := &googleapi.Error{Code: 404, Errors: []googleapi.ErrorItem{{Reason: "notFound", Message: "The 'parameters.managedZone' resource named 'my-zone' does not exist."}}}
+		Expect(isManagedZoneNotFound(err)).To(BeTrue())
+		Expect(isManagedZoneNotFound(fmt.Errorf("wrapped: %w", err))).To(BeTrue())
+	})
+
+	It("ignores other not found errors", func() {
+		err := &googleapi.Error{Code: 404, Errors: []googleapi.ErrorItem{{Reason: "notFound", Message: "The 'entity.change.deletions[0]' resource named 'a.example.org. (A)' does not exist."}}}
+		Expect(isManagedZoneNotFound(err)).To(BeFalse())
+		Expect(isManagedZoneNotFound(&googleapi.Error{Code: 500, Message: "managedZone"})).To(BeFalse())
+	})
+
+	It("detects denied access", func() {
+		Expect(isAccessDenied(fmt.Errorf("wrapped: %w", &googleapi.Error{Code: 403, Message: "Forbidden"}))).To(BeTrue())
+		Expect(isAccessDenied(&googleapi.Error{Code: 404})).To(BeFalse())
+		Expect(isAccessDenied(fmt.Errorf("other"))).To(BeFalse())
+	})
+
+	It("detects concurrently changed record sets", func() {
+		err := &googleapi.Error{Code: 412, Errors: []googleapi.ErrorItem{{Reason: "conditionNotMet", Message: "Precondition not met for 'entity.change.deletions[0]'"}}}
+		Expect(isConditionNotMet(err)).To(BeTrue())
+		Expect(isConditionNotMet(fmt.Errorf("wrapped: %w", err))).To(BeTrue())
+		Expect(isConditionNotMet(&googleapi.Error{Code: 409, Errors: []googleapi.ErrorItem{{Reason: "alreadyExists"}}})).To(BeTrue())
+		Expect(isConditionNotMet(&googleapi.Error{Code: 404})).To(BeFalse())
+	})
+})
+
 type testDoneHandler struct {
 	invalidCount int
 	failedCount  int
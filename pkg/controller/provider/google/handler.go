@@ -6,6 +6,8 @@ package google
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -14,6 +16,7 @@ import (
 	"k8s.io/client-go/util/flowcontrol"
 
 	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	dnserrors "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
 
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
@@ -27,6 +30,7 @@ import (
 
 type Handler struct {
 	provider.DefaultDNSHandler
+	provider.DeniedZonesTracker
 	config      provider.DNSHandlerConfig
 	cache       provider.ZoneCache
 	credentials *google.Credentials
@@ -34,11 +38,33 @@ type Handler struct {
 	ctx         context.Context
 	service     *googledns.Service
 	rateLimiter flowcontrol.RateLimiter
+	batchSize   int
+	gcpConfig   GoogleConfig
+}
+
+// GoogleConfig is the provider config of Google CloudDNS providers.
+type GoogleConfig struct {
+	provider.ZoneListingConfig `json:",inline"`
 }
 
 const epsilon = 0.00001
 
-var _ provider.DNSHandler = &Handler{}
+// maxZoneListPageSize is the maximum number of managed zones per page requested from Google CloudDNS.
+const maxZoneListPageSize = 1000
+
+// nameForm is the canonical form of DNS names in Google Cloud DNS: lower case with trailing dot.
+var nameForm = dns.NameForm{TrailingDot: true, LowerCase: true}
+
+// errMaxZonesReached stops the paginated zone listing after the maximum number of zones.
+var errMaxZonesReached = errors.New("maximum number of zones reached")
+
+var (
+	_ provider.DNSHandler           = &Handler{}
+	_ provider.ChangeBatchLimiter   = &Handler{}
+	_ provider.NameFormer           = &Handler{}
+	_ provider.CapabilitiesReporter = &Handler{}
+	_ provider.DeniedZonesReporter  = &Handler{}
+)
 
 func NewHandler(config *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
 	var err error
@@ -48,6 +74,17 @@ func NewHandler(config *provider.DNSHandlerConfig) (provider.DNSHandler, error)
 		config:            *config,
 		rateLimiter:       config.RateLimiter,
 	}
+	if config.Options != nil {
+		h.batchSize = config.Options.AdvancedOptions.GetAdvancedConfig().BatchSize
+	}
+	if config.Config != nil {
+		if err := json.Unmarshal(config.Config.Raw, &h.gcpConfig); err != nil {
+			return nil, fmt.Errorf("unmarshal google-clouddns providerConfig failed with: %s", err)
+		}
+		if err := h.gcpConfig.ZoneListingConfig.Validate(maxZoneListPageSize); err != nil {
+			return nil, fmt.Errorf("invalid google-clouddns providerConfig: %w", err)
+		}
+	}
 	scopes := []string{
 		//	"https://www.googleapis.com/auth/compute",
 		//	"https://www.googleapis.com/auth/cloud-platform",
@@ -60,9 +97,8 @@ func NewHandler(config *provider.DNSHandlerConfig) (provider.DNSHandler, error)
 		return nil, fmt.Errorf("'serviceaccount.json' required in secret")
 	}
 
-	// c:=*http.DefaultClient
-	// h.ctx=context.WithValue(config.Context,oauth2.HTTPClient,&c)
-	h.ctx = config.Context
+	// the HTTP client in the context is used for token requests and as base of the oauth2 client
+	h.ctx = context.WithValue(config.Context, oauth2.HTTPClient, config.NewHTTPClient())
 
 	h.credentials, err = google.CredentialsFromJSON(h.ctx, []byte(json), scopes...)
 	// cfg, err:=google.JWTConfigFromJSON([]byte(json))
@@ -77,7 +113,7 @@ func NewHandler(config *provider.DNSHandlerConfig) (provider.DNSHandler, error)
 		return nil, err
 	}
 
-	h.cache, err = config.ZoneCacheFactory.CreateZoneCache(provider.CacheZoneState, config.Metrics, h.getZones, h.getZoneState)
+	h.cache, err = config.ZoneCacheFactory.CreateIncrementalZoneCache(config.Metrics, h.getZones, h.getZoneState, h.getZoneStateChanges)
 	if err != nil {
 		return nil, err
 	}
@@ -85,6 +121,22 @@ func NewHandler(config *provider.DNSHandlerConfig) (provider.DNSHandler, error)
 	return h, nil
 }
 
+// MaxChangeBatchSize returns the maximum number of change requests submitted in a single change.
+// A Google change is applied atomically, but the changes of a zone split into several changes are not.
+func (h *Handler) MaxChangeBatchSize() int {
+	return h.batchSize
+}
+
+// NameForm returns the form of DNS names written to Google CloudDNS.
+func (h *Handler) NameForm() dns.NameForm {
+	return nameForm
+}
+
+// Capabilities returns the optional record types supported by Google CloudDNS.
+func (h *Handler) Capabilities() provider.Capabilities {
+	return provider.Capabilities{RecordTypes: []string{dns.RS_NS, dns.RS_DS, dns.RS_MX}}
+}
+
 func (h *Handler) Release() {
 	h.cache.Release()
 }
@@ -93,13 +145,17 @@ func (h *Handler) GetZones() (provider.DNSHostedZones, error) {
 	return h.cache.GetZones()
 }
 
-func (h *Handler) getZones(_ provider.ZoneCache) (provider.DNSHostedZones, error) {
+func (h *Handler) getZones(ctx context.Context, _ provider.ZoneCache) (provider.DNSHostedZones, error) {
 	blockedZones := h.config.Options.AdvancedOptions.GetBlockedZones()
 
 	rt := provider.M_LISTZONES
+	listing := h.gcpConfig.ZoneListingConfig
 	raw := []*googledns.ManagedZone{}
 	f := func(resp *googledns.ManagedZonesListResponse) error {
 		for _, zone := range resp.ManagedZones {
+			if listing.MaxZonesReached(len(raw)) {
+				return errMaxZonesReached
+			}
 			zoneID := h.makeZoneID(zone.Name)
 			if blockedZones.Contains(zoneID) {
 				h.config.Logger.Infof("ignoring blocked zone id: %s", zoneID)
@@ -113,14 +169,27 @@ func (h *Handler) getZones(_ provider.ZoneCache) (provider.DNSHostedZones, error
 	}
 
 	h.config.RateLimiter.Accept()
-	if err := h.service.ManagedZones.List(h.credentials.ProjectID).Pages(h.ctx, f); err != nil {
-		return nil, err
+	ctx, cancel := listing.Context(ctx)
+	defer cancel()
+	call := h.service.ManagedZones.List(h.credentials.ProjectID)
+	if listing.ZoneListPageSize > 0 {
+		call = call.MaxResults(int64(listing.ZoneListPageSize))
+	}
+	if err := call.Pages(ctx, f); err != nil {
+		switch {
+		case errors.Is(err, errMaxZonesReached):
+			h.config.Logger.Warnf("zone listing stopped after maximum number of %d zones", listing.ZoneListMaxZones)
+		case listing.IsPartialResult(ctx, err, len(raw)):
+			h.config.Logger.Warnf("zone listing timed out after %s, serving %d zones listed so far", listing.ZoneListTimeout.Duration, len(raw))
+		default:
+			return nil, err
+		}
 	}
 
 	zones := provider.DNSHostedZones{}
 	for _, z := range raw {
 		zoneID := h.makeZoneID(z.Name)
-		hostedZone := provider.NewDNSHostedZone(h.ProviderType(), zoneID, dns.NormalizeHostname(z.DnsName), "", false)
+		hostedZone := provider.NewDNSHostedZoneWithTags(h.ProviderType(), zoneID, dns.NormalizeHostname(z.DnsName), "", false, z.Labels)
 		zones = append(zones, hostedZone)
 	}
 
@@ -150,42 +219,53 @@ func (h *Handler) getZoneState(zone provider.DNSHostedZone, _ provider.ZoneCache
 	dnssets := dns.DNSSets{}
 
 	f := func(r *googledns.ResourceRecordSet) {
-		if dns.SupportedRecordType(r.Type) {
-			if len(r.Rrdatas) > 0 {
-				rs := dns.NewRecordSet(r.Type, r.Ttl, nil)
-				for _, rr := range r.Rrdatas {
-					rs.Add(&dns.Record{Value: rr})
-				}
-				dnssets.AddRecordSetFromProvider(r.Name, rs)
-			} else if r.RoutingPolicy != nil && r.RoutingPolicy.Wrr != nil {
-				for _, item := range r.RoutingPolicy.Wrr.Items {
-					if int64(item.Weight+epsilon)*10 != int64(item.Weight*10+epsilon) {
-						return // foreign as managed recordsets only use integral weights
-					}
-				}
-				for i, item := range r.RoutingPolicy.Wrr.Items {
-					if isWrrPlaceHolderItem(r.Type, item) {
-						continue
-					}
-					rs := dns.NewRecordSet(r.Type, r.Ttl, nil)
-					for _, rr := range item.Rrdatas {
-						rs.Add(&dns.Record{Value: rr})
-					}
-					dnsSetName := dns.DNSSetName{DNSName: r.Name, SetIdentifier: fmt.Sprintf("%d", i)}
-					policy := dns.NewRoutingPolicy(dns.RoutingPolicyWeighted, "weight", strconv.FormatInt(int64(item.Weight+epsilon), 10))
-					dnssets.AddRecordSetFromProviderEx(dnsSetName, policy, rs)
-				}
-			}
-		}
+		addResourceRecordSet(dnssets, r)
 	}
 
 	if err := h.handleRecordSets(zone, f); err != nil {
+		h.UpdateDeniedZone(zone.Id().ID, isAccessDenied(err))
+		if isManagedZoneNotFound(err) {
+			err = &dnserrors.NoSuchHostedZone{ZoneId: zone.Id().ID, Err: err}
+		}
 		return nil, err
 	}
 
+	h.UpdateDeniedZone(zone.Id().ID, false)
 	return provider.NewDNSZoneState(dnssets), nil
 }
 
+// addResourceRecordSet adds the record sets of a supported resource record set to the given DNS sets.
+func addResourceRecordSet(dnssets dns.DNSSets, r *googledns.ResourceRecordSet) {
+	if !dns.SupportedRecordType(r.Type) {
+		return
+	}
+	if len(r.Rrdatas) > 0 {
+		rs := dns.NewRecordSet(r.Type, r.Ttl, nil)
+		for _, rr := range r.Rrdatas {
+			rs.Add(&dns.Record{Value: rr})
+		}
+		dnssets.AddRecordSetFromProvider(r.Name, rs)
+	} else if r.RoutingPolicy != nil && r.RoutingPolicy.Wrr != nil {
+		for _, item := range r.RoutingPolicy.Wrr.Items {
+			if int64(item.Weight+epsilon)*10 != int64(item.Weight*10+epsilon) {
+				return // foreign as managed recordsets only use integral weights
+			}
+		}
+		for i, item := range r.RoutingPolicy.Wrr.Items {
+			if isWrrPlaceHolderItem(r.Type, item) {
+				continue
+			}
+			rs := dns.NewRecordSet(r.Type, r.Ttl, nil)
+			for _, rr := range item.Rrdatas {
+				rs.Add(&dns.Record{Value: rr})
+			}
+			dnsSetName := dns.DNSSetName{DNSName: r.Name, SetIdentifier: fmt.Sprintf("%d", i)}
+			policy := dns.NewRoutingPolicy(dns.RoutingPolicyWeighted, "weight", strconv.FormatInt(int64(item.Weight+epsilon), 10))
+			dnssets.AddRecordSetFromProviderEx(dnsSetName, policy, rs)
+		}
+	}
+}
+
 func (h *Handler) ReportZoneStateConflict(zone provider.DNSHostedZone, err error) bool {
 	return h.cache.ReportZoneStateConflict(zone, err)
 }
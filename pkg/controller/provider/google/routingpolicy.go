@@ -340,8 +340,16 @@ func rrDefaultValue(typ string) string {
 	case dns.RS_A:
 		// use dummy documentation IP address
 		return "233.252.0.1"
-	case dns.RS_CNAME:
+	case dns.RS_CNAME, dns.RS_NS:
 		return "dummy.dummy.dummy.com."
+	case dns.RS_SRV:
+		return "0 0 0 dummy.dummy.dummy.com."
+	case dns.RS_CAA:
+		return `0 issue ";"`
+	case dns.RS_MX:
+		return "0 ."
+	case dns.RS_DS:
+		return "0 8 2 " + strings.Repeat("0", 64)
 	case dns.RS_AAAA:
 		// use dummy documentation IP address
 		return "2001:db8::1"
@@ -0,0 +1,226 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package hetzner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"k8s.io/client-go/util/flowcontrol"
+
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/raw"
+)
+
+const (
+	defaultAPIURL = "https://dns.hetzner.com/api/v1"
+	// pageSize is the number of zones or records requested per page.
+	pageSize = 100
+)
+
+// Zone is a zone of a Hetzner DNS account.
+type Zone struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	TTL  int64  `json:"ttl"`
+}
+
+type pagination struct {
+	Page     int `json:"page"`
+	LastPage int `json:"last_page"`
+}
+
+type meta struct {
+	Pagination pagination `json:"pagination"`
+}
+
+// APIError is an error response of the Hetzner DNS API.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("Hetzner DNS API request failed with status %d: %s", e.StatusCode, e.Message)
+}
+
+type client struct {
+	ctx         context.Context
+	baseURL     string
+	token       string
+	httpClient  *http.Client
+	metrics     provider.Metrics
+	rateLimiter flowcontrol.RateLimiter
+}
+
+var _ raw.Executor = &client{}
+
+func newClient(ctx context.Context, httpClient *http.Client, baseURL, token string, metrics provider.Metrics, rateLimiter flowcontrol.RateLimiter) *client {
+	return &client{
+		ctx:         ctx,
+		baseURL:     strings.TrimSuffix(baseURL, "/"),
+		token:       token,
+		httpClient:  httpClient,
+		metrics:     metrics,
+		rateLimiter: rateLimiter,
+	}
+}
+
+// ListZones returns all zones of the account.
+func (c *client) ListZones() ([]Zone, error) {
+	var result []Zone
+	err := c.list(c.baseURL+"/zones", url.Values{}, func() { c.metrics.AddGenericRequests(provider.M_LISTZONES, 1) }, func(data []byte) (meta, error) {
+		var page struct {
+			Zones []Zone `json:"zones"`
+			Meta  meta   `json:"meta"`
+		}
+		if err := json.Unmarshal(data, &page); err != nil {
+			return meta{}, err
+		}
+		result = append(result, page.Zones...)
+		return page.Meta, nil
+	})
+	return result, err
+}
+
+// ListRecords returns all records of a zone.
+// Records without an explicit TTL get the default TTL of the zone.
+func (c *client) ListRecords(zone provider.DNSHostedZone, defaultTTL int64) ([]*Record, error) {
+	var result []*Record
+	query := url.Values{"zone_id": []string{zone.Id().ID}}
+	err := c.list(c.baseURL+"/records", query, func() { c.metrics.AddZoneRequests(zone.Id().ID, provider.M_LISTRECORDS, 1) }, func(data []byte) (meta, error) {
+		var page struct {
+			Records []*Record `json:"records"`
+			Meta    meta      `json:"meta"`
+		}
+		if err := json.Unmarshal(data, &page); err != nil {
+			return meta{}, err
+		}
+		for _, r := range page.Records {
+			r.domain = zone.Domain()
+			r.defaultTTL = defaultTTL
+			result = append(result, r)
+		}
+		return page.Meta, nil
+	})
+	return result, err
+}
+
+func (c *client) CreateRecord(r raw.Record, zone provider.DNSHostedZone) error {
+	c.metrics.AddZoneRequests(zone.Id().ID, provider.M_CREATERECORDS, 1)
+	return c.send(http.MethodPost, c.baseURL+"/records", r.(*Record))
+}
+
+func (c *client) UpdateRecord(r raw.Record, zone provider.DNSHostedZone) error {
+	c.metrics.AddZoneRequests(zone.Id().ID, provider.M_UPDATERECORDS, 1)
+	return c.send(http.MethodPut, c.recordURL(r.GetId()), r.(*Record))
+}
+
+func (c *client) DeleteRecord(r raw.Record, zone provider.DNSHostedZone) error {
+	c.metrics.AddZoneRequests(zone.Id().ID, provider.M_DELETERECORDS, 1)
+	_, err := c.do(http.MethodDelete, c.recordURL(r.GetId()), nil)
+	return err
+}
+
+func (c *client) NewRecord(fqdn, rtype, value string, zone provider.DNSHostedZone, ttl int64) raw.Record {
+	r := &Record{
+		ZoneID: zone.Id().ID,
+		Type:   rtype,
+		Name:   recordName(fqdn, zone.Domain()),
+		Value:  recordValue(rtype, value),
+		domain: zone.Domain(),
+	}
+	r.SetTTL(ttl)
+	return r
+}
+
+func (c *client) GetRecordSet(dnsName, rtype string, zone provider.DNSHostedZone) (raw.RecordSet, error) {
+	records, err := c.ListRecords(zone, 0)
+	if err != nil {
+		return nil, err
+	}
+	// no filtering provided by API, we have to list complete zone and filter
+	rs := raw.RecordSet{}
+	for _, r := range records {
+		if r.Type == rtype && r.GetDNSName() == dnsName {
+			rs = append(rs, r)
+		}
+	}
+	return rs, nil
+}
+
+func (c *client) recordURL(id string) string {
+	return fmt.Sprintf("%s/records/%s", c.baseURL, url.PathEscape(id))
+}
+
+func (c *client) send(method, u string, r *Record) error {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	_, err = c.do(method, u, body)
+	return err
+}
+
+// list reads all pages of a list request.
+func (c *client) list(u string, query url.Values, count func(), consume func(data []byte) (meta, error)) error {
+	query.Set("per_page", fmt.Sprintf("%d", pageSize))
+	for page := 1; ; page++ {
+		query.Set("page", fmt.Sprintf("%d", page))
+		count()
+		data, err := c.do(http.MethodGet, u+"?"+query.Encode(), nil)
+		if err != nil {
+			return err
+		}
+		m, err := consume(data)
+		if err != nil {
+			return err
+		}
+		if m.Pagination.LastPage <= page {
+			return nil
+		}
+	}
+}
+
+func (c *client) do(method, u string, body []byte) ([]byte, error) {
+	c.rateLimiter.Accept()
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(c.ctx, method, u, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Auth-API-Token", c.token)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 {
+		apiErr := &APIError{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(data))}
+		if resp.StatusCode == http.StatusTooManyRequests {
+			return nil, perrs.NewThrottlingError(apiErr)
+		}
+		return nil, apiErr
+	}
+	return data, nil
+}
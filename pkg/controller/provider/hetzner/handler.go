@@ -0,0 +1,178 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package hetzner
+
+import (
+	"context"
+
+	"fmt"
+	"sync"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/raw"
+)
+
+type Handler struct {
+	provider.DefaultDNSHandler
+	config provider.DNSHandlerConfig
+	cache  provider.ZoneCache
+	client *client
+
+	lock        sync.Mutex
+	defaultTTLs map[string]int64
+}
+
+var (
+	_ provider.DNSHandler       = &Handler{}
+	_ provider.TXTRecordLimiter = &Handler{}
+	_ provider.TTLLimiter       = &Handler{}
+)
+
+func NewHandler(c *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
+	h := &Handler{
+		DefaultDNSHandler: provider.NewDefaultDNSHandler(TYPE_CODE),
+		config:            *c,
+		defaultTTLs:       map[string]int64{},
+	}
+
+	token, err := c.GetRequiredProperty("HETZNER_DNS_API_TOKEN", "apiToken")
+	if err != nil {
+		return nil, err
+	}
+	apiURL := c.GetDefaultedProperty("HETZNER_DNS_API_URL", defaultAPIURL, "apiURL")
+
+	h.client = newClient(c.Context, c.NewHTTPClient(), apiURL, token, c.Metrics, c.RateLimiter)
+
+	h.cache, err = c.ZoneCacheFactory.CreateZoneCache(provider.CacheZonesOnly, c.Metrics, h.getZones, h.getZoneState)
+	if err != nil {
+		return nil, err
+	}
+
+	return h, nil
+}
+
+func (h *Handler) Release() {
+	h.cache.Release()
+}
+
+// TXTRecordLimits returns the limits of Hetzner DNS for TXT record values.
+// Texts longer than 255 characters must be split into multiple character strings.
+func (h *Handler) TXTRecordLimits() dns.TXTRecordLimits {
+	return dns.TXTRecordLimits{ChunkSize: dns.MaxTXTCharacterStringLength}
+}
+
+// MinTTL returns the lowest TTL written to Hetzner DNS.
+func (h *Handler) MinTTL() int64 {
+	return minimumTTL
+}
+
+func (h *Handler) GetZones() (provider.DNSHostedZones, error) {
+	return h.cache.GetZones()
+}
+
+func (h *Handler) getZones(_ context.Context, _ provider.ZoneCache) (provider.DNSHostedZones, error) {
+	blockedZones := h.config.Options.AdvancedOptions.GetBlockedZones()
+	rawZones, err := h.client.ListZones()
+	if err != nil {
+		return nil, err
+	}
+
+	defaultTTLs := map[string]int64{}
+	zones := provider.DNSHostedZones{}
+	for _, z := range rawZones {
+		if blockedZones.Contains(z.ID) {
+			h.config.Logger.Infof("ignoring blocked zone id: %s", z.ID)
+			continue
+		}
+		defaultTTLs[z.ID] = z.TTL
+		zones = append(zones, provider.NewDNSHostedZone(h.ProviderType(), z.ID, dns.NormalizeHostname(z.Name), z.ID, false))
+	}
+
+	h.lock.Lock()
+	h.defaultTTLs = defaultTTLs
+	h.lock.Unlock()
+	return zones, nil
+}
+
+func (h *Handler) GetZoneState(zone provider.DNSHostedZone) (provider.DNSZoneState, error) {
+	return h.cache.GetZoneState(zone)
+}
+
+func (h *Handler) getZoneState(zone provider.DNSHostedZone, _ provider.ZoneCache) (provider.DNSZoneState, error) {
+	records, err := h.client.ListRecords(zone, h.defaultTTL(zone.Id().ID))
+	if err != nil {
+		return nil, err
+	}
+	return newZoneState(records), nil
+}
+
+// newZoneState returns the zone state for the records of a zone.
+func newZoneState(records []*Record) provider.DNSZoneState {
+	state := raw.NewState()
+	for _, r := range records {
+		state.AddRecord(r)
+	}
+	state.CalculateDNSSets()
+	for _, set := range state.GetDNSSets() {
+		for _, rs := range set.Sets {
+			// the desired TTL may have been raised to the minimum TTL
+			rs.IgnoreTTL = rs.TTL <= minimumTTL
+		}
+	}
+	return state
+}
+
+func (h *Handler) ReportZoneStateConflict(zone provider.DNSHostedZone, err error) bool {
+	return h.cache.ReportZoneStateConflict(zone, err)
+}
+
+func (h *Handler) ExecuteRequests(logger logger.LogContext, zone provider.DNSHostedZone, state provider.DNSZoneState, reqs []*provider.ChangeRequest) error {
+	err := h.executeRequests(logger, zone, state, reqs)
+	h.cache.ApplyRequests(logger, err, zone, reqs)
+	return err
+}
+
+func (h *Handler) executeRequests(logger logger.LogContext, zone provider.DNSHostedZone, state provider.DNSZoneState, reqs []*provider.ChangeRequest) error {
+	var valid []*provider.ChangeRequest
+	for _, req := range reqs {
+		if err := validateRequest(zone, req); err != nil {
+			logger.Warnf("%s %s record set [%s]: %s", req.Action, req.Type, zone.Id(), err)
+			if req.Done != nil {
+				req.Done.SetInvalid(err)
+			}
+			continue
+		}
+		valid = append(valid, req)
+	}
+	return raw.ExecuteRequests(logger, &h.config, h.client, zone, state, valid)
+}
+
+// validateRequest checks the constraints of Hetzner DNS for records to be created or updated.
+func validateRequest(zone provider.DNSHostedZone, req *provider.ChangeRequest) error {
+	if req.Addition == nil || req.Action == provider.R_DELETE {
+		return nil
+	}
+	name, rset := dns.MapToProvider(req.Type, req.Addition, zone.Domain())
+	if rset == nil || name.DNSName != zone.Domain() {
+		return nil
+	}
+	switch rset.Type {
+	case dns.RS_CNAME:
+		return fmt.Errorf("CNAME record not allowed at zone apex %s", zone.Domain())
+	case dns.RS_NS:
+		return fmt.Errorf("NS records at zone apex %s are managed by Hetzner DNS", zone.Domain())
+	}
+	return nil
+}
+
+// defaultTTL returns the default TTL of the zone as read from the zone list.
+func (h *Handler) defaultTTL(zoneID string) int64 {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	return h.defaultTTLs[zoneID]
+}
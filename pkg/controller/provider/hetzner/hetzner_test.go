@@ -0,0 +1,166 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package hetzner
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+
+	"github.com/gardener/external-dns-management/pkg/controller/provider/testutils"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+)
+
+func newTestClient(url string) *client {
+	return newClient(testutils.ClientArgs(url))
+}
+
+func newTestZone() provider.DNSHostedZone {
+	return provider.NewDNSHostedZone(TYPE_CODE, "z1", "example.com", "z1", false)
+}
+
+func TestListZonesPaginated(t *testing.T) {
+	serverURL := testutils.NewServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Auth-API-Token") != testutils.Token {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		page := r.URL.Query().Get("page")
+		_, _ = fmt.Fprintf(w, `{"zones":[{"id":"z%s","name":"example%s.com","ttl":86400}],"meta":{"pagination":{"page":%s,"per_page":100,"last_page":2}}}`, page, page, page)
+	})
+	c := newTestClient(serverURL)
+
+	zones, err := c.ListZones()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(zones) != 2 || zones[0].ID != "z1" || zones[1].Name != "example2.com" || zones[1].TTL != 86400 {
+		t.Errorf("unexpected zones: %v", zones)
+	}
+}
+
+func TestListRecords(t *testing.T) {
+	serverURL := testutils.NewServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("zone_id") != "z1" {
+			t.Errorf("unexpected zone %s", r.URL.Query().Get("zone_id"))
+		}
+		_, _ = io.WriteString(w, `{"records":[
+{"id":"1","zone_id":"z1","type":"A","name":"@","value":"1.1.1.1","ttl":300},
+{"id":"2","zone_id":"z1","type":"CNAME","name":"www","value":"target.example.org."},
+{"id":"3","zone_id":"z1","type":"CNAME","name":"alias","value":"www"},
+{"id":"4","zone_id":"z1","type":"TXT","name":"txt","value":"foo"},
+{"id":"5","zone_id":"z1","type":"TXT","name":"long","value":"\"abc\" \"def\""}
+],"meta":{"pagination":{"page":1,"per_page":100,"last_page":1}}}`)
+	})
+	c := newTestClient(serverURL)
+
+	records, err := c.ListRecords(newTestZone(), 3600)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := []struct {
+		name, value string
+		ttl         int64
+	}{
+		{"example.com", "1.1.1.1", 300},
+		{"www.example.com", "target.example.org", 3600},
+		{"alias.example.com", "www.example.com", 3600},
+		{"txt.example.com", `"foo"`, 3600},
+		{"long.example.com", `"abc" "def"`, 3600},
+	}
+	if len(records) != len(expected) {
+		t.Fatalf("unexpected records: %v", records)
+	}
+	for i, e := range expected {
+		r := records[i]
+		if r.GetDNSName() != e.name || r.GetValue() != e.value || r.GetTTL() != e.ttl {
+			t.Errorf("record %d: expected %s %s %d, but got %s %s %d", i, e.name, e.value, e.ttl, r.GetDNSName(), r.GetValue(), r.GetTTL())
+		}
+	}
+}
+
+func TestThrottled(t *testing.T) {
+	serverURL := testutils.NewServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	c := newTestClient(serverURL)
+
+	_, err := c.ListZones()
+	if !perrs.IsThrottlingError(err) {
+		t.Errorf("expected throttling error, but got %v", err)
+	}
+}
+
+func TestNewRecord(t *testing.T) {
+	c := newTestClient("")
+	zone := newTestZone()
+
+	r := c.NewRecord("example.com", dns.RS_A, "1.1.1.1", zone, 30).(*Record)
+	if r.Name != apexName || r.ZoneID != "z1" || r.GetTTL() != minimumTTL {
+		t.Errorf("unexpected record %v", r)
+	}
+	r = c.NewRecord("*.a.example.com", dns.RS_CNAME, "target.example.org", zone, 600).(*Record)
+	if r.Name != "*.a" || r.Value != "target.example.org." || r.GetTTL() != 600 || r.GetValue() != "target.example.org" {
+		t.Errorf("unexpected record %v", r)
+	}
+}
+
+func TestExecuteRequests(t *testing.T) {
+	var lock sync.Mutex
+	var requests []string
+	var created []Record
+	serverURL := testutils.NewServer(t, func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.Method == http.MethodPost {
+			var record Record
+			_ = json.NewDecoder(r.Body).Decode(&record)
+			created = append(created, record)
+		}
+		_, _ = io.WriteString(w, `{}`)
+	})
+	c := newTestClient(serverURL)
+
+	zone := newTestZone()
+	h := &Handler{client: c}
+	ttl := int64(3600)
+	state := newZoneState([]*Record{
+		{ID: "1", ZoneID: "z1", Type: dns.RS_A, Name: "a", Value: "1.1.1.1", TTL: &ttl, domain: "example.com"},
+		{ID: "2", ZoneID: "z1", Type: dns.RS_A, Name: "b", Value: "2.2.2.2", TTL: &ttl, domain: "example.com"},
+	})
+
+	apex := &testutils.DoneHandler{}
+	update := &testutils.DoneHandler{}
+	reqs := []*provider.ChangeRequest{
+		provider.NewChangeRequest(provider.R_CREATE, dns.RS_CNAME, nil, testutils.NewDNSSet("example.com", dns.RS_CNAME, 300, "target.example.org"), apex),
+		provider.NewChangeRequest(provider.R_UPDATE, dns.RS_A, testutils.NewDNSSet("a.example.com", dns.RS_A, 3600, "1.1.1.1"), testutils.NewDNSSet("a.example.com", dns.RS_A, 3600, "3.3.3.3"), update),
+		provider.NewChangeRequest(provider.R_DELETE, dns.RS_A, testutils.NewDNSSet("b.example.com", dns.RS_A, 3600, "2.2.2.2"), nil, nil),
+	}
+	if err := h.executeRequests(logger.New(), zone, state, reqs); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if apex.Invalid == nil {
+		t.Errorf("expected CNAME at zone apex to be invalid")
+	}
+	if !update.Success {
+		t.Errorf("expected update to succeed")
+	}
+	expected := []string{"POST /records", "DELETE /records/1", "DELETE /records/2"}
+	if fmt.Sprint(requests) != fmt.Sprint(expected) {
+		t.Errorf("expected requests %v, but got %v", expected, requests)
+	}
+	if len(created) != 1 || created[0].Name != "a" || created[0].Value != "3.3.3.3" || *created[0].TTL != 3600 {
+		t.Errorf("unexpected created records %v", created)
+	}
+}
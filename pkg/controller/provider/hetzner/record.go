@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package hetzner
+
+import (
+	"strings"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/raw"
+)
+
+const (
+	// apexName is the record name used by Hetzner for the zone apex.
+	apexName = "@"
+	// minimumTTL is the lowest TTL written to Hetzner DNS, lower TTLs are raised to this value.
+	minimumTTL = 60
+)
+
+// Record is a record of a Hetzner DNS zone.
+// The name is relative to the zone, a record without TTL uses the default TTL of the zone.
+type Record struct {
+	ID     string `json:"id,omitempty"`
+	ZoneID string `json:"zone_id"`
+	Type   string `json:"type"`
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	TTL    *int64 `json:"ttl,omitempty"`
+
+	domain     string
+	defaultTTL int64
+}
+
+var _ raw.Record = &Record{}
+
+func (r *Record) GetType() string          { return r.Type }
+func (r *Record) GetId() string            { return r.ID }
+func (r *Record) GetSetIdentifier() string { return "" }
+
+func (r *Record) GetDNSName() string {
+	if r.Name == apexName || r.Name == "" {
+		return r.domain
+	}
+	return r.Name + "." + r.domain
+}
+
+func (r *Record) GetValue() string {
+	switch r.Type {
+	case dns.RS_CNAME, dns.RS_NS:
+		return dns.NormalizeHostname(absoluteName(r.Value, r.domain))
+	case dns.RS_SRV:
+		return dns.NormalizeSRVValue(r.Value)
+	case dns.RS_CAA:
+		return dns.NormalizeCAAValue(r.Value)
+	case dns.RS_TXT:
+		// long texts are stored as multiple quoted character strings
+		if strings.HasPrefix(r.Value, `"`) {
+			return r.Value
+		}
+		return raw.EnsureQuotedText(r.Value)
+	}
+	return r.Value
+}
+
+func (r *Record) GetTTL() int64 {
+	if r.TTL == nil {
+		return r.defaultTTL
+	}
+	return *r.TTL
+}
+
+func (r *Record) SetTTL(ttl int64) {
+	if ttl < minimumTTL {
+		ttl = minimumTTL
+	}
+	r.TTL = &ttl
+}
+
+func (r *Record) Copy() raw.Record {
+	n := *r
+	if r.TTL != nil {
+		ttl := *r.TTL
+		n.TTL = &ttl
+	}
+	return &n
+}
+
+// recordName returns the record name relative to the domain of the zone.
+func recordName(dnsName, domain string) string {
+	if dnsName == domain {
+		return apexName
+	}
+	return strings.TrimSuffix(dnsName, "."+domain)
+}
+
+// recordValue returns the record value in the presentation format expected by Hetzner DNS.
+func recordValue(rtype, value string) string {
+	switch rtype {
+	case dns.RS_CNAME, dns.RS_NS:
+		return dns.AlignHostname(value)
+	case dns.RS_SRV:
+		return dns.AlignSRVValue(value)
+	}
+	return value
+}
+
+// absoluteName returns the absolute form of a host name given relative to the domain of the zone.
+func absoluteName(name, domain string) string {
+	switch {
+	case name == apexName:
+		return domain + "."
+	case strings.HasSuffix(name, "."):
+		return name
+	}
+	return name + "." + domain + "."
+}
@@ -88,6 +88,13 @@ func (this *access) NewRecord(fqdn string, rtype string, value string, _ provide
 		r.View = this.view
 		r.Ea = this.extattrs
 		record = (*RecordTXT)(r)
+	case dns.RS_PTR:
+		r := ibclient.NewEmptyRecordPTR()
+		r.Name = fqdn
+		r.PtrdName = value
+		r.View = this.view
+		r.Ea = this.extattrs
+		record = (*RecordPTR)(r)
 	}
 	if record != nil {
 		record.SetTTL(ttl)
@@ -11,7 +11,21 @@ import (
 
 const TYPE_CODE = "infoblox-dns"
 
-var Factory = provider.NewDNSHandlerFactory(TYPE_CODE, NewHandler)
+var Factory = provider.NewDNSHandlerFactory(TYPE_CODE, NewHandler).
+	SetSecretKeys(
+		provider.RequiredSecretKey("USERNAME", "username"),
+		provider.RequiredSecretKey("PASSWORD", "password"),
+		provider.OptionalSecretKey("VERSION", "version"),
+		provider.OptionalSecretKey("VIEW", "view"),
+		provider.OptionalSecretKey("HOST", "host"), // may be given in the provider config instead
+		provider.OptionalSecretKey("PORT", "port"),
+		provider.OptionalSecretKey("HTTP_POOL_CONNECTIONS", "http_pool_connections", "httpPoolConnections"),
+		provider.OptionalSecretKey("HTTP_REQUEST_TIMEOUT", "http_request_timeout", "httpRequestTimeout"),
+		provider.OptionalSecretKey("PROXY_URL", "proxy_url", "proxyUrl"),
+		provider.OptionalSecretKey("CA_CERT", "ca_cert", "caCert"),
+		provider.OptionalSecretKey("SSL_VERIFY", "ssl_verify", "sslVerify"),
+	).
+	SetProviderAttributes(provider.AnyProviderAttribute)
 
 func init() {
 	compound.MustRegister(Factory)
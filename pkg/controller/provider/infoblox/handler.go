@@ -9,6 +9,8 @@ import (
 	"crypto/x509"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"net"
 	"net/url"
 	"strconv"
 
@@ -43,7 +45,11 @@ type InfobloxConfig struct {
 	ExtAttrs        *map[string]string `json:"extAttrs,omitempty"`
 }
 
-var _ provider.DNSHandler = &Handler{}
+var (
+	_ provider.DNSHandler                   = &Handler{}
+	_ provider.ProviderAttributesNormalizer = &Handler{}
+	_ provider.CapabilitiesReporter         = &Handler{}
+)
 
 func NewHandler(config *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
 	infobloxConfig := &InfobloxConfig{}
@@ -170,7 +176,7 @@ func NewHandler(config *provider.DNSHandlerConfig) (provider.DNSHandler, error)
 // Infoblox does not support zone forwarding???
 // Just removed the forwarding stuff from code
 
-func (h *Handler) getZones(_ provider.ZoneCache) (provider.DNSHostedZones, error) {
+func (h *Handler) getZones(_ context.Context, _ provider.ZoneCache) (provider.DNSHostedZones, error) {
 	var raw []ibclient.ZoneAuth
 	h.config.Metrics.AddGenericRequests(provider.M_LISTZONES, 1)
 	obj := ibclient.NewZoneAuth(ibclient.ZoneAuth{})
@@ -187,7 +193,11 @@ func (h *Handler) getZones(_ provider.ZoneCache) (provider.DNSHostedZones, error
 			continue
 		}
 
-		hostedZone := provider.NewDNSHostedZone(h.ProviderType(), z.Ref, dns.NormalizeHostname(z.Fqdn), z.Fqdn, false)
+		domain, key := dns.NormalizeHostname(z.Fqdn), z.Fqdn
+		if reverse := reverseZoneDomain(z.Fqdn); reverse != "" {
+			domain, key = reverse, reverse
+		}
+		hostedZone := provider.NewDNSHostedZone(h.ProviderType(), z.Ref, domain, key, false)
 		zones = append(zones, hostedZone)
 	}
 	return zones, nil
@@ -239,6 +249,19 @@ func (h *Handler) getZoneState(zone provider.DNSHostedZone, _ provider.ZoneCache
 		state.AddRecord((&res).Copy())
 	}
 
+	// PTR records are only managed in reverse zones
+	if dns.IsReverseDomain(zone.Domain()) {
+		h.config.Metrics.AddZoneRequests(zone.Id().ID, rt, 1)
+		var resP []RecordPTR
+		err = h.access.GetObject(ibclient.NewEmptyRecordPTR(), "", params, &resP)
+		if filterNotFound(err) != nil {
+			return nil, fmt.Errorf("could not fetch PTR records from zone '%s': %s", zone.Key(), err)
+		}
+		for _, res := range resP {
+			state.AddRecord((&res).Copy())
+		}
+	}
+
 	state.CalculateDNSSets()
 	return state, nil
 }
@@ -249,6 +272,19 @@ func (h *Handler) ExecuteRequests(logger logger.LogContext, zone provider.DNSHos
 	return err
 }
 
+// NormalizeProviderAttributes adds the extensible attributes configured on provider level to the provider attributes
+// of an entry. The attributes of the entry override them for the same name.
+func (h *Handler) NormalizeProviderAttributes(attrs map[string]string) map[string]string {
+	normalized := eaToAttributes(h.access.extattrs)
+	maps.Copy(normalized, attrs)
+	return normalized
+}
+
+// Capabilities returns the optional record types supported by Infoblox.
+func (h *Handler) Capabilities() provider.Capabilities {
+	return provider.Capabilities{RecordTypes: []string{dns.RS_PTR}}
+}
+
 func (h *Handler) GetRecordSet(zone provider.DNSHostedZone, dnsName, recordType string) (provider.DedicatedRecordSet, error) {
 	rs, err := h.access.GetRecordSet(dnsName, recordType, zone)
 	if err != nil {
@@ -296,6 +332,18 @@ func (h *Handler) createCertPool(cert []byte) (*x509.CertPool, error) {
 	return caPool, nil
 }
 
+// reverseZoneDomain returns the reverse domain of a reverse zone or an empty string for forward zones.
+// Infoblox returns reverse zones with their network in CIDR notation, which is mapped to the
+// corresponding reverse domain (e.g. `1.168.192.in-addr.arpa` for `192.168.1.0/24`).
+func reverseZoneDomain(fqdn string) string {
+	if _, cidr, err := net.ParseCIDR(fqdn); err == nil {
+		if domain, err := dns.ReverseDomainForCIDR(cidr); err == nil {
+			return domain
+		}
+	}
+	return ""
+}
+
 func filterNotFound(err error) error {
 	if err == nil {
 		return nil
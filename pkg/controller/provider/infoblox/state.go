@@ -5,6 +5,9 @@
 package infoblox
 
 import (
+	"fmt"
+	"strconv"
+
 	"github.com/gardener/external-dns-management/pkg/dns/utils"
 	ibclient "github.com/infobloxopen/infoblox-go-client/v2"
 
@@ -19,14 +22,18 @@ type Record interface {
 
 type RecordA ibclient.RecordA
 
-func (r *RecordA) GetType() string          { return dns.RS_A }
-func (r *RecordA) GetId() string            { return r.Ref }
-func (r *RecordA) GetDNSName() string       { return r.Name }
-func (r *RecordA) GetSetIdentifier() string { return "" }
-func (r *RecordA) GetValue() string         { return r.Ipv4Addr }
-func (r *RecordA) GetTTL() int64            { return int64(r.Ttl) }
-func (r *RecordA) SetTTL(ttl int64)         { r.Ttl = utils.TTLToUint32(ttl); r.UseTtl = ttl != 0 }
-func (r *RecordA) Copy() raw.Record         { n := *r; return &n }
+func (r *RecordA) GetType() string                               { return dns.RS_A }
+func (r *RecordA) GetId() string                                 { return r.Ref }
+func (r *RecordA) GetDNSName() string                            { return r.Name }
+func (r *RecordA) GetSetIdentifier() string                      { return "" }
+func (r *RecordA) GetValue() string                              { return r.Ipv4Addr }
+func (r *RecordA) GetTTL() int64                                 { return int64(r.Ttl) }
+func (r *RecordA) SetTTL(ttl int64)                              { r.Ttl = utils.TTLToUint32(ttl); r.UseTtl = ttl != 0 }
+func (r *RecordA) Copy() raw.Record                              { n := *r; return &n }
+func (r *RecordA) GetComment() string                            { return r.Comment }
+func (r *RecordA) SetComment(c string)                           { r.Comment = c }
+func (r *RecordA) GetProviderAttributes() map[string]string      { return eaToAttributes(r.Ea) }
+func (r *RecordA) SetProviderAttributes(attrs map[string]string) { r.Ea = attributesToEA(r.Ea, attrs) }
 func (r *RecordA) PrepareUpdate() raw.Record {
 	n := *r
 	n.Zone = ""
@@ -37,14 +44,20 @@ func (r *RecordA) PrepareUpdate() raw.Record {
 
 type RecordAAAA ibclient.RecordAAAA
 
-func (r *RecordAAAA) GetType() string          { return dns.RS_AAAA }
-func (r *RecordAAAA) GetId() string            { return r.Ref }
-func (r *RecordAAAA) GetDNSName() string       { return r.Name }
-func (r *RecordAAAA) GetSetIdentifier() string { return "" }
-func (r *RecordAAAA) GetValue() string         { return r.Ipv6Addr }
-func (r *RecordAAAA) GetTTL() int64            { return int64(r.Ttl) }
-func (r *RecordAAAA) SetTTL(ttl int64)         { r.Ttl = utils.TTLToUint32(ttl); r.UseTtl = ttl != 0 }
-func (r *RecordAAAA) Copy() raw.Record         { n := *r; return &n }
+func (r *RecordAAAA) GetType() string                          { return dns.RS_AAAA }
+func (r *RecordAAAA) GetId() string                            { return r.Ref }
+func (r *RecordAAAA) GetDNSName() string                       { return r.Name }
+func (r *RecordAAAA) GetSetIdentifier() string                 { return "" }
+func (r *RecordAAAA) GetValue() string                         { return r.Ipv6Addr }
+func (r *RecordAAAA) GetTTL() int64                            { return int64(r.Ttl) }
+func (r *RecordAAAA) SetTTL(ttl int64)                         { r.Ttl = utils.TTLToUint32(ttl); r.UseTtl = ttl != 0 }
+func (r *RecordAAAA) Copy() raw.Record                         { n := *r; return &n }
+func (r *RecordAAAA) GetComment() string                       { return r.Comment }
+func (r *RecordAAAA) SetComment(c string)                      { r.Comment = c }
+func (r *RecordAAAA) GetProviderAttributes() map[string]string { return eaToAttributes(r.Ea) }
+func (r *RecordAAAA) SetProviderAttributes(attrs map[string]string) {
+	r.Ea = attributesToEA(r.Ea, attrs)
+}
 func (r *RecordAAAA) PrepareUpdate() raw.Record {
 	n := *r
 	n.Zone = ""
@@ -55,33 +68,123 @@ func (r *RecordAAAA) PrepareUpdate() raw.Record {
 
 type RecordCNAME ibclient.RecordCNAME
 
-func (r *RecordCNAME) GetType() string           { return dns.RS_CNAME }
-func (r *RecordCNAME) GetId() string             { return r.Ref }
-func (r *RecordCNAME) GetDNSName() string        { return r.Name }
-func (r *RecordCNAME) GetSetIdentifier() string  { return "" }
-func (r *RecordCNAME) GetValue() string          { return r.Canonical }
-func (r *RecordCNAME) GetTTL() int64             { return int64(r.Ttl) }
-func (r *RecordCNAME) SetTTL(ttl int64)          { r.Ttl = utils.TTLToUint32(ttl); r.UseTtl = ttl != 0 }
-func (r *RecordCNAME) Copy() raw.Record          { n := *r; return &n }
+func (r *RecordCNAME) GetType() string                          { return dns.RS_CNAME }
+func (r *RecordCNAME) GetId() string                            { return r.Ref }
+func (r *RecordCNAME) GetDNSName() string                       { return r.Name }
+func (r *RecordCNAME) GetSetIdentifier() string                 { return "" }
+func (r *RecordCNAME) GetValue() string                         { return r.Canonical }
+func (r *RecordCNAME) GetTTL() int64                            { return int64(r.Ttl) }
+func (r *RecordCNAME) SetTTL(ttl int64)                         { r.Ttl = utils.TTLToUint32(ttl); r.UseTtl = ttl != 0 }
+func (r *RecordCNAME) Copy() raw.Record                         { n := *r; return &n }
+func (r *RecordCNAME) GetComment() string                       { return r.Comment }
+func (r *RecordCNAME) SetComment(c string)                      { r.Comment = c }
+func (r *RecordCNAME) GetProviderAttributes() map[string]string { return eaToAttributes(r.Ea) }
+func (r *RecordCNAME) SetProviderAttributes(attrs map[string]string) {
+	r.Ea = attributesToEA(r.Ea, attrs)
+}
 func (r *RecordCNAME) PrepareUpdate() raw.Record { n := *r; n.Zone = ""; n.View = ""; return &n }
 
 type RecordTXT ibclient.RecordTXT
 
-func (r *RecordTXT) GetType() string           { return dns.RS_TXT }
-func (r *RecordTXT) GetId() string             { return r.Ref }
-func (r *RecordTXT) GetDNSName() string        { return r.Name }
-func (r *RecordTXT) GetSetIdentifier() string  { return "" }
-func (r *RecordTXT) GetValue() string          { return raw.EnsureQuotedText(r.Text) }
-func (r *RecordTXT) GetTTL() int64             { return int64(r.Ttl) }
-func (r *RecordTXT) SetTTL(ttl int64)          { r.Ttl = utils.TTLToUint32(ttl); r.UseTtl = ttl != 0 }
-func (r *RecordTXT) Copy() raw.Record          { n := *r; return &n }
+func (r *RecordTXT) GetType() string                          { return dns.RS_TXT }
+func (r *RecordTXT) GetId() string                            { return r.Ref }
+func (r *RecordTXT) GetDNSName() string                       { return r.Name }
+func (r *RecordTXT) GetSetIdentifier() string                 { return "" }
+func (r *RecordTXT) GetValue() string                         { return raw.EnsureQuotedText(r.Text) }
+func (r *RecordTXT) GetTTL() int64                            { return int64(r.Ttl) }
+func (r *RecordTXT) SetTTL(ttl int64)                         { r.Ttl = utils.TTLToUint32(ttl); r.UseTtl = ttl != 0 }
+func (r *RecordTXT) Copy() raw.Record                         { n := *r; return &n }
+func (r *RecordTXT) GetComment() string                       { return r.Comment }
+func (r *RecordTXT) SetComment(c string)                      { r.Comment = c }
+func (r *RecordTXT) GetProviderAttributes() map[string]string { return eaToAttributes(r.Ea) }
+func (r *RecordTXT) SetProviderAttributes(attrs map[string]string) {
+	r.Ea = attributesToEA(r.Ea, attrs)
+}
 func (r *RecordTXT) PrepareUpdate() raw.Record { n := *r; n.Zone = ""; n.View = ""; return &n }
 
+type RecordPTR ibclient.RecordPTR
+
+func (r *RecordPTR) GetType() string                          { return dns.RS_PTR }
+func (r *RecordPTR) GetId() string                            { return r.Ref }
+func (r *RecordPTR) GetDNSName() string                       { return r.Name }
+func (r *RecordPTR) GetSetIdentifier() string                 { return "" }
+func (r *RecordPTR) GetValue() string                         { return r.PtrdName }
+func (r *RecordPTR) GetTTL() int64                            { return int64(r.Ttl) }
+func (r *RecordPTR) SetTTL(ttl int64)                         { r.Ttl = utils.TTLToUint32(ttl); r.UseTtl = ttl != 0 }
+func (r *RecordPTR) Copy() raw.Record                         { n := *r; return &n }
+func (r *RecordPTR) GetComment() string                       { return r.Comment }
+func (r *RecordPTR) SetComment(c string)                      { r.Comment = c }
+func (r *RecordPTR) GetProviderAttributes() map[string]string { return eaToAttributes(r.Ea) }
+func (r *RecordPTR) SetProviderAttributes(attrs map[string]string) {
+	r.Ea = attributesToEA(r.Ea, attrs)
+}
+func (r *RecordPTR) PrepareUpdate() raw.Record {
+	n := *r
+	// the address is derived from the reverse name
+	n.Ipv4Addr = ""
+	n.Ipv6Addr = ""
+	n.Zone = ""
+	n.View = ""
+	return &n
+}
+
 var (
 	_ raw.Record = (*RecordA)(nil)
 	_ raw.Record = (*RecordAAAA)(nil)
 	_ raw.Record = (*RecordCNAME)(nil)
 	_ raw.Record = (*RecordTXT)(nil)
+	_ raw.Record = (*RecordPTR)(nil)
+
+	_ raw.CommentRecord = (*RecordA)(nil)
+	_ raw.CommentRecord = (*RecordAAAA)(nil)
+	_ raw.CommentRecord = (*RecordCNAME)(nil)
+	_ raw.CommentRecord = (*RecordTXT)(nil)
+	_ raw.CommentRecord = (*RecordPTR)(nil)
+
+	_ raw.AttributesRecord = (*RecordA)(nil)
+	_ raw.AttributesRecord = (*RecordAAAA)(nil)
+	_ raw.AttributesRecord = (*RecordCNAME)(nil)
+	_ raw.AttributesRecord = (*RecordTXT)(nil)
+	_ raw.AttributesRecord = (*RecordPTR)(nil)
 )
 
+// eaToAttributes converts the extensible attributes of a record to provider attributes.
+func eaToAttributes(ea ibclient.EA) map[string]string {
+	attrs := map[string]string{}
+	for k, v := range ea {
+		switch b := v.(type) {
+		case ibclient.Bool:
+			if b {
+				attrs[k] = "True"
+			} else {
+				attrs[k] = "False"
+			}
+		default:
+			attrs[k] = fmt.Sprint(v)
+		}
+	}
+	return attrs
+}
+
+// attributesToEA returns the extensible attributes for the given provider attributes replacing all attributes of the record.
+// Infoblox rejects values not matching the type of the attribute definition. Therefore, values are sent as integers if
+// the current value of the attribute is an integer or, for attributes not set yet, if the value is a decimal integer.
+func attributesToEA(ea ibclient.EA, attrs map[string]string) ibclient.EA {
+	result := ibclient.EA{}
+	for k, v := range attrs {
+		result[k] = eaValue(ea[k], v)
+	}
+	return result
+}
+
+func eaValue(current interface{}, value string) interface{} {
+	switch current.(type) {
+	case nil, int:
+		if i, err := strconv.Atoi(value); err == nil && (current != nil || strconv.Itoa(i) == value) {
+			return i
+		}
+	}
+	return value
+}
+
 type RecordNS ibclient.RecordNS
@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package infoblox
+
+import (
+	"testing"
+
+	ibclient "github.com/infobloxopen/infoblox-go-client/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestSetProviderAttributesRemovesDroppedAttributes(t *testing.T) {
+	RegisterTestingT(t)
+
+	r := &RecordA{Ea: ibclient.EA{"Site": "berlin", "Tenant": "a"}}
+	r.SetProviderAttributes(map[string]string{"Site": "paris"})
+
+	Expect(r.Ea).To(Equal(ibclient.EA{"Site": "paris"}))
+	Expect(r.GetProviderAttributes()).To(Equal(map[string]string{"Site": "paris"}))
+}
+
+func TestSetProviderAttributesKeepsAttributeTypes(t *testing.T) {
+	RegisterTestingT(t)
+
+	r := &RecordTXT{Ea: ibclient.EA{"Count": 1, "Code": "007", "Flag": ibclient.Bool(true)}}
+	r.SetProviderAttributes(map[string]string{"Count": "2", "Code": "008", "Flag": "False", "Port": "8080", "Id": "0815"})
+
+	Expect(r.Ea).To(Equal(ibclient.EA{"Count": 2, "Code": "008", "Flag": "False", "Port": 8080, "Id": "0815"}))
+}
+
+func TestNormalizeProviderAttributes(t *testing.T) {
+	RegisterTestingT(t)
+
+	h := &Handler{access: &access{extattrs: ibclient.EA{"Owner": "dns", "Site": "berlin"}}}
+
+	Expect(h.NormalizeProviderAttributes(nil)).To(Equal(map[string]string{"Owner": "dns", "Site": "berlin"}))
+	Expect(h.NormalizeProviderAttributes(map[string]string{"Site": "paris", "Tenant": "a"})).
+		To(Equal(map[string]string{"Owner": "dns", "Site": "paris", "Tenant": "a"}))
+}
@@ -5,16 +5,20 @@
 package mock
 
 import (
+	"context"
+
 	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/gardener/controller-manager-library/pkg/logger"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/flowcontrol"
 
 	"github.com/gardener/external-dns-management/pkg/dns"
 	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
 )
 
 type Handler struct {
@@ -29,6 +33,8 @@ type Handler struct {
 type MockZone struct {
 	ZonePrefix string `json:"zonePrefix"`
 	DNSName    string `json:"dnsName"`
+	// Tags models tags of hosted zones used for the zone selection.
+	Tags map[string]string `json:"tags,omitempty"`
 }
 
 func (m MockZone) ZoneID() dns.ZoneID {
@@ -41,9 +47,23 @@ type MockConfig struct {
 	FailGetZones    bool       `json:"failGetZones"`
 	FailDeleteEntry bool       `json:"failDeleteEntry"`
 	LatencyMillis   int        `json:"latencyMillis"`
+	// TXTChunkSize models providers storing long texts split into multiple character strings (unchunked if zero).
+	TXTChunkSize int `json:"txtChunkSize"`
+	// TXTMaxLength models the maximum text length of a provider (unlimited if zero).
+	TXTMaxLength int `json:"txtMaxLength"`
+	// MinTTL models providers rejecting TTLs below a minimum (no minimum if zero).
+	MinTTL int64 `json:"minTTL"`
+	// PropagationDelay models providers making changes visible to reads only after a delay (immediately if not set).
+	PropagationDelay *metav1.Duration `json:"propagationDelay,omitempty"`
 }
 
-var _ provider.DNSHandler = &Handler{}
+var (
+	_ provider.DNSHandler              = &Handler{}
+	_ provider.TXTRecordLimiter        = &Handler{}
+	_ provider.RoutingPolicyNormalizer = &Handler{}
+	_ provider.TTLLimiter              = &Handler{}
+	_ provider.CapabilitiesReporter    = &Handler{}
+)
 
 // TestMock allows tests to access mocked DNSHosted Zones
 var TestMock = map[string]*provider.InMemory{}
@@ -64,13 +84,16 @@ func NewHandler(config *provider.DNSHandlerConfig) (provider.DNSHandler, error)
 	}
 
 	TestMock[h.mockConfig.Name] = mock
+	if h.mockConfig.PropagationDelay != nil {
+		mock.SetPropagationDelay(h.mockConfig.PropagationDelay.Duration)
+	}
 
 	for _, mockZone := range h.mockConfig.Zones {
 		if mockZone.DNSName != "" {
 			zoneID := mockZone.ZoneID().ID
 			logger.Infof("Providing mock DNSZone %s[%s]", mockZone.DNSName, zoneID)
 			isPrivate := strings.Contains(mockZone.ZonePrefix, ":private:")
-			hostedZone := provider.NewDNSHostedZone(h.ProviderType(), zoneID, mockZone.DNSName, "", isPrivate)
+			hostedZone := provider.NewDNSHostedZoneWithTags(h.ProviderType(), zoneID, mockZone.DNSName, "", isPrivate, mockZone.Tags)
 			mock.AddZone(hostedZone)
 		}
 	}
@@ -91,7 +114,7 @@ func (h *Handler) GetZones() (provider.DNSHostedZones, error) {
 	return h.cache.GetZones()
 }
 
-func (h *Handler) getZones(_ provider.ZoneCache) (provider.DNSHostedZones, error) {
+func (h *Handler) getZones(_ context.Context, _ provider.ZoneCache) (provider.DNSHostedZones, error) {
 	if h.mockConfig.FailGetZones {
 		return nil, fmt.Errorf("forced error by mockConfig.FailGetZones")
 	}
@@ -113,6 +136,42 @@ func (h *Handler) ReportZoneStateConflict(zone provider.DNSHostedZone, err error
 	return h.cache.ReportZoneStateConflict(zone, err)
 }
 
+// MapTargets maps CNAME targets with an explicitly requested alias hosted zone to alias targets
+// to model alias records like AWS Route53.
+func (h *Handler) MapTargets(_ string, targets []provider.Target) []provider.Target {
+	mapped := make([]provider.Target, 0, len(targets))
+	for _, t := range targets {
+		if t.GetRecordType() == dns.RS_CNAME && t.GetAliasHostedZone() != "" {
+			value := dns.AliasTargetValue(t.GetHostName(), t.GetAliasHostedZone())
+			mapped = append(mapped, dnsutils.NewTarget(dns.RS_ALIAS_A, value, t.GetTTL()))
+		} else {
+			mapped = append(mapped, t)
+		}
+	}
+	return mapped
+}
+
+// TXTRecordLimits returns the TXT record limits given by the mock config.
+func (h *Handler) TXTRecordLimits() dns.TXTRecordLimits {
+	return dns.TXTRecordLimits{ChunkSize: h.mockConfig.TXTChunkSize, MaxLength: h.mockConfig.TXTMaxLength}
+}
+
+// MinTTL returns the minimum TTL given by the mock config.
+func (h *Handler) MinTTL() int64 {
+	return h.mockConfig.MinTTL
+}
+
+// Capabilities returns all optional record types, as the mock supports any record type.
+func (h *Handler) Capabilities() provider.Capabilities {
+	return provider.Capabilities{RecordTypes: provider.OptionalRecordTypes.AsArray()}
+}
+
+// NormalizeRoutingPolicy maps the record type of failover routing policies to upper case
+// to model the record sets read back from AWS Route53.
+func (h *Handler) NormalizeRoutingPolicy(policy *dns.RoutingPolicy) *dns.RoutingPolicy {
+	return policy.NormalizeFailoverRecordType()
+}
+
 func (h *Handler) ExecuteRequests(logger logger.LogContext, zone provider.DNSHostedZone, state provider.DNSZoneState, reqs []*provider.ChangeRequest) error {
 	err := h.executeRequests(logger, zone, state, reqs)
 	h.cache.ApplyRequests(logger, err, zone, reqs)
@@ -129,7 +188,10 @@ func (h *Handler) executeRequests(logger logger.LogContext, zone provider.DNSHos
 		var err error
 		if h.mockConfig.FailDeleteEntry && r.Action == provider.R_DELETE {
 			err = fmt.Errorf("forced error by mockConfig.FailDeleteEntry")
-		} else {
+		} else if r.Addition != nil {
+			err = h.validateAddition(r.Addition)
+		}
+		if err == nil {
 			err = h.mock.Apply(zone.Id(), r, h.config.Metrics)
 		}
 		if err != nil {
@@ -155,3 +217,22 @@ func (h *Handler) executeRequests(logger logger.LogContext, zone provider.DNSHos
 
 	return nil
 }
+
+// validateAddition models the rejection of record sets by the DNS backend.
+func (h *Handler) validateAddition(set *dns.DNSSet) error {
+	for _, rs := range set.Sets {
+		if rs.TTL < h.mockConfig.MinTTL {
+			return fmt.Errorf("TTL %d of %s record set %s is below the minimum TTL %d", rs.TTL, rs.Type, set.Name, h.mockConfig.MinTTL)
+		}
+	}
+	return validateFailoverPolicy(set.RoutingPolicy)
+}
+
+// validateFailoverPolicy models the validation of failover routing policies by AWS Route53.
+func validateFailoverPolicy(policy *dns.RoutingPolicy) error {
+	_, failover, err := policy.FailoverRecordType()
+	if err != nil || !failover {
+		return err
+	}
+	return policy.ValidateHealthCheckID()
+}
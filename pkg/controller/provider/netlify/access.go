@@ -96,8 +96,9 @@ func (this *access) CreateRecord(r raw.Record, zone provider.DNSHostedZone) erro
 }
 
 func (this *access) UpdateRecord(r raw.Record, zone provider.DNSHostedZone) error {
-	// Netlify does not support updating a record
-	// Delete the existing record and re-create it
+	// Netlify does not support updating a record.
+	// Updates only change the TTL of a record with an unchanged value, so the existing record must be deleted
+	// before it can be re-created. Records with changed values are created before the obsolete ones are deleted.
 	err := this.DeleteRecord(r, zone)
 	if err != nil {
 		return err
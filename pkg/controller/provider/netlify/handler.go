@@ -5,7 +5,10 @@
 package netlify
 
 import (
+	"context"
+
 	"strings"
+	"sync"
 
 	"github.com/gardener/controller-manager-library/pkg/logger"
 	"github.com/netlify/open-api/go/models"
@@ -20,9 +23,15 @@ type Handler struct {
 	config provider.DNSHandlerConfig
 	cache  provider.ZoneCache
 	access Access
+
+	lock        sync.Mutex
+	deniedZones []string
 }
 
-var _ provider.DNSHandler = &Handler{}
+var (
+	_ provider.DNSHandler          = &Handler{}
+	_ provider.DeniedZonesReporter = &Handler{}
+)
 
 func NewHandler(c *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
 	var err error
@@ -60,7 +69,7 @@ func (h *Handler) GetZones() (provider.DNSHostedZones, error) {
 	return h.cache.GetZones()
 }
 
-func (h *Handler) getZones(_ provider.ZoneCache) (provider.DNSHostedZones, error) {
+func (h *Handler) getZones(_ context.Context, _ provider.ZoneCache) (provider.DNSHostedZones, error) {
 	blockedZones := h.config.Options.AdvancedOptions.GetBlockedZones()
 	rawZones := []models.DNSZone{}
 	{
@@ -79,6 +88,7 @@ func (h *Handler) getZones(_ provider.ZoneCache) (provider.DNSHostedZones, error
 	}
 
 	zones := provider.DNSHostedZones{}
+	deniedZones := []string{}
 
 	for _, z := range rawZones {
 		forwarded := []string{}
@@ -96,6 +106,7 @@ func (h *Handler) getZones(_ provider.ZoneCache) (provider.DNSHostedZones, error
 			if checkAccessForbidden(err) {
 				// It is possible to deny access to certain zones in the account
 				// As a result, z zone should not be appended to the hosted zones
+				deniedZones = append(deniedZones, z.ID)
 				continue
 			}
 			return nil, err
@@ -104,9 +115,19 @@ func (h *Handler) getZones(_ provider.ZoneCache) (provider.DNSHostedZones, error
 		zones = append(zones, hostedZone)
 	}
 
+	h.lock.Lock()
+	h.deniedZones = deniedZones
+	h.lock.Unlock()
 	return zones, nil
 }
 
+// DeniedZones returns the ids of the zones skipped on the last listing of the zones as access is forbidden.
+func (h *Handler) DeniedZones() []string {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	return h.deniedZones
+}
+
 func (h *Handler) GetZoneState(zone provider.DNSHostedZone) (provider.DNSZoneState, error) {
 	return h.cache.GetZoneState(zone)
 }
@@ -156,19 +177,40 @@ func (h *Handler) GetRecordSet(zone provider.DNSHostedZone, dnsName, recordType
 	return d, nil
 }
 
+// CreateOrUpdateRecordSet reconciles the records by value. Records with new values are created before the records
+// with obsolete values are deleted to avoid a resolution gap. As Netlify does not support updating a record,
+// records only changing the TTL are deleted before they are re-created.
 func (h *Handler) CreateOrUpdateRecordSet(logger logger.LogContext, zone provider.DNSHostedZone, old, new provider.DedicatedRecordSet) error {
-	err := h.DeleteRecordSet(logger, zone, old)
-	if err != nil {
-		return err
+	existing := map[string]provider.DedicatedRecord{}
+	for _, r := range old {
+		existing[recordKey(r)] = r
 	}
 	for _, r := range new {
-		r0 := h.access.NewRecord(r.GetDNSName(), r.GetType(), r.GetValue(), zone, int64(r.GetTTL()))
-		err = h.access.CreateRecord(r0, zone)
-		if err != nil {
+		if o := existing[recordKey(r)]; o != nil {
+			delete(existing, recordKey(r))
+			if o.GetTTL() == r.GetTTL() {
+				continue
+			}
+			if err := h.DeleteRecordSet(logger, zone, provider.DedicatedRecordSet{o}); err != nil {
+				return err
+			}
+		}
+		r0 := h.access.NewRecord(r.GetDNSName(), r.GetType(), r.GetValue(), zone, r.GetTTL())
+		if err := h.access.CreateRecord(r0, zone); err != nil {
 			return err
 		}
 	}
-	return err
+	obsolete := provider.DedicatedRecordSet{}
+	for _, r := range old {
+		if existing[recordKey(r)] != nil {
+			obsolete = append(obsolete, r)
+		}
+	}
+	return h.DeleteRecordSet(logger, zone, obsolete)
+}
+
+func recordKey(r provider.DedicatedRecord) string {
+	return r.GetType() + " " + r.GetValue()
 }
 
 func (h *Handler) DeleteRecordSet(_ logger.LogContext, zone provider.DNSHostedZone, rs provider.DedicatedRecordSet) error {
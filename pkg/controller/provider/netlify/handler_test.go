@@ -0,0 +1,145 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package netlify
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/go-openapi/runtime"
+	"github.com/netlify/open-api/go/models"
+	"github.com/netlify/open-api/go/plumbing/operations"
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/util/flowcontrol"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+)
+
+// mockClient records the calls modifying DNS records.
+type mockClient struct {
+	operations.ClientService
+	calls     []string
+	zones     models.DNSZones
+	forbidden map[string]bool
+}
+
+func (c *mockClient) GetDNSZones(_ *operations.GetDNSZonesParams, _ runtime.ClientAuthInfoWriter) (*operations.GetDNSZonesOK, error) {
+	return &operations.GetDNSZonesOK{Payload: c.zones}, nil
+}
+
+func (c *mockClient) GetDNSRecords(params *operations.GetDNSRecordsParams, _ runtime.ClientAuthInfoWriter) (*operations.GetDNSRecordsOK, error) {
+	if c.forbidden[params.ZoneID] {
+		return nil, fmt.Errorf("[GET /dns_zones/{zone_id}/dns_records][403] getDnsRecords default")
+	}
+	return &operations.GetDNSRecordsOK{}, nil
+}
+
+func (c *mockClient) CreateDNSRecord(params *operations.CreateDNSRecordParams, _ runtime.ClientAuthInfoWriter) (*operations.CreateDNSRecordCreated, error) {
+	r := params.DNSRecord
+	c.calls = append(c.calls, fmt.Sprintf("create %s %s %s %d", r.Hostname, r.Type, r.Value, r.TTL))
+	return &operations.CreateDNSRecordCreated{}, nil
+}
+
+func (c *mockClient) DeleteDNSRecord(params *operations.DeleteDNSRecordParams, _ runtime.ClientAuthInfoWriter) (*operations.DeleteDNSRecordNoContent, error) {
+	c.calls = append(c.calls, "delete "+params.DNSRecordID)
+	return &operations.DeleteDNSRecordNoContent{}, nil
+}
+
+func newTestHandler() (*Handler, *mockClient) {
+	client := &mockClient{}
+	h := &Handler{
+		DefaultDNSHandler: provider.NewDefaultDNSHandler(TYPE_CODE),
+		config: provider.DNSHandlerConfig{
+			Options: &provider.FactoryOptions{GenericFactoryOptions: provider.GenericFactoryOptionDefaults},
+		},
+		access: &access{
+			client:      client,
+			metrics:     &provider.NullMetrics{},
+			rateLimiter: flowcontrol.NewFakeAlwaysRateLimiter(),
+		},
+	}
+	return h, client
+}
+
+var testZone = provider.NewDNSHostedZone(TYPE_CODE, "zone1", "example.com", "", false)
+
+func existingRecord(id, value string, ttl int64) *Record {
+	return &Record{ID: id, DNSZoneID: "zone1", Hostname: "a.example.com", Type: dns.RS_A, Value: value, TTL: ttl}
+}
+
+func desiredRecords(ttl int64, values ...string) provider.DedicatedRecordSet {
+	rs := dns.NewRecordSet(dns.RS_A, ttl, nil)
+	for _, v := range values {
+		rs.Add(&dns.Record{Value: v})
+	}
+	return provider.FromDedicatedRecordSet(dns.DNSSetName{DNSName: "a.example.com"}, rs)
+}
+
+func TestUpdateRecordDeletesBeforeCreate(t *testing.T) {
+	RegisterTestingT(t)
+	h, client := newTestHandler()
+
+	Expect(h.access.UpdateRecord(existingRecord("id1", "1.1.1.1", 600), testZone)).To(Succeed())
+	Expect(client.calls).To(Equal([]string{
+		"delete id1",
+		"create a.example.com A 1.1.1.1 600",
+	}))
+}
+
+func TestCreateOrUpdateRecordSetByValue(t *testing.T) {
+	RegisterTestingT(t)
+	h, client := newTestHandler()
+
+	old := provider.DedicatedRecordSet{existingRecord("id1", "1.1.1.1", 300), existingRecord("id2", "1.1.1.2", 300)}
+	Expect(h.CreateOrUpdateRecordSet(logger.New(), testZone, old, desiredRecords(300, "1.1.1.1", "1.1.1.3"))).To(Succeed())
+	// the unchanged record is kept, the new record is created before the obsolete one is deleted
+	Expect(client.calls).To(Equal([]string{
+		"create a.example.com A 1.1.1.3 300",
+		"delete id2",
+	}))
+}
+
+func TestCreateOrUpdateRecordSetChangedTTL(t *testing.T) {
+	RegisterTestingT(t)
+	h, client := newTestHandler()
+
+	old := provider.DedicatedRecordSet{existingRecord("id1", "1.1.1.1", 300)}
+	Expect(h.CreateOrUpdateRecordSet(logger.New(), testZone, old, desiredRecords(600, "1.1.1.1"))).To(Succeed())
+	Expect(client.calls).To(Equal([]string{
+		"delete id1",
+		"create a.example.com A 1.1.1.1 600",
+	}))
+}
+
+func TestCreateOrUpdateRecordSetWithoutOld(t *testing.T) {
+	RegisterTestingT(t)
+	h, client := newTestHandler()
+
+	Expect(h.CreateOrUpdateRecordSet(logger.New(), testZone, nil, desiredRecords(300, "1.1.1.1"))).To(Succeed())
+	Expect(client.calls).To(Equal([]string{"create a.example.com A 1.1.1.1 300"}))
+}
+
+func TestGetZonesTracksDeniedZones(t *testing.T) {
+	RegisterTestingT(t)
+	h, client := newTestHandler()
+	client.zones = models.DNSZones{{ID: "zone1", Name: "example.com"}, {ID: "zone2", Name: "example.org"}}
+	client.forbidden = map[string]bool{"zone2": true}
+
+	zones, err := h.getZones(context.Background(), nil)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(zones).To(HaveLen(1))
+	Expect(zones[0].Id().ID).To(Equal("zone1"))
+	Expect(h.DeniedZones()).To(Equal([]string{"zone2"}))
+
+	// access has been granted in the meantime
+	client.forbidden = nil
+	zones, err = h.getZones(context.Background(), nil)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(zones).To(HaveLen(2))
+	Expect(h.DeniedZones()).To(BeEmpty())
+}
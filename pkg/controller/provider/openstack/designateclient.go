@@ -7,6 +7,7 @@ package openstack
 import (
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
@@ -27,6 +28,10 @@ type designateClientInterface interface {
 	// ForEachZone calls handler for each zone managed by the Designate
 	ForEachZone(handler func(zone *zones.Zone) error) error
 
+	// ForEachSharedZone calls handler for each zone shared with the project by another project.
+	// It returns errSharedZonesUnsupported if the Designate API does not support zone sharing.
+	ForEachSharedZone(handler func(zone *zones.Zone) error) error
+
 	// ForEachRecordSet calls handler for each recordset in the given DNS zone
 	ForEachRecordSet(zoneID string, handler func(recordSet *recordsets.RecordSet) error) error
 
@@ -43,6 +48,23 @@ type designateClientInterface interface {
 	DeleteRecordSet(zoneID, recordSetID string) error
 }
 
+// errSharedZonesUnsupported is returned if the Designate API does not support zone sharing (before 2023.1)
+var errSharedZonesUnsupported = errors.New("shared zones not supported by Designate API")
+
+// sharedZoneListOpts restricts the zone list to zones shared with the project
+type sharedZoneListOpts struct {
+	zones.ListOpts
+	Shared bool `q:"shared"`
+}
+
+func (opts sharedZoneListOpts) ToZoneListQuery() (string, error) {
+	q, err := gophercloud.BuildQueryString(opts)
+	if err != nil {
+		return "", err
+	}
+	return q.String(), nil
+}
+
 // implementation of the designateClientInterface
 type designateClient struct {
 	serviceClient *gophercloud.ServiceClient
@@ -156,6 +178,35 @@ func (c designateClient) ForEachZone(handler func(zone *zones.Zone) error) error
 	)
 }
 
+// ForEachSharedZone calls handler for each zone shared with the project by another project
+func (c designateClient) ForEachSharedZone(handler func(zone *zones.Zone) error) error {
+	pager := zones.List(c.serviceClient, sharedZoneListOpts{Shared: true})
+	rt := provider.M_LISTZONES
+	err := pager.EachPage(
+		func(page pagination.Page) (bool, error) {
+			c.metrics.AddGenericRequests(rt, 1)
+			rt = provider.M_PLISTZONES
+			list, err := zones.ExtractZones(page)
+			if err != nil {
+				return false, err
+			}
+			for _, zone := range list {
+				err := handler(&zone)
+				if err != nil {
+					return false, err
+				}
+			}
+			return true, nil
+		},
+	)
+	var badRequest gophercloud.ErrDefault400
+	var notFound gophercloud.ErrDefault404
+	if errors.As(err, &badRequest) || errors.As(err, &notFound) {
+		return errSharedZonesUnsupported
+	}
+	return err
+}
+
 // ForEachRecordSet calls handler for each recordset in the given DNS zone
 func (c designateClient) ForEachRecordSet(zoneID string, handler func(recordSet *recordsets.RecordSet) error) error {
 	return c.ForEachRecordSetFilterByTypeAndName(zoneID, "", "", handler)
@@ -72,7 +72,7 @@ func (exec *Execution) buildMappedRecordSet(name string, rset *dns.RecordSet) (b
 	for _, r := range rset.Records {
 		value := r.Value
 		if rset.Type == dns.RS_CNAME {
-			value = dns.AlignHostname(value)
+			value = nameForm.Hostname(value)
 		}
 		osRSet.Records = append(osRSet.Records, value)
 	}
@@ -95,7 +95,7 @@ func (exec *Execution) apply(action string, rset *recordsets.RecordSet) error {
 
 func (exec *Execution) create(rset *recordsets.RecordSet) error {
 	opts := recordsets.CreateOpts{
-		Name:    dns.AlignHostname(rset.Name),
+		Name:    nameForm.Hostname(rset.Name),
 		Type:    rset.Type,
 		TTL:     rset.TTL,
 		Records: rset.Records,
@@ -106,10 +106,10 @@ func (exec *Execution) create(rset *recordsets.RecordSet) error {
 }
 
 func (exec *Execution) lookupRecordSetID(rset *recordsets.RecordSet) (string, error) {
-	name := dns.AlignHostname(rset.Name)
+	name := nameForm.Hostname(rset.Name)
 	recordSetID := ""
 	handler := func(recordSet *recordsets.RecordSet) error {
-		if recordSet.Name == name {
+		if dns.CanonicalHostname(recordSet.Name) == dns.CanonicalHostname(name) {
 			recordSetID = recordSet.ID
 		}
 		return nil
@@ -5,6 +5,8 @@
 package openstack
 
 import (
+	"github.com/gardener/controller-manager-library/pkg/utils"
+
 	"github.com/gardener/external-dns-management/pkg/controller/provider/compound"
 	"github.com/gardener/external-dns-management/pkg/dns/provider"
 )
@@ -18,7 +20,32 @@ var rateLimiterDefaults = provider.RateLimiterOptions{
 }
 
 var Factory = provider.NewDNSHandlerFactory(TYPE_CODE, NewHandler).
-	SetGenericFactoryOptionDefaults(provider.GenericFactoryOptionDefaults.SetRateLimiterOptions(rateLimiterDefaults))
+	SetGenericFactoryOptionDefaults(provider.GenericFactoryOptionDefaults.SetRateLimiterOptions(rateLimiterDefaults)).
+	SetSecretKeys(
+		provider.RequiredSecretKey("OS_AUTH_URL"),
+		provider.OptionalSecretKey("OS_APPLICATION_CREDENTIAL_ID", "applicationCredentialID"),
+		provider.OptionalSecretKey("OS_APPLICATION_CREDENTIAL_NAME", "applicationCredentialName"),
+		provider.OptionalSecretKey("OS_APPLICATION_CREDENTIAL_SECRET", "applicationCredentialSecret"),
+		provider.RequiredSecretKey("OS_USERNAME", "username").If(withoutApplicationCredentials),
+		provider.RequiredSecretKey("OS_PASSWORD", "password").If(withoutApplicationCredentials),
+		provider.OptionalSecretKey("OS_DOMAIN_NAME", "domainName"),
+		provider.OptionalSecretKey("OS_DOMAIN_ID", "domainID"),
+		provider.OptionalSecretKey("OS_PROJECT_NAME", "tenantName"),
+		provider.OptionalSecretKey("OS_PROJECT_ID", "tenantID"),
+		provider.OptionalSecretKey("OS_USER_DOMAIN_NAME", "userDomainName"),
+		provider.OptionalSecretKey("OS_USER_DOMAIN_ID", "userDomainID"),
+		provider.OptionalSecretKey("OS_REGION_NAME"),
+		provider.OptionalSecretKey("CACERT", "caCert"),
+		provider.OptionalSecretKey("CLIENTCERT", "clientCert"),
+		provider.OptionalSecretKey("CLIENTKEY", "clientKey"),
+		provider.OptionalSecretKey("INSECURE", "insecure"),
+	)
+
+// withoutApplicationCredentials checks if the secret uses username and password instead of application credentials.
+func withoutApplicationCredentials(props utils.Properties) bool {
+	return provider.IsNotGiven("OS_APPLICATION_CREDENTIAL_ID", "applicationCredentialID")(props) &&
+		provider.IsNotGiven("OS_APPLICATION_CREDENTIAL_NAME", "applicationCredentialName")(props)
+}
 
 func init() {
 	compound.MustRegister(Factory)
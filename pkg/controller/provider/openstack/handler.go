@@ -6,6 +6,7 @@ package openstack
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/gophercloud/gophercloud/openstack/dns/v2/recordsets"
 	"github.com/gophercloud/gophercloud/openstack/dns/v2/zones"
 	"github.com/gophercloud/utils/openstack/clientconfig"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	"github.com/gardener/external-dns-management/pkg/dns"
 	"github.com/gardener/external-dns-management/pkg/dns/provider"
@@ -26,9 +28,18 @@ type Handler struct {
 	ctx    context.Context
 
 	client designateClientInterface
+
+	// sharedZonesUnsupported is set if the Designate API does not support zone sharing
+	sharedZonesUnsupported bool
 }
 
-var _ provider.DNSHandler = &Handler{}
+var (
+	_ provider.DNSHandler = &Handler{}
+	_ provider.NameFormer = &Handler{}
+)
+
+// nameForm is the canonical form of DNS names in OpenStack Designate: fully qualified with trailing dot.
+var nameForm = dns.NameForm{TrailingDot: true}
 
 // NewHandler constructs a new DNSHandler object.
 func NewHandler(config *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
@@ -129,6 +140,11 @@ func readAuthConfig(c *provider.DNSHandlerConfig) (*clientAuthConfig, error) {
 	return &authConfig, nil
 }
 
+// NameForm returns the form of DNS names written to OpenStack Designate.
+func (h *Handler) NameForm() dns.NameForm {
+	return nameForm
+}
+
 // Release releases the zone cache.
 func (h *Handler) Release() {
 	h.cache.Release()
@@ -139,11 +155,17 @@ func (h *Handler) GetZones() (provider.DNSHostedZones, error) {
 	return h.cache.GetZones()
 }
 
-func (h *Handler) getZones(_ provider.ZoneCache) (provider.DNSHostedZones, error) {
+func (h *Handler) getZones(_ context.Context, _ provider.ZoneCache) (provider.DNSHostedZones, error) {
 	blockedZones := h.config.Options.AdvancedOptions.GetBlockedZones()
 	hostedZones := provider.DNSHostedZones{}
+	zoneIDs := sets.New[string]()
 
 	zoneHandler := func(zone *zones.Zone) error {
+		if zoneIDs.Has(zone.ID) {
+			// zones shared with the project may also be listed as zones of the project
+			return nil
+		}
+		zoneIDs.Insert(zone.ID)
 		if blockedZones.Contains(zone.ID) {
 			h.config.Logger.Infof("ignoring blocked zone id: %s", zone.ID)
 			return nil
@@ -159,6 +181,18 @@ func (h *Handler) getZones(_ provider.ZoneCache) (provider.DNSHostedZones, error
 		return nil, fmt.Errorf("listing DNS zones failed. Details: %s", err.Error())
 	}
 
+	if !h.sharedZonesUnsupported {
+		h.config.RateLimiter.Accept()
+		if err := h.client.ForEachSharedZone(zoneHandler); err != nil {
+			if errors.Is(err, errSharedZonesUnsupported) {
+				h.config.Logger.Infof("ignoring shared zones: %s", err)
+				h.sharedZonesUnsupported = true
+			} else {
+				h.config.Logger.Warnf("listing shared DNS zones failed. Details: %s", err)
+			}
+		}
+	}
+
 	return hostedZones, nil
 }
 
@@ -5,6 +5,7 @@
 package openstack
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"strings"
@@ -63,6 +64,10 @@ func (tz *testzone) Match(dnsname string) int {
 
 type designateMockClient struct {
 	tzmap map[string]*testzone
+	// shared contains the zones shared with the project
+	shared                 map[string]*testzone
+	sharedZonesUnsupported bool
+	sharedZoneListings     int
 }
 
 var _ designateClientInterface = &designateMockClient{}
@@ -78,6 +83,19 @@ func (c *designateMockClient) ForEachZone(handler func(zone *zones.Zone) error)
 	return nil
 }
 
+func (c *designateMockClient) ForEachSharedZone(handler func(zone *zones.Zone) error) error {
+	c.sharedZoneListings++
+	if c.sharedZonesUnsupported {
+		return errSharedZonesUnsupported
+	}
+	for _, tz := range c.shared {
+		if err := handler(tz.zone); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (c *designateMockClient) ForEachRecordSet(zoneID string, handler func(recordSet *recordsets.RecordSet) error) error {
 	return c.ForEachRecordSetFilterByTypeAndName(zoneID, "", "", handler)
 }
@@ -182,6 +200,7 @@ func newMockHandler(mockZones ...*zones.Zone) *Handler {
 	h := &Handler{
 		client: &c,
 		config: provider.DNSHandlerConfig{
+			Logger:      logger.New(),
 			RateLimiter: rateLimiter,
 		},
 	}
@@ -233,6 +252,38 @@ func TestGetZones(t *testing.T) {
 	}
 }
 
+func TestGetZonesWithSharedZones(t *testing.T) {
+	RegisterTestingT(t)
+	h := newPreparedMockHandler(t)
+	c := h.client.(*designateMockClient)
+	c.shared = map[string]*testzone{
+		"z2": c.tzmap["z2"],
+		"s1": {zone: &zones.Zone{ID: "s1", Name: "shared.test."}},
+	}
+
+	hostedZones, err := h.GetZones()
+	Ω(err).ShouldNot(HaveOccurred())
+	var ids []string
+	for _, z := range hostedZones {
+		ids = append(ids, z.Id().ID+"="+z.Domain())
+	}
+	Ω(ids).Should(ConsistOf("z1=z1.test", "z2=z2.test", "s1=shared.test"))
+}
+
+func TestGetZonesWithSharedZonesUnsupported(t *testing.T) {
+	RegisterTestingT(t)
+	h := newPreparedMockHandler(t)
+	c := h.client.(*designateMockClient)
+	c.sharedZonesUnsupported = true
+
+	for i := 0; i < 2; i++ {
+		hostedZones, err := h.getZones(context.Background(), h.cache)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(hostedZones).Should(HaveLen(2))
+	}
+	Ω(c.sharedZoneListings).Should(Equal(1), "shared zones should not be listed again if unsupported")
+}
+
 func getDNSHostedZone(h *Handler, zoneID string) (provider.DNSHostedZone, error) {
 	tz, ok := h.client.(*designateMockClient).tzmap[zoneID]
 	if !ok {
@@ -18,7 +18,14 @@ var rateLimiterDefaults = provider.RateLimiterOptions{
 }
 
 var Factory = provider.NewDNSHandlerFactory(TYPE_CODE, NewHandler).
-	SetGenericFactoryOptionDefaults(provider.GenericFactoryOptionDefaults.SetRateLimiterOptions(rateLimiterDefaults))
+	SetGenericFactoryOptionDefaults(provider.GenericFactoryOptionDefaults.SetRateLimiterOptions(rateLimiterDefaults)).
+	SetSecretKeys(
+		provider.RequiredSecretKey("Server", "server"),
+		provider.RequiredSecretKey("ApiKey", "apiKey"),
+		provider.OptionalSecretKey("VirtualHost", "virtualHost"),
+		provider.OptionalSecretKey("InsecureSkipVerify", "insecureSkipVerify"),
+		provider.OptionalSecretKey("TrustedCaCert", "trustedCaCert"),
+	)
 
 func init() {
 	compound.MustRegister(Factory)
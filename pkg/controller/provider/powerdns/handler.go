@@ -100,9 +100,9 @@ func (h *Handler) GetZones() (provider.DNSHostedZones, error) {
 	return h.cache.GetZones()
 }
 
-func (h *Handler) getZones(_ provider.ZoneCache) (provider.DNSHostedZones, error) {
+func (h *Handler) getZones(ctx context.Context, _ provider.ZoneCache) (provider.DNSHostedZones, error) {
 	hostedZones := provider.DNSHostedZones{}
-	zones, err := h.powerdns.Zones.List(h.ctx)
+	zones, err := h.powerdns.Zones.List(ctx)
 	if err != nil {
 		return nil, err
 	}
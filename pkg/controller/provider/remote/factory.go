@@ -5,6 +5,8 @@
 package remote
 
 import (
+	corev1 "k8s.io/api/core/v1"
+
 	"github.com/gardener/external-dns-management/pkg/controller/provider/compound"
 	"github.com/gardener/external-dns-management/pkg/dns/provider"
 )
@@ -24,7 +26,15 @@ var advancedDefaults = provider.AdvancedOptions{
 
 var Factory = provider.NewDNSHandlerFactory(TYPE_CODE, NewHandler).
 	SetGenericFactoryOptionDefaults(provider.GenericFactoryOptionDefaults.
-		SetRateLimiterOptions(rateLimiterDefaults).SetAdvancedOptions(advancedDefaults))
+		SetRateLimiterOptions(rateLimiterDefaults).SetAdvancedOptions(advancedDefaults)).
+	SetSecretKeys(
+		provider.RequiredSecretKey("REMOTE_ENDPOINT", "remoteEndpoint"),
+		provider.RequiredSecretKey("NAMESPACE", "namespace"),
+		provider.RequiredSecretKey("CLIENT_CERT", corev1.TLSCertKey),
+		provider.RequiredSecretKey("CLIENT_KEY", corev1.TLSPrivateKeyKey),
+		provider.OptionalSecretKey("SERVER_CA_CERT", "ca.crt"),
+		provider.OptionalSecretKey("OVERRIDE_SERVER_NAME", "overrideServerName"),
+	)
 
 func init() {
 	compound.MustRegister(Factory)
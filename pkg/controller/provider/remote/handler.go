@@ -6,8 +6,6 @@ package remote
 
 import (
 	"context"
-	"crypto/tls"
-	"crypto/x509"
 	"fmt"
 	"os"
 	"strings"
@@ -18,12 +16,12 @@ import (
 	"github.com/gardener/external-dns-management/pkg/controller/provider/aws/mapping"
 	"github.com/gardener/external-dns-management/pkg/dns"
 	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	"github.com/gardener/external-dns-management/pkg/server/remote/client"
 	"github.com/gardener/external-dns-management/pkg/server/remote/common"
 	"github.com/gardener/external-dns-management/pkg/server/remote/conversion"
 	"go.uber.org/atomic"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/status"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -74,7 +72,7 @@ func NewHandler(c *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
 	overrideServerName := c.GetDefaultedProperty("OVERRIDE_SERVER_NAME", "", "overrideServerName")
 	c.Logger.Infof("creating remote handler for %s, namespace: %s, overrideServerName: %s", serverEndpoint, h.remoteNamespace, overrideServerName)
 
-	creds, err := h.loadTLSCredentials([]byte(serverCA_PEM), []byte(clientCert_PEM), []byte(clientKey_PEM))
+	creds, err := client.LoadTLSCredentials([]byte(serverCA_PEM), []byte(clientCert_PEM), []byte(clientKey_PEM))
 	if err != nil {
 		return nil, err
 	}
@@ -112,31 +110,6 @@ func getClientID() string {
 	return fmt.Sprintf("pid-%d", os.Getpid())
 }
 
-// serverCA_PEM: certificate (PEM) of the CA who signed server's certificate
-func (h *Handler) loadTLSCredentials(serverCA_PEM, clientCert_PEM, clientKey_PEM []byte) (credentials.TransportCredentials, error) {
-	// Load client's certificate and private key
-	clientCert, err := tls.X509KeyPair(clientCert_PEM, clientKey_PEM)
-	if err != nil {
-		return nil, err
-	}
-
-	// Create the credentials and return it
-	config := &tls.Config{
-		MinVersion:   tls.VersionTLS12,
-		Certificates: []tls.Certificate{clientCert},
-	}
-
-	if len(serverCA_PEM) > 0 {
-		certPool := x509.NewCertPool()
-		if !certPool.AppendCertsFromPEM(serverCA_PEM) {
-			return nil, fmt.Errorf("failed to add server CA's certificate")
-		}
-		config.RootCAs = certPool
-	}
-
-	return credentials.NewTLS(config), nil
-}
-
 func (h *Handler) Release() {
 	h.cache.Release()
 	if h.connection != nil {
@@ -191,8 +164,8 @@ func (h *Handler) retryOnInvalidTokenError(ctx context.Context, f func(token str
 	return err
 }
 
-func (h *Handler) getZones(_ provider.ZoneCache) (provider.DNSHostedZones, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+func (h *Handler) getZones(ctx context.Context, _ provider.ZoneCache) (provider.DNSHostedZones, error) {
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
 	defer cancel()
 
 	var remoteZones *common.Zones
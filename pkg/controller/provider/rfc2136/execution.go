@@ -129,6 +129,12 @@ func (exec *Execution) buildMappedRecordSet(name string, rset *dns.RecordSet) ([
 			records = append(records, &miekgdns.CNAME{Hdr: hdr, Target: miekgdns.Fqdn(r.Value)})
 		}
 		return records, nil
+	case dns.RS_PTR:
+		hdr.Rrtype = miekgdns.TypePTR
+		for _, r := range rset.Records {
+			records = append(records, &miekgdns.PTR{Hdr: hdr, Ptr: miekgdns.Fqdn(r.Value)})
+		}
+		return records, nil
 	case dns.RS_TXT:
 		hdr.Rrtype = miekgdns.TypeTXT
 		txtRecord := &miekgdns.TXT{Hdr: hdr}
@@ -0,0 +1,35 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package rfc2136
+
+import (
+	"testing"
+
+	miekgdns "github.com/miekg/dns"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+func TestBuildMappedRecordSetPTR(t *testing.T) {
+	exec := &Execution{}
+	rs := dns.NewRecordSet(dns.RS_PTR, 300, []*dns.Record{{Value: "host.example.com"}})
+	records, err := exec.buildMappedRecordSet("4.3.2.1.in-addr.arpa", rs)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(records))
+	}
+	ptr, ok := records[0].(*miekgdns.PTR)
+	if !ok {
+		t.Fatalf("expected PTR record, got %T", records[0])
+	}
+	if ptr.Hdr.Name != "4.3.2.1.in-addr.arpa." || ptr.Hdr.Rrtype != miekgdns.TypePTR || ptr.Hdr.Ttl != 300 {
+		t.Errorf("unexpected header %s", ptr.Hdr.String())
+	}
+	if ptr.Ptr != "host.example.com." {
+		t.Errorf("expected target host.example.com., got %s", ptr.Ptr)
+	}
+}
@@ -18,7 +18,14 @@ var rateLimiterDefaults = provider.RateLimiterOptions{
 }
 
 var Factory = provider.NewDNSHandlerFactory(TYPE_CODE, NewHandler).
-	SetGenericFactoryOptionDefaults(provider.GenericFactoryOptionDefaults.SetRateLimiterOptions(rateLimiterDefaults))
+	SetGenericFactoryOptionDefaults(provider.GenericFactoryOptionDefaults.SetRateLimiterOptions(rateLimiterDefaults)).
+	SetSecretKeys(
+		provider.RequiredSecretKey("Server"),
+		provider.RequiredSecretKey("Zone"),
+		provider.RequiredSecretKey("TSIGKeyName"),
+		provider.RequiredSecretKey("TSIGSecret"),
+		provider.OptionalSecretKey("TSIGSecretAlgorithm"),
+	)
 
 func init() {
 	compound.MustRegister(Factory)
@@ -38,7 +38,10 @@ type Handler struct {
 	tsigAlgorithm string
 }
 
-var _ provider.DNSHandler = &Handler{}
+var (
+	_ provider.DNSHandler           = &Handler{}
+	_ provider.CapabilitiesReporter = &Handler{}
+)
 
 // tsigAlgs are the supported TSIG algorithms
 var tsigAlgs = []string{miekgdns.HmacSHA1, miekgdns.HmacSHA224, miekgdns.HmacSHA256, miekgdns.HmacSHA384, miekgdns.HmacSHA512}
@@ -101,11 +104,16 @@ func (h *Handler) Release() {
 	h.cache.Release()
 }
 
+// Capabilities returns the optional record types supported by the RFC2136 DNS server.
+func (h *Handler) Capabilities() provider.Capabilities {
+	return provider.Capabilities{RecordTypes: []string{dns.RS_PTR}}
+}
+
 func (h *Handler) GetZones() (provider.DNSHostedZones, error) {
 	return h.cache.GetZones()
 }
 
-func (h *Handler) getZones(_ provider.ZoneCache) (provider.DNSHostedZones, error) {
+func (h *Handler) getZones(_ context.Context, _ provider.ZoneCache) (provider.DNSHostedZones, error) {
 	domainName := dns.NormalizeHostname(h.zone)
 	h.config.Metrics.AddGenericRequests(provider.M_LISTZONES, 1)
 	return provider.DNSHostedZones{
@@ -172,6 +180,9 @@ func (h *Handler) getZoneState(zone provider.DNSHostedZone, _ provider.ZoneCache
 			case miekgdns.TypeCNAME:
 				currentRType = dns.RS_CNAME
 				currentRecord = &dns.Record{Value: rr.(*miekgdns.CNAME).Target}
+			case miekgdns.TypePTR:
+				currentRType = dns.RS_PTR
+				currentRecord = &dns.Record{Value: rr.(*miekgdns.PTR).Ptr}
 			case miekgdns.TypeTXT:
 				txt := rr.(*miekgdns.TXT).Txt
 				records := make([]*dns.Record, len(txt))
@@ -253,7 +264,8 @@ func findTsigAlgorithm(alg string) (string, error) {
 		return miekgdns.HmacSHA256, nil
 	}
 
-	fqdnAlg := miekgdns.Fqdn(alg)
+	// algorithm names are case-insensitive, e.g. BIND uses 'HMAC-SHA512' in its key files
+	fqdnAlg := miekgdns.Fqdn(strings.ToLower(alg))
 	for _, a := range tsigAlgs {
 		if fqdnAlg == a {
 			return fqdnAlg, nil
@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package rfc2136
+
+import (
+	"testing"
+
+	miekgdns "github.com/miekg/dns"
+)
+
+func TestFindTsigAlgorithm(t *testing.T) {
+	table := []struct {
+		alg      string
+		expected string
+	}{
+		{"", miekgdns.HmacSHA256},
+		{"hmac-sha1", miekgdns.HmacSHA1},
+		{"hmac-sha224", miekgdns.HmacSHA224},
+		{"hmac-sha256.", miekgdns.HmacSHA256},
+		{"hmac-sha384", miekgdns.HmacSHA384},
+		{"hmac-sha512", miekgdns.HmacSHA512},
+		{"HMAC-SHA512", miekgdns.HmacSHA512},
+	}
+	for _, entry := range table {
+		alg, err := findTsigAlgorithm(entry.alg)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %s", entry.alg, err)
+		} else if alg != entry.expected {
+			t.Errorf("%q: expected %s, got %s", entry.alg, entry.expected, alg)
+		}
+	}
+
+	if _, err := findTsigAlgorithm("hmac-md5"); err == nil {
+		t.Errorf("expected error for unsupported algorithm")
+	}
+}
@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package testutils provides fixtures shared by the tests of the HTTP API based DNS providers.
+package testutils
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"k8s.io/client-go/util/flowcontrol"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+)
+
+// Token is the API token used by the test clients.
+const Token = "secret"
+
+// ClientArgs returns the arguments of the provider client constructors for a test server with the given URL.
+// The client uses the token Token, no metrics and no rate limiting.
+func ClientArgs(url string) (context.Context, *http.Client, string, string, provider.Metrics, flowcontrol.RateLimiter) {
+	return context.Background(), http.DefaultClient, url, Token, &provider.NullMetrics{}, flowcontrol.NewFakeAlwaysRateLimiter()
+}
+
+// NewServer starts a test server with the given handler, which is closed at the end of the test.
+// It returns the URL of the server.
+func NewServer(t testing.TB, handler http.HandlerFunc) string {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+// NewDNSSet returns a DNS set with a single record set of the given type.
+func NewDNSSet(name, rtype string, ttl int64, values ...string) *dns.DNSSet {
+	set := dns.NewDNSSet(dns.DNSSetName{DNSName: name}, nil)
+	var records []*dns.Record
+	for _, v := range values {
+		records = append(records, &dns.Record{Value: v})
+	}
+	set.Sets[rtype] = dns.NewRecordSet(rtype, ttl, records)
+	return set
+}
+
+// DoneHandler records the outcome of a change request.
+type DoneHandler struct {
+	Invalid error
+	Failure error
+	Success bool
+}
+
+var _ provider.DoneHandler = &DoneHandler{}
+
+func (d *DoneHandler) SetInvalid(err error) { d.Invalid = err }
+func (d *DoneHandler) Failed(err error)     { d.Failure = err }
+func (d *DoneHandler) Throttled()           {}
+func (d *DoneHandler) Succeeded()           { d.Success = true }
@@ -0,0 +1,232 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package vultr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"k8s.io/client-go/util/flowcontrol"
+
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/raw"
+)
+
+const (
+	defaultAPIURL = "https://api.vultr.com/v2"
+	// pageSize is the number of domains or records requested per page.
+	pageSize = 500
+)
+
+// Domain is a domain of a Vultr account.
+type Domain struct {
+	Domain string `json:"domain"`
+}
+
+type links struct {
+	Next string `json:"next"`
+}
+
+type meta struct {
+	Links links `json:"links"`
+}
+
+// APIError is an error response of the Vultr API.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("Vultr API request failed with status %d: %s", e.StatusCode, e.Message)
+}
+
+type client struct {
+	ctx         context.Context
+	baseURL     string
+	apiKey      string
+	httpClient  *http.Client
+	metrics     provider.Metrics
+	rateLimiter flowcontrol.RateLimiter
+}
+
+var _ raw.Executor = &client{}
+
+func newClient(ctx context.Context, httpClient *http.Client, baseURL, apiKey string, metrics provider.Metrics, rateLimiter flowcontrol.RateLimiter) *client {
+	return &client{
+		ctx:         ctx,
+		baseURL:     strings.TrimSuffix(baseURL, "/"),
+		apiKey:      apiKey,
+		httpClient:  httpClient,
+		metrics:     metrics,
+		rateLimiter: rateLimiter,
+	}
+}
+
+// ListDomains returns all domains of the account.
+func (c *client) ListDomains() ([]Domain, error) {
+	var result []Domain
+	err := c.list(c.baseURL+"/domains", func() { c.metrics.AddGenericRequests(provider.M_LISTZONES, 1) }, func(data []byte) (meta, error) {
+		var page struct {
+			Domains []Domain `json:"domains"`
+			Meta    meta     `json:"meta"`
+		}
+		if err := json.Unmarshal(data, &page); err != nil {
+			return meta{}, err
+		}
+		result = append(result, page.Domains...)
+		return page.Meta, nil
+	})
+	return result, err
+}
+
+// ListRecords returns all records of a zone.
+func (c *client) ListRecords(zone provider.DNSHostedZone) ([]*Record, error) {
+	var result []*Record
+	err := c.list(c.recordsURL(zone), func() { c.metrics.AddZoneRequests(zone.Id().ID, provider.M_LISTRECORDS, 1) }, func(data []byte) (meta, error) {
+		var page struct {
+			Records []*Record `json:"records"`
+			Meta    meta      `json:"meta"`
+		}
+		if err := json.Unmarshal(data, &page); err != nil {
+			return meta{}, err
+		}
+		for _, r := range page.Records {
+			r.domain = zone.Domain()
+			result = append(result, r)
+		}
+		return page.Meta, nil
+	})
+	return result, err
+}
+
+func (c *client) CreateRecord(r raw.Record, zone provider.DNSHostedZone) error {
+	c.metrics.AddZoneRequests(zone.Id().ID, provider.M_CREATERECORDS, 1)
+	return c.send(http.MethodPost, c.recordsURL(zone), r.(*Record))
+}
+
+func (c *client) UpdateRecord(r raw.Record, zone provider.DNSHostedZone) error {
+	c.metrics.AddZoneRequests(zone.Id().ID, provider.M_UPDATERECORDS, 1)
+	record := r.(*Record)
+	return c.send(http.MethodPatch, c.recordURL(zone, r.GetId()), &recordUpdate{
+		Name:     record.Name,
+		Data:     record.Data,
+		Priority: record.Priority,
+		TTL:      record.TTL,
+	})
+}
+
+func (c *client) DeleteRecord(r raw.Record, zone provider.DNSHostedZone) error {
+	c.metrics.AddZoneRequests(zone.Id().ID, provider.M_DELETERECORDS, 1)
+	_, err := c.do(http.MethodDelete, c.recordURL(zone, r.GetId()), nil)
+	return err
+}
+
+func (c *client) NewRecord(fqdn, rtype, value string, zone provider.DNSHostedZone, ttl int64) raw.Record {
+	data, priority := recordData(rtype, value)
+	r := &Record{
+		Type:     rtype,
+		Name:     recordName(fqdn, zone.Domain()),
+		Data:     data,
+		Priority: priority,
+		domain:   zone.Domain(),
+	}
+	r.SetTTL(ttl)
+	return r
+}
+
+func (c *client) GetRecordSet(dnsName, rtype string, zone provider.DNSHostedZone) (raw.RecordSet, error) {
+	records, err := c.ListRecords(zone)
+	if err != nil {
+		return nil, err
+	}
+	// no filtering provided by API, we have to list complete zone and filter
+	rs := raw.RecordSet{}
+	for _, r := range records {
+		if r.Type == rtype && r.GetDNSName() == dnsName {
+			rs = append(rs, r)
+		}
+	}
+	return rs, nil
+}
+
+func (c *client) recordsURL(zone provider.DNSHostedZone) string {
+	return fmt.Sprintf("%s/domains/%s/records", c.baseURL, url.PathEscape(zone.Id().ID))
+}
+
+func (c *client) recordURL(zone provider.DNSHostedZone, id string) string {
+	return fmt.Sprintf("%s/%s", c.recordsURL(zone), url.PathEscape(id))
+}
+
+func (c *client) send(method, u string, body any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	_, err = c.do(method, u, data)
+	return err
+}
+
+// list reads all pages of a list request following the cursor of the next page.
+func (c *client) list(u string, count func(), consume func(data []byte) (meta, error)) error {
+	query := url.Values{"per_page": []string{fmt.Sprintf("%d", pageSize)}}
+	for {
+		count()
+		data, err := c.do(http.MethodGet, u+"?"+query.Encode(), nil)
+		if err != nil {
+			return err
+		}
+		m, err := consume(data)
+		if err != nil {
+			return err
+		}
+		if m.Links.Next == "" {
+			return nil
+		}
+		query.Set("cursor", m.Links.Next)
+	}
+}
+
+func (c *client) do(method, u string, body []byte) ([]byte, error) {
+	c.rateLimiter.Accept()
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(c.ctx, method, u, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 {
+		apiErr := &APIError{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(data))}
+		// Vultr answers requests exceeding the rate limit with status code 429 or 503
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+			return nil, perrs.NewThrottlingError(apiErr)
+		}
+		return nil, apiErr
+	}
+	return data, nil
+}
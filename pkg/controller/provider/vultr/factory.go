@@ -0,0 +1,31 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package vultr
+
+import (
+	"github.com/gardener/external-dns-management/pkg/controller/provider/compound"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+)
+
+const TYPE_CODE = "vultr-dns"
+
+// rateLimiterDefaults stays below the limit of 30 requests per second of the Vultr API.
+var rateLimiterDefaults = provider.RateLimiterOptions{
+	Enabled: true,
+	QPS:     10,
+	Burst:   20,
+}
+
+var Factory = provider.NewDNSHandlerFactory(TYPE_CODE, NewHandler).
+	SetGenericFactoryOptionDefaults(provider.GenericFactoryOptionDefaults.SetRateLimiterOptions(rateLimiterDefaults)).
+	SetSecretKeys(
+		provider.RequiredSecretKey("VULTR_API_KEY", "apiKey"),
+		provider.OptionalSecretKey("VULTR_API_URL", "apiURL"),
+		provider.ProxySecretKey,
+	)
+
+func init() {
+	compound.MustRegister(Factory)
+}
@@ -0,0 +1,168 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package vultr
+
+import (
+	"context"
+
+	"fmt"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/raw"
+)
+
+type Handler struct {
+	provider.DefaultDNSHandler
+	config provider.DNSHandlerConfig
+	cache  provider.ZoneCache
+	client *client
+}
+
+var (
+	_ provider.DNSHandler       = &Handler{}
+	_ provider.TXTRecordLimiter = &Handler{}
+	_ provider.TTLLimiter       = &Handler{}
+)
+
+func NewHandler(c *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
+	h := &Handler{
+		DefaultDNSHandler: provider.NewDefaultDNSHandler(TYPE_CODE),
+		config:            *c,
+	}
+
+	apiKey, err := c.GetRequiredProperty("VULTR_API_KEY", "apiKey")
+	if err != nil {
+		return nil, err
+	}
+	apiURL := c.GetDefaultedProperty("VULTR_API_URL", defaultAPIURL, "apiURL")
+
+	h.client = newClient(c.Context, c.NewHTTPClient(), apiURL, apiKey, c.Metrics, c.RateLimiter)
+
+	h.cache, err = c.ZoneCacheFactory.CreateZoneCache(provider.CacheZonesOnly, c.Metrics, h.getZones, h.getZoneState)
+	if err != nil {
+		return nil, err
+	}
+
+	return h, nil
+}
+
+func (h *Handler) Release() {
+	h.cache.Release()
+}
+
+// TXTRecordLimits returns the limits of Vultr DNS for TXT record values.
+// Texts longer than 255 characters must be split into multiple character strings.
+func (h *Handler) TXTRecordLimits() dns.TXTRecordLimits {
+	return dns.TXTRecordLimits{ChunkSize: dns.MaxTXTCharacterStringLength}
+}
+
+// MinTTL returns the lowest TTL written to Vultr DNS.
+func (h *Handler) MinTTL() int64 {
+	return minimumTTL
+}
+
+func (h *Handler) GetZones() (provider.DNSHostedZones, error) {
+	return h.cache.GetZones()
+}
+
+func (h *Handler) getZones(_ context.Context, _ provider.ZoneCache) (provider.DNSHostedZones, error) {
+	blockedZones := h.config.Options.AdvancedOptions.GetBlockedZones()
+	domains, err := h.client.ListDomains()
+	if err != nil {
+		return nil, err
+	}
+
+	zones := provider.DNSHostedZones{}
+	for _, d := range domains {
+		// Vultr identifies a domain by its name
+		name := dns.NormalizeHostname(d.Domain)
+		if blockedZones.Contains(name) {
+			h.config.Logger.Infof("ignoring blocked zone id: %s", name)
+			continue
+		}
+		zones = append(zones, provider.NewDNSHostedZone(h.ProviderType(), name, name, name, false))
+	}
+	return zones, nil
+}
+
+func (h *Handler) GetZoneState(zone provider.DNSHostedZone) (provider.DNSZoneState, error) {
+	return h.cache.GetZoneState(zone)
+}
+
+func (h *Handler) getZoneState(zone provider.DNSHostedZone, _ provider.ZoneCache) (provider.DNSZoneState, error) {
+	records, err := h.client.ListRecords(zone)
+	if err != nil {
+		return nil, err
+	}
+	return newZoneState(records), nil
+}
+
+// newZoneState returns the zone state for the records of a zone.
+// Records of types not supported for DNS entries are ignored.
+func newZoneState(records []*Record) provider.DNSZoneState {
+	state := raw.NewState()
+	for _, r := range records {
+		if supportedRecordTypes[r.Type] {
+			state.AddRecord(r)
+		}
+	}
+	state.CalculateDNSSets()
+	for _, set := range state.GetDNSSets() {
+		for _, rs := range set.Sets {
+			// the desired TTL may have been raised to the minimum TTL
+			rs.IgnoreTTL = rs.TTL <= minimumTTL
+		}
+	}
+	return state
+}
+
+func (h *Handler) ReportZoneStateConflict(zone provider.DNSHostedZone, err error) bool {
+	return h.cache.ReportZoneStateConflict(zone, err)
+}
+
+func (h *Handler) ExecuteRequests(logger logger.LogContext, zone provider.DNSHostedZone, state provider.DNSZoneState, reqs []*provider.ChangeRequest) error {
+	err := h.executeRequests(logger, zone, state, reqs)
+	h.cache.ApplyRequests(logger, err, zone, reqs)
+	return err
+}
+
+func (h *Handler) executeRequests(logger logger.LogContext, zone provider.DNSHostedZone, state provider.DNSZoneState, reqs []*provider.ChangeRequest) error {
+	var valid []*provider.ChangeRequest
+	for _, req := range reqs {
+		if err := validateRequest(zone, req); err != nil {
+			logger.Warnf("%s %s record set [%s]: %s", req.Action, req.Type, zone.Id(), err)
+			if req.Done != nil {
+				req.Done.SetInvalid(err)
+			}
+			continue
+		}
+		valid = append(valid, req)
+	}
+	return raw.ExecuteRequests(logger, &h.config, h.client, zone, state, valid)
+}
+
+// validateRequest checks the constraints of Vultr DNS for records to be created or updated.
+func validateRequest(zone provider.DNSHostedZone, req *provider.ChangeRequest) error {
+	if req.Addition == nil || req.Action == provider.R_DELETE {
+		return nil
+	}
+	name, rset := dns.MapToProvider(req.Type, req.Addition, zone.Domain())
+	if rset == nil {
+		return nil
+	}
+	if !supportedRecordTypes[rset.Type] {
+		return fmt.Errorf("record type %s not supported by Vultr DNS", rset.Type)
+	}
+	if rset.TTL > maximumTTL {
+		return fmt.Errorf("TTL %d exceeds the maximum TTL %d of Vultr DNS", rset.TTL, maximumTTL)
+	}
+	if rset.Type == dns.RS_CNAME && name.DNSName == zone.Domain() {
+		return fmt.Errorf("CNAME record not allowed at zone apex %s", zone.Domain())
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package vultr
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/raw"
+)
+
+const (
+	// minimumTTL is the lowest TTL written to Vultr DNS, lower TTLs are raised to this value.
+	minimumTTL = 60
+	// maximumTTL is the highest TTL accepted by Vultr DNS.
+	maximumTTL = 2147483647
+)
+
+// supportedRecordTypes are the record types of DNS entries supported by Vultr DNS.
+var supportedRecordTypes = map[string]bool{
+	dns.RS_A:     true,
+	dns.RS_AAAA:  true,
+	dns.RS_CNAME: true,
+	dns.RS_TXT:   true,
+	dns.RS_SRV:   true,
+	dns.RS_CAA:   true,
+	dns.RS_MX:    true,
+	dns.RS_NS:    true,
+}
+
+// Record is a record of a Vultr DNS domain.
+// The name is relative to the domain, the priority of MX and SRV records is kept separately from the data.
+type Record struct {
+	ID       string `json:"id,omitempty"`
+	Type     string `json:"type"`
+	Name     string `json:"name"`
+	Data     string `json:"data"`
+	Priority *int   `json:"priority,omitempty"`
+	TTL      int64  `json:"ttl"`
+
+	domain string
+}
+
+var _ raw.Record = &Record{}
+
+func (r *Record) GetType() string          { return r.Type }
+func (r *Record) GetId() string            { return r.ID }
+func (r *Record) GetSetIdentifier() string { return "" }
+func (r *Record) GetTTL() int64            { return r.TTL }
+
+func (r *Record) GetDNSName() string {
+	if r.Name == "" {
+		return r.domain
+	}
+	return r.Name + "." + r.domain
+}
+
+func (r *Record) GetValue() string {
+	switch r.Type {
+	case dns.RS_CNAME, dns.RS_NS:
+		return dns.NormalizeHostname(r.Data)
+	case dns.RS_MX:
+		return dns.NormalizeMXValue(fmt.Sprintf("%d %s", r.priority(), r.Data))
+	case dns.RS_SRV:
+		return dns.NormalizeSRVValue(fmt.Sprintf("%d %s", r.priority(), r.Data))
+	case dns.RS_CAA:
+		return dns.NormalizeCAAValue(r.Data)
+	case dns.RS_TXT:
+		// long texts are stored as multiple quoted character strings
+		if strings.HasPrefix(r.Data, `"`) {
+			return r.Data
+		}
+		return raw.EnsureQuotedText(r.Data)
+	}
+	return r.Data
+}
+
+func (r *Record) SetTTL(ttl int64) {
+	if ttl < minimumTTL {
+		ttl = minimumTTL
+	}
+	r.TTL = ttl
+}
+
+func (r *Record) Copy() raw.Record {
+	n := *r
+	if r.Priority != nil {
+		priority := *r.Priority
+		n.Priority = &priority
+	}
+	return &n
+}
+
+func (r *Record) priority() int {
+	if r.Priority == nil {
+		return 0
+	}
+	return *r.Priority
+}
+
+// recordUpdate is the body of a record update, the type of a record cannot be changed.
+type recordUpdate struct {
+	Name     string `json:"name"`
+	Data     string `json:"data"`
+	Priority *int   `json:"priority,omitempty"`
+	TTL      int64  `json:"ttl"`
+}
+
+// recordName returns the record name relative to the domain of the zone.
+func recordName(dnsName, domain string) string {
+	if dnsName == domain {
+		return ""
+	}
+	return strings.TrimSuffix(dnsName, "."+domain)
+}
+
+// recordData splits a record value into the data and the priority expected by Vultr DNS.
+func recordData(rtype, value string) (string, *int) {
+	switch rtype {
+	case dns.RS_CNAME, dns.RS_NS:
+		return dns.NormalizeHostname(value), nil
+	case dns.RS_MX:
+		if mx, err := dns.ParseMXRecord(value); err == nil {
+			priority := int(mx.Priority)
+			return mx.Hostname, &priority
+		}
+	case dns.RS_SRV:
+		if srv, err := dns.ParseSRVRecord(value); err == nil {
+			priority := int(srv.Priority)
+			return fmt.Sprintf("%d %d %s", srv.Weight, srv.Port, srv.Target), &priority
+		}
+	}
+	return value, nil
+}
@@ -0,0 +1,203 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package vultr
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"k8s.io/utils/ptr"
+
+	"github.com/gardener/external-dns-management/pkg/controller/provider/testutils"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+)
+
+func newTestClient(url string) *client {
+	return newClient(testutils.ClientArgs(url))
+}
+
+func newTestZone() provider.DNSHostedZone {
+	return provider.NewDNSHostedZone(TYPE_CODE, "example.com", "example.com", "example.com", false)
+}
+
+func TestListDomainsPaginated(t *testing.T) {
+	serverURL := testutils.NewServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+testutils.Token {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			_, _ = io.WriteString(w, `{"domains":[{"domain":"example1.com"}],"meta":{"total":2,"links":{"next":"page2","prev":""}}}`)
+		case "page2":
+			_, _ = io.WriteString(w, `{"domains":[{"domain":"example2.com"}],"meta":{"total":2,"links":{"next":"","prev":"page1"}}}`)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	domains, err := newTestClient(serverURL).ListDomains()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(domains) != 2 || domains[0].Domain != "example1.com" || domains[1].Domain != "example2.com" {
+		t.Errorf("unexpected domains: %v", domains)
+	}
+}
+
+func TestListRecords(t *testing.T) {
+	serverURL := testutils.NewServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/domains/example.com/records" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		_, _ = io.WriteString(w, `{"records":[
+{"id":"1","type":"A","name":"","data":"1.1.1.1","priority":-1,"ttl":300},
+{"id":"2","type":"CNAME","name":"www","data":"target.example.org","priority":-1,"ttl":3600},
+{"id":"3","type":"MX","name":"","data":"mail.example.com","priority":10,"ttl":3600},
+{"id":"4","type":"SRV","name":"_sip._tcp","data":"5 5060 sip.example.com","priority":1,"ttl":3600},
+{"id":"5","type":"TXT","name":"txt","data":"foo","priority":-1,"ttl":3600}
+],"meta":{"total":5,"links":{"next":"","prev":""}}}`)
+	})
+
+	records, err := newTestClient(serverURL).ListRecords(newTestZone())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := []struct {
+		name, value string
+		ttl         int64
+	}{
+		{"example.com", "1.1.1.1", 300},
+		{"www.example.com", "target.example.org", 3600},
+		{"example.com", "10 mail.example.com", 3600},
+		{"_sip._tcp.example.com", "1 5 5060 sip.example.com", 3600},
+		{"txt.example.com", `"foo"`, 3600},
+	}
+	if len(records) != len(expected) {
+		t.Fatalf("unexpected records: %v", records)
+	}
+	for i, e := range expected {
+		r := records[i]
+		if r.GetDNSName() != e.name || r.GetValue() != e.value || r.GetTTL() != e.ttl {
+			t.Errorf("record %d: expected %s %s %d, but got %s %s %d", i, e.name, e.value, e.ttl, r.GetDNSName(), r.GetValue(), r.GetTTL())
+		}
+	}
+}
+
+func TestThrottled(t *testing.T) {
+	for _, status := range []int{http.StatusTooManyRequests, http.StatusServiceUnavailable} {
+		serverURL := testutils.NewServer(t, func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(status)
+		})
+		_, err := newTestClient(serverURL).ListDomains()
+		if !perrs.IsThrottlingError(err) {
+			t.Errorf("expected throttling error for status %d, but got %v", status, err)
+		}
+	}
+}
+
+func TestNewRecord(t *testing.T) {
+	c := newTestClient("")
+	zone := newTestZone()
+
+	r := c.NewRecord("example.com", dns.RS_A, "1.1.1.1", zone, 30).(*Record)
+	if r.Name != "" || r.Data != "1.1.1.1" || r.GetTTL() != minimumTTL || r.Priority != nil {
+		t.Errorf("unexpected record %v", r)
+	}
+	r = c.NewRecord("*.a.example.com", dns.RS_CNAME, "target.example.org", zone, 600).(*Record)
+	if r.Name != "*.a" || r.Data != "target.example.org" || r.GetTTL() != 600 || r.GetValue() != "target.example.org" {
+		t.Errorf("unexpected record %v", r)
+	}
+	r = c.NewRecord("example.com", dns.RS_MX, "0 mail.example.com", zone, 600).(*Record)
+	if r.Data != "mail.example.com" || r.Priority == nil || *r.Priority != 0 || r.GetValue() != "0 mail.example.com" {
+		t.Errorf("unexpected record %v", r)
+	}
+	r = c.NewRecord("_sip._tcp.example.com", dns.RS_SRV, "1 5 5060 sip.example.com", zone, 600).(*Record)
+	if r.Data != "5 5060 sip.example.com" || r.Priority == nil || *r.Priority != 1 || r.GetValue() != "1 5 5060 sip.example.com" {
+		t.Errorf("unexpected record %v", r)
+	}
+}
+
+func TestExecuteRequests(t *testing.T) {
+	var lock sync.Mutex
+	var requests []string
+	var created []Record
+	var updated []map[string]any
+	serverURL := testutils.NewServer(t, func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method {
+		case http.MethodPost:
+			var record Record
+			_ = json.NewDecoder(r.Body).Decode(&record)
+			created = append(created, record)
+		case http.MethodPatch:
+			var update map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&update)
+			updated = append(updated, update)
+		}
+		_, _ = io.WriteString(w, `{}`)
+	})
+
+	zone := newTestZone()
+	h := &Handler{client: newTestClient(serverURL)}
+	state := newZoneState([]*Record{
+		{ID: "1", Type: dns.RS_A, Name: "a", Data: "1.1.1.1", TTL: 3600, domain: "example.com"},
+		{ID: "2", Type: dns.RS_A, Name: "b", Data: "2.2.2.2", TTL: 3600, domain: "example.com"},
+		{ID: "3", Type: dns.RS_MX, Name: "", Data: "mail.example.com", Priority: ptr.To(10), TTL: 3600, domain: "example.com"},
+	})
+
+	apex := &testutils.DoneHandler{}
+	ptrDone := &testutils.DoneHandler{}
+	ttl := &testutils.DoneHandler{}
+	update := &testutils.DoneHandler{}
+	reqs := []*provider.ChangeRequest{
+		provider.NewChangeRequest(provider.R_CREATE, dns.RS_CNAME, nil, testutils.NewDNSSet("example.com", dns.RS_CNAME, 300, "target.example.org"), apex),
+		provider.NewChangeRequest(provider.R_CREATE, dns.RS_PTR, nil, testutils.NewDNSSet("ptr.example.com", dns.RS_PTR, 300, "target.example.org"), ptrDone),
+		provider.NewChangeRequest(provider.R_CREATE, dns.RS_A, nil, testutils.NewDNSSet("c.example.com", dns.RS_A, maximumTTL+1, "4.4.4.4"), ttl),
+		provider.NewChangeRequest(provider.R_UPDATE, dns.RS_A, testutils.NewDNSSet("a.example.com", dns.RS_A, 3600, "1.1.1.1"), testutils.NewDNSSet("a.example.com", dns.RS_A, 3600, "3.3.3.3"), update),
+		provider.NewChangeRequest(provider.R_UPDATE, dns.RS_MX, testutils.NewDNSSet("example.com", dns.RS_MX, 3600, "10 mail.example.com"), testutils.NewDNSSet("example.com", dns.RS_MX, 600, "10 mail.example.com"), nil),
+		provider.NewChangeRequest(provider.R_DELETE, dns.RS_A, testutils.NewDNSSet("b.example.com", dns.RS_A, 3600, "2.2.2.2"), nil, nil),
+	}
+	if err := h.executeRequests(logger.New(), zone, state, reqs); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if apex.Invalid == nil {
+		t.Errorf("expected CNAME at zone apex to be invalid")
+	}
+	if ptrDone.Invalid == nil {
+		t.Errorf("expected PTR record to be invalid")
+	}
+	if ttl.Invalid == nil {
+		t.Errorf("expected TTL above maximum to be invalid")
+	}
+	if !update.Success {
+		t.Errorf("expected update to succeed")
+	}
+	expected := []string{
+		"POST /domains/example.com/records",
+		"PATCH /domains/example.com/records/3",
+		"DELETE /domains/example.com/records/1",
+		"DELETE /domains/example.com/records/2",
+	}
+	if fmt.Sprint(requests) != fmt.Sprint(expected) {
+		t.Errorf("expected requests %v, but got %v", expected, requests)
+	}
+	if len(created) != 1 || created[0].Name != "a" || created[0].Data != "3.3.3.3" || created[0].TTL != 3600 || created[0].Type != dns.RS_A {
+		t.Errorf("unexpected created records %v", created)
+	}
+	if len(updated) != 1 || updated[0]["ttl"] != float64(600) || updated[0]["priority"] != float64(10) || updated[0]["type"] != nil {
+		t.Errorf("unexpected updated records %v", updated)
+	}
+}
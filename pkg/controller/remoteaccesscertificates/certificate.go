@@ -15,15 +15,12 @@ import (
 	"time"
 
 	"github.com/gardener/controller-manager-library/pkg/utils/pkiutil"
+
+	"github.com/gardener/external-dns-management/pkg/server/remote/common"
 )
 
 // CertData contains the created certificate
-type CertData struct {
-	Certificate *x509.Certificate
-	CACrt       []byte
-	TLSKey      []byte
-	TLSCrt      []byte
-}
+type CertData = common.CertData
 
 // CreateSubject is a helper to create a subject for a given common name
 func CreateSubject(commonName string) pkix.Name {
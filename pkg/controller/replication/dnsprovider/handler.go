@@ -264,6 +264,7 @@ func (this *sourceReconciler) updateEntryFor(logger logger.LogContext, obj resou
 		mod.AssureInt64PtrPtr(&targetSpec.DefaultTTL, sourceSpec.DefaultTTL)
 		assureDNSSelection(mod, &targetSpec.Domains, sourceSpec.Domains)
 		assureDNSSelection(mod, &targetSpec.Zones, sourceSpec.Zones)
+		assureLabelSelector(mod, &targetSpec.ZoneTagSelector, sourceSpec.ZoneTagSelector)
 
 		changed, err := this.updateSecretIfNeeded(logger, target, slave, obj)
 		if err != nil {
@@ -398,3 +399,17 @@ func assureDNSSelection(mod *utils.ModificationState, t **api.DNSSelection, s *a
 		}
 	}
 }
+
+func assureLabelSelector(mod *utils.ModificationState, t **metav1.LabelSelector, s *metav1.LabelSelector) {
+	if s == nil {
+		if *t != nil {
+			*t = nil
+			mod.Modify(true)
+		}
+	} else {
+		if *t == nil || !reflect.DeepEqual(*t, s) {
+			*t = s.DeepCopy()
+			mod.Modify(true)
+		}
+	}
+}
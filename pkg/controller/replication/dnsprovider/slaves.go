@@ -81,6 +81,7 @@ func (this *slaveReconciler) Reconcile(logger logger.LogContext, obj resources.O
 				mod.AssureStringValue(&ownerStatus.State, status.State)
 				assureDNSSelectionStatus(mod, &ownerStatus.Domains, status.Domains)
 				assureDNSSelectionStatus(mod, &ownerStatus.Zones, status.Zones)
+				assureProblematicZones(mod, &ownerStatus.ProblematicZones, status.ProblematicZones)
 				assureRateLimit(mod, &ownerStatus.RateLimit, status.RateLimit)
 				mod.AssureInt64PtrPtr(&ownerStatus.DefaultTTL, status.DefaultTTL)
 				var msg *string
@@ -118,6 +119,13 @@ func assureDNSSelectionStatus(mod *utils2.ModificationState, t *api.DNSSelection
 	}
 }
 
+func assureProblematicZones(mod *utils2.ModificationState, t *[]api.ProblematicZone, s []api.ProblematicZone) {
+	if !reflect.DeepEqual(*t, s) {
+		*t = s
+		mod.Modify(true)
+	}
+}
+
 func assureRateLimit(mod *utils2.ModificationState, t **api.RateLimit, s *api.RateLimit) {
 	if s == nil && *t != nil {
 		*t = nil
@@ -47,6 +47,8 @@ func Create(controller controller.Interface) (reconcile.Interface, error) {
 			"virtualservices.networking.istio.io":  false,
 			"gateways.gateway.networking.k8s.io":   false,
 			"httproutes.gateway.networking.k8s.io": false,
+			"tcproutes.gateway.networking.k8s.io":  false,
+			"tlsroutes.gateway.networking.k8s.io":  false,
 		},
 	}, nil
 }
@@ -5,10 +5,13 @@
 package gatewayapi
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/gardener/controller-manager-library/pkg/controllermanager/cluster"
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/watches"
 	"github.com/gardener/controller-manager-library/pkg/resources"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/gardener/external-dns-management/pkg/dns/source"
 )
@@ -18,16 +21,20 @@ const Group = "gateway.networking.k8s.io"
 var (
 	GroupKindGateway   = resources.NewGroupKind(Group, "Gateway")
 	GroupKindHTTPRoute = resources.NewGroupKind(Group, "HTTPRoute")
+	GroupKindTCPRoute  = resources.NewGroupKind(Group, "TCPRoute")
+	GroupKindTLSRoute  = resources.NewGroupKind(Group, "TLSRoute")
 )
 
 func init() {
 	source.DNSSourceController(source.NewDNSSouceTypeForCreator("k8s-gateways-dns", GroupKindGateway, NewGatewaySource), nil).
 		FinalizerDomain("dns.gardener.cloud").
 		DeactivateOnCreationErrorCheck(deactivateOnMissingMainResource).
-		Reconciler(HTTPRoutesReconciler, "httproutes").
+		Reconciler(RoutesReconciler, "httproutes").
 		Cluster(cluster.DEFAULT).
 		WorkerPool("httproutes", 2, 0).
 		ReconcilerWatchesByGK("httproutes", GroupKindHTTPRoute).
+		FlavoredReconcilerWatch("httproutes", watches.ResourceFlavorByGK(GroupKindTCPRoute, resourceAvailable(GroupKindTCPRoute))).
+		FlavoredReconcilerWatch("httproutes", watches.ResourceFlavorByGK(GroupKindTLSRoute, resourceAvailable(GroupKindTLSRoute))).
 		MustRegister(source.CONTROLLER_GROUP_DNS_SOURCES)
 }
 
@@ -35,3 +42,12 @@ func deactivateOnMissingMainResource(err error) bool {
 	return strings.Contains(err.Error(), "gardener/cml/resources/UNKNOWN_RESOURCE") &&
 		(strings.Contains(err.Error(), GroupKindGateway.String()) || strings.Contains(err.Error(), GroupKindHTTPRoute.String()))
 }
+
+// resourceAvailable is a watch constraint for optional route kinds, which are only part of the experimental
+// channel of the Gateway API. The watch is only established if the CRD is installed in the cluster.
+func resourceAvailable(gk schema.GroupKind) watches.WatchConstraint {
+	return watches.NewFunctionWatchConstraint(func(wctx watches.WatchContext) bool {
+		_, err := wctx.Cluster().Resources().GetByGK(gk)
+		return err == nil
+	}, fmt.Sprintf("resource %s available", gk))
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/gardener/controller-manager-library/pkg/resources"
 	"github.com/gardener/controller-manager-library/pkg/utils"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/utils/ptr"
 	gatewayapisv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gatewayapisv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
@@ -22,19 +23,20 @@ import (
 	"github.com/gardener/external-dns-management/pkg/dns/source"
 )
 
-type httpRouteLister interface {
-	ListHTTPRoutes(gateway *resources.ObjectName) ([]resources.ObjectData, error)
+type routeLister interface {
+	// ListRoutes lists the HTTPRoutes, TCPRoutes, and TLSRoutes attached to the given gateway or all routes if gateway is nil.
+	ListRoutes(gateway *resources.ObjectName) ([]resources.ObjectData, error)
 }
 
 type gatewaySource struct {
 	source.DefaultDNSSource
-	lister httpRouteLister
+	lister routeLister
 	state  *routesState
 }
 
 // NewGatewaySource is the DNSSource for gateways.gateway.networking.k8s.io resources.
 func NewGatewaySource(c controller.Interface) (source.DNSSource, error) {
-	lister, err := newServiceLister(c)
+	lister, err := newRouteLister(c)
 	if err != nil {
 		return nil, err
 	}
@@ -45,18 +47,18 @@ func NewGatewaySource(c controller.Interface) (source.DNSSource, error) {
 	return newGatewaySourceWithRouteLister(lister, state)
 }
 
-func newGatewaySourceWithRouteLister(lister httpRouteLister, state *routesState) (source.DNSSource, error) {
+func newGatewaySourceWithRouteLister(lister routeLister, state *routesState) (source.DNSSource, error) {
 	return &gatewaySource{lister: lister, DefaultDNSSource: source.NewDefaultDNSSource(nil), state: state}, nil
 }
 
 func (s *gatewaySource) Setup() error {
-	routes, err := s.lister.ListHTTPRoutes(nil)
+	routes, err := s.lister.ListRoutes(nil)
 	if err != nil {
 		return err
 	}
 	for _, route := range routes {
 		gateways := extractGatewayNames(route)
-		s.state.AddRoute(resources.NewObjectNameForData(route), gateways)
+		s.state.AddRoute(routeKey(route), gateways)
 	}
 	return nil
 }
@@ -116,7 +118,7 @@ func (s *gatewaySource) extractServerHosts(obj resources.ObjectData) ([]string,
 		return nil, fmt.Errorf("unexpected istio gateway type: %#v", obj)
 	}
 
-	routes, err := s.lister.ListHTTPRoutes(ptr.To(resources.NewObjectNameForData(obj)))
+	routes, err := s.lister.ListRoutes(ptr.To(resources.NewObjectNameForData(obj)))
 	if err != nil {
 		return nil, err
 	}
@@ -133,6 +135,7 @@ func (s *gatewaySource) extractServerHosts(obj resources.ObjectData) ([]string,
 		return append(hosts, host)
 	}
 
+	// TCPRoutes have no hostnames, they are only served with the hostnames of the gateway listeners
 	for _, route := range routes {
 		switch r := route.(type) {
 		case *gatewayapisv1.HTTPRoute:
@@ -147,6 +150,10 @@ func (s *gatewaySource) extractServerHosts(obj resources.ObjectData) ([]string,
 			for _, h := range r.Spec.Hostnames {
 				hosts = addHost(hosts, string(h))
 			}
+		case *gatewayapisv1alpha2.TLSRoute:
+			for _, h := range r.Spec.Hostnames {
+				hosts = addHost(hosts, string(h))
+			}
 		}
 	}
 	return hosts, nil
@@ -201,30 +208,36 @@ func (s *gatewaySource) getTargets(obj resources.ObjectData) utils.StringSet {
 	}
 }
 
-var _ httpRouteLister = &httprouteLister{}
+var _ routeLister = &clusterRouteLister{}
 
-type httprouteLister struct {
-	httprouteResources resources.Interface
+type clusterRouteLister struct {
+	routeResources []resources.Interface
 }
 
-func newServiceLister(c controller.Interface) (*httprouteLister, error) {
-	httprouteResources, err := c.GetMainCluster().Resources().GetByGK(resources.NewGroupKind(Group, "HTTPRoute"))
+func newRouteLister(c controller.Interface) (*clusterRouteLister, error) {
+	httprouteResources, err := c.GetMainCluster().Resources().GetByGK(GroupKindHTTPRoute)
 	if err != nil {
 		return nil, err
 	}
-	return &httprouteLister{httprouteResources: httprouteResources}, nil
+	lister := &clusterRouteLister{routeResources: []resources.Interface{httprouteResources}}
+	// TCPRoutes and TLSRoutes are only available if the CRDs of the experimental channel are installed
+	for _, gk := range []schema.GroupKind{GroupKindTCPRoute, GroupKindTLSRoute} {
+		if res, err := c.GetMainCluster().Resources().GetByGK(gk); err == nil {
+			lister.routeResources = append(lister.routeResources, res)
+		}
+	}
+	return lister, nil
 }
 
-func (l *httprouteLister) ListHTTPRoutes(gateway *resources.ObjectName) ([]resources.ObjectData, error) {
-	objs, err := l.httprouteResources.List(metav1.ListOptions{})
-	if err != nil {
-		return nil, err
-	}
+func (l *clusterRouteLister) ListRoutes(gateway *resources.ObjectName) ([]resources.ObjectData, error) {
 	var array []resources.ObjectData
-	for _, obj := range objs {
-		gateways := extractGatewayNames(obj.Data())
-		for g := range gateways {
-			if gateway == nil || g == *gateway {
+	for _, res := range l.routeResources {
+		objs, err := res.List(metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range objs {
+			if gateway == nil || extractGatewayNames(obj.Data()).Contains(*gateway) {
 				array = append(array, obj.Data())
 			}
 		}
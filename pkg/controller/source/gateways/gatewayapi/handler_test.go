@@ -13,6 +13,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 	gatewayapisv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayapisv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	"github.com/gardener/external-dns-management/pkg/dns"
 	dnssource "github.com/gardener/external-dns-management/pkg/dns/source"
@@ -53,15 +54,41 @@ var _ = Describe("Kubernetes Networking Gateway Handler", func() {
 				Hostnames: []gatewayapisv1.Hostname{"bla.example.com"},
 			},
 		}
-		routes = []*gatewayapisv1.HTTPRoute{route1, route2, route3}
+		tlsRoute = &gatewayapisv1alpha2.TLSRoute{
+			Spec: gatewayapisv1alpha2.TLSRouteSpec{
+				CommonRouteSpec: gatewayapisv1.CommonRouteSpec{ParentRefs: []gatewayapisv1.ParentReference{
+					{
+						Namespace: ptr.To(gatewayapisv1.Namespace("test")),
+						Name:      "g1",
+					},
+					{
+						Namespace: ptr.To(gatewayapisv1.Namespace("test")),
+						Name:      "g2",
+					},
+				}},
+				Hostnames: []gatewayapisv1.Hostname{"tls.example.com"},
+			},
+		}
+		tcpRoute = &gatewayapisv1alpha2.TCPRoute{
+			Spec: gatewayapisv1alpha2.TCPRouteSpec{
+				CommonRouteSpec: gatewayapisv1.CommonRouteSpec{ParentRefs: []gatewayapisv1.ParentReference{
+					{
+						Namespace: ptr.To(gatewayapisv1.Namespace("test")),
+						Name:      "g2",
+					},
+				}},
+			},
+		}
+		routes    = []resources.ObjectData{route1, route2, route3}
+		allRoutes = []resources.ObjectData{route1, route2, route3, tlsRoute, tcpRoute}
 
 		log          = logger.NewContext("", "TestEnv")
 		emptyDNSInfo = &dnssource.DNSInfo{Names: dns.DNSNameSet{}}
 	)
 
 	var _ = DescribeTable("GetDNSInfo",
-		func(gateway *gatewayapisv1.Gateway, httpRoutes []*gatewayapisv1.HTTPRoute, expectedInfo *dnssource.DNSInfo) {
-			handler, err := newGatewaySourceWithRouteLister(&testRouteLister{routes: httpRoutes}, newState())
+		func(gateway *gatewayapisv1.Gateway, routes []resources.ObjectData, expectedInfo *dnssource.DNSInfo) {
+			handler, err := newGatewaySourceWithRouteLister(&testRouteLister{routes: routes}, newState())
 			Expect(err).To(Succeed())
 			current := &dnssource.DNSCurrentState{Names: map[dns.DNSSetName]*dnssource.DNSState{}, Targets: utils.StringSet{}}
 			annos := gateway.GetAnnotations()
@@ -216,6 +243,46 @@ var _ = Describe("Kubernetes Networking Gateway Handler", func() {
 				},
 			},
 		}, routes, makeDNSInfo([]string{"b.example.com", "bar.example.com", "foo.example.com"}, []string{"lb-example.com"})),
+		Entry("assigned gateway to service with hostname and TLSRoutes", &gatewayapisv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "test",
+				Name:        "g1",
+				Annotations: map[string]string{dnssource.DNS_ANNOTATION: "*"},
+			},
+			Spec: gatewayapisv1.GatewaySpec{
+				Listeners: []gatewayapisv1.Listener{
+					{Hostname: ptr.To(gatewayapisv1.Hostname("b.example.com"))},
+				},
+			},
+			Status: gatewayapisv1.GatewayStatus{
+				Addresses: []gatewayapisv1.GatewayStatusAddress{
+					{
+						Type:  ptr.To(gatewayapisv1.IPAddressType),
+						Value: "lb-example.com",
+					},
+				},
+			},
+		}, allRoutes, makeDNSInfo([]string{"b.example.com", "bar.example.com", "foo.example.com", "tls.example.com"}, []string{"lb-example.com"})),
+		Entry("second parent gateway of TLSRoute with TCPRoute", &gatewayapisv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "test",
+				Name:        "g2",
+				Annotations: map[string]string{dnssource.DNS_ANNOTATION: "*"},
+			},
+			Spec: gatewayapisv1.GatewaySpec{
+				Listeners: []gatewayapisv1.Listener{
+					{Hostname: ptr.To(gatewayapisv1.Hostname("tcp.example.com"))},
+				},
+			},
+			Status: gatewayapisv1.GatewayStatus{
+				Addresses: []gatewayapisv1.GatewayStatusAddress{
+					{
+						Type:  ptr.To(gatewayapisv1.IPAddressType),
+						Value: "lb-example.com",
+					},
+				},
+			},
+		}, allRoutes, makeDNSInfo([]string{"bla.example.com", "tcp.example.com", "tls.example.com"}, []string{"lb-example.com"})),
 		Entry("unmatched host in DNS annotation", &gatewayapisv1.Gateway{
 			ObjectMeta: metav1.ObjectMeta{
 				Namespace:   "test",
@@ -245,19 +312,16 @@ var _ = Describe("Kubernetes Networking Gateway Handler", func() {
 })
 
 type testRouteLister struct {
-	routes []*gatewayapisv1.HTTPRoute
+	routes []resources.ObjectData
 }
 
-var _ httpRouteLister = &testRouteLister{}
+var _ routeLister = &testRouteLister{}
 
-func (t testRouteLister) ListHTTPRoutes(gateway *resources.ObjectName) ([]resources.ObjectData, error) {
+func (t testRouteLister) ListRoutes(gateway *resources.ObjectName) ([]resources.ObjectData, error) {
 	var filtered []resources.ObjectData
 	for _, r := range t.routes {
-		for _, ref := range r.Spec.ParentRefs {
-			if gateway == nil ||
-				(ref.Namespace == nil || string(*ref.Namespace) == (*gateway).Namespace()) && string(ref.Name) == (*gateway).Name() {
-				filtered = append(filtered, r)
-			}
+		if gateway == nil || extractGatewayNames(r).Contains(*gateway) {
+			filtered = append(filtered, r)
 		}
 	}
 	return filtered, nil
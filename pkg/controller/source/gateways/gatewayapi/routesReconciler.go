@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package gatewayapi
+
+import (
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/reconcile"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	gatewayapisv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayapisv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayapisv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+// RoutesReconciler creates the reconciler for HTTPRoutes, TCPRoutes, and TLSRoutes,
+// which triggers the referenced parent gateways on changes.
+func RoutesReconciler(c controller.Interface) (reconcile.Interface, error) {
+	state, err := getOrCreateSharedState(c)
+	if err != nil {
+		return nil, err
+	}
+	return &routesReconciler{controller: c, state: state}, nil
+}
+
+var _ reconcile.Interface = &routesReconciler{}
+
+type routesReconciler struct {
+	reconcile.DefaultReconciler
+	controller controller.Interface
+	state      *routesState
+}
+
+func (r *routesReconciler) Reconcile(logger logger.LogContext, obj resources.Object) reconcile.Status {
+	gateways := extractGatewayNames(obj.Data())
+	oldGateways := r.state.AddRoute(obj.Key(), gateways)
+	gateways.Add(oldGateways...)
+	r.triggerGateways(obj.ClusterKey().Cluster(), gateways)
+	return reconcile.Succeeded(logger)
+}
+
+func (r *routesReconciler) Delete(logger logger.LogContext, obj resources.Object) reconcile.Status {
+	r.state.RemoveRoute(obj.Key())
+	r.triggerGateways(obj.ClusterKey().Cluster(), extractGatewayNames(obj.Data()))
+	return reconcile.Succeeded(logger)
+}
+
+func (r *routesReconciler) Deleted(logger logger.LogContext, key resources.ClusterObjectKey) reconcile.Status {
+	gateways := r.state.MatchingGatewaysByRoute(key.ObjectKey())
+	r.state.RemoveRoute(key.ObjectKey())
+	r.triggerGateways(key.Cluster(), resources.NewObjectNameSetByArray(gateways))
+	return reconcile.Succeeded(logger)
+}
+
+func (r *routesReconciler) triggerGateways(cluster string, gateways resources.ObjectNameSet) {
+	for g := range gateways {
+		_ = r.controller.EnqueueKey(resources.NewClusterKeyForObject(cluster, g.ForGroupKind(GroupKindGateway)))
+	}
+}
+
+func extractGatewayNames(route resources.ObjectData) resources.ObjectNameSet {
+	gatewayNames := resources.NewObjectNameSet()
+	switch data := route.(type) {
+	case *gatewayapisv1.HTTPRoute:
+		addParentGateways(gatewayNames, data.Namespace, data.Spec.ParentRefs)
+	case *gatewayapisv1beta1.HTTPRoute:
+		addParentGateways(gatewayNames, data.Namespace, data.Spec.ParentRefs)
+	case *gatewayapisv1alpha2.HTTPRoute:
+		addParentGateways(gatewayNames, data.Namespace, data.Spec.ParentRefs)
+	case *gatewayapisv1alpha2.TCPRoute:
+		addParentGateways(gatewayNames, data.Namespace, data.Spec.ParentRefs)
+	case *gatewayapisv1alpha2.TLSRoute:
+		addParentGateways(gatewayNames, data.Namespace, data.Spec.ParentRefs)
+	}
+	return gatewayNames
+}
+
+// addParentGateways adds the gateways referenced by the parent references of a route.
+// A route may be attached to multiple gateways, or multiple times to the same gateway with different sections.
+func addParentGateways(gatewayNames resources.ObjectNameSet, routeNamespace string, refs []gatewayapisv1.ParentReference) {
+	for _, ref := range refs {
+		if (ref.Group == nil || string(*ref.Group) == GroupKindGateway.Group) &&
+			(ref.Kind == nil || string(*ref.Kind) == GroupKindGateway.Kind) {
+			namespace := routeNamespace
+			if ref.Namespace != nil {
+				namespace = string(*ref.Namespace)
+			}
+			gatewayNames.Add(resources.NewObjectName(namespace, string(ref.Name)))
+		}
+	}
+}
+
+// routeKey returns the object key of a route. The group kind is derived from the type,
+// as the type meta is not always set for objects from the cache.
+func routeKey(route resources.ObjectData) resources.ObjectKey {
+	gk := GroupKindHTTPRoute
+	switch route.(type) {
+	case *gatewayapisv1alpha2.TCPRoute:
+		gk = GroupKindTCPRoute
+	case *gatewayapisv1alpha2.TLSRoute:
+		gk = GroupKindTLSRoute
+	}
+	return resources.NewKey(gk, route.GetNamespace(), route.GetName())
+}
@@ -17,12 +17,12 @@ var stateKey = ctxutil.SimpleKey("gatewayapi-gateways-state")
 type routesState struct {
 	lock sync.Mutex
 
-	routesToGateways map[resources.ObjectName][]resources.ObjectName
+	routesToGateways map[resources.ObjectKey][]resources.ObjectName
 }
 
 func newState() *routesState {
 	return &routesState{
-		routesToGateways: map[resources.ObjectName][]resources.ObjectName{},
+		routesToGateways: map[resources.ObjectKey][]resources.ObjectName{},
 	}
 }
 
@@ -34,7 +34,7 @@ func getOrCreateSharedState(c controller.Interface) (*routesState, error) {
 	return state, nil
 }
 
-func (s *routesState) AddRoute(route resources.ObjectName, gateways resources.ObjectNameSet) []resources.ObjectName {
+func (s *routesState) AddRoute(route resources.ObjectKey, gateways resources.ObjectNameSet) []resources.ObjectName {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
@@ -48,14 +48,14 @@ func (s *routesState) AddRoute(route resources.ObjectName, gateways resources.Ob
 	return oldGateways
 }
 
-func (s *routesState) RemoveRoute(route resources.ObjectName) {
+func (s *routesState) RemoveRoute(route resources.ObjectKey) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
 	delete(s.routesToGateways, route)
 }
 
-func (s *routesState) MatchingGatewaysByRoute(route resources.ObjectName) []resources.ObjectName {
+func (s *routesState) MatchingGatewaysByRoute(route resources.ObjectKey) []resources.ObjectName {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
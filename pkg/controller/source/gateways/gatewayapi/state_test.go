@@ -15,8 +15,8 @@ var _ = Describe("routesState", func() {
 		state        *routesState
 		gatewayName1 resources.ObjectName
 		gatewayName2 resources.ObjectName
-		routeName1   resources.ObjectName
-		routeName2   resources.ObjectName
+		routeName1   resources.ObjectKey
+		routeName2   resources.ObjectKey
 		gatewayNames resources.ObjectNameSet
 	)
 
@@ -24,8 +24,8 @@ var _ = Describe("routesState", func() {
 		state = newState()
 		gatewayName1 = resources.NewObjectName("foo", "gateway1")
 		gatewayName2 = resources.NewObjectName("foo", "gateway2")
-		routeName1 = resources.NewObjectName("foo", "route1")
-		routeName2 = resources.NewObjectName("foo", "route2")
+		routeName1 = resources.NewKey(GroupKindHTTPRoute, "foo", "route1")
+		routeName2 = resources.NewKey(GroupKindHTTPRoute, "foo", "route2")
 		gatewayNames = resources.NewObjectNameSet(gatewayName1, gatewayName2)
 	})
 
@@ -49,6 +49,18 @@ var _ = Describe("routesState", func() {
 		})
 	})
 
+	Describe("route kinds", func() {
+		It("should distinguish routes of different kinds with the same name", func() {
+			tlsRouteName1 := resources.NewKey(GroupKindTLSRoute, "foo", "route1")
+			state.AddRoute(routeName1, resources.NewObjectNameSet(gatewayName1))
+			state.AddRoute(tlsRouteName1, resources.NewObjectNameSet(gatewayName2))
+			Expect(state.MatchingGatewaysByRoute(routeName1)).To(ConsistOf(gatewayName1))
+			Expect(state.MatchingGatewaysByRoute(tlsRouteName1)).To(ConsistOf(gatewayName2))
+			state.RemoveRoute(tlsRouteName1)
+			Expect(state.MatchingGatewaysByRoute(routeName1)).To(ConsistOf(gatewayName1))
+		})
+	})
+
 	Describe("RemoveRoute", func() {
 		Context("when removing an existing route", func() {
 			BeforeEach(func() {
@@ -5,14 +5,22 @@
 package service
 
 import (
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/cluster"
 	"github.com/gardener/controller-manager-library/pkg/resources"
 	"github.com/gardener/external-dns-management/pkg/dns/source"
 )
 
-var MainResource = resources.NewGroupKind("core", "Service")
+var (
+	MainResource           = resources.NewGroupKind("core", "Service")
+	GroupKindEndpointSlice = resources.NewGroupKind("discovery.k8s.io", "EndpointSlice")
+)
 
 func init() {
-	source.DNSSourceController(source.NewDNSSouceTypeForExtractor("service-dns", MainResource, GetTargets), nil).
+	source.DNSSourceController(source.NewDNSSouceTypeForCreator("service-dns", MainResource, NewServiceSource), nil).
 		FinalizerDomain("dns.gardener.cloud").
+		Reconciler(EndpointSlicesReconciler, "endpointslices").
+		Cluster(cluster.DEFAULT).
+		WorkerPool("endpointslices", 2, 0).
+		ReconcilerSelectedWatchesByGK("endpointslices", serviceEndpointSlices, GroupKindEndpointSlice).
 		MustRegister(source.CONTROLLER_GROUP_DNS_SOURCES)
 }
@@ -0,0 +1,216 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/reconcile"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	api "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/gardener/external-dns-management/pkg/controller/annotation/annotations"
+	"github.com/gardener/external-dns-management/pkg/dns/source"
+)
+
+// serviceEndpointSlices restricts the watch to EndpointSlices owned by a service.
+func serviceEndpointSlices(_ controller.Interface) (string, resources.TweakListOptionsFunc) {
+	return "", func(options *metav1.ListOptions) {
+		// a bare label key selects all objects having this label
+		options.LabelSelector = discoveryv1.LabelServiceName
+	}
+}
+
+type endpointSliceLister interface {
+	// ListEndpointSlices lists the EndpointSlices of the given service.
+	ListEndpointSlices(namespace, service string) ([]*discoveryv1.EndpointSlice, error)
+}
+
+type clusterEndpointSliceLister struct {
+	resources resources.Interface
+}
+
+func newEndpointSliceLister(c controller.Interface) (*clusterEndpointSliceLister, error) {
+	res, err := c.GetMainCluster().Resources().GetByGK(GroupKindEndpointSlice)
+	if err != nil {
+		return nil, err
+	}
+	return &clusterEndpointSliceLister{resources: res}, nil
+}
+
+func (l *clusterEndpointSliceLister) ListEndpointSlices(namespace, service string) ([]*discoveryv1.EndpointSlice, error) {
+	selector := labels.SelectorFromSet(labels.Set{discoveryv1.LabelServiceName: service})
+	objs, err := l.resources.Namespace(namespace).ListCached(selector)
+	if err != nil {
+		return nil, err
+	}
+	var slices []*discoveryv1.EndpointSlice
+	for _, obj := range objs {
+		if slice, ok := obj.Data().(*discoveryv1.EndpointSlice); ok {
+			slices = append(slices, slice)
+		}
+	}
+	return slices, nil
+}
+
+// readyAddresses returns the addresses of all ready endpoints of the given EndpointSlices.
+// Endpoints with unknown readiness are considered ready.
+func readyAddresses(slices ...*discoveryv1.EndpointSlice) utils.StringSet {
+	addresses := utils.StringSet{}
+	for _, slice := range slices {
+		if slice.AddressType != discoveryv1.AddressTypeIPv4 && slice.AddressType != discoveryv1.AddressTypeIPv6 {
+			continue
+		}
+		for _, endpoint := range slice.Endpoints {
+			if endpoint.Conditions.Ready != nil && !*endpoint.Conditions.Ready {
+				continue
+			}
+			addresses.Add(endpoint.Addresses...)
+		}
+	}
+	return addresses
+}
+
+// EndpointSlicesReconciler creates the reconciler for EndpointSlices, which triggers the owning services
+// if the ready addresses of an EndpointSlice have changed.
+func EndpointSlicesReconciler(c controller.Interface) (reconcile.Interface, error) {
+	return &endpointSlicesReconciler{
+		controller:  c,
+		annotations: annotations.GetOrCreateWatches(c),
+		state:       newEndpointSlicesState(),
+	}, nil
+}
+
+var _ reconcile.Interface = &endpointSlicesReconciler{}
+
+type endpointSlicesReconciler struct {
+	reconcile.DefaultReconciler
+	controller  controller.Interface
+	annotations *annotations.State
+	state       *endpointSlicesState
+}
+
+func (r *endpointSlicesReconciler) Reconcile(logger logger.LogContext, obj resources.Object) reconcile.Status {
+	slice, ok := obj.Data().(*discoveryv1.EndpointSlice)
+	if !ok {
+		return reconcile.Succeeded(logger)
+	}
+	service := slice.Labels[discoveryv1.LabelServiceName]
+	if service != "" && !r.isAnnotatedHeadlessService(obj.ClusterKey().Cluster(), obj.GetNamespace(), service) {
+		// forget the slice, the service is triggered by its own watch once it becomes relevant
+		service = ""
+	}
+	services := r.state.Update(obj.ObjectName(), service, readyAddresses(slice))
+	r.triggerServices(obj.ClusterKey().Cluster(), services)
+	return reconcile.Succeeded(logger)
+}
+
+func (r *endpointSlicesReconciler) Delete(logger logger.LogContext, obj resources.Object) reconcile.Status {
+	r.triggerServices(obj.ClusterKey().Cluster(), r.state.Remove(obj.ObjectName()))
+	return reconcile.Succeeded(logger)
+}
+
+func (r *endpointSlicesReconciler) Deleted(logger logger.LogContext, key resources.ClusterObjectKey) reconcile.Status {
+	r.triggerServices(key.Cluster(), r.state.Remove(key.ObjectName()))
+	return reconcile.Succeeded(logger)
+}
+
+// isAnnotatedHeadlessService checks whether the given service is a headless service with DNS names,
+// either annotated directly or injected by a DNSAnnotation object.
+func (r *endpointSlicesReconciler) isAnnotatedHeadlessService(cluster, namespace, name string) bool {
+	key := resources.NewClusterKey(cluster, MainResource, namespace, name)
+	obj, err := r.controller.GetCachedObject(key)
+	if err != nil {
+		return false
+	}
+	svc, ok := obj.Data().(*api.Service)
+	return ok && isAnnotatedHeadlessService(svc, r.annotations.GetInfoFor(key))
+}
+
+func isAnnotatedHeadlessService(svc *api.Service, injected annotations.Annotations) bool {
+	if svc.Spec.ClusterIP != api.ClusterIPNone {
+		return false
+	}
+	return svc.Annotations[source.DNS_ANNOTATION] != "" || injected[source.DNS_ANNOTATION] != ""
+}
+
+func (r *endpointSlicesReconciler) triggerServices(cluster string, services resources.ObjectNameSet) {
+	for s := range services {
+		_ = r.controller.EnqueueKey(resources.NewClusterKeyForObject(cluster, s.ForGroupKind(MainResource)))
+	}
+}
+
+type endpointSliceInfo struct {
+	service   resources.ObjectName
+	addresses string
+}
+
+// endpointSlicesState remembers the service and ready addresses of each EndpointSlice.
+// Services are only triggered if the ready addresses have changed, so that the frequent status updates
+// of EndpointSlices, e.g. during rolling updates, do not cause unnecessary reconciliations.
+type endpointSlicesState struct {
+	lock   sync.Mutex
+	slices map[resources.ObjectName]endpointSliceInfo
+}
+
+func newEndpointSlicesState() *endpointSlicesState {
+	return &endpointSlicesState{slices: map[resources.ObjectName]endpointSliceInfo{}}
+}
+
+// Update stores the service and ready addresses of an EndpointSlice and returns the services to trigger.
+func (s *endpointSlicesState) Update(name resources.ObjectName, service string, addresses utils.StringSet) resources.ObjectNameSet {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	services := resources.NewObjectNameSet()
+	old, found := s.slices[name]
+	if service == "" {
+		delete(s.slices, name)
+		if found {
+			services.Add(old.service)
+		}
+		return services
+	}
+	info := endpointSliceInfo{
+		service:   resources.NewObjectName(name.Namespace(), service),
+		addresses: joinAddresses(addresses),
+	}
+	s.slices[name] = info
+	if found && old == info {
+		return services
+	}
+	services.Add(info.service)
+	if found {
+		services.Add(old.service)
+	}
+	return services
+}
+
+// Remove forgets an EndpointSlice and returns the service to trigger.
+func (s *endpointSlicesState) Remove(name resources.ObjectName) resources.ObjectNameSet {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	services := resources.NewObjectNameSet()
+	if old, found := s.slices[name]; found {
+		delete(s.slices, name)
+		services.Add(old.service)
+	}
+	return services
+}
+
+func joinAddresses(addresses utils.StringSet) string {
+	list := addresses.AsArray()
+	sort.Strings(list)
+	return strings.Join(list, ",")
+}
@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"testing"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	api "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/gardener/external-dns-management/pkg/controller/annotation/annotations"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/source"
+)
+
+type testEndpointSliceLister map[string][]*discoveryv1.EndpointSlice
+
+func (l testEndpointSliceLister) ListEndpointSlices(namespace, service string) ([]*discoveryv1.EndpointSlice, error) {
+	return l[namespace+"/"+service], nil
+}
+
+func newEndpointSlice(addressType discoveryv1.AddressType, endpoints ...discoveryv1.Endpoint) *discoveryv1.EndpointSlice {
+	return &discoveryv1.EndpointSlice{AddressType: addressType, Endpoints: endpoints}
+}
+
+func newEndpoint(ready *bool, addresses ...string) discoveryv1.Endpoint {
+	return discoveryv1.Endpoint{Addresses: addresses, Conditions: discoveryv1.EndpointConditions{Ready: ready}}
+}
+
+func TestReadyAddresses(t *testing.T) {
+	addresses := readyAddresses(
+		newEndpointSlice(discoveryv1.AddressTypeIPv4,
+			newEndpoint(ptr.To(true), "10.0.0.1"),
+			newEndpoint(nil, "10.0.0.2"),
+			newEndpoint(ptr.To(false), "10.0.0.3"),
+		),
+		newEndpointSlice(discoveryv1.AddressTypeIPv6, newEndpoint(ptr.To(true), "fd00::1")),
+		newEndpointSlice(discoveryv1.AddressTypeFQDN, newEndpoint(ptr.To(true), "host.example.com")),
+	)
+	expected := utils.NewStringSet("10.0.0.1", "10.0.0.2", "fd00::1")
+	if !addresses.Equals(expected) {
+		t.Errorf("expected %s, but got %s", expected, addresses)
+	}
+}
+
+func TestHeadlessServiceTargets(t *testing.T) {
+	lister := testEndpointSliceLister{
+		"ns/headless": {
+			newEndpointSlice(discoveryv1.AddressTypeIPv4, newEndpoint(ptr.To(true), "10.0.0.1"), newEndpoint(ptr.To(false), "10.0.0.2")),
+			newEndpointSlice(discoveryv1.AddressTypeIPv6, newEndpoint(ptr.To(true), "fd00::1")),
+		},
+	}
+	targets := &serviceTargets{lister: lister}
+	names := dns.NewDNSNameSet(dns.DNSSetName{DNSName: "headless.example.com"})
+
+	headless := &api.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "headless"},
+		Spec:       api.ServiceSpec{Type: api.ServiceTypeClusterIP, ClusterIP: api.ClusterIPNone},
+	}
+	extraction, err := targets.getTargets(logger.New(), headless, names)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if expected := utils.NewStringSet("10.0.0.1", "fd00::1"); !extraction.Targets.Equals(expected) {
+		t.Errorf("expected targets %s, but got %s", expected, extraction.Targets)
+	}
+
+	extraction, err = targets.getTargets(logger.New(), headless, dns.DNSNameSet{})
+	if err != nil || extraction != nil {
+		t.Errorf("expected no extraction without names, but got %v, %v", extraction, err)
+	}
+
+	clusterIP := &api.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "headless"},
+		Spec:       api.ServiceSpec{Type: api.ServiceTypeClusterIP, ClusterIP: "10.1.0.1"},
+	}
+	if _, err := targets.getTargets(logger.New(), clusterIP, names); err == nil {
+		t.Errorf("expected error for service of type ClusterIP")
+	}
+
+	var _ source.DNSSource = newServiceSourceWithEndpointSliceLister(lister)
+}
+
+func TestEndpointSlicesStateTriggersOnChangedAddresses(t *testing.T) {
+	state := newEndpointSlicesState()
+	slice := resources.NewObjectName("ns", "svc-abcde")
+	service := resources.NewObjectName("ns", "svc")
+
+	if services := state.Update(slice, "svc", utils.NewStringSet("10.0.0.1", "10.0.0.2")); !services.Equals(resources.NewObjectNameSet(service)) {
+		t.Errorf("expected service to be triggered for new slice, but got %s", services)
+	}
+	if services := state.Update(slice, "svc", utils.NewStringSet("10.0.0.2", "10.0.0.1")); len(services) != 0 {
+		t.Errorf("expected no trigger for unchanged addresses, but got %s", services)
+	}
+	if services := state.Update(slice, "svc", utils.NewStringSet("10.0.0.1")); !services.Equals(resources.NewObjectNameSet(service)) {
+		t.Errorf("expected service to be triggered for changed addresses, but got %s", services)
+	}
+	other := resources.NewObjectName("ns", "other")
+	if services := state.Update(slice, "other", utils.NewStringSet("10.0.0.1")); !services.Equals(resources.NewObjectNameSet(service, other)) {
+		t.Errorf("expected both services to be triggered on changed service, but got %s", services)
+	}
+	if services := state.Remove(slice); !services.Equals(resources.NewObjectNameSet(other)) {
+		t.Errorf("expected service to be triggered on removal, but got %s", services)
+	}
+	if services := state.Remove(slice); len(services) != 0 {
+		t.Errorf("expected no trigger for unknown slice, but got %s", services)
+	}
+}
+
+func TestIsAnnotatedHeadlessService(t *testing.T) {
+	headless := &api.Service{Spec: api.ServiceSpec{ClusterIP: api.ClusterIPNone}}
+	if isAnnotatedHeadlessService(headless, nil) {
+		t.Errorf("expected headless service without annotation to be ignored")
+	}
+	if !isAnnotatedHeadlessService(headless, annotations.Annotations{source.DNS_ANNOTATION: "a.example.com"}) {
+		t.Errorf("expected headless service with injected annotation to be relevant")
+	}
+	headless.Annotations = map[string]string{source.DNS_ANNOTATION: "a.example.com"}
+	if !isAnnotatedHeadlessService(headless, nil) {
+		t.Errorf("expected annotated headless service to be relevant")
+	}
+	clusterIP := &api.Service{
+		ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{source.DNS_ANNOTATION: "a.example.com"}},
+		Spec:       api.ServiceSpec{ClusterIP: "10.1.0.1"},
+	}
+	if isAnnotatedHeadlessService(clusterIP, nil) {
+		t.Errorf("expected annotated service with cluster IP to be ignored")
+	}
+}
@@ -7,6 +7,7 @@ package service
 import (
 	"fmt"
 
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
 	"github.com/gardener/controller-manager-library/pkg/logger"
 	"github.com/gardener/controller-manager-library/pkg/resources"
 	"github.com/gardener/controller-manager-library/pkg/utils"
@@ -16,6 +17,47 @@ import (
 	"k8s.io/utils/ptr"
 )
 
+// NewServiceSource is the DNSSource for services.
+// Services of type LoadBalancer use the addresses of their load balancers as targets,
+// headless services the addresses of the ready endpoints of their EndpointSlices.
+func NewServiceSource(c controller.Interface) (source.DNSSource, error) {
+	lister, err := newEndpointSliceLister(c)
+	if err != nil {
+		return nil, err
+	}
+	return newServiceSourceWithEndpointSliceLister(lister), nil
+}
+
+func newServiceSourceWithEndpointSliceLister(lister endpointSliceLister) source.DNSSource {
+	t := &serviceTargets{lister: lister}
+	s := source.NewDefaultDNSSource(t.getTargets)
+	return &s
+}
+
+type serviceTargets struct {
+	lister endpointSliceLister
+}
+
+func (t *serviceTargets) getTargets(logger logger.LogContext, obj resources.ObjectData, names dns.DNSNameSet) (*source.TargetExtraction, error) {
+	svc := obj.(*api.Service)
+	if svc.Spec.ClusterIP != api.ClusterIPNone {
+		return GetTargets(logger, obj, names)
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+	slices, err := t.lister.ListEndpointSlices(svc.Namespace, svc.Name)
+	if err != nil {
+		return nil, err
+	}
+	extraction := &source.TargetExtraction{Targets: readyAddresses(slices...)}
+	if v := svc.Annotations[dns.AnnotationIgnore]; v != "" {
+		extraction.Ignore = v == "true"
+	}
+	return extraction, nil
+}
+
+// GetTargets returns the addresses of the load balancer of a service of type LoadBalancer as targets.
 func GetTargets(_ logger.LogContext, obj resources.ObjectData, names dns.DNSNameSet) (*source.TargetExtraction, error) {
 	svc := obj.(*api.Service)
 	if svc.Spec.Type != api.ServiceTypeLoadBalancer {
@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dns
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var caaTagRegexp = regexp.MustCompile(`^[a-zA-Z0-9]+$`)
+
+// CAARecord is the parsed value of a CAA record in the format `flags tag value`.
+type CAARecord struct {
+	Flags uint8
+	Tag   string
+	Value string
+}
+
+// ParseCAARecord parses and validates a CAA record value in the format `flags tag value`.
+// The value may be quoted, e.g. `0 issue "letsencrypt.org"`. The tag is normalized to lower case.
+func ParseCAARecord(value string) (*CAARecord, error) {
+	fields := strings.Fields(value)
+	if len(fields) < 3 {
+		return nil, fmt.Errorf("invalid CAA record %q: expected format 'flags tag value'", value)
+	}
+	flags, err := strconv.ParseUint(fields[0], 10, 8)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CAA record %q: flags must be a number between 0 and 255", value)
+	}
+	tag := fields[1]
+	if !caaTagRegexp.MatchString(tag) {
+		return nil, fmt.Errorf("invalid CAA record %q: tag must consist of alphanumeric characters", value)
+	}
+	rest := strings.TrimSpace(value)
+	for i := 0; i < 2; i++ {
+		rest = strings.TrimSpace(strings.TrimPrefix(rest, fields[i]))
+	}
+	if len(rest) >= 2 && strings.HasPrefix(rest, `"`) && strings.HasSuffix(rest, `"`) {
+		rest = rest[1 : len(rest)-1]
+	}
+	if strings.Contains(rest, `"`) {
+		return nil, fmt.Errorf("invalid CAA record %q: value must not contain quotes", value)
+	}
+	return &CAARecord{Flags: uint8(flags), Tag: strings.ToLower(tag), Value: rest}, nil
+}
+
+// String returns the normalized record value with quoted value.
+func (r *CAARecord) String() string {
+	return fmt.Sprintf("%d %s %q", r.Flags, r.Tag, r.Value)
+}
+
+// NormalizeCAAValue returns the normalized CAA record value, or the unchanged value if it cannot be parsed.
+func NormalizeCAAValue(value string) string {
+	if r, err := ParseCAARecord(value); err == nil {
+		return r.String()
+	}
+	return value
+}
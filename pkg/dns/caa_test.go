@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dns
+
+import (
+	"testing"
+)
+
+func TestParseCAARecord(t *testing.T) {
+	table := []struct {
+		input      string
+		ok         bool
+		normalized string
+	}{
+		{`0 issue "letsencrypt.org"`, true, `0 issue "letsencrypt.org"`},
+		{`0 issue letsencrypt.org`, true, `0 issue "letsencrypt.org"`},
+		{`  128  ISSUEWILD   ";" `, true, `128 issuewild ";"`},
+		{`0 iodef "mailto:security@example.com"`, true, `0 iodef "mailto:security@example.com"`},
+		{`0 issue "ca.example.net; account=230123"`, true, `0 issue "ca.example.net; account=230123"`},
+		{`0 issue ""`, true, `0 issue ""`},
+		{`0 issue`, false, ""},
+		{`256 issue "letsencrypt.org"`, false, ""},
+		{`-1 issue "letsencrypt.org"`, false, ""},
+		{`x issue "letsencrypt.org"`, false, ""},
+		{`0 is-sue "letsencrypt.org"`, false, ""},
+		{`0 issue "lets"encrypt.org"`, false, ""},
+		{"", false, ""},
+	}
+	for _, entry := range table {
+		r, err := ParseCAARecord(entry.input)
+		if entry.ok && err != nil {
+			t.Errorf("%q should be ok, but got error %s", entry.input, err)
+			continue
+		} else if !entry.ok {
+			if err == nil {
+				t.Errorf("%q should not be ok, but got no error", entry.input)
+			}
+			continue
+		}
+		if r.String() != entry.normalized {
+			t.Errorf("%q: expected normalized %q, but got %q", entry.input, entry.normalized, r.String())
+		}
+	}
+}
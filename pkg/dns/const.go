@@ -30,10 +30,57 @@ const (
 	AnnotationValueIPStackIPDualStack = "dual-stack"
 	AnnotationValueIPStackIPv6        = "ipv6"
 
+	// AnnotationAliasHostedZoneID is an optional annotation for DNSEntries to request alias target records
+	// for all hostname targets using the given hosted zone id of the alias target.
+	// This annotation is currently only relevant for AWS-Route53. Targets with a well-known AWS hostname
+	// (e.g. load balancers, CloudFront distributions, S3 website endpoints) are mapped to alias targets anyway.
+	AnnotationAliasHostedZoneID = ANNOTATION_GROUP + "/alias-hosted-zone-id"
+
 	// AnnotationIgnore is an optional annotation for DNSEntries and source resources to ignore them on reconciliation.
 	AnnotationIgnore = ANNOTATION_GROUP + "/ignore"
 	// AnnotationHardIgnore is an optional annotation for a generated target DNSEntry to ignore it on reconciliation.
 	// This annotation is not propagated from source objects to the target DNSEntry.
 	// IMPORTANT NOTE: The entry is even ignored on deletion, so use with caution to avoid orphaned entries.
 	AnnotationHardIgnore = ANNOTATION_GROUP + "/target-hard-ignore"
+
+	// AnnotationRateLimitPriority is an optional annotation for DNSEntries to specify the priority for the provider rate limiter.
+	// Values are 'normal' and 'high'. If not specified, 'normal' is assumed.
+	// Entries with priority 'high' are processed first and use a separate lane of the provider rate limiter with the same
+	// rate limit, so that they are not delayed by entries with normal priority.
+	AnnotationRateLimitPriority            = ANNOTATION_GROUP + "/rate-limit-priority"
+	AnnotationValueRateLimitPriorityNormal = "normal"
+	AnnotationValueRateLimitPriorityHigh   = "high"
+
+	// AnnotationImported marks DNSEntries created from records already existing in the DNS backend.
+	AnnotationImported = ANNOTATION_GROUP + "/imported"
+
+	// AnnotationProviderAttributePrefix is the prefix of optional annotations for DNSEntries to specify provider specific
+	// attributes of the DNS records. The attribute name is the remainder of the annotation key,
+	// e.g. 'dns.gardener.cloud/provider-attr-Site: berlin' sets the attribute 'Site' to 'berlin'.
+	// Attributes are only applied by providers supporting them (currently only Infoblox, as extensible attributes).
+	AnnotationProviderAttributePrefix = ANNOTATION_GROUP + "/provider-attr-"
+
+	// AnnotationAliasOf marks DNSEntries maintained for an additional DNS name (spec.dnsNames) of another DNSEntry.
+	// The value is the name of the DNSEntry in the same namespace.
+	AnnotationAliasOf = ANNOTATION_GROUP + "/alias-of"
+
+	// AnnotationSplitOf marks DNSEntries maintained for a further provider (spec.splitProviders) of another DNSEntry.
+	// The value is the name of the DNSEntry in the same namespace.
+	AnnotationSplitOf = ANNOTATION_GROUP + "/split-of"
+
+	// AnnotationMirrorOf marks DNSEntries maintained for the mirror provider of the provider of another DNSEntry.
+	// The value is the name of the DNSEntry in the same namespace.
+	AnnotationMirrorOf = ANNOTATION_GROUP + "/mirror-of"
+
+	// AnnotationReconcileInterval is an optional annotation for DNSEntries to specify the interval of periodic
+	// reconciliations as duration, e.g. '30s'. It is raised to the minimum given by option 'entry-reconcile-min-interval'.
+	AnnotationReconcileInterval = ANNOTATION_GROUP + "/reconcile-interval"
+
+	// AnnotationSourceKind, AnnotationSourceNamespace, and AnnotationSourceName record the source object a DNSEntry
+	// has been generated from by a source controller. The kind is given as `<kind>.<group>`, e.g. `Ingress.networking.k8s.io`.
+	AnnotationSourceKind      = ANNOTATION_GROUP + "/source-kind"
+	AnnotationSourceNamespace = ANNOTATION_GROUP + "/source-namespace"
+	AnnotationSourceName      = ANNOTATION_GROUP + "/source-name"
+	// AnnotationSourceTrigger records the annotation of the source object that triggered the generation of a DNSEntry.
+	AnnotationSourceTrigger = ANNOTATION_GROUP + "/source-trigger"
 )
@@ -68,10 +68,27 @@ func (dnssets DNSSets) AddRecordSet(name DNSSetName, policy *RoutingPolicy, rs *
 		dnssets[name] = dnsset
 	}
 	dnsset.Sets[rs.Type] = rs
-	if rs.Type == RS_CNAME {
+	switch rs.Type {
+	case RS_CNAME, RS_NS, RS_PTR:
 		for i := range rs.Records {
 			rs.Records[i].Value = NormalizeHostname(rs.Records[i].Value)
 		}
+	case RS_SRV:
+		for i := range rs.Records {
+			rs.Records[i].Value = NormalizeSRVValue(rs.Records[i].Value)
+		}
+	case RS_CAA:
+		for i := range rs.Records {
+			rs.Records[i].Value = NormalizeCAAValue(rs.Records[i].Value)
+		}
+	case RS_MX:
+		for i := range rs.Records {
+			rs.Records[i].Value = NormalizeMXValue(rs.Records[i].Value)
+		}
+	case RS_DS:
+		for i := range rs.Records {
+			rs.Records[i].Value = NormalizeDSValue(rs.Records[i].Value)
+		}
 	}
 	dnsset.RoutingPolicy = policy
 }
@@ -113,6 +130,13 @@ const (
 
 	ATTR_TIMESTAMP = "ts"
 	ATTR_LOCKID    = "lockid"
+
+	// ATTR_WRITER is the controller instance which has written the records of an owner id.
+	ATTR_WRITER = "writer"
+	// ATTR_WRITER_PRIORITY is the priority of the controller instance which has written the records.
+	ATTR_WRITER_PRIORITY = "writer-priority"
+	// ATTR_LEASE is the time (unix seconds) the writer has last renewed its lease on the records.
+	ATTR_LEASE = "lease"
 )
 
 type DNSSet struct {
@@ -28,8 +28,9 @@ func (n DNSSetName) Align() DNSSetName {
 	return n.WithDNSName(AlignHostname(n.DNSName))
 }
 
+// Normalize returns the set name with the DNS name in canonical form (see CanonicalHostname).
 func (n DNSSetName) Normalize() DNSSetName {
-	return n.WithDNSName(NormalizeHostname(n.DNSName))
+	return n.WithDNSName(CanonicalHostname(n.DNSName))
 }
 
 type DNSNameSet map[DNSSetName]struct{}
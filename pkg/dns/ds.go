@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dns
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// dsDigestLengths contains the digest lengths in bytes of the known DS digest types (RFC 4509, RFC 6605).
+var dsDigestLengths = map[uint8]int{
+	1: 20, // SHA-1
+	2: 32, // SHA-256
+	4: 48, // SHA-384
+}
+
+// DSRecord is the parsed value of a DS record in the format `keyTag algorithm digestType digest`.
+type DSRecord struct {
+	KeyTag     uint16
+	Algorithm  uint8
+	DigestType uint8
+	Digest     string
+}
+
+// ParseDSRecord parses and validates a DS record value in the format `keyTag algorithm digestType digest`.
+// The hexadecimal digest may be split by whitespace and is normalized to upper case.
+func ParseDSRecord(value string) (*DSRecord, error) {
+	fields := strings.Fields(value)
+	if len(fields) < 4 {
+		return nil, fmt.Errorf("invalid DS record %q: expected format 'keyTag algorithm digestType digest'", value)
+	}
+	keyTag, err := strconv.ParseUint(fields[0], 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DS record %q: key tag must be a number between 0 and 65535", value)
+	}
+	var numbers [2]uint8
+	for i, name := range []string{"algorithm", "digest type"} {
+		n, err := strconv.ParseUint(fields[i+1], 10, 8)
+		if err != nil {
+			return nil, fmt.Errorf("invalid DS record %q: %s must be a number between 0 and 255", value, name)
+		}
+		numbers[i] = uint8(n)
+	}
+	digest := strings.ToUpper(strings.Join(fields[3:], ""))
+	bytes, err := hex.DecodeString(digest)
+	if err != nil || len(bytes) == 0 {
+		return nil, fmt.Errorf("invalid DS record %q: digest must be a hexadecimal string", value)
+	}
+	if l, ok := dsDigestLengths[numbers[1]]; ok && len(bytes) != l {
+		return nil, fmt.Errorf("invalid DS record %q: digest of digest type %d must have %d bytes, but has %d", value, numbers[1], l, len(bytes))
+	}
+	return &DSRecord{KeyTag: uint16(keyTag), Algorithm: numbers[0], DigestType: numbers[1], Digest: digest}, nil
+}
+
+// String returns the normalized record value.
+func (r *DSRecord) String() string {
+	return fmt.Sprintf("%d %d %d %s", r.KeyTag, r.Algorithm, r.DigestType, r.Digest)
+}
+
+// NormalizeDSValue returns the normalized DS record value, or the unchanged value if it cannot be parsed.
+func NormalizeDSValue(value string) string {
+	if r, err := ParseDSRecord(value); err == nil {
+		return r.String()
+	}
+	return value
+}
@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dns
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseDSRecord(t *testing.T) {
+	sha256 := "2BB183AF5F22588179A53B0A98631FAD1A292118"
+	sha256 += "8C9F1E5A3F2D1B0C4A5D6E7F"
+	table := []struct {
+		input      string
+		ok         bool
+		normalized string
+	}{
+		{"60485 5 1 2bb183af5f22588179a53b0a98631fad1a292118", true, "60485 5 1 2BB183AF5F22588179A53B0A98631FAD1A292118"},
+		{" 60485  5 1 2BB183AF5F22588179A5 3B0A98631FAD1A292118 ", true, "60485 5 1 2BB183AF5F22588179A53B0A98631FAD1A292118"},
+		{"12345 13 2 " + sha256, true, "12345 13 2 " + sha256},
+		{"12345 13 4 " + strings.Repeat("AB", 48), true, "12345 13 4 " + strings.Repeat("AB", 48)},
+		{"12345 13 99 ABCD", true, "12345 13 99 ABCD"},
+		{"12345 13 2 " + sha256[:62], false, ""},
+		{"12345 13 2 " + sha256[:63], false, ""},
+		{"12345 13 2 XYZ", false, ""},
+		{"65536 13 2 " + sha256, false, ""},
+		{"12345 256 2 " + sha256, false, ""},
+		{"12345 13 x " + sha256, false, ""},
+		{"12345 13 2", false, ""},
+		{"", false, ""},
+	}
+	for _, entry := range table {
+		r, err := ParseDSRecord(entry.input)
+		if entry.ok && err != nil {
+			t.Errorf("%q should be ok, but got error %s", entry.input, err)
+			continue
+		} else if !entry.ok {
+			if err == nil {
+				t.Errorf("%q should not be ok, but got no error", entry.input)
+			}
+			continue
+		}
+		if r.String() != entry.normalized {
+			t.Errorf("%q: expected normalized %q, but got %q", entry.input, entry.normalized, r.String())
+		}
+	}
+}
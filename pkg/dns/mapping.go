@@ -31,6 +31,12 @@ func NormalizeHostname(host string) string {
 	return host
 }
 
+// CanonicalHostname returns the canonical form of a host name used for comparisons:
+// without trailing dot and in lower case, as DNS names are case-insensitive.
+func CanonicalHostname(host string) string {
+	return strings.ToLower(NormalizeHostname(host))
+}
+
 func MapToProvider(rtype string, dnsset *DNSSet, base string) (DNSSetName, *RecordSet) {
 	dnsName := dnsset.Name.DNSName
 	rs := dnsset.Sets[rtype]
@@ -45,6 +51,11 @@ func MapToProvider(rtype string, dnsset *DNSSet, base string) (DNSSetName, *Reco
 		new.Type = RS_TXT
 		return dnsset.Name.WithDNSName(metaName), &new
 	}
+	if rtype == RS_OWNERSHIP && rs != nil {
+		new := *rs
+		new.Type = RS_TXT
+		return dnsset.Name.WithDNSName(calcMetaRecordDomainName(dnsName, OwnershipPrefix, base)), &new
+	}
 	return dnsset.Name, rs
 }
 
@@ -77,29 +88,44 @@ func MapFromProvider(name DNSSetName, rs *RecordSet) (DNSSetName, *RecordSet) {
 	if rs.Type == RS_TXT {
 		prefix := rs.GetAttr(ATTR_PREFIX)
 		if prefix != "" {
-			add := ""
-			if strings.HasPrefix(dns, "*.") {
-				add = "*."
-				dns = dns[2:]
-			}
-			if strings.HasPrefix(dns, prefix) {
+			if orig, ok := stripMetaRecordPrefix(dns, prefix); ok {
 				new := *rs
 				new.Type = RS_META
-				dns = dns[len(prefix):]
-				if strings.HasPrefix(dns, "-base.") {
-					dns = dns[6:]
-				} else if strings.HasPrefix(dns, "---at.") {
-					dns = dns[6:]
-					add = "@."
-				} else {
-					// for backwards compatibility of form *.comment-.basedomain
-					dns = strings.TrimPrefix(dns, ".")
-				}
-				return name.WithDNSName(add + dns), &new
-			} else {
-				return name.WithDNSName(add + dns), rs
+				return name.WithDNSName(orig), &new
+			}
+			return name.WithDNSName(dns), rs
+		}
+		if GetOwnershipRecordOwner(rs) != "" {
+			if orig, ok := stripMetaRecordPrefix(dns, OwnershipPrefix); ok {
+				new := *rs
+				new.Type = RS_OWNERSHIP
+				return name.WithDNSName(orig), &new
 			}
 		}
 	}
 	return name.WithDNSName(dns), rs
 }
+
+// stripMetaRecordPrefix returns the original dns name of a record stored for a dns name composed of
+// the given prefix and the original name (see calcMetaRecordDomainName).
+func stripMetaRecordPrefix(dns, prefix string) (string, bool) {
+	add := ""
+	if strings.HasPrefix(dns, "*.") {
+		add = "*."
+		dns = dns[2:]
+	}
+	if !strings.HasPrefix(dns, prefix) {
+		return "", false
+	}
+	dns = dns[len(prefix):]
+	if strings.HasPrefix(dns, "-base.") {
+		dns = dns[6:]
+	} else if strings.HasPrefix(dns, "---at.") {
+		dns = dns[6:]
+		add = "@."
+	} else {
+		// for backwards compatibility of form *.comment-.basedomain
+		dns = strings.TrimPrefix(dns, ".")
+	}
+	return add + dns, true
+}
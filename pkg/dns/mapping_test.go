@@ -37,6 +37,46 @@ func TestNormalizeHostname(t *testing.T) {
 	}
 }
 
+func TestCanonicalHostname(t *testing.T) {
+	table := []struct {
+		input  string
+		wanted string
+	}{
+		{"a.b", "a.b"},
+		{"A.b.", "a.b"},
+		{"\\052.A.b.", "*.a.b"},
+	}
+	for _, entry := range table {
+		result := CanonicalHostname(entry.input)
+		if result != entry.wanted {
+			t.Errorf("%s: wanted %s, but got %s", entry.input, entry.wanted, result)
+		}
+	}
+}
+
+func TestNameForm(t *testing.T) {
+	table := []struct {
+		form   NameForm
+		input  string
+		wanted string
+	}{
+		{NameForm{}, "A.b.", "A.b"},
+		{NameForm{TrailingDot: true}, "A.b", "A.b."},
+		{NameForm{LowerCase: true}, "A.b.", "a.b"},
+		{NameForm{TrailingDot: true, LowerCase: true}, "A.b", "a.b."},
+	}
+	for _, entry := range table {
+		result := entry.form.Hostname(entry.input)
+		if result != entry.wanted {
+			t.Errorf("%+v %s: wanted %s, but got %s", entry.form, entry.input, entry.wanted, result)
+		}
+	}
+	name := NameForm{TrailingDot: true}.SetName(DNSSetName{DNSName: "a.b", SetIdentifier: "id"})
+	if name != (DNSSetName{DNSName: "a.b.", SetIdentifier: "id"}) {
+		t.Errorf("unexpected set name %s", name)
+	}
+}
+
 func TestMapToFromProvider(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -85,3 +125,40 @@ func TestMapToFromProvider(t *testing.T) {
 		Ω(reversedRecordSet.Records).Should(Equal(wantedRecords))
 	}
 }
+
+func TestMapOwnershipRecord(t *testing.T) {
+	RegisterTestingT(t)
+
+	table := []struct {
+		domainName string
+		wantedName string
+	}{
+		{"a.myzone.de", "extdns-a.myzone.de"},
+		{"*.a.myzone.de", "*.extdns-a.myzone.de"},
+		{"myzone.de", "extdns--base.myzone.de"},
+	}
+
+	for _, entry := range table {
+		dnsset := NewDNSSet(DNSSetName{DNSName: entry.domainName}, nil)
+		dnsset.Sets[RS_OWNERSHIP] = NewOwnershipRecordSet("my-owner")
+
+		actualName, actualRecordSet := MapToProvider(RS_OWNERSHIP, dnsset, "myzone.de")
+
+		Ω(actualName).Should(Equal(DNSSetName{DNSName: entry.wantedName}), "Name should match")
+		Ω(actualRecordSet.Type).Should(Equal(RS_TXT), "Type mismatch")
+		Ω(actualRecordSet.Records).Should(Equal(Records{&Record{"\"heritage=external-dns,external-dns/owner=my-owner\""}}))
+
+		reversedName, reversedRecordSet := MapFromProvider(actualName, actualRecordSet)
+
+		Ω(reversedName).Should(Equal(DNSSetName{DNSName: entry.domainName}), "Reversed name should match")
+		Ω(reversedRecordSet.Type).Should(Equal(RS_OWNERSHIP), "Reversed RecordSet.Type should match")
+		Ω(GetOwnershipRecordOwner(reversedRecordSet)).Should(Equal("my-owner"))
+	}
+
+	// ordinary TXT records are not mapped
+	txt := NewRecordSet(RS_TXT, 300, Records{&Record{"\"heritage=external-dns,external-dns/owner=my-owner\""}})
+	name, rs := MapFromProvider(DNSSetName{DNSName: "a.myzone.de"}, txt)
+	Ω(name).Should(Equal(DNSSetName{DNSName: "a.myzone.de"}))
+	Ω(rs.Type).Should(Equal(RS_TXT))
+	Ω(GetOwnershipRecordOwner(NewRecordSet(RS_TXT, 300, Records{&Record{"\"foo\""}}))).Should(BeEmpty())
+}
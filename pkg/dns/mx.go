@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dns
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// MXRecord is the parsed value of a MX record in the format `priority hostname`.
+type MXRecord struct {
+	Priority uint16
+	Hostname string
+}
+
+// ParseMXRecord parses and validates a MX record value in the format `priority hostname`.
+// The hostname is normalized (i.e. without trailing dot). A hostname `.` denotes a null MX record (RFC 7505).
+func ParseMXRecord(value string) (*MXRecord, error) {
+	fields := strings.Fields(value)
+	if len(fields) != 2 {
+		return nil, fmt.Errorf("invalid MX record %q: expected format 'priority hostname'", value)
+	}
+	priority, err := strconv.ParseUint(fields[0], 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MX record %q: priority must be a number between 0 and 65535", value)
+	}
+	hostname := fields[1]
+	if hostname != "." {
+		hostname = NormalizeHostname(hostname)
+		if errs := validation.IsDNS1123Subdomain(strings.ToLower(hostname)); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid MX record %q: hostname %q is no valid dns name (%v)", value, fields[1], errs)
+		}
+	}
+	return &MXRecord{Priority: uint16(priority), Hostname: hostname}, nil
+}
+
+// String returns the normalized record value.
+func (r *MXRecord) String() string {
+	return fmt.Sprintf("%d %s", r.Priority, r.Hostname)
+}
+
+// AlignedString returns the record value with a fully qualified hostname (i.e. with trailing dot).
+func (r *MXRecord) AlignedString() string {
+	return fmt.Sprintf("%d %s", r.Priority, AlignHostname(r.Hostname))
+}
+
+// NormalizeMXValue returns the normalized MX record value, or the unchanged value if it cannot be parsed.
+func NormalizeMXValue(value string) string {
+	if r, err := ParseMXRecord(value); err == nil {
+		return r.String()
+	}
+	return value
+}
+
+// AlignMXValue returns the MX record value with fully qualified hostname, or the unchanged value if it cannot be parsed.
+func AlignMXValue(value string) string {
+	if r, err := ParseMXRecord(value); err == nil {
+		return r.AlignedString()
+	}
+	return value
+}
@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dns
+
+import (
+	"testing"
+)
+
+func TestParseMXRecord(t *testing.T) {
+	table := []struct {
+		input      string
+		ok         bool
+		normalized string
+		aligned    string
+	}{
+		{"10 mail.example.com", true, "10 mail.example.com", "10 mail.example.com."},
+		{"10 mail.example.com.", true, "10 mail.example.com", "10 mail.example.com."},
+		{"  0   a.b ", true, "0 a.b", "0 a.b."},
+		{"0 .", true, "0 .", "0 ."},
+		{"65535 a.b", true, "65535 a.b", "65535 a.b."},
+		{"10", false, "", ""},
+		{"10 mail.example.com extra", false, "", ""},
+		{"-1 mail.example.com", false, "", ""},
+		{"65536 mail.example.com", false, "", ""},
+		{"x mail.example.com", false, "", ""},
+		{"10 mail_example.com", false, "", ""},
+		{"", false, "", ""},
+	}
+	for _, entry := range table {
+		r, err := ParseMXRecord(entry.input)
+		if entry.ok && err != nil {
+			t.Errorf("%q should be ok, but got error %s", entry.input, err)
+			continue
+		} else if !entry.ok {
+			if err == nil {
+				t.Errorf("%q should not be ok, but got no error", entry.input)
+			}
+			continue
+		}
+		if r.String() != entry.normalized {
+			t.Errorf("%q: expected normalized %q, but got %q", entry.input, entry.normalized, r.String())
+		}
+		if r.AlignedString() != entry.aligned {
+			t.Errorf("%q: expected aligned %q, but got %q", entry.input, entry.aligned, r.AlignedString())
+		}
+	}
+}
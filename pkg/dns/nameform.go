@@ -0,0 +1,34 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dns
+
+import "strings"
+
+// NameForm describes the canonical form of DNS names used by a provider when writing records.
+// Internally, DNS names are always kept without trailing dot and compared case-insensitively
+// (see CanonicalHostname), so that formatting differences of providers do not cause updates.
+type NameForm struct {
+	// TrailingDot is set if the provider expects fully qualified names with trailing dot.
+	TrailingDot bool
+	// LowerCase is set if the provider expects names in lower case.
+	LowerCase bool
+}
+
+// Hostname converts a host name into the form of the provider.
+func (f NameForm) Hostname(host string) string {
+	host = NormalizeHostname(host)
+	if f.LowerCase {
+		host = strings.ToLower(host)
+	}
+	if f.TrailingDot {
+		host = AlignHostname(host)
+	}
+	return host
+}
+
+// SetName converts the DNS name of a set name into the form of the provider.
+func (f NameForm) SetName(name DNSSetName) DNSSetName {
+	return name.WithDNSName(f.Hostname(name.DNSName))
+}
@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dns
+
+import (
+	"strconv"
+	"strings"
+)
+
+// RS_OWNERSHIP is the pseudo record type of ownership records in the format of external-dns.
+// Like META records, they are stored as TXT records for a separate dns name composed of
+// the OwnershipPrefix and the original name.
+const RS_OWNERSHIP = "OWNERSHIP"
+
+// OwnershipPrefix is the prefix of the dns names of ownership records.
+// To be recognized by external-dns, it must be configured with the same value for its option `--txt-prefix`.
+var OwnershipPrefix = "extdns-"
+
+const (
+	ownershipHeritage = "heritage=external-dns"
+	ownershipOwnerKey = "external-dns/owner"
+)
+
+// OwnershipRecordTTL is the TTL of ownership records.
+const OwnershipRecordTTL = 600
+
+// NewOwnershipRecordSet creates the record set of an ownership record for the given owner id.
+func NewOwnershipRecordSet(ownerID string) *RecordSet {
+	value := strconv.Quote(ownershipHeritage + "," + ownershipOwnerKey + "=" + ownerID)
+	return NewRecordSet(RS_OWNERSHIP, OwnershipRecordTTL, []*Record{{Value: value}})
+}
+
+// GetOwnershipRecordOwner returns the owner id of an ownership record set or an empty string
+// if the record set is no ownership record.
+func GetOwnershipRecordOwner(rs *RecordSet) string {
+	if rs == nil || len(rs.Records) != 1 {
+		return ""
+	}
+	value, err := strconv.Unquote(rs.Records[0].Value)
+	if err != nil || !strings.HasPrefix(value, ownershipHeritage+",") {
+		return ""
+	}
+	for _, field := range strings.Split(value, ",") {
+		if key, owner, ok := strings.Cut(field, "="); ok && key == ownershipOwnerKey {
+			return owner
+		}
+	}
+	return ""
+}
+
+// GetOwnershipRecordOwner returns the owner id of the ownership record of the set or an empty string.
+func (this *DNSSet) GetOwnershipRecordOwner() string {
+	return GetOwnershipRecordOwner(this.Sets[RS_OWNERSHIP])
+}
@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// additional DNS names (aliases) of DNS entries
+//
+// For each alias given in spec.dnsNames a DNS entry is maintained in the namespace
+// of the entry. It references the entry to inherit its targets and is owned by it.
+////////////////////////////////////////////////////////////////////////////////
+
+// normalizeAlias returns the normalized lower case DNS name of an alias.
+func normalizeAlias(alias string) string {
+	return strings.ToLower(dns.NormalizeHostname(alias))
+}
+
+// AliasEntryName returns the name of the DNS entry maintained for an alias of the given entry.
+func AliasEntryName(entryName, alias string) string {
+	return aliasEntries.entryName(entryName, normalizeAlias(alias))
+}
+
+// aliasEntrySpec returns the desired spec of the DNS entry maintained for an alias of the given entry.
+func aliasEntrySpec(entry *api.DNSEntry, alias string) api.DNSEntrySpec {
+	return dependentEntrySpec(entry, normalizeAlias(alias), entry.Spec.ProviderRef.DeepCopy())
+}
+
+// aliasEntryAnnotations returns the desired annotations of the DNS entry maintained for an alias of the given entry.
+func aliasEntryAnnotations(entry *api.DNSEntry) map[string]string {
+	return dependentEntryAnnotations(entry, aliasEntries)
+}
+
+// syncAliasEntries creates, updates, or deletes the DNS entries maintained for the aliases of the entry
+// and updates the alias states in the status of the entry.
+func (this *state) syncAliasEntries(logger logger.LogContext, object *dnsutils.DNSEntryObject) error {
+	if len(object.Spec().DNSNames) == 0 && len(object.Status().Aliases) == 0 && len(this.dependents.get(object.ObjectName())) == 0 {
+		return nil
+	}
+
+	entry := object.DNSEntry()
+	var desired []string
+	for _, alias := range object.Spec().DNSNames {
+		desired = append(desired, AliasEntryName(entry.Name, alias))
+	}
+	existing, err := this.getDependentEntries(aliasEntries, object, desired...)
+	if err != nil {
+		return err
+	}
+
+	var aliases []api.DNSEntryAliasStatus
+	if !object.IsDeleting() {
+		valid := object.Status().State != api.STATE_INVALID
+		for i, alias := range object.Spec().DNSNames {
+			name := desired[i]
+			status := api.DNSEntryAliasStatus{DNSName: alias, Entry: name}
+			if o := existing[name]; o != nil {
+				delete(existing, name)
+				status.State = o.Status().State
+				status.Message = o.Status().Message
+			}
+			if valid {
+				if err := this.assureDependentEntry(logger, object.GetResource(), entry, name, "alias "+alias, aliasEntrySpec(entry, alias), aliasEntryAnnotations(entry)); err != nil {
+					return err
+				}
+			}
+			aliases = append(aliases, status)
+		}
+	}
+	if err := deleteDependentEntries(logger, aliasEntries, existing); err != nil {
+		return err
+	}
+
+	if object.IsDeleting() {
+		return nil
+	}
+	_, err = object.ModifyStatus(func(data resources.ObjectData) (bool, error) {
+		status := &data.(*api.DNSEntry).Status
+		if reflect.DeepEqual(status.Aliases, aliases) {
+			return false, nil
+		}
+		status.Aliases = aliases
+		return true, nil
+	})
+	return err
+}
@@ -0,0 +1,166 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+)
+
+// ChangeBatchLimiter is an optional interface of a DNSHandler to limit the number of change requests
+// executed at once.
+// The batches are executed one after the other, so the changes of a zone are not atomic anymore if they are
+// split: if a batch fails, the preceding batches stay applied and the requests of the failed batch are retried
+// with the next reconciliation of the zone. Only the requests of a record set are always kept in the same batch.
+type ChangeBatchLimiter interface {
+	// MaxChangeBatchSize returns the maximum number of change requests executed at once (unlimited if 0).
+	MaxChangeBatchSize() int
+}
+
+// executeInBatches executes the change requests in batches of at most maxSize requests (unlimited if 0).
+// If the provider rejects a batch because it exceeds a provider limit (see errors.BatchTooLargeError),
+// the failed requests are split into halves and executed again.
+func executeInBatches(logger logger.LogContext, reqs []*ChangeRequest, maxSize int, execute func(batch []*ChangeRequest) error) error {
+	return executeBatches(logger, splitChangeRequests(reqs, maxSize), execute)
+}
+
+func executeBatches(logger logger.LogContext, batches [][]*ChangeRequest, execute func(batch []*ChangeRequest) error) error {
+	var errs []error
+	for _, batch := range batches {
+		if err := executeBatch(logger, batch, execute); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	return errors.Join(errs...)
+}
+
+func executeBatch(logger logger.LogContext, batch []*ChangeRequest, execute func(batch []*ChangeRequest) error) error {
+	recorders := make([]*recordingDoneHandler, len(batch))
+	for i, r := range batch {
+		recorders[i] = &recordingDoneHandler{inner: r.Done}
+		r.Done = recorders[i]
+	}
+	err := execute(batch)
+
+	var failed []*ChangeRequest
+	for i, r := range batch {
+		r.Done = recorders[i].inner
+		if !recorders[i].completed {
+			failed = append(failed, r)
+		}
+	}
+	if err != nil && len(failed) > 1 && perrs.IsBatchTooLargeError(err) {
+		// the requests of a single record set cannot be split any further
+		if batches := splitChangeRequests(failed, (len(failed)+1)/2); len(batches) > 1 {
+			logger.Infof("batch of %d change requests rejected as too large, retrying %d failed requests in smaller batches", len(batch), len(failed))
+			return executeBatches(logger, batches, execute)
+		}
+	}
+	for _, rec := range recorders {
+		rec.flush()
+	}
+	return err
+}
+
+// splitChangeRequests splits the change requests into batches of at most maxSize requests (unlimited if 0).
+// The requests of a record set are kept in the same batch, so that a record set is never changed partially,
+// even if the batch exceeds maxSize this way. The order of the requests is kept within a batch.
+func splitChangeRequests(reqs []*ChangeRequest, maxSize int) [][]*ChangeRequest {
+	if maxSize <= 0 || len(reqs) <= maxSize {
+		return [][]*ChangeRequest{reqs}
+	}
+	var keys []string
+	groups := map[string][]int{}
+	for i, r := range reqs {
+		key := recordSetKey(r)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], i)
+	}
+
+	var batches [][]*ChangeRequest
+	var indices []int
+	flush := func() {
+		sort.Ints(indices)
+		batch := make([]*ChangeRequest, len(indices))
+		for i, index := range indices {
+			batch[i] = reqs[index]
+		}
+		batches = append(batches, batch)
+		indices = nil
+	}
+	for _, key := range keys {
+		if len(indices) > 0 && len(indices)+len(groups[key]) > maxSize {
+			flush()
+		}
+		indices = append(indices, groups[key]...)
+	}
+	flush()
+	return batches
+}
+
+// recordSetKey returns the key of the record set changed by the request.
+func recordSetKey(r *ChangeRequest) string {
+	set := r.Addition
+	if set == nil {
+		set = r.Deletion
+	}
+	return set.Name.DNSName + "|" + r.Type
+}
+
+// recordingDoneHandler defers reporting a failure until it is clear that the request will not be retried.
+// All other outcomes are reported immediately.
+type recordingDoneHandler struct {
+	inner     DoneHandler
+	completed bool
+	err       error
+}
+
+var _ DryRunDoneHandler = &recordingDoneHandler{}
+
+func (h *recordingDoneHandler) SetInvalid(err error) {
+	h.completed = true
+	if h.inner != nil {
+		h.inner.SetInvalid(err)
+	}
+}
+
+func (h *recordingDoneHandler) Failed(err error) {
+	h.err = err
+}
+
+func (h *recordingDoneHandler) Throttled() {
+	h.completed = true
+	if h.inner != nil {
+		h.inner.Throttled()
+	}
+}
+
+func (h *recordingDoneHandler) Succeeded() {
+	h.completed = true
+	if h.inner != nil {
+		h.inner.Succeeded()
+	}
+}
+
+func (h *recordingDoneHandler) DryRun(planned string) {
+	if d, ok := h.inner.(DryRunDoneHandler); ok {
+		d.DryRun(planned)
+	}
+}
+
+func (h *recordingDoneHandler) flush() {
+	if !h.completed && h.err != nil && h.inner != nil {
+		h.inner.Failed(h.err)
+	}
+}
@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+)
+
+type testBatchDoneHandler struct {
+	failed    int
+	succeeded int
+}
+
+func (h *testBatchDoneHandler) SetInvalid(_ error) {}
+func (h *testBatchDoneHandler) Failed(_ error)     { h.failed++ }
+func (h *testBatchDoneHandler) Throttled()         {}
+func (h *testBatchDoneHandler) Succeeded()         { h.succeeded++ }
+
+var _ = ginkgov2.Describe("Batch execution", func() {
+	var (
+		done    *testBatchDoneHandler
+		reqs    []*ChangeRequest
+		batches []int
+	)
+
+	ginkgov2.BeforeEach(func() {
+		done = &testBatchDoneHandler{}
+		reqs = nil
+		batches = nil
+		for i := 0; i < 10; i++ {
+			set := newTestDNSSet(fmt.Sprintf("a%d.example.com", i), "1.1.1.1")
+			reqs = append(reqs, NewChangeRequest(R_CREATE, dns.RS_A, nil, set, done))
+		}
+	})
+
+	// executor simulates a provider rejecting batches larger than the given limit
+	executor := func(limit int) func(batch []*ChangeRequest) error {
+		return func(batch []*ChangeRequest) error {
+			batches = append(batches, len(batch))
+			if len(batch) > limit {
+				err := perrs.NewBatchTooLargeError(fmt.Errorf("limit of %d exceeded", limit))
+				for _, r := range batch {
+					r.Done.Failed(err)
+				}
+				return err
+			}
+			for _, r := range batch {
+				r.Done.Succeeded()
+			}
+			return nil
+		}
+	}
+
+	ginkgov2.It("splits the requests by the maximum batch size", func() {
+		Expect(executeInBatches(logger.New(), reqs, 4, executor(10))).To(Succeed())
+		Expect(batches).To(Equal([]int{4, 4, 2}))
+		Expect(done.succeeded).To(Equal(10))
+	})
+
+	ginkgov2.It("retries with smaller batches if a batch is too large", func() {
+		Expect(executeInBatches(logger.New(), reqs, 0, executor(3))).To(Succeed())
+		Expect(batches).To(Equal([]int{10, 5, 3, 2, 5, 3, 2}))
+		Expect(done.succeeded).To(Equal(10))
+		Expect(done.failed).To(Equal(0))
+		for _, r := range reqs {
+			Expect(r.Applied).To(BeTrue())
+		}
+	})
+
+	ginkgov2.It("reports failure if a single request is too large", func() {
+		err := executeInBatches(logger.New(), reqs[:2], 0, executor(0))
+		Expect(perrs.IsBatchTooLargeError(err)).To(BeTrue())
+		Expect(batches).To(Equal([]int{2, 1, 1}))
+		Expect(done.failed).To(Equal(2))
+		Expect(done.succeeded).To(Equal(0))
+	})
+
+	ginkgov2.It("does not retry on other errors", func() {
+		err := executeInBatches(logger.New(), reqs, 0, func(batch []*ChangeRequest) error {
+			batches = append(batches, len(batch))
+			for _, r := range batch {
+				r.Done.Failed(fmt.Errorf("failed"))
+			}
+			return fmt.Errorf("failed")
+		})
+		Expect(err).To(MatchError("failed"))
+		Expect(batches).To(Equal([]int{10}))
+		Expect(done.failed).To(Equal(10))
+	})
+
+	ginkgov2.It("keeps the requests of a record set in the same batch", func() {
+		update := newTestDNSSet("a1.example.com", "2.2.2.2")
+		reqs = append(reqs, NewChangeRequest(R_UPDATE, dns.RS_A, reqs[1].Addition, update, done))
+		Expect(executeInBatches(logger.New(), reqs, 4, executor(10))).To(Succeed())
+		Expect(batches).To(Equal([]int{4, 4, 3}))
+		Expect(done.succeeded).To(Equal(11))
+	})
+
+	ginkgov2.It("does not split the requests of a single record set on retry", func() {
+		set := newTestDNSSet("a.example.com", "1.1.1.1")
+		single := []*ChangeRequest{
+			NewChangeRequest(R_DELETE, dns.RS_A, set, nil, done),
+			NewChangeRequest(R_CREATE, dns.RS_A, nil, set, done),
+		}
+		err := executeInBatches(logger.New(), single, 0, executor(1))
+		Expect(perrs.IsBatchTooLargeError(err)).To(BeTrue())
+		Expect(batches).To(Equal([]int{2}))
+		Expect(done.failed).To(Equal(2))
+	})
+})
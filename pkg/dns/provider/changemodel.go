@@ -5,8 +5,11 @@
 package provider
 
 import (
+	"cmp"
 	"fmt"
+	"maps"
 	"reflect"
+	"slices"
 	"time"
 
 	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
@@ -39,6 +42,60 @@ type ChangeRequest struct {
 	Applied  bool
 }
 
+// ETag returns the version of the record set as read from the provider while planning an update or deletion.
+// It is empty for creations or if the provider does not support conditional updates.
+func (r *ChangeRequest) ETag() string {
+	if r.Action == R_CREATE || r.Deletion == nil {
+		return ""
+	}
+	if rs := r.Deletion.Sets[r.Type]; rs != nil {
+		return rs.ETag
+	}
+	return ""
+}
+
+// SetETag sets the version of the record set returned by the provider after creating or updating it,
+// so that the cached zone state can be used for further conditional changes.
+func (r *ChangeRequest) SetETag(etag string) {
+	if r.Action == R_DELETE || r.Addition == nil {
+		return
+	}
+	if rs := r.Addition.Sets[r.Type]; rs != nil {
+		rs.ETag = etag
+	}
+}
+
+// lostETag returns true if a record set read with an etag has been updated without getting its new etag
+// from the provider.
+func (r *ChangeRequest) lostETag() bool {
+	if r.Action != R_UPDATE || r.ETag() == "" {
+		return false
+	}
+	rs := r.Addition.Sets[r.Type]
+	return rs != nil && rs.ETag == ""
+}
+
+// Describe returns a human readable description of the planned change.
+func (r *ChangeRequest) Describe() string {
+	return r.DescribeIn(dns.NameForm{})
+}
+
+// DescribeIn describes the change request with the DNS name in the given form of a provider.
+func (r *ChangeRequest) DescribeIn(form dns.NameForm) string {
+	dnsset := r.Addition
+	if r.Action == R_DELETE {
+		dnsset = r.Deletion
+	}
+	if dnsset == nil {
+		return fmt.Sprintf("%s %s record set", r.Action, r.Type)
+	}
+	records := "no records"
+	if rs := dnsset.Sets[r.Type]; rs != nil {
+		records = rs.RecordString()
+	}
+	return fmt.Sprintf("%s %s record set %s: %s", r.Action, r.Type, form.SetName(dnsset.Name), records)
+}
+
 func (r *ChangeRequest) IsSemanticEqualTo(other *ChangeRequest) bool {
 	return r.Action == other.Action && r.Type == other.Type &&
 		(r.Addition == nil && other.Addition == nil || r.Addition != nil && other.Addition != nil && r.Addition.MatchRecordTypeSubset(other.Addition, r.Type)) &&
@@ -76,11 +133,20 @@ func (h *applyingDoneHandler) Throttled() {
 
 func (h *applyingDoneHandler) Succeeded() {
 	h.changeRequest.Applied = true
+	if w, ok := h.inner.(WrittenDoneHandler); ok {
+		w.Written()
+	}
 	if h.inner != nil {
 		h.inner.Succeeded()
 	}
 }
 
+func (h *applyingDoneHandler) DryRun(planned string) {
+	if d, ok := h.inner.(DryRunDoneHandler); ok {
+		d.DryRun(planned)
+	}
+}
+
 type ChangeGroup struct {
 	name          string
 	provider      DNSProvider
@@ -121,6 +187,8 @@ func (this *ChangeGroup) cleanup(logger logger.LogContext, model *ChangeModel) b
 					if trigger && (!upd || err != nil) {
 						e.Trigger(logger)
 					}
+				} else if writer := model.config.OwnerConflict.deferTo(s, time.Now()); writer != "" {
+					model.Debugf("found unapplied managed set '%s' written by controller instance %s -> keep", s.Name, writer)
 				} else {
 					model.Infof("found unapplied managed set '%s'", s.Name)
 					var done DoneHandler
@@ -145,19 +213,73 @@ func (this *ChangeGroup) update(logger logger.LogContext, model *ChangeModel) bo
 	ok := true
 	model.Infof("reconcile entries for %s (with %d requests)", this.name, len(this.requests))
 
-	reqs := this.requests
+	reqs := this.orderedRequests()
+
+	if len(reqs) > 0 && model.config.Dryrun {
+		this.reportDryRun(logger, reqs)
+		return ok
+	}
 	if len(reqs) > 0 {
 		this.model.context.dnsTicker.TickWhile(logger, func() {
-			err := this.provider.ExecuteRequests(logger, model.context.zone.getZone(), this.model.zonestate, reqs)
+			zone := model.context.zone.getZone()
+			err := traceExecuteRequests(zone, reqs, func() error {
+				return this.provider.ExecuteRequests(logger, zone, this.model.zonestate, reqs)
+			})
 			if err != nil {
 				model.Errorf("entry reconciliation failed for %s: %s", this.name, err)
 				ok = false
+				if perrs.IsThrottlingError(err) {
+					model.throttled = true
+				}
+				if perrs.IsNoSuchHostedZone(err) {
+					model.zoneGone = true
+				}
+				if perrs.IsConditionalUpdateConflictError(err) {
+					model.conflict = true
+				}
+				if cerr, ok := perrs.IsCircuitOpen(err); ok {
+					model.circuitOpen = cerr
+				}
 			}
 		})
 	}
 	return ok
 }
 
+// orderedRequests returns the requests of the group with the delete requests first to avoid conflicts with
+// existing records, e.g. if the DNS name of an entry moves from one record set to another.
+// All requests are still executed together to keep the change batches of the providers atomic.
+func (this *ChangeGroup) orderedRequests() ChangeRequests {
+	reqs := slices.Clone(this.requests)
+	slices.SortStableFunc(reqs, func(a, b *ChangeRequest) int {
+		return cmp.Compare(actionOrder(a.Action), actionOrder(b.Action))
+	})
+	return reqs
+}
+
+func actionOrder(action string) int {
+	if action == R_DELETE {
+		return 0
+	}
+	return 1
+}
+
+// reportDryRun reports the planned changes without executing them.
+// The DNS names are reported in the form they would be written to the DNS backend.
+func (this *ChangeGroup) reportDryRun(logger logger.LogContext, reqs []*ChangeRequest) {
+	form := dns.NameForm{}
+	if this.provider != nil {
+		form = this.provider.NameForm()
+	}
+	for _, r := range reqs {
+		planned := r.DescribeIn(form)
+		logger.Infof("dry run: would %s", planned)
+		if d, ok := r.Done.(DryRunDoneHandler); ok {
+			d.DryRun(planned)
+		}
+	}
+}
+
 func (this *ChangeGroup) addCreateRequest(dnsset *dns.DNSSet, rtype string, done DoneHandler) {
 	this.addChangeRequest(R_CREATE, nil, dnsset, rtype, done)
 }
@@ -191,6 +313,11 @@ type ChangeModel struct {
 	providergroups map[string]*ChangeGroup
 	zonestate      DNSZoneState
 	failedDNSNames dns.DNSNameSet
+	throttled      bool
+	zoneGone       bool
+	conflict       bool
+	circuitOpen    *perrs.CircuitOpen
+	recordSets     int
 }
 
 type ChangeResult struct {
@@ -268,10 +395,24 @@ func (this *ChangeModel) Setup() error {
 	}
 	sets := this.zonestate.GetDNSSets()
 	this.context.zone.SetOwners(sets.GetOwners())
+	this.recordSets = countRecordSets(sets)
 	this.dangling = newChangeGroup("dangling entries", provider, this)
+	chunkSize := provider.TXTRecordLimits().ChunkSize
 	for setName, set := range sets {
+		if setName.DNSName == this.Domain() && set.Sets[dns.RS_NS] != nil {
+			// the name servers of the hosted zone itself are never managed
+			set = set.Clone()
+			delete(set.Sets, dns.RS_NS)
+			if len(set.Sets) == 0 {
+				continue
+			}
+		}
+		if chunkSize > 0 {
+			// compare texts split into multiple character strings by the provider as a whole
+			set = joinTXTRecords(set)
+		}
 		var view *ChangeGroup
-		provider = this.context.providers.LookupFor(setName.DNSName)
+		provider = this.context.providers.LookupFor(setName.DNSName, recordTypes(set)...)
 		if provider != nil {
 			this.dumpf("  %s: %d types (provider %s)", setName, len(set.Sets), provider.ObjectName())
 			view = this.getProviderView(provider)
@@ -288,6 +429,51 @@ func (this *ChangeModel) Setup() error {
 	return err
 }
 
+// recordTypes returns the record types of the DNS set managed by providers, i.e. without the meta data records.
+func recordTypes(set *dns.DNSSet) []string {
+	var rtypes []string
+	for rtype := range set.Sets {
+		if rtype != dns.RS_META && rtype != dns.RS_OWNERSHIP {
+			rtypes = append(rtypes, rtype)
+		}
+	}
+	return rtypes
+}
+
+// targetRecordTypes returns the distinct record types of the targets.
+func targetRecordTypes(targets Targets) []string {
+	var rtypes []string
+	for _, t := range targets {
+		if !slices.Contains(rtypes, t.GetRecordType()) {
+			rtypes = append(rtypes, t.GetRecordType())
+		}
+	}
+	return rtypes
+}
+
+// RecordSets returns the number of record sets in the zone found on setup.
+func (this *ChangeModel) RecordSets() int {
+	return this.recordSets
+}
+
+// countRecordSets returns the number of record sets of all DNS sets.
+func countRecordSets(sets dns.DNSSets) int {
+	count := 0
+	for _, set := range sets {
+		count += len(set.Sets)
+	}
+	return count
+}
+
+// checkRecordSetLimit returns an error if creating the given DNS set would exceed the record set limit of the zone.
+func (this *ChangeModel) checkRecordSetLimit(newset *dns.DNSSet) error {
+	limit := this.context.recordSetLimit
+	if limit <= 0 || this.recordSets+len(newset.Sets) <= limit {
+		return nil
+	}
+	return fmt.Errorf("record set limit %d of zone %s would be exceeded (%d record sets)", limit, this.ZoneId().ID, this.recordSets)
+}
+
 func (this *ChangeModel) Check(name dns.DNSSetName, updateGroup string, createdAt time.Time, done DoneHandler, spec TargetSpec) ChangeResult {
 	return this.Exec(false, false, name, updateGroup, createdAt, done, spec)
 }
@@ -300,6 +486,30 @@ func (this *ChangeModel) Delete(name dns.DNSSetName, updateGroup string, created
 	return this.Exec(true, true, name, updateGroup, createdAt, done, spec)
 }
 
+// Release deletes only the ownership records of the given DNS set on deletion of its entry.
+// The other records are preserved in the DNS backend, but are not owned anymore.
+func (this *ChangeModel) Release(name dns.DNSSetName, done DoneHandler) ChangeResult {
+	done = this.wrappedDoneHandler(name, done)
+	this.applied[name] = dns.NewDNSSet(name, nil)
+	mod := false
+	if p := this.context.providers.LookupFor(name.DNSName); p != nil {
+		view := this.getProviderView(p)
+		if oldset := view.dnssets[name]; oldset != nil && this.Owns(oldset) {
+			this.applied[name] = oldset
+			for _, ty := range []string{dns.RS_META, dns.RS_OWNERSHIP} {
+				if oldset.Sets[ty] != nil {
+					view.addDeleteRequest(oldset, ty, done)
+					mod = true
+				}
+			}
+		}
+	}
+	if !mod && done != nil {
+		done.Succeeded()
+	}
+	return ChangeResult{Modified: mod}
+}
+
 func (this *ChangeModel) PseudoApply(name dns.DNSSetName, spec TargetSpec) {
 	this.applied[name] = dns.NewDNSSet(name, spec.RoutingPolicy())
 }
@@ -314,7 +524,7 @@ func (this *ChangeModel) Exec(apply bool, delete bool, name dns.DNSSetName, upda
 		this.applied[name] = nil
 		done = this.wrappedDoneHandler(name, done)
 	}
-	p := this.context.providers.LookupFor(name.DNSName)
+	p := this.context.providers.LookupFor(name.DNSName, targetRecordTypes(spec.Targets())...)
 	if p == nil {
 		err := fmt.Errorf("no provider found for %q", name)
 		if done != nil {
@@ -329,7 +539,7 @@ func (this *ChangeModel) Exec(apply bool, delete bool, name dns.DNSSetName, upda
 
 	view := this.getProviderView(p)
 	oldset := view.dnssets[name]
-	newset := dns.NewDNSSet(name, spec.RoutingPolicy())
+	newset := dns.NewDNSSet(name, p.NormalizeRoutingPolicy(spec.RoutingPolicy()))
 	newset.UpdateGroup = updateGroup
 	newset.SetKind(spec.Kind())
 	if !delete {
@@ -339,7 +549,7 @@ func (this *ChangeModel) Exec(apply bool, delete bool, name dns.DNSSetName, upda
 	if oldset != nil {
 		this.Debugf("found old for %s %q", oldset.GetKind(), oldset.Name)
 		if this.IsForeign(oldset) {
-			err := &perrs.AlreadyBusyForOwner{Name: name, EntryCreatedAt: createdAt, Owner: oldset.GetOwner()}
+			err := &perrs.AlreadyBusyForOwner{Name: name, EntryCreatedAt: createdAt, Owner: this.foreignOwner(oldset)}
 			retry := p.ReportZoneStateConflict(this.context.zone.getZone(), err)
 			if done != nil {
 				if apply && !retry {
@@ -353,6 +563,19 @@ func (this *ChangeModel) Exec(apply bool, delete bool, name dns.DNSSetName, upda
 			if !spec.Responsible(oldset, this.ownership) {
 				return ChangeResult{}
 			}
+			if writer := this.config.OwnerConflict.deferTo(oldset, time.Now()); writer != "" {
+				if delete {
+					// the records are kept for the other controller instance
+					return ChangeResult{}
+				}
+				// no failure, the entry is reconciled again with the next lease renewal of the zone
+				err := &perrs.DeferredToWriter{Name: name, Owner: oldset.GetOwner(), Writer: writer}
+				this.Debugf("%s", err)
+				if d, ok := done.(DeferredDoneHandler); ok && apply {
+					d.Deferred(err)
+				}
+				return ChangeResult{}
+			}
 			if oldset.GetOwner() == "" && !this.Owns(oldset) {
 				if delete {
 					return ChangeResult{}
@@ -370,7 +593,7 @@ func (this *ChangeModel) Exec(apply bool, delete bool, name dns.DNSSetName, upda
 					olddns, _ := dns.MapToProvider(ty, oldset, this.Domain())
 					newdns, _ := dns.MapToProvider(ty, newset, this.Domain())
 					if olddns == newdns {
-						if !curset.Match(rset) || !reflect.DeepEqual(spec.RoutingPolicy(), oldset.RoutingPolicy) {
+						if !curset.Match(rset) || !reflect.DeepEqual(newset.RoutingPolicy, oldset.RoutingPolicy) {
 							if apply {
 								view.addUpdateRequest(oldset, newset, ty, done)
 							}
@@ -400,12 +623,19 @@ func (this *ChangeModel) Exec(apply bool, delete bool, name dns.DNSSetName, upda
 		}
 	} else {
 		if !delete {
+			if err := this.checkRecordSetLimit(newset); err != nil {
+				if apply && done != nil {
+					done.Failed(err)
+				}
+				return ChangeResult{Error: err}
+			}
 			if apply {
 				this.Infof("no existing entry found for %s", name)
 				this.setOwner(newset, spec.OwnerId())
 				for ty := range newset.Sets {
 					view.addCreateRequest(newset, ty, done)
 				}
+				this.recordSets += len(newset.Sets)
 			}
 			mod = true
 		}
@@ -438,11 +668,30 @@ func (this *ChangeModel) Update(logger logger.LogContext) error {
 	}
 	failed = !this.dangling.update(logger, this) || failed
 	if failed {
-		return fmt.Errorf("entry reconciliation failed for some provider(s)")
+		err := fmt.Errorf("entry reconciliation failed for some provider(s)")
+		if this.zoneGone {
+			return &perrs.NoSuchHostedZone{ZoneId: this.context.zone.Id().ID, Err: err}
+		}
+		if this.throttled {
+			return perrs.NewThrottlingError(err)
+		}
+		if this.conflict {
+			return perrs.NewConditionalUpdateConflictError(err)
+		}
+		if this.circuitOpen != nil {
+			return this.circuitOpen
+		}
+		return err
 	}
 	return nil
 }
 
+// IsApplied returns true if the given DNS set has already been applied.
+func (this *ChangeModel) IsApplied(name dns.DNSSetName) bool {
+	_, ok := this.applied[name]
+	return ok
+}
+
 func (this *ChangeModel) IsFailed(name dns.DNSSetName) bool {
 	return this.failedDNSNames.Contains(name)
 }
@@ -483,12 +732,30 @@ func (this *changeModelDoneHandler) Succeeded() {
 	}
 }
 
+func (this *changeModelDoneHandler) Written() {
+	if w, ok := this.inner.(WrittenDoneHandler); ok {
+		w.Written()
+	}
+}
+
+func (this *changeModelDoneHandler) Deferred(err error) {
+	if d, ok := this.inner.(DeferredDoneHandler); ok {
+		d.Deferred(err)
+	}
+}
+
 func (this *changeModelDoneHandler) Throttled() {
 	if this.inner != nil {
 		this.inner.Throttled()
 	}
 }
 
+func (this *changeModelDoneHandler) DryRun(planned string) {
+	if d, ok := this.inner.(DryRunDoneHandler); ok {
+		d.DryRun(planned)
+	}
+}
+
 /////////////////////////////////////////////////////////////////////////////////
 // DNSSets
 
@@ -497,7 +764,22 @@ func (this *ChangeModel) Owns(set *dns.DNSSet) bool {
 }
 
 func (this *ChangeModel) IsForeign(set *dns.DNSSet) bool {
-	return set.IsForeign(this.ownership)
+	return set.IsForeign(this.ownership) || this.isForeignOwnershipRecord(set)
+}
+
+// isForeignOwnershipRecord returns true if the set has no owner of its own but an ownership record
+// of another owner, e.g. written by an external-dns instance.
+func (this *ChangeModel) isForeignOwnershipRecord(set *dns.DNSSet) bool {
+	owner := set.GetOwnershipRecordOwner()
+	return set.GetOwner() == "" && owner != "" && owner != this.config.OwnershipRecordOwnerID
+}
+
+// foreignOwner returns the owner of a foreign set.
+func (this *ChangeModel) foreignOwner(set *dns.DNSSet) string {
+	if owner := set.GetOwner(); owner != "" {
+		return owner
+	}
+	return set.GetOwnershipRecordOwner()
 }
 
 func (this *ChangeModel) setOwner(set *dns.DNSSet, id string) bool {
@@ -516,6 +798,10 @@ func (this *ChangeModel) ApplySpec(set *dns.DNSSet, base *dns.DNSSet, provider D
 	if base == nil || !this.IsForeign(base) {
 		if this.setOwner(set, spec.OwnerId()) {
 			set.SetMetaAttr(dns.ATTR_PREFIX, dns.TxtPrefix)
+			this.config.OwnerConflict.markWriter(set, base, time.Now())
+			if this.config.OwnershipRecordOwnerID != "" {
+				set.Sets[dns.RS_OWNERSHIP] = dns.NewOwnershipRecordSet(this.config.OwnershipRecordOwnerID)
+			}
 		}
 	}
 
@@ -524,6 +810,18 @@ func (this *ChangeModel) ApplySpec(set *dns.DNSSet, base *dns.DNSSet, provider D
 	for _, t := range targets {
 		AddRecord(targetsets, t.GetRecordType(), t.GetHostName(), t.GetTTL())
 	}
+	// the comment is left unset without comment in the spec to keep existing comments of the records
+	var comment *string
+	if c := spec.Comment(); c != "" {
+		comment = &c
+	}
+	attributes := provider.NormalizeProviderAttributes(spec.ProviderAttributes())
+	for _, t := range targets {
+		targetsets[t.GetRecordType()].Comment = comment
+		if attributes != nil {
+			targetsets[t.GetRecordType()].ProviderAttributes = maps.Clone(attributes)
+		}
+	}
 	set.Sets = targetsets
 	return set
 }
@@ -0,0 +1,319 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"maps"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+type testDryRunDoneHandler struct {
+	planned   []string
+	succeeded bool
+}
+
+var _ DryRunDoneHandler = &testDryRunDoneHandler{}
+
+func (h *testDryRunDoneHandler) SetInvalid(_ error) {}
+func (h *testDryRunDoneHandler) Failed(_ error)     {}
+func (h *testDryRunDoneHandler) Throttled()         {}
+func (h *testDryRunDoneHandler) Succeeded()         { h.succeeded = true }
+func (h *testDryRunDoneHandler) DryRun(planned string) {
+	h.planned = append(h.planned, planned)
+}
+
+var _ = ginkgov2.Describe("ChangeModel dry run", func() {
+	ginkgov2.It("describes change requests", func() {
+		set := newTestDNSSet("a.example.com", "1.1.1.1")
+		Expect(NewChangeRequest(R_CREATE, dns.RS_A, nil, set, nil).Describe()).To(Equal("create A record set a.example.com: [1.1.1.1]"))
+		Expect(NewChangeRequest(R_DELETE, dns.RS_A, set, nil, nil).Describe()).To(Equal("delete A record set a.example.com: [1.1.1.1]"))
+		Expect(NewChangeRequest(R_UPDATE, dns.RS_AAAA, nil, set, nil).Describe()).To(Equal("update AAAA record set a.example.com: no records"))
+		Expect(NewChangeRequest(R_CREATE, dns.RS_A, nil, set, nil).DescribeIn(dns.NameForm{TrailingDot: true})).To(Equal("create A record set a.example.com.: [1.1.1.1]"))
+	})
+
+	ginkgov2.It("reports planned changes without execution", func() {
+		model := NewChangeModel(logger.New(), nil, nil, Config{Dryrun: true})
+		group := newChangeGroup("test", nil, model)
+		done := &testDryRunDoneHandler{}
+		group.addCreateRequest(newTestDNSSet("a.example.com", "1.1.1.1"), dns.RS_A, model.wrappedDoneHandler(dns.DNSSetName{DNSName: "a.example.com"}, done))
+		group.addDeleteRequest(newTestDNSSet("b.example.com", "1.1.1.2"), dns.RS_A, model.wrappedDoneHandler(dns.DNSSetName{DNSName: "b.example.com"}, done))
+
+		Expect(group.update(logger.New(), model)).To(BeTrue())
+		Expect(done.planned).To(Equal([]string{
+			"delete A record set b.example.com: [1.1.1.2]",
+			"create A record set a.example.com: [1.1.1.1]",
+		}))
+		Expect(done.succeeded).To(BeFalse())
+		for _, r := range group.requests {
+			Expect(r.Applied).To(BeFalse())
+		}
+	})
+
+	ginkgov2.It("orders delete requests before other requests", func() {
+		model := NewChangeModel(logger.New(), nil, nil, Config{})
+		group := newChangeGroup("test", nil, model)
+		group.addCreateRequest(newTestDNSSet("a.example.com", "1.1.1.1"), dns.RS_A, nil)
+		group.addDeleteRequest(newTestDNSSet("b.example.com", "1.1.1.2"), dns.RS_A, nil)
+		group.addUpdateRequest(nil, newTestDNSSet("c.example.com", "1.1.1.3"), dns.RS_A, nil)
+		group.addDeleteRequest(newTestDNSSet("a.example.com", "1.1.1.4"), dns.RS_CNAME, nil)
+
+		describe := func(reqs ChangeRequests) []string {
+			var result []string
+			for _, r := range reqs {
+				result = append(result, r.Action+" "+r.Type)
+			}
+			return result
+		}
+		Expect(describe(group.orderedRequests())).To(Equal([]string{"delete A", "delete CNAME", "create A", "update A"}))
+		Expect(describe(group.requests)).To(Equal([]string{"create A", "delete A", "update A", "delete CNAME"}))
+	})
+})
+
+var _ = ginkgov2.Describe("ChangeModel record set limit", func() {
+	newModel := func(limit, recordSets int) *ChangeModel {
+		zone := newDNSHostedZone(0, NewDNSHostedZone("test", "z1", "example.com", "", false))
+		model := NewChangeModel(logger.New(), nil, &zoneReconciliation{zone: zone, recordSetLimit: limit}, Config{})
+		model.recordSets = recordSets
+		return model
+	}
+
+	ginkgov2.It("counts the record sets of all DNS sets", func() {
+		sets := dns.DNSSets{}
+		sets.AddRecordSet(dns.DNSSetName{DNSName: "a.example.com"}, nil, dns.NewRecordSet(dns.RS_A, 300, nil))
+		sets.AddRecordSet(dns.DNSSetName{DNSName: "a.example.com"}, nil, dns.NewRecordSet(dns.RS_AAAA, 300, nil))
+		sets.AddRecordSet(dns.DNSSetName{DNSName: "b.example.com"}, nil, dns.NewRecordSet(dns.RS_CNAME, 300, nil))
+		Expect(countRecordSets(sets)).To(Equal(3))
+	})
+
+	ginkgov2.It("accepts new DNS sets within the limit", func() {
+		Expect(newModel(0, 1000).checkRecordSetLimit(newTestDNSSet("a.example.com", "1.1.1.1"))).To(Succeed())
+		Expect(newModel(10, 9).checkRecordSetLimit(newTestDNSSet("a.example.com", "1.1.1.1"))).To(Succeed())
+	})
+
+	ginkgov2.It("refuses new DNS sets exceeding the limit", func() {
+		err := newModel(10, 10).checkRecordSetLimit(newTestDNSSet("a.example.com", "1.1.1.1"))
+		Expect(err).To(MatchError("record set limit 10 of zone z1 would be exceeded (10 record sets)"))
+	})
+})
+
+var _ = ginkgov2.Describe("ChangeRequest etag", func() {
+	ginkgov2.It("returns the etag of the record set read from the provider", func() {
+		old := newTestDNSSet("a.example.com", "1.1.1.1")
+		old.Sets[dns.RS_A].ETag = "etag-1"
+		set := newTestDNSSet("a.example.com", "2.2.2.2")
+		Expect(NewChangeRequest(R_UPDATE, dns.RS_A, old, set, nil).ETag()).To(Equal("etag-1"))
+		Expect(NewChangeRequest(R_DELETE, dns.RS_A, old, nil, nil).ETag()).To(Equal("etag-1"))
+		Expect(NewChangeRequest(R_CREATE, dns.RS_A, nil, set, nil).ETag()).To(BeEmpty())
+		Expect(NewChangeRequest(R_UPDATE, dns.RS_AAAA, old, set, nil).ETag()).To(BeEmpty())
+	})
+
+	ginkgov2.It("keeps the etag when cloning and ignores it on matching", func() {
+		rs := dns.NewRecordSet(dns.RS_A, 300, []*dns.Record{{Value: "1.1.1.1"}})
+		rs.ETag = "etag-1"
+		clone := rs.Clone()
+		Expect(clone.ETag).To(Equal("etag-1"))
+		clone.ETag = "etag-2"
+		Expect(clone.Match(rs)).To(BeTrue())
+	})
+})
+
+type testWrittenDoneHandler struct {
+	testDryRunDoneHandler
+	written bool
+}
+
+var _ WrittenDoneHandler = &testWrittenDoneHandler{}
+
+func (h *testWrittenDoneHandler) Written() { h.written = h.written || !h.succeeded }
+
+var _ = ginkgov2.Describe("ChangeModel backend writes", func() {
+	name := dns.DNSSetName{DNSName: "a.example.com"}
+
+	ginkgov2.It("reports succeeded change requests as written", func() {
+		model := NewChangeModel(logger.New(), nil, nil, Config{})
+		done := &testWrittenDoneHandler{}
+		req := NewChangeRequest(R_CREATE, dns.RS_A, nil, newTestDNSSet("a.example.com", "1.1.1.1"), model.wrappedDoneHandler(name, done))
+		req.Done.Succeeded()
+		Expect(done.written).To(BeTrue())
+		Expect(done.succeeded).To(BeTrue())
+	})
+
+	ginkgov2.It("reports entries without changes as succeeded only", func() {
+		model := NewChangeModel(logger.New(), nil, nil, Config{})
+		done := &testWrittenDoneHandler{}
+		model.wrappedDoneHandler(name, done).Succeeded()
+		Expect(done.written).To(BeFalse())
+		Expect(done.succeeded).To(BeTrue())
+	})
+})
+
+var _ = ginkgov2.Describe("ChangeModel ownership records", func() {
+	newModel := func(ownerID string) *ChangeModel {
+		zone := newDNSHostedZone(0, NewDNSHostedZone("test", "z1", "example.com", "", false))
+		return NewChangeModel(logger.New(), nil, &zoneReconciliation{zone: zone}, Config{OwnershipRecordOwnerID: ownerID})
+	}
+	newSet := func(ownerID string) *dns.DNSSet {
+		set := newTestDNSSet("a.example.com", "1.1.1.1")
+		set.Sets[dns.RS_OWNERSHIP] = dns.NewOwnershipRecordSet(ownerID)
+		return set
+	}
+
+	ginkgov2.It("treats sets with ownership records of other owners as foreign", func() {
+		model := newModel("my-owner")
+		Expect(model.IsForeign(newSet("my-owner"))).To(BeFalse())
+		Expect(model.IsForeign(newSet("other"))).To(BeTrue())
+		Expect(model.foreignOwner(newSet("other"))).To(Equal("other"))
+		Expect(newModel("").IsForeign(newSet("other"))).To(BeTrue())
+	})
+
+	ginkgov2.It("ignores ownership records of sets with an owner", func() {
+		set := newSet("other")
+		set.SetOwner("test")
+		Expect(newModel("my-owner").isForeignOwnershipRecord(set)).To(BeFalse())
+	})
+})
+
+type testTargetSpec struct {
+	TargetSpec
+	targets []Target
+	comment string
+}
+
+func (this *testTargetSpec) Kind() string                          { return "DNSEntry" }
+func (this *testTargetSpec) OwnerId() string                       { return "" }
+func (this *testTargetSpec) Targets() []Target                     { return this.targets }
+func (this *testTargetSpec) Comment() string                       { return this.comment }
+func (this *testTargetSpec) ProviderAttributes() map[string]string { return nil }
+func (this *testTargetSpec) RoutingPolicy() *dns.RoutingPolicy     { return nil }
+func (this *testTargetSpec) Responsible(set *dns.DNSSet, ownership dns.Ownership) bool {
+	return !set.IsForeign(ownership)
+}
+
+type testMapProvider struct {
+	DNSProvider
+	attributes map[string]string
+}
+
+func (this *testMapProvider) MapTargets(_ string, targets []Target) []Target { return targets }
+func (this *testMapProvider) NormalizeProviderAttributes(attrs map[string]string) map[string]string {
+	if this.attributes == nil {
+		return attrs
+	}
+	normalized := maps.Clone(this.attributes)
+	maps.Copy(normalized, attrs)
+	return normalized
+}
+
+var _ = ginkgov2.Describe("ChangeModel comments", func() {
+	apply := func(comment string) *dns.RecordSet {
+		model := NewChangeModel(logger.New(), nil, nil, Config{})
+		spec := &testTargetSpec{targets: []Target{dnsutils.NewTarget(dns.RS_A, "1.1.1.1", 300)}, comment: comment}
+		set := model.ApplySpec(dns.NewDNSSet(dns.DNSSetName{DNSName: "a.example.com"}, nil), nil, &testMapProvider{}, spec)
+		return set.Sets[dns.RS_A]
+	}
+
+	ginkgov2.It("sets the comment of the spec", func() {
+		Expect(apply("my comment").Comment).To(Equal(ptr.To("my comment")))
+	})
+
+	ginkgov2.It("leaves the comment unset without comment in the spec", func() {
+		Expect(apply("").Comment).To(BeNil())
+	})
+})
+
+var _ = ginkgov2.Describe("ChangeModel provider attributes", func() {
+	ginkgov2.It("uses the provider attributes normalized by the provider", func() {
+		model := NewChangeModel(logger.New(), nil, nil, Config{})
+		spec := &testTargetSpec{targets: []Target{dnsutils.NewTarget(dns.RS_A, "1.1.1.1", 300)}}
+		p := &testMapProvider{attributes: map[string]string{"Owner": "dns"}}
+		set := model.ApplySpec(dns.NewDNSSet(dns.DNSSetName{DNSName: "a.example.com"}, nil), nil, p, spec)
+		Expect(set.Sets[dns.RS_A].ProviderAttributes).To(Equal(map[string]string{"Owner": "dns"}))
+	})
+})
+
+type testDeferredDoneHandler struct {
+	failed    error
+	deferred  error
+	succeeded bool
+}
+
+var _ DeferredDoneHandler = &testDeferredDoneHandler{}
+
+func (h *testDeferredDoneHandler) SetInvalid(err error) { h.failed = err }
+func (h *testDeferredDoneHandler) Failed(err error)     { h.failed = err }
+func (h *testDeferredDoneHandler) Throttled()           {}
+func (h *testDeferredDoneHandler) Succeeded()           { h.succeeded = true }
+func (h *testDeferredDoneHandler) Deferred(err error)   { h.deferred = err }
+
+var _ = ginkgov2.Describe("ChangeModel owner conflicts", func() {
+	var (
+		zone    *dnsHostedZone
+		backend *InMemory
+		p       *dnsProviderVersion
+	)
+	name := dns.DNSSetName{DNSName: "a.example.com"}
+
+	ginkgov2.BeforeEach(func() {
+		zone = newDNSHostedZone(0, NewDNSHostedZone("test", "z1", "example.com", "", false))
+		backend = NewInMemory()
+		backend.AddZone(zone.getZone())
+		p = newTestProvider("p1", zone.getZone(), &testZoneHandler{inMemory: backend})
+	})
+
+	// apply applies the target with the change model of the given controller instance using the same owner id
+	apply := func(instance, target string, done DoneHandler) ChangeResult {
+		config := Config{Ident: "owner", OwnerConflict: &OwnerConflictConfig{
+			Strategy:      OwnerConflictStrategyFirstWriterWins,
+			Instance:      instance,
+			LeaseDuration: 10 * time.Minute,
+		}}
+		req := &zoneReconciliation{zone: zone, providers: DNSProviders{p.ObjectName(): p}}
+		model := NewChangeModel(logger.New(), NewOwnerCache(nil, &config), req, config)
+		Expect(model.Setup()).To(Succeed())
+		spec := &testTargetSpec{targets: []Target{dnsutils.NewTarget(dns.RS_A, target, 300)}}
+		result := model.Apply(name, "", time.Now(), done, spec)
+		Expect(model.Cleanup(logger.New())).To(BeFalse())
+		Expect(model.Update(logger.New())).To(Succeed())
+		return result
+	}
+	records := func() string {
+		state, err := backend.CloneZoneState(zone.getZone())
+		Expect(err).NotTo(HaveOccurred())
+		return state.GetDNSSets()[name].Sets[dns.RS_A].RecordString()
+	}
+
+	ginkgov2.It("defers quietly to the instance holding the lease", func() {
+		doneA := &testDeferredDoneHandler{}
+		Expect(apply("a", "1.1.1.1", doneA).Modified).To(BeTrue())
+		Expect(doneA.succeeded).To(BeTrue())
+
+		doneB := &testDeferredDoneHandler{}
+		result := apply("b", "2.2.2.2", doneB)
+		Expect(result).To(Equal(ChangeResult{}))
+		Expect(doneB.failed).To(BeNil())
+		Expect(doneB.succeeded).To(BeFalse())
+		Expect(doneB.deferred).To(BeAssignableToTypeOf(&perrs.DeferredToWriter{}))
+		Expect(doneB.deferred.(*perrs.DeferredToWriter).Writer).To(Equal("a"))
+		Expect(records()).To(Equal("[1.1.1.1]"))
+	})
+
+	ginkgov2.It("keeps writing as the instance holding the lease", func() {
+		Expect(apply("a", "1.1.1.1", &testDeferredDoneHandler{}).Modified).To(BeTrue())
+		done := &testDeferredDoneHandler{}
+		Expect(apply("a", "3.3.3.3", done).Modified).To(BeTrue())
+		Expect(done.deferred).To(BeNil())
+		Expect(done.succeeded).To(BeTrue())
+		Expect(records()).To(Equal("[3.3.3.3]"))
+	})
+})
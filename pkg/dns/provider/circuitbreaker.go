@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"sync"
+	"time"
+
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+)
+
+// circuitBreaker stops calling a persistently failing DNS backend. After threshold consecutive failures, the
+// circuit is opened and backend calls are rejected for the cool-down period. Afterwards, a single probing call
+// is let through (half-open). Its success closes the circuit again, its failure reopens it.
+type circuitBreaker struct {
+	lock      sync.Mutex
+	threshold int
+	coolDown  time.Duration
+	now       func() time.Time
+
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func newCircuitBreaker(threshold int, coolDown time.Duration) *circuitBreaker {
+	if threshold <= 0 {
+		return nil
+	}
+	return &circuitBreaker{threshold: threshold, coolDown: coolDown, now: time.Now}
+}
+
+// Allow returns a CircuitOpen error if a backend call is not allowed.
+func (this *circuitBreaker) Allow() error {
+	if this == nil {
+		return nil
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	if this.openedAt.IsZero() {
+		return nil
+	}
+	remaining := this.coolDown - this.now().Sub(this.openedAt)
+	if remaining > 0 {
+		return &perrs.CircuitOpen{Failures: this.failures, RetryAfter: remaining}
+	}
+	if this.probing {
+		// a probing call is in flight, it is given another cool-down period to finish
+		return &perrs.CircuitOpen{Failures: this.failures, RetryAfter: this.coolDown}
+	}
+	this.probing = true
+	this.openedAt = this.now()
+	return nil
+}
+
+// Done records the result of a backend call.
+func (this *circuitBreaker) Done(err error) {
+	if this == nil {
+		return
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	this.probing = false
+	if !countsAsBackendFailure(err) {
+		this.failures = 0
+		this.openedAt = time.Time{}
+		return
+	}
+	this.failures++
+	if this.failures >= this.threshold {
+		this.openedAt = this.now()
+	}
+}
+
+// Status returns the CircuitOpen error if the circuit is open or half-open, otherwise nil.
+func (this *circuitBreaker) Status() *perrs.CircuitOpen {
+	if this == nil {
+		return nil
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if this.openedAt.IsZero() {
+		return nil
+	}
+	return &perrs.CircuitOpen{Failures: this.failures, RetryAfter: max(this.coolDown-this.now().Sub(this.openedAt), 0)}
+}
+
+// countsAsBackendFailure returns true if the error indicates a failing DNS backend. Errors handled by other
+// mechanisms like throttling or conflicts are not counted.
+func countsAsBackendFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	if _, ok := perrs.IsCircuitOpen(err); ok {
+		return false
+	}
+	return !perrs.IsThrottlingError(err) && !perrs.IsNoSuchHostedZone(err) && !perrs.IsConditionalUpdateConflictError(err) &&
+		!perrs.IsBatchTooLargeError(err) && !perrs.IsStaleZoneState(err)
+}
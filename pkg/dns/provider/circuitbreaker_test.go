@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+)
+
+var _ = ginkgov2.Describe("Circuit breaker", func() {
+	var (
+		now     time.Time
+		breaker *circuitBreaker
+		failure = fmt.Errorf("backend unavailable")
+	)
+
+	ginkgov2.BeforeEach(func() {
+		now = time.Now()
+		breaker = newCircuitBreaker(3, time.Minute)
+		breaker.now = func() time.Time { return now }
+	})
+
+	ginkgov2.It("is disabled without threshold", func() {
+		disabled := newCircuitBreaker(0, time.Minute)
+		Expect(disabled).To(BeNil())
+		disabled.Done(failure)
+		Expect(disabled.Allow()).To(Succeed())
+		Expect(disabled.Status()).To(BeNil())
+	})
+
+	ginkgov2.It("opens after consecutive failures only", func() {
+		breaker.Done(failure)
+		breaker.Done(failure)
+		breaker.Done(nil)
+		breaker.Done(failure)
+		breaker.Done(failure)
+		Expect(breaker.Allow()).To(Succeed())
+		Expect(breaker.Status()).To(BeNil())
+
+		breaker.Done(failure)
+		err := breaker.Allow()
+		cerr, ok := perrs.IsCircuitOpen(err)
+		Expect(ok).To(BeTrue())
+		Expect(cerr.Failures).To(Equal(3))
+		Expect(cerr.RetryAfter).To(Equal(time.Minute))
+		Expect(breaker.Status()).NotTo(BeNil())
+	})
+
+	ginkgov2.It("ignores throttling and conflict errors", func() {
+		for i := 0; i < 5; i++ {
+			breaker.Done(perrs.NewThrottlingError(failure))
+			breaker.Done(perrs.NewConditionalUpdateConflictError(failure))
+		}
+		Expect(breaker.Allow()).To(Succeed())
+	})
+
+	ginkgov2.It("lets a single probe through after the cool-down", func() {
+		for i := 0; i < 3; i++ {
+			breaker.Done(failure)
+		}
+		now = now.Add(30 * time.Second)
+		cerr, _ := perrs.IsCircuitOpen(breaker.Allow())
+		Expect(cerr.RetryAfter).To(Equal(30 * time.Second))
+
+		now = now.Add(30 * time.Second)
+		Expect(breaker.Allow()).To(Succeed())
+		_, ok := perrs.IsCircuitOpen(breaker.Allow())
+		Expect(ok).To(BeTrue())
+
+		breaker.Done(nil)
+		Expect(breaker.Allow()).To(Succeed())
+		Expect(breaker.Status()).To(BeNil())
+	})
+
+	ginkgov2.It("reopens if the probe fails", func() {
+		for i := 0; i < 3; i++ {
+			breaker.Done(failure)
+		}
+		now = now.Add(time.Minute)
+		Expect(breaker.Allow()).To(Succeed())
+		breaker.Done(failure)
+
+		cerr, ok := perrs.IsCircuitOpen(breaker.Allow())
+		Expect(ok).To(BeTrue())
+		Expect(cerr.Failures).To(Equal(4))
+		Expect(cerr.RetryAfter).To(Equal(time.Minute))
+	})
+
+	ginkgov2.It("only reports zone states fetched from the DNS backend", func() {
+		zone := NewDNSHostedZone("test", "breaker-zone", "example.com", "", false)
+		account := &DNSAccount{breaker: breaker}
+		factory := NewTestZoneCacheFactory(time.Hour, time.Hour)
+		factory.account = account
+		var stateErr error
+		fetches := 0
+		cache, err := factory.CreateZoneCache(CacheZoneState, &NullMetrics{},
+			func(_ context.Context, _ ZoneCache) (DNSHostedZones, error) { return DNSHostedZones{zone}, nil },
+			func(_ DNSHostedZone, _ ZoneCache) (DNSZoneState, error) {
+				fetches++
+				if stateErr != nil {
+					return nil, stateErr
+				}
+				return NewDNSZoneState(dns.DNSSets{}), nil
+			})
+		Expect(err).NotTo(HaveOccurred())
+		defer cache.Release()
+
+		_, err = cache.GetZoneState(zone)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fetches).To(Equal(1))
+
+		// consecutive failures of other backend requests are not reset by cache hits
+		breaker.Done(failure)
+		breaker.Done(failure)
+		_, err = cache.GetZoneState(zone)
+		Expect(err).NotTo(HaveOccurred())
+		breaker.Done(failure)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fetches).To(Equal(1))
+		Expect(breaker.Status()).NotTo(BeNil())
+
+		// the open circuit rejects fetching the zone state from the backend
+		cache.(*defaultZoneCache).cleanZoneState(zone.Id())
+		_, err = cache.GetZoneState(zone)
+		_, ok := perrs.IsCircuitOpen(err)
+		Expect(ok).To(BeTrue())
+		Expect(fetches).To(Equal(1))
+
+		// a failing probe reopens the circuit
+		now = now.Add(time.Minute)
+		stateErr = failure
+		_, err = cache.GetZoneState(zone)
+		Expect(err).To(MatchError(failure))
+		Expect(fetches).To(Equal(2))
+		cerr, _ := perrs.IsCircuitOpen(breaker.Allow())
+		Expect(cerr.Failures).To(Equal(4))
+	})
+})
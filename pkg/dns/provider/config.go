@@ -261,5 +261,13 @@ func (c *DNSHandlerConfig) Complete() error {
 		c.Logger.Infof("rate limiter: %v", rateLimiterConfig)
 	}
 	c.RateLimiter = rateLimiter
+	proxyURL, err := parseProxyURL(c.GetProperty(ProxySecretKey.Key, ProxySecretKey.AltKeys...))
+	if err != nil {
+		return err
+	}
+	if proxyURL != nil {
+		c.Logger.Infof("using HTTP proxy %s", proxyURL.Redacted())
+	}
+	c.ProxyURL = proxyURL
 	return nil
 }
@@ -18,20 +18,69 @@ const (
 	OPT_CLASS                      = source.OPT_CLASS
 	OPT_DRYRUN                     = "dry-run"
 	OPT_TTL                        = "ttl"
+	OPT_MIN_TTL                    = "min-ttl"
+	OPT_MAX_TTL                    = "max-ttl"
 	OPT_CACHE_TTL                  = "cache-ttl"
 	OPT_SETUP                      = dns.OPT_SETUP
 	OPT_DNSDELAY                   = "dns-delay"
 	OPT_RESCHEDULEDELAY            = "reschedule-delay"
 	OPT_LOCKSTATUSCHECKPERIOD      = "lock-status-check-period"
+	OPT_LOOKUP_NEGATIVE_CACHE_TTL  = "lookup-negative-cache-ttl"
+	OPT_LOOKUP_DEDUP_WINDOW        = "lookup-dedup-window"
+	OPT_LOOKUP_NAMESERVERS         = "lookup-nameservers"
+	OPT_PROVIDER_PROBE_INTERVAL    = "provider-probe-interval"
 	OPT_DISABLE_ZONE_STATE_CACHING = "disable-zone-state-caching"
 	OPT_DISABLE_DNSNAME_VALIDATION = "disable-dnsname-validation"
+	OPT_MAX_TARGETS                = "max-targets"
+	OPT_MAX_CNAME_TARGETS          = "max-cname-targets"
+	OPT_ZONE_RECORD_SET_LIMIT      = "zone-record-set-limit"
+	OPT_LOG_STATE_TRANSITIONS      = "log-state-transitions"
+	OPT_ZONE_AUDIT_INTERVAL        = "zone-audit-interval"
+	OPT_ZONE_AUDIT_MODE            = "zone-audit-mode"
+	OPT_INITIAL_ZONE_CACHE_TIMEOUT = "initial-zone-cache-timeout"
+	OPT_FINALIZER_NAME             = "finalizer-name"
+	OPT_PROVIDER_CREDENTIALS_DIR   = "provider-credentials-dir"
+	OPT_OWNERSHIP_RECORD_OWNER_ID  = "ownership-record-owner-id"
+	OPT_ENTRY_NAMESPACES           = "entry-namespaces"
+	OPT_EXCLUDED_ENTRY_NAMESPACES  = "excluded-entry-namespaces"
+	OPT_PROVIDER_TIE_BREAKER       = "provider-tie-breaker"
+
+	OPT_OWNER_CONFLICT_STRATEGY = "owner-conflict-strategy"
+	OPT_OWNER_CONFLICT_INSTANCE = "owner-conflict-instance"
+	OPT_OWNER_CONFLICT_PRIORITY = "owner-conflict-priority"
+	OPT_OWNER_CONFLICT_LEASE    = "owner-conflict-lease-duration"
+
+	OPT_CIRCUIT_BREAKER_THRESHOLD = "provider-circuit-breaker-threshold"
+	OPT_CIRCUIT_BREAKER_COOLDOWN  = "provider-circuit-breaker-cooldown"
+
+	OPT_CNAME_LOOKUP_INTERVAL     = "cname-lookup-interval"
+	OPT_CNAME_LOOKUP_MIN_INTERVAL = "cname-lookup-min-interval"
+	OPT_CNAME_LOOKUP_TTL_DIVISOR  = "cname-lookup-ttl-divisor"
+
+	OPT_PROPAGATION_VERIFICATION          = "propagation-verification"
+	OPT_PROPAGATION_VERIFICATION_TIMEOUT  = "propagation-verification-timeout"
+	OPT_PROPAGATION_VERIFICATION_INTERVAL = "propagation-verification-interval"
+
+	OPT_TARGET_REFS = "target-refs"
+
+	OPT_ENTRY_RECONCILE_QPS   = "entry-reconcile-qps"
+	OPT_ENTRY_RECONCILE_BURST = "entry-reconcile-burst"
+	OPT_ENTRY_STATUS_INTERVAL = "entry-status-min-interval"
+	OPT_ENTRY_EVENT_WINDOW    = "entry-event-dedup-window"
+
+	OPT_ENTRY_RECONCILE_MIN_INTERVAL = "entry-reconcile-min-interval"
+
+	OPT_DRAIN_TIMEOUT = "drain-timeout"
 
 	OPT_REMOTE_ACCESS_PORT               = "remote-access-port"
 	OPT_REMOTE_ACCESS_CACERT             = "remote-access-cacert"
 	OPT_REMOTE_ACCESS_SERVER_SECRET_NAME = "remote-access-server-secret-name"
 	OPT_REMOTE_ACCESS_CLIENT_ID          = "remote-access-client-id"
 
-	OPT_PROVIDERTYPES = "provider-types"
+	OPT_TRACING_ENDPOINT = "tracing-endpoint"
+
+	OPT_PROVIDERTYPES         = "provider-types"
+	OPT_PROVIDERTYPE_DEFAULTS = "provider-type-defaults"
 
 	OPT_RATELIMITER_ENABLED = "ratelimiter.enabled"
 	OPT_RATELIMITER_QPS     = "ratelimiter.qps"
@@ -43,10 +92,47 @@ const (
 
 	CMD_HOSTEDZONE_PREFIX = "hostedzone:"
 	CMD_STATISTIC         = "statistic"
+	CMD_ZONE_AUDIT        = "zoneaudit"
 
 	MSG_THROTTLING = "provider throttled"
+	MSG_PAUSED     = "Paused"
+)
+
+// Reasons of the events emitted during the reconciliation of DNS entries.
+// They are stable to allow filtering events, e.g. with `kubectl get events --field-selector reason=BackendError`.
+const (
+	EventReasonProviderAssigned   = "ProviderAssigned"
+	EventReasonProviderUnassigned = "ProviderUnassigned"
+	EventReasonValidationFailed   = "ValidationFailed"
+	EventReasonValidationWarning  = "ValidationWarning"
+	EventReasonInvalidAnnotation  = "InvalidAnnotation"
+	EventReasonIgnoredAttributes  = "ProviderAttributesIgnored"
+	EventReasonTTLClamped         = "TTLClamped"
+	EventReasonBackendError       = "BackendError"
+	EventReasonRateLimited        = "RateLimited"
+	EventReasonResolved           = "Resolved"
+	EventReasonLookupFailed       = "LookupFailed"
+	EventReasonPropagationFailed  = "PropagationFailed"
+	EventReasonPreserved          = "Preserved"
+	EventReasonDryRun             = "DryRun"
+)
+
+// Reasons of the events emitted for DNS providers using rotated credentials.
+const (
+	// EventReasonCredentialsRotated is used if the next credentials are used as the primary credentials failed.
+	EventReasonCredentialsRotated = "CredentialsRotated"
+	// EventReasonCredentialsRestored is used if the primary credentials are used again.
+	EventReasonCredentialsRestored = "CredentialsRestored"
 )
 
 const (
 	AnnotationRemoteAccess = dns.ANNOTATION_GROUP + "/remote-access"
+	// AnnotationForceCleanup is an optional annotation for DNSProviders. If set to 'true', the deletion of the provider
+	// does not delete the records of its entries in the DNS backend. Instead, the entries are released, i.e. their
+	// finalizers are removed and they are set to state Error. It is intended for decommissioned backend accounts.
+	AnnotationForceCleanup = dns.ANNOTATION_GROUP + "/force-cleanup"
 )
+
+// SecretKeyPrefixNext is the key prefix of the next credentials in a provider secret.
+// They are used as fallback if the primary credentials are not working, e.g. during credential rotation.
+const SecretKeyPrefixNext = "next."
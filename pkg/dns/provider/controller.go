@@ -7,6 +7,7 @@ package provider
 import (
 	"fmt"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/gardener/external-dns-management/pkg/apis/dns/crds"
@@ -16,11 +17,13 @@ import (
 	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/gardener/controller-manager-library/pkg/config"
 	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
 	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/reconcile"
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/watches"
 	"github.com/gardener/controller-manager-library/pkg/controllermanager/extension"
 	"github.com/gardener/controller-manager-library/pkg/logger"
 	"github.com/gardener/controller-manager-library/pkg/resources"
@@ -105,26 +108,71 @@ func DNSController(name string, factory DNSHandlerFactory) controller.Configurat
 		DefaultedBoolOption(OPT_DISABLE_ZONE_STATE_CACHING, false, "disable use of cached dns zone state on changes").
 		DefaultedBoolOption(OPT_DISABLE_DNSNAME_VALIDATION, false, "disable validation of domain names according to RFC 1123.").
 		DefaultedIntOption(OPT_TTL, 300, "Default time-to-live for DNS entries. Defines how long the record is kept in cache by DNS servers or resolvers.").
+		DefaultedIntOption(OPT_MIN_TTL, 0, "minimum time-to-live of DNS records, smaller TTLs of entries or providers are raised to this value (no minimum if 0)").
+		DefaultedIntOption(OPT_MAX_TTL, 0, "maximum time-to-live of DNS records, larger TTLs of entries or providers are lowered to this value (no maximum if 0)").
 		DefaultedIntOption(OPT_CACHE_TTL, 120, "Time-to-live for provider hosted zone cache").
+		DefaultedIntOption(OPT_MAX_TARGETS, 0, "maximum number of targets of a DNS entry (unlimited if 0)").
+		DefaultedIntOption(OPT_ZONE_RECORD_SET_LIMIT, 0, "maximum number of record sets per hosted zone, new DNS entries are refused if exceeded (unlimited if 0)").
+		DefaultedIntOption(OPT_MAX_CNAME_TARGETS, maxCNAMETargets, "maximum number of CNAME targets of a DNS entry to be resolved to addresses (at most 25)").
 		DefaultedIntOption(OPT_SETUP, 10, "number of processors for controller setup").
 		DefaultedDurationOption(OPT_DNSDELAY, 10*time.Second, "delay between two dns reconciliations").
 		DefaultedDurationOption(OPT_RESCHEDULEDELAY, 120*time.Second, "reschedule delay after losing provider").
 		DefaultedDurationOption(OPT_LOCKSTATUSCHECKPERIOD, 120*time.Second, "interval for dns lock status checks").
+		DefaultedIntOption(OPT_CIRCUIT_BREAKER_THRESHOLD, 0, "number of consecutive failures of the DNS backend of a provider account after which backend requests are suspended for the cool-down period (disabled if 0)").
+		DefaultedDurationOption(OPT_CIRCUIT_BREAKER_COOLDOWN, 5*time.Minute, "cool-down period of the provider circuit breaker before a probing request is sent to the DNS backend").
+		DefaultedDurationOption(OPT_PROVIDER_PROBE_INTERVAL, 0, "interval for probing the credentials of DNS providers by listing the hosted zones (disabled if 0)").
+		DefaultedDurationOption(OPT_LOOKUP_NEGATIVE_CACHE_TTL, 30*time.Second, "time-to-live for caching permanent lookup failures (NXDOMAIN) of CNAME targets to be resolved to addresses").
+		DefaultedDurationOption(OPT_LOOKUP_DEDUP_WINDOW, 2*time.Second, "window for sharing lookup results of the same CNAME target between DNS entries (disabled if 0)").
+		DefaultedStringOption(OPT_LOOKUP_NAMESERVERS, "", "comma separated list of name servers (ip:port) used to resolve CNAME targets to addresses (system resolver if empty)").
+		DefaultedDurationOption(OPT_CNAME_LOOKUP_INTERVAL, defaultCNameLookupInterval, "default lookup interval for CNAME targets to be resolved to addresses").
+		DefaultedDurationOption(OPT_CNAME_LOOKUP_MIN_INTERVAL, defaultCNameLookupMinInterval, "minimum lookup interval for CNAME targets to be resolved to addresses (at least 5s)").
+		DefaultedIntOption(OPT_CNAME_LOOKUP_TTL_DIVISOR, defaultCNameLookupTTLDivisor, "divisor of the TTL of resolved CNAME targets used as lower bound of the lookup interval").
+		DefaultedBoolOption(OPT_LOG_STATE_TRANSITIONS, false, "write state transitions of DNS entries as JSON lines to stdout independent of the log level").
+		DefaultedDurationOption(OPT_ZONE_AUDIT_INTERVAL, 0, "interval for auditing the records of all served hosted zones against the DNS entries (disabled if 0)").
+		DefaultedStringOption(OPT_ZONE_AUDIT_MODE, ZoneAuditModeReport, "mode of the zone audit: 'report' only reports drifted entries, 'heal' reapplies their records").
+		DefaultedDurationOption(OPT_INITIAL_ZONE_CACHE_TIMEOUT, 2*time.Minute, "maximum time to wait on startup for the first zone listing of all providers before entries without matching provider are reported as erroneous (disabled if 0)").
+		DefaultedBoolOption(OPT_PROPAGATION_VERIFICATION, false, "verify that records are resolvable at the authoritative name servers after changes").
+		DefaultedDurationOption(OPT_PROPAGATION_VERIFICATION_TIMEOUT, 5*time.Minute, "timeout for the propagation verification of a DNS entry").
+		DefaultedDurationOption(OPT_PROPAGATION_VERIFICATION_INTERVAL, 15*time.Second, "retry interval for the propagation verification of a DNS entry").
+		DefaultedBoolOption(OPT_TARGET_REFS, false, "resolve target references of DNS entries to Services and Ingresses, which are watched in the target cluster").
+		DefaultedStringOption(OPT_ENTRY_RECONCILE_QPS, "0", "maximum number of DNS entry reconciliations per second, fractions like 0.5 are allowed (unlimited if 0)").
+		DefaultedIntOption(OPT_ENTRY_RECONCILE_BURST, 10, "number of burst DNS entry reconciliations for the entry reconcile rate limiter").
+		DefaultedDurationOption(OPT_ENTRY_RECONCILE_MIN_INTERVAL, defaultEntryReconcileMinInterval, "minimum reconcile interval of DNS entries annotated with "+dns.AnnotationReconcileInterval).
+		DefaultedDurationOption(OPT_DRAIN_TIMEOUT, 20*time.Second, "maximum time to wait on shutdown for in-flight reconciliations to finish, no new zone reconciliations are started meanwhile (disabled if 0)").
+		DefaultedDurationOption(OPT_ENTRY_STATUS_INTERVAL, 0, "minimum interval between two status updates of a DNS entry without state transition, intermediate changes are coalesced (disabled if 0)").
+		DefaultedDurationOption(OPT_ENTRY_EVENT_WINDOW, 5*time.Minute, "window in which identical events of a DNS entry are not emitted again (disabled if 0)").
 		DefaultedIntOption(OPT_REMOTE_ACCESS_PORT, 0, "port of remote access server for remote-enabled providers").
 		DefaultedStringOption(OPT_REMOTE_ACCESS_CACERT, "", "CA who signed client certs file").
 		DefaultedStringOption(OPT_REMOTE_ACCESS_SERVER_SECRET_NAME, "", "name of secret containing remote access server's certificate").
 		DefaultedStringOption(OPT_REMOTE_ACCESS_CLIENT_ID, "", "identifier used for remote access").
+		DefaultedStringOption(OPT_TRACING_ENDPOINT, "", "OTLP/HTTP endpoint URL (e.g. http://jaeger-collector:4318) for exporting the tracing spans of change request executions (disabled if empty)").
+		DefaultedStringOption(OPT_PROVIDERTYPE_DEFAULTS, "", "YAML file with defaults for DNS providers per provider type (defaultTTL, rateLimit, maxConcurrentRequests, zoneStateCacheTTL)").
+		DefaultedStringOption(OPT_PROVIDER_CREDENTIALS_DIR, "", "directory with mounted credentials referenced by DNS providers with credentialsPath (disabled if empty)").
+		DefaultedStringOption(OPT_OWNERSHIP_RECORD_OWNER_ID, "", "owner id for additional ownership TXT records in the format of external-dns (disabled if empty)").
+		DefaultedStringOption(OPT_ENTRY_NAMESPACES, "", "comma separated list of namespaces of DNS entries handled by the controller (all namespaces if empty)").
+		DefaultedStringOption(OPT_EXCLUDED_ENTRY_NAMESPACES, "", "comma separated list of namespaces of DNS entries ignored by the controller").
+		DefaultedStringOption(OPT_PROVIDER_TIE_BREAKER, ProviderTieBreakerName, "tie breaker for providers with same priority matching a DNS entry equally: 'name' prefers the lowest provider name, 'creation-time' the oldest provider").
+		DefaultedStringOption(OPT_OWNER_CONFLICT_STRATEGY, OwnerConflictStrategyNone, "strategy for controller instances using the same owner id for the same records: 'none', 'first-writer-wins' defers to the instance holding a valid lease, 'priority' to the instance with higher priority").
+		DefaultedStringOption(OPT_OWNER_CONFLICT_INSTANCE, "", "unique name of the controller instance used for the owner conflict strategy").
+		DefaultedIntOption(OPT_OWNER_CONFLICT_PRIORITY, 0, "priority of the controller instance for owner conflict strategy 'priority'").
+		DefaultedDurationOption(OPT_OWNER_CONFLICT_LEASE, 30*time.Minute, "validity of the lease of a controller instance on the records it has written, renewed after half of it").
+		DefaultedStringOption(OPT_FINALIZER_NAME, "", "name of the finalizer for DNS entries and providers (default: derived from controller name); use distinct names for controller instances running side by side").
 		FinalizerDomain("dns.gardener.cloud").
 		Reconciler(DNSReconcilerType(factory)).
 		Cluster(TARGET_CLUSTER).
 		Syncer(SYNC_ENTRIES, controller.NewResourceKey(api.GroupName, api.DNSEntryKind)).
 		CustomResourceDefinitions(ownerGroupKind, entryGroupKind).
-		MainResource(api.GroupName, api.DNSEntryKind).
+		MainResource(api.GroupName, api.DNSEntryKind, entryNamespaceSelection).
 		DefaultWorkerPool(2, 0).
 		WorkerPool("ownerids", 1, 0).
 		Watches(
 			controller.NewResourceKey(api.GroupName, api.DNSOwnerKind),
 		).
+		WorkerPool("targetrefs", 2, 0).
+		FlavoredWatches(
+			watches.SimpleResourceFlavorsByGK(serviceGroupKind, watches.FlagOption(OPT_TARGET_REFS)),
+			watches.SimpleResourceFlavorsByGK(ingressGroupKind, watches.FlagOption(OPT_TARGET_REFS)),
+		).
 		Cluster(PROVIDER_CLUSTER).
 		CustomResourceDefinitions(providerGroupKind).
 		WorkerPool("providers", 2, 10*time.Minute).
@@ -142,10 +190,32 @@ func DNSController(name string, factory DNSHandlerFactory) controller.Configurat
 		).
 		WorkerPool(DNS_POOL, 1, 15*time.Minute).CommandMatchers(utils.NewStringGlobMatcher(CMD_HOSTEDZONE_PREFIX+"*")).
 		WorkerPool("statistic", 2, 0).Commands(CMD_STATISTIC).
+		WorkerPool("zoneaudit", 1, 0).Commands(CMD_ZONE_AUDIT).
 		OptionSource(FACTORY_OPTIONS, FactoryOptionSourceCreator(factory))
 	return cfg
 }
 
+// validateFinalizerName checks that the finalizer name is a qualified name with domain prefix.
+func validateFinalizerName(name string) error {
+	if !strings.Contains(name, "/") {
+		return fmt.Errorf("invalid value %q for option %s: domain prefix required (e.g. dns.gardener.cloud/my-controller)", name, OPT_FINALIZER_NAME)
+	}
+	if errs := validation.IsQualifiedName(name); len(errs) > 0 {
+		return fmt.Errorf("invalid value %q for option %s: %s", name, OPT_FINALIZER_NAME, strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// newFinalizerHandler returns the finalizer handler setting the given finalizer and replacing the
+// finalizers of older controller versions if provided by the factory.
+func newFinalizerHandler(logger logger.LogContext, finalizerName string, factory DNSHandlerFactory, classes *controller.Classes) controller.Finalizer {
+	if f, ok := factory.(Finalizers); ok {
+		g := controller.NewFinalizerGroup(finalizerName, f.Finalizers())
+		return controller.NewFinalizerForGroupAndClasses(g, classes)
+	}
+	return controller.NewFinalizerForClasses(logger, finalizerName, classes)
+}
+
 type reconciler struct {
 	reconcile.DefaultReconciler
 	controller controller.Interface
@@ -168,12 +238,17 @@ func DNSReconcilerType(factory DNSHandlerFactory) controller.ReconcilerType {
 
 func Create(c controller.Interface, factory DNSHandlerFactory) (reconcile.Interface, error) {
 	classes := controller.NewClassesByOption(c, OPT_CLASS, source.CLASS_ANNOTATION, dns.DEFAULT_CLASS)
-	if f, ok := factory.(Finalizers); ok {
-		g := controller.NewFinalizerGroup(c.GetDefinition().FinalizerName(), f.Finalizers())
-		c.SetFinalizerHandler(controller.NewFinalizerForGroupAndClasses(g, classes))
+	finalizerName, _ := c.GetStringOption(OPT_FINALIZER_NAME)
+	if finalizerName != "" {
+		if err := validateFinalizerName(finalizerName); err != nil {
+			return nil, err
+		}
+		// the finalizer of the default controller instance is kept for instances running side by side
+		c.Infof("using finalizer %q", finalizerName)
 	} else {
-		c.SetFinalizerHandler(controller.NewFinalizerForClasses(c, c.GetDefinition().FinalizerName(), classes))
+		finalizerName = c.GetDefinition().FinalizerName()
 	}
+	c.SetFinalizerHandler(newFinalizerHandler(c, finalizerName, factory, classes))
 
 	config, err := NewConfigForController(c, factory)
 	if err != nil {
@@ -214,6 +289,8 @@ func (this *reconciler) Command(logger logger.LogContext, cmd string) reconcile.
 	switch cmd {
 	case CMD_STATISTIC:
 		this.state.UpdateOwnerCounts(logger)
+	case CMD_ZONE_AUDIT:
+		return this.state.AuditZones(logger)
 	default:
 		zoneid := this.state.DecodeZoneCommand(cmd)
 		if zoneid != nil {
@@ -240,8 +317,12 @@ func (this *reconciler) Reconcile(logger logger.LogContext, obj resources.Object
 		}
 	case obj.IsA(&api.DNSEntry{}):
 		if this.state.IsResponsibleFor(logger, obj) {
+			if accepted, delay := this.state.TryAcceptEntryReconcile(logger); !accepted {
+				return reconcile.Succeeded(logger).RescheduleAfter(delay)
+			}
 			return this.state.UpdateEntry(logger, dnsutils.DNSEntry(obj))
 		} else {
+			this.state.releaseOutOfScopeEntry(logger, obj)
 			return this.state.EntryDeleted(logger, obj.ClusterKey())
 		}
 	case obj.IsA(&api.DNSHostedZonePolicy{}):
@@ -252,6 +333,8 @@ func (this *reconciler) Reconcile(logger logger.LogContext, obj resources.Object
 		}
 	case obj.IsMinimal() && obj.GroupVersionKind().GroupKind() == secretGroupKind:
 		return this.state.UpdateSecret(logger, obj)
+	case isTargetRefGroupKind(obj.GroupVersionKind().GroupKind()):
+		this.state.TargetRefObjectChanged(logger, obj.ClusterKey())
 	}
 	return reconcile.Succeeded(logger)
 }
@@ -268,6 +351,8 @@ func (this *reconciler) Delete(logger logger.LogContext, obj resources.Object) r
 		case obj.IsMinimal() && obj.GroupVersionKind().GroupKind() == secretGroupKind:
 			return this.state.UpdateSecret(logger, obj)
 		}
+	} else if obj.IsA(&api.DNSEntry{}) {
+		this.state.releaseOutOfScopeEntry(logger, obj)
 	}
 	return reconcile.Succeeded(logger)
 }
@@ -283,6 +368,8 @@ func (this *reconciler) Deleted(logger logger.LogContext, key resources.ClusterO
 		return this.state.EntryDeleted(logger, key)
 	case zonePolicyGroupKind:
 		return this.state.ZonePolicyDeleted(logger, key)
+	case serviceGroupKind, ingressGroupKind:
+		this.state.TargetRefObjectChanged(logger, key)
 	}
 	return reconcile.Succeeded(logger)
 }
@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/source"
+)
+
+var _ = ginkgov2.Describe("Finalizer name", func() {
+	ginkgov2.It("accepts qualified names with domain prefix", func() {
+		Expect(validateFinalizerName("dns.gardener.cloud/compound-new")).To(Succeed())
+	})
+
+	ginkgov2.It("rejects names without domain prefix", func() {
+		Expect(validateFinalizerName("compound-new")).To(MatchError(ContainSubstring("domain prefix required")))
+	})
+
+	ginkgov2.It("rejects invalid names", func() {
+		Expect(validateFinalizerName("dns.gardener.cloud/compound_new!")).NotTo(Succeed())
+	})
+})
+
+var _ = ginkgov2.Describe("Finalizer handler", func() {
+	ginkgov2.It("replaces the finalizers of older controller versions by the configured finalizer", func() {
+		factory := NewDNSHandlerCompoundFactory("test")
+		Expect(factory.Add(NewDNSHandlerFactory("aws-route53", nil))).To(Succeed())
+		classes := controller.NewClasses(nil, "", source.CLASS_ANNOTATION, dns.DEFAULT_CLASS)
+
+		h := newFinalizerHandler(nil, "dns.gardener.cloud/compound-new", factory, classes)
+		g, ok := h.(controller.FinalizerGroup)
+		Expect(ok).To(BeTrue())
+		Expect(g.Main()).To(Equal("dns.gardener.cloud/compound-new"))
+		Expect(g.Finalizers().AsArray()).To(ConsistOf("dns.gardener.cloud/compound-new", "dns.gardener.cloud/aws-route53"))
+	})
+})
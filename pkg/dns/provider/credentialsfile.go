@@ -0,0 +1,226 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	"sigs.k8s.io/yaml"
+)
+
+// credentialsFileCheckPeriod is the interval for checking mounted provider credentials for changes.
+const credentialsFileCheckPeriod = 30 * time.Second
+
+// credentialsFile is the mounted credentials file or directory used by a provider.
+type credentialsFile struct {
+	path        string
+	fingerprint string
+}
+
+// readCredentialsFile reads the provider credentials from the given path relative to the credentials directory.
+// The path is either a directory with one file per key (like a mounted secret) or a YAML file with keys and values.
+// The returned credentials file is also set if reading failed to detect the credentials appearing later.
+func readCredentialsFile(dir, path string) (*credentialsFile, utils.Properties, error) {
+	if dir == "" {
+		return nil, nil, fmt.Errorf("credentials path not supported: option %s not set", OPT_PROVIDER_CREDENTIALS_DIR)
+	}
+	if path == "" {
+		return nil, nil, fmt.Errorf("empty credentials path")
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	file := &credentialsFile{path: filepath.Clean(path)}
+	props, err := readCredentials(dir, file.path)
+	if err != nil {
+		return file, nil, err
+	}
+	file.fingerprint = credentialsFingerprint(props)
+	return file, props, nil
+}
+
+func readCredentials(dir, path string) (utils.Properties, error) {
+	base, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return nil, fmt.Errorf("cannot access credentials directory: %w", err)
+	}
+	if err := checkWithin(base, path); err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	props := utils.Properties{}
+	if info.IsDir() {
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			// skip hidden files and the internal links of mounted secrets (e.g. ..data)
+			if strings.HasPrefix(e.Name(), ".") {
+				continue
+			}
+			file := filepath.Join(path, e.Name())
+			if err := checkWithin(base, file); err != nil {
+				return nil, err
+			}
+			fi, err := os.Stat(file)
+			if err != nil {
+				return nil, err
+			}
+			if fi.IsDir() {
+				continue
+			}
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return nil, err
+			}
+			props[e.Name()] = string(data)
+		}
+	} else {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		values := map[string]string{}
+		if err := yaml.UnmarshalStrict(data, &values); err != nil {
+			return nil, fmt.Errorf("invalid credentials file %s: %w", path, err)
+		}
+		for k, v := range values {
+			props[k] = v
+		}
+	}
+	if len(props) == 0 {
+		return nil, fmt.Errorf("no credentials found in %s", path)
+	}
+	return props, nil
+}
+
+// checkWithin checks that the given path resolved by following symbolic links is located in the base directory.
+func checkWithin(base, path string) error {
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return fmt.Errorf("cannot access credentials: %w", err)
+	}
+	rel, err := filepath.Rel(base, resolved)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("credentials path %s is outside of the credentials directory", path)
+	}
+	return nil
+}
+
+func credentialsFingerprint(props utils.Properties) string {
+	keys := make([]string, 0, len(props))
+	for k := range props {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	h := sha256.New()
+	for _, k := range keys {
+		fmt.Fprintf(h, "%s=%d:%s\n", k, len(props[k]), props[k])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// credentialsFiles keeps track of the mounted credentials used by providers to detect changes.
+type credentialsFiles struct {
+	lock  sync.Mutex
+	dir   string
+	files map[resources.ObjectName]*credentialsFile
+}
+
+func newCredentialsFiles(dir string) *credentialsFiles {
+	return &credentialsFiles{dir: dir, files: map[resources.ObjectName]*credentialsFile{}}
+}
+
+// Set sets the credentials file used by a provider. A nil file removes the provider.
+func (this *credentialsFiles) Set(name resources.ObjectName, file *credentialsFile) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if file == nil {
+		delete(this.files, name)
+	} else {
+		this.files[name] = &credentialsFile{path: file.path, fingerprint: file.fingerprint}
+	}
+}
+
+// Changed returns the providers whose credentials have changed since the last check.
+func (this *credentialsFiles) Changed() []resources.ObjectName {
+	this.lock.Lock()
+	files := make(map[resources.ObjectName]credentialsFile, len(this.files))
+	for name, file := range this.files {
+		files[name] = *file
+	}
+	this.lock.Unlock()
+
+	changed := map[resources.ObjectName]string{}
+	for name, file := range files {
+		fingerprint := ""
+		if props, err := readCredentials(this.dir, file.path); err == nil {
+			fingerprint = credentialsFingerprint(props)
+		}
+		if fingerprint != file.fingerprint {
+			changed[name] = fingerprint
+		}
+	}
+
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	var result []resources.ObjectName
+	for name, fingerprint := range changed {
+		if cur := this.files[name]; cur != nil && cur.fingerprint == files[name].fingerprint {
+			// report a change only once, even if the provider has not been reconciled until the next check
+			cur.fingerprint = fingerprint
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+// watchCredentialsFiles periodically requeues providers with changed credentials files.
+func (this *state) watchCredentialsFiles() {
+	log := this.context.AddIndent("credentials files: ")
+	ticker := time.NewTicker(credentialsFileCheckPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-this.context.GetContext().Done():
+			return
+		case <-ticker.C:
+			this.requeueProvidersWithChangedCredentials(log)
+		}
+	}
+}
+
+func (this *state) requeueProvidersWithChangedCredentials(log logger.LogContext) {
+	for _, name := range this.credentialsFiles.Changed() {
+		this.lock.RLock()
+		p := this.providers[name]
+		this.lock.RUnlock()
+		if p == nil {
+			continue
+		}
+		log.Infof("requeueing provider %q with changed credentials", name)
+		if err := this.context.Enqueue(p.object); err != nil {
+			log.Warnf("cannot enqueue provider %q: %s", name, err)
+		}
+	}
+}
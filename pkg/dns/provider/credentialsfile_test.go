@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = ginkgov2.Describe("Credentials files", func() {
+	var dir string
+
+	// mountSecret creates the layout of a mounted secret with a file per key linked to a versioned data directory
+	mountSecret := func(name string, data map[string]string) {
+		mount := filepath.Join(dir, name)
+		Expect(os.MkdirAll(mount, 0o755)).To(Succeed())
+		version := "..v" + data["version"]
+		Expect(os.Mkdir(filepath.Join(mount, version), 0o755)).To(Succeed())
+		for k, v := range data {
+			Expect(os.WriteFile(filepath.Join(mount, version, k), []byte(v), 0o600)).To(Succeed())
+		}
+		_ = os.Remove(filepath.Join(mount, "..data"))
+		Expect(os.Symlink(version, filepath.Join(mount, "..data"))).To(Succeed())
+		for k := range data {
+			_ = os.Symlink(filepath.Join("..data", k), filepath.Join(mount, k))
+		}
+	}
+
+	ginkgov2.BeforeEach(func() {
+		dir = ginkgov2.GinkgoT().TempDir()
+	})
+
+	ginkgov2.It("reads the keys of a mounted secret", func() {
+		mountSecret("aws", map[string]string{"version": "1", "AWS_ACCESS_KEY_ID": "id"})
+		file, props, err := readCredentialsFile(dir, "aws")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(props).To(Equal(utils.Properties{"version": "1", "AWS_ACCESS_KEY_ID": "id"}))
+		Expect(file.path).To(Equal(filepath.Join(dir, "aws")))
+		Expect(file.fingerprint).NotTo(BeEmpty())
+	})
+
+	ginkgov2.It("reads a YAML file", func() {
+		Expect(os.WriteFile(filepath.Join(dir, "creds.yaml"), []byte("AWS_ACCESS_KEY_ID: id\nAWS_SECRET_ACCESS_KEY: secret\n"), 0o600)).To(Succeed())
+		_, props, err := readCredentialsFile(dir, filepath.Join(dir, "creds.yaml"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(props).To(Equal(utils.Properties{"AWS_ACCESS_KEY_ID": "id", "AWS_SECRET_ACCESS_KEY": "secret"}))
+	})
+
+	ginkgov2.It("fails if not enabled", func() {
+		_, _, err := readCredentialsFile("", "aws")
+		Expect(err).To(MatchError(ContainSubstring(OPT_PROVIDER_CREDENTIALS_DIR)))
+	})
+
+	ginkgov2.It("rejects paths outside of the credentials directory", func() {
+		outside := ginkgov2.GinkgoT().TempDir()
+		Expect(os.WriteFile(filepath.Join(outside, "creds.yaml"), []byte("key: value\n"), 0o600)).To(Succeed())
+		Expect(os.Symlink(outside, filepath.Join(dir, "link"))).To(Succeed())
+
+		for _, path := range []string{filepath.Join(outside, "creds.yaml"), "../" + filepath.Base(outside) + "/creds.yaml", "link/creds.yaml", "link"} {
+			_, _, err := readCredentialsFile(dir, path)
+			Expect(err).To(MatchError(ContainSubstring("outside of the credentials directory")), path)
+		}
+	})
+
+	ginkgov2.It("detects changed credentials once", func() {
+		mountSecret("aws", map[string]string{"version": "1"})
+		name := resources.NewObjectName("default", "p1")
+		files := newCredentialsFiles(dir)
+		file, _, err := readCredentialsFile(dir, "aws")
+		Expect(err).NotTo(HaveOccurred())
+		files.Set(name, file)
+		Expect(files.Changed()).To(BeEmpty())
+
+		mountSecret("aws", map[string]string{"version": "2"})
+		Expect(files.Changed()).To(ConsistOf(name))
+		Expect(files.Changed()).To(BeEmpty())
+
+		files.Set(name, nil)
+		mountSecret("aws", map[string]string{"version": "3"})
+		Expect(files.Changed()).To(BeEmpty())
+	})
+
+	ginkgov2.It("detects credentials appearing later", func() {
+		name := resources.NewObjectName("default", "p1")
+		files := newCredentialsFiles(dir)
+		file, _, err := readCredentialsFile(dir, "aws")
+		Expect(err).To(HaveOccurred())
+		files.Set(name, file)
+		Expect(files.Changed()).To(BeEmpty())
+
+		mountSecret("aws", map[string]string{"version": "1"})
+		Expect(files.Changed()).To(ConsistOf(name))
+	})
+})
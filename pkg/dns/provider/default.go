@@ -6,6 +6,7 @@ package provider
 
 import (
 	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/selection"
 	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
 )
 
@@ -39,6 +40,7 @@ type DefaultDNSHostedZone struct {
 	forwarded []string   // forwarded sub domains
 	key       string     // internal key used by provider (not used by this lib)
 	isPrivate bool       // indicates a private zone
+	tags      map[string]string
 }
 
 func (this *DefaultDNSHostedZone) Key() string {
@@ -64,6 +66,11 @@ func (this *DefaultDNSHostedZone) IsPrivate() bool {
 	return this.isPrivate
 }
 
+// Tags returns the tags or labels of the zone. It is nil if not provided by the provider.
+func (this *DefaultDNSHostedZone) Tags() map[string]string {
+	return this.tags
+}
+
 func (this *DefaultDNSHostedZone) Match(dnsname string) int {
 	return Match(this, dnsname)
 }
@@ -84,9 +91,18 @@ func NewDNSHostedZone(providerType, id, domain, key string, isPrivate bool) DNSH
 	return &DefaultDNSHostedZone{zoneid: dns.NewZoneID(providerType, id), key: key, domain: domain, isPrivate: isPrivate}
 }
 
+// NewDNSHostedZoneWithTags creates a hosted zone with the tags or labels of the zone used for the zone selection.
+func NewDNSHostedZoneWithTags(providerType, id, domain, key string, isPrivate bool, tags map[string]string) DNSHostedZone {
+	return &DefaultDNSHostedZone{zoneid: dns.NewZoneID(providerType, id), key: key, domain: domain, isPrivate: isPrivate, tags: tags}
+}
+
 func CopyDNSHostedZone(zone DNSHostedZone, forwardedDomains []string) DNSHostedZone {
-	return &DefaultDNSHostedZone{
+	result := &DefaultDNSHostedZone{
 		zoneid: zone.Id(), key: zone.Key(),
 		domain: zone.Domain(), forwarded: forwardedDomains, isPrivate: zone.IsPrivate(),
 	}
+	if tz, ok := zone.(selection.TaggedDNSHostedZone); ok {
+		result.tags = tz.Tags()
+	}
+	return result
 }
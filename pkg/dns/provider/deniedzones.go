@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/gardener/controller-manager-library/pkg/utils"
+)
+
+// DeniedZonesTracker can be embedded by DNS handlers listing hosted zones the credentials grant no access to.
+// A zone is reported as denied after reading its records failed with an access denied error, until a later
+// read succeeds. The embedding handler implements DeniedZonesReporter.
+type DeniedZonesTracker struct {
+	lock   sync.Mutex
+	denied utils.StringSet
+}
+
+// UpdateDeniedZone records whether reading the records of the zone with the given id was denied.
+func (this *DeniedZonesTracker) UpdateDeniedZone(id string, denied bool) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if denied {
+		if this.denied == nil {
+			this.denied = utils.StringSet{}
+		}
+		this.denied.Add(id)
+	} else {
+		this.denied.Remove(id)
+	}
+}
+
+// DeniedZones returns the sorted ids of the zones the last read of the records was denied for.
+func (this *DeniedZonesTracker) DeniedZones() []string {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if len(this.denied) == 0 {
+		return nil
+	}
+	ids := this.denied.AsArray()
+	sort.Strings(ids)
+	return ids
+}
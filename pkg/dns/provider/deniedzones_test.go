@@ -0,0 +1,25 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = ginkgov2.Describe("Denied zones tracker", func() {
+	ginkgov2.It("reports zones until reading them succeeds again", func() {
+		tracker := &DeniedZonesTracker{}
+		Expect(tracker.DeniedZones()).To(BeNil())
+
+		tracker.UpdateDeniedZone("z2", true)
+		tracker.UpdateDeniedZone("z1", true)
+		tracker.UpdateDeniedZone("z3", false)
+		Expect(tracker.DeniedZones()).To(Equal([]string{"z1", "z2"}))
+
+		tracker.UpdateDeniedZone("z2", false)
+		Expect(tracker.DeniedZones()).To(Equal([]string{"z1"}))
+	})
+})
@@ -0,0 +1,349 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// dependent DNS entries
+//
+// Aliases, further split providers and mirror providers of a DNS entry are
+// served by DNS entries maintained in the namespace of the (owner) entry.
+// They reference the owner entry to inherit its targets, are owned by it and
+// are marked with an annotation containing the name of the owner entry.
+////////////////////////////////////////////////////////////////////////////////
+
+// dependentKind describes a kind of DNS entries maintained for an owner entry.
+type dependentKind struct {
+	// name is used as infix of the entry names and in log messages
+	name string
+	// marker is the annotation containing the name of the owner entry
+	marker string
+	// reported returns the states of the maintained entries reported in the status of the owner entry
+	reported func(status *api.DNSEntryStatus) []dependentState
+}
+
+// dependentState is the state of a maintained entry as reported in the status of the owner entry.
+type dependentState struct {
+	entry   string
+	state   string
+	message *string
+}
+
+var (
+	aliasEntries = &dependentKind{
+		name:   "alias",
+		marker: dns.AnnotationAliasOf,
+		reported: func(status *api.DNSEntryStatus) []dependentState {
+			var states []dependentState
+			for _, a := range status.Aliases {
+				states = append(states, dependentState{entry: a.Entry, state: a.State, message: a.Message})
+			}
+			return states
+		},
+	}
+	splitEntries = &dependentKind{
+		name:   "split",
+		marker: dns.AnnotationSplitOf,
+		reported: func(status *api.DNSEntryStatus) []dependentState {
+			var states []dependentState
+			for _, s := range status.SplitProviders {
+				states = append(states, dependentState{entry: s.Entry, state: s.State, message: s.Message})
+			}
+			return states
+		},
+	}
+	mirrorEntries = &dependentKind{
+		name:   "mirror",
+		marker: dns.AnnotationMirrorOf,
+		reported: func(status *api.DNSEntryStatus) []dependentState {
+			if m := status.Mirror; m != nil {
+				return []dependentState{{entry: m.Entry, state: m.State, message: m.Message}}
+			}
+			return nil
+		},
+	}
+
+	dependentKinds = []*dependentKind{aliasEntries, splitEntries, mirrorEntries}
+)
+
+// entryName returns the name of the DNS entry maintained for the owner entry with the given name.
+// The key identifies the maintained entry among the entries of this kind of the owner entry.
+func (this *dependentKind) entryName(owner, key string) string {
+	sum := sha256.Sum256([]byte(key))
+	infix := "-" + this.name + "-"
+	prefix := owner
+	if maxPrefix := 253 - len(infix) - 8; len(prefix) > maxPrefix {
+		prefix = prefix[:maxPrefix]
+	}
+	return prefix + infix + hex.EncodeToString(sum[:])[:8]
+}
+
+// ownerOf returns the name of the owner entry the given entry is maintained for or an empty string.
+func (this *dependentKind) ownerOf(object resources.Object) string {
+	return object.GetAnnotations()[this.marker]
+}
+
+// isEntryOf checks whether the given object is an entry of this kind maintained for the given owner.
+func (this *dependentKind) isEntryOf(object, owner resources.Object) bool {
+	return this.ownerOf(object) == owner.GetName() && hasOwnerReference(object.GetOwnerReferences(), owner.GetUID())
+}
+
+// dependentOwner returns the name of the owner entry the given entry is maintained for or nil.
+func dependentOwner(object resources.Object) resources.ObjectName {
+	for _, kind := range dependentKinds {
+		if owner := kind.ownerOf(object); owner != "" {
+			return resources.NewObjectName(object.GetNamespace(), owner)
+		}
+	}
+	return nil
+}
+
+// dependentIndex keeps the names of the maintained entries by the name of their owner entry as given by their
+// marker annotations, so that the maintained entries of an entry are found without listing the whole namespace.
+type dependentIndex struct {
+	lock    sync.Mutex
+	owners  map[resources.ObjectName]resources.ObjectName
+	entries map[resources.ObjectName]sets.Set[string]
+}
+
+func newDependentIndex() *dependentIndex {
+	return &dependentIndex{
+		owners:  map[resources.ObjectName]resources.ObjectName{},
+		entries: map[resources.ObjectName]sets.Set[string]{},
+	}
+}
+
+// update sets the owner entry of the given entry, nil removes it from the index.
+func (this *dependentIndex) update(name, owner resources.ObjectName) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if old := this.owners[name]; old != nil {
+		if owner != nil && old == owner {
+			return
+		}
+		this.unset(name, old)
+	}
+	if owner != nil {
+		this.owners[name] = owner
+		if this.entries[owner] == nil {
+			this.entries[owner] = sets.New[string]()
+		}
+		this.entries[owner].Insert(name.Name())
+	}
+}
+
+// remove removes the given entry from the index.
+func (this *dependentIndex) remove(name resources.ObjectName) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if old := this.owners[name]; old != nil {
+		this.unset(name, old)
+	}
+}
+
+func (this *dependentIndex) unset(name, owner resources.ObjectName) {
+	delete(this.owners, name)
+	if names := this.entries[owner]; names != nil {
+		names.Delete(name.Name())
+		if names.Len() == 0 {
+			delete(this.entries, owner)
+		}
+	}
+}
+
+// get returns the names of the entries maintained for the given owner entry.
+func (this *dependentIndex) get(owner resources.ObjectName) []string {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return sets.List(this.entries[owner])
+}
+
+// getDependentEntries returns the cached DNS entries of the given kind maintained for the entry by name.
+// Candidates are the desired entries, the entries reported in the status of the entry and the indexed entries.
+func (this *state) getDependentEntries(kind *dependentKind, object *dnsutils.DNSEntryObject, desired ...string) (map[string]*dnsutils.DNSEntryObject, error) {
+	names := sets.New(desired...)
+	for _, s := range kind.reported(object.Status()) {
+		names.Insert(s.entry)
+	}
+	names.Insert(this.dependents.get(object.ObjectName())...)
+	names.Delete("", object.GetName())
+
+	res := object.GetResource().Namespace(object.GetNamespace())
+	result := map[string]*dnsutils.DNSEntryObject{}
+	for name := range names {
+		o, err := res.GetCached(name)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		if kind.isEntryOf(o, object) {
+			result[name] = dnsutils.DNSEntry(o)
+		}
+	}
+	return result, nil
+}
+
+// deleteDependentEntries deletes the given maintained entries which are not desired anymore.
+func deleteDependentEntries(logger logger.LogContext, kind *dependentKind, obsolete map[string]*dnsutils.DNSEntryObject) error {
+	for name, o := range obsolete {
+		if o.IsDeleting() {
+			continue
+		}
+		logger.Infof("deleting obsolete %s entry %s", kind.name, name)
+		if err := o.Delete(); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// notifyDependentOwner triggers the owner entry of a maintained entry if the state of the maintained entry
+// reported in the status of the owner entry is outdated.
+func (this *state) notifyDependentOwner(object *dnsutils.DNSEntryObject) {
+	for _, kind := range dependentKinds {
+		owner := kind.ownerOf(object)
+		if owner == "" {
+			continue
+		}
+		o, err := object.GetResource().Namespace(object.GetNamespace()).GetCached(owner)
+		if err != nil || !kind.isEntryOf(object, o) {
+			continue
+		}
+		if !isReportedState(kind, dnsutils.DNSEntry(o).Status(), object) {
+			this.triggerKey(o.ClusterKey())
+		}
+	}
+}
+
+func isReportedState(kind *dependentKind, owner *api.DNSEntryStatus, object *dnsutils.DNSEntryObject) bool {
+	status := object.Status()
+	for _, s := range kind.reported(owner) {
+		if s.entry == object.GetName() {
+			return s.state == status.State && reflect.DeepEqual(s.message, status.Message)
+		}
+	}
+	return false
+}
+
+// dependentEntrySpec returns the desired spec of a DNS entry maintained for the given entry with the given DNS name
+// and provider. The targets are inherited by referencing the entry. A target reference of the entry is copied instead,
+// so that the load balancer addresses are watched for the maintained entry, too.
+func dependentEntrySpec(entry *api.DNSEntry, dnsName string, provider *api.ProviderReference) api.DNSEntrySpec {
+	spec := api.DNSEntrySpec{
+		DNSName:                   dnsName,
+		ProviderRef:               provider,
+		RoutingPolicy:             entry.Spec.RoutingPolicy.DeepCopy(),
+		Comment:                   entry.Spec.Comment,
+		ResolveTargetsToAddresses: entry.Spec.ResolveTargetsToAddresses,
+		ResolveTargetsExclusions:  entry.Spec.ResolveTargetsExclusions,
+	}
+	if ref := entry.Spec.TargetRef; ref != nil {
+		spec.TargetRef = ref.DeepCopy()
+		if spec.TargetRef.Namespace == "" {
+			spec.TargetRef.Namespace = entry.Namespace
+		}
+		// inherited by the entry reference otherwise
+		spec.TTL = entry.Spec.TTL
+		spec.OwnerId = entry.Spec.OwnerId
+		spec.CNameLookupInterval = entry.Spec.CNameLookupInterval
+	} else {
+		spec.Reference = &api.EntryReference{Name: entry.Name}
+	}
+	return spec
+}
+
+// dependentEntryAnnotations returns the desired annotations of a DNS entry of the given kind maintained for
+// the given entry. All annotations of the DNS annotation group are inherited, except the markers of other
+// maintained entries.
+func dependentEntryAnnotations(entry *api.DNSEntry, kind *dependentKind) map[string]string {
+	annotations := map[string]string{}
+	for k, v := range entry.Annotations {
+		switch k {
+		case dns.AnnotationImported, dns.AnnotationAliasOf, dns.AnnotationSplitOf, dns.AnnotationMirrorOf:
+			continue
+		}
+		if strings.HasPrefix(k, dns.ANNOTATION_GROUP+"/") {
+			annotations[k] = v
+		}
+	}
+	annotations[kind.marker] = entry.Name
+	return annotations
+}
+
+// assureDependentEntry creates or updates a DNS entry owned by the given entry with the desired spec and annotations.
+func (this *state) assureDependentEntry(logger logger.LogContext, res resources.Interface, entry *api.DNSEntry, name, desc string, spec api.DNSEntrySpec, annotations map[string]string) error {
+	child := &api.DNSEntry{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: entry.Namespace,
+			Name:      name,
+		},
+	}
+	ownerRef := metav1.OwnerReference{
+		APIVersion: api.SchemeGroupVersion.String(),
+		Kind:       api.DNSEntryKind,
+		Name:       entry.Name,
+		UID:        entry.UID,
+		Controller: ptr.To(true),
+	}
+	_, mod, err := res.CreateOrModifyByName(child, func(data resources.ObjectData) (bool, error) {
+		e := data.(*api.DNSEntry)
+		modified := false
+		if !reflect.DeepEqual(e.Spec, spec) {
+			e.Spec = spec
+			modified = true
+		}
+		for k, v := range annotations {
+			modified = resources.SetAnnotation(e, k, v) || modified
+		}
+		for k := range e.GetAnnotations() {
+			if _, ok := annotations[k]; !ok && strings.HasPrefix(k, dns.ANNOTATION_GROUP+"/") {
+				modified = resources.RemoveAnnotation(e, k) || modified
+			}
+		}
+		if !hasOwnerReference(e.OwnerReferences, entry.UID) {
+			e.OwnerReferences = append(e.OwnerReferences, ownerRef)
+			modified = true
+		}
+		return modified, nil
+	})
+	if err != nil {
+		return fmt.Errorf("cannot maintain entry %s for %s: %w", name, desc, err)
+	}
+	if mod {
+		logger.Infof("updated entry %s for %s", name, desc)
+	}
+	return nil
+}
+
+func hasOwnerReference(refs []metav1.OwnerReference, uid types.UID) bool {
+	for _, r := range refs {
+		if r.UID == uid {
+			return true
+		}
+	}
+	return false
+}
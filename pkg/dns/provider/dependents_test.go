@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+)
+
+var _ = ginkgov2.Describe("Dependent entries", func() {
+	owner := resources.NewObjectName("ns", "entry")
+	other := resources.NewObjectName("ns", "other")
+
+	ginkgov2.It("indexes the maintained entries by owner", func() {
+		index := newDependentIndex()
+		index.update(resources.NewObjectName("ns", "entry-alias-1"), owner)
+		index.update(resources.NewObjectName("ns", "entry-mirror-1"), owner)
+		index.update(resources.NewObjectName("ns", "other-alias-1"), other)
+		Expect(index.get(owner)).To(Equal([]string{"entry-alias-1", "entry-mirror-1"}))
+		Expect(index.get(other)).To(Equal([]string{"other-alias-1"}))
+
+		// the marker annotation has been changed or removed
+		index.update(resources.NewObjectName("ns", "entry-alias-1"), other)
+		index.update(resources.NewObjectName("ns", "entry-mirror-1"), nil)
+		Expect(index.get(owner)).To(BeEmpty())
+		Expect(index.get(other)).To(Equal([]string{"entry-alias-1", "other-alias-1"}))
+
+		index.remove(resources.NewObjectName("ns", "entry-alias-1"))
+		index.remove(resources.NewObjectName("ns", "other-alias-1"))
+		Expect(index.get(other)).To(BeEmpty())
+		Expect(index.owners).To(BeEmpty())
+		Expect(index.entries).To(BeEmpty())
+	})
+
+	ginkgov2.It("derives distinct entry names per kind", func() {
+		Expect(aliasEntries.entryName("entry", "key")).To(HavePrefix("entry-alias-"))
+		Expect(splitEntries.entryName("entry", "key")).To(HavePrefix("entry-split-"))
+		Expect(mirrorEntries.entryName("entry", "key")).To(HavePrefix("entry-mirror-"))
+	})
+
+	ginkgov2.It("reads the reported states of the maintained entries", func() {
+		status := &api.DNSEntryStatus{
+			Aliases:        []api.DNSEntryAliasStatus{{DNSName: "b.example.com", Entry: "entry-alias-1", State: api.STATE_READY}},
+			SplitProviders: []api.DNSEntrySplitProviderStatus{{Entry: "entry"}, {Entry: "entry-split-1", State: api.STATE_ERROR, Message: ptr.To("failed")}},
+			Mirror:         &api.DNSEntryMirrorStatus{Entry: "entry-mirror-1", State: api.STATE_PENDING},
+		}
+		Expect(aliasEntries.reported(status)).To(Equal([]dependentState{{entry: "entry-alias-1", state: api.STATE_READY}}))
+		Expect(splitEntries.reported(status)).To(Equal([]dependentState{{entry: "entry"}, {entry: "entry-split-1", state: api.STATE_ERROR, message: ptr.To("failed")}}))
+		Expect(mirrorEntries.reported(status)).To(Equal([]dependentState{{entry: "entry-mirror-1", state: api.STATE_PENDING}}))
+		Expect(mirrorEntries.reported(&api.DNSEntryStatus{})).To(BeEmpty())
+	})
+})
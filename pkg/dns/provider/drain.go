@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"sync"
+	"time"
+)
+
+// drainer tracks in-flight reconciliations to finish them on shutdown of the controller.
+// Once draining has started, no new reconciliations with backend writes are started,
+// while reconciliations only updating the status of DNS entries are still allowed.
+type drainer struct {
+	lock     sync.Mutex
+	draining bool
+	inflight int
+	idle     chan struct{}
+}
+
+func newDrainer() *drainer {
+	return &drainer{}
+}
+
+// Begin registers an in-flight reconciliation. If backendWrites is set, the reconciliation
+// is refused while draining and false is returned.
+func (this *drainer) Begin(backendWrites bool) bool {
+	if this == nil {
+		return true
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if this.draining && backendWrites {
+		return false
+	}
+	this.inflight++
+	return true
+}
+
+// End unregisters an in-flight reconciliation registered with Begin.
+func (this *drainer) End() {
+	if this == nil {
+		return
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.inflight--
+	if this.inflight == 0 && this.idle != nil {
+		close(this.idle)
+		this.idle = nil
+	}
+}
+
+// Drain starts draining and waits until all in-flight reconciliations are finished or the timeout is reached.
+// It returns the number of reconciliations still in-flight.
+func (this *drainer) Drain(timeout time.Duration) int {
+	this.lock.Lock()
+	this.draining = true
+	if this.inflight == 0 {
+		this.lock.Unlock()
+		return 0
+	}
+	if this.idle == nil {
+		this.idle = make(chan struct{})
+	}
+	idle := this.idle
+	this.lock.Unlock()
+
+	select {
+	case <-idle:
+	case <-time.After(timeout):
+	}
+
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return this.inflight
+}
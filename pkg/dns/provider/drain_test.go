@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"time"
+
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = ginkgov2.Describe("Drainer", func() {
+	ginkgov2.It("returns immediately without in-flight reconciliations", func() {
+		d := newDrainer()
+		Expect(d.Drain(time.Minute)).To(Equal(0))
+	})
+
+	ginkgov2.It("waits for in-flight reconciliations", func() {
+		d := newDrainer()
+		Expect(d.Begin(true)).To(BeTrue())
+		Expect(d.Begin(false)).To(BeTrue())
+
+		result := make(chan int)
+		go func() { result <- d.Drain(time.Minute) }()
+
+		Eventually(func() bool {
+			d.lock.Lock()
+			defer d.lock.Unlock()
+			return d.draining
+		}).Should(BeTrue())
+		Expect(d.Begin(true)).To(BeFalse())
+		Expect(d.Begin(false)).To(BeTrue())
+		d.End()
+		d.End()
+		Consistently(result, 50*time.Millisecond).ShouldNot(Receive())
+		d.End()
+		Eventually(result).Should(Receive(Equal(0)))
+	})
+
+	ginkgov2.It("stops waiting after the timeout", func() {
+		d := newDrainer()
+		Expect(d.Begin(true)).To(BeTrue())
+		Expect(d.Drain(10 * time.Millisecond)).To(Equal(1))
+		Expect(d.Begin(true)).To(BeFalse())
+	})
+
+	ginkgov2.It("is a no-op if not set", func() {
+		var d *drainer
+		Expect(d.Begin(true)).To(BeTrue())
+		d.End()
+	})
+})
@@ -7,12 +7,14 @@ package provider
 import (
 	"context"
 	"fmt"
+	"maps"
 	"reflect"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/reconcile"
 	"github.com/gardener/controller-manager-library/pkg/logger"
@@ -21,6 +23,7 @@ import (
 	"github.com/gardener/controller-manager-library/pkg/utils"
 	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
 	"github.com/gardener/external-dns-management/pkg/dns"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
 	"github.com/gardener/external-dns-management/pkg/dns/provider/statistic"
 	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
 	"k8s.io/utils/ptr"
@@ -31,9 +34,29 @@ import (
 
 const MSG_PRESERVED = "errorneous entry preserved in provider"
 
-// maxCNAMETargets is the maximum number of CNAME targets. It is restricted, as it needs regular DNS lookups.
+// MSG_PRESERVED_ON_DELETE is the status message of a deleted entry whose records are preserved in the provider.
+const MSG_PRESERVED_ON_DELETE = "records preserved in provider on deletion"
+
+// maxCNAMETargets is the default and upper bound for the maximum number of CNAME targets.
+// It is restricted, as it needs regular DNS lookups.
 const maxCNAMETargets = 25
 
+const (
+	// defaultCNameLookupInterval is the default lookup interval for CNAME targets to be resolved to addresses.
+	defaultCNameLookupInterval = 600 * time.Second
+	// defaultCNameLookupMinInterval is the default minimum of explicitly specified lookup intervals.
+	defaultCNameLookupMinInterval = 30 * time.Second
+	// defaultCNameLookupTTLDivisor is the default divisor of the TTL of the resolved targets
+	// used as lower bound of explicitly specified lookup intervals.
+	defaultCNameLookupTTLDivisor = 3
+	// cnameLookupIntervalFloor is the smallest allowed minimum lookup interval to protect the upstream resolvers.
+	cnameLookupIntervalFloor = 5 * time.Second
+	// defaultEntryReconcileMinInterval is the default minimum of reconcile intervals given by annotation.
+	defaultEntryReconcileMinInterval = 30 * time.Second
+	// entryReconcileIntervalFloor is the smallest allowed minimum reconcile interval to protect the DNS backends.
+	entryReconcileIntervalFloor = 5 * time.Second
+)
+
 type EntryPremise struct {
 	ptype    string
 	provider DNSProvider
@@ -74,27 +97,39 @@ type EntryVersion struct {
 	dnsSetName    dns.DNSSetName
 	targets       Targets
 	routingPolicy *dns.RoutingPolicy
+	comment       string
+	attributes    map[string]string
 	mappings      map[string][]string
 	warnings      []string
+	// defaultedTTL is the provider default TTL used for an explicit TTL 0 (not defaulted if 0).
+	defaultedTTL int64
 
-	status api.DNSEntryStatus
+	status         api.DNSEntryStatus
+	statusThrottle *statusThrottle
+	eventThrottle  *eventThrottle
 
 	interval    int64
 	responsible bool
 	valid       bool
 	duplicate   bool
 	obsolete    bool
+
+	// reconcileInterval is the interval of periodic reconciliations given by annotation (disabled if 0).
+	reconcileInterval time.Duration
+	// annotationErrors contains the reported errors of invalid annotations by annotation name.
+	annotationErrors map[string]string
 }
 
 func NewEntryVersion(object *dnsutils.DNSEntryObject, old *Entry) *EntryVersion {
 	v := &EntryVersion{
 		object:     object,
-		dnsSetName: dns.DNSSetName{DNSName: object.GetDNSName(), SetIdentifier: object.GetSetIdentifier()},
+		dnsSetName: dns.DNSSetName{DNSName: object.GetDNSName(), SetIdentifier: object.GetSetIdentifier()}.Normalize(),
 		targets:    Targets{},
 		mappings:   map[string][]string{},
 	}
 	if old != nil {
 		v.status = old.status
+		v.annotationErrors = old.annotationErrors
 	} else {
 		v.status = *object.Status()
 	}
@@ -127,6 +162,12 @@ func (this *EntryVersion) RequiresUpdateFor(e *EntryVersion) (reasons []string)
 	if !reflect.DeepEqual(this.routingPolicy, e.routingPolicy) {
 		reasons = append(reasons, "routing policy changed")
 	}
+	if this.comment != e.comment {
+		reasons = append(reasons, "comment changed")
+	}
+	if !maps.Equal(this.attributes, e.attributes) {
+		reasons = append(reasons, "provider attributes changed")
+	}
 	if this.State() != e.State() {
 		if e.State() != api.STATE_READY {
 			reasons = append(reasons, "state changed")
@@ -154,6 +195,17 @@ func (this *EntryVersion) Object() *dnsutils.DNSEntryObject {
 	return this.object
 }
 
+// event emits an event for the entry unless an identical event has been emitted recently.
+func (this *EntryVersion) event(eventtype, reason, message string) {
+	if this.eventThrottle.Allow(this.object.ObjectName(), eventtype, reason, message) {
+		this.object.Event(eventtype, reason, message)
+	}
+}
+
+func (this *EntryVersion) eventf(eventtype, reason, messageFmt string, args ...interface{}) {
+	this.event(eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
 func (this *EntryVersion) Message() string {
 	return utils.StringValue(this.status.Message)
 }
@@ -202,6 +254,14 @@ func (this *EntryVersion) RoutingPolicy() *dns.RoutingPolicy {
 	return this.routingPolicy
 }
 
+func (this *EntryVersion) Comment() string {
+	return this.comment
+}
+
+func (this *EntryVersion) ProviderAttributes() map[string]string {
+	return this.attributes
+}
+
 func (this *EntryVersion) Description() string {
 	return this.object.Description()
 }
@@ -235,6 +295,9 @@ func (this *EntryVersion) OwnerId() string {
 
 func complete(logger logger.LogContext, state *state, entry *dnsutils.DNSEntryObject, prefix string) (*api.DNSEntrySpec, error) {
 	if ref := entry.GetReference(); ref != nil && ref.Name != "" {
+		if entry.GetTargetRef() != nil {
+			return nil, fmt.Errorf("%starget reference specified together with entry reference", prefix)
+		}
 		newSpec := entry.Spec().DeepCopy()
 		ns := ref.Namespace
 		if ns == "" {
@@ -271,8 +334,13 @@ func complete(logger logger.LogContext, state *state, entry *dnsutils.DNSEntryOb
 			err = fmt.Errorf("%stext specified together with entry reference", prefix)
 			return nil, err
 		}
+		if entry.GetRecords() != nil {
+			return nil, fmt.Errorf("%srecords specified together with entry reference", prefix)
+		}
 		newSpec.Targets = rspec.Targets
 		newSpec.Text = rspec.Text
+		newSpec.RecordType = rspec.RecordType
+		newSpec.Records = rspec.Records
 
 		if entry.GetTTL() == nil {
 			newSpec.TTL = rspec.TTL
@@ -284,6 +352,8 @@ func complete(logger logger.LogContext, state *state, entry *dnsutils.DNSEntryOb
 			newSpec.CNameLookupInterval = rspec.CNameLookupInterval
 		}
 		return newSpec, nil
+	} else if ref := entry.GetTargetRef(); ref != nil {
+		return completeByTargetRef(logger, state, entry, ref, prefix)
 	} else {
 		state.references.DelRef(entry.ClusterKey())
 	}
@@ -306,23 +376,44 @@ func validate(logger logger.LogContext, state *state, entry *EntryVersion, p *En
 		return
 	}
 
-	if p.zonedomain == entry.dnsSetName.DNSName {
-		for _, t := range []string{"azure-dns", "azure-private-dns"} {
-			if p.provider != nil && p.provider.TypeCode() == t {
-				err = fmt.Errorf("usage of dns name (%s) identical to domain of hosted zone (%s) is not supported. Please use apex prefix '@.'",
-					p.zonedomain, p.zoneid)
-				return
-			}
-		}
-	}
 	if len(effspec.Targets) > 0 && len(effspec.Text) > 0 {
 		err = fmt.Errorf("only Text or Targets possible")
 		return
 	}
-	if ttl := effspec.TTL; ttl != nil && (*ttl == 0 || *ttl < 0) {
-		err = fmt.Errorf("TTL must be greater than zero")
+	if len(effspec.Records) > 0 && (len(effspec.Targets) > 0 || len(effspec.Text) > 0) {
+		err = fmt.Errorf("only Text, Targets or Records possible")
+		return
+	}
+	if ttl := effspec.TTL; ttl != nil && *ttl < 0 {
+		err = fmt.Errorf("TTL must not be negative")
+		return
+	}
+	if _, _, err = dnsutils.ToDNSRoutingPolicy(effspec.RoutingPolicy).PercentageWeight(); err != nil {
+		err = fmt.Errorf("invalid value for spec.routingPolicy.parameters.weight: %w", err)
+		return
+	}
+	if _, _, err = dnsutils.ToDNSRoutingPolicy(effspec.RoutingPolicy).FailoverRecordType(); err != nil {
+		err = fmt.Errorf("invalid routing policy: %w", err)
 		return
 	}
+	if err = dnsutils.ToDNSRoutingPolicy(effspec.RoutingPolicy).ValidateHealthCheckID(); err != nil {
+		err = fmt.Errorf("invalid routing policy: %w", err)
+		return
+	}
+	if err = validatePreserveOnDelete(entry.object.Spec()); err != nil {
+		return
+	}
+	if err = validateSplitProviders(entry.object.GetNamespace(), entry.object.Spec()); err != nil {
+		return
+	}
+	if p.provider != nil {
+		if err = validateComment(p.provider.TypeCode(), effspec.Comment); err != nil {
+			return
+		}
+		if err = validateAliases(p.provider, entry.object.GetDNSName(), entry.object.Spec().DNSNames, !state.config.DisableDNSNameValidation); err != nil {
+			return
+		}
+	}
 
 	for i, t := range effspec.Targets {
 		if strings.TrimSpace(t) == "" {
@@ -358,13 +449,350 @@ func validate(logger logger.LogContext, state *state, entry *EntryVersion, p *En
 		err = fmt.Errorf("dns entry has only empty text")
 		return
 	}
+	if len(effspec.Records) > 0 || effspec.RecordType != "" {
+		var records Targets
+		records, warnings, err = validateRecords(entry, effspec, warnings)
+		if err != nil {
+			return
+		}
+		if err = validateRecordType(p, effspec.RecordType); err != nil {
+			return
+		}
+		if effspec.RecordType == dns.RS_NS {
+			if err = validateNSDelegation(p, entry.dnsSetName.DNSName); err != nil {
+				return
+			}
+		}
+		if effspec.RecordType == dns.RS_PTR {
+			if _, err = dns.ParseReverseName(entry.dnsSetName.DNSName); err != nil {
+				return
+			}
+		}
+		if effspec.RecordType == dns.RS_DS {
+			if err = validateDSDelegation(p, entry.dnsSetName.DNSName); err != nil {
+				return
+			}
+		}
+		targets = append(targets, records...)
+	}
 
 	if len(targets) == 0 {
-		err = fmt.Errorf("no target or text specified")
+		err = fmt.Errorf("no target, text or records specified")
+		return
+	}
+	if err = validateApex(p, entry.dnsSetName.DNSName, targets, effspec.ResolveTargetsExclusions); err != nil {
+		return
+	}
+	if err = validateTargetCount(&state.config, targets); err != nil {
+		return
 	}
+	if err = validateTextLengths(p.provider, targets); err != nil {
+		return
+	}
+	err = validateResolveTargetsExclusions(effspec, targets)
 	return
 }
 
+// validateApex checks a DNS name at the zone apex. Some providers require the apex prefix (see Capabilities),
+// and a CNAME target excluded from resolution is only allowed if the provider supports it natively.
+func validateApex(p *EntryPremise, dnsName string, targets Targets, exclusions []string) error {
+	if !isZoneApex(p, dnsName) {
+		return nil
+	}
+	if p.provider != nil && p.provider.Capabilities().ApexPrefix && p.zonedomain == dnsName {
+		return fmt.Errorf("usage of dns name (%s) identical to domain of hosted zone (%s) is not supported. Please use apex prefix '@.'",
+			p.zonedomain, p.zoneid)
+	}
+	if apexAliasStrategy(p, dnsName, targets) == api.APEX_ALIAS_RESOLVED && isExcludedFromResolution(targets[0].GetHostName(), exclusions) {
+		return fmt.Errorf("CNAME target %s at the apex of the hosted zone %s is excluded from resolution, but not supported natively by the provider",
+			targets[0].GetHostName(), p.zonedomain)
+	}
+	return nil
+}
+
+// apexAliasStrategy returns the strategy for a single CNAME target of a DNS name at the zone apex, where CNAME records
+// are not allowed. The native alias of the provider is used if available, otherwise the target is resolved to addresses.
+// It returns an empty string if no apex alias is needed.
+func apexAliasStrategy(p *EntryPremise, dnsName string, targets Targets) string {
+	if p.provider == nil || !isZoneApex(p, dnsName) || len(targets) != 1 || targets[0].GetRecordType() != dns.RS_CNAME {
+		return ""
+	}
+	if p.provider.SupportsApexAlias(targets[0]) {
+		return api.APEX_ALIAS_NATIVE
+	}
+	return api.APEX_ALIAS_RESOLVED
+}
+
+// isZoneApex returns true if the DNS name is the domain of the hosted zone, optionally given with the apex prefix '@.'.
+func isZoneApex(p *EntryPremise, dnsName string) bool {
+	name := dns.NormalizeHostname(dnsName)
+	return p.zonedomain != "" && (name == p.zonedomain || name == "@."+p.zonedomain)
+}
+
+// validateRecordType checks that the record type is supported by the provider,
+// as the optional record types are only supported by some DNS backends (see OptionalRecordTypes).
+func validateRecordType(p *EntryPremise, rtype string) error {
+	if p.provider != nil && !p.provider.Capabilities().SupportsRecordType(rtype) {
+		return fmt.Errorf("%s records are not supported for provider type %s", rtype, p.provider.TypeCode())
+	}
+	return nil
+}
+
+// validateNSDelegation checks that NS records are not created at the zone apex,
+// as they would conflict with the name servers of the hosted zone itself.
+func validateNSDelegation(p *EntryPremise, dnsName string) error {
+	if isZoneApex(p, dnsName) {
+		return fmt.Errorf("NS records are not allowed at the apex of the hosted zone %s", p.zonedomain)
+	}
+	return nil
+}
+
+// validateDSDelegation checks that DS records are not created at the zone apex,
+// as they belong to the delegation of a signed child zone in its parent zone.
+func validateDSDelegation(p *EntryPremise, dnsName string) error {
+	if isZoneApex(p, dnsName) {
+		return fmt.Errorf("DS records are not allowed at the apex of the hosted zone %s", p.zonedomain)
+	}
+	return nil
+}
+
+// unsupportedProviderAttributes returns the sorted names of the attributes not contained in the supported attribute names.
+func unsupportedProviderAttributes(supported utils.StringSet, attrs map[string]string) []string {
+	if supported.Contains(AnyProviderAttribute) {
+		return nil
+	}
+	var unsupported []string
+	for name := range attrs {
+		if !supported.Contains(name) {
+			unsupported = append(unsupported, name)
+		}
+	}
+	sort.Strings(unsupported)
+	return unsupported
+}
+
+// validateAliases checks that the additional DNS names are valid, unique, and served by the provider.
+func validateAliases(provider DNSProvider, dnsName string, aliases []string, validateNames bool) error {
+	names := utils.NewStringSet(normalizeAlias(dnsName))
+	for _, alias := range aliases {
+		if validateNames {
+			if err := dns.ValidateDomainName(alias); err != nil {
+				return fmt.Errorf("invalid alias %q: %w", alias, err)
+			}
+		}
+		name := normalizeAlias(alias)
+		if names.Contains(name) {
+			return fmt.Errorf("duplicate DNS name %q in dnsNames", alias)
+		}
+		names.Add(name)
+		if provider.Match(name) <= 0 {
+			return fmt.Errorf("alias %q is not served by provider %s", alias, provider.ObjectName())
+		}
+	}
+	return nil
+}
+
+// maxCommentLengths contains the maximum comment length for provider types with known limits.
+var maxCommentLengths = map[string]int{
+	"cloudflare-dns": 100,
+	"infoblox-dns":   256,
+}
+
+// validatePreserveOnDelete checks that records to be preserved on deletion are not maintained by alias entries,
+// which are deleted together with their records.
+func validatePreserveOnDelete(spec *api.DNSEntrySpec) error {
+	if ptr.Deref(spec.PreserveOnDelete, false) && len(spec.DNSNames) > 0 {
+		return fmt.Errorf("preserveOnDelete cannot be combined with dnsNames")
+	}
+	return nil
+}
+
+// validateComment checks the length of the comment in characters against the limit of the provider type.
+func validateComment(providerType string, comment *string) error {
+	if comment == nil {
+		return nil
+	}
+	if max, ok := maxCommentLengths[providerType]; ok {
+		if n := utf8.RuneCountInString(*comment); n > max {
+			return fmt.Errorf("comment too long: %d characters (maximum allowed for provider type %s: %d)", n, providerType, max)
+		}
+	}
+	return nil
+}
+
+// validateTargetCount checks the number of address and CNAME targets against the configured limits.
+func validateTargetCount(config *Config, targets Targets) error {
+	count, cnames := 0, 0
+	for _, t := range targets {
+		switch t.GetRecordType() {
+		case dns.RS_A, dns.RS_AAAA:
+			count++
+		case dns.RS_CNAME:
+			count++
+			cnames++
+		}
+	}
+	if config.MaxTargets > 0 && count > config.MaxTargets {
+		return fmt.Errorf("too many targets: %d (maximum allowed: %d)", count, config.MaxTargets)
+	}
+	if max := effectiveMaxCNAMETargets(config); cnames > max {
+		return fmt.Errorf("too many CNAME targets: %d (maximum allowed: %d)", cnames, max)
+	}
+	return nil
+}
+
+// effectiveMaxCNAMETargets returns the configured maximum number of CNAME targets or the default if not configured.
+func effectiveMaxCNAMETargets(config *Config) int {
+	if config.MaxCNAMETargets <= 0 || config.MaxCNAMETargets > maxCNAMETargets {
+		return maxCNAMETargets
+	}
+	return config.MaxCNAMETargets
+}
+
+func validateResolveTargetsExclusions(spec *api.DNSEntrySpec, targets Targets) error {
+	if len(spec.ResolveTargetsExclusions) == 0 {
+		return nil
+	}
+	for _, exclusion := range spec.ResolveTargetsExclusions {
+		if err := dns.ValidateDomainName(exclusion); err != nil {
+			return fmt.Errorf("invalid resolve targets exclusion %q: %w", exclusion, err)
+		}
+	}
+	if len(targets) < 2 {
+		return nil
+	}
+	for _, t := range targets {
+		if t.GetRecordType() == dns.RS_CNAME && isExcludedFromResolution(t.GetHostName(), spec.ResolveTargetsExclusions) {
+			return fmt.Errorf("target %q is excluded from resolution and cannot be combined with other targets (a CNAME record must be the only record)", t.GetHostName())
+		}
+	}
+	return nil
+}
+
+// isExcludedFromResolution checks if the hostname matches one of the exclusions.
+// An exclusion `*.<domain>` matches all subdomains of the domain, other exclusions match the domain and its subdomains.
+func isExcludedFromResolution(hostname string, exclusions []string) bool {
+	hostname = strings.TrimSuffix(strings.ToLower(hostname), ".")
+	for _, exclusion := range exclusions {
+		exclusion = strings.TrimSuffix(strings.ToLower(exclusion), ".")
+		if domain, ok := strings.CutPrefix(exclusion, "*."); ok {
+			if strings.HasSuffix(hostname, "."+domain) {
+				return true
+			}
+		} else if dnsutils.Match(hostname, exclusion) {
+			return true
+		}
+	}
+	return false
+}
+
+func validateRecordTypes(provider DNSProvider, targets Targets) error {
+	if provider == nil {
+		return nil
+	}
+	for _, t := range targets {
+		if !provider.AcceptsRecordType(t.GetRecordType()) {
+			return fmt.Errorf("record type %s is not managed by provider %s (excluded by record type selection)",
+				t.GetRecordType(), provider.ObjectName())
+		}
+	}
+	return nil
+}
+
+// validateTextLengths checks the lengths of the TXT record values against the maximum supported by the provider.
+func validateTextLengths(provider DNSProvider, targets Targets) error {
+	if provider == nil {
+		return nil
+	}
+	maxLength := provider.TXTRecordLimits().MaxLength
+	if maxLength <= 0 {
+		return nil
+	}
+	for _, t := range targets {
+		if t.GetRecordType() != dns.RS_TXT {
+			continue
+		}
+		if l := dns.TXTTextLength(t.GetHostName()); l > maxLength {
+			return fmt.Errorf("text with length %d exceeds maximum length %d supported by provider type %s", l, maxLength, provider.TypeCode())
+		}
+	}
+	return nil
+}
+
+func validateRecords(entry *EntryVersion, spec *api.DNSEntrySpec, warnings []string) (Targets, []string, error) {
+	var normalize func(value string) (string, error)
+	switch spec.RecordType {
+	case dns.RS_SRV:
+		normalize = func(value string) (string, error) {
+			srv, err := dns.ParseSRVRecord(value)
+			if err != nil {
+				return "", err
+			}
+			return srv.String(), nil
+		}
+	case dns.RS_CAA:
+		normalize = func(value string) (string, error) {
+			caa, err := dns.ParseCAARecord(value)
+			if err != nil {
+				return "", err
+			}
+			return caa.String(), nil
+		}
+	case dns.RS_MX:
+		normalize = func(value string) (string, error) {
+			mx, err := dns.ParseMXRecord(value)
+			if err != nil {
+				return "", err
+			}
+			return mx.String(), nil
+		}
+	case dns.RS_DS:
+		normalize = func(value string) (string, error) {
+			ds, err := dns.ParseDSRecord(value)
+			if err != nil {
+				return "", err
+			}
+			return ds.String(), nil
+		}
+	case dns.RS_NS:
+		normalize = func(value string) (string, error) {
+			if err := dns.ValidateDomainName(value); err != nil {
+				return "", fmt.Errorf("invalid name server %q: %w", value, err)
+			}
+			return dns.NormalizeHostname(value), nil
+		}
+	case dns.RS_PTR:
+		normalize = func(value string) (string, error) {
+			if strings.HasPrefix(value, "*.") || dns.ValidateDomainName(value) != nil {
+				return "", fmt.Errorf("invalid PTR target %q: must be a hostname", value)
+			}
+			return dns.NormalizeHostname(value), nil
+		}
+	case "":
+		return nil, warnings, fmt.Errorf("recordType must be specified for records")
+	default:
+		return nil, warnings, fmt.Errorf("unsupported recordType %q for records", spec.RecordType)
+	}
+
+	targets := Targets{}
+	for i, r := range spec.Records {
+		value, err := normalize(r)
+		if err != nil {
+			return nil, warnings, fmt.Errorf("record %d: %w", i+1, err)
+		}
+		new := dnsutils.NewTarget(spec.RecordType, value, entry.TTL())
+		if targets.Has(new) {
+			warnings = append(warnings, fmt.Sprintf("dns entry %q has duplicate record %q", entry.ObjectName(), r))
+		} else {
+			targets = append(targets, new)
+		}
+	}
+	if len(targets) == 0 {
+		return nil, warnings, fmt.Errorf("no records specified for recordType %q", spec.RecordType)
+	}
+	return targets, warnings, nil
+}
+
 func validateOwner(_ logger.LogContext, state *state, entry *EntryVersion) error {
 	effspec := entry.object
 
@@ -372,10 +800,99 @@ func validateOwner(_ logger.LogContext, state *state, entry *EntryVersion) error
 		if !state.ownerCache.IsResponsibleFor(ownerid) && !state.ownerCache.IsResponsiblePendingFor(ownerid) {
 			return fmt.Errorf("unknown owner id '%s'", ownerid)
 		}
+		if state.ownerCache.IsResponsibleFor(ownerid) && !state.ownerCache.IsResponsibleForNamespace(ownerid, entry.ObjectName().Namespace()) {
+			return fmt.Errorf("owner id '%s' not valid for namespace '%s'", ownerid, entry.ObjectName().Namespace())
+		}
 	}
 	return nil
 }
 
+// cnameLookupInterval returns the lookup interval in seconds for CNAME targets to be resolved to addresses.
+// An explicitly specified interval is raised to the minimum interval and to the TTL of the resolved targets
+// divided by the TTL divisor.
+func cnameLookupInterval(config *Config, specInterval *int64, targets Targets) int64 {
+	interval := int64(durationOrDefault(config.CNameLookupInterval, defaultCNameLookupInterval).Seconds())
+	if specInterval != nil && *specInterval > 0 {
+		interval = *specInterval
+		if minInterval := int64(durationOrDefault(config.CNameLookupMinInterval, defaultCNameLookupMinInterval).Seconds()); interval < minInterval {
+			interval = minInterval
+		}
+		divisor := int64(config.CNameLookupTTLDivisor)
+		if divisor <= 0 {
+			divisor = defaultCNameLookupTTLDivisor
+		}
+		if len(targets) > 0 && interval < targets[0].GetTTL()/divisor {
+			interval = targets[0].GetTTL() / divisor
+		}
+	}
+	return interval
+}
+
+func durationOrDefault(d, def time.Duration) time.Duration {
+	if d <= 0 {
+		return def
+	}
+	return d
+}
+
+// effectiveTTL returns the TTL given in the spec or the default TTL of the provider if it is unset or 0.
+// The returned flag reports whether an explicit TTL 0 has been replaced by the default TTL.
+func effectiveTTL(ttl *int64, defaultTTL int64) (int64, bool) {
+	if ttl == nil {
+		return defaultTTL, false
+	}
+	if *ttl == 0 {
+		return defaultTTL, true
+	}
+	return *ttl, false
+}
+
+// clampTTL restricts the TTL to the range given by the options min-ttl and max-ttl.
+func clampTTL(config *Config, ttl int64) int64 {
+	if config.MinTTL > 0 && ttl < config.MinTTL {
+		return config.MinTTL
+	}
+	if config.MaxTTL > 0 && ttl > config.MaxTTL {
+		return config.MaxTTL
+	}
+	return ttl
+}
+
+// withProviderMinTTL returns the config with the minimum TTL raised to the minimum TTL accepted by the provider.
+func withProviderMinTTL(config Config, minTTL int64) Config {
+	if minTTL > config.MinTTL {
+		config.MinTTL = minTTL
+	}
+	return config
+}
+
+// clampTTL returns the clamped TTL. A warning event is emitted if the TTL has been clamped
+// and the TTL in the status is not up to date yet.
+func (this *EntryVersion) clampTTL(logger logger.LogContext, config *Config, ttl int64) *int64 {
+	clamped := clampTTL(config, ttl)
+	if clamped != ttl && !utils.Int64Equal(this.object.Status().TTL, &clamped) {
+		msg := fmt.Sprintf("TTL %d is out of the allowed range and has been clamped to %d", ttl, clamped)
+		logger.Warn(msg)
+		this.event(corev1.EventTypeWarning, EventReasonTTLClamped, msg)
+	}
+	return &clamped
+}
+
+// validationFailed emits a ValidationFailed event if the error is not reported in the status of the entry yet.
+func (this *EntryVersion) validationFailed(verr error) {
+	if utils.StringValue(this.object.Status().Message) != verr.Error() {
+		this.event(corev1.EventTypeWarning, EventReasonValidationFailed, verr.Error())
+	}
+}
+
+// activeMessage returns the status message of a ready entry.
+func (this *EntryVersion) activeMessage() string {
+	if this.defaultedTTL > 0 {
+		return fmt.Sprintf("dns entry active (TTL 0 defaulted to provider default TTL %d)", this.defaultedTTL)
+	}
+	return "dns entry active"
+}
+
 func (this *EntryVersion) Setup(logger logger.LogContext, state *state, p *EntryPremise, op string, err error, config Config) reconcile.Status {
 	hello := dnsutils.NewLogMessage("%s ENTRY: %s, zoneid: %s, handler: %s, provider: %s, ref %+v", op, this.Object().Status().State, p.zoneid, p.ptype, Provider(p.provider), this.Object().GetReference())
 
@@ -446,15 +963,20 @@ func (this *EntryVersion) Setup(logger logger.LogContext, state *state, p *Entry
 	this.status.Zone = &p.zoneid
 	this.status.ProviderType = &p.ptype
 	this.responsible = true
+	this.defaultedTTL = 0
 	if p.provider != nil {
+		config = withProviderMinTTL(config, p.provider.MinTTL())
 		this.providername = p.provider.ObjectName()
 		provider = p.provider.ObjectName().String()
+		if utils.StringValue(this.object.Status().Provider) != provider {
+			this.eventf(corev1.EventTypeNormal, EventReasonProviderAssigned, "assigned to provider %s for zone %s", provider, p.zoneid)
+		}
 		this.status.Provider = &provider
-		defaultTTL := p.provider.DefaultTTL()
-		this.status.TTL = &defaultTTL
-		if spec.TTL != nil {
-			this.status.TTL = spec.TTL
+		ttl, defaulted := effectiveTTL(spec.TTL, p.provider.DefaultTTL())
+		if defaulted {
+			this.defaultedTTL = ttl
 		}
+		this.status.TTL = this.clampTTL(logger, &config, ttl)
 	} else {
 		this.providername = nil
 		this.status.Provider = nil
@@ -466,24 +988,70 @@ func (this *EntryVersion) Setup(logger logger.LogContext, state *state, p *Entry
 	if verr := validateOwner(logger, state, this); verr != nil {
 		hello.Infof(logger, "owner validation failed: %s", verr)
 
+		this.validationFailed(verr)
 		_, _ = this.UpdateStatus(logger, api.STATE_STALE, verr.Error())
 		return reconcile.Failed(logger, verr)
 	}
 
 	spec, targets, warnings, verr := validate(logger, state, this, p)
+	if perrs.IsTargetRefPending(verr) {
+		hello.Infof(logger, "target reference pending: %s", verr)
+
+		newState := api.STATE_PENDING
+		// keep existing records if the entry was already served
+		if this.status.State == api.STATE_READY || this.status.State == api.STATE_STALE {
+			newState = api.STATE_STALE
+		}
+		_, _ = this.UpdateStatus(logger, newState, verr.Error())
+		// the entry is triggered again if the referenced object changes
+		return reconcile.Succeeded(logger)
+	}
 	if verr != nil {
 		hello.Infof(logger, "validation failed: %s", verr)
 
+		this.validationFailed(verr)
 		_, _ = this.UpdateStatus(logger, api.STATE_INVALID, verr.Error())
 		return reconcile.Failed(logger, verr)
 	}
 
+	if verr := validateRecordTypes(p.provider, targets); verr != nil {
+		hello.Infof(logger, "record type validation failed: %s", verr)
+		this.validationFailed(verr)
+
+		newState := api.STATE_ERROR
+		// keep existing records if the entry was already served
+		if this.status.State == api.STATE_READY || this.status.State == api.STATE_STALE {
+			newState = api.STATE_STALE
+		}
+		_, _ = this.UpdateStatus(logger, newState, verr.Error())
+		return reconcile.Failed(logger, verr)
+	}
+
 	///////////// handle
 
 	hello.Infof(logger, "validation ok")
 
-	if p.provider != nil && spec.TTL != nil {
-		this.status.TTL = spec.TTL
+	if _, err := this.RateLimitPriority(); this.annotationErrorChanged(dns.AnnotationRateLimitPriority, err) {
+		logger.Warn(err)
+		this.event(corev1.EventTypeWarning, EventReasonInvalidAnnotation, err.Error())
+	}
+	reconcileInterval, ierr := this.ReconcileInterval(config.EntryReconcileMinInterval)
+	if this.annotationErrorChanged(dns.AnnotationReconcileInterval, ierr) {
+		logger.Warn(ierr)
+		this.event(corev1.EventTypeWarning, EventReasonInvalidAnnotation, ierr.Error())
+	}
+	this.reconcileInterval = reconcileInterval
+
+	if p.provider != nil {
+		supported, _ := state.GetHandlerFactory().ProviderAttributes(p.provider.TypeCode())
+		var aerr error
+		if unsupported := unsupportedProviderAttributes(supported, this.object.GetProviderAttributes()); len(unsupported) > 0 {
+			aerr = fmt.Errorf("provider attributes not supported by provider type %s are ignored: %s", p.provider.TypeCode(), strings.Join(unsupported, ", "))
+		}
+		if this.annotationErrorChanged(dns.AnnotationProviderAttributePrefix, aerr) {
+			logger.Warn(aerr)
+			this.event(corev1.EventTypeWarning, EventReasonIgnoredAttributes, aerr.Error())
+		}
 	}
 
 	if this.IsDeleting() {
@@ -494,18 +1062,15 @@ func (this *EntryVersion) Setup(logger logger.LogContext, state *state, p *Entry
 		state.DeleteLookupJob(this.object.ObjectName())
 	} else {
 		this.warnings = warnings
-		targets, lookupResults, multiCName := normalizeTargets(logger, this.object, targets...)
+		apexAlias := apexAliasStrategy(p, this.dnsSetName.DNSName, targets)
+		this.status.ApexAlias = nil
+		if apexAlias != "" {
+			this.status.ApexAlias = &apexAlias
+		}
+		resolve := ptr.Deref(this.object.ResolveTargetsToAddresses(), false) || apexAlias == api.APEX_ALIAS_RESOLVED
+		targets, lookupResults, multiCName := this.normalizeTargets(logger, state.lookupProcessor, effectiveMaxCNAMETargets(&state.config), resolve, targets...)
 		if multiCName {
-			this.interval = int64(600)
-			if iv := spec.CNameLookupInterval; iv != nil && *iv > 0 {
-				this.interval = *iv
-				if this.interval < 30 {
-					this.interval = 30
-				}
-				if len(targets) > 0 && this.interval < targets[0].GetTTL()/3 {
-					this.interval = targets[0].GetTTL() / 3
-				}
-			}
+			this.interval = cnameLookupInterval(&config, spec.CNameLookupInterval, targets)
 			if lookupResults != nil {
 				state.UpsertLookupJob(this.object.ObjectName(), *lookupResults, time.Duration(this.interval)*time.Second)
 			} else {
@@ -538,6 +1103,8 @@ func (this *EntryVersion) Setup(logger logger.LogContext, state *state, p *Entry
 
 		this.targets = targets
 		this.routingPolicy = dnsutils.ToDNSRoutingPolicy(spec.RoutingPolicy)
+		this.comment = utils.StringValue(spec.Comment)
+		this.attributes = this.object.GetProviderAttributes()
 		if err != nil {
 			if this.status.State != api.STATE_STALE {
 				if this.status.State == api.STATE_READY && (p.provider != nil && !p.provider.IsValid()) {
@@ -571,16 +1138,22 @@ func (this *EntryVersion) Setup(logger logger.LogContext, state *state, p *Entry
 		if this.status.State == api.STATE_READY && this.object.Status() != nil && this.object.GetGeneration() != this.object.Status().ObservedGeneration {
 			this.status.State = api.STATE_PENDING
 		}
+		if this.valid && this.status.State == api.STATE_READY {
+			// the TTL defaulting may change without a change of the records
+			this.status.Message = StatusMessage(this.activeMessage())
+		}
 	}
 
 	logger.Infof("%s: valid: %t, message: %s%s", this.status.State, this.valid, utils.StringValue(this.status.Message), errorValue(", err: %s", err))
 	logmsg := dnsutils.NewLogMessage("update entry status")
+	var transition *stateTransition
 	f := func(data resources.ObjectData) (bool, error) {
 		obj, err := this.object.GetResource().Wrap(data)
 		if err != nil {
 			return false, err
 		}
 		status := dnsutils.DNSEntry(obj).Status()
+		transition = newStateTransition(obj.ObjectName(), status)
 		mod := &utils.ModificationState{}
 		if p.zoneid != "" {
 			mod.AssureStringPtrValue(&status.ProviderType, p.ptype)
@@ -588,7 +1161,8 @@ func (this *EntryVersion) Setup(logger logger.LogContext, state *state, p *Entry
 		mod.AssureStringValue(&status.State, this.status.State).
 			AssureStringPtrPtr(&status.Message, this.status.Message).
 			AssureStringPtrPtr(&status.Zone, this.status.Zone).
-			AssureStringPtrPtr(&status.Provider, this.status.Provider)
+			AssureStringPtrPtr(&status.Provider, this.status.Provider).
+			AssureStringPtrPtr(&status.ApexAlias, this.status.ApexAlias)
 		if mod.IsModified() {
 			dnsutils.SetLastUpdateTime(&status.LastUptimeTime)
 			logmsg.Infof(logger)
@@ -596,8 +1170,13 @@ func (this *EntryVersion) Setup(logger logger.LogContext, state *state, p *Entry
 		mod.Modify(dnsutils.DNSEntry(obj).AcknowledgeCNAMELookupInterval(this.interval))
 		return mod.IsModified(), nil
 	}
-	_, err = this.object.ModifyStatus(f)
+	if _, err = this.object.ModifyStatus(f); err == nil {
+		transition.log()
+	}
 
+	if err == nil && this.reconcileInterval > 0 && !this.IsDeleting() {
+		return reconcile.Succeeded(logger).RescheduleAfter(this.reconcileInterval)
+	}
 	return reconcile.DelayOnError(logger, err)
 }
 
@@ -610,8 +1189,78 @@ func (this *EntryVersion) NotRateLimited() bool {
 	return ok
 }
 
+// RateLimitPriority returns the priority for the provider rate limiter given by annotation dns.gardener.cloud/rate-limit-priority.
+// For unknown values, an error and the normal priority is returned.
+func (this *EntryVersion) RateLimitPriority() (string, error) {
+	return rateLimitPriority(this.object.GetAnnotations())
+}
+
+func rateLimitPriority(annotations map[string]string) (string, error) {
+	value, ok := annotations[dns.AnnotationRateLimitPriority]
+	if !ok {
+		return dns.AnnotationValueRateLimitPriorityNormal, nil
+	}
+	switch priority := strings.ToLower(strings.TrimSpace(value)); priority {
+	case dns.AnnotationValueRateLimitPriorityNormal, dns.AnnotationValueRateLimitPriorityHigh:
+		return priority, nil
+	}
+	return dns.AnnotationValueRateLimitPriorityNormal, fmt.Errorf("invalid value %q for annotation %s (allowed values: %s, %s)",
+		value, dns.AnnotationRateLimitPriority, dns.AnnotationValueRateLimitPriorityNormal, dns.AnnotationValueRateLimitPriorityHigh)
+}
+
+// ReconcileInterval returns the interval of periodic reconciliations given by annotation dns.gardener.cloud/reconcile-interval
+// raised to the given minimum interval. It returns 0 if the annotation is not set. For invalid values, an error is returned.
+func (this *EntryVersion) ReconcileInterval(minInterval time.Duration) (time.Duration, error) {
+	return reconcileInterval(this.object.GetAnnotations(), minInterval)
+}
+
+func reconcileInterval(annotations map[string]string, minInterval time.Duration) (time.Duration, error) {
+	value, ok := annotations[dns.AnnotationReconcileInterval]
+	if !ok {
+		return 0, nil
+	}
+	interval, err := time.ParseDuration(strings.TrimSpace(value))
+	if err != nil || interval <= 0 {
+		return 0, fmt.Errorf("invalid value %q for annotation %s (positive duration like '30s' expected)", value, dns.AnnotationReconcileInterval)
+	}
+	if interval < minInterval {
+		interval = minInterval
+	}
+	return interval, nil
+}
+
+// annotationErrorChanged records the validation error of the given annotation and returns true if it is a new error,
+// which has not been reported for the previous version of the entry.
+func (this *EntryVersion) annotationErrorChanged(annotation string, err error) bool {
+	msg := ""
+	if err != nil {
+		msg = err.Error()
+	}
+	if this.annotationErrors[annotation] == msg {
+		return false
+	}
+	reported := map[string]string{}
+	for k, v := range this.annotationErrors {
+		if k != annotation {
+			reported[k] = v
+		}
+	}
+	if msg != "" {
+		reported[annotation] = msg
+	}
+	this.annotationErrors = reported
+	return msg != ""
+}
+
+// HasHighRateLimitPriority returns true if the entry has the high priority for the provider rate limiter.
+func (this *EntryVersion) HasHighRateLimitPriority() bool {
+	priority, _ := this.RateLimitPriority()
+	return priority == dns.AnnotationValueRateLimitPriorityHigh
+}
+
 func (this *EntryVersion) updateStatus(logger logger.LogContext, state, msg string, args ...interface{}) error {
 	logmsg := dnsutils.NewLogMessage(msg, args...)
+	var transition *stateTransition
 	f := func(data resources.ObjectData) (bool, error) {
 		tmp, err := this.object.GetResource().Wrap(data)
 		if err != nil {
@@ -619,6 +1268,7 @@ func (this *EntryVersion) updateStatus(logger logger.LogContext, state, msg stri
 		}
 		o := dnsutils.DNSEntry(tmp)
 		status := o.Status()
+		transition = newStateTransition(o.ObjectName(), status)
 		mod := (&utils.ModificationState{}).
 			AssureStringPtrPtr(&status.ProviderType, this.status.ProviderType).
 			AssureStringValue(&status.State, state).
@@ -639,11 +1289,21 @@ func (this *EntryVersion) updateStatus(logger logger.LogContext, state, msg stri
 		return mod.IsModified(), nil
 	}
 	_, err := this.object.ModifyStatus(f)
-	this.object.Event(corev1.EventTypeNormal, "reconcile", logmsg.Get())
+	if err == nil {
+		transition.log()
+	}
+	this.event(corev1.EventTypeNormal, EventReasonProviderUnassigned, logmsg.Get())
 	return err
 }
 
 func (this *EntryVersion) UpdateStatus(logger logger.LogContext, state string, msg string) (bool, error) {
+	return this.updateEntryStatus(logger, state, msg, false)
+}
+
+// updateEntryStatus updates the state of the entry. If written is set, the last backend sync time is set, too.
+// Updates without state transition are subject to the status throttle.
+func (this *EntryVersion) updateEntryStatus(logger logger.LogContext, state string, msg string, written bool) (bool, error) {
+	var transition *stateTransition
 	f := func(data resources.ObjectData) (bool, error) {
 		obj, err := this.object.GetResource().Wrap(data)
 		if err != nil {
@@ -651,6 +1311,7 @@ func (this *EntryVersion) UpdateStatus(logger logger.LogContext, state string, m
 		}
 		o := dnsutils.DNSEntry(obj)
 		b := o.Status()
+		transition = newStateTransition(o.ObjectName(), b)
 		if state == api.STATE_PENDING && b.State != "" {
 			return false, nil
 		}
@@ -669,10 +1330,19 @@ func (this *EntryVersion) UpdateStatus(logger logger.LogContext, state string, m
 			if this.status.Provider != nil {
 				mod.AssureStringPtrPtr(&b.Provider, this.status.Provider)
 			}
+			if written {
+				dnsutils.SetLastUpdateTime(&b.LastBackendSyncTime)
+				mod.Modify(true)
+			}
 		} else if state != api.STATE_STALE {
 			mod.Modify(o.AcknowledgeTargets(nil))
 			mod.Modify(o.AcknowledgeRoutingPolicy(nil))
+			if b.Propagated != nil {
+				b.Propagated = nil
+				mod.Modify(true)
+			}
 		}
+		stateChanged := b.State != state || b.ObservedGeneration != o.GetGeneration()
 		mod.AssureInt64Value(&b.ObservedGeneration, o.GetGeneration())
 		if !(this.status.State == api.STATE_STALE && this.status.State == state) {
 			mod.AssureStringPtrValue(&b.Message, msg)
@@ -680,16 +1350,28 @@ func (this *EntryVersion) UpdateStatus(logger logger.LogContext, state string, m
 		}
 		mod.AssureStringValue(&b.State, state)
 		this.status.State = state
+		if mod.IsModified() && !this.statusThrottle.Allow(o.ClusterKey(), stateChanged) {
+			logger.Debugf("throttling status update of '%s/%s'", o.GetNamespace(), o.GetName())
+			return false, nil
+		}
 		if mod.IsModified() {
 			dnsutils.SetLastUpdateTime(&b.LastUptimeTime)
 			logger.Infof("update state of '%s/%s' to %s (%s)", o.GetNamespace(), o.GetName(), state, msg)
 		}
 		return mod.IsModified(), nil
 	}
-	return this.object.ModifyStatus(f)
+	mod, err := this.object.ModifyStatus(f)
+	if err == nil {
+		transition.log()
+		if mod {
+			this.statusThrottle.Written(this.ObjectName())
+		}
+	}
+	return mod, err
 }
 
 func (this *EntryVersion) UpdateState(logger logger.LogContext, state, msg string) (bool, error) {
+	var transition *stateTransition
 	f := func(data resources.ObjectData) (bool, error) {
 		obj, err := this.object.GetResource().Wrap(data)
 		if err != nil {
@@ -697,6 +1379,7 @@ func (this *EntryVersion) UpdateState(logger logger.LogContext, state, msg strin
 		}
 		o := dnsutils.DNSEntry(obj)
 		b := o.Status()
+		transition = newStateTransition(o.ObjectName(), b)
 		mod := &utils.ModificationState{}
 
 		mod.AssureStringPtrValue(&b.Message, msg)
@@ -709,7 +1392,37 @@ func (this *EntryVersion) UpdateState(logger logger.LogContext, state, msg strin
 		}
 		return mod.IsModified(), nil
 	}
-	return this.object.ModifyStatus(f)
+	mod, err := this.object.ModifyStatus(f)
+	if err == nil {
+		transition.log()
+	}
+	return mod, err
+}
+
+// UpdatePropagated sets the result of the propagation verification in the status if the entry is ready.
+// If not propagated, a warning event with the message is emitted.
+func (this *EntryVersion) UpdatePropagated(logger logger.LogContext, propagated *bool, msg string) error {
+	f := func(data resources.ObjectData) (bool, error) {
+		obj, err := this.object.GetResource().Wrap(data)
+		if err != nil {
+			return false, err
+		}
+		b := dnsutils.DNSEntry(obj).Status()
+		if b.State != api.STATE_READY || reflect.DeepEqual(b.Propagated, propagated) {
+			return false, nil
+		}
+		b.Propagated = propagated
+		if propagated != nil {
+			logger.Infof("update propagated of '%s/%s' to %t", obj.GetNamespace(), obj.GetName(), *propagated)
+		}
+		return true, nil
+	}
+	_, err := this.object.ModifyStatus(f)
+	if err == nil && propagated != nil && !*propagated {
+		logger.Warn(msg)
+		this.event(corev1.EventTypeWarning, EventReasonPropagationFailed, msg)
+	}
+	return err
 }
 
 func targetList(targets Targets) []string {
@@ -720,8 +1433,11 @@ func targetList(targets Targets) []string {
 	return targetList
 }
 
-func normalizeTargets(logger logger.LogContext, object *dnsutils.DNSEntryObject, targets ...Target) (Targets, *lookupAllResults, bool) {
-	multiCNAME := len(targets) > 0 && targets[0].GetRecordType() == dns.RS_CNAME && (len(targets) > 1 || ptr.Deref(object.ResolveTargetsToAddresses(), false))
+func (this *EntryVersion) normalizeTargets(logger logger.LogContext, processor *lookupProcessor, maxCNAMETargets int, resolve bool, targets ...Target) (Targets, *lookupAllResults, bool) {
+	multiCNAME := len(targets) > 0 && targets[0].GetRecordType() == dns.RS_CNAME && (len(targets) > 1 || resolve)
+	if multiCNAME && len(targets) == 1 && isExcludedFromResolution(targets[0].GetHostName(), this.object.ResolveTargetsExclusions()) {
+		multiCNAME = false
+	}
 	if !multiCNAME {
 		return targets, nil, false
 	}
@@ -729,7 +1445,7 @@ func normalizeTargets(logger logger.LogContext, object *dnsutils.DNSEntryObject,
 	if len(targets) > maxCNAMETargets {
 		w := fmt.Sprintf("too many CNAME targets: %d (maximum allowed: %d)", len(targets), maxCNAMETargets)
 		logger.Warn(w)
-		object.Event(corev1.EventTypeWarning, "dnslookup restriction", w)
+		this.event(corev1.EventTypeWarning, EventReasonValidationFailed, w)
 		return nil, nil, true
 	}
 	result := make(Targets, 0, len(targets))
@@ -738,7 +1454,7 @@ func normalizeTargets(logger logger.LogContext, object *dnsutils.DNSEntryObject,
 		hostnames[i] = t.GetHostName()
 	}
 	ctx := context.Background()
-	results := lookupAllHostnamesIPs(ctx, hostnames...)
+	results := processor.Lookup(ctx, this.object.ObjectName(), hostnames...)
 	ttl := targets[0].GetTTL()
 	for _, addr := range results.ipv4Addrs {
 		result = append(result, dnsutils.NewTarget(dns.RS_A, addr, ttl))
@@ -748,7 +1464,7 @@ func normalizeTargets(logger logger.LogContext, object *dnsutils.DNSEntryObject,
 	}
 	for _, err := range results.errs {
 		logger.Warn(err.Error())
-		object.Event(corev1.EventTypeNormal, "dnslookup", err.Error())
+		this.event(corev1.EventTypeWarning, EventReasonLookupFailed, err.Error())
 	}
 	return result, &results, true
 }
@@ -794,7 +1510,7 @@ func (this *Entry) IsActive() bool {
 	if id == "" {
 		id = this.state.config.Ident
 	}
-	return this.state.ownerCache.IsResponsibleFor(id)
+	return this.state.ownerCache.IsResponsibleForNamespace(id, this.ObjectName().Namespace())
 }
 
 func (this *Entry) IsModified() bool {
@@ -817,12 +1533,12 @@ func (this *Entry) Update(logger logger.LogContext, new *EntryVersion) *Entry {
 			logger.Infof("targets differ from internal state")
 			for _, w := range new.warnings {
 				logger.Warn(w)
-				this.object.Event(corev1.EventTypeNormal, "reconcile", w)
+				this.event(corev1.EventTypeNormal, EventReasonValidationWarning, w)
 			}
 			for dns, m := range new.mappings {
 				msg := fmt.Sprintf("mapping cname %q to %v", dns, m)
 				logger.Info(msg)
-				this.object.Event(corev1.EventTypeNormal, "dnslookup", msg)
+				this.event(corev1.EventTypeNormal, EventReasonResolved, msg)
 			}
 			logger.Infof("update effective targets: [%s]", strings.Join(targetList(new.targets), ", "))
 		}
@@ -855,6 +1571,7 @@ func (this *Entry) updateStatistic(statistic *statistic.EntryStatistic) {
 	defer this.lock.Unlock()
 	statistic.Owners.Inc(this.OwnerId(), this.ProviderType(), this.ProviderName())
 	statistic.Providers.Inc(this.ProviderType(), this.ProviderName())
+	statistic.States.Inc(this.ObjectName().Namespace(), this.ProviderType(), this.State())
 }
 
 ////////////////////////////////////////////////////////////////////////////////
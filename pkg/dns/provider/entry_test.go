@@ -0,0 +1,667 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+var _ = ginkgov2.Describe("Resolve targets exclusions", func() {
+	exclusions := []string{"internal.example.com", "*.svc.example.org"}
+
+	ginkgov2.DescribeTable("matches hostnames",
+		func(hostname string, expected bool) {
+			Expect(isExcludedFromResolution(hostname, exclusions)).To(Equal(expected))
+		},
+		ginkgov2.Entry("domain itself", "internal.example.com", true),
+		ginkgov2.Entry("subdomain", "a.internal.example.com", true),
+		ginkgov2.Entry("case and final dot", "A.Internal.Example.COM.", true),
+		ginkgov2.Entry("other domain", "external.example.com", false),
+		ginkgov2.Entry("wildcard subdomain", "foo.svc.example.org", true),
+		ginkgov2.Entry("wildcard domain itself", "svc.example.org", false),
+		ginkgov2.Entry("suffix without label boundary", "myinternal.example.com", false),
+	)
+
+	ginkgov2.It("accepts a single excluded target", func() {
+		spec := &api.DNSEntrySpec{ResolveTargetsExclusions: exclusions}
+		targets := Targets{dnsutils.NewTarget(dns.RS_CNAME, "a.internal.example.com", 300)}
+		Expect(validateResolveTargetsExclusions(spec, targets)).To(Succeed())
+	})
+
+	ginkgov2.It("accepts multiple resolvable targets", func() {
+		spec := &api.DNSEntrySpec{ResolveTargetsExclusions: exclusions}
+		targets := Targets{
+			dnsutils.NewTarget(dns.RS_CNAME, "a.example.com", 300),
+			dnsutils.NewTarget(dns.RS_CNAME, "b.example.com", 300),
+		}
+		Expect(validateResolveTargetsExclusions(spec, targets)).To(Succeed())
+	})
+
+	ginkgov2.It("rejects an excluded target mixed with other targets", func() {
+		spec := &api.DNSEntrySpec{ResolveTargetsExclusions: exclusions}
+		targets := Targets{
+			dnsutils.NewTarget(dns.RS_CNAME, "a.example.com", 300),
+			dnsutils.NewTarget(dns.RS_CNAME, "a.internal.example.com", 300),
+		}
+		Expect(validateResolveTargetsExclusions(spec, targets)).To(MatchError(ContainSubstring(`target "a.internal.example.com" is excluded from resolution`)))
+	})
+
+	ginkgov2.It("rejects invalid exclusions", func() {
+		spec := &api.DNSEntrySpec{ResolveTargetsExclusions: []string{"in valid"}}
+		targets := Targets{dnsutils.NewTarget(dns.RS_CNAME, "a.example.com", 300)}
+		Expect(validateResolveTargetsExclusions(spec, targets)).To(MatchError(ContainSubstring("invalid resolve targets exclusion")))
+	})
+})
+
+var _ = ginkgov2.Describe("Target count validation", func() {
+	cnames := func(n int) Targets {
+		var targets Targets
+		for i := 0; i < n; i++ {
+			targets = append(targets, dnsutils.NewTarget(dns.RS_CNAME, fmt.Sprintf("t%d.example.com", i), 300))
+		}
+		return targets
+	}
+
+	ginkgov2.It("uses the default CNAME limit if not configured", func() {
+		Expect(validateTargetCount(&Config{}, cnames(maxCNAMETargets))).To(Succeed())
+		Expect(validateTargetCount(&Config{}, cnames(maxCNAMETargets+1))).To(MatchError("too many CNAME targets: 26 (maximum allowed: 25)"))
+	})
+
+	ginkgov2.It("applies a lowered CNAME limit", func() {
+		config := &Config{MaxCNAMETargets: 3}
+		Expect(validateTargetCount(config, cnames(3))).To(Succeed())
+		Expect(validateTargetCount(config, cnames(4))).To(MatchError("too many CNAME targets: 4 (maximum allowed: 3)"))
+	})
+
+	ginkgov2.It("applies the maximum number of targets", func() {
+		config := &Config{MaxTargets: 2}
+		targets := Targets{
+			dnsutils.NewTarget(dns.RS_A, "1.1.1.1", 300),
+			dnsutils.NewTarget(dns.RS_AAAA, "::1", 300),
+			dnsutils.NewText("some text", 300),
+		}
+		Expect(validateTargetCount(config, targets)).To(Succeed())
+		targets = append(targets, dnsutils.NewTarget(dns.RS_A, "2.2.2.2", 300))
+		Expect(validateTargetCount(config, targets)).To(MatchError("too many targets: 3 (maximum allowed: 2)"))
+	})
+})
+
+var _ = ginkgov2.Describe("Comment validation", func() {
+	ginkgov2.It("accepts missing comments", func() {
+		Expect(validateComment("infoblox-dns", nil)).To(Succeed())
+	})
+
+	ginkgov2.It("applies the comment length limit of the provider type", func() {
+		Expect(validateComment("infoblox-dns", ptr.To(strings.Repeat("x", 256)))).To(Succeed())
+		Expect(validateComment("infoblox-dns", ptr.To(strings.Repeat("x", 257)))).To(MatchError("comment too long: 257 characters (maximum allowed for provider type infoblox-dns: 256)"))
+	})
+
+	ginkgov2.It("counts characters instead of bytes", func() {
+		Expect(validateComment("infoblox-dns", ptr.To(strings.Repeat("ä", 256)))).To(Succeed())
+		Expect(validateComment("infoblox-dns", ptr.To(strings.Repeat("ä", 257)))).To(MatchError("comment too long: 257 characters (maximum allowed for provider type infoblox-dns: 256)"))
+	})
+
+	ginkgov2.It("ignores comments for provider types without known limit", func() {
+		Expect(validateComment("aws-route53", ptr.To(strings.Repeat("x", 1000)))).To(Succeed())
+	})
+})
+
+var _ = ginkgov2.Describe("Preserve on delete validation", func() {
+	ginkgov2.It("accepts preserving records of entries without aliases", func() {
+		Expect(validatePreserveOnDelete(&api.DNSEntrySpec{PreserveOnDelete: ptr.To(true)})).To(Succeed())
+		Expect(validatePreserveOnDelete(&api.DNSEntrySpec{DNSNames: []string{"b.example.com"}})).To(Succeed())
+	})
+
+	ginkgov2.It("rejects preserving records of entries with aliases", func() {
+		spec := &api.DNSEntrySpec{PreserveOnDelete: ptr.To(true), DNSNames: []string{"b.example.com"}}
+		Expect(validatePreserveOnDelete(spec)).To(MatchError("preserveOnDelete cannot be combined with dnsNames"))
+	})
+})
+
+var _ = ginkgov2.Describe("Provider attributes", func() {
+	attrs := map[string]string{"Site": "berlin", "Tenant": "t1"}
+
+	ginkgov2.It("reports all attributes if provider type does not support attributes", func() {
+		Expect(unsupportedProviderAttributes(nil, attrs)).To(Equal([]string{"Site", "Tenant"}))
+	})
+
+	ginkgov2.It("reports attributes not declared by the provider type", func() {
+		Expect(unsupportedProviderAttributes(utils.NewStringSet("Site"), attrs)).To(Equal([]string{"Tenant"}))
+	})
+
+	ginkgov2.It("accepts any attribute if declared by the provider type", func() {
+		Expect(unsupportedProviderAttributes(utils.NewStringSet(AnyProviderAttribute), attrs)).To(BeEmpty())
+	})
+
+	ginkgov2.It("extracts attributes from entry annotations", func() {
+		annotations := map[string]string{
+			dns.AnnotationProviderAttributePrefix + "Site": "berlin",
+			dns.AnnotationProviderAttributePrefix:          "ignored",
+			dns.AnnotationIPStack:                          "ipv4",
+		}
+		Expect(dnsutils.ProviderAttributesFromAnnotations(annotations)).To(Equal(map[string]string{"Site": "berlin"}))
+		Expect(dnsutils.ProviderAttributesFromAnnotations(nil)).To(BeNil())
+	})
+})
+
+var _ = ginkgov2.Describe("NS delegation validation", func() {
+	ginkgov2.It("accepts NS records below the zone apex", func() {
+		p := &EntryPremise{ptype: "aws-route53", zonedomain: "example.com"}
+		Expect(validateNSDelegation(p, "sub.example.com")).To(Succeed())
+	})
+
+	ginkgov2.It("rejects NS records at the zone apex", func() {
+		p := &EntryPremise{ptype: "google-clouddns", zonedomain: "example.com"}
+		Expect(validateNSDelegation(p, "example.com")).To(MatchError("NS records are not allowed at the apex of the hosted zone example.com"))
+		Expect(validateNSDelegation(p, "@.example.com")).To(MatchError("NS records are not allowed at the apex of the hosted zone example.com"))
+	})
+})
+
+var _ = ginkgov2.Describe("TTL defaulting", func() {
+	ginkgov2.It("uses the provider default TTL if the TTL is unset or 0", func() {
+		ttl, defaulted := effectiveTTL(nil, 300)
+		Expect(ttl).To(Equal(int64(300)))
+		Expect(defaulted).To(BeFalse())
+		ttl, defaulted = effectiveTTL(ptr.To[int64](0), 300)
+		Expect(ttl).To(Equal(int64(300)))
+		Expect(defaulted).To(BeTrue())
+		ttl, defaulted = effectiveTTL(ptr.To[int64](60), 300)
+		Expect(ttl).To(Equal(int64(60)))
+		Expect(defaulted).To(BeFalse())
+	})
+})
+
+var _ = ginkgov2.Describe("TTL clamping", func() {
+	ginkgov2.It("keeps the TTL without range", func() {
+		Expect(clampTTL(&Config{}, 1)).To(Equal(int64(1)))
+		Expect(clampTTL(&Config{}, 100000)).To(Equal(int64(100000)))
+	})
+
+	ginkgov2.It("clamps the TTL to the allowed range", func() {
+		config := &Config{MinTTL: 60, MaxTTL: 3600}
+		Expect(clampTTL(config, 30)).To(Equal(int64(60)))
+		Expect(clampTTL(config, 300)).To(Equal(int64(300)))
+		Expect(clampTTL(config, 7200)).To(Equal(int64(3600)))
+	})
+
+	ginkgov2.It("supports a floor without maximum", func() {
+		config := &Config{MinTTL: 60}
+		Expect(clampTTL(config, 10)).To(Equal(int64(60)))
+		Expect(clampTTL(config, 86400)).To(Equal(int64(86400)))
+	})
+
+	ginkgov2.It("raises the floor to the minimum TTL of the provider", func() {
+		config := withProviderMinTTL(Config{MinTTL: 60, MaxTTL: 3600}, 120)
+		Expect(clampTTL(&config, 60)).To(Equal(int64(120)))
+		Expect(clampTTL(&config, 300)).To(Equal(int64(300)))
+		config = withProviderMinTTL(Config{MinTTL: 300}, 120)
+		Expect(clampTTL(&config, 60)).To(Equal(int64(300)))
+	})
+})
+
+var _ = ginkgov2.Describe("CNAME lookup interval", func() {
+	targets := Targets{dnsutils.NewTarget(dns.RS_A, "1.1.1.1", 600)}
+
+	ginkgov2.It("uses the default interval if not specified", func() {
+		Expect(cnameLookupInterval(&Config{}, nil, targets)).To(Equal(int64(600)))
+		Expect(cnameLookupInterval(&Config{CNameLookupInterval: 2 * time.Minute}, nil, targets)).To(Equal(int64(120)))
+	})
+
+	ginkgov2.It("raises a specified interval to the minimum interval", func() {
+		Expect(cnameLookupInterval(&Config{}, ptr.To[int64](10), nil)).To(Equal(int64(30)))
+		Expect(cnameLookupInterval(&Config{CNameLookupMinInterval: 5 * time.Second}, ptr.To[int64](10), nil)).To(Equal(int64(10)))
+	})
+
+	ginkgov2.It("raises a specified interval to the TTL divided by the divisor", func() {
+		Expect(cnameLookupInterval(&Config{}, ptr.To[int64](60), targets)).To(Equal(int64(200)))
+		Expect(cnameLookupInterval(&Config{CNameLookupTTLDivisor: 20}, ptr.To[int64](10), targets)).To(Equal(int64(30)))
+		Expect(cnameLookupInterval(&Config{CNameLookupMinInterval: 5 * time.Second, CNameLookupTTLDivisor: 100}, ptr.To[int64](5), targets)).To(Equal(int64(6)))
+	})
+})
+
+type aliasTestProvider struct {
+	DNSProvider
+	domain string
+}
+
+func (p *aliasTestProvider) ObjectName() resources.ObjectName {
+	return resources.NewObjectName("default", "test")
+}
+
+func (p *aliasTestProvider) Match(dns string) int {
+	if dns == p.domain || strings.HasSuffix(dns, "."+p.domain) {
+		return len(p.domain)
+	}
+	return 0
+}
+
+var _ = ginkgov2.Describe("Reconcile interval", func() {
+	ginkgov2.It("is disabled without annotation", func() {
+		Expect(reconcileInterval(nil, 30*time.Second)).To(BeZero())
+	})
+
+	ginkgov2.It("uses the annotated interval", func() {
+		annotations := map[string]string{dns.AnnotationReconcileInterval: "2m"}
+		Expect(reconcileInterval(annotations, 30*time.Second)).To(Equal(2 * time.Minute))
+	})
+
+	ginkgov2.It("raises the annotated interval to the minimum", func() {
+		annotations := map[string]string{dns.AnnotationReconcileInterval: "10s"}
+		Expect(reconcileInterval(annotations, 30*time.Second)).To(Equal(30 * time.Second))
+	})
+
+	ginkgov2.It("rejects invalid values", func() {
+		for _, value := range []string{"often", "-30s", "0"} {
+			annotations := map[string]string{dns.AnnotationReconcileInterval: value}
+			_, err := reconcileInterval(annotations, 30*time.Second)
+			Expect(err).To(HaveOccurred(), value)
+		}
+	})
+})
+
+var _ = ginkgov2.Describe("Rate limit priority", func() {
+	ginkgov2.It("is normal without annotation", func() {
+		Expect(rateLimitPriority(nil)).To(Equal(dns.AnnotationValueRateLimitPriorityNormal))
+	})
+
+	ginkgov2.It("uses the annotated priority", func() {
+		annotations := map[string]string{dns.AnnotationRateLimitPriority: " High"}
+		Expect(rateLimitPriority(annotations)).To(Equal(dns.AnnotationValueRateLimitPriorityHigh))
+	})
+
+	ginkgov2.It("rejects unknown priorities", func() {
+		annotations := map[string]string{dns.AnnotationRateLimitPriority: "urgent"}
+		priority, err := rateLimitPriority(annotations)
+		Expect(err).To(MatchError(ContainSubstring(`invalid value "urgent"`)))
+		Expect(priority).To(Equal(dns.AnnotationValueRateLimitPriorityNormal))
+	})
+})
+
+var _ = ginkgov2.Describe("Annotation errors", func() {
+	ginkgov2.It("are reported only on change", func() {
+		invalid := fmt.Errorf("invalid value")
+		v := &EntryVersion{}
+		Expect(v.annotationErrorChanged(dns.AnnotationRateLimitPriority, nil)).To(BeFalse())
+		Expect(v.annotationErrorChanged(dns.AnnotationRateLimitPriority, invalid)).To(BeTrue())
+		Expect(v.annotationErrorChanged(dns.AnnotationReconcileInterval, invalid)).To(BeTrue())
+
+		// the reported errors are kept for the next version of the entry
+		next := &EntryVersion{annotationErrors: v.annotationErrors}
+		Expect(next.annotationErrorChanged(dns.AnnotationRateLimitPriority, invalid)).To(BeFalse())
+		Expect(next.annotationErrorChanged(dns.AnnotationRateLimitPriority, fmt.Errorf("other value"))).To(BeTrue())
+
+		// a fixed annotation is reported again if it becomes invalid again
+		Expect(next.annotationErrorChanged(dns.AnnotationRateLimitPriority, nil)).To(BeFalse())
+		Expect(next.annotationErrorChanged(dns.AnnotationRateLimitPriority, invalid)).To(BeTrue())
+		Expect(v.annotationErrors).To(HaveKeyWithValue(dns.AnnotationRateLimitPriority, "invalid value"))
+	})
+})
+
+var _ = ginkgov2.Describe("Aliases", func() {
+	provider := &aliasTestProvider{domain: "example.com"}
+
+	ginkgov2.It("accepts aliases served by the provider", func() {
+		Expect(validateAliases(provider, "a.example.com", []string{"b.example.com", "*.c.example.com"}, true)).To(Succeed())
+	})
+
+	ginkgov2.It("rejects aliases not served by the provider", func() {
+		err := validateAliases(provider, "a.example.com", []string{"b.example.org"}, true)
+		Expect(err).To(MatchError(ContainSubstring("not served by provider")))
+	})
+
+	ginkgov2.It("rejects duplicate names", func() {
+		Expect(validateAliases(provider, "a.example.com", []string{"A.example.com"}, true)).NotTo(Succeed())
+		Expect(validateAliases(provider, "a.example.com", []string{"b.example.com", "b.example.com"}, true)).NotTo(Succeed())
+	})
+
+	ginkgov2.It("rejects invalid names", func() {
+		Expect(validateAliases(provider, "a.example.com", []string{"b_.example.com"}, true)).NotTo(Succeed())
+	})
+
+	ginkgov2.It("derives stable entry names for aliases", func() {
+		name := AliasEntryName("entry", "b.example.com")
+		Expect(name).To(HavePrefix("entry-alias-"))
+		Expect(name).To(HaveLen(len("entry-alias-") + 8))
+		Expect(AliasEntryName("entry", "B.example.com.")).To(Equal(name))
+		Expect(AliasEntryName("entry", "c.example.com")).NotTo(Equal(name))
+		Expect(len(AliasEntryName(strings.Repeat("x", 253), "b.example.com"))).To(BeNumerically("<=", 253))
+	})
+
+	ginkgov2.It("inherits the DNS annotations and the routing policy", func() {
+		entry := &api.DNSEntry{}
+		entry.Name = "entry"
+		entry.Annotations = map[string]string{dns.CLASS_ANNOTATION: "foo", dns.AnnotationImported: "true", "other": "x"}
+		entry.Spec.RoutingPolicy = &api.RoutingPolicy{Type: "weighted", SetIdentifier: "id", Parameters: map[string]string{"weight": "1"}}
+		Expect(aliasEntryAnnotations(entry)).To(Equal(map[string]string{dns.CLASS_ANNOTATION: "foo", dns.AnnotationAliasOf: "entry"}))
+		spec := aliasEntrySpec(entry, "B.example.com")
+		Expect(spec.DNSName).To(Equal("b.example.com"))
+		Expect(spec.Reference).To(Equal(&api.EntryReference{Name: "entry"}))
+		Expect(spec.RoutingPolicy).To(Equal(entry.Spec.RoutingPolicy))
+	})
+
+	ginkgov2.It("inherits the provider reference", func() {
+		entry := &api.DNSEntry{}
+		entry.Name = "entry"
+		entry.Spec.ProviderRef = &api.ProviderReference{Name: "provider"}
+		Expect(aliasEntrySpec(entry, "b.example.com").ProviderRef).To(Equal(entry.Spec.ProviderRef))
+	})
+
+	ginkgov2.It("copies the target reference instead of referencing the entry", func() {
+		entry := &api.DNSEntry{}
+		entry.Name = "entry"
+		entry.Namespace = "ns"
+		entry.Spec.TargetRef = &api.TargetReference{Kind: "Service", Name: "svc"}
+		entry.Spec.TTL = ptr.To[int64](120)
+		spec := aliasEntrySpec(entry, "b.example.com")
+		Expect(spec.Reference).To(BeNil())
+		Expect(spec.TargetRef).To(Equal(&api.TargetReference{Kind: "Service", Name: "svc", Namespace: "ns"}))
+		Expect(spec.TTL).To(Equal(ptr.To[int64](120)))
+		Expect(entry.Spec.TargetRef.Namespace).To(BeEmpty())
+	})
+})
+
+var _ = ginkgov2.Describe("Split providers", func() {
+	providers := []api.ProviderReference{{Name: "aws"}, {Name: "google", Namespace: "other"}}
+
+	ginkgov2.It("accepts distinct providers", func() {
+		Expect(validateSplitProviders("default", &api.DNSEntrySpec{})).To(Succeed())
+		Expect(validateSplitProviders("default", &api.DNSEntrySpec{SplitProviders: providers})).To(Succeed())
+	})
+
+	ginkgov2.It("rejects a single or duplicate providers", func() {
+		Expect(validateSplitProviders("default", &api.DNSEntrySpec{SplitProviders: providers[:1]})).To(MatchError("splitProviders requires at least two providers"))
+		spec := &api.DNSEntrySpec{SplitProviders: []api.ProviderReference{{Name: "aws"}, {Name: "aws", Namespace: "default"}}}
+		Expect(validateSplitProviders("default", spec)).To(MatchError("duplicate provider default/aws in splitProviders"))
+	})
+
+	ginkgov2.It("rejects conflicting fields", func() {
+		spec := &api.DNSEntrySpec{SplitProviders: providers, ProviderRef: &api.ProviderReference{Name: "aws"}}
+		Expect(validateSplitProviders("default", spec)).To(MatchError("splitProviders cannot be combined with providerRef"))
+		spec = &api.DNSEntrySpec{SplitProviders: providers, DNSNames: []string{"b.example.com"}}
+		Expect(validateSplitProviders("default", spec)).To(MatchError("splitProviders cannot be combined with dnsNames"))
+		spec = &api.DNSEntrySpec{SplitProviders: providers, PreserveOnDelete: ptr.To(true)}
+		Expect(validateSplitProviders("default", spec)).To(MatchError("splitProviders cannot be combined with preserveOnDelete"))
+	})
+
+	ginkgov2.It("pins the entry to the first provider", func() {
+		Expect(primaryProviderRef(&api.DNSEntrySpec{})).To(BeNil())
+		Expect(primaryProviderRef(&api.DNSEntrySpec{ProviderRef: &api.ProviderReference{Name: "aws"}})).To(Equal(&api.ProviderReference{Name: "aws"}))
+		Expect(primaryProviderRef(&api.DNSEntrySpec{SplitProviders: providers})).To(Equal(&providers[0]))
+	})
+
+	ginkgov2.It("derives stable entry names for providers", func() {
+		name := SplitEntryName("entry", resources.NewObjectName("other", "google"))
+		Expect(name).To(HavePrefix("entry-split-"))
+		Expect(name).To(HaveLen(len("entry-split-") + 8))
+		Expect(SplitEntryName("entry", resources.NewObjectName("other", "google"))).To(Equal(name))
+		Expect(SplitEntryName("entry", resources.NewObjectName("default", "google"))).NotTo(Equal(name))
+		Expect(len(SplitEntryName(strings.Repeat("x", 253), resources.NewObjectName("other", "google")))).To(BeNumerically("<=", 253))
+	})
+
+	ginkgov2.It("references the entry and pins the provider", func() {
+		entry := &api.DNSEntry{}
+		entry.Name = "entry"
+		entry.Annotations = map[string]string{dns.CLASS_ANNOTATION: "foo", dns.AnnotationImported: "true", "other": "x"}
+		entry.Spec.DNSName = "example.com"
+		entry.Spec.SplitProviders = providers
+		Expect(splitEntryAnnotations(entry)).To(Equal(map[string]string{dns.CLASS_ANNOTATION: "foo", dns.AnnotationSplitOf: "entry"}))
+		spec := splitEntrySpec(entry, &providers[1])
+		Expect(spec.DNSName).To(Equal("example.com"))
+		Expect(spec.Reference).To(Equal(&api.EntryReference{Name: "entry"}))
+		Expect(spec.ProviderRef).To(Equal(&providers[1]))
+		Expect(spec.SplitProviders).To(BeNil())
+	})
+
+	ginkgov2.It("does not inherit the markers of other maintained entries", func() {
+		entry := &api.DNSEntry{}
+		entry.Name = "entry"
+		entry.Annotations = map[string]string{dns.CLASS_ANNOTATION: "foo", dns.AnnotationAliasOf: "a", dns.AnnotationMirrorOf: "m"}
+		Expect(splitEntryAnnotations(entry)).To(Equal(map[string]string{dns.CLASS_ANNOTATION: "foo", dns.AnnotationSplitOf: "entry"}))
+		entry.Annotations = map[string]string{dns.AnnotationSplitOf: "s"}
+		Expect(aliasEntryAnnotations(entry)).To(Equal(map[string]string{dns.AnnotationAliasOf: "entry"}))
+	})
+})
+
+var _ = ginkgov2.Describe("Provider reference", func() {
+	name := resources.NewObjectName("default", "test")
+
+	ginkgov2.It("defaults the namespace by the one of the entry", func() {
+		Expect(providerRefName("default", &api.ProviderReference{Name: "test"})).To(Equal(name))
+		Expect(providerRefName("default", &api.ProviderReference{Name: "test", Namespace: "other"})).To(Equal(resources.NewObjectName("other", "test")))
+	})
+
+	ginkgov2.It("requires an existing provider", func() {
+		Expect(checkReferencedProvider(name, nil, "a.example.com")).To(MatchError("referenced provider default/test not found"))
+	})
+
+	ginkgov2.It("requires the provider to serve the DNS name", func() {
+		provider := &aliasTestProvider{domain: "example.com"}
+		Expect(checkReferencedProvider(name, provider, "a.example.com")).To(Succeed())
+		Expect(checkReferencedProvider(name, provider, "a.example.org")).To(MatchError("referenced provider default/test does not serve domain name a.example.org"))
+	})
+})
+
+var _ = ginkgov2.Describe("Record type validation", func() {
+	premise := func(capabilities Capabilities) *EntryPremise {
+		return &EntryPremise{provider: &capabilityTestProvider{typeCode: "test", capabilities: capabilities}}
+	}
+
+	ginkgov2.It("accepts common record types for all providers", func() {
+		for _, rtype := range []string{dns.RS_A, dns.RS_AAAA, dns.RS_CNAME, dns.RS_TXT, dns.RS_SRV, dns.RS_CAA} {
+			Expect(validateRecordType(premise(Capabilities{}), rtype)).To(Succeed())
+		}
+	})
+
+	ginkgov2.It("accepts optional record types declared by the provider", func() {
+		p := premise(Capabilities{RecordTypes: []string{dns.RS_PTR, dns.RS_MX}})
+		Expect(validateRecordType(p, dns.RS_PTR)).To(Succeed())
+		Expect(validateRecordType(p, dns.RS_MX)).To(Succeed())
+	})
+
+	ginkgov2.It("rejects optional record types not declared by the provider", func() {
+		p := premise(Capabilities{RecordTypes: []string{dns.RS_PTR}})
+		Expect(validateRecordType(p, dns.RS_NS)).To(MatchError("NS records are not supported for provider type test"))
+		Expect(validateRecordType(p, dns.RS_DS)).To(MatchError("DS records are not supported for provider type test"))
+		Expect(validateRecordType(p, dns.RS_MX)).To(MatchError("MX records are not supported for provider type test"))
+	})
+
+	ginkgov2.It("skips the check without provider", func() {
+		Expect(validateRecordType(&EntryPremise{ptype: "test"}, dns.RS_MX)).To(Succeed())
+	})
+})
+
+var _ = ginkgov2.Describe("DS record validation", func() {
+	ginkgov2.It("accepts DS records at delegation points", func() {
+		p := &EntryPremise{ptype: "aws-route53", zonedomain: "example.com"}
+		Expect(validateDSDelegation(p, "child.example.com")).To(Succeed())
+	})
+
+	ginkgov2.It("rejects DS records at the zone apex", func() {
+		p := &EntryPremise{ptype: "google-clouddns", zonedomain: "example.com"}
+		Expect(validateDSDelegation(p, "example.com")).To(MatchError("DS records are not allowed at the apex of the hosted zone example.com"))
+	})
+
+})
+
+type capabilityTestProvider struct {
+	DNSProvider
+	typeCode     string
+	native       bool
+	capabilities Capabilities
+}
+
+func (p *capabilityTestProvider) TypeCode() string {
+	return p.typeCode
+}
+
+func (p *capabilityTestProvider) SupportsApexAlias(_ Target) bool {
+	return p.native
+}
+
+func (p *capabilityTestProvider) Capabilities() Capabilities {
+	return p.capabilities
+}
+
+var _ = ginkgov2.Describe("Apex alias", func() {
+	premise := func(native, apexPrefix bool) *EntryPremise {
+		return &EntryPremise{
+			provider:   &capabilityTestProvider{typeCode: "test", native: native, capabilities: Capabilities{ApexPrefix: apexPrefix}},
+			zoneid:     "z1",
+			zonedomain: "example.com",
+		}
+	}
+	cname := Targets{dnsutils.NewTarget(dns.RS_CNAME, "lb.example.org", 300)}
+
+	ginkgov2.It("needs no strategy below the apex", func() {
+		Expect(apexAliasStrategy(premise(true, false), "www.example.com", cname)).To(BeEmpty())
+	})
+
+	ginkgov2.It("needs no strategy for address targets", func() {
+		targets := Targets{dnsutils.NewTarget(dns.RS_A, "1.2.3.4", 300)}
+		Expect(apexAliasStrategy(premise(false, false), "example.com", targets)).To(BeEmpty())
+	})
+
+	ginkgov2.It("uses the native alias if supported", func() {
+		Expect(apexAliasStrategy(premise(true, false), "example.com", cname)).To(Equal(api.APEX_ALIAS_NATIVE))
+	})
+
+	ginkgov2.It("resolves the target otherwise", func() {
+		Expect(apexAliasStrategy(premise(false, false), "example.com", cname)).To(Equal(api.APEX_ALIAS_RESOLVED))
+	})
+
+	ginkgov2.It("requires the apex prefix if declared by the provider", func() {
+		Expect(validateApex(premise(false, true), "example.com", cname, nil)).To(MatchError(ContainSubstring("Please use apex prefix '@.'")))
+		Expect(validateApex(premise(false, true), "@.example.com", cname, nil)).To(Succeed())
+	})
+
+	ginkgov2.It("resolves the target at the apex prefix", func() {
+		Expect(apexAliasStrategy(premise(false, true), "@.example.com", cname)).To(Equal(api.APEX_ALIAS_RESOLVED))
+	})
+
+	ginkgov2.It("rejects a resolved target excluded from resolution", func() {
+		exclusions := []string{"example.org"}
+		Expect(validateApex(premise(false, false), "example.com", cname, exclusions)).To(MatchError(ContainSubstring("excluded from resolution")))
+		Expect(validateApex(premise(true, false), "example.com", cname, exclusions)).To(Succeed())
+	})
+})
+
+var _ = ginkgov2.Describe("Entry setup", func() {
+	var (
+		s       *state
+		p       *dnsProviderVersion
+		premise *EntryPremise
+	)
+
+	ginkgov2.BeforeEach(func() {
+		zone := NewDNSHostedZone("test", "z1", "example.com", "", false)
+		p = newTestProvider("p1", zone, &testZoneHandler{inMemory: NewInMemory()})
+		p.defaultTTL = 300
+		s = &state{
+			context:         &testProviderContext{},
+			zones:           map[dns.ZoneID]*dnsHostedZone{zone.Id(): newDNSHostedZone(0, zone)},
+			providers:       map[resources.ObjectName]*dnsProviderVersion{p.ObjectName(): p},
+			references:      NewReferenceCache(),
+			config:          Config{TTL: 120, Factory: NewDNSHandlerCompoundFactory("test")},
+			lookupProcessor: newLookupProcessor(logger.New(), &testEnqueuer{enqueuedCount: map[resources.ObjectName]int{}}, 1, time.Second, "default", &testMetrics{lookups: map[resources.ObjectName]lookupStat{}}, time.Hour, 0, nil),
+		}
+		s.ownerCache = NewOwnerCache(nil, &s.config)
+		premise = &EntryPremise{ptype: "test", provider: p, zoneid: "z1", zonedomain: "example.com"}
+	})
+
+	newEntry := func() *Entry {
+		e := newTestEntry(s, "a", "a.example.com", api.STATE_READY, dns.NewZoneID("test", "z1"), "1.1.1.1")
+		e.object.Status().Provider = ptr.To(p.ObjectName().String())
+		return e
+	}
+
+	setup := func(e *Entry) {
+		e.Setup(logger.New(), s, premise, "reconcile", nil, s.config)
+	}
+
+	ginkgov2.It("reports an invalid reconcile interval annotation only on change", func() {
+		e := newEntry()
+		e.object.Data().SetAnnotations(map[string]string{dns.AnnotationReconcileInterval: "often"})
+		setup(e)
+		setup(e)
+		Expect(testEvents(e)).To(ConsistOf(ContainSubstring(`invalid value "often" for annotation ` + dns.AnnotationReconcileInterval)))
+
+		e.object.Data().SetAnnotations(map[string]string{dns.AnnotationReconcileInterval: "never"})
+		setup(e)
+		Expect(testEvents(e)).To(HaveLen(2))
+	})
+
+	ginkgov2.It("defaults TTL 0 by the provider default TTL for ready entries", func() {
+		e := newEntry()
+		e.object.Spec().TTL = ptr.To[int64](0)
+		setup(e)
+		Expect(e.IsValid()).To(BeTrue())
+		Expect(e.status.TTL).To(Equal(ptr.To[int64](300)))
+		Expect(e.targets[0].GetTTL()).To(Equal(int64(300)))
+		Expect(e.object.Status().Message).To(Equal(ptr.To("dns entry active (TTL 0 defaulted to provider default TTL 300)")))
+
+		e.object.Spec().TTL = ptr.To[int64](60)
+		setup(e)
+		Expect(e.status.TTL).To(Equal(ptr.To[int64](60)))
+		Expect(e.object.Status().Message).To(Equal(ptr.To("dns entry active")))
+	})
+
+	ginkgov2.It("clamps the defaulted TTL", func() {
+		s.config.MaxTTL = 120
+		e := newEntry()
+		e.object.Spec().TTL = ptr.To[int64](0)
+		setup(e)
+		Expect(e.status.TTL).To(Equal(ptr.To[int64](120)))
+		Expect(testEvents(e)).To(ConsistOf("TTLClamped: TTL 300 is out of the allowed range and has been clamped to 120"))
+	})
+
+	ginkgov2.Context("with provider reference", func() {
+		reconcile := func(e *Entry) {
+			e.object.Spec().ProviderRef = &api.ProviderReference{Name: p.ObjectName().Name()}
+			premise, err := s.entryPremise(e.object)
+			e.Setup(logger.New(), s, premise, "reconcile", err, s.config)
+		}
+
+		ginkgov2.It("assigns the entry to the valid referenced provider", func() {
+			e := newEntry()
+			reconcile(e)
+			Expect(e.IsValid()).To(BeTrue())
+			Expect(e.status.Provider).To(Equal(ptr.To(p.ObjectName().String())))
+		})
+
+		ginkgov2.It("keeps the records of a served entry if the referenced provider is invalid", func() {
+			p.valid = false
+			e := newEntry()
+			reconcile(e)
+			Expect(e.IsValid()).To(BeFalse())
+			Expect(e.status.State).To(Equal(api.STATE_STALE))
+			Expect(e.status.Zone).To(Equal(ptr.To("z1")))
+			Expect(utils.StringValue(e.status.Message)).To(Equal("referenced provider test/p1 is not valid"))
+		})
+
+		ginkgov2.It("does not serve a new entry if the referenced provider is invalid", func() {
+			p.valid = false
+			e := newEntry()
+			e.status = api.DNSEntryStatus{}
+			*e.object.Status() = api.DNSEntryStatus{}
+			reconcile(e)
+			Expect(e.IsValid()).To(BeFalse())
+			Expect(e.status.State).To(Equal(api.STATE_ERROR))
+			Expect(utils.StringValue(e.status.Message)).To(Equal("referenced provider test/p1 is not valid"))
+		})
+	})
+})
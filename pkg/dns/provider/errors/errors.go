@@ -5,6 +5,7 @@
 package errors
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
@@ -31,6 +32,18 @@ func (e *AlreadyBusyForOwner) Error() string {
 	return fmt.Sprintf("DNS name %q already busy for owner %q", e.Name, e.Owner)
 }
 
+// DeferredToWriter is returned if the records of a DNS name are maintained by another controller instance
+// using the same owner id, which takes precedence according to the owner conflict strategy.
+type DeferredToWriter struct {
+	Name   dns.DNSSetName
+	Owner  string
+	Writer string
+}
+
+func (e *DeferredToWriter) Error() string {
+	return fmt.Sprintf("DNS name %q deferred to controller instance %q writing for owner %q", e.Name, e.Writer, e.Owner)
+}
+
 type NoSuchHostedZone struct {
 	ZoneId string
 	Err    error
@@ -40,6 +53,17 @@ func (e *NoSuchHostedZone) Error() string {
 	return fmt.Sprintf("No such hosted zone %s: %s", e.ZoneId, e.Err)
 }
 
+func (e *NoSuchHostedZone) Unwrap() error {
+	return e.Err
+}
+
+// IsNoSuchHostedZone returns true if the error or one of its wrapped errors is a NoSuchHostedZone error.
+// Handlers must only return this error if the hosted zone definitively does not exist anymore in the DNS backend.
+func IsNoSuchHostedZone(err error) bool {
+	var nerr *NoSuchHostedZone
+	return errors.As(err, &nerr)
+}
+
 func NewThrottlingError(err error) *ThrottlingError {
 	return &ThrottlingError{err: err}
 }
@@ -52,7 +76,109 @@ func (e *ThrottlingError) Error() string {
 	return fmt.Sprintf("Throttling: %s", e.err)
 }
 
+func (e *ThrottlingError) Unwrap() error {
+	return e.err
+}
+
+// IsThrottlingError returns true if the error or one of its wrapped errors is a ThrottlingError.
 func IsThrottlingError(err error) bool {
-	_, ok := err.(*ThrottlingError)
-	return ok
+	var terr *ThrottlingError
+	return errors.As(err, &terr)
+}
+
+// NewBatchTooLargeError marks an error of a provider rejecting a change batch because it exceeds a provider limit.
+func NewBatchTooLargeError(err error) *BatchTooLargeError {
+	return &BatchTooLargeError{err: err}
+}
+
+type BatchTooLargeError struct {
+	err error
+}
+
+func (e *BatchTooLargeError) Error() string {
+	return fmt.Sprintf("Batch too large: %s", e.err)
+}
+
+func (e *BatchTooLargeError) Unwrap() error {
+	return e.err
+}
+
+// IsBatchTooLargeError returns true if the error or one of its wrapped errors is a BatchTooLargeError.
+func IsBatchTooLargeError(err error) bool {
+	var berr *BatchTooLargeError
+	return errors.As(err, &berr)
+}
+
+// TargetRefPending indicates that the object referenced by the target reference of an entry has no address yet.
+type TargetRefPending struct {
+	Kind string
+	Name resources.ObjectName
+}
+
+func (e *TargetRefPending) Error() string {
+	return fmt.Sprintf("waiting for load balancer address of %s %s", e.Kind, e.Name)
+}
+
+// IsTargetRefPending returns true if the error or one of its wrapped errors is a TargetRefPending error.
+func IsTargetRefPending(err error) bool {
+	var perr *TargetRefPending
+	return errors.As(err, &perr)
+}
+
+// NewConditionalUpdateConflictError marks an error of a provider rejecting a conditional change of a record set,
+// because the record set has been changed since the zone state was read.
+func NewConditionalUpdateConflictError(err error) *ConditionalUpdateConflictError {
+	return &ConditionalUpdateConflictError{err: err}
+}
+
+type ConditionalUpdateConflictError struct {
+	err error
+}
+
+func (e *ConditionalUpdateConflictError) Error() string {
+	return fmt.Sprintf("Conditional update conflict: %s", e.err)
+}
+
+func (e *ConditionalUpdateConflictError) Unwrap() error {
+	return e.err
+}
+
+// IsConditionalUpdateConflictError returns true if the error or one of its wrapped errors is a ConditionalUpdateConflictError.
+func IsConditionalUpdateConflictError(err error) bool {
+	var cerr *ConditionalUpdateConflictError
+	return errors.As(err, &cerr)
+}
+
+// StaleZoneState is reported to request a refresh of cached zone state, e.g. to detect changes
+// of records made directly in the DNS backend.
+type StaleZoneState struct {
+	Reason string
+}
+
+func (e *StaleZoneState) Error() string {
+	return fmt.Sprintf("Zone state may be stale: %s", e.Reason)
+}
+
+// IsStaleZoneState returns true if the error or one of its wrapped errors is a StaleZoneState.
+func IsStaleZoneState(err error) bool {
+	var serr *StaleZoneState
+	return errors.As(err, &serr)
+}
+
+// CircuitOpen is returned instead of calling the DNS backend while the circuit breaker of an account is open
+// because of consecutive failures.
+type CircuitOpen struct {
+	Failures   int
+	RetryAfter time.Duration
+}
+
+func (e *CircuitOpen) Error() string {
+	return fmt.Sprintf("circuit breaker open after %d consecutive failures of the DNS backend, retrying in %s", e.Failures, e.RetryAfter.Round(time.Second))
+}
+
+// IsCircuitOpen returns the CircuitOpen error if the error or one of its wrapped errors is a CircuitOpen error.
+func IsCircuitOpen(err error) (*CircuitOpen, bool) {
+	var cerr *CircuitOpen
+	ok := errors.As(err, &cerr)
+	return cerr, ok
 }
@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+)
+
+// eventThrottle suppresses identical events of DNS entries, i.e. events with the same type, reason, and message,
+// within a window. This avoids event storms caused by repeated failures, e.g. during outages of a DNS backend.
+// An event emitted again after the window is aggregated by the event recorder, so that its count is increased.
+type eventThrottle struct {
+	lock       sync.Mutex
+	window     time.Duration
+	lastEvents map[resources.ObjectName]map[eventKey]time.Time
+	now        func() time.Time
+}
+
+type eventKey struct {
+	eventtype string
+	reason    string
+	message   string
+}
+
+func newEventThrottle(window time.Duration) *eventThrottle {
+	return &eventThrottle{
+		window:     window,
+		lastEvents: map[resources.ObjectName]map[eventKey]time.Time{},
+		now:        time.Now,
+	}
+}
+
+// Allow checks if the event of the entry may be emitted now and records it in this case.
+func (this *eventThrottle) Allow(name resources.ObjectName, eventtype, reason, message string) bool {
+	if this == nil || this.window <= 0 {
+		return true
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	now := this.now()
+	events := this.lastEvents[name]
+	if events == nil {
+		events = map[eventKey]time.Time{}
+		this.lastEvents[name] = events
+	}
+	key := eventKey{eventtype: eventtype, reason: reason, message: message}
+	if last, ok := events[key]; ok && now.Sub(last) < this.window {
+		return false
+	}
+	for k, last := range events {
+		if now.Sub(last) >= this.window {
+			delete(events, k)
+		}
+	}
+	events[key] = now
+	return true
+}
+
+// Remove forgets the events of a deleted entry.
+func (this *eventThrottle) Remove(name resources.ObjectName) {
+	if this == nil {
+		return
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	delete(this.lastEvents, name)
+}
@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ = ginkgov2.Describe("Event throttle", func() {
+	var (
+		now      time.Time
+		throttle *eventThrottle
+		name     = resources.NewObjectName("ns", "entry")
+		other    = resources.NewObjectName("ns", "other")
+	)
+
+	ginkgov2.BeforeEach(func() {
+		now = time.Now()
+		throttle = newEventThrottle(1 * time.Minute)
+		throttle.now = func() time.Time { return now }
+	})
+
+	ginkgov2.It("allows everything if disabled", func() {
+		var disabled *eventThrottle
+		Expect(disabled.Allow(name, corev1.EventTypeWarning, EventReasonBackendError, "failed")).To(BeTrue())
+		disabled.Remove(name)
+		throttle.window = 0
+		Expect(throttle.Allow(name, corev1.EventTypeWarning, EventReasonBackendError, "failed")).To(BeTrue())
+		Expect(throttle.Allow(name, corev1.EventTypeWarning, EventReasonBackendError, "failed")).To(BeTrue())
+	})
+
+	ginkgov2.It("suppresses identical events within the window", func() {
+		Expect(throttle.Allow(name, corev1.EventTypeWarning, EventReasonBackendError, "failed")).To(BeTrue())
+		now = now.Add(30 * time.Second)
+		Expect(throttle.Allow(name, corev1.EventTypeWarning, EventReasonBackendError, "failed")).To(BeFalse())
+		Expect(throttle.Allow(name, corev1.EventTypeWarning, EventReasonBackendError, "failed again")).To(BeTrue())
+		Expect(throttle.Allow(name, corev1.EventTypeWarning, EventReasonLookupFailed, "failed")).To(BeTrue())
+		Expect(throttle.Allow(other, corev1.EventTypeWarning, EventReasonBackendError, "failed")).To(BeTrue())
+
+		now = now.Add(30 * time.Second)
+		Expect(throttle.Allow(name, corev1.EventTypeWarning, EventReasonBackendError, "failed")).To(BeTrue())
+		Expect(throttle.Allow(name, corev1.EventTypeWarning, EventReasonBackendError, "failed")).To(BeFalse())
+	})
+
+	ginkgov2.It("forgets expired and removed events", func() {
+		Expect(throttle.Allow(name, corev1.EventTypeWarning, EventReasonBackendError, "a")).To(BeTrue())
+		Expect(throttle.Allow(name, corev1.EventTypeWarning, EventReasonBackendError, "b")).To(BeTrue())
+		now = now.Add(2 * time.Minute)
+		Expect(throttle.Allow(name, corev1.EventTypeWarning, EventReasonBackendError, "c")).To(BeTrue())
+		Expect(throttle.lastEvents[name]).To(HaveLen(1))
+
+		throttle.Remove(name)
+		Expect(throttle.lastEvents).NotTo(HaveKey(name))
+		Expect(throttle.Allow(name, corev1.EventTypeWarning, EventReasonBackendError, "c")).To(BeTrue())
+	})
+})
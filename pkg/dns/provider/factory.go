@@ -22,10 +22,15 @@ type Factory struct {
 	optionCreator         extension.OptionSourceCreator
 	genericDefaults       *GenericFactoryOptions
 	supportZoneStateCache bool
+	secretKeys            SecretKeys
+	providerAttributes    utils.StringSet
 }
 
 var _ DNSHandlerFactory = &Factory{}
 
+// AnyProviderAttribute is used with SetProviderAttributes to declare support for arbitrary provider attribute names.
+const AnyProviderAttribute = "*"
+
 func NewDNSHandlerFactory(typecode string, create DNSHandlerCreatorFunction, disableZoneStateCache ...bool) *Factory {
 	disable := false
 	for _, b := range disableZoneStateCache {
@@ -58,6 +63,19 @@ func (this *Factory) SetOptionSourceByExample(proto config.OptionSource, default
 	return this.SetGenericFactoryOptionDefaults(defaults...)
 }
 
+// SetSecretKeys declares the required and optional keys of the provider secrets.
+func (this *Factory) SetSecretKeys(keys ...SecretKey) *Factory {
+	this.secretKeys = keys
+	return this
+}
+
+// SetProviderAttributes declares the names of the supported provider specific record attributes.
+// The name AnyProviderAttribute declares support for arbitrary attribute names.
+func (this *Factory) SetProviderAttributes(names ...string) *Factory {
+	this.providerAttributes = utils.NewStringSet(names...)
+	return this
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 
 func (this *Factory) IsResponsibleFor(object *dnsutils.DNSProviderObject) bool {
@@ -97,6 +115,20 @@ func (this *Factory) SupportZoneStateCache(typecode string) (bool, error) {
 	return false, fmt.Errorf("not responsible for %q", typecode)
 }
 
+func (this *Factory) SecretKeys(typecode string) (SecretKeys, error) {
+	if typecode == this.typecode {
+		return this.secretKeys, nil
+	}
+	return nil, fmt.Errorf("not responsible for %q", typecode)
+}
+
+func (this *Factory) ProviderAttributes(typecode string) (utils.StringSet, error) {
+	if typecode == this.typecode {
+		return this.providerAttributes, nil
+	}
+	return nil, fmt.Errorf("not responsible for %q", typecode)
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 
 type CompoundFactory struct {
@@ -198,3 +230,19 @@ func (this *CompoundFactory) SupportZoneStateCache(typecode string) (bool, error
 	}
 	return false, fmt.Errorf("not responsible for %q", typecode)
 }
+
+func (this *CompoundFactory) SecretKeys(typecode string) (SecretKeys, error) {
+	f := this.factories[typecode]
+	if f != nil {
+		return f.SecretKeys(typecode)
+	}
+	return nil, fmt.Errorf("not responsible for %q", typecode)
+}
+
+func (this *CompoundFactory) ProviderAttributes(typecode string) (utils.StringSet, error) {
+	f := this.factories[typecode]
+	if f != nil {
+		return f.ProviderAttributes(typecode)
+	}
+	return nil, fmt.Errorf("not responsible for %q", typecode)
+}
@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+// validateFailoverPolicies checks that the record sets with failover routing policies of each DNS name contain
+// exactly one primary record set. The returned map contains the error for all record sets of invalid failover groups.
+// Record sets with an invalid failover record type are validated on entry level and are ignored here.
+func validateFailoverPolicies(policies map[dns.DNSSetName]*dns.RoutingPolicy) map[dns.DNSSetName]error {
+	siblings := map[string][]dns.DNSSetName{}
+	primaries := map[string]int{}
+	for name, policy := range policies {
+		typ, ok, err := policy.FailoverRecordType()
+		if !ok || err != nil {
+			continue
+		}
+		siblings[name.DNSName] = append(siblings[name.DNSName], name)
+		if typ == dns.FailoverRecordTypePrimary {
+			primaries[name.DNSName]++
+		}
+	}
+
+	result := map[dns.DNSSetName]error{}
+	for dnsName, names := range siblings {
+		if count := primaries[dnsName]; count != 1 {
+			err := fmt.Errorf("failover routing policy for %s requires exactly one primary record set, but found %d", dnsName, count)
+			for _, name := range names {
+				result[name] = err
+			}
+		}
+	}
+	return result
+}
+
+// invalidFailoverGroups returns the errors for the entries belonging to invalid failover groups.
+// Entries being deleted are not considered as siblings.
+func invalidFailoverGroups(entries ...Entries) map[dns.DNSSetName]error {
+	policies := map[dns.DNSSetName]*dns.RoutingPolicy{}
+	for _, list := range entries {
+		for _, e := range list {
+			if !e.IsDeleting() && e.RoutingPolicy() != nil {
+				policies[e.DNSSetName()] = e.RoutingPolicy()
+			}
+		}
+	}
+	return validateFailoverPolicies(policies)
+}
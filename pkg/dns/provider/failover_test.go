@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+var _ = ginkgov2.Describe("Failover validation", func() {
+	setName := func(dnsName, id string) dns.DNSSetName {
+		return dns.DNSSetName{DNSName: dnsName, SetIdentifier: id}
+	}
+	failover := func(typ string) *dns.RoutingPolicy {
+		return dns.NewRoutingPolicy(dns.RoutingPolicyFailover, "failoverRecordType", typ)
+	}
+
+	ginkgov2.It("accepts failover groups with exactly one primary", func() {
+		policies := map[dns.DNSSetName]*dns.RoutingPolicy{
+			setName("a.example.com", "primary"):   failover("primary"),
+			setName("a.example.com", "secondary"): failover("SECONDARY"),
+			setName("b.example.com", "primary"):   failover("PRIMARY"),
+			setName("c.example.com", "blue"):      dns.NewRoutingPolicy(dns.RoutingPolicyWeighted, "weight", "10"),
+		}
+		Expect(validateFailoverPolicies(policies)).To(BeEmpty())
+	})
+
+	ginkgov2.It("rejects failover groups without or with multiple primaries", func() {
+		policies := map[dns.DNSSetName]*dns.RoutingPolicy{
+			setName("a.example.com", "one"):       failover("primary"),
+			setName("a.example.com", "two"):       failover("primary"),
+			setName("b.example.com", "secondary"): failover("secondary"),
+			setName("c.example.com", "primary"):   failover("primary"),
+			setName("c.example.com", "invalid"):   failover("tertiary"),
+		}
+		result := validateFailoverPolicies(policies)
+		Expect(result).To(HaveLen(3))
+		Expect(result[setName("a.example.com", "one")]).To(MatchError(ContainSubstring("found 2")))
+		Expect(result[setName("a.example.com", "two")]).To(MatchError(ContainSubstring("found 2")))
+		Expect(result[setName("b.example.com", "secondary")]).To(MatchError(ContainSubstring("found 0")))
+	})
+})
@@ -0,0 +1,190 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+// testObject is a resource object without cluster recording the emitted events and status modifications.
+type testObject struct {
+	resources.Object
+	data                resources.ObjectData
+	kind                string
+	events              []string
+	statusModifications int
+}
+
+func newTestObject(data resources.ObjectData, kind string) *testObject {
+	return &testObject{data: data, kind: kind}
+}
+
+func (o *testObject) Data() resources.ObjectData { return o.data }
+func (o *testObject) GetName() string            { return o.data.GetName() }
+func (o *testObject) GetNamespace() string       { return o.data.GetNamespace() }
+func (o *testObject) IsDeleting() bool           { return o.data.GetDeletionTimestamp() != nil }
+func (o *testObject) GetFinalizers() []string    { return o.data.GetFinalizers() }
+func (o *testObject) GetGeneration() int64       { return o.data.GetGeneration() }
+func (o *testObject) GetAnnotations() map[string]string {
+	return o.data.GetAnnotations()
+}
+func (o *testObject) GetLabels() map[string]string { return o.data.GetLabels() }
+func (o *testObject) GetCreationTimestamp() metav1.Time {
+	return o.data.GetCreationTimestamp()
+}
+func (o *testObject) GetOwners(_ ...schema.GroupKind) resources.ClusterObjectKeySet { return nil }
+func (o *testObject) GroupKind() schema.GroupKind {
+	return schema.GroupKind{Group: api.GroupName, Kind: o.kind}
+}
+func (o *testObject) ObjectName() resources.ObjectName {
+	return resources.NewObjectName(o.data.GetNamespace(), o.data.GetName())
+}
+func (o *testObject) ClusterKey() resources.ClusterObjectKey {
+	return resources.NewClusterKey("test", o.GroupKind(), o.data.GetNamespace(), o.data.GetName())
+}
+func (o *testObject) IsA(spec interface{}) bool {
+	switch spec.(type) {
+	case *api.DNSEntry:
+		return o.kind == api.DNSEntryKind
+	case *api.DNSProvider:
+		return o.kind == api.DNSProviderKind
+	}
+	return false
+}
+func (o *testObject) Event(_, reason, message string) {
+	o.events = append(o.events, reason+": "+message)
+}
+func (o *testObject) GetResource() resources.Interface { return &testResource{object: o} }
+func (o *testObject) ModifyStatus(modifier resources.Modifier) (bool, error) {
+	o.statusModifications++
+	return modifier(o.data)
+}
+
+// testResource wraps object data into its test object.
+type testResource struct {
+	resources.Interface
+	object *testObject
+}
+
+func (r *testResource) Wrap(_ resources.ObjectData) (resources.Object, error) { return r.object, nil }
+
+// testZoneHandler is a DNS handler serving the hosted zones of an in-memory backend.
+type testZoneHandler struct {
+	inMemory  *InMemory
+	conflicts []error
+}
+
+var _ DNSHandler = &testZoneHandler{}
+
+func (h *testZoneHandler) ProviderType() string                     { return "test" }
+func (h *testZoneHandler) GetZones() (DNSHostedZones, error)        { return h.inMemory.GetZones(), nil }
+func (h *testZoneHandler) MapTargets(_ string, t []Target) []Target { return t }
+func (h *testZoneHandler) Release()                                 {}
+func (h *testZoneHandler) GetZoneState(zone DNSHostedZone) (DNSZoneState, error) {
+	return h.inMemory.CloneZoneState(zone)
+}
+func (h *testZoneHandler) ReportZoneStateConflict(_ DNSHostedZone, err error) bool {
+	h.conflicts = append(h.conflicts, err)
+	return true
+}
+func (h *testZoneHandler) ExecuteRequests(_ logger.LogContext, zone DNSHostedZone, _ DNSZoneState, reqs []*ChangeRequest) error {
+	for _, r := range reqs {
+		err := h.inMemory.Apply(zone.Id(), r, &NullMetrics{})
+		if r.Done != nil {
+			if err != nil {
+				r.Done.Failed(err)
+			} else {
+				r.Done.Succeeded()
+			}
+		}
+	}
+	return nil
+}
+
+// newTestProvider returns a valid provider for the given domain serving the zone by the handler.
+func newTestProvider(name string, zone DNSHostedZone, handler DNSHandler) *dnsProviderVersion {
+	object := &api.DNSProvider{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: name, CreationTimestamp: metav1.Now()},
+		Spec:       api.DNSProviderSpec{Type: zone.Id().ProviderType},
+	}
+	return &dnsProviderVersion{
+		object:         dnsutils.DNSProvider(newTestObject(object, api.DNSProviderKind)),
+		account:        &DNSAccount{RateLimiter: dnsutils.NewRateLimiter(time.Second, time.Minute), handler: handler, hash: name},
+		valid:          true,
+		zones:          DNSHostedZones{zone},
+		included_zones: utils.NewStringSet(zone.Id().ID),
+		included:       utils.NewStringSet(zone.Domain()),
+		excluded:       utils.NewStringSet(),
+	}
+}
+
+// newTestEntry returns a valid entry in the given state for an A record served by the zone.
+func newTestEntry(s *state, name, dnsName, state string, zoneID dns.ZoneID, targets ...string) *Entry {
+	object := &api.DNSEntry{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: name, CreationTimestamp: metav1.Now()},
+		Spec:       api.DNSEntrySpec{DNSName: dnsName, Targets: targets},
+		Status: api.DNSEntryStatus{
+			State:        state,
+			ProviderType: &zoneID.ProviderType,
+			Zone:         &zoneID.ID,
+		},
+	}
+	v := &EntryVersion{
+		object:      dnsutils.DNSEntry(newTestObject(object, api.DNSEntryKind)),
+		dnsSetName:  dns.DNSSetName{DNSName: dnsName},
+		status:      object.Status,
+		valid:       true,
+		responsible: true,
+	}
+	for _, t := range targets {
+		v.targets = append(v.targets, dnsutils.NewTarget(dns.RS_A, t, 300))
+	}
+	return &Entry{EntryVersion: v, state: s, activezone: zoneID, createdAt: time.Now()}
+}
+
+// testEvents returns the events emitted for the entry.
+func testEvents(e *Entry) []string {
+	return e.object.Object.(*testObject).events
+}
+
+// testStatusModifications returns the number of status modifications of the entry.
+func testStatusModifications(e *Entry) int {
+	return e.object.Object.(*testObject).statusModifications
+}
+
+// testProviderContext records the enqueued commands and keys.
+type testProviderContext struct {
+	ProviderContext
+	commands []string
+	keys     []resources.ClusterObjectKey
+}
+
+func (c *testProviderContext) IsReady() bool { return true }
+func (c *testProviderContext) EnqueueCommand(cmd string) error {
+	c.commands = append(c.commands, cmd)
+	return nil
+}
+func (c *testProviderContext) EnqueueKey(key resources.ClusterObjectKey) error {
+	c.keys = append(c.keys, key)
+	return nil
+}
+func (c *testProviderContext) GetCluster(_ string) resources.Cluster { return &testCluster{} }
+
+// testCluster is a cluster with a fixed id.
+type testCluster struct {
+	resources.Cluster
+}
+
+func (c *testCluster) GetId() string { return "test" }
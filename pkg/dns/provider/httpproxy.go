@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// ProxySecretKey is the optional key of the provider secret specifying the URL of an HTTP(S) proxy
+// used for the API requests of provider types declaring it.
+var ProxySecretKey = OptionalSecretKey("HTTP_PROXY_URL", "httpProxyURL")
+
+// parseProxyURL validates the proxy URL given in the provider secret. It returns nil for an empty value.
+func parseProxyURL(value string) (*url.URL, error) {
+	if value == "" {
+		return nil, nil
+	}
+	u, err := url.Parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL in secret: %w", err)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("invalid proxy URL %q in secret: scheme must be http, https, or socks5", u.Redacted())
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL %q in secret: host missing", u.Redacted())
+	}
+	return u, nil
+}
+
+// Proxy returns the proxy for an API request of the handler. It is the proxy given in the provider secret,
+// or the proxy given by the standard environment variables HTTPS_PROXY, HTTP_PROXY, and NO_PROXY if unset.
+// It can be used as proxy function of a http.Transport.
+func (c *DNSHandlerConfig) Proxy(req *http.Request) (*url.URL, error) {
+	if c.ProxyURL != nil {
+		return c.ProxyURL, nil
+	}
+	return http.ProxyFromEnvironment(req)
+}
+
+// NewHTTPClient returns a HTTP client using the proxy of the handler.
+func (c *DNSHandlerConfig) NewHTTPClient() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = c.Proxy
+	return &http.Client{Transport: c.WrapProxyErrors(transport)}
+}
+
+// WrapProxyErrors wraps a round tripper to report the used proxy for failed requests.
+func (c *DNSHandlerConfig) WrapProxyErrors(rt http.RoundTripper) http.RoundTripper {
+	return &proxyErrorRoundTripper{proxy: c.Proxy, inner: rt}
+}
+
+type proxyErrorRoundTripper struct {
+	proxy func(*http.Request) (*url.URL, error)
+	inner http.RoundTripper
+}
+
+func (rt *proxyErrorRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := rt.inner.RoundTrip(req)
+	if err != nil {
+		if p, perr := rt.proxy(req); perr == nil && p != nil {
+			return nil, fmt.Errorf("request to %s via proxy %s failed: %w", req.URL.Host, p.Redacted(), err)
+		}
+	}
+	return resp, err
+}
@@ -7,6 +7,7 @@ package provider
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/gardener/external-dns-management/pkg/dns"
 	"k8s.io/apimachinery/pkg/util/uuid"
@@ -23,6 +24,20 @@ type InMemory struct {
 	lock            sync.Mutex
 	zones           map[dns.ZoneID]zonedata
 	failSimulations map[string]*inMemoryApplyFailSimulation
+
+	propagationDelay time.Duration
+	pending          []*inMemoryPendingChange
+	now              func() time.Time
+}
+
+// inMemoryPendingChange is an applied change not yet visible to reads because of the propagation delay.
+type inMemoryPendingChange struct {
+	visibleAt     time.Time
+	zoneID        dns.ZoneID
+	action        string
+	name          dns.DNSSetName
+	routingPolicy *dns.RoutingPolicy
+	rset          *dns.RecordSet
 }
 
 // inMemoryApplyFailSimulation is a struct to simulate apply failures.
@@ -33,7 +48,31 @@ type inMemoryApplyFailSimulation struct {
 }
 
 func NewInMemory() *InMemory {
-	return &InMemory{zones: map[dns.ZoneID]zonedata{}}
+	return &InMemory{zones: map[dns.ZoneID]zonedata{}, now: time.Now}
+}
+
+// SetPropagationDelay sets the delay after which applied changes become visible to reads
+// to simulate the propagation of changes by real DNS backends.
+func (m *InMemory) SetPropagationDelay(delay time.Duration) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.propagationDelay = delay
+}
+
+// propagate makes all pending changes visible whose propagation delay has elapsed.
+func (m *InMemory) propagate() {
+	if len(m.pending) == 0 {
+		return
+	}
+	now := m.now()
+	i := 0
+	for ; i < len(m.pending) && !m.pending[i].visibleAt.After(now); i++ {
+		change := m.pending[i]
+		if data, ok := m.zones[change.zoneID]; ok {
+			applyRecordSet(data.dnssets, change.action, change.name, change.routingPolicy, change.rset)
+		}
+	}
+	m.pending = m.pending[i:]
 }
 
 func (m *InMemory) GetZones() DNSHostedZones {
@@ -62,6 +101,7 @@ func (m *InMemory) CloneZoneState(zone DNSHostedZone) (DNSZoneState, error) {
 	m.lock.Lock()
 	defer m.lock.Unlock()
 
+	m.propagate()
 	data, ok := m.zones[zone.Id()]
 	if !ok {
 		return nil, fmt.Errorf("DNSZone %s not hosted", zone.Id())
@@ -71,6 +111,15 @@ func (m *InMemory) CloneZoneState(zone DNSHostedZone) (DNSZoneState, error) {
 	return NewDNSZoneState(dnssets), nil
 }
 
+// GetDNSSetCount returns the number of DNS sets of the given zone.
+func (m *InMemory) GetDNSSetCount(zoneID dns.ZoneID) int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.propagate()
+	return len(m.zones[zoneID].dnssets)
+}
+
 func (m *InMemory) SetZone(zone DNSHostedZone, zoneState DNSZoneState) {
 	clone := zoneState.GetDNSSets().Clone()
 
@@ -115,14 +164,60 @@ func (m *InMemory) Apply(zoneID dns.ZoneID, request *ChangeRequest, metrics Metr
 	}
 
 	name, rset := buildRecordSet(request)
+	var routingPolicy *dns.RoutingPolicy
 	switch request.Action {
 	case R_CREATE, R_UPDATE:
-		data.dnssets.AddRecordSet(name, request.Addition.RoutingPolicy, rset)
+		routingPolicy = request.Addition.RoutingPolicy
 		metrics.AddZoneRequests(zoneID.ID, M_UPDATERECORDS, 1)
 	case R_DELETE:
-		data.dnssets.RemoveRecordSet(name, rset.Type)
 		metrics.AddZoneRequests(zoneID.ID, M_DELETERECORDS, 1)
 	}
+	if m.propagationDelay > 0 {
+		m.pending = append(m.pending, &inMemoryPendingChange{
+			visibleAt:     m.now().Add(m.propagationDelay),
+			zoneID:        zoneID,
+			action:        request.Action,
+			name:          name,
+			routingPolicy: routingPolicy,
+			rset:          rset.Clone(),
+		})
+		return nil
+	}
+	applyRecordSet(data.dnssets, request.Action, name, routingPolicy, rset.Clone())
+	return nil
+}
+
+func applyRecordSet(dnssets dns.DNSSets, action string, name dns.DNSSetName, routingPolicy *dns.RoutingPolicy, rset *dns.RecordSet) {
+	switch action {
+	case R_CREATE, R_UPDATE:
+		dnssets.AddRecordSet(name, routingPolicy, rset)
+	case R_DELETE:
+		dnssets.RemoveRecordSet(name, rset.Type)
+	}
+}
+
+// ApplyZoneStateChanges replaces the record sets of the changed DNS names and record types of the zone.
+func (m *InMemory) ApplyZoneStateChanges(zoneID dns.ZoneID, changes []ZoneStateChange) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	data, ok := m.zones[zoneID]
+	if !ok {
+		return fmt.Errorf("DNSZone %s not hosted", zoneID)
+	}
+
+	for _, change := range changes {
+		for name := range data.dnssets {
+			if name.DNSName == change.DNSName {
+				data.dnssets.RemoveRecordSet(name, change.Type)
+			}
+		}
+		for name, set := range change.DNSSets {
+			if rs := set.Sets[change.Type]; rs != nil {
+				data.dnssets.AddRecordSet(name, set.RoutingPolicy, rs.Clone())
+			}
+		}
+	}
 	return nil
 }
 
@@ -198,6 +293,7 @@ func (m *InMemory) BuildFullDump() *FullDump {
 	m.lock.Lock()
 	defer m.lock.Unlock()
 
+	m.propagate()
 	all := FullDump{InMemory: map[dns.ZoneID]*ZoneDump{}}
 
 	for zoneId := range m.zones {
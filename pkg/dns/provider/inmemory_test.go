@@ -0,0 +1,156 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"time"
+
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+var _ = ginkgov2.Describe("InMemory", func() {
+	ginkgov2.It("stores and dumps CAA records", func() {
+		m := NewInMemory()
+		zone := NewDNSHostedZone("mock", "z1", "example.com", "", false)
+		Expect(m.AddZone(zone)).To(BeTrue())
+
+		set := dns.NewDNSSet(dns.DNSSetName{DNSName: "example.com"}, nil)
+		set.SetRecordSet(dns.RS_CAA, 300, "0 issue letsencrypt.org", `128 ISSUEWILD ";"`)
+		req := NewChangeRequest(R_CREATE, dns.RS_CAA, nil, set, nil)
+		Expect(m.Apply(zone.Id(), req, &NullMetrics{})).To(Succeed())
+
+		state, err := m.CloneZoneState(zone)
+		Expect(err).NotTo(HaveOccurred())
+		rs := state.GetDNSSets()[dns.DNSSetName{DNSName: "example.com"}].Sets[dns.RS_CAA]
+		Expect(rs.Records).To(ConsistOf(
+			&dns.Record{Value: `0 issue "letsencrypt.org"`},
+			&dns.Record{Value: `128 issuewild ";"`},
+		))
+
+		dump := m.BuildFullDump().ToYAMLString()
+		Expect(dump).To(ContainSubstring(`0 issue "letsencrypt.org"`))
+
+		req = NewChangeRequest(R_DELETE, dns.RS_CAA, set, nil, nil)
+		Expect(m.Apply(zone.Id(), req, &NullMetrics{})).To(Succeed())
+		state, err = m.CloneZoneState(zone)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state.GetDNSSets()).To(BeEmpty())
+	})
+
+	ginkgov2.It("stores NS records for delegation", func() {
+		m := NewInMemory()
+		zone := NewDNSHostedZone("mock", "z1", "example.com", "", false)
+		Expect(m.AddZone(zone)).To(BeTrue())
+
+		name := dns.DNSSetName{DNSName: "sub.example.com"}
+		set := dns.NewDNSSet(name, nil)
+		set.SetRecordSet(dns.RS_NS, 300, "ns-1.example.org", "ns-2.example.org.")
+		req := NewChangeRequest(R_CREATE, dns.RS_NS, nil, set, nil)
+		Expect(m.Apply(zone.Id(), req, &NullMetrics{})).To(Succeed())
+
+		state, err := m.CloneZoneState(zone)
+		Expect(err).NotTo(HaveOccurred())
+		rs := state.GetDNSSets()[name].Sets[dns.RS_NS]
+		Expect(rs.Records).To(ConsistOf(
+			&dns.Record{Value: "ns-1.example.org"},
+			&dns.Record{Value: "ns-2.example.org"},
+		))
+	})
+
+	ginkgov2.It("stores PTR records of reverse zones", func() {
+		m := NewInMemory()
+		zone := NewDNSHostedZone("mock", "z1", "2.1.in-addr.arpa", "", false)
+		Expect(m.AddZone(zone)).To(BeTrue())
+
+		name := dns.DNSSetName{DNSName: "4.3.2.1.in-addr.arpa"}
+		set := dns.NewDNSSet(name, nil)
+		set.SetRecordSet(dns.RS_PTR, 300, "host.example.com.")
+		req := NewChangeRequest(R_CREATE, dns.RS_PTR, nil, set, nil)
+		Expect(m.Apply(zone.Id(), req, &NullMetrics{})).To(Succeed())
+
+		state, err := m.CloneZoneState(zone)
+		Expect(err).NotTo(HaveOccurred())
+		rs := state.GetDNSSets()[name].Sets[dns.RS_PTR]
+		Expect(rs.Records).To(ConsistOf(&dns.Record{Value: "host.example.com"}))
+	})
+
+	ginkgov2.It("stores MX records", func() {
+		m := NewInMemory()
+		zone := NewDNSHostedZone("mock", "z1", "example.com", "", false)
+		Expect(m.AddZone(zone)).To(BeTrue())
+
+		name := dns.DNSSetName{DNSName: "example.com"}
+		set := dns.NewDNSSet(name, nil)
+		set.SetRecordSet(dns.RS_MX, 300, "10 mx1.example.com.", " 20  mx2.example.com")
+		req := NewChangeRequest(R_CREATE, dns.RS_MX, nil, set, nil)
+		Expect(m.Apply(zone.Id(), req, &NullMetrics{})).To(Succeed())
+
+		state, err := m.CloneZoneState(zone)
+		Expect(err).NotTo(HaveOccurred())
+		rs := state.GetDNSSets()[name].Sets[dns.RS_MX]
+		Expect(rs.Records).To(ConsistOf(
+			&dns.Record{Value: "10 mx1.example.com"},
+			&dns.Record{Value: "20 mx2.example.com"},
+		))
+	})
+
+	ginkgov2.It("round-trips DS records", func() {
+		m := NewInMemory()
+		zone := NewDNSHostedZone("mock", "z1", "example.com", "", false)
+		Expect(m.AddZone(zone)).To(BeTrue())
+
+		name := dns.DNSSetName{DNSName: "child.example.com"}
+		set := dns.NewDNSSet(name, nil)
+		set.SetRecordSet(dns.RS_DS, 300, "60485 5 1 2bb183af5f22588179a53b0a 98631fad1a292118")
+		req := NewChangeRequest(R_CREATE, dns.RS_DS, nil, set, nil)
+		Expect(m.Apply(zone.Id(), req, &NullMetrics{})).To(Succeed())
+
+		state, err := m.CloneZoneState(zone)
+		Expect(err).NotTo(HaveOccurred())
+		rs := state.GetDNSSets()[name].Sets[dns.RS_DS]
+		Expect(rs.Records).To(ConsistOf(&dns.Record{Value: "60485 5 1 2BB183AF5F22588179A53B0A98631FAD1A292118"}))
+
+		req = NewChangeRequest(R_DELETE, dns.RS_DS, set, nil, nil)
+		Expect(m.Apply(zone.Id(), req, &NullMetrics{})).To(Succeed())
+		state, err = m.CloneZoneState(zone)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state.GetDNSSets()).To(BeEmpty())
+	})
+
+	ginkgov2.It("makes changes visible after the propagation delay", func() {
+		m := NewInMemory()
+		now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		m.now = func() time.Time { return now }
+		m.SetPropagationDelay(30 * time.Second)
+		zone := NewDNSHostedZone("mock", "z1", "example.com", "", false)
+		Expect(m.AddZone(zone)).To(BeTrue())
+
+		name := dns.DNSSetName{DNSName: "a.example.com"}
+		set := dns.NewDNSSet(name, nil)
+		set.SetRecordSet(dns.RS_A, 300, "1.2.3.4")
+		req := NewChangeRequest(R_CREATE, dns.RS_A, nil, set, nil)
+		Expect(m.Apply(zone.Id(), req, &NullMetrics{})).To(Succeed())
+
+		state, err := m.CloneZoneState(zone)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state.GetDNSSets()).To(BeEmpty())
+		Expect(m.GetDNSSetCount(zone.Id())).To(Equal(0))
+
+		now = now.Add(30 * time.Second)
+		state, err = m.CloneZoneState(zone)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state.GetDNSSets()[name].Sets[dns.RS_A].Records).To(ConsistOf(&dns.Record{Value: "1.2.3.4"}))
+
+		req = NewChangeRequest(R_DELETE, dns.RS_A, set, nil, nil)
+		Expect(m.Apply(zone.Id(), req, &NullMetrics{})).To(Succeed())
+		now = now.Add(10 * time.Second)
+		Expect(m.GetDNSSetCount(zone.Id())).To(Equal(1))
+		now = now.Add(20 * time.Second)
+		Expect(m.GetDNSSetCount(zone.Id())).To(Equal(0))
+	})
+})
@@ -7,6 +7,11 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gardener/controller-manager-library/pkg/config"
@@ -24,18 +29,74 @@ import (
 
 type Config struct {
 	TTL                      int64
+	MinTTL                   int64 // minimum effective TTL of DNS entries (no minimum if 0)
+	MaxTTL                   int64 // maximum effective TTL of DNS entries (no maximum if 0)
 	CacheTTL                 time.Duration
 	RescheduleDelay          time.Duration
 	StatusCheckPeriod        time.Duration
+	LookupNegativeCacheTTL   time.Duration
+	LookupNameservers        []string // name servers (ip:port) to resolve CNAME targets (system resolver if empty)
+	ProviderProbeInterval    time.Duration
 	Ident                    string
 	Dryrun                   bool
 	ZoneStateCaching         bool
 	DisableDNSNameValidation bool
-	Delay                    time.Duration
-	EnabledTypes             utils.StringSet
-	Options                  *FactoryOptions
-	Factory                  DNSHandlerFactory
-	RemoteAccessConfig       *embed.RemoteAccessServerConfig
+	MaxTargets               int
+	MaxCNAMETargets          int
+	// ZoneRecordSetLimit is the default maximum number of record sets per hosted zone (unlimited if 0).
+	ZoneRecordSetLimit  int
+	LogStateTransitions bool
+	// ZoneAuditInterval is the interval of the periodic zone audit (disabled if 0).
+	ZoneAuditInterval time.Duration
+	// ZoneAuditMode is the mode of the zone audit (ZoneAuditModeReport or ZoneAuditModeHeal).
+	ZoneAuditMode string
+	// InitialZoneCacheTimeout is the maximum time to wait for the first zone listing of all providers on startup (disabled if 0).
+	InitialZoneCacheTimeout time.Duration
+	CNameLookupInterval     time.Duration
+	CNameLookupMinInterval  time.Duration
+	CNameLookupTTLDivisor   int
+	PropagationVerification bool
+	PropagationTimeout      time.Duration
+	PropagationInterval     time.Duration
+	// TargetRefs enables the target references of DNS entries to Services and Ingresses.
+	TargetRefs bool
+	// EntryReconcileRateLimiter limits the DNS entry reconciliations. It is nil if unlimited.
+	EntryReconcileRateLimiter *RateLimiterConfig
+	Delay                     time.Duration
+	EnabledTypes              utils.StringSet
+	// CircuitBreakerThreshold is the number of consecutive backend failures opening the circuit breaker of an account (disabled if 0).
+	CircuitBreakerThreshold int
+	// CircuitBreakerCoolDown is the period backend requests are rejected by an open circuit breaker.
+	CircuitBreakerCoolDown time.Duration
+	// EntryStatusMinInterval is the minimum interval between two status updates of a DNS entry without state transition (disabled if 0).
+	EntryStatusMinInterval time.Duration
+	// EntryReconcileMinInterval is the minimum of the reconcile intervals of DNS entries given by annotation.
+	EntryReconcileMinInterval time.Duration
+	// DrainTimeout is the maximum time to wait on shutdown for in-flight reconciliations to finish (disabled if 0).
+	DrainTimeout time.Duration
+	// LookupDedupWindow is the window for sharing lookup results of the same hostname between entries (disabled if 0).
+	LookupDedupWindow time.Duration
+	// EntryEventWindow is the window in which identical events of a DNS entry are suppressed (disabled if 0).
+	EntryEventWindow time.Duration
+	// OwnerConflict configures the resolution of conflicts between controller instances using the same owner id.
+	// It is nil if disabled.
+	OwnerConflict *OwnerConflictConfig
+	// ProviderTypeDefaults contains the defaults for DNS providers per provider type.
+	ProviderTypeDefaults ProviderTypeDefaultsMap
+	Options              *FactoryOptions
+	Factory              DNSHandlerFactory
+	RemoteAccessConfig   *embed.RemoteAccessServerConfig
+	// TracingEndpoint is the OTLP/HTTP endpoint URL for exporting tracing spans (disabled if empty).
+	TracingEndpoint string
+	// CredentialsDir is the directory containing the mounted credentials referenced by DNS providers (disabled if empty).
+	CredentialsDir string
+	// OwnershipRecordOwnerID is the owner id of the ownership records written in the format of external-dns (disabled if empty).
+	OwnershipRecordOwnerID string
+	// EntryNamespaceFilter restricts the namespaces of the handled DNS entries. It is nil if all namespaces are handled.
+	EntryNamespaceFilter *NamespaceFilter
+	// ProviderTieBreaker selects the provider if several providers with same priority match a DNS entry equally
+	// (ProviderTieBreakerName or ProviderTieBreakerCreationTime).
+	ProviderTieBreaker string
 }
 
 func NewConfigForController(c controller.Interface, factory DNSHandlerFactory) (*Config, error) {
@@ -47,6 +108,11 @@ func NewConfigForController(c controller.Interface, factory DNSHandlerFactory) (
 	if err != nil {
 		ttl = 300
 	}
+	minTTL, _ := c.GetIntOption(OPT_MIN_TTL)
+	maxTTL, _ := c.GetIntOption(OPT_MAX_TTL)
+	if minTTL < 0 || maxTTL < 0 || (maxTTL > 0 && minTTL > maxTTL) {
+		return nil, fmt.Errorf("invalid TTL range: options %s (%d) and %s (%d) must not be negative and %s must not be greater than %s", OPT_MIN_TTL, minTTL, OPT_MAX_TTL, maxTTL, OPT_MIN_TTL, OPT_MAX_TTL)
+	}
 	cttl, err := c.GetIntOption(OPT_CACHE_TTL)
 	if err != nil {
 		cttl = 60
@@ -66,6 +132,23 @@ func NewConfigForController(c controller.Interface, factory DNSHandlerFactory) (
 	if err != nil {
 		statuscheckperiod = 120 * time.Second
 	}
+	lookupNegativeCacheTTL, err := c.GetDurationOption(OPT_LOOKUP_NEGATIVE_CACHE_TTL)
+	if err != nil {
+		lookupNegativeCacheTTL = 30 * time.Second
+	}
+	lookupDedupWindow, err := c.GetDurationOption(OPT_LOOKUP_DEDUP_WINDOW)
+	if err != nil {
+		lookupDedupWindow = 2 * time.Second
+	}
+	lookupNameservers, err := c.GetStringOption(OPT_LOOKUP_NAMESERVERS)
+	if err != nil {
+		lookupNameservers = ""
+	}
+	nameservers, err := parseLookupNameservers(lookupNameservers)
+	if err != nil {
+		return nil, fmt.Errorf("invalid option %s: %w", OPT_LOOKUP_NAMESERVERS, err)
+	}
+	providerProbeInterval, _ := c.GetDurationOption(OPT_PROVIDER_PROBE_INTERVAL)
 
 	RemoteAccessClientID, err = c.GetStringOption(OPT_REMOTE_ACCESS_CLIENT_ID)
 	if err != nil {
@@ -77,8 +160,125 @@ func NewConfigForController(c controller.Interface, factory DNSHandlerFactory) (
 		return nil, err
 	}
 
+	tracingEndpoint, _ := c.GetStringOption(OPT_TRACING_ENDPOINT)
+
 	disableZoneStateCaching, _ := c.GetBoolOption(OPT_DISABLE_ZONE_STATE_CACHING)
 	disableDNSNameValidation, _ := c.GetBoolOption(OPT_DISABLE_DNSNAME_VALIDATION)
+	maxTargets, _ := c.GetIntOption(OPT_MAX_TARGETS)
+	if maxTargets < 0 {
+		return nil, fmt.Errorf("invalid value %d for option %s: must not be negative", maxTargets, OPT_MAX_TARGETS)
+	}
+	zoneRecordSetLimit, _ := c.GetIntOption(OPT_ZONE_RECORD_SET_LIMIT)
+	if zoneRecordSetLimit < 0 {
+		return nil, fmt.Errorf("invalid value %d for option %s: must not be negative", zoneRecordSetLimit, OPT_ZONE_RECORD_SET_LIMIT)
+	}
+	maxCNAMETargetsOpt, err := c.GetIntOption(OPT_MAX_CNAME_TARGETS)
+	if err != nil {
+		maxCNAMETargetsOpt = maxCNAMETargets
+	}
+	if maxCNAMETargetsOpt <= 0 || maxCNAMETargetsOpt > maxCNAMETargets {
+		return nil, fmt.Errorf("invalid value %d for option %s: must be between 1 and %d", maxCNAMETargetsOpt, OPT_MAX_CNAME_TARGETS, maxCNAMETargets)
+	}
+
+	logStateTransitions, _ := c.GetBoolOption(OPT_LOG_STATE_TRANSITIONS)
+	zoneAuditInterval, _ := c.GetDurationOption(OPT_ZONE_AUDIT_INTERVAL)
+	if zoneAuditInterval < 0 {
+		return nil, fmt.Errorf("invalid value %v for option %s: must not be negative", zoneAuditInterval, OPT_ZONE_AUDIT_INTERVAL)
+	}
+	zoneAuditMode, err := c.GetStringOption(OPT_ZONE_AUDIT_MODE)
+	if err != nil || zoneAuditMode == "" {
+		zoneAuditMode = ZoneAuditModeReport
+	}
+	if zoneAuditMode != ZoneAuditModeReport && zoneAuditMode != ZoneAuditModeHeal {
+		return nil, fmt.Errorf("invalid value %q for option %s (allowed values: %s, %s)", zoneAuditMode, OPT_ZONE_AUDIT_MODE, ZoneAuditModeReport, ZoneAuditModeHeal)
+	}
+	circuitBreakerThreshold, _ := c.GetIntOption(OPT_CIRCUIT_BREAKER_THRESHOLD)
+	if circuitBreakerThreshold < 0 {
+		return nil, fmt.Errorf("invalid value %d for option %s: must not be negative", circuitBreakerThreshold, OPT_CIRCUIT_BREAKER_THRESHOLD)
+	}
+	circuitBreakerCoolDown, err := c.GetDurationOption(OPT_CIRCUIT_BREAKER_COOLDOWN)
+	if err != nil {
+		circuitBreakerCoolDown = 5 * time.Minute
+	}
+	if circuitBreakerThreshold > 0 && circuitBreakerCoolDown <= 0 {
+		return nil, fmt.Errorf("invalid value %v for option %s: must be positive", circuitBreakerCoolDown, OPT_CIRCUIT_BREAKER_COOLDOWN)
+	}
+	entryStatusMinInterval, _ := c.GetDurationOption(OPT_ENTRY_STATUS_INTERVAL)
+	if entryStatusMinInterval < 0 {
+		return nil, fmt.Errorf("invalid value %v for option %s: must not be negative", entryStatusMinInterval, OPT_ENTRY_STATUS_INTERVAL)
+	}
+	entryEventWindow, _ := c.GetDurationOption(OPT_ENTRY_EVENT_WINDOW)
+	if entryEventWindow < 0 {
+		return nil, fmt.Errorf("invalid value %v for option %s: must not be negative", entryEventWindow, OPT_ENTRY_EVENT_WINDOW)
+	}
+	entryReconcileMinInterval, err := c.GetDurationOption(OPT_ENTRY_RECONCILE_MIN_INTERVAL)
+	if err != nil {
+		entryReconcileMinInterval = defaultEntryReconcileMinInterval
+	}
+	if entryReconcileMinInterval < entryReconcileIntervalFloor {
+		return nil, fmt.Errorf("invalid option %s (%v): must be at least %v to protect the DNS backends", OPT_ENTRY_RECONCILE_MIN_INTERVAL, entryReconcileMinInterval, entryReconcileIntervalFloor)
+	}
+	drainTimeout, _ := c.GetDurationOption(OPT_DRAIN_TIMEOUT)
+	if drainTimeout < 0 {
+		return nil, fmt.Errorf("invalid value %v for option %s: must not be negative", drainTimeout, OPT_DRAIN_TIMEOUT)
+	}
+	initialZoneCacheTimeout, _ := c.GetDurationOption(OPT_INITIAL_ZONE_CACHE_TIMEOUT)
+	if initialZoneCacheTimeout < 0 {
+		return nil, fmt.Errorf("invalid value %v for option %s: must not be negative", initialZoneCacheTimeout, OPT_INITIAL_ZONE_CACHE_TIMEOUT)
+	}
+	cnameLookupInterval, err := c.GetDurationOption(OPT_CNAME_LOOKUP_INTERVAL)
+	if err != nil {
+		cnameLookupInterval = defaultCNameLookupInterval
+	}
+	cnameLookupMinInterval, err := c.GetDurationOption(OPT_CNAME_LOOKUP_MIN_INTERVAL)
+	if err != nil {
+		cnameLookupMinInterval = defaultCNameLookupMinInterval
+	}
+	if cnameLookupMinInterval < cnameLookupIntervalFloor {
+		return nil, fmt.Errorf("invalid option %s (%v): must be at least %v to protect upstream resolvers", OPT_CNAME_LOOKUP_MIN_INTERVAL, cnameLookupMinInterval, cnameLookupIntervalFloor)
+	}
+	if cnameLookupInterval < cnameLookupMinInterval {
+		return nil, fmt.Errorf("invalid option %s (%v): must not be smaller than %s (%v)", OPT_CNAME_LOOKUP_INTERVAL, cnameLookupInterval, OPT_CNAME_LOOKUP_MIN_INTERVAL, cnameLookupMinInterval)
+	}
+	cnameLookupTTLDivisor, err := c.GetIntOption(OPT_CNAME_LOOKUP_TTL_DIVISOR)
+	if err != nil {
+		cnameLookupTTLDivisor = defaultCNameLookupTTLDivisor
+	}
+	if cnameLookupTTLDivisor < 1 {
+		return nil, fmt.Errorf("invalid option %s (%d): must be positive", OPT_CNAME_LOOKUP_TTL_DIVISOR, cnameLookupTTLDivisor)
+	}
+	propagationVerification, _ := c.GetBoolOption(OPT_PROPAGATION_VERIFICATION)
+	targetRefs, _ := c.GetBoolOption(OPT_TARGET_REFS)
+	propagationTimeout, err := c.GetDurationOption(OPT_PROPAGATION_VERIFICATION_TIMEOUT)
+	if err != nil {
+		propagationTimeout = 5 * time.Minute
+	}
+	propagationInterval, err := c.GetDurationOption(OPT_PROPAGATION_VERIFICATION_INTERVAL)
+	if err != nil {
+		propagationInterval = 15 * time.Second
+	}
+	if propagationVerification && (propagationTimeout <= 0 || propagationInterval <= 0) {
+		return nil, fmt.Errorf("invalid propagation verification: options %s and %s must be positive", OPT_PROPAGATION_VERIFICATION_TIMEOUT, OPT_PROPAGATION_VERIFICATION_INTERVAL)
+	}
+
+	var entryReconcileRateLimiter *RateLimiterConfig
+	entryReconcileQPS := 0.0
+	if qps, _ := c.GetStringOption(OPT_ENTRY_RECONCILE_QPS); qps != "" {
+		entryReconcileQPS, err = strconv.ParseFloat(qps, 32)
+		if err != nil || entryReconcileQPS < 0 {
+			return nil, fmt.Errorf("invalid option %s (%s): must be a non-negative number", OPT_ENTRY_RECONCILE_QPS, qps)
+		}
+	}
+	if entryReconcileQPS > 0 {
+		entryReconcileBurst, err := c.GetIntOption(OPT_ENTRY_RECONCILE_BURST)
+		if err != nil {
+			entryReconcileBurst = 10
+		}
+		entryReconcileRateLimiter = &RateLimiterConfig{QPS: float32(entryReconcileQPS), Burst: entryReconcileBurst}
+		if _, err := entryReconcileRateLimiter.NewRateLimiter(); err != nil {
+			return nil, fmt.Errorf("invalid entry reconcile rate limiter (options %s and %s): %w", OPT_ENTRY_RECONCILE_QPS, OPT_ENTRY_RECONCILE_BURST, err)
+		}
+	}
 
 	enabled := utils.StringSet{}
 	types, err := c.GetStringOption(OPT_PROVIDERTYPES)
@@ -98,23 +298,101 @@ func NewConfigForController(c controller.Interface, factory DNSHandlerFactory) (
 		}
 	}
 
+	var typeDefaults ProviderTypeDefaultsMap
+	if filename, _ := c.GetStringOption(OPT_PROVIDERTYPE_DEFAULTS); filename != "" {
+		typeDefaults, err = LoadProviderTypeDefaults(filename, factory.TypeCodes())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	credentialsDir, _ := c.GetStringOption(OPT_PROVIDER_CREDENTIALS_DIR)
+	if credentialsDir != "" {
+		if info, err := os.Stat(credentialsDir); err != nil || !info.IsDir() {
+			return nil, fmt.Errorf("invalid value %q for option %s: not a directory", credentialsDir, OPT_PROVIDER_CREDENTIALS_DIR)
+		}
+	}
+
+	ownershipRecordOwnerID, _ := c.GetStringOption(OPT_OWNERSHIP_RECORD_OWNER_ID)
+	if strings.ContainsAny(ownershipRecordOwnerID, ",=\" \t") {
+		return nil, fmt.Errorf("invalid value %q for option %s: must not contain commas, equal signs, quotes, or whitespace", ownershipRecordOwnerID, OPT_OWNERSHIP_RECORD_OWNER_ID)
+	}
+
+	entryNamespaces, _ := c.GetStringOption(OPT_ENTRY_NAMESPACES)
+	excludedEntryNamespaces, _ := c.GetStringOption(OPT_EXCLUDED_ENTRY_NAMESPACES)
+	entryNamespaceFilter, err := NewNamespaceFilter(entryNamespaces, excludedEntryNamespaces)
+	if err != nil {
+		return nil, fmt.Errorf("invalid options %s and %s: %w", OPT_ENTRY_NAMESPACES, OPT_EXCLUDED_ENTRY_NAMESPACES, err)
+	}
+
+	providerTieBreaker, err := c.GetStringOption(OPT_PROVIDER_TIE_BREAKER)
+	if err != nil || providerTieBreaker == "" {
+		providerTieBreaker = ProviderTieBreakerName
+	}
+	if providerTieBreaker != ProviderTieBreakerName && providerTieBreaker != ProviderTieBreakerCreationTime {
+		return nil, fmt.Errorf("invalid value %q for option %s (allowed values: %s, %s)", providerTieBreaker, OPT_PROVIDER_TIE_BREAKER, ProviderTieBreakerName, ProviderTieBreakerCreationTime)
+	}
+
+	ownerConflictStrategy, _ := c.GetStringOption(OPT_OWNER_CONFLICT_STRATEGY)
+	ownerConflictInstance, _ := c.GetStringOption(OPT_OWNER_CONFLICT_INSTANCE)
+	ownerConflictPriority, _ := c.GetIntOption(OPT_OWNER_CONFLICT_PRIORITY)
+	ownerConflictLease, _ := c.GetDurationOption(OPT_OWNER_CONFLICT_LEASE)
+	ownerConflict, err := newOwnerConflictConfig(ownerConflictStrategy, ownerConflictInstance, ownerConflictPriority, ownerConflictLease)
+	if err != nil {
+		return nil, err
+	}
+
 	osrc, _ := c.GetOptionSource(FACTORY_OPTIONS)
 	fopts := GetFactoryOptions(osrc)
 
 	return &Config{
-		Ident:                    ident,
-		TTL:                      int64(ttl),
-		CacheTTL:                 time.Duration(cttl) * time.Second,
-		RescheduleDelay:          rescheduleDelay,
-		StatusCheckPeriod:        statuscheckperiod,
-		Dryrun:                   dryrun,
-		ZoneStateCaching:         !disableZoneStateCaching,
-		DisableDNSNameValidation: disableDNSNameValidation,
-		Delay:                    delay,
-		EnabledTypes:             enabled,
-		Options:                  fopts,
-		Factory:                  factory,
-		RemoteAccessConfig:       remoteAccessConfig,
+		Ident:                     ident,
+		TTL:                       int64(ttl),
+		MinTTL:                    int64(minTTL),
+		MaxTTL:                    int64(maxTTL),
+		CacheTTL:                  time.Duration(cttl) * time.Second,
+		RescheduleDelay:           rescheduleDelay,
+		StatusCheckPeriod:         statuscheckperiod,
+		LookupNegativeCacheTTL:    lookupNegativeCacheTTL,
+		LookupDedupWindow:         lookupDedupWindow,
+		EntryEventWindow:          entryEventWindow,
+		OwnerConflict:             ownerConflict,
+		LookupNameservers:         nameservers,
+		ProviderProbeInterval:     providerProbeInterval,
+		Dryrun:                    dryrun,
+		ZoneStateCaching:          !disableZoneStateCaching,
+		DisableDNSNameValidation:  disableDNSNameValidation,
+		MaxTargets:                maxTargets,
+		MaxCNAMETargets:           maxCNAMETargetsOpt,
+		ZoneRecordSetLimit:        zoneRecordSetLimit,
+		LogStateTransitions:       logStateTransitions,
+		ZoneAuditInterval:         zoneAuditInterval,
+		ZoneAuditMode:             zoneAuditMode,
+		InitialZoneCacheTimeout:   initialZoneCacheTimeout,
+		CNameLookupInterval:       cnameLookupInterval,
+		CNameLookupMinInterval:    cnameLookupMinInterval,
+		CNameLookupTTLDivisor:     cnameLookupTTLDivisor,
+		PropagationVerification:   propagationVerification,
+		TargetRefs:                targetRefs,
+		PropagationTimeout:        propagationTimeout,
+		PropagationInterval:       propagationInterval,
+		EntryReconcileRateLimiter: entryReconcileRateLimiter,
+		EntryStatusMinInterval:    entryStatusMinInterval,
+		EntryReconcileMinInterval: entryReconcileMinInterval,
+		DrainTimeout:              drainTimeout,
+		CircuitBreakerThreshold:   circuitBreakerThreshold,
+		CircuitBreakerCoolDown:    circuitBreakerCoolDown,
+		Delay:                     delay,
+		EnabledTypes:              enabled,
+		ProviderTypeDefaults:      typeDefaults,
+		Options:                   fopts,
+		Factory:                   factory,
+		RemoteAccessConfig:        remoteAccessConfig,
+		TracingEndpoint:           tracingEndpoint,
+		CredentialsDir:            credentialsDir,
+		OwnershipRecordOwnerID:    ownershipRecordOwnerID,
+		EntryNamespaceFilter:      entryNamespaceFilter,
+		ProviderTieBreaker:        providerTieBreaker,
 	}, nil
 }
 
@@ -155,6 +433,11 @@ type DNSHandlerConfig struct {
 	Options          *FactoryOptions
 	Metrics          Metrics
 	RateLimiter      flowcontrol.RateLimiter
+	// ProxyURL is the HTTP(S) proxy given in the provider secret (see ProxySecretKey).
+	ProxyURL *url.URL
+	// ZoneTags is set if zones are selected by tags. Handlers fetching the tags with extra requests
+	// only need to provide them in this case.
+	ZoneTags bool
 }
 
 type DNSZoneState interface {
@@ -165,9 +448,14 @@ const (
 	M_LISTZONES  = "list_zones"
 	M_PLISTZONES = "list_zones_pages"
 
+	M_LISTZONETAGS = "list_zone_tags"
+
 	M_LISTRECORDS  = "list_records"
 	M_PLISTRECORDS = "list_records_pages"
 
+	M_LISTCHANGES  = "list_changes"
+	M_PLISTCHANGES = "list_changes_pages"
+
 	M_UPDATERECORDS = "update_records"
 	M_PUPDATEREORDS = "update_records_pages"
 
@@ -197,6 +485,80 @@ type DNSHandler interface {
 	Release()
 }
 
+// RoutingPolicyNormalizer is optionally implemented by DNS handlers supporting alternative parameter values for
+// routing policies. The routing policy of an entry is mapped to the form read back from the DNS backend.
+type RoutingPolicyNormalizer interface {
+	NormalizeRoutingPolicy(policy *dns.RoutingPolicy) *dns.RoutingPolicy
+}
+
+// ProviderAttributesNormalizer is optionally implemented by DNS handlers adding provider attributes configured
+// on provider level to the records. The provider attributes of an entry are mapped to the complete set of attributes
+// of the records read back from the DNS backend, so that attributes dropped from the entry are removed from the records.
+type ProviderAttributesNormalizer interface {
+	NormalizeProviderAttributes(attrs map[string]string) map[string]string
+}
+
+// DeniedZonesReporter is optionally implemented by DNS handlers detecting hosted zones of the account
+// the credentials grant no access to, either by skipping them on listing or by tracking failed reads of
+// their records (see DeniedZonesTracker). The ids of these zones are reported as problematic zones of the provider.
+type DeniedZonesReporter interface {
+	DeniedZones() []string
+}
+
+// TXTRecordLimiter is optionally implemented by DNS handlers restricting the length of TXT record values.
+// If a chunk size is given, texts are passed to the handler split into multiple quoted character strings
+// and values read back are reassembled for comparison.
+type TXTRecordLimiter interface {
+	TXTRecordLimits() dns.TXTRecordLimits
+}
+
+// WeightScaler is optionally implemented by DNS handlers supporting weighted routing policies with a weight
+// scale different from dns.DefaultMaxWeight. Percentage weights are normalized to this scale.
+type WeightScaler interface {
+	MaxWeight() int64
+}
+
+// NameFormer is optionally implemented by DNS handlers of DNS backends expecting DNS names in a dedicated form,
+// e.g. fully qualified with trailing dot. Names are kept in canonical form internally (see dns.CanonicalHostname).
+type NameFormer interface {
+	NameForm() dns.NameForm
+}
+
+// TTLLimiter is optionally implemented by DNS handlers of DNS backends rejecting TTLs below a minimum.
+// Lower TTLs of entries are raised to this minimum.
+type TTLLimiter interface {
+	MinTTL() int64
+}
+
+// ApexAliasSupporter is optionally implemented by DNS handlers of DNS backends supporting a CNAME target
+// at the zone apex natively, e.g. by alias records or CNAME flattening.
+// Otherwise CNAME targets at the zone apex are resolved to addresses.
+type ApexAliasSupporter interface {
+	SupportsApexAlias(target Target) bool
+}
+
+// CapabilitiesReporter is optionally implemented by DNS handlers of DNS backends supporting optional record types
+// or requiring the apex prefix for DNS names at the zone apex (see Capabilities).
+type CapabilitiesReporter interface {
+	Capabilities() Capabilities
+}
+
+// OptionalRecordTypes contains the record types which are only supported by DNS backends declaring them in their capabilities.
+var OptionalRecordTypes = utils.NewStringSet(dns.RS_NS, dns.RS_DS, dns.RS_PTR, dns.RS_MX)
+
+// Capabilities describes the capabilities of a DNS backend which differ between the DNS backends.
+type Capabilities struct {
+	// RecordTypes contains the supported optional record types (see OptionalRecordTypes).
+	RecordTypes []string
+	// ApexPrefix is set if DNS names at the zone apex must be given with the apex prefix '@.'.
+	ApexPrefix bool
+}
+
+// SupportsRecordType returns true if the record type is no optional record type or declared as supported.
+func (c Capabilities) SupportsRecordType(rtype string) bool {
+	return !OptionalRecordTypes.Contains(rtype) || slices.Contains(c.RecordTypes, rtype)
+}
+
 type DefaultDNSHandler struct {
 	providerType string
 }
@@ -225,6 +587,10 @@ type DNSHandlerFactory interface {
 	Create(typecode string, config *DNSHandlerConfig) (DNSHandler, error)
 	IsResponsibleFor(object *dnsutils.DNSProviderObject) bool
 	SupportZoneStateCache(typecode string) (bool, error)
+	// SecretKeys returns the declared keys of the provider secrets of the provider type (nil if not declared).
+	SecretKeys(typecode string) (SecretKeys, error)
+	// ProviderAttributes returns the names of the supported provider specific record attributes of the provider type.
+	ProviderAttributes(typecode string) (utils.StringSet, error)
 }
 
 type DNSProviders map[resources.ObjectName]DNSProvider
@@ -247,10 +613,28 @@ type DNSProvider interface {
 
 	Match(dns string) int
 	MatchZone(dns string) int
+	// AcceptsRecordType returns true if the record type is included by the record type selection of the provider.
+	AcceptsRecordType(rtype string) bool
 	IsValid() bool
 
 	AccountHash() string
 	MapTargets(dnsName string, targets []Target) []Target
+	// NormalizeRoutingPolicy maps the routing policy to the form read back from the DNS backend.
+	NormalizeRoutingPolicy(policy *dns.RoutingPolicy) *dns.RoutingPolicy
+	// NormalizeProviderAttributes maps the provider attributes of an entry to the attributes read back from the DNS backend.
+	NormalizeProviderAttributes(attrs map[string]string) map[string]string
+	// TXTRecordLimits returns the restrictions of the DNS backend for TXT record values.
+	TXTRecordLimits() dns.TXTRecordLimits
+	// MaxWeight returns the maximum weight of weighted routing policies.
+	MaxWeight() int64
+	// MinTTL returns the minimum TTL accepted by the DNS backend (no minimum if 0).
+	MinTTL() int64
+	// NameForm returns the form of DNS names written to the DNS backend.
+	NameForm() dns.NameForm
+	// SupportsApexAlias returns true if the DNS backend supports the CNAME target at the zone apex natively.
+	SupportsApexAlias(target Target) bool
+	// Capabilities returns the capabilities of the DNS backend differing between the DNS backends.
+	Capabilities() Capabilities
 
 	// ReportZoneStateConflict is used to report a conflict because of stale data.
 	// It returns true if zone data will be updated and a retry may resolve the conflict
@@ -264,6 +648,29 @@ type DoneHandler interface {
 	Succeeded()
 }
 
+// DryRunDoneHandler is an optional extension of a DoneHandler
+// to report the planned changes of a change request in dry run mode.
+type DryRunDoneHandler interface {
+	DoneHandler
+	DryRun(planned string)
+}
+
+// WrittenDoneHandler is an optional extension of a DoneHandler to be informed
+// that a succeeding change request has actually been written to the DNS backend.
+// Written is called before Succeeded, which is also called for entries without changes.
+type WrittenDoneHandler interface {
+	DoneHandler
+	Written()
+}
+
+// DeferredDoneHandler is an optional extension of a DoneHandler to be informed
+// that the records are maintained by another controller instance. The change request
+// is postponed without failing until the lease of the writer expires.
+type DeferredDoneHandler interface {
+	DoneHandler
+	Deferred(err error)
+}
+
 type ProviderEventListener interface {
 	ProviderUpdatedEvent(logger logger.LogContext, name resources.ObjectName, annotations map[string]string, handler LightDNSHandler)
 	ProviderRemovedEvent(logger logger.LogContext, name resources.ObjectName)
@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/atomic"
+)
+
+const (
+	nameserverDialTimeout   = 5 * time.Second
+	nameserverLookupTimeout = 15 * time.Second
+)
+
+// parseLookupNameservers parses a comma separated list of name servers in the format `ip:port`.
+func parseLookupNameservers(value string) ([]string, error) {
+	var nameservers []string
+	for _, ns := range strings.Split(value, ",") {
+		ns = strings.TrimSpace(ns)
+		if ns == "" {
+			continue
+		}
+		host, port, err := net.SplitHostPort(ns)
+		if err != nil {
+			return nil, fmt.Errorf("invalid name server %q: %w", ns, err)
+		}
+		if net.ParseIP(host) == nil {
+			return nil, fmt.Errorf("invalid name server %q: %q is no IP address", ns, host)
+		}
+		if p, err := strconv.ParseUint(port, 10, 16); err != nil || p == 0 {
+			return nil, fmt.Errorf("invalid name server %q: invalid port %q", ns, port)
+		}
+		nameservers = append(nameservers, ns)
+	}
+	return nameservers, nil
+}
+
+// newNameserverLookup returns a lookup function for IP addresses querying the given name servers
+// instead of the ones of the system resolver configuration. The name servers are used in turn.
+// It returns nil if no name servers are given.
+func newNameserverLookup(nameservers []string) func(string) ([]net.IP, error) {
+	if len(nameservers) == 0 {
+		return nil
+	}
+	var next atomic.Uint32
+	resolver := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			ns := nameservers[int(next.Inc()-1)%len(nameservers)]
+			dialer := net.Dialer{Timeout: nameserverDialTimeout}
+			return dialer.DialContext(ctx, network, ns)
+		},
+	}
+	return func(hostname string) ([]net.IP, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), nameserverLookupTimeout)
+		defer cancel()
+		return resolver.LookupIP(ctx, "ip", hostname)
+	}
+}
@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"net"
+
+	miekgdns "github.com/miekg/dns"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = ginkgov2.Describe("Lookup name servers", func() {
+	ginkgov2.It("parses name server lists", func() {
+		Expect(parseLookupNameservers("")).To(BeEmpty())
+		Expect(parseLookupNameservers("8.8.8.8:53, [2001:4860:4860::8888]:53")).To(Equal([]string{"8.8.8.8:53", "[2001:4860:4860::8888]:53"}))
+	})
+
+	ginkgov2.It("rejects invalid name servers", func() {
+		_, err := parseLookupNameservers("8.8.8.8")
+		Expect(err).To(MatchError(ContainSubstring(`invalid name server "8.8.8.8"`)))
+		_, err = parseLookupNameservers("dns.google:53")
+		Expect(err).To(MatchError(ContainSubstring(`"dns.google" is no IP address`)))
+		_, err = parseLookupNameservers("8.8.8.8:0")
+		Expect(err).To(MatchError(ContainSubstring(`invalid port "0"`)))
+	})
+
+	ginkgov2.It("uses the system resolver if no name servers are given", func() {
+		Expect(newNameserverLookup(nil)).To(BeNil())
+	})
+
+	ginkgov2.It("resolves addresses with the given name server", func() {
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		handler := miekgdns.HandlerFunc(func(w miekgdns.ResponseWriter, req *miekgdns.Msg) {
+			msg := new(miekgdns.Msg)
+			msg.SetReply(req)
+			for _, q := range req.Question {
+				if q.Qtype == miekgdns.TypeA && q.Name == "public.example.com." {
+					rr, _ := miekgdns.NewRR("public.example.com. 60 IN A 203.0.113.1")
+					msg.Answer = append(msg.Answer, rr)
+				}
+			}
+			_ = w.WriteMsg(msg)
+		})
+		server := &miekgdns.Server{PacketConn: conn, Handler: handler}
+		go func() {
+			_ = server.ActivateAndServe()
+		}()
+		defer func() {
+			_ = server.Shutdown()
+		}()
+
+		lookup := newNameserverLookup([]string{conn.LocalAddr().String()})
+		ips, err := lookup("public.example.com")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ips).To(HaveLen(1))
+		Expect(ips[0].String()).To(Equal("203.0.113.1"))
+	})
+})
@@ -7,6 +7,7 @@ package provider
 import (
 	"container/heap"
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"sort"
@@ -18,6 +19,7 @@ import (
 	"github.com/gardener/external-dns-management/pkg/server/metrics"
 	"go.uber.org/atomic"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/clock"
 )
 
 type lookupHostConfig struct {
@@ -39,6 +41,120 @@ func defaultLookupHostConfig() lookupHostConfig {
 // lookupHost allows to override the default lookup function for testing purposes
 var lookupHost lookupHostConfig = defaultLookupHostConfig()
 
+type lookupNegativeCacheEntry struct {
+	err       error
+	expiresAt time.Time
+}
+
+// lookupNegativeCache caches permanent lookup failures (e.g. NXDOMAIN) of hostnames for a configurable TTL.
+type lookupNegativeCache struct {
+	lock    sync.Mutex
+	ttl     time.Duration
+	entries map[string]lookupNegativeCacheEntry
+}
+
+func newLookupNegativeCache(ttl time.Duration) *lookupNegativeCache {
+	if ttl <= 0 {
+		return nil
+	}
+	return &lookupNegativeCache{ttl: ttl, entries: map[string]lookupNegativeCacheEntry{}}
+}
+
+// get returns the cached lookup error for the hostname if there is a not expired entry.
+func (c *lookupNegativeCache) get(hostname string) error {
+	if c == nil {
+		return nil
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	entry, ok := c.entries[hostname]
+	if !ok {
+		return nil
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(c.entries, hostname)
+		return nil
+	}
+	return entry.err
+}
+
+// add caches the permanent lookup error for the hostname and drops expired entries.
+func (c *lookupNegativeCache) add(hostname string, err error) {
+	if c == nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	now := time.Now()
+	for h, entry := range c.entries {
+		if now.After(entry.expiresAt) {
+			delete(c.entries, h)
+		}
+	}
+	c.entries[hostname] = lookupNegativeCacheEntry{err: err, expiresAt: now.Add(c.ttl)}
+}
+
+type lookupDedupCall struct {
+	done       chan struct{}
+	result     lookupIPsResult
+	finishedAt time.Time
+}
+
+// lookupDedup deduplicates lookups of the same hostname across lookup jobs. Concurrent lookups share a single
+// resolver query, and its result is reused for lookups started within the deduplication window.
+type lookupDedup struct {
+	lock         sync.Mutex
+	clock        clock.PassiveClock
+	window       time.Duration
+	calls        map[string]*lookupDedupCall
+	deduplicated atomic.Int64
+}
+
+func newLookupDedup(window time.Duration) *lookupDedup {
+	if window <= 0 {
+		return nil
+	}
+	return &lookupDedup{clock: clock.RealClock{}, window: window, calls: map[string]*lookupDedupCall{}}
+}
+
+// lookup returns the result of a running or recent lookup of the hostname or calls the lookup function otherwise.
+func (d *lookupDedup) lookup(hostname string, lookup func() lookupIPsResult) lookupIPsResult {
+	if d == nil {
+		return lookup()
+	}
+	d.lock.Lock()
+	now := d.clock.Now()
+	if call := d.calls[hostname]; call != nil && (call.finishedAt.IsZero() || now.Sub(call.finishedAt) < d.window) {
+		d.lock.Unlock()
+		d.deduplicated.Inc()
+		<-call.done
+		return call.result
+	}
+	for h, call := range d.calls {
+		if !call.finishedAt.IsZero() && now.Sub(call.finishedAt) >= d.window {
+			delete(d.calls, h)
+		}
+	}
+	call := &lookupDedupCall{done: make(chan struct{})}
+	d.calls[hostname] = call
+	d.lock.Unlock()
+
+	call.result = lookup()
+	d.lock.Lock()
+	call.finishedAt = d.clock.Now()
+	d.lock.Unlock()
+	close(call.done)
+	return call.result
+}
+
+// count returns the number of deduplicated lookups.
+func (d *lookupDedup) count() int64 {
+	if d == nil {
+		return 0
+	}
+	return d.deduplicated.Load()
+}
+
 type lookupJob struct {
 	objectName resources.ObjectName
 
@@ -50,11 +166,11 @@ type lookupJob struct {
 	running atomic.Bool
 }
 
-func (j *lookupJob) updateWithLock(newResults lookupAllResults, interval time.Duration) bool {
+func (j *lookupJob) updateWithLock(now time.Time, newResults lookupAllResults, interval time.Duration) bool {
 	j.lock.Lock()
 	defer j.lock.Unlock()
 	j.interval = interval
-	j.scheduledAt = time.Now().Add(interval)
+	j.scheduledAt = now.Add(interval)
 	return j.updateLookupResult(newResults)
 }
 
@@ -135,6 +251,7 @@ func (d defaultLookupMetrics) RemoveJob(name resources.ObjectName) {
 type lookupProcessor struct {
 	lock           sync.Mutex
 	logger         logger.LogContext
+	clock          clock.Clock
 	checkPeriod    time.Duration
 	concurrentJobs int
 	slots          chan struct{}
@@ -144,6 +261,10 @@ type lookupProcessor struct {
 	running        atomic.Bool
 	skipped        atomic.Int64
 	metrics        lookupMetrics
+	negativeCache  *lookupNegativeCache
+	dedup          *lookupDedup
+	// lookup resolves hostnames with explicit name servers (default lookup if nil)
+	lookup func(string) ([]net.IP, error)
 }
 
 func newLookupProcessor(
@@ -153,9 +274,13 @@ func newLookupProcessor(
 	checkPeriod time.Duration,
 	cluster string,
 	metrics lookupMetrics,
+	negativeCacheTTL time.Duration,
+	dedupWindow time.Duration,
+	nameservers []string,
 ) *lookupProcessor {
 	return &lookupProcessor{
 		logger:         logger,
+		clock:          clock.RealClock{},
 		checkPeriod:    checkPeriod,
 		concurrentJobs: concurrentJobs,
 		slots:          make(chan struct{}, concurrentJobs),
@@ -163,9 +288,34 @@ func newLookupProcessor(
 		cluster:        cluster,
 		enqueuer:       enqueuer,
 		metrics:        metrics,
+		negativeCache:  newLookupNegativeCache(negativeCacheTTL),
+		dedup:          newLookupDedup(dedupWindow),
+		lookup:         newNameserverLookup(nameservers),
 	}
 }
 
+// Lookup looks up the IP addresses of the given hostnames for the given object name.
+// Permanent lookup failures are cached for the negative cache TTL.
+// Lookups of the same hostname by other entries within the deduplication window are shared.
+// For hostnames with transient lookup errors, the addresses of an existing lookup job are kept.
+func (p *lookupProcessor) Lookup(ctx context.Context, name resources.ObjectName, hostnames ...string) lookupAllResults {
+	var previous *lookupAllResults
+	p.lock.Lock()
+	idx := p.find(name)
+	var job *lookupJob
+	if idx >= 0 {
+		job = p.queue[idx]
+	}
+	p.lock.Unlock()
+	if job != nil {
+		job.lock.Lock()
+		old := job.oldLookupResults
+		job.lock.Unlock()
+		previous = &old
+	}
+	return lookupAllHostnamesIPsWithCache(ctx, p.negativeCache, p.dedup, p.lookup, hostnames...).keepAddressesOnTransientErrors(previous)
+}
+
 // Upsert inserts or updates a lookup job for the given object name.
 func (p *lookupProcessor) Upsert(name resources.ObjectName, results lookupAllResults, interval time.Duration) {
 	p.lock.Lock()
@@ -175,7 +325,7 @@ func (p *lookupProcessor) Upsert(name resources.ObjectName, results lookupAllRes
 
 	idx := p.find(name)
 	if idx >= 0 {
-		changed := p.queue[idx].updateWithLock(results, interval)
+		changed := p.queue[idx].updateWithLock(p.clock.Now(), results, interval)
 		heap.Fix(&p.queue, idx)
 		if changed {
 			p.enqueueKey(name)
@@ -184,7 +334,7 @@ func (p *lookupProcessor) Upsert(name resources.ObjectName, results lookupAllRes
 	}
 
 	job := &lookupJob{
-		scheduledAt:      time.Now().Add(interval),
+		scheduledAt:      p.clock.Now().Add(interval),
 		objectName:       name,
 		interval:         interval,
 		oldLookupResults: results,
@@ -214,7 +364,7 @@ func (p *lookupProcessor) Run(ctx context.Context) {
 
 	nextCheck := p.checkPeriod
 	for {
-		if err := sleep(ctx, nextCheck); err != nil {
+		if err := sleepWithClock(ctx, p.clock, nextCheck); err != nil {
 			p.logger.Infof("lookup processor stopped: %s", ctx.Err())
 			return
 		}
@@ -222,7 +372,7 @@ func (p *lookupProcessor) Run(ctx context.Context) {
 		if skipped != nil {
 			p.logger.Infof("skipped entry %s as lookup not yet finished", *skipped)
 		}
-		nextCheck = time.Until(nextCheckTime)
+		nextCheck = nextCheckTime.Sub(p.clock.Now())
 	}
 }
 
@@ -232,13 +382,14 @@ func (p *lookupProcessor) runJob(ctx context.Context) (time.Time, *resources.Obj
 		job       *lookupJob
 	)
 	p.lock.Lock()
+	now := p.clock.Now()
 	if len(p.queue) == 0 {
-		nextCheck = time.Now().Add(p.checkPeriod)
+		nextCheck = now.Add(p.checkPeriod)
 	} else {
-		idle := time.Until(p.queue[0].scheduledAt)
-		if idle < 0 {
+		idle := p.queue[0].scheduledAt.Sub(now)
+		if idle <= 0 {
 			job = p.queue[0]
-			job.scheduledAt = time.Now().Add(job.interval)
+			job.scheduledAt = now.Add(job.interval)
 			heap.Fix(&p.queue, 0)
 		}
 		nextCheck = p.queue[0].scheduledAt
@@ -264,7 +415,8 @@ func (p *lookupProcessor) runJob(ctx context.Context) (time.Time, *resources.Obj
 				}()
 				j.lock.Lock()
 				defer j.lock.Unlock()
-				newLookupResult := lookupAllHostnamesIPs(ctx, j.oldLookupResults.hostnames...)
+				newLookupResult := lookupAllHostnamesIPsWithCache(ctx, p.negativeCache, p.dedup, p.lookup, j.oldLookupResults.hostnames...).
+					keepAddressesOnTransientErrors(&j.oldLookupResults)
 				p.incrHostnameLookups(j.objectName, newLookupResult)
 				if j.updateLookupResult(newLookupResult) {
 					p.enqueueKey(j.objectName)
@@ -297,15 +449,20 @@ func (p *lookupProcessor) find(name resources.ObjectName) int {
 }
 
 type lookupAllResults struct {
-	hostnames  []string
-	ipv4Addrs  []string
-	ipv6Addrs  []string
-	errs       []error
-	allIPAddrs sets.Set[string]
-	duration   time.Duration
+	hostnames   []string
+	ipv4Addrs   []string
+	ipv6Addrs   []string
+	errs        []error
+	allIPAddrs  sets.Set[string]
+	hostResults map[string]lookupIPsResult
+	duration    time.Duration
 }
 
 func lookupAllHostnamesIPs(ctx context.Context, hostnames ...string) lookupAllResults {
+	return lookupAllHostnamesIPsWithCache(ctx, nil, nil, nil, hostnames...)
+}
+
+func lookupAllHostnamesIPsWithCache(ctx context.Context, negativeCache *lookupNegativeCache, dedup *lookupDedup, lookup func(string) ([]net.IP, error), hostnames ...string) lookupAllResults {
 	start := time.Now()
 	results := make(chan lookupIPsResult, lookupHost.maxConcurrentLookupsPerJob)
 	go func() {
@@ -313,23 +470,39 @@ func lookupAllHostnamesIPs(ctx context.Context, hostnames ...string) lookupAllRe
 		for _, hostname := range hostnames {
 			select {
 			case <-ctx.Done():
-				results <- lookupIPsResult{err: ctx.Err()}
+				results <- lookupIPsResult{hostname: hostname, err: ctx.Err(), transient: true}
 			case sem <- struct{}{}: // Acquire semaphore slot
 				go func(h string) {
 					defer func() {
 						<-sem // Release semaphore slot
 					}()
-					results <- lookupIPs(h)
+					results <- dedup.lookup(h, func() lookupIPsResult {
+						return lookupIPs(negativeCache, lookup, h)
+					})
 				}(hostname)
 			}
 		}
 	}()
 
-	all := lookupAllResults{hostnames: hostnames, allIPAddrs: sets.New[string]()}
+	hostResults := make(map[string]lookupIPsResult, len(hostnames))
+	var errs []error
 	for range len(hostnames) {
 		result := <-results
+		hostResults[result.hostname] = result
+		if result.err != nil {
+			errs = append(errs, result.err)
+		}
+	}
+	all := newLookupAllResults(hostnames, hostResults, errs)
+	all.duration = time.Since(start)
+	return all
+}
+
+func newLookupAllResults(hostnames []string, hostResults map[string]lookupIPsResult, errs []error) lookupAllResults {
+	all := lookupAllResults{hostnames: hostnames, allIPAddrs: sets.New[string](), hostResults: hostResults, errs: errs}
+	for _, hostname := range hostnames {
+		result := hostResults[hostname]
 		if result.err != nil {
-			all.errs = append(all.errs, result.err)
 			continue
 		}
 
@@ -348,35 +521,72 @@ func lookupAllHostnamesIPs(ctx context.Context, hostnames ...string) lookupAllRe
 			all.allIPAddrs.Insert(addr)
 		}
 	}
-	all.duration = time.Since(start)
 	sort.Strings(all.ipv4Addrs)
 	sort.Strings(all.ipv6Addrs)
 	return all
 }
 
+// keepAddressesOnTransientErrors returns the results where hostnames with transient lookup errors keep
+// their addresses from the previous results. This avoids flapping if the upstream DNS is briefly unreachable.
+// Permanent failures like NXDOMAIN drop the addresses immediately.
+func (r lookupAllResults) keepAddressesOnTransientErrors(previous *lookupAllResults) lookupAllResults {
+	if previous == nil || previous.hostResults == nil {
+		return r
+	}
+	kept := false
+	hostResults := make(map[string]lookupIPsResult, len(r.hostResults))
+	for hostname, result := range r.hostResults {
+		if old, ok := previous.hostResults[hostname]; ok && result.err != nil && result.transient && old.err == nil {
+			result = old
+			kept = true
+		}
+		hostResults[hostname] = result
+	}
+	if !kept {
+		return r
+	}
+	all := newLookupAllResults(r.hostnames, hostResults, r.errs)
+	all.duration = r.duration
+	return all
+}
+
 type lookupIPsResult struct {
+	hostname  string
 	ipv4Addrs []string
 	ipv6Addrs []string
 	err       error
+	// transient is set if the lookup error is only temporary (e.g. timeout or server failure)
+	transient bool
 }
 
-func lookupIPs(hostname string) lookupIPsResult {
+func lookupIPs(negativeCache *lookupNegativeCache, lookup func(string) ([]net.IP, error), hostname string) lookupIPsResult {
+	if err := negativeCache.get(hostname); err != nil {
+		return lookupIPsResult{hostname: hostname, err: fmt.Errorf("cannot lookup '%s': %s (cached)", hostname, err)}
+	}
+
+	if lookup == nil {
+		lookup = lookupHost.lookupHost
+	}
 	var (
 		ips []net.IP
 		err error
 	)
 	for i := 1; i <= lookupHost.maxLookupRetries; i++ {
-		ips, err = lookupHost.lookupHost(hostname)
+		ips, err = lookup(hostname)
 		if err == nil || i == lookupHost.maxLookupRetries {
 			break
 		}
-		if netErr, ok := err.(net.Error); !ok || !netErr.Timeout() {
+		if !isTransientLookupError(err) {
 			break
 		}
 		time.Sleep(lookupHost.waitLookupRetry)
 	}
 	if err != nil {
-		return lookupIPsResult{err: fmt.Errorf("cannot lookup '%s': %s", hostname, err)}
+		transient := isTransientLookupError(err)
+		if !transient {
+			negativeCache.add(hostname, err)
+		}
+		return lookupIPsResult{hostname: hostname, err: fmt.Errorf("cannot lookup '%s': %s", hostname, err), transient: transient}
 	}
 	ipv4addrs := make([]string, 0, len(ips))
 	ipv6addrs := make([]string, 0, len(ips))
@@ -388,23 +598,38 @@ func lookupIPs(hostname string) lookupIPsResult {
 		}
 	}
 	if len(ipv4addrs) == 0 && len(ipv6addrs) == 0 {
-		return lookupIPsResult{err: fmt.Errorf("%s has no IPv4/IPv6 address (of %d addresses)", hostname, len(ips))}
+		return lookupIPsResult{hostname: hostname, err: fmt.Errorf("%s has no IPv4/IPv6 address (of %d addresses)", hostname, len(ips))}
 	}
-	return lookupIPsResult{ipv4Addrs: ipv4addrs, ipv6Addrs: ipv6addrs}
+	return lookupIPsResult{hostname: hostname, ipv4Addrs: ipv4addrs, ipv6Addrs: ipv6addrs}
+}
+
+// isTransientLookupError returns true if the lookup error is only temporary, e.g. on timeouts or server failures.
+// Permanent failures like NXDOMAIN (host not found) are not transient.
+func isTransientLookupError(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return !dnsErr.IsNotFound
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
 }
 
 func sleep(ctx context.Context, d time.Duration) error {
+	return sleepWithClock(ctx, clock.RealClock{}, d)
+}
+
+func sleepWithClock(ctx context.Context, clock clock.Clock, d time.Duration) error {
 	if d < 1*time.Microsecond {
 		return nil
 	} else if d > 30*time.Second {
 		d = 30 * time.Second
 	}
-	t := time.NewTimer(d)
+	t := clock.NewTimer(d)
 	select {
 	case <-ctx.Done():
 		t.Stop()
 		return ctx.Err()
-	case <-t.C:
+	case <-t.C():
 		return nil
 	}
 }
@@ -7,6 +7,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"maps"
 	"net"
 	"sync"
 	"time"
@@ -16,9 +17,11 @@ import (
 	ginkgov2 "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"go.uber.org/atomic"
+	testclock "k8s.io/utils/clock/testing"
 )
 
 type testEnqueuer struct {
+	lock          sync.Mutex
 	enqueuedCount map[resources.ObjectName]int
 	stopped       atomic.Bool
 }
@@ -27,10 +30,19 @@ func (e *testEnqueuer) EnqueueKey(key resources.ClusterObjectKey) error {
 	if e.stopped.Load() {
 		return nil
 	}
+	e.lock.Lock()
+	defer e.lock.Unlock()
 	e.enqueuedCount[key.ObjectName()]++
 	return nil
 }
 
+// counts returns a copy of the enqueue counts by object name.
+func (e *testEnqueuer) counts() map[resources.ObjectName]int {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return maps.Clone(e.enqueuedCount)
+}
+
 type timeoutError struct{}
 
 func (timeoutError) Error() string   { return "timeout" }
@@ -49,9 +61,14 @@ type mockLookupHost struct {
 	lookupCount map[string]int
 	stopped     atomic.Bool
 	retryMap    map[string]int
+	// blocked delays all lookups until it is closed (if set)
+	blocked chan struct{}
 }
 
 func (lh *mockLookupHost) LookupHost(hostname string) ([]net.IP, error) {
+	if lh.blocked != nil {
+		<-lh.blocked
+	}
 	time.Sleep(lh.delay)
 	retry := false
 	lh.lock.Lock()
@@ -74,6 +91,13 @@ func (lh *mockLookupHost) LookupHost(hostname string) ([]net.IP, error) {
 	return result.ips, result.err
 }
 
+// count returns the number of lookups of the hostname.
+func (lh *mockLookupHost) count(hostname string) int {
+	lh.lock.Lock()
+	defer lh.lock.Unlock()
+	return lh.lookupCount[hostname]
+}
+
 type lookupStat struct {
 	count       int
 	targetCount int
@@ -107,6 +131,13 @@ func (t *testMetrics) IncrHostnameLookups(name resources.ObjectName, targetCount
 	t.lookups[name] = stat
 }
 
+// stat returns the lookup statistics of the object name.
+func (t *testMetrics) stat(name resources.ObjectName) lookupStat {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.lookups[name]
+}
+
 func (t *testMetrics) ReportCurrentJobCount(count int) {
 	t.jobs = count
 }
@@ -123,6 +154,8 @@ var _ = ginkgov2.Describe("Lookup processor", func() {
 		enqueuer  *testEnqueuer
 		mlh       *mockLookupHost
 		metrics   *testMetrics
+		clock     *testclock.FakeClock
+		runDone   chan struct{}
 
 		nameE1    = resources.NewObjectName("ns1", "e1")
 		nameE2    = resources.NewObjectName("ns1", "e2")
@@ -130,12 +163,35 @@ var _ = ginkgov2.Describe("Lookup processor", func() {
 		ctx       context.Context
 		ctxCancel context.CancelFunc
 
+		run = func() {
+			runDone = make(chan struct{})
+			go func() {
+				defer ginkgov2.GinkgoRecover()
+				defer close(runDone)
+				processor.Run(ctx)
+			}()
+		}
+		// step advances the clock as soon as the processor waits for its next check.
+		step = func(d time.Duration) {
+			Eventually(clock.HasWaiters).Should(BeTrue())
+			clock.Step(d)
+		}
+		// waitIdle waits until the processor waits for its next check and all started jobs are finished.
+		waitIdle = func() {
+			Eventually(clock.HasWaiters).Should(BeTrue())
+			Eventually(func() int { return len(processor.slots) }).Should(BeZero())
+		}
+		stepIdle = func(d time.Duration) {
+			waitIdle()
+			clock.Step(d)
+		}
 		cancel = func() {
+			waitIdle()
 			enqueuer.stopped.Store(true)
 			mlh.stopped.Store(true)
 			metrics.stopped.Store(true)
 			ctxCancel()
-			time.Sleep(10 * time.Millisecond)
+			<-runDone
 			Expect(processor.running.Load()).To(BeFalse())
 		}
 	)
@@ -143,7 +199,9 @@ var _ = ginkgov2.Describe("Lookup processor", func() {
 	ginkgov2.BeforeEach(func() {
 		enqueuer = &testEnqueuer{enqueuedCount: map[resources.ObjectName]int{}}
 		metrics = &testMetrics{lookups: map[resources.ObjectName]lookupStat{}}
-		processor = newLookupProcessor(logger.New(), enqueuer, 2, 10*time.Millisecond, "default", metrics)
+		clock = testclock.NewFakeClock(time.Now())
+		processor = newLookupProcessor(logger.New(), enqueuer, 2, 10*time.Millisecond, "default", metrics, 1*time.Hour, 0, nil)
+		processor.clock = clock
 		mlh = &mockLookupHost{
 			delay: 1 * time.Microsecond,
 			lookupMap: map[string]mockLookupHostResult{
@@ -190,83 +248,152 @@ var _ = ginkgov2.Describe("Lookup processor", func() {
 		Expect(results1.allIPAddrs).To(HaveLen(4))
 	})
 
+	ginkgov2.It("keeps addresses on transient lookup errors but drops them on NXDOMAIN", func() {
+		results1 := processor.Lookup(ctx, nameE3, "host3a", "host3b", "host3c")
+		Expect(results1.ipv4Addrs).To(Equal([]string{"1.1.1.3", "1.1.2.3", "1.1.3.3", "1.1.3.4"}))
+		processor.Upsert(nameE3, results1, 1*time.Hour)
+
+		mlh.lookupMap["host3a"] = mockLookupHostResult{err: &net.DNSError{Err: "server misbehaving", Name: "host3a", IsTemporary: true}}
+		mlh.lookupMap["host3b"] = mockLookupHostResult{err: &net.DNSError{Err: "no such host", Name: "host3b", IsNotFound: true}}
+		results2 := processor.Lookup(ctx, nameE3, "host3a", "host3b", "host3c")
+		Expect(results2.ipv4Addrs).To(Equal([]string{"1.1.1.3", "1.1.3.3", "1.1.3.4"}))
+		Expect(results2.ipv6Addrs).To(Equal([]string{"fc00::3"}))
+		Expect(results2.errs).To(HaveLen(2))
+		Expect(mlh.count("host3a")).To(Equal(1 + lookupHost.maxLookupRetries))
+		Expect(mlh.count("host3b")).To(Equal(2))
+
+		// permanent failure is served from negative cache
+		results3 := processor.Lookup(ctx, nameE3, "host3b")
+		Expect(results3.allIPAddrs).To(BeEmpty())
+		Expect(results3.errs).To(HaveLen(1))
+		Expect(mlh.count("host3b")).To(Equal(2))
+
+		// without existing lookup job, there are no addresses to keep
+		results4 := processor.Lookup(ctx, nameE1, "host3a")
+		Expect(results4.allIPAddrs).To(BeEmpty())
+		Expect(results4.errs).To(HaveLen(1))
+	})
+
+	ginkgov2.It("deduplicates lookups of the same hostname across entries", func() {
+		mlh.blocked = make(chan struct{})
+		processor = newLookupProcessor(logger.New(), enqueuer, 2, 10*time.Millisecond, "default", metrics, 1*time.Hour, 50*time.Millisecond, nil)
+		processor.dedup.clock = clock
+		var wg sync.WaitGroup
+		for i := range 10 {
+			wg.Add(1)
+			go func(name resources.ObjectName) {
+				defer wg.Done()
+				defer ginkgov2.GinkgoRecover()
+				results := processor.Lookup(ctx, name, "host1", "host2")
+				Expect(results.ipv4Addrs).To(Equal([]string{"1.1.1.1", "1.1.1.2"}))
+			}(resources.NewObjectName("ns1", fmt.Sprintf("e%d", i)))
+		}
+		// all lookups wait for the blocked first lookup of each hostname
+		Eventually(processor.dedup.count).Should(Equal(int64(18)))
+		close(mlh.blocked)
+		wg.Wait()
+		Expect(mlh.count("host1")).To(Equal(1))
+		Expect(mlh.count("host2")).To(Equal(1))
+		Expect(processor.dedup.count()).To(Equal(int64(18)))
+
+		// results are only shared within the deduplication window
+		clock.Step(60 * time.Millisecond)
+		processor.Lookup(ctx, nameE1, "host1")
+		Expect(mlh.count("host1")).To(Equal(2))
+		Expect(processor.dedup.count()).To(Equal(int64(18)))
+	})
+
+	ginkgov2.It("does not deduplicate lookups if disabled", func() {
+		processor.Lookup(ctx, nameE1, "host1")
+		processor.Lookup(ctx, nameE2, "host1")
+		Expect(mlh.count("host1")).To(Equal(2))
+		Expect(processor.dedup.count()).To(BeZero())
+	})
+
 	ginkgov2.It("performs multiple lookup jobs regularly", func() {
-		go processor.Run(ctx)
+		run()
 		processor.Upsert(nameE1, lookupAllHostnamesIPs(ctx, "host1"), 1*time.Millisecond)
 		processor.Upsert(nameE2, lookupAllHostnamesIPs(ctx, "host2"), 2*time.Millisecond)
 		processor.Upsert(nameE3, lookupAllHostnamesIPs(ctx, "host3a", "host3b", "host3c"), 3*time.Millisecond)
-		time.Sleep(processor.checkPeriod)
+		stepIdle(processor.checkPeriod)
 
-		time.Sleep(18 * time.Millisecond)
+		for range 18 {
+			stepIdle(1 * time.Millisecond)
+		}
+		waitIdle()
 		processor.Delete(nameE3)
 		processor.Delete(nameE3)
-		time.Sleep(18 * time.Millisecond)
+		for range 18 {
+			stepIdle(1 * time.Millisecond)
+		}
 		cancel()
 
-		count1 := mlh.lookupCount["host1"]
-		count2 := mlh.lookupCount["host2"]
-		count3a := mlh.lookupCount["host3a"]
-		count3c := mlh.lookupCount["host3c"]
-		expectCountBetween("count1", count1, 18, 54)
-		expectCountBetween("count2", count2, 9, 27)
-		expectCountBetween("count3a", count3a, 3, 9)
-		expectCountBetween("count3c-count3a", count3c-count3a, -1, 1)
-		Expect(enqueuer.enqueuedCount).To(BeEmpty())
-		expectCountBetween("skipped", int(processor.skipped.Load()), 0, 10)
+		Expect(mlh.count("host1")).To(Equal(1 + 1 + 36))
+		Expect(mlh.count("host2")).To(Equal(1 + 1 + 18))
+		Expect(mlh.count("host3a")).To(Equal(1 + 1 + 6))
+		Expect(mlh.count("host3c")).To(Equal(mlh.count("host3a")))
+		Expect(enqueuer.counts()).To(BeEmpty())
+		Expect(processor.skipped.Load()).To(BeZero())
 	})
 
 	ginkgov2.It("performs multiple lookup jobs but skips on overload", func() {
-		mlh.delay = 1900 * time.Microsecond
-		go processor.Run(ctx)
 		processor.Upsert(nameE1, lookupAllHostnamesIPs(ctx, "host1"), 1*time.Millisecond)
 		processor.Upsert(nameE3, lookupAllHostnamesIPs(ctx, "host3a", "host3b", "host3c"), 1*time.Millisecond)
-		time.Sleep(processor.checkPeriod)
+		mlh.blocked = make(chan struct{})
+		run()
+		stepIdle(processor.checkPeriod)
 
-		time.Sleep(30 * time.Millisecond)
+		// both jobs are still running and are skipped
+		for range 10 {
+			step(1 * time.Millisecond)
+		}
+		Eventually(processor.skipped.Load).Should(Equal(int64(20)))
+		close(mlh.blocked)
+		for range 10 {
+			stepIdle(1 * time.Millisecond)
+		}
 		cancel()
 
-		count1 := mlh.lookupCount["host1"]
-		count3a := mlh.lookupCount["host3a"]
-		count3c := mlh.lookupCount["host3c"]
-		expectCountBetween("count1", count1, 10, 20)
-		expectCountBetween("count3a", count3a, 10, 20)
-		expectCountBetween("count3c-count3a", count3c-count3a, -1, 1)
-		Expect(enqueuer.enqueuedCount).To(BeEmpty())
-		expectCountBetween("skipped", int(processor.skipped.Load()), 20, 50)
+		Expect(mlh.count("host1")).To(Equal(1 + 1 + 10))
+		Expect(mlh.count("host3a")).To(Equal(1 + 1 + 10))
+		Expect(mlh.count("host3c")).To(Equal(mlh.count("host3a")))
+		Expect(enqueuer.counts()).To(BeEmpty())
+		Expect(processor.skipped.Load()).To(Equal(int64(20)))
 	})
 
 	ginkgov2.It("performs multiple lookup jobs and enqueues keys on lookup changes", func() {
 		changedIP := net.ParseIP("1.1.1.42")
-		go processor.Run(ctx)
+		run()
 		processor.Upsert(nameE1, lookupAllHostnamesIPs(ctx, "host1"), 1*time.Millisecond)
 		processor.Upsert(nameE2, lookupAllHostnamesIPs(ctx, "host2"), 1*time.Millisecond)
 		processor.Upsert(nameE3, lookupAllHostnamesIPs(ctx, "host3a", "host3b", "host3c"), 1*time.Millisecond)
-		time.Sleep(processor.checkPeriod)
+		stepIdle(processor.checkPeriod)
 
-		time.Sleep(10 * time.Millisecond)
+		for range 10 {
+			stepIdle(1 * time.Millisecond)
+		}
+		waitIdle()
 		processor.Upsert(nameE3, lookupAllHostnamesIPs(ctx, "host3a", "host3b", "not-existing-host"), 1*time.Millisecond)
 		mlh.lookupMap["host2"].ips[0] = changedIP
-		time.Sleep(20 * time.Millisecond)
+		for range 20 {
+			stepIdle(1 * time.Millisecond)
+		}
 		cancel()
 
-		count1 := mlh.lookupCount["host1"]
-		count2 := mlh.lookupCount["host2"]
-		count3a := mlh.lookupCount["host3a"]
-		count3c := mlh.lookupCount["host3c"]
-		expectCountBetween("count1", count1, 20, 40)
-		expectCountBetween("count2", count2, 20, 40)
-		expectCountBetween("count3a", count3a, 20, 40)
-		expectCountBetween("count3c", count3c, 5, 15)
-		Expect(enqueuer.enqueuedCount).To(HaveLen(2))
-		Expect(enqueuer.enqueuedCount[nameE2]).To(Equal(1))
-		Expect(enqueuer.enqueuedCount[nameE3]).To(Equal(1))
-		expectCountBetween("skipped", int(processor.skipped.Load()), 0, 10)
-		stat1 := metrics.lookups[nameE1]
-		expectCountBetween("stat1.count-count1", stat1.count-count1, -1, 1)
-		stat3 := metrics.lookups[nameE3]
-		expectCountBetween("stat3.count-count3a", stat3.count-count3a, -1, 1)
+		count1 := mlh.count("host1")
+		count3a := mlh.count("host3a")
+		Expect(count1).To(Equal(1 + 11 + 20))
+		Expect(mlh.count("host2")).To(Equal(count1))
+		Expect(count3a).To(Equal(2 + 11 + 20))
+		Expect(mlh.count("host3c")).To(Equal(1 + 11))
+		Expect(enqueuer.counts()).To(Equal(map[resources.ObjectName]int{nameE2: 1, nameE3: 1}))
+		Expect(processor.skipped.Load()).To(BeZero())
+		Expect(metrics.stat(nameE1).count).To(Equal(count1))
+		stat3 := metrics.stat(nameE3)
+		Expect(stat3.count).To(Equal(count3a))
 		Expect(stat3.targetCount).To(Equal(count3a * 3))
-		expectCountBetween("count not-existing-host", stat3.errorCount, 10, 30)
+		// lookups of the not existing host are served from the negative cache
+		Expect(stat3.errorCount).To(Equal(1 + 20))
 	})
 })
 
@@ -0,0 +1,200 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// mirror providers
+//
+// The records of the entries assigned to a provider with spec.mirror are copied
+// to the mirror provider. For each entry a DNS entry is maintained in the
+// namespace of the entry. It references the entry to inherit its targets, is
+// pinned to the mirror provider and is owned by the entry. Failures of the
+// mirror entries never affect the original entries.
+////////////////////////////////////////////////////////////////////////////////
+
+// mirrorRecheckInterval is the interval for updating the mirror status of a provider.
+const mirrorRecheckInterval = time.Minute
+
+// MirrorEntryName returns the name of the DNS entry maintained for the mirror provider of the given entry.
+func MirrorEntryName(entryName string, mirror resources.ObjectName) string {
+	return mirrorEntries.entryName(entryName, mirror.String())
+}
+
+// mirrorProviderName returns the name of the mirror provider of the given provider or nil.
+func mirrorProviderName(provider *api.DNSProvider) resources.ObjectName {
+	mirror := provider.Spec.Mirror
+	if mirror == nil || mirror.TargetProvider == "" || mirror.TargetProvider == provider.Name {
+		return nil
+	}
+	return resources.NewObjectName(provider.Namespace, mirror.TargetProvider)
+}
+
+// mirrorEntrySpec returns the desired spec of the DNS entry maintained for the mirror provider of the given entry.
+func mirrorEntrySpec(entry *api.DNSEntry, mirror resources.ObjectName) api.DNSEntrySpec {
+	ref := &api.ProviderReference{Name: mirror.Name()}
+	if mirror.Namespace() != entry.Namespace {
+		ref.Namespace = mirror.Namespace()
+	}
+	return dependentEntrySpec(entry, entry.Spec.DNSName, ref)
+}
+
+// mirrorEntryAnnotations returns the desired annotations of the DNS entry maintained for the mirror provider of
+// the given entry.
+func mirrorEntryAnnotations(entry *api.DNSEntry) map[string]string {
+	return dependentEntryAnnotations(entry, mirrorEntries)
+}
+
+// getEntryMirror returns the name of the mirror provider of the provider assigned to the given entry or nil.
+func (this *state) getEntryMirror(name resources.ObjectName) resources.ObjectName {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+
+	e := this.entries[name]
+	if e == nil || e.ProviderName() == nil {
+		return nil
+	}
+	p := this.providers[e.ProviderName()]
+	if p == nil {
+		return nil
+	}
+	return mirrorProviderName(p.object.DNSProvider())
+}
+
+// syncMirrorEntry creates, updates, or deletes the DNS entry maintained for the mirror provider of the provider
+// assigned to the entry and updates the mirror state in the status of the entry.
+// Mirror entries are never mirrored again to avoid cycles.
+func (this *state) syncMirrorEntry(logger logger.LogContext, object *dnsutils.DNSEntryObject) error {
+	var mirror resources.ObjectName
+	if !object.IsDeleting() && mirrorEntries.ownerOf(object) == "" {
+		mirror = this.getEntryMirror(object.ObjectName())
+	}
+	if mirror == nil && object.Status().Mirror == nil && len(this.dependents.get(object.ObjectName())) == 0 {
+		return nil
+	}
+
+	entry := object.DNSEntry()
+	var desired []string
+	if mirror != nil {
+		desired = append(desired, MirrorEntryName(entry.Name, mirror))
+	}
+	existing, err := this.getDependentEntries(mirrorEntries, object, desired...)
+	if err != nil {
+		return err
+	}
+
+	var status *api.DNSEntryMirrorStatus
+	if mirror != nil {
+		name := desired[0]
+		status = &api.DNSEntryMirrorStatus{Provider: mirror.String(), Entry: name}
+		if o := existing[name]; o != nil {
+			delete(existing, name)
+			status.State = o.Status().State
+			status.Message = o.Status().Message
+		}
+		if object.Status().State != api.STATE_INVALID {
+			desc := "mirror provider " + mirror.String()
+			if err := this.assureDependentEntry(logger, object.GetResource(), entry, name, desc, mirrorEntrySpec(entry, mirror), mirrorEntryAnnotations(entry)); err != nil {
+				return err
+			}
+		}
+	}
+	if err := deleteDependentEntries(logger, mirrorEntries, existing); err != nil {
+		return err
+	}
+
+	if object.IsDeleting() {
+		return nil
+	}
+	_, err = object.ModifyStatus(func(data resources.ObjectData) (bool, error) {
+		s := &data.(*api.DNSEntry).Status
+		if reflect.DeepEqual(s.Mirror, status) {
+			return false, nil
+		}
+		s.Mirror = status
+		return true, nil
+	})
+	return err
+}
+
+// updateProviderMirror returns the mirror status of the given provider. If the mirror provider has changed,
+// the entries of the provider are triggered to maintain their mirror entries.
+func (this *state) updateProviderMirror(logger logger.LogContext, p *dnsProviderVersion) *api.DNSProviderMirrorStatus {
+	name := p.ObjectName()
+	mirror := mirrorProviderName(p.object.DNSProvider())
+
+	this.mlock.Lock()
+	old := this.mirrors[name]
+	if mirror == nil {
+		delete(this.mirrors, name)
+	} else {
+		this.mirrors[name] = mirror
+	}
+	this.mlock.Unlock()
+
+	if old != mirror {
+		if mirror != nil {
+			logger.Infof("mirror to provider %s", mirror)
+		} else {
+			logger.Infof("mirror to provider %s removed", old)
+		}
+		this.triggerProviderEntries(logger, name)
+	}
+	if mirror == nil {
+		if p.object.Spec().Mirror != nil {
+			msg := "mirror provider must not be the provider itself"
+			return &api.DNSProviderMirrorStatus{Message: &msg}
+		}
+		return nil
+	}
+
+	entries, mirrored := this.countMirroredEntries(name, mirror)
+	var msg string
+	if target := this.GetProvider(mirror); target == nil || !target.IsValid() {
+		msg = fmt.Sprintf("mirror provider %s not available", mirror)
+	} else {
+		msg = fmt.Sprintf("records of %d of %d entries mirrored to provider %s", mirrored, entries, mirror)
+	}
+	return &api.DNSProviderMirrorStatus{
+		Entries:         entries,
+		MirroredEntries: mirrored,
+		Message:         &msg,
+	}
+}
+
+func (this *state) removeProviderMirror(name resources.ObjectName) {
+	this.mlock.Lock()
+	defer this.mlock.Unlock()
+	delete(this.mirrors, name)
+}
+
+// countMirroredEntries returns the number of entries assigned to the given provider
+// and the number of those entries whose records are in place in the given mirror provider.
+func (this *state) countMirroredEntries(name, mirror resources.ObjectName) (int, int) {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+
+	entries, mirrored := 0, 0
+	for _, e := range this.entries {
+		if e.ProviderName() == name && mirrorEntries.ownerOf(e.Object()) == "" {
+			entries++
+			if m := e.Object().Status().Mirror; m != nil && m.Provider == mirror.String() && m.State == api.STATE_READY {
+				mirrored++
+			}
+		}
+	}
+	return entries, mirrored
+}
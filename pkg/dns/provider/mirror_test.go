@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"strings"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+var _ = ginkgov2.Describe("Mirror provider", func() {
+	mirror := resources.NewObjectName("dns", "standby")
+
+	ginkgov2.It("determines the mirror provider in the namespace of the provider", func() {
+		provider := &api.DNSProvider{}
+		provider.Namespace = "dns"
+		provider.Name = "primary"
+		Expect(mirrorProviderName(provider)).To(BeNil())
+		provider.Spec.Mirror = &api.DNSProviderMirror{TargetProvider: "primary"}
+		Expect(mirrorProviderName(provider)).To(BeNil())
+		provider.Spec.Mirror = &api.DNSProviderMirror{TargetProvider: "standby"}
+		Expect(mirrorProviderName(provider)).To(Equal(mirror))
+	})
+
+	ginkgov2.It("derives stable entry names for mirror providers", func() {
+		name := MirrorEntryName("entry", mirror)
+		Expect(name).To(HavePrefix("entry-mirror-"))
+		Expect(name).To(HaveLen(len("entry-mirror-") + 8))
+		Expect(MirrorEntryName("entry", resources.NewObjectName("dns", "standby"))).To(Equal(name))
+		Expect(MirrorEntryName("entry", resources.NewObjectName("dns", "other"))).NotTo(Equal(name))
+		Expect(len(MirrorEntryName(strings.Repeat("x", 253), mirror))).To(BeNumerically("<=", 253))
+	})
+
+	ginkgov2.It("references the entry and pins the mirror provider", func() {
+		entry := &api.DNSEntry{}
+		entry.Namespace = "default"
+		entry.Name = "entry"
+		entry.Spec.DNSName = "a.example.com"
+		entry.Spec.TTL = ptr.To[int64](120)
+		spec := mirrorEntrySpec(entry, mirror)
+		Expect(spec.DNSName).To(Equal("a.example.com"))
+		Expect(spec.Reference).To(Equal(&api.EntryReference{Name: "entry"}))
+		Expect(spec.ProviderRef).To(Equal(&api.ProviderReference{Name: "standby", Namespace: "dns"}))
+		Expect(spec.TTL).To(BeNil())
+
+		entry.Namespace = "dns"
+		Expect(mirrorEntrySpec(entry, mirror).ProviderRef).To(Equal(&api.ProviderReference{Name: "standby"}))
+	})
+
+	ginkgov2.It("does not inherit the markers of other maintained entries", func() {
+		entry := &api.DNSEntry{}
+		entry.Name = "entry-split-12345678"
+		entry.Annotations = map[string]string{dns.CLASS_ANNOTATION: "foo", dns.AnnotationSplitOf: "entry", "other": "x"}
+		Expect(mirrorEntryAnnotations(entry)).To(Equal(map[string]string{dns.CLASS_ANNOTATION: "foo", dns.AnnotationMirrorOf: "entry-split-12345678"}))
+	})
+})
@@ -0,0 +1,162 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+)
+
+// NamespaceFilter restricts the namespaces of the DNS entries handled by the controller.
+// A nil filter accepts all namespaces.
+type NamespaceFilter struct {
+	// included is the allow list of namespaces (all namespaces if empty).
+	included utils.StringSet
+	// excluded is the deny list of namespaces.
+	excluded utils.StringSet
+}
+
+// NewNamespaceFilter creates a namespace filter from comma separated lists of allowed and denied namespaces.
+// It returns nil if both lists are empty.
+func NewNamespaceFilter(included, excluded string) (*NamespaceFilter, error) {
+	filter := &NamespaceFilter{included: utils.StringSet{}, excluded: utils.StringSet{}}
+	filter.included.AddAllSplittedSelected(included, utils.NonEmptyStringElement)
+	filter.excluded.AddAllSplittedSelected(excluded, utils.NonEmptyStringElement)
+	if len(filter.included) == 0 && len(filter.excluded) == 0 {
+		return nil, nil
+	}
+	for _, set := range []utils.StringSet{filter.included, filter.excluded} {
+		for ns := range set {
+			if errs := validation.IsDNS1123Label(ns); len(errs) > 0 {
+				return nil, fmt.Errorf("invalid namespace %q: %s", ns, strings.Join(errs, ", "))
+			}
+		}
+	}
+	if both := filter.included.Intersect(filter.excluded); len(both) > 0 {
+		return nil, fmt.Errorf("namespaces %s are both allowed and denied", both)
+	}
+	return filter, nil
+}
+
+// Matches returns true if objects of the given namespace are handled.
+func (this *NamespaceFilter) Matches(namespace string) bool {
+	if this == nil {
+		return true
+	}
+	if this.excluded.Contains(namespace) {
+		return false
+	}
+	return len(this.included) == 0 || this.included.Contains(namespace)
+}
+
+func (this *NamespaceFilter) String() string {
+	if this == nil {
+		return "all"
+	}
+	s := "all"
+	if len(this.included) > 0 {
+		s = this.included.String()
+	}
+	if len(this.excluded) > 0 {
+		s += " except " + this.excluded.String()
+	}
+	return s
+}
+
+// watchSelection returns the namespace and the list options restricting the watch of the DNS entries.
+// A single allowed namespace is watched directly, denied namespaces are excluded by a field selector.
+// Several allowed namespaces cannot be expressed by a selector, they are only filtered on reconciliation.
+func (this *NamespaceFilter) watchSelection() (string, resources.TweakListOptionsFunc) {
+	if this == nil {
+		return "", nil
+	}
+	if len(this.included) == 1 {
+		return this.included.AsArray()[0], nil
+	}
+	if len(this.included) > 0 || len(this.excluded) == 0 {
+		return "", nil
+	}
+	var selectors []fields.Selector
+	excluded := this.excluded.AsArray()
+	sort.Strings(excluded)
+	for _, ns := range excluded {
+		selectors = append(selectors, fields.OneTermNotEqualSelector("metadata.namespace", ns))
+	}
+	selector := fields.AndSelectors(selectors...).String()
+	return "", func(options *metav1.ListOptions) {
+		options.FieldSelector = selector
+	}
+}
+
+// listEntries lists the DNS entries of the handled namespaces.
+// The entries are taken from the cache of the entry watch if it is not restricted by a field selector.
+func (this *NamespaceFilter) listEntries(res resources.Interface) ([]resources.Object, error) {
+	namespace, tweak := this.watchSelection()
+	if tweak != nil {
+		options := metav1.ListOptions{}
+		tweak(&options)
+		return res.List(options)
+	}
+	if namespace != "" {
+		return res.Namespace(namespace).ListCached(labels.Everything())
+	}
+	return res.ListCached(labels.Everything())
+}
+
+// entryNamespaceSelection restricts the watch of the DNS entries to the handled namespaces.
+func entryNamespaceSelection(c controller.Interface) (string, resources.TweakListOptionsFunc) {
+	included, _ := c.GetStringOption(OPT_ENTRY_NAMESPACES)
+	excluded, _ := c.GetStringOption(OPT_EXCLUDED_ENTRY_NAMESPACES)
+	// invalid options are rejected on creation of the controller config
+	filter, err := NewNamespaceFilter(included, excluded)
+	if err != nil {
+		return "", nil
+	}
+	return filter.watchSelection()
+}
+
+// releaseOutOfScopeEntry removes the finalizer of a DNS entry outside of the handled namespaces.
+// The DNS records of the entry are kept for the controller handling its namespace now.
+func (this *state) releaseOutOfScopeEntry(logger logger.LogContext, obj resources.Object) {
+	if this.config.EntryNamespaceFilter.Matches(obj.GetNamespace()) || !this.HasFinalizer(obj) {
+		return
+	}
+	logger.Infof("releasing entry %s outside of the handled namespaces", obj.ObjectName())
+	if err := this.RemoveFinalizer(obj); err != nil {
+		logger.Warnf("cannot remove finalizer of entry %s: %s", obj.ObjectName(), err)
+	}
+}
+
+// releaseOutOfScopeEntries removes the finalizers of all DNS entries outside of the handled namespaces on startup.
+// These entries are not watched, so they are listed once without cache.
+func (this *state) releaseOutOfScopeEntries() error {
+	if this.config.EntryNamespaceFilter == nil {
+		return nil
+	}
+	res, err := this.context.GetByExample(&api.DNSEntry{})
+	if err != nil {
+		return err
+	}
+	list, err := res.List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, obj := range list {
+		this.releaseOutOfScopeEntry(this.context, obj)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = ginkgov2.Describe("NamespaceFilter", func() {
+	ginkgov2.It("is nil and matches all namespaces if not configured", func() {
+		filter, err := NewNamespaceFilter("", " ")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(filter).To(BeNil())
+		Expect(filter.Matches("default")).To(BeTrue())
+	})
+
+	ginkgov2.It("matches allowed namespaces only", func() {
+		filter, err := NewNamespaceFilter("team-a, team-b", "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(filter.Matches("team-a")).To(BeTrue())
+		Expect(filter.Matches("team-b")).To(BeTrue())
+		Expect(filter.Matches("default")).To(BeFalse())
+	})
+
+	ginkgov2.It("does not match denied namespaces", func() {
+		filter, err := NewNamespaceFilter("", "kube-system")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(filter.Matches("kube-system")).To(BeFalse())
+		Expect(filter.Matches("default")).To(BeTrue())
+	})
+
+	ginkgov2.It("rejects invalid namespaces", func() {
+		_, err := NewNamespaceFilter("Team_A", "")
+		Expect(err).To(MatchError(ContainSubstring("invalid namespace \"Team_A\"")))
+	})
+
+	ginkgov2.It("rejects namespaces both allowed and denied", func() {
+		_, err := NewNamespaceFilter("team-a,team-b", "team-b")
+		Expect(err).To(MatchError(ContainSubstring("both allowed and denied")))
+	})
+
+	ginkgov2.Context("watch selection", func() {
+		selection := func(included, excluded string) (string, string) {
+			filter, err := NewNamespaceFilter(included, excluded)
+			Expect(err).NotTo(HaveOccurred())
+			namespace, tweak := filter.watchSelection()
+			if tweak == nil {
+				return namespace, ""
+			}
+			options := metav1.ListOptions{}
+			tweak(&options)
+			return namespace, options.FieldSelector
+		}
+
+		ginkgov2.It("watches all namespaces if not configured", func() {
+			namespace, fieldSelector := selection("", "")
+			Expect(namespace).To(BeEmpty())
+			Expect(fieldSelector).To(BeEmpty())
+		})
+
+		ginkgov2.It("watches a single allowed namespace directly", func() {
+			namespace, fieldSelector := selection("team-a", "")
+			Expect(namespace).To(Equal("team-a"))
+			Expect(fieldSelector).To(BeEmpty())
+		})
+
+		ginkgov2.It("excludes denied namespaces by field selector", func() {
+			namespace, fieldSelector := selection("", "kube-system,garden")
+			Expect(namespace).To(BeEmpty())
+			Expect(fieldSelector).To(Equal("metadata.namespace!=garden,metadata.namespace!=kube-system"))
+		})
+
+		ginkgov2.It("watches all namespaces for several allowed namespaces", func() {
+			namespace, fieldSelector := selection("team-a,team-b", "")
+			Expect(namespace).To(BeEmpty())
+			Expect(fieldSelector).To(BeEmpty())
+		})
+	})
+})
@@ -30,6 +30,8 @@ type (
 type OwnerObjectInfo struct {
 	active bool
 	id     string
+	// namespaces restricts the owner id to entries in these namespaces (nil for all namespaces)
+	namespaces utils.StringSet
 }
 
 type OwnerName string
@@ -37,7 +39,9 @@ type OwnerName string
 type OwnerObjectInfos map[OwnerName]OwnerObjectInfo
 
 type OwnerIDInfo struct {
-	refcount    int
+	refcount int
+	// scoped is the number of active owner objects restricting the owner id to namespaces
+	scoped      int
 	entrycounts map[string]int
 }
 type OwnerIDInfos map[string]OwnerIDInfo
@@ -79,6 +83,25 @@ func (this *OwnerCache) IsResponsibleFor(id string) bool {
 	return this.ownerids.Contains(id)
 }
 
+// IsResponsibleForNamespace returns true if the owner id is active for entries in the given namespace.
+func (this *OwnerCache) IsResponsibleForNamespace(id, namespace string) bool {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	e, ok := this.ownerids[id]
+	if !ok {
+		return false
+	}
+	if e.refcount > e.scoped {
+		return true
+	}
+	for _, o := range this.owners {
+		if o.active && o.id == id && o.namespaces.Contains(namespace) {
+			return true
+		}
+	}
+	return false
+}
+
 func (this *OwnerCache) IsResponsiblePendingFor(id string) bool {
 	this.lock.RLock()
 	defer this.lock.RUnlock()
@@ -129,26 +152,31 @@ func (this *OwnerCache) UpdateOwner(owner *dnsutils.DNSOwnerObject) (changeset u
 	active := owner.IsActive()
 	this.lock.Lock()
 	defer this.lock.Unlock()
-	return this._updateOwnerData(OwnerName(owner.GetName()), owner.GetOwnerId(), active, owner.GetCounts())
+	return this._updateOwnerData(OwnerName(owner.GetName()), owner.GetOwnerId(), active, owner.GetNamespaces(), owner.GetCounts())
 }
 
-func (this *OwnerCache) updateOwnerData(cachekey OwnerName, id string, active bool, counts ProviderTypeCounts) (changeset utils.StringSet, activeset utils.StringSet) {
+func (this *OwnerCache) updateOwnerData(cachekey OwnerName, id string, active bool, namespaces utils.StringSet, counts ProviderTypeCounts) (changeset utils.StringSet, activeset utils.StringSet) {
 	this.lock.Lock()
 	defer this.lock.Unlock()
-	return this._updateOwnerData(cachekey, id, active, counts)
+	return this._updateOwnerData(cachekey, id, active, namespaces, counts)
 }
 
-func (this *OwnerCache) _updateOwnerData(cachekey OwnerName, id string, active bool, counts ProviderTypeCounts) (changeset utils.StringSet, activeset utils.StringSet) {
+func (this *OwnerCache) _updateOwnerData(cachekey OwnerName, id string, active bool, namespaces utils.StringSet, counts ProviderTypeCounts) (changeset utils.StringSet, activeset utils.StringSet) {
 	changeset = utils.StringSet{}
 
 	old, ok := this.owners[cachekey]
 	if ok {
-		if old.id == id && old.active == active {
+		sameScope := (old.namespaces == nil) == (namespaces == nil) && old.namespaces.Equals(namespaces)
+		if old.id == id && old.active == active && sameScope {
 			return changeset, this.ownerids.KeySet()
 		}
 		this.deactivate(cachekey, old, changeset)
+		if old.id == id && active && !sameScope {
+			// entries of the owner id must be checked again for the changed namespaces
+			changeset.Add(id)
+		}
 	}
-	this.activate(cachekey, id, active, changeset, counts)
+	this.activate(cachekey, id, active, namespaces, changeset, counts)
 	return changeset, this.ownerids.KeySet()
 }
 
@@ -169,6 +197,9 @@ func (this *OwnerCache) deactivate(cachekey OwnerName, old OwnerObjectInfo, chan
 	if old.active {
 		e := this.ownerids[old.id]
 		e.refcount--
+		if old.namespaces != nil {
+			e.scoped--
+		}
 		this.ownerids[old.id] = e
 		if e.refcount == 0 {
 			delete(this.ownerids, old.id)
@@ -178,7 +209,7 @@ func (this *OwnerCache) deactivate(cachekey OwnerName, old OwnerObjectInfo, chan
 	delete(this.owners, cachekey)
 }
 
-func (this *OwnerCache) activate(cachekey OwnerName, id string, active bool, changeset utils.StringSet, counts ProviderTypeCounts) {
+func (this *OwnerCache) activate(cachekey OwnerName, id string, active bool, namespaces utils.StringSet, changeset utils.StringSet, counts ProviderTypeCounts) {
 	this.pendingids.Remove(id)
 	if active {
 		e, ok := this.ownerids[id]
@@ -189,12 +220,15 @@ func (this *OwnerCache) activate(cachekey OwnerName, id string, active bool, cha
 			e.entrycounts = ProviderTypeCounts{}
 		}
 		e.refcount++
+		if namespaces != nil {
+			e.scoped++
+		}
 		this.ownerids[id] = e
 		if e.refcount == 1 {
 			changeset.Add(id)
 		}
 	}
-	this.owners[cachekey] = OwnerObjectInfo{id: id, active: active}
+	this.owners[cachekey] = OwnerObjectInfo{id: id, active: active, namespaces: namespaces}
 }
 
 func (this *OwnerCache) SetPending(id string) {
@@ -19,8 +19,9 @@ const ident = "TEST"
 // test driver
 
 type owner struct {
-	id     string
-	active bool
+	id         string
+	active     bool
+	namespaces utils.StringSet
 }
 type TestOwnerCacheContext struct {
 	ids map[OwnerName]owner
@@ -38,21 +39,26 @@ func (this *TestOwnerCacheContext) EnqueueKey(key resources.ClusterObjectKey) er
 	cachekey := OwnerName(key.Name())
 	old, ok := this.ids[cachekey]
 	if ok {
-		this.OwnerCache.updateOwnerData(cachekey, old.id, old.active, nil)
+		this.OwnerCache.updateOwnerData(cachekey, old.id, old.active, old.namespaces, nil)
 	}
 	return nil
 }
 
 func (this *TestOwnerCacheContext) updateOwnerData(cachekey OwnerName, id string, active bool) (changeset utils.StringSet, activeset utils.StringSet) {
-	return this._updateOwnerData(cachekey, id, active)
+	return this._updateOwnerData(cachekey, id, active, nil)
 }
 
-func (this *TestOwnerCacheContext) _updateOwnerData(cachekey OwnerName, id string, active bool) (changeset utils.StringSet, activeset utils.StringSet) {
+func (this *TestOwnerCacheContext) updateScopedOwnerData(cachekey OwnerName, id string, active bool, namespaces ...string) (changeset utils.StringSet, activeset utils.StringSet) {
+	return this._updateOwnerData(cachekey, id, active, utils.NewStringSet(namespaces...))
+}
+
+func (this *TestOwnerCacheContext) _updateOwnerData(cachekey OwnerName, id string, active bool, namespaces utils.StringSet) (changeset utils.StringSet, activeset utils.StringSet) {
 	this.ids[cachekey] = owner{
-		id:     id,
-		active: active,
+		id:         id,
+		active:     active,
+		namespaces: namespaces,
 	}
-	return this.OwnerCache.updateOwnerData(cachekey, id, active, nil)
+	return this.OwnerCache.updateOwnerData(cachekey, id, active, namespaces, nil)
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -208,4 +214,48 @@ var _ = ginkgov2.Describe("Owner cache", func() {
 
 		Expect(cache.GetIds()).To(Equal(utils.NewStringSet(ident)))
 	})
+
+	ginkgov2.It("restricts owner ids to namespaces", func() {
+		cache.updateScopedOwnerData(name1, "id1", true, "ns1", "ns2")
+
+		Expect(cache.GetIds()).To(Equal(utils.NewStringSet(ident, "id1")))
+		Expect(cache.IsResponsibleForNamespace("id1", "ns1")).To(BeTrue())
+		Expect(cache.IsResponsibleForNamespace("id1", "ns2")).To(BeTrue())
+		Expect(cache.IsResponsibleForNamespace("id1", "other")).To(BeFalse())
+		Expect(cache.IsResponsibleForNamespace(ident, "other")).To(BeTrue())
+		Expect(cache.IsResponsibleForNamespace("id2", "ns1")).To(BeFalse())
+	})
+
+	ginkgov2.It("combines namespaces of owners with the same owner id", func() {
+		cache.updateScopedOwnerData(name1, "id1", true, "ns1")
+		cache.updateScopedOwnerData(name2, "id1", true, "ns2")
+		Expect(cache.IsResponsibleForNamespace("id1", "ns2")).To(BeTrue())
+		Expect(cache.IsResponsibleForNamespace("id1", "other")).To(BeFalse())
+
+		cache.updateOwnerData(name2, "id1", true)
+		Expect(cache.IsResponsibleForNamespace("id1", "other")).To(BeTrue())
+
+		cache.DeleteOwner(key2)
+		Expect(cache.IsResponsibleForNamespace("id1", "ns1")).To(BeTrue())
+		Expect(cache.IsResponsibleForNamespace("id1", "other")).To(BeFalse())
+	})
+
+	ginkgov2.It("reports changed namespaces of an active owner", func() {
+		changed, _ := cache.updateScopedOwnerData(name1, "id1", true, "ns1")
+		Expect(changed).To(Equal(utils.NewStringSet("id1")))
+		changed, _ = cache.updateScopedOwnerData(name1, "id1", true, "ns1")
+		Expect(changed).To(Equal(utils.NewStringSet()))
+		changed, _ = cache.updateScopedOwnerData(name1, "id1", true, "ns2")
+		Expect(changed).To(Equal(utils.NewStringSet("id1")))
+		Expect(cache.IsResponsibleForNamespace("id1", "ns1")).To(BeFalse())
+		changed, _ = cache.updateOwnerData(name1, "id1", true)
+		Expect(changed).To(Equal(utils.NewStringSet("id1")))
+		Expect(cache.IsResponsibleForNamespace("id1", "ns1")).To(BeTrue())
+	})
+
+	ginkgov2.It("ignores namespaces of inactive owners", func() {
+		cache.updateScopedOwnerData(name1, "id1", false, "ns1")
+		cache.updateOwnerData(name2, "id1", true)
+		Expect(cache.IsResponsibleForNamespace("id1", "other")).To(BeTrue())
+	})
 })
@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+const (
+	// OwnerConflictStrategyNone disables the conflict resolution, all controller instances using an owner id write its records.
+	OwnerConflictStrategyNone = "none"
+	// OwnerConflictStrategyFirstWriterWins defers to the controller instance holding a valid lease on the records.
+	OwnerConflictStrategyFirstWriterWins = "first-writer-wins"
+	// OwnerConflictStrategyPriority defers to the controller instance with the higher priority. For equal priorities,
+	// the instance holding a valid lease on the records wins.
+	OwnerConflictStrategyPriority = "priority"
+)
+
+// OwnerConflictConfig configures the resolution of conflicts between controller instances using the same owner id
+// for the same records. Every instance marks the records it writes with its name, its priority, and a lease,
+// which is renewed periodically. An instance defers to another writer as long as its lease is valid and it takes
+// precedence according to the strategy.
+type OwnerConflictConfig struct {
+	// Strategy is the conflict resolution strategy (OwnerConflictStrategyFirstWriterWins or OwnerConflictStrategyPriority).
+	Strategy string
+	// Instance is the unique name of the controller instance.
+	Instance string
+	// Priority is the priority of the controller instance.
+	Priority int
+	// LeaseDuration is the validity of a lease, which is renewed after half of it.
+	LeaseDuration time.Duration
+}
+
+func (this *OwnerConflictConfig) String() string {
+	return fmt.Sprintf("%s (instance %s, priority %d, lease %v)", this.Strategy, this.Instance, this.Priority, this.LeaseDuration)
+}
+
+// newOwnerConflictConfig validates the owner conflict options and returns the config or nil if disabled.
+func newOwnerConflictConfig(strategy, instance string, priority int, leaseDuration time.Duration) (*OwnerConflictConfig, error) {
+	switch strategy {
+	case "", OwnerConflictStrategyNone:
+		return nil, nil
+	case OwnerConflictStrategyFirstWriterWins, OwnerConflictStrategyPriority:
+	default:
+		return nil, fmt.Errorf("invalid value %q for option %s (allowed values: %s, %s, %s)", strategy, OPT_OWNER_CONFLICT_STRATEGY,
+			OwnerConflictStrategyNone, OwnerConflictStrategyFirstWriterWins, OwnerConflictStrategyPriority)
+	}
+	if instance == "" {
+		return nil, fmt.Errorf("option %s is required for owner conflict strategy %s", OPT_OWNER_CONFLICT_INSTANCE, strategy)
+	}
+	if leaseDuration <= 0 {
+		return nil, fmt.Errorf("invalid value %v for option %s: must be positive", leaseDuration, OPT_OWNER_CONFLICT_LEASE)
+	}
+	return &OwnerConflictConfig{
+		Strategy:      strategy,
+		Instance:      instance,
+		Priority:      priority,
+		LeaseDuration: leaseDuration,
+	}, nil
+}
+
+// RenewInterval returns the interval after which the leases of the written records are renewed.
+func (this *OwnerConflictConfig) RenewInterval() time.Duration {
+	return this.LeaseDuration / 2
+}
+
+// markWriter marks the set as written by this instance. The lease of the base set is kept if it is held by this
+// instance and does not need to be renewed yet, so that the records are not rewritten on every reconciliation.
+func (this *OwnerConflictConfig) markWriter(set, base *dns.DNSSet, now time.Time) {
+	if this == nil {
+		return
+	}
+	lease := now
+	if base != nil && base.GetMetaAttr(dns.ATTR_WRITER) == this.Instance {
+		if t, ok := getLease(base); ok && now.Sub(t) < this.RenewInterval() {
+			lease = t
+		}
+	}
+	set.SetMetaAttr(dns.ATTR_WRITER, this.Instance)
+	set.SetMetaAttr(dns.ATTR_WRITER_PRIORITY, strconv.Itoa(this.Priority))
+	set.SetMetaAttr(dns.ATTR_LEASE, strconv.FormatInt(lease.Unix(), 10))
+}
+
+// deferTo returns the controller instance this instance must defer to for the given set or an empty string.
+func (this *OwnerConflictConfig) deferTo(set *dns.DNSSet, now time.Time) string {
+	if this == nil {
+		return ""
+	}
+	writer := set.GetMetaAttr(dns.ATTR_WRITER)
+	if writer == "" || writer == this.Instance {
+		return ""
+	}
+	lease, ok := getLease(set)
+	if !ok || now.After(lease.Add(this.LeaseDuration)) {
+		// lease expired, the other instance seems to be gone
+		return ""
+	}
+	if this.Strategy == OwnerConflictStrategyPriority {
+		priority, err := strconv.Atoi(set.GetMetaAttr(dns.ATTR_WRITER_PRIORITY))
+		if err == nil && priority < this.Priority {
+			return ""
+		}
+	}
+	return writer
+}
+
+func getLease(set *dns.DNSSet) (time.Time, bool) {
+	secs, err := strconv.ParseInt(set.GetMetaAttr(dns.ATTR_LEASE), 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(secs, 0), true
+}
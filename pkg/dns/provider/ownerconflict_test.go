@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"time"
+
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+var _ = ginkgov2.Describe("Owner conflict resolution", func() {
+	var (
+		now  time.Time
+		name = dns.DNSSetName{DNSName: "a.example.com"}
+	)
+
+	newConfig := func(strategy, instance string, priority int) *OwnerConflictConfig {
+		config, err := newOwnerConflictConfig(strategy, instance, priority, 10*time.Minute)
+		Expect(err).NotTo(HaveOccurred())
+		return config
+	}
+
+	written := func(config *OwnerConflictConfig, base *dns.DNSSet, at time.Time) *dns.DNSSet {
+		set := dns.NewDNSSet(name, nil)
+		set.SetOwner("owner")
+		config.markWriter(set, base, at)
+		return set
+	}
+
+	ginkgov2.BeforeEach(func() {
+		now = time.Now()
+	})
+
+	ginkgov2.It("validates the options", func() {
+		Expect(newOwnerConflictConfig("", "", 0, 0)).To(BeNil())
+		Expect(newOwnerConflictConfig(OwnerConflictStrategyNone, "a", 0, time.Minute)).To(BeNil())
+		_, err := newOwnerConflictConfig("last-writer-wins", "a", 0, time.Minute)
+		Expect(err).To(MatchError(ContainSubstring("invalid value \"last-writer-wins\" for option owner-conflict-strategy")))
+		_, err = newOwnerConflictConfig(OwnerConflictStrategyPriority, "", 0, time.Minute)
+		Expect(err).To(MatchError("option owner-conflict-instance is required for owner conflict strategy priority"))
+		_, err = newOwnerConflictConfig(OwnerConflictStrategyPriority, "a", 0, 0)
+		Expect(err).To(MatchError("invalid value 0s for option owner-conflict-lease-duration: must be positive"))
+	})
+
+	ginkgov2.It("never defers if disabled", func() {
+		var disabled *OwnerConflictConfig
+		set := written(newConfig(OwnerConflictStrategyFirstWriterWins, "a", 0), nil, now)
+		Expect(disabled.deferTo(set, now)).To(BeEmpty())
+		plain := written(disabled, nil, now)
+		Expect(plain.GetMetaAttr(dns.ATTR_WRITER)).To(BeEmpty())
+	})
+
+	ginkgov2.It("defers to the first writer until its lease expires", func() {
+		a := newConfig(OwnerConflictStrategyFirstWriterWins, "a", 0)
+		b := newConfig(OwnerConflictStrategyFirstWriterWins, "b", 0)
+		set := written(a, nil, now)
+		Expect(a.deferTo(set, now)).To(BeEmpty())
+		Expect(b.deferTo(set, now.Add(5*time.Minute))).To(Equal("a"))
+		Expect(b.deferTo(set, now.Add(11*time.Minute))).To(BeEmpty())
+		set = written(b, set, now.Add(11*time.Minute))
+		Expect(a.deferTo(set, now.Add(11*time.Minute))).To(Equal("b"))
+	})
+
+	ginkgov2.It("defers to the writer with higher or equal priority", func() {
+		low := newConfig(OwnerConflictStrategyPriority, "low", 1)
+		high := newConfig(OwnerConflictStrategyPriority, "high", 2)
+		other := newConfig(OwnerConflictStrategyPriority, "other", 2)
+		set := written(low, nil, now)
+		Expect(high.deferTo(set, now)).To(BeEmpty())
+		set = written(high, set, now)
+		Expect(low.deferTo(set, now)).To(Equal("high"))
+		Expect(other.deferTo(set, now)).To(Equal("high"))
+	})
+
+	ginkgov2.It("renews the own lease only after half of its duration", func() {
+		a := newConfig(OwnerConflictStrategyFirstWriterWins, "a", 0)
+		set := written(a, nil, now)
+		lease := set.GetMetaAttr(dns.ATTR_LEASE)
+		Expect(written(a, set, now.Add(4*time.Minute)).GetMetaAttr(dns.ATTR_LEASE)).To(Equal(lease))
+		Expect(written(a, set, now.Add(6*time.Minute)).GetMetaAttr(dns.ATTR_LEASE)).NotTo(Equal(lease))
+	})
+})
@@ -0,0 +1,250 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	miekgdns "github.com/miekg/dns"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+type propagationQueryConfig struct {
+	lookupNS func(ctx context.Context, domain string) ([]string, error)
+	query    func(ctx context.Context, server, name, rtype string) ([]string, error)
+}
+
+// propagationQuery allows to override the DNS queries against the authoritative name servers for testing purposes
+var propagationQuery = propagationQueryConfig{
+	lookupNS: lookupAuthoritativeServers,
+	query:    queryAuthoritativeServer,
+}
+
+// propagationExpectation describes the records of an entry expected at the authoritative name servers.
+type propagationExpectation struct {
+	dnsName string
+	zoneid  dns.ZoneID
+	// records contains the expected values per record type. An empty set only requires a non-empty answer.
+	records map[string]sets.Set[string]
+}
+
+// newPropagationExpectation returns the expectation for the targets of an entry.
+// For entries with routing policy, the answer depends on the policy, so only a non-empty answer is expected.
+func newPropagationExpectation(dnsName string, zoneid dns.ZoneID, targets Targets, routingPolicy *dns.RoutingPolicy) *propagationExpectation {
+	exp := &propagationExpectation{
+		dnsName: dnsName,
+		zoneid:  zoneid,
+		records: map[string]sets.Set[string]{},
+	}
+	for _, t := range targets {
+		values := exp.records[t.GetRecordType()]
+		if values == nil {
+			values = sets.New[string]()
+			exp.records[t.GetRecordType()] = values
+		}
+		if routingPolicy == nil {
+			if value := normalizeRecordValue(t.GetRecordType(), t.GetHostName()); value != "" {
+				values.Insert(value)
+			}
+		}
+	}
+	return exp
+}
+
+// verify checks the expected records at all authoritative name servers of the zone domain.
+func (this *propagationExpectation) verify(ctx context.Context, zoneDomain string) error {
+	servers, err := propagationQuery.lookupNS(ctx, zoneDomain)
+	if err != nil {
+		return fmt.Errorf("cannot lookup name servers of %s: %w", zoneDomain, err)
+	}
+	if len(servers) == 0 {
+		return fmt.Errorf("no name servers found for %s", zoneDomain)
+	}
+	rtypes := sets.List(sets.KeySet(this.records))
+	for _, server := range servers {
+		for _, rtype := range rtypes {
+			answer, err := propagationQuery.query(ctx, server, this.dnsName, rtype)
+			if err != nil {
+				return fmt.Errorf("query for %s record of %s at %s failed: %w", rtype, this.dnsName, server, err)
+			}
+			values := sets.New[string]()
+			for _, value := range answer {
+				values.Insert(normalizeRecordValue(rtype, value))
+			}
+			if len(values) == 0 {
+				return fmt.Errorf("%s record of %s not found at %s", rtype, this.dnsName, server)
+			}
+			if missing := this.records[rtype].Difference(values); len(missing) > 0 {
+				return fmt.Errorf("%s record of %s at %s is missing %s", rtype, this.dnsName, server, strings.Join(sets.List(missing), ", "))
+			}
+		}
+	}
+	return nil
+}
+
+func normalizeRecordValue(rtype, value string) string {
+	switch rtype {
+	case dns.RS_A, dns.RS_AAAA:
+		if ip := net.ParseIP(value); ip != nil {
+			return ip.String()
+		}
+	case dns.RS_CNAME:
+		return strings.ToLower(strings.TrimSuffix(value, "."))
+	case dns.RS_TXT:
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			return unquoted
+		}
+	}
+	return value
+}
+
+func lookupAuthoritativeServers(ctx context.Context, domain string) ([]string, error) {
+	nss, err := net.DefaultResolver.LookupNS(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+	servers := make([]string, 0, len(nss))
+	for _, ns := range nss {
+		servers = append(servers, strings.TrimSuffix(ns.Host, "."))
+	}
+	sort.Strings(servers)
+	return servers, nil
+}
+
+func queryAuthoritativeServer(ctx context.Context, server, name, rtype string) ([]string, error) {
+	qtype, ok := miekgdns.StringToType[rtype]
+	if !ok {
+		return nil, fmt.Errorf("unsupported record type %s", rtype)
+	}
+	msg := new(miekgdns.Msg)
+	msg.SetQuestion(miekgdns.Fqdn(name), qtype)
+	msg.RecursionDesired = false
+	resp, _, err := new(miekgdns.Client).ExchangeContext(ctx, msg, net.JoinHostPort(server, "53"))
+	if err != nil {
+		return nil, err
+	}
+	if resp.Rcode != miekgdns.RcodeSuccess && resp.Rcode != miekgdns.RcodeNameError {
+		return nil, fmt.Errorf("unexpected response code %s", miekgdns.RcodeToString[resp.Rcode])
+	}
+	var values []string
+	for _, rr := range resp.Answer {
+		if rr.Header().Rrtype != qtype {
+			continue
+		}
+		switch r := rr.(type) {
+		case *miekgdns.A:
+			values = append(values, r.A.String())
+		case *miekgdns.AAAA:
+			values = append(values, r.AAAA.String())
+		case *miekgdns.CNAME:
+			values = append(values, r.Target)
+		case *miekgdns.TXT:
+			values = append(values, strings.Join(r.Txt, ""))
+		default:
+			values = append(values, strings.TrimPrefix(rr.String(), rr.Header().String()))
+		}
+	}
+	return values, nil
+}
+
+type propagationJob struct {
+	cancel context.CancelFunc
+}
+
+// propagationVerifier verifies asynchronously that the records of entries are resolvable at the
+// authoritative name servers of their zones. The verification is retried until the timeout is reached.
+type propagationVerifier struct {
+	lock       sync.Mutex
+	logger     logger.LogContext
+	timeout    time.Duration
+	interval   time.Duration
+	zoneDomain func(zoneid dns.ZoneID) (string, bool)
+	jobs       map[resources.ObjectName]*propagationJob
+}
+
+func newPropagationVerifier(logger logger.LogContext, timeout, interval time.Duration, zoneDomain func(zoneid dns.ZoneID) (string, bool)) *propagationVerifier {
+	return &propagationVerifier{
+		logger:     logger,
+		timeout:    timeout,
+		interval:   interval,
+		zoneDomain: zoneDomain,
+		jobs:       map[resources.ObjectName]*propagationJob{},
+	}
+}
+
+// Start starts the verification for the given entry. A running verification of the entry is cancelled.
+// The report function is called with the result once the records are found or the timeout is reached.
+func (this *propagationVerifier) Start(ctx context.Context, name resources.ObjectName, exp *propagationExpectation, report func(propagated bool, err error)) {
+	ctx, cancel := context.WithTimeout(ctx, this.timeout)
+	job := &propagationJob{cancel: cancel}
+
+	this.lock.Lock()
+	if old := this.jobs[name]; old != nil {
+		old.cancel()
+	}
+	this.jobs[name] = job
+	this.lock.Unlock()
+
+	go func() {
+		defer this.finish(name, job)
+		propagated, err := this.run(ctx, exp)
+		if !propagated && ctx.Err() == context.Canceled {
+			// superseded by a newer verification or the entry has been deleted
+			return
+		}
+		report(propagated, err)
+	}()
+}
+
+// Cancel cancels a running verification for the given entry.
+func (this *propagationVerifier) Cancel(name resources.ObjectName) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if job := this.jobs[name]; job != nil {
+		job.cancel()
+		delete(this.jobs, name)
+	}
+}
+
+func (this *propagationVerifier) finish(name resources.ObjectName, job *propagationJob) {
+	job.cancel()
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if this.jobs[name] == job {
+		delete(this.jobs, name)
+	}
+}
+
+func (this *propagationVerifier) run(ctx context.Context, exp *propagationExpectation) (bool, error) {
+	var lastErr error
+	for attempt := 1; ; attempt++ {
+		if domain, ok := this.zoneDomain(exp.zoneid); !ok {
+			lastErr = fmt.Errorf("zone %s not found", exp.zoneid)
+		} else if lastErr = this.verifyAttempt(ctx, exp, domain); lastErr == nil {
+			return true, nil
+		}
+		this.logger.Debugf("propagation of %s not yet verified (attempt %d): %s", exp.dnsName, attempt, lastErr)
+		if err := sleep(ctx, this.interval); err != nil {
+			return false, lastErr
+		}
+	}
+}
+
+func (this *propagationVerifier) verifyAttempt(ctx context.Context, exp *propagationExpectation, zoneDomain string) error {
+	ctx, cancel := context.WithTimeout(ctx, this.interval)
+	defer cancel()
+	return exp.verify(ctx, zoneDomain)
+}
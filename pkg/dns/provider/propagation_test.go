@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+var _ = ginkgov2.Describe("Propagation verification", func() {
+	var (
+		zoneid  = dns.NewZoneID("test", "zone1")
+		lock    sync.Mutex
+		answers map[string][]string
+		saved   propagationQueryConfig
+	)
+
+	ginkgov2.BeforeEach(func() {
+		saved = propagationQuery
+		answers = map[string][]string{}
+		propagationQuery = propagationQueryConfig{
+			lookupNS: func(_ context.Context, domain string) ([]string, error) {
+				if domain != "example.com" {
+					return nil, fmt.Errorf("unknown domain %s", domain)
+				}
+				return []string{"ns1.example.com", "ns2.example.com"}, nil
+			},
+			query: func(_ context.Context, server, name, rtype string) ([]string, error) {
+				lock.Lock()
+				defer lock.Unlock()
+				return answers[server+"/"+name+"/"+rtype], nil
+			},
+		}
+	})
+
+	ginkgov2.AfterEach(func() {
+		propagationQuery = saved
+	})
+
+	setAnswer := func(server, name, rtype string, values ...string) {
+		lock.Lock()
+		defer lock.Unlock()
+		answers[server+"/"+name+"/"+rtype] = values
+	}
+	setAnswers := func(name, rtype string, values ...string) {
+		setAnswer("ns1.example.com", name, rtype, values...)
+		setAnswer("ns2.example.com", name, rtype, values...)
+	}
+
+	ginkgov2.It("succeeds if all targets are found at all name servers", func() {
+		targets := Targets{
+			dnsutils.NewTarget(dns.RS_A, "1.1.1.1", 300),
+			dnsutils.NewTarget(dns.RS_A, "2.2.2.2", 300),
+			dnsutils.NewText("foo bar", 300),
+		}
+		exp := newPropagationExpectation("a.example.com", zoneid, targets, nil)
+		setAnswers("a.example.com", dns.RS_A, "2.2.2.2", "1.1.1.1")
+		setAnswers("a.example.com", dns.RS_TXT, "foo bar")
+		Expect(exp.verify(context.Background(), "example.com")).To(Succeed())
+	})
+
+	ginkgov2.It("fails if a target is missing at one name server", func() {
+		targets := Targets{dnsutils.NewTarget(dns.RS_CNAME, "target.example.org", 300)}
+		exp := newPropagationExpectation("a.example.com", zoneid, targets, nil)
+		setAnswer("ns1.example.com", "a.example.com", dns.RS_CNAME, "Target.Example.org.")
+		Expect(exp.verify(context.Background(), "example.com")).To(MatchError("CNAME record of a.example.com not found at ns2.example.com"))
+		setAnswer("ns2.example.com", "a.example.com", dns.RS_CNAME, "other.example.org.")
+		Expect(exp.verify(context.Background(), "example.com")).To(MatchError("CNAME record of a.example.com at ns2.example.com is missing target.example.org"))
+	})
+
+	ginkgov2.It("only requires an answer for entries with routing policy", func() {
+		targets := Targets{dnsutils.NewTarget(dns.RS_A, "1.1.1.1", 300)}
+		policy := dns.NewRoutingPolicy(dns.RoutingPolicyWeighted, "weight", "10")
+		exp := newPropagationExpectation("a.example.com", zoneid, targets, policy)
+		setAnswers("a.example.com", dns.RS_A, "3.3.3.3")
+		Expect(exp.verify(context.Background(), "example.com")).To(Succeed())
+	})
+
+	ginkgov2.It("reports propagation after retries", func() {
+		verifier := newPropagationVerifier(logger.New(), time.Second, 10*time.Millisecond, func(dns.ZoneID) (string, bool) {
+			return "example.com", true
+		})
+		targets := Targets{dnsutils.NewTarget(dns.RS_A, "1.1.1.1", 300)}
+		exp := newPropagationExpectation("a.example.com", zoneid, targets, nil)
+		result := make(chan bool, 1)
+		go func() {
+			time.Sleep(30 * time.Millisecond)
+			setAnswers("a.example.com", dns.RS_A, "1.1.1.1")
+		}()
+		verifier.Start(context.Background(), resources.NewObjectName("ns", "a"), exp, func(propagated bool, _ error) {
+			result <- propagated
+		})
+		Eventually(result).Should(Receive(BeTrue()))
+	})
+
+	ginkgov2.It("reports failure after the timeout", func() {
+		verifier := newPropagationVerifier(logger.New(), 50*time.Millisecond, 10*time.Millisecond, func(dns.ZoneID) (string, bool) {
+			return "example.com", true
+		})
+		targets := Targets{dnsutils.NewTarget(dns.RS_A, "1.1.1.1", 300)}
+		exp := newPropagationExpectation("a.example.com", zoneid, targets, nil)
+		errs := make(chan error, 1)
+		verifier.Start(context.Background(), resources.NewObjectName("ns", "a"), exp, func(propagated bool, err error) {
+			Expect(propagated).To(BeFalse())
+			errs <- err
+		})
+		Eventually(errs).Should(Receive(MatchError(ContainSubstring("A record of a.example.com not found"))))
+	})
+})
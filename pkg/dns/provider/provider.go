@@ -5,21 +5,28 @@
 package provider
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"maps"
 	"reflect"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
 
 	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
 	"github.com/gardener/external-dns-management/pkg/dns"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
 	"github.com/gardener/external-dns-management/pkg/dns/provider/selection"
 	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
 	"github.com/gardener/external-dns-management/pkg/server/metrics"
@@ -32,21 +39,38 @@ import (
 
 const ZoneCachePrefix = "zc-"
 
-func (this DNSProviders) LookupFor(dns string) DNSProvider {
+// providerProbeTimeout is the timeout for the credentials probe of a provider account.
+const providerProbeTimeout = 30 * time.Second
+
+// LookupFor returns the provider with the longest domain match for the DNS name. Providers managing all
+// given record types according to their record type selection are preferred.
+func (this DNSProviders) LookupFor(dns string, rtypes ...string) DNSProvider {
 	var found DNSProvider
 	match := -1
+	accepting := false
 	for _, p := range this {
 		n := p.Match(dns)
 		if n > 0 {
-			if match < n || match == n && found != nil && strings.Compare(p.AccountHash(), found.AccountHash()) < 0 {
+			a := acceptsRecordTypes(p, rtypes)
+			if a && !accepting || a == accepting && (match < n || match == n && found != nil && strings.Compare(p.AccountHash(), found.AccountHash()) < 0) {
 				found = p
 				match = n
+				accepting = a
 			}
 		}
 	}
 	return found
 }
 
+func acceptsRecordTypes(p DNSProvider, rtypes []string) bool {
+	for _, rtype := range rtypes {
+		if !p.AcceptsRecordType(rtype) {
+			return false
+		}
+	}
+	return true
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 
 type DNSAccount struct {
@@ -56,6 +80,13 @@ type DNSAccount struct {
 
 	hash    string
 	clients resources.ObjectNameSet
+
+	// requestSlots limits the number of concurrent backend requests (unlimited if nil)
+	requestSlots chan struct{}
+	// breaker stops calling a persistently failing backend (disabled if nil)
+	breaker *circuitBreaker
+
+	probeZones func(ctx context.Context) (DNSHostedZones, error)
 }
 
 var (
@@ -73,6 +104,38 @@ func NewDNSAccount(config utils.Properties, handler DNSHandler, hash string) *DN
 	}
 }
 
+// SetMaxConcurrentRequests limits the number of concurrent backend requests of the account (unlimited if 0).
+func (this *DNSAccount) SetMaxConcurrentRequests(n int) {
+	if n > 0 {
+		this.requestSlots = make(chan struct{}, n)
+	} else {
+		this.requestSlots = nil
+	}
+}
+
+// SetCircuitBreaker enables the circuit breaker of the account, which rejects backend requests for the cool-down
+// period after the given number of consecutive failures (disabled if 0).
+func (this *DNSAccount) SetCircuitBreaker(threshold int, coolDown time.Duration) {
+	this.breaker = newCircuitBreaker(threshold, coolDown)
+}
+
+// CircuitBreakerStatus returns a CircuitOpen error if the circuit breaker of the account is open.
+func (this *DNSAccount) CircuitBreakerStatus() error {
+	if cerr := this.breaker.Status(); cerr != nil {
+		return cerr
+	}
+	return nil
+}
+
+// acquireRequestSlot blocks until a backend request is allowed and returns the function to release it again.
+func (this *DNSAccount) acquireRequestSlot() func() {
+	if this.requestSlots == nil {
+		return func() {}
+	}
+	this.requestSlots <- struct{}{}
+	return func() { <-this.requestSlots }
+}
+
 func (this *DNSAccount) AddGenericRequests(requestType string, n int) {
 	metrics.AddRequests(this.handler.ProviderType(), this.hash, requestType, n, nil)
 }
@@ -90,7 +153,9 @@ func (this *DNSAccount) Hash() string {
 }
 
 func (this *DNSAccount) GetZones() (DNSHostedZones, error) {
+	release := this.acquireRequestSlot()
 	zones, err := this.handler.GetZones()
+	release()
 	if err == nil {
 		zones = addObviousForwardedDomains(zones)
 		this.Succeeded()
@@ -100,6 +165,26 @@ func (this *DNSAccount) GetZones() (DNSHostedZones, error) {
 	return zones, err
 }
 
+// Probe checks the credentials of the account by listing the hosted zones bypassing the zone cache.
+// It fails if the provider does not answer within the given timeout. The listing is canceled on timeout,
+// handlers not passing the context to their clients are only bounded by the timeouts of these clients.
+// The probe does not take one of the request slots, so it cannot starve or be starved by regular requests.
+func (this *DNSAccount) Probe(timeout time.Duration) error {
+	probe := this.probeZones
+	if probe == nil {
+		probe = func(_ context.Context) (DNSHostedZones, error) {
+			return this.handler.GetZones()
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	_, err := probe(ctx)
+	if err != nil && ctx.Err() != nil {
+		return fmt.Errorf("no response within %s: %w", timeout, err)
+	}
+	return err
+}
+
 func addObviousForwardedDomains(zones DNSHostedZones) DNSHostedZones {
 	result := make(DNSHostedZones, len(zones))
 	for i, zone := range zones {
@@ -131,8 +216,38 @@ func addObviousForwardedDomains(zones DNSHostedZones) DNSHostedZones {
 	return result
 }
 
+// guardStateUpdater reports the zone state requests to the DNS backend to the circuit breaker.
+// Zone states served from the zone cache are neither rejected nor reported.
+func (this *DNSAccount) guardStateUpdater(updater ZoneCacheStateUpdater) ZoneCacheStateUpdater {
+	return func(zone DNSHostedZone, cache ZoneCache) (DNSZoneState, error) {
+		if err := this.breaker.Allow(); err != nil {
+			return nil, err
+		}
+		state, err := updater(zone, cache)
+		this.breaker.Done(err)
+		return state, err
+	}
+}
+
+// guardChangesUpdater reports the requests for the changes of a zone state to the circuit breaker.
+func (this *DNSAccount) guardChangesUpdater(updater ZoneCacheStateChangesUpdater) ZoneCacheStateChangesUpdater {
+	if updater == nil {
+		return nil
+	}
+	return func(zone DNSHostedZone, marker string) (*ZoneStateChanges, error) {
+		if err := this.breaker.Allow(); err != nil {
+			return nil, err
+		}
+		changes, err := updater(zone, marker)
+		this.breaker.Done(err)
+		return changes, err
+	}
+}
+
 func (this *DNSAccount) GetZoneState(zone DNSHostedZone) (DNSZoneState, error) {
+	release := this.acquireRequestSlot()
 	state, err := this.handler.GetZoneState(zone)
+	release()
 	if err == nil {
 		this.Succeeded()
 	} else {
@@ -146,13 +261,101 @@ func (this *DNSAccount) ReportZoneStateConflict(zone DNSHostedZone, err error) b
 }
 
 func (this *DNSAccount) ExecuteRequests(logger logger.LogContext, zone DNSHostedZone, state DNSZoneState, reqs []*ChangeRequest) error {
-	return this.handler.ExecuteRequests(logger, zone, state, reqs)
+	maxSize := 0
+	if limiter, ok := this.handler.(ChangeBatchLimiter); ok {
+		maxSize = limiter.MaxChangeBatchSize()
+	}
+	if limits := this.TXTRecordLimits(); limits.ChunkSize > 0 {
+		reqs = chunkTXTRecords(reqs, limits.ChunkSize)
+	}
+	return executeInBatches(logger, reqs, maxSize, func(batch []*ChangeRequest) error {
+		if err := this.breaker.Allow(); err != nil {
+			return err
+		}
+		defer this.acquireRequestSlot()()
+		err := this.handler.ExecuteRequests(logger, zone, state, batch)
+		this.breaker.Done(err)
+		return err
+	})
 }
 
 func (this *DNSAccount) MapTargets(dnsName string, targets []Target) []Target {
 	return this.handler.MapTargets(dnsName, targets)
 }
 
+func (this *DNSAccount) NormalizeRoutingPolicy(policy *dns.RoutingPolicy) *dns.RoutingPolicy {
+	if n, ok := this.handler.(RoutingPolicyNormalizer); ok {
+		return n.NormalizeRoutingPolicy(policy)
+	}
+	return policy
+}
+
+// NormalizeProviderAttributes maps the provider attributes of an entry to the attributes read back from the DNS backend.
+func (this *DNSAccount) NormalizeProviderAttributes(attrs map[string]string) map[string]string {
+	if n, ok := this.handler.(ProviderAttributesNormalizer); ok {
+		return n.NormalizeProviderAttributes(attrs)
+	}
+	return attrs
+}
+
+// DeniedZones returns the ids of the hosted zones the credentials grant no access to.
+func (this *DNSAccount) DeniedZones() []string {
+	if r, ok := this.handler.(DeniedZonesReporter); ok {
+		return r.DeniedZones()
+	}
+	return nil
+}
+
+// calcSelection calculates the zone and domain selection of the provider for the zones of the account.
+// The zones the handler of the account reports as access denied are reported as problematic zones, too.
+func calcSelection(spec api.DNSProviderSpec, account *DNSAccount, zones DNSHostedZones) selection.SelectionResult {
+	results := selection.CalcZoneAndDomainSelection(spec, toLightZones(zones))
+	results.AddAccessDeniedZones(account.DeniedZones())
+	return results
+}
+
+func (this *DNSAccount) TXTRecordLimits() dns.TXTRecordLimits {
+	if l, ok := this.handler.(TXTRecordLimiter); ok {
+		return l.TXTRecordLimits()
+	}
+	return dns.TXTRecordLimits{}
+}
+
+func (this *DNSAccount) MaxWeight() int64 {
+	if s, ok := this.handler.(WeightScaler); ok {
+		return s.MaxWeight()
+	}
+	return dns.DefaultMaxWeight
+}
+
+func (this *DNSAccount) MinTTL() int64 {
+	if l, ok := this.handler.(TTLLimiter); ok {
+		return l.MinTTL()
+	}
+	return 0
+}
+
+func (this *DNSAccount) NameForm() dns.NameForm {
+	if f, ok := this.handler.(NameFormer); ok {
+		return f.NameForm()
+	}
+	return dns.NameForm{}
+}
+
+func (this *DNSAccount) SupportsApexAlias(target Target) bool {
+	if s, ok := this.handler.(ApexAliasSupporter); ok {
+		return s.SupportsApexAlias(target)
+	}
+	return false
+}
+
+func (this *DNSAccount) Capabilities() Capabilities {
+	if r, ok := this.handler.(CapabilitiesReporter); ok {
+		return r.Capabilities()
+	}
+	return Capabilities{}
+}
+
 func (this *DNSAccount) Release() {
 	this.handler.Release()
 }
@@ -175,12 +378,26 @@ func NewAccountCache(ttl time.Duration, opts *FactoryOptions) *AccountCache {
 
 func (this *AccountCache) Get(logger logger.LogContext, provider *dnsutils.DNSProviderObject, props utils.Properties, state *state) (*DNSAccount, error) {
 	name := provider.ObjectName()
-	hash := this.Hash(props, provider.Spec().Type, provider.Spec().ProviderConfig)
+	maxConcurrentRequests := 0
+	n := provider.Spec().MaxConcurrentRequests
+	if n == nil {
+		n = state.config.ProviderTypeDefaults.Get(provider.TypeCode()).MaxConcurrentRequests
+	}
+	if n != nil {
+		if *n <= 0 {
+			return nil, fmt.Errorf("invalid maxConcurrentRequests %d: must be positive", *n)
+		}
+		maxConcurrentRequests = *n
+	}
+	zoneTags := provider.Spec().ZoneTagSelector != nil
+	hash := this.Hash(props, provider.Spec().Type, provider.Spec().ProviderConfig, maxConcurrentRequests, zoneTags)
 	this.lock.Lock()
 	defer this.lock.Unlock()
 	a := this.cache[hash]
 	if a == nil {
 		a = NewDNSAccount(props, nil, hash)
+		a.SetMaxConcurrentRequests(maxConcurrentRequests)
+		a.SetCircuitBreaker(state.config.CircuitBreakerThreshold, state.config.CircuitBreakerCoolDown)
 		syncPeriod := state.GetContext().GetPoolPeriod("dns")
 		if syncPeriod == nil {
 			return nil, fmt.Errorf("Pool dns not found")
@@ -191,6 +408,7 @@ func (this *AccountCache) Get(logger logger.LogContext, provider *dnsutils.DNSPr
 			zonesTTL:              this.ttl,
 			zoneStates:            state.zoneStates,
 			disableZoneStateCache: !state.config.ZoneStateCaching,
+			account:               a,
 		}
 
 		cfg := DNSHandlerConfig{
@@ -202,6 +420,7 @@ func (this *AccountCache) Get(logger logger.LogContext, provider *dnsutils.DNSPr
 			ZoneCacheFactory: cacheFactory,
 			Options:          this.options,
 			Metrics:          a,
+			ZoneTags:         zoneTags,
 		}
 		var err error
 		a.handler, err = state.GetHandlerFactory().Create(provider.TypeCode(), &cfg)
@@ -209,6 +428,11 @@ func (this *AccountCache) Get(logger logger.LogContext, provider *dnsutils.DNSPr
 			return nil, err
 		}
 		logger.Infof("creating account for %s (%s)", name, a.Hash())
+		if keys, _ := state.GetHandlerFactory().SecretKeys(provider.TypeCode()); keys != nil {
+			if unknown := keys.Unknown(props); len(unknown) > 0 {
+				logger.Warnf("secret contains keys not used by provider type %s: %s", provider.TypeCode(), strings.Join(unknown, ", "))
+			}
+		}
 		this.cache[hash] = a
 	}
 	old := len(a.clients)
@@ -239,7 +463,8 @@ func (this *AccountCache) Release(logger logger.LogContext, a *DNSAccount, name
 	}
 }
 
-func (this *AccountCache) Hash(props utils.Properties, ptype string, extension *runtime.RawExtension) string {
+// Hash calculates the account hash. Providers with different limits of concurrent requests must not share an account.
+func (this *AccountCache) Hash(props utils.Properties, ptype string, extension *runtime.RawExtension, maxConcurrentRequests int, zoneTags bool) string {
 	keys := make([]string, len(props))
 	i := 0
 	h := sha256.New224()
@@ -261,6 +486,14 @@ func (this *AccountCache) Hash(props utils.Properties, ptype string, extension *
 	}
 	h.Write(null)
 	h.Write([]byte(ptype))
+	if maxConcurrentRequests > 0 {
+		h.Write(null)
+		h.Write([]byte(strconv.Itoa(maxConcurrentRequests)))
+	}
+	if zoneTags {
+		h.Write(null)
+		h.Write([]byte("zonetags"))
+	}
 	return hex.EncodeToString(h.Sum(nil))
 }
 
@@ -275,17 +508,27 @@ type dnsProviderVersion struct {
 
 	defaultTTL int64
 
-	secret      resources.ObjectName
-	def_include utils.StringSet
-	def_exclude utils.StringSet
+	secret          resources.ObjectName
+	credentialsFile *credentialsFile
+	def_include     utils.StringSet
+	def_exclude     utils.StringSet
 
 	zones          DNSHostedZones
 	included_zones utils.StringSet
 	excluded_zones utils.StringSet
 
-	included  utils.StringSet
-	excluded  utils.StringSet
-	rateLimit *api.RateLimit
+	included    utils.StringSet
+	excluded    utils.StringSet
+	recordTypes selection.SubSelection
+	rateLimit   *api.RateLimit
+	migration   *api.DNSProviderMigrationStatus
+	mirror      *api.DNSProviderMirrorStatus
+
+	lastProbe    time.Time
+	lastProbeErr error
+
+	// failedPrimary contains the primary credentials if they failed and the next credentials are used instead.
+	failedPrimary utils.Properties
 }
 
 var _ DNSProvider = &dnsProviderVersion{}
@@ -318,6 +561,10 @@ func (this *dnsProviderVersion) equivalentTo(v *dnsProviderVersion) bool {
 	if !reflect.DeepEqual(this.defaultTTL, v.defaultTTL) {
 		return false
 	}
+	if this.Priority() != v.Priority() {
+		// entries matched by several providers may be reassigned
+		return false
+	}
 	if this.secret != nil && v.secret != nil && this.secret != v.secret {
 		return false
 	} else {
@@ -346,6 +593,7 @@ func updateDNSProvider(logger logger.LogContext, state *state, provider *dnsutil
 		this.excluded = last.excluded
 		this.included_zones = last.included_zones
 		this.excluded_zones = last.excluded_zones
+		this.recordTypes = last.recordTypes
 	} else {
 		this.included = utils.NewStringSet(provider.Status().Domains.Included...)
 		this.excluded = utils.NewStringSet(provider.Status().Domains.Excluded...)
@@ -355,6 +603,8 @@ func updateDNSProvider(logger logger.LogContext, state *state, provider *dnsutil
 
 	if provider.Spec().DefaultTTL != nil {
 		this.defaultTTL = *provider.Spec().DefaultTTL
+	} else if ttl := state.config.ProviderTypeDefaults.Get(provider.TypeCode()).DefaultTTL; ttl != nil {
+		this.defaultTTL = *ttl
 	} else {
 		this.defaultTTL = state.config.TTL
 	}
@@ -367,53 +617,82 @@ func updateDNSProvider(logger logger.LogContext, state *state, provider *dnsutil
 	var err error
 
 	ref := this.object.DNSProvider().Spec.SecretRef
-	if ref == nil {
-		return this, this.failed(logger, false, fmt.Errorf("no secret specified"), false)
+	if path := this.object.DNSProvider().Spec.CredentialsPath; path != nil {
+		if ref != nil {
+			return this, this.failed(logger, false, fmt.Errorf("secretRef and credentialsPath must not be specified together"), false)
+		}
+		this.credentialsFile, props, err = readCredentialsFile(state.config.CredentialsDir, *path)
+		if err != nil {
+			return this, this.failed(logger, false, fmt.Errorf("cannot read credentials %s for provider %s: %s",
+				*path, provider.Description(), err), false)
+		}
+	} else {
+		if ref == nil {
+			return this, this.failed(logger, false, fmt.Errorf("no secret specified"), false)
+		}
+
+		localref := *ref
+		ref = &localref
+		if ref.Namespace == "" {
+			ref.Namespace = provider.GetNamespace()
+		}
+		this.secret = resources.NewObjectName(ref.Namespace, ref.Name)
+		props, _, err = state.GetContext().GetSecretPropertiesByRef(provider, ref)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return this, this.failed(logger, false, fmt.Errorf("cannot get secret %s/%s for provider %s: %s",
+					ref.Namespace, ref.Name, provider.Description(), err), false)
+			}
+			return this, this.failed(logger, false, fmt.Errorf("error reading secret for provider %q", provider.Description()), true)
+		}
 	}
 
-	localref := *ref
-	ref = &localref
-	if ref.Namespace == "" {
-		ref.Namespace = provider.GetNamespace()
+	var lastFailedPrimary utils.Properties
+	if last != nil {
+		lastFailedPrimary = last.failedPrimary
 	}
-	this.secret = resources.NewObjectName(ref.Namespace, ref.Name)
-	props, _, err = state.GetContext().GetSecretPropertiesByRef(provider, ref)
-	if err != nil {
-		if errors.IsNotFound(err) {
-			return this, this.failed(logger, false, fmt.Errorf("cannot get secret %s/%s for provider %s: %s",
-				ref.Namespace, ref.Name, provider.Description(), err), false)
+	release := func(account *DNSAccount) {
+		if last == nil || last.account != account {
+			state.accountCache.Release(logger, account, this.ObjectName())
 		}
-		return this, this.failed(logger, false, fmt.Errorf("error reading secret for provider %q", provider.Description()), true)
 	}
-
-	this.account, err = state.GetDNSAccount(logger, provider, props)
-	if err != nil {
-		return this, this.failed(logger, false, err, true)
+	primary, next := splitRotationProperties(props)
+	account, zones, failedPrimary, err := getAccountWithRotation(logger, primary, next, lastFailedPrimary, this.getAccountWithZones, release)
+	if err == nil {
+		this.failedPrimary = failedPrimary
+		switch {
+		case failedPrimary != nil && lastFailedPrimary == nil:
+			this.object.Eventf(corev1.EventTypeWarning, EventReasonCredentialsRotated, "using next credentials as primary credentials failed")
+		case failedPrimary == nil && lastFailedPrimary != nil:
+			this.object.Eventf(corev1.EventTypeNormal, EventReasonCredentialsRestored, "using primary credentials again")
+		}
 	}
-
-	zones, err := this.account.GetZones()
+	this.account = account
 	if err != nil {
 		this.zones = nil
-		return this, this.failed(logger, false, fmt.Errorf("cannot get hosted zones: %w", err), true)
+		return this, this.failed(logger, false, err, true)
 	}
+	results := calcSelection(provider.DNSProvider().Spec, account, zones)
 	if len(zones) == 0 {
 		empty := utils.StringSet{}
 		mod := this.object.SetSelection(empty, empty, &this.object.Status().Domains)
 		mod = this.object.SetSelection(empty, empty, &this.object.Status().Zones) || mod
+		mod = this.object.SetProblematicZones(results.ProblematicZones) || mod
 		return this, this.failedButRecheck(logger, fmt.Errorf("no hosted zones available in account"), mod)
 	}
 
-	results := selection.CalcZoneAndDomainSelection(provider.DNSProvider().Spec, toLightZones(zones))
 	this.zones = fromLightZones(results.Zones)
 	this.included = results.DomainSel.Include
 	this.excluded = results.DomainSel.Exclude
 	this.included_zones = results.ZoneSel.Include
 	this.excluded_zones = results.ZoneSel.Exclude
+	this.recordTypes = results.RecordTypeSel
 	for _, warning := range results.Warnings {
 		this.object.Eventf(corev1.EventTypeWarning, "reconcile", "%s", warning)
 	}
 	mod := this.object.SetSelection(this.included, this.excluded, &this.object.Status().Domains)
 	mod = this.object.SetSelection(this.included_zones, this.excluded_zones, &this.object.Status().Zones) || mod
+	mod = this.object.SetProblematicZones(results.ProblematicZones) || mod
 	if results.Error != "" {
 		return this, this.failedButRecheck(logger, fmt.Errorf("%s", results.Error), mod)
 	}
@@ -458,12 +737,129 @@ func updateDNSProvider(logger logger.LogContext, state *state, provider *dnsutil
 		}
 	}
 
+	if last == nil || !this.recordTypes.Include.Equals(last.recordTypes.Include) {
+		if len(this.recordTypes.Include) > 0 {
+			logger.Infof("  included record types: %s", this.recordTypes.Include)
+		}
+		if len(this.recordTypes.Exclude) > 0 {
+			logger.Infof("  excluded record types: %s", this.recordTypes.Exclude)
+		}
+	}
+
 	this.valid = true
 	this.rateLimit = state.updateProviderRateLimiter(logger, provider)
+	this.migration = state.updateProviderMigration(logger, this)
+	this.mirror = state.updateProviderMirror(logger, this)
 
+	if err := this.probeCredentials(logger, last); err != nil {
+		// keep provider valid to continue serving the zones
+		return this, this.probeFailed(logger, mod, err)
+	}
 	return this, this.succeeded(logger, mod)
 }
 
+// getAccountWithZones returns the DNS account for the given credentials together with its hosted zones.
+// The account is also returned if getting the hosted zones failed.
+func (this *dnsProviderVersion) getAccountWithZones(logger logger.LogContext, props utils.Properties) (*DNSAccount, DNSHostedZones, error) {
+	if keys, _ := this.state.GetHandlerFactory().SecretKeys(this.TypeCode()); keys != nil {
+		if err := keys.Validate(this.TypeCode(), props); err != nil {
+			return nil, nil, err
+		}
+	}
+	account, err := this.state.GetDNSAccount(logger, this.object, props)
+	if err != nil {
+		return nil, nil, err
+	}
+	zones, err := account.GetZones()
+	if err != nil {
+		return account, nil, fmt.Errorf("cannot get hosted zones: %w", err)
+	}
+	return account, zones, nil
+}
+
+// getAccountWithRotation returns the DNS account for the primary credentials or for the next credentials if the primary
+// credentials failed. In this case, the failed primary credentials are returned, too. If the primary credentials
+// failed for the last version of the provider, they are not tried again before the next credentials fail or
+// the primary credentials are changed. Accounts not used are released.
+func getAccountWithRotation(logger logger.LogContext, primary, next, lastFailedPrimary utils.Properties,
+	get func(logger logger.LogContext, props utils.Properties) (*DNSAccount, DNSHostedZones, error),
+	release func(account *DNSAccount),
+) (*DNSAccount, DNSHostedZones, utils.Properties, error) {
+	if next != nil && lastFailedPrimary != nil && maps.Equal(lastFailedPrimary, primary) {
+		account, zones, err := get(logger, next)
+		if err == nil {
+			return account, zones, lastFailedPrimary, nil
+		}
+		logger.Warnf("next credentials failed, trying primary credentials again: %s", err)
+		if account != nil {
+			release(account)
+		}
+		account, zones, err = get(logger, primary)
+		return account, zones, nil, err
+	}
+
+	account, zones, err := get(logger, primary)
+	if err == nil || next == nil {
+		return account, zones, nil, err
+	}
+	logger.Warnf("primary credentials failed, trying next credentials: %s", err)
+	nextAccount, nextZones, nextErr := get(logger, next)
+	if nextErr != nil {
+		logger.Warnf("next credentials failed, too: %s", nextErr)
+		if nextAccount != nil {
+			release(nextAccount)
+		}
+		return account, zones, nil, err
+	}
+	if account != nil {
+		release(account)
+	}
+	return nextAccount, nextZones, primary, nil
+}
+
+// splitRotationProperties splits the secret properties into the primary and the next credentials.
+// The next credentials consist of the primary properties overwritten by the properties with key prefix
+// SecretKeyPrefixNext. If there are no such properties, nil is returned for the next credentials.
+func splitRotationProperties(props utils.Properties) (utils.Properties, utils.Properties) {
+	primary := utils.Properties{}
+	overwrites := utils.Properties{}
+	for k, v := range props {
+		if key, ok := strings.CutPrefix(k, SecretKeyPrefixNext); ok {
+			overwrites[key] = v
+		} else {
+			primary[k] = v
+		}
+	}
+	if len(overwrites) == 0 {
+		return primary, nil
+	}
+	next := utils.Properties{}
+	for k, v := range primary {
+		next[k] = v
+	}
+	for k, v := range overwrites {
+		next[k] = v
+	}
+	return primary, next
+}
+
+// probeCredentials probes the credentials of the account if the probe interval has elapsed since the last probe.
+// It returns the error of the actual or the last probe.
+func (this *dnsProviderVersion) probeCredentials(logger logger.LogContext, last *dnsProviderVersion) error {
+	if this.state.config.ProviderProbeInterval <= 0 {
+		return nil
+	}
+	if last != nil && last.account == this.account && time.Since(last.lastProbe) < this.state.config.ProviderProbeInterval {
+		this.lastProbe = last.lastProbe
+		this.lastProbeErr = last.lastProbeErr
+		return this.lastProbeErr
+	}
+	logger.Infof("probing credentials")
+	this.lastProbe = time.Now()
+	this.lastProbeErr = this.account.Probe(providerProbeTimeout)
+	return this.lastProbeErr
+}
+
 func toLightZones(zones DNSHostedZones) []selection.LightDNSHostedZone {
 	lzones := make([]selection.LightDNSHostedZone, len(zones))
 	for i, z := range zones {
@@ -508,6 +904,11 @@ func (this *dnsProviderVersion) GetExcludedDomains() utils.StringSet {
 	return this.excluded.Copy()
 }
 
+// AcceptsRecordType returns true if the provider manages the given record type.
+func (this *dnsProviderVersion) AcceptsRecordType(rtype string) bool {
+	return this.recordTypes.Include == nil || this.recordTypes.Include.Contains(rtype)
+}
+
 func (this *dnsProviderVersion) Match(dns string) int {
 	ilen := dnsutils.MatchSet(dns, this.included)
 	elen := dnsutils.MatchSet(dns, this.excluded)
@@ -531,6 +932,43 @@ func (this *dnsProviderVersion) MapTargets(dnsName string, targets []Target) []T
 	return this.account.MapTargets(dnsName, targets)
 }
 
+func (this *dnsProviderVersion) NormalizeRoutingPolicy(policy *dns.RoutingPolicy) *dns.RoutingPolicy {
+	return this.account.NormalizeRoutingPolicy(policy)
+}
+
+func (this *dnsProviderVersion) NormalizeProviderAttributes(attrs map[string]string) map[string]string {
+	return this.account.NormalizeProviderAttributes(attrs)
+}
+
+func (this *dnsProviderVersion) TXTRecordLimits() dns.TXTRecordLimits {
+	return this.account.TXTRecordLimits()
+}
+
+// Priority returns the priority of the provider for DNS entries matched equally well by several providers.
+func (this *dnsProviderVersion) Priority() int32 {
+	return ptr.Deref(this.object.Spec().Priority, 0)
+}
+
+func (this *dnsProviderVersion) MaxWeight() int64 {
+	return this.account.MaxWeight()
+}
+
+func (this *dnsProviderVersion) MinTTL() int64 {
+	return this.account.MinTTL()
+}
+
+func (this *dnsProviderVersion) NameForm() dns.NameForm {
+	return this.account.NameForm()
+}
+
+func (this *dnsProviderVersion) SupportsApexAlias(target Target) bool {
+	return this.account.SupportsApexAlias(target)
+}
+
+func (this *dnsProviderVersion) Capabilities() Capabilities {
+	return this.account.Capabilities()
+}
+
 func (this *dnsProviderVersion) setError(modified bool, err error) error {
 	modified = this.object.SetStateWithError(api.STATE_ERROR, err) || modified
 	if modified {
@@ -587,16 +1025,77 @@ func (this *dnsProviderVersion) succeeded(logger logger.LogContext, modified boo
 	status := &this.object.DNSProvider().Status
 	mod := resources.NewModificationState(this.object, modified)
 	mod.AssureStringValue(&status.State, api.STATE_READY)
-	mod.AssureStringPtrValue(&status.Message, "provider operational")
+	msg := "provider operational"
+	interval := this.state.config.ProviderProbeInterval
+	if cerr, ok := perrs.IsCircuitOpen(this.account.CircuitBreakerStatus()); ok {
+		// zones are still served, but changes are suspended until the DNS backend recovers
+		msg = fmt.Sprintf("provider operational, but changes suspended: %s", cerr)
+		if interval == 0 || cerr.RetryAfter < interval {
+			interval = cerr.RetryAfter
+		}
+	}
+	mod.AssureStringPtrValue(&status.Message, msg)
 	mod.AssureInt64Value(&status.ObservedGeneration, this.object.DNSProvider().Generation)
 	mod.AssureInt64PtrValue(&status.DefaultTTL, this.defaultTTL)
 	assureRateLimit(mod, &status.RateLimit, this.rateLimit)
+	assureZoneRecordSets(mod, &status.ZoneRecordSets, this.state.getZoneRecordSetCounts(this.zones))
+	assureMigrationStatus(mod, &status.Migration, this.migration)
+	assureMirrorStatus(mod, &status.Mirror, this.mirror)
+	if this.state.config.ProviderProbeInterval > 0 {
+		mod.Modify(meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               api.CONDITION_CREDENTIALS_VALID,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: this.object.DNSProvider().Generation,
+			Reason:             "ProbeSucceeded",
+			Message:            "listing hosted zones succeeded",
+		}))
+	} else {
+		mod.Modify(meta.RemoveStatusCondition(&status.Conditions, api.CONDITION_CREDENTIALS_VALID))
+	}
 	if mod.IsModified() {
 		dnsutils.SetLastUpdateTime(&this.object.Status().LastUptimeTime)
 	}
+	if this.migration != nil && this.migration.Phase != api.MIGRATION_PHASE_COMPLETED && (interval == 0 || interval > migrationRecheckInterval) {
+		// check the progress of the migration
+		interval = migrationRecheckInterval
+	}
+	if this.mirror != nil && (interval == 0 || interval > mirrorRecheckInterval) {
+		// update the progress of the mirror
+		interval = mirrorRecheckInterval
+	}
+	if interval > 0 {
+		return reconcile.UpdateStatus(logger, mod, interval)
+	}
 	return reconcile.UpdateStatus(logger, mod)
 }
 
+// probeFailed sets the provider state to error, but keeps the served zones and rechecks after the probe interval.
+func (this *dnsProviderVersion) probeFailed(logger logger.LogContext, modified bool, err error) reconcile.Status {
+	msg := fmt.Sprintf("credentials probe failed at %s: %s", this.lastProbe.UTC().Format(time.RFC3339), err)
+	logger.Warn(msg)
+	status := &this.object.DNSProvider().Status
+	mod := resources.NewModificationState(this.object, modified)
+	mod.AssureStringValue(&status.State, api.STATE_ERROR)
+	mod.AssureStringPtrValue(&status.Message, msg)
+	mod.AssureInt64Value(&status.ObservedGeneration, this.object.DNSProvider().Generation)
+	mod.AssureInt64PtrValue(&status.DefaultTTL, this.defaultTTL)
+	assureRateLimit(mod, &status.RateLimit, this.rateLimit)
+	assureZoneRecordSets(mod, &status.ZoneRecordSets, this.state.getZoneRecordSetCounts(this.zones))
+	assureMigrationStatus(mod, &status.Migration, this.migration)
+	assureMirrorStatus(mod, &status.Mirror, this.mirror)
+	mod.Modify(meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               api.CONDITION_CREDENTIALS_VALID,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: this.object.DNSProvider().Generation,
+		Reason:             "ProbeFailed",
+		Message:            err.Error(),
+	}))
+	if mod.IsModified() {
+		dnsutils.SetLastUpdateTime(&this.object.Status().LastUptimeTime)
+	}
+	return reconcile.UpdateStatus(logger, mod, this.state.config.ProviderProbeInterval)
+}
+
 func (this *dnsProviderVersion) GetZoneState(zone DNSHostedZone) (DNSZoneState, error) {
 	return this.account.GetZoneState(zone)
 }
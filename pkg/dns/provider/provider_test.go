@@ -0,0 +1,346 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+type testProbeMetrics struct{}
+
+func (testProbeMetrics) AddGenericRequests(_ string, _ int) {}
+func (testProbeMetrics) AddZoneRequests(_, _ string, _ int) {}
+
+var _ = ginkgov2.Describe("DNSAccount probe", func() {
+	var (
+		account *DNSAccount
+		cache   ZoneCache
+		calls   int
+		delay   time.Duration
+		err     error
+	)
+
+	ginkgov2.BeforeEach(func() {
+		calls = 0
+		delay = 0
+		err = nil
+		account = NewDNSAccount(utils.Properties{}, nil, "hash")
+		factory := NewTestZoneCacheFactory(1*time.Hour, 1*time.Hour)
+		factory.account = account
+		zonesUpdater := func(ctx context.Context, _ ZoneCache) (DNSHostedZones, error) {
+			calls++
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+			return DNSHostedZones{}, err
+		}
+		var cerr error
+		cache, cerr = factory.CreateZoneCache(CacheZonesOnly, testProbeMetrics{}, zonesUpdater, nil)
+		Expect(cerr).NotTo(HaveOccurred())
+	})
+
+	ginkgov2.It("bypasses the zone cache", func() {
+		_, _ = cache.GetZones()
+		_, _ = cache.GetZones()
+		Expect(calls).To(Equal(1))
+		Expect(account.Probe(1 * time.Second)).To(Succeed())
+		Expect(calls).To(Equal(2))
+	})
+
+	ginkgov2.It("reports errors of listing zones", func() {
+		err = fmt.Errorf("invalid credentials")
+		Expect(account.Probe(1 * time.Second)).To(MatchError("invalid credentials"))
+	})
+
+	ginkgov2.It("cancels the listing on timeout", func() {
+		delay = 1 * time.Hour
+		err := account.Probe(10 * time.Millisecond)
+		Expect(err).To(MatchError(ContainSubstring("no response within")))
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+	})
+
+	ginkgov2.It("does not wait for a request slot", func() {
+		account.SetMaxConcurrentRequests(1)
+		release := account.acquireRequestSlot()
+		defer release()
+		Expect(account.Probe(1 * time.Second)).To(Succeed())
+	})
+})
+
+// concurrencyTestHandler records the maximum number of concurrent calls of GetZoneState.
+type concurrencyTestHandler struct {
+	DefaultDNSHandler
+	lock    sync.Mutex
+	current int
+	max     int
+}
+
+func (h *concurrencyTestHandler) GetZones() (DNSHostedZones, error) { return nil, nil }
+func (h *concurrencyTestHandler) GetZoneState(_ DNSHostedZone) (DNSZoneState, error) {
+	h.lock.Lock()
+	h.current++
+	h.max = max(h.max, h.current)
+	h.lock.Unlock()
+	time.Sleep(10 * time.Millisecond)
+	h.lock.Lock()
+	h.current--
+	h.lock.Unlock()
+	return nil, nil
+}
+func (h *concurrencyTestHandler) ReportZoneStateConflict(_ DNSHostedZone, _ error) bool { return false }
+func (h *concurrencyTestHandler) ExecuteRequests(_ logger.LogContext, _ DNSHostedZone, _ DNSZoneState, _ []*ChangeRequest) error {
+	return nil
+}
+func (h *concurrencyTestHandler) Release() {}
+
+var _ = ginkgov2.Describe("DNSAccount concurrent requests", func() {
+	run := func(maxConcurrentRequests int) int {
+		handler := &concurrencyTestHandler{}
+		account := NewDNSAccount(utils.Properties{}, handler, "hash")
+		account.SetMaxConcurrentRequests(maxConcurrentRequests)
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, _ = account.GetZoneState(nil)
+			}()
+		}
+		wg.Wait()
+		return handler.max
+	}
+
+	ginkgov2.It("limits the number of concurrent requests", func() {
+		Expect(run(2)).To(BeNumerically("<=", 2))
+	})
+
+	ginkgov2.It("does not limit the number of concurrent requests by default", func() {
+		Expect(run(0)).To(BeNumerically(">", 2))
+	})
+
+	ginkgov2.It("uses separate accounts for different limits", func() {
+		cache := NewAccountCache(time.Minute, nil)
+		props := utils.Properties{"key": "value"}
+		Expect(cache.Hash(props, "mock", nil, 0, false)).NotTo(Equal(cache.Hash(props, "mock", nil, 2, false)))
+		Expect(cache.Hash(props, "mock", nil, 2, false)).To(Equal(cache.Hash(props, "mock", nil, 2, false)))
+	})
+
+	ginkgov2.It("uses separate accounts for zone selection by tags", func() {
+		cache := NewAccountCache(time.Minute, nil)
+		props := utils.Properties{"key": "value"}
+		Expect(cache.Hash(props, "mock", nil, 0, false)).NotTo(Equal(cache.Hash(props, "mock", nil, 0, true)))
+		Expect(cache.Hash(props, "mock", nil, 0, true)).To(Equal(cache.Hash(props, "mock", nil, 0, true)))
+	})
+})
+
+// deniedZonesTestHandler reports zones skipped as access is denied.
+type deniedZonesTestHandler struct {
+	concurrencyTestHandler
+	denied []string
+}
+
+func (h *deniedZonesTestHandler) DeniedZones() []string { return h.denied }
+
+var _ = ginkgov2.Describe("DNSProvider zone selection", func() {
+	zones := DNSHostedZones{
+		NewDNSHostedZone("test", "ZAB", "a.b", "", false),
+		NewDNSHostedZone("test", "ZOP", "o.p", "", false),
+	}
+	spec := api.DNSProviderSpec{
+		Type:  "test",
+		Zones: &api.DNSSelection{Include: []string{"ZAB", "ZXY"}},
+	}
+
+	ginkgov2.It("reports the zones denied by the handler as problematic", func() {
+		account := NewDNSAccount(utils.Properties{}, &deniedZonesTestHandler{denied: []string{"ZXY"}}, "hash")
+		results := calcSelection(spec, account, zones)
+		Expect(results.Error).To(BeEmpty())
+		Expect(results.ProblematicZones).To(Equal([]api.ProblematicZone{
+			{ID: "ZXY", Reason: api.ZONE_PROBLEM_ACCESS_DENIED, Message: "no access to zone with given credentials"},
+		}))
+	})
+
+	ginkgov2.It("reports missing zones as not found for handlers not reporting denied zones", func() {
+		account := NewDNSAccount(utils.Properties{}, &concurrencyTestHandler{}, "hash")
+		results := calcSelection(spec, account, zones)
+		Expect(results.ProblematicZones).To(Equal([]api.ProblematicZone{
+			{ID: "ZXY", Reason: api.ZONE_PROBLEM_NOT_FOUND, Message: "zone not found in account"},
+		}))
+	})
+})
+
+var _ = ginkgov2.Describe("Credential rotation", func() {
+	ginkgov2.It("returns no next credentials without prefixed keys", func() {
+		primary, next := splitRotationProperties(utils.Properties{"AWS_ACCESS_KEY_ID": "id", "AWS_SECRET_ACCESS_KEY": "secret"})
+		Expect(primary).To(Equal(utils.Properties{"AWS_ACCESS_KEY_ID": "id", "AWS_SECRET_ACCESS_KEY": "secret"}))
+		Expect(next).To(BeNil())
+	})
+
+	ginkgov2.It("overwrites the primary properties with the next credentials", func() {
+		primary, next := splitRotationProperties(utils.Properties{
+			"AWS_ACCESS_KEY_ID":          "id",
+			"AWS_SECRET_ACCESS_KEY":      "secret",
+			"AWS_REGION":                 "eu-west-1",
+			"next.AWS_ACCESS_KEY_ID":     "id2",
+			"next.AWS_SECRET_ACCESS_KEY": "secret2",
+		})
+		Expect(primary).To(Equal(utils.Properties{"AWS_ACCESS_KEY_ID": "id", "AWS_SECRET_ACCESS_KEY": "secret", "AWS_REGION": "eu-west-1"}))
+		Expect(next).To(Equal(utils.Properties{"AWS_ACCESS_KEY_ID": "id2", "AWS_SECRET_ACCESS_KEY": "secret2", "AWS_REGION": "eu-west-1"}))
+	})
+
+	ginkgov2.Context("fallback", func() {
+		var (
+			primary  = utils.Properties{"key": "primary"}
+			next     = utils.Properties{"key": "next"}
+			accounts map[string]*DNSAccount
+			failing  map[string]bool
+			calls    []string
+			released []*DNSAccount
+		)
+
+		get := func(_ logger.LogContext, props utils.Properties) (*DNSAccount, DNSHostedZones, error) {
+			calls = append(calls, props["key"])
+			account := accounts[props["key"]]
+			if failing[props["key"]] {
+				return account, nil, fmt.Errorf("%s credentials failed", props["key"])
+			}
+			return account, DNSHostedZones{}, nil
+		}
+		release := func(account *DNSAccount) {
+			released = append(released, account)
+		}
+
+		ginkgov2.BeforeEach(func() {
+			accounts = map[string]*DNSAccount{
+				"primary": NewDNSAccount(primary, nil, "primary"),
+				"next":    NewDNSAccount(next, nil, "next"),
+			}
+			failing = map[string]bool{}
+			calls = nil
+			released = nil
+		})
+
+		ginkgov2.It("uses the primary credentials if working", func() {
+			account, _, failedPrimary, err := getAccountWithRotation(logger.New(), primary, next, nil, get, release)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(account).To(BeIdenticalTo(accounts["primary"]))
+			Expect(failedPrimary).To(BeNil())
+			Expect(calls).To(Equal([]string{"primary"}))
+		})
+
+		ginkgov2.It("falls back to the next credentials and releases the primary account", func() {
+			failing["primary"] = true
+			account, _, failedPrimary, err := getAccountWithRotation(logger.New(), primary, next, nil, get, release)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(account).To(BeIdenticalTo(accounts["next"]))
+			Expect(failedPrimary).To(Equal(primary))
+			Expect(calls).To(Equal([]string{"primary", "next"}))
+			Expect(released).To(Equal([]*DNSAccount{accounts["primary"]}))
+		})
+
+		ginkgov2.It("does not retry the failed primary credentials on the next reconciliation", func() {
+			failing["primary"] = true
+			account, _, failedPrimary, err := getAccountWithRotation(logger.New(), primary, next, primary, get, release)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(account).To(BeIdenticalTo(accounts["next"]))
+			Expect(failedPrimary).To(Equal(primary))
+			Expect(calls).To(Equal([]string{"next"}))
+			Expect(released).To(BeEmpty())
+		})
+
+		ginkgov2.It("retries the primary credentials if they have been changed", func() {
+			account, _, failedPrimary, err := getAccountWithRotation(logger.New(), primary, next, utils.Properties{"key": "old"}, get, release)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(account).To(BeIdenticalTo(accounts["primary"]))
+			Expect(failedPrimary).To(BeNil())
+			Expect(calls).To(Equal([]string{"primary"}))
+		})
+
+		ginkgov2.It("retries the primary credentials if the next credentials fail", func() {
+			failing["next"] = true
+			account, _, failedPrimary, err := getAccountWithRotation(logger.New(), primary, next, primary, get, release)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(account).To(BeIdenticalTo(accounts["primary"]))
+			Expect(failedPrimary).To(BeNil())
+			Expect(calls).To(Equal([]string{"next", "primary"}))
+			Expect(released).To(Equal([]*DNSAccount{accounts["next"]}))
+		})
+
+		ginkgov2.It("returns the error of the primary credentials if both fail", func() {
+			failing["primary"] = true
+			failing["next"] = true
+			account, _, failedPrimary, err := getAccountWithRotation(logger.New(), primary, next, nil, get, release)
+			Expect(err).To(MatchError("primary credentials failed"))
+			Expect(account).To(BeIdenticalTo(accounts["primary"]))
+			Expect(failedPrimary).To(BeNil())
+			Expect(released).To(Equal([]*DNSAccount{accounts["next"]}))
+		})
+	})
+})
+
+type testLookupProvider struct {
+	DNSProvider
+	domain      string
+	recordTypes utils.StringSet
+}
+
+func (p *testLookupProvider) Match(dns string) int {
+	if dnsutils.Match(dns, p.domain) {
+		return len(p.domain)
+	}
+	return 0
+}
+
+func (p *testLookupProvider) AcceptsRecordType(rtype string) bool {
+	return p.recordTypes == nil || p.recordTypes.Contains(rtype)
+}
+
+func (p *testLookupProvider) AccountHash() string { return p.domain }
+
+var _ = ginkgov2.Describe("DNSProviders lookup", func() {
+	var (
+		providers DNSProviders
+		all       *testLookupProvider
+		addresses *testLookupProvider
+	)
+
+	ginkgov2.BeforeEach(func() {
+		all = &testLookupProvider{domain: "example.com"}
+		addresses = &testLookupProvider{domain: "sub.example.com", recordTypes: utils.NewStringSet("A", "AAAA")}
+		providers = DNSProviders{
+			resources.NewObjectName("ns", "all"):       all,
+			resources.NewObjectName("ns", "addresses"): addresses,
+		}
+	})
+
+	ginkgov2.It("uses the longest domain match without record types", func() {
+		Expect(providers.LookupFor("a.sub.example.com")).To(BeIdenticalTo(addresses))
+	})
+
+	ginkgov2.It("prefers providers managing the record types", func() {
+		Expect(providers.LookupFor("a.sub.example.com", "A")).To(BeIdenticalTo(addresses))
+		Expect(providers.LookupFor("a.sub.example.com", "TXT")).To(BeIdenticalTo(all))
+		Expect(providers.LookupFor("a.sub.example.com", "A", "TXT")).To(BeIdenticalTo(all))
+	})
+
+	ginkgov2.It("falls back to the longest domain match if no provider manages the record types", func() {
+		all.recordTypes = utils.NewStringSet("A")
+		Expect(providers.LookupFor("a.sub.example.com", "TXT")).To(BeIdenticalTo(addresses))
+	})
+})
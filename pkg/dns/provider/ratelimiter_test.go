@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/util/flowcontrol"
+	testclock "k8s.io/utils/clock/testing"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+var _ = ginkgov2.Describe("Entry reconcile rate limiter", func() {
+	ginkgov2.It("does not throttle if unlimited", func() {
+		var config *RateLimiterConfig
+		limiter, err := config.NewRateLimiter()
+		Expect(err).NotTo(HaveOccurred())
+		s := &state{entryRateLimiter: limiter}
+		for i := 0; i < 100; i++ {
+			accepted, _ := s.TryAcceptEntryReconcile(logger.New())
+			Expect(accepted).To(BeTrue())
+		}
+	})
+
+	ginkgov2.It("requeues reconciliations exceeding the rate", func() {
+		clock := testclock.NewFakeClock(time.Now())
+		s := &state{entryRateLimiter: flowcontrol.NewTokenBucketRateLimiterWithClock(0.5, 2, clock)}
+		for i := 0; i < 2; i++ {
+			accepted, _ := s.TryAcceptEntryReconcile(logger.New())
+			Expect(accepted).To(BeTrue())
+		}
+		accepted, delay := s.TryAcceptEntryReconcile(logger.New())
+		Expect(accepted).To(BeFalse())
+		Expect(delay).To(Equal(2 * time.Second))
+
+		clock.Step(delay)
+		accepted, _ = s.TryAcceptEntryReconcile(logger.New())
+		Expect(accepted).To(BeTrue())
+		accepted, _ = s.TryAcceptEntryReconcile(logger.New())
+		Expect(accepted).To(BeFalse())
+	})
+
+	ginkgov2.It("rejects invalid configurations", func() {
+		_, err := (&RateLimiterConfig{QPS: 10, Burst: -1}).NewRateLimiter()
+		Expect(err).To(MatchError("invalid burst value -1"))
+		_, err = (&RateLimiterConfig{QPS: 0.5, Burst: 1}).NewRateLimiter()
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
+
+var _ = ginkgov2.Describe("Provider rate limiter", func() {
+	ginkgov2.It("limits the priority lane separately", func() {
+		rt := newRateLimiterData(api.RateLimit{RequestsPerDay: 100, Burst: 1})
+		accepted, _ := rt.tryAccept(rt.RequestsPerDay)
+		Expect(accepted).To(BeTrue())
+		accepted, delay := rt.tryAccept(rt.RequestsPerDay)
+		Expect(accepted).To(BeFalse())
+		Expect(delay).To(BeNumerically(">", 0))
+
+		// the priority lane is not exhausted by the default lane, but rate limited on its own
+		accepted, _ = rt.priority.tryAccept(rt.RequestsPerDay)
+		Expect(accepted).To(BeTrue())
+		accepted, _ = rt.priority.tryAccept(rt.RequestsPerDay)
+		Expect(accepted).To(BeFalse())
+	})
+})
+
+var _ = ginkgov2.Describe("Zone policy rate limiter", func() {
+	var (
+		s        *state
+		zone1    *dnsHostedZone
+		zone2    *dnsHostedZone
+		provider resources.ObjectName
+	)
+
+	ginkgov2.BeforeEach(func() {
+		zone1 = newDNSHostedZone(0, NewDNSHostedZone("test", "z1", "example.com", "", false))
+		zone2 = newDNSHostedZone(0, NewDNSHostedZone("test", "z2", "example.org", "", false))
+		provider = resources.NewObjectName("default", "p1")
+		s = &state{
+			zones:               map[dns.ZoneID]*dnsHostedZone{zone1.Id(): zone1, zone2.Id(): zone2},
+			providerRateLimiter: map[resources.ObjectName]*rateLimiterData{provider: newRateLimiterData(api.RateLimit{RequestsPerDay: 1000, Burst: 10})},
+			zoneRateLimiter:     map[dns.ZoneID]*rateLimiterData{},
+		}
+	})
+
+	setPolicy := func(zone *dnsHostedZone, rateLimit *api.RateLimit) {
+		zone.SetPolicy(newDNSHostedZonePolicy("policy", &api.DNSHostedZonePolicySpec{Policy: api.ZonePolicy{RateLimit: rateLimit}}))
+		s.updateZoneRateLimiters(logger.New())
+	}
+
+	ginkgov2.It("takes precedence over the provider rate limit", func() {
+		setPolicy(zone1, &api.RateLimit{RequestsPerDay: 100, Burst: 1})
+		Expect(s.getRateLimiter(logger.New(), zone1, provider).RateLimit).To(Equal(api.RateLimit{RequestsPerDay: 100, Burst: 1}))
+		Expect(s.getRateLimiter(logger.New(), zone2, provider)).To(BeIdenticalTo(s.providerRateLimiter[provider]))
+		Expect(s.getRateLimiter(logger.New(), zone2, nil)).To(BeNil())
+	})
+
+	ginkgov2.It("keeps the rate limiter if unchanged", func() {
+		setPolicy(zone1, &api.RateLimit{RequestsPerDay: 100, Burst: 1})
+		rt := s.getRateLimiter(logger.New(), zone1, provider)
+		Expect(rt.rateLimiter.TryAccept()).To(BeTrue())
+
+		setPolicy(zone1, &api.RateLimit{RequestsPerDay: 100, Burst: 1})
+		Expect(s.getRateLimiter(logger.New(), zone1, provider)).To(BeIdenticalTo(rt))
+		Expect(rt.rateLimiter.TryAccept()).To(BeFalse())
+
+		setPolicy(zone1, &api.RateLimit{RequestsPerDay: 100, Burst: 2})
+		Expect(s.getRateLimiter(logger.New(), zone1, provider)).NotTo(BeIdenticalTo(rt))
+	})
+
+	ginkgov2.It("is removed with the policy", func() {
+		setPolicy(zone1, &api.RateLimit{RequestsPerDay: 100, Burst: 1})
+		zone1.SetPolicy(nil)
+		s.updateZoneRateLimiters(logger.New())
+		Expect(s.zoneRateLimiter).To(BeEmpty())
+		Expect(s.getRateLimiter(logger.New(), zone1, provider)).To(BeIdenticalTo(s.providerRateLimiter[provider]))
+	})
+
+	ginkgov2.It("is created lazily for zones discovered after the update", func() {
+		zone3 := newDNSHostedZone(0, NewDNSHostedZone("test", "z3", "example.net", "", false))
+		zone3.SetPolicy(newDNSHostedZonePolicy("policy", &api.DNSHostedZonePolicySpec{Policy: api.ZonePolicy{RateLimit: &api.RateLimit{RequestsPerDay: 100, Burst: 1}}}))
+		Expect(s.zoneRateLimiter).To(BeEmpty())
+
+		rt := s.getRateLimiter(logger.New(), zone3, nil)
+		Expect(rt).NotTo(BeNil())
+		Expect(rt.RateLimit).To(Equal(api.RateLimit{RequestsPerDay: 100, Burst: 1}))
+		Expect(s.zoneRateLimiter).To(HaveKeyWithValue(zone3.Id(), rt))
+		Expect(s.getRateLimiter(logger.New(), zone3, provider)).To(BeIdenticalTo(rt))
+	})
+
+	ginkgov2.It("ignores invalid rate limits", func() {
+		setPolicy(zone1, &api.RateLimit{RequestsPerDay: 0, Burst: 1})
+		Expect(s.zoneRateLimiter).To(BeEmpty())
+		Expect(validateZonePolicyRateLimit(&api.RateLimit{RequestsPerDay: 0, Burst: 1})).To(HaveOccurred())
+		Expect(validateZonePolicyRateLimit(&api.RateLimit{RequestsPerDay: 10, Burst: -1})).To(HaveOccurred())
+		Expect(validateZonePolicyRateLimit(nil)).To(Succeed())
+	})
+})
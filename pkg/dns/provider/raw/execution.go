@@ -6,6 +6,7 @@ package raw
 
 import (
 	"fmt"
+	"maps"
 
 	"github.com/gardener/controller-manager-library/pkg/logger"
 
@@ -109,20 +110,49 @@ func (this *Execution) add(name dns.DNSSetName, rset *dns.RecordSet, modonly boo
 	for _, r := range rset.Records {
 		old := this.state.GetRecord(name, rtype, r.Value)
 		if old != nil {
-			if (!modonly) || (old.GetTTL() != rset.TTL) {
+			if (!modonly) || (old.GetTTL() != rset.TTL) || commentChanged(old, rset.Comment) || attributesChanged(old, rset.ProviderAttributes) {
 				or := old.Copy()
 				or.SetTTL(rset.TTL)
+				setComment(or, rset.Comment)
+				setAttributes(or, rset.ProviderAttributes)
 				*found = append(*found, or)
 			}
 		} else {
 			if notfound != nil {
 				record := this.executor.NewRecord(name.DNSName, rset.Type, r.Value, this.zone, rset.TTL)
+				setComment(record, rset.Comment)
+				setAttributes(record, rset.ProviderAttributes)
 				*notfound = append(*notfound, record)
 			}
 		}
 	}
 }
 
+func commentChanged(r Record, comment *string) bool {
+	cr, ok := r.(CommentRecord)
+	return ok && comment != nil && !dns.CommentsEqual(cr.GetComment(), *comment)
+}
+
+func setComment(r Record, comment *string) {
+	if cr, ok := r.(CommentRecord); ok && comment != nil {
+		cr.SetComment(*comment)
+	}
+}
+
+func attributesChanged(r Record, attrs map[string]string) bool {
+	ar, ok := r.(AttributesRecord)
+	if !ok {
+		return false
+	}
+	return attrs != nil && !maps.Equal(ar.GetProviderAttributes(), attrs)
+}
+
+func setAttributes(r Record, attrs map[string]string) {
+	if ar, ok := r.(AttributesRecord); ok && attrs != nil {
+		ar.SetProviderAttributes(attrs)
+	}
+}
+
 func (this *Execution) SubmitChanges() error {
 	if len(this.additions) == 0 && len(this.updates) == 0 && len(this.deletions) == 0 {
 		return nil
@@ -171,7 +201,7 @@ func (this *Execution) SubmitChanges() error {
 func (this *Execution) submit(f func(record Record, zone provider.DNSHostedZone) error, r Record) {
 	err := f(r, this.zone)
 	if err != nil {
-		res := this.results[dns.DNSSetName{DNSName: r.GetDNSName(), SetIdentifier: r.GetSetIdentifier()}]
+		res := this.results[dns.DNSSetName{DNSName: r.GetDNSName(), SetIdentifier: r.GetSetIdentifier()}.Normalize()]
 		if res != nil {
 			res.err = err
 			this.Infof("operation failed for %s %s: %s", r.GetType(), r.GetDNSName(), err)
@@ -22,6 +22,20 @@ type Record interface {
 	Copy() Record
 }
 
+// CommentRecord is implemented by records of providers supporting record comments.
+type CommentRecord interface {
+	GetComment() string
+	SetComment(string)
+}
+
+// AttributesRecord is implemented by records of providers supporting provider specific record attributes.
+type AttributesRecord interface {
+	GetProviderAttributes() map[string]string
+	// SetProviderAttributes sets the given attributes. Providers supporting only dedicated attributes keep the
+	// attributes not given, others replace the complete set of attributes of the record.
+	SetProviderAttributes(map[string]string)
+}
+
 type RecordSet []Record
 
 func (this RecordSet) Clone() RecordSet {
@@ -69,7 +83,8 @@ func (this *ZoneState) Clone() provider.DNSZoneState {
 
 func (this *ZoneState) AddRecord(r Record) {
 	if dns.SupportedRecordType(r.GetType()) {
-		name := dns.DNSSetName{DNSName: r.GetDNSName(), SetIdentifier: r.GetSetIdentifier()}
+		// records are kept by the canonical name as used by the change requests
+		name := dns.DNSSetName{DNSName: r.GetDNSName(), SetIdentifier: r.GetSetIdentifier()}.Normalize()
 		t := r.GetType()
 		e := this.records[name]
 		if e == nil {
@@ -81,7 +96,7 @@ func (this *ZoneState) AddRecord(r Record) {
 }
 
 func (this *ZoneState) GetRecord(dnsname dns.DNSSetName, rtype, value string) Record {
-	e := this.records[dnsname]
+	e := this.records[dnsname.Normalize()]
 	if e != nil {
 		for _, r := range e[rtype] {
 			if r.GetValue() == value {
@@ -99,6 +114,13 @@ func (this *ZoneState) CalculateDNSSets() {
 			rs := dns.NewRecordSet(rtype, 0, nil)
 			for _, r := range rset {
 				rs.TTL = r.GetTTL()
+				if cr, ok := r.(CommentRecord); ok {
+					comment := cr.GetComment()
+					rs.Comment = &comment
+				}
+				if ar, ok := r.(AttributesRecord); ok {
+					rs.ProviderAttributes = ar.GetProviderAttributes()
+				}
 				rs.Add(&dns.Record{Value: r.GetValue()})
 			}
 			this.dnssets.AddRecordSetFromProviderEx(dnsname, nil, rs)
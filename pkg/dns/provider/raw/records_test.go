@@ -0,0 +1,48 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package raw
+
+import (
+	"testing"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+type testRecord struct {
+	dnsName string
+	rtype   string
+	value   string
+	ttl     int64
+}
+
+var _ Record = &testRecord{}
+
+func (r *testRecord) GetId() string            { return r.dnsName + "/" + r.rtype + "/" + r.value }
+func (r *testRecord) GetType() string          { return r.rtype }
+func (r *testRecord) GetValue() string         { return r.value }
+func (r *testRecord) GetDNSName() string       { return r.dnsName }
+func (r *testRecord) GetSetIdentifier() string { return "" }
+func (r *testRecord) GetTTL() int64            { return r.ttl }
+func (r *testRecord) SetTTL(ttl int64)         { r.ttl = ttl }
+func (r *testRecord) Copy() Record             { c := *r; return &c }
+
+func TestZoneStateCanonicalNames(t *testing.T) {
+	state := NewState()
+	state.AddRecord(&testRecord{dnsName: "Www.Example.com.", rtype: dns.RS_A, value: "1.1.1.1", ttl: 300})
+
+	for _, name := range []string{"www.example.com", "Www.Example.com.", "WWW.EXAMPLE.COM"} {
+		if r := state.GetRecord(dns.DNSSetName{DNSName: name}, dns.RS_A, "1.1.1.1"); r == nil {
+			t.Errorf("record not found for %q", name)
+		}
+	}
+	if r := state.GetRecord(dns.DNSSetName{DNSName: "www.example.com"}, dns.RS_A, "1.1.1.2"); r != nil {
+		t.Errorf("unexpected record for other value: %s", r.GetId())
+	}
+
+	state.CalculateDNSSets()
+	if set := state.GetDNSSets()[dns.DNSSetName{DNSName: "www.example.com"}]; set == nil {
+		t.Errorf("DNS set not found by canonical name")
+	}
+}
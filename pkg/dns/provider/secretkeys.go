@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gardener/controller-manager-library/pkg/utils"
+)
+
+// SecretKey describes a key of the secret of a DNS provider.
+type SecretKey struct {
+	// Key is the name of the key.
+	Key string
+	// AltKeys are alternative names of the key.
+	AltKeys []string
+	// Required indicates that the key must be given with a non-empty value.
+	Required bool
+	// condition restricts the requirement to secrets fulfilling the condition.
+	condition func(props utils.Properties) bool
+}
+
+// RequiredSecretKey returns a secret key which must be given with a non-empty value.
+func RequiredSecretKey(key string, altKeys ...string) SecretKey {
+	return SecretKey{Key: key, AltKeys: altKeys, Required: true}
+}
+
+// OptionalSecretKey returns a secret key which may be given.
+func OptionalSecretKey(key string, altKeys ...string) SecretKey {
+	return SecretKey{Key: key, AltKeys: altKeys}
+}
+
+// If restricts the requirement of the key to secrets fulfilling the given condition.
+func (this SecretKey) If(condition func(props utils.Properties) bool) SecretKey {
+	this.condition = condition
+	return this
+}
+
+// Names returns the key and its alternative names.
+func (this SecretKey) Names() []string {
+	return append([]string{this.Key}, this.AltKeys...)
+}
+
+// Value returns the trimmed value of the key or of the first given alternative key.
+func (this SecretKey) Value(props utils.Properties) string {
+	for _, name := range this.Names() {
+		if value, ok := props[name]; ok {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+func (this SecretKey) String() string {
+	s := fmt.Sprintf("'%s'", this.Key)
+	if len(this.AltKeys) > 0 {
+		s += fmt.Sprintf(" (or '%s')", strings.Join(this.AltKeys, "' or '"))
+	}
+	return s
+}
+
+// SecretKeys is the list of keys of the secret of a DNS provider type.
+type SecretKeys []SecretKey
+
+// Validate checks that all required keys are given in the secret properties.
+func (this SecretKeys) Validate(ptype string, props utils.Properties) error {
+	var missing []string
+	for _, key := range this {
+		if !key.Required || (key.condition != nil && !key.condition(props)) {
+			continue
+		}
+		if key.Value(props) == "" {
+			missing = append(missing, key.String())
+		}
+	}
+	switch len(missing) {
+	case 0:
+		return nil
+	case 1:
+		return fmt.Errorf("secret missing key %s required for provider type %s", missing[0], ptype)
+	default:
+		return fmt.Errorf("secret missing keys %s required for provider type %s", strings.Join(missing, ", "), ptype)
+	}
+}
+
+// Unknown returns the sorted keys of the secret properties not declared as secret key.
+func (this SecretKeys) Unknown(props utils.Properties) []string {
+	known := utils.StringSet{}
+	for _, key := range this {
+		known.AddAll(key.Names())
+	}
+	var unknown []string
+	for k := range props {
+		if !known.Contains(k) {
+			unknown = append(unknown, k)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+// IsTrue returns a condition for SecretKey.If checking if the given boolean key is set to true.
+func IsTrue(key string) func(props utils.Properties) bool {
+	return func(props utils.Properties) bool {
+		b, err := strconv.ParseBool(strings.TrimSpace(props[key]))
+		return err == nil && b
+	}
+}
+
+// IsNotTrue returns a condition for SecretKey.If checking if the given boolean key is not set to true.
+func IsNotTrue(key string) func(props utils.Properties) bool {
+	return func(props utils.Properties) bool {
+		return !IsTrue(key)(props)
+	}
+}
+
+// IsNotGiven returns a condition for SecretKey.If checking if none of the given keys has a non-empty value.
+func IsNotGiven(key string, altKeys ...string) func(props utils.Properties) bool {
+	return func(props utils.Properties) bool {
+		return OptionalSecretKey(key, altKeys...).Value(props) == ""
+	}
+}
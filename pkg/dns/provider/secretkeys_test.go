@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = ginkgov2.Describe("Secret keys", func() {
+	keys := SecretKeys{
+		OptionalSecretKey("USE_CHAIN"),
+		RequiredSecretKey("CLIENT_ID", "clientID").If(IsNotTrue("USE_CHAIN")),
+		RequiredSecretKey("CLIENT_SECRET", "clientSecret").If(IsNotTrue("USE_CHAIN")),
+		RequiredSecretKey("SUBSCRIPTION_ID").If(IsNotGiven("SUBSCRIPTION_IDS", "subscriptionIDs")),
+		OptionalSecretKey("SUBSCRIPTION_IDS", "subscriptionIDs"),
+	}
+
+	ginkgov2.It("accepts secrets with all required keys", func() {
+		Expect(keys.Validate("test", utils.Properties{"clientID": "id", "CLIENT_SECRET": "secret", "SUBSCRIPTION_ID": "sub"})).To(Succeed())
+	})
+
+	ginkgov2.It("reports a single missing key", func() {
+		err := keys.Validate("test", utils.Properties{"CLIENT_ID": "id", "SUBSCRIPTION_ID": "sub"})
+		Expect(err).To(MatchError("secret missing key 'CLIENT_SECRET' (or 'clientSecret') required for provider type test"))
+	})
+
+	ginkgov2.It("reports all missing keys and treats blank values as missing", func() {
+		err := keys.Validate("test", utils.Properties{"CLIENT_ID": " "})
+		Expect(err).To(MatchError("secret missing keys 'CLIENT_ID' (or 'clientID'), 'CLIENT_SECRET' (or 'clientSecret'), 'SUBSCRIPTION_ID' required for provider type test"))
+	})
+
+	ginkgov2.It("respects conditions", func() {
+		Expect(keys.Validate("test", utils.Properties{"USE_CHAIN": "true", "subscriptionIDs": "a,b"})).To(Succeed())
+		Expect(keys.Validate("test", utils.Properties{"USE_CHAIN": "false", "subscriptionIDs": "a,b"})).NotTo(Succeed())
+		Expect(keys.Validate("test", utils.Properties{"USE_CHAIN": "invalid", "subscriptionIDs": "a,b"})).NotTo(Succeed())
+	})
+
+	ginkgov2.It("returns unknown keys", func() {
+		Expect(keys.Unknown(utils.Properties{"clientID": "id", "foo": "x", "CLIENT_SECRETS": "y"})).To(Equal([]string{"CLIENT_SECRETS", "foo"}))
+		Expect(keys.Unknown(utils.Properties{"clientID": "id"})).To(BeEmpty())
+	})
+})
@@ -6,9 +6,13 @@ package selection
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/gardener/controller-manager-library/pkg/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
 	"github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
 	"github.com/gardener/external-dns-management/pkg/dns"
 	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
@@ -21,8 +25,12 @@ type SelectionResult struct {
 	SpecDomainSel SubSelection
 	ZoneSel       SubSelection
 	DomainSel     SubSelection
+	// RecordTypeSel contains the managed record types. It is empty if the spec has no record type selection.
+	RecordTypeSel SubSelection
 	Error         string
 	Warnings      []string
+	// ProblematicZones contains the zones expected or available, but not served, with the reason.
+	ProblematicZones []v1alpha1.ProblematicZone
 }
 
 // SubSelection contains an included and an excluded string set
@@ -46,9 +54,107 @@ type LightDNSHostedZone interface {
 	ForwardedDomains() []string
 }
 
+// TaggedDNSHostedZone is implemented by hosted zones providing the tags or labels of the zone.
+type TaggedDNSHostedZone interface {
+	Tags() map[string]string
+}
+
+// zoneTags returns the tags of the zone or nil if the zone does not provide tags.
+func zoneTags(z LightDNSHostedZone) map[string]string {
+	if tz, ok := z.(TaggedDNSHostedZone); ok {
+		return tz.Tags()
+	}
+	return nil
+}
+
 // CalcZoneAndDomainSelection calculates the effective included/excluded domains and zones for the given spec and
-// zones supported by a provider.
+// zones supported by a provider. The expected zones not served and the zones of the spec not found are reported
+// as problematic.
 func CalcZoneAndDomainSelection(spec v1alpha1.DNSProviderSpec, allzones []LightDNSHostedZone) SelectionResult {
+	reasons := map[string]string{}
+	expected := utils.NewStringSet()
+	this := calcZoneAndDomainSelection(spec, allzones, reasons, expected)
+	this.ProblematicZones = problematicZones(this, allzones, reasons, expected)
+	return this
+}
+
+// AddAccessDeniedZones reports the given zones of the account as problematic, as the credentials grant no access to them.
+func (this *SelectionResult) AddAccessDeniedZones(ids []string) {
+	if len(ids) == 0 {
+		return
+	}
+	denied := utils.NewStringSetByArray(ids)
+	var result []v1alpha1.ProblematicZone
+	for _, z := range this.ProblematicZones {
+		if !denied.Contains(z.ID) {
+			result = append(result, z)
+		}
+	}
+	for id := range denied {
+		result = append(result, v1alpha1.ProblematicZone{
+			ID:      id,
+			Reason:  v1alpha1.ZONE_PROBLEM_ACCESS_DENIED,
+			Message: "no access to zone with given credentials",
+		})
+	}
+	sortProblematicZones(result)
+	this.ProblematicZones = result
+}
+
+// problematicZones returns the excluded zones expected to be served and the included zones of the spec not found
+// in the account. A zone is expected if it is included explicitly or by tags, or if it contains an included domain.
+// Other zones of the account are excluded on purpose and not reported.
+func problematicZones(result SelectionResult, allzones []LightDNSHostedZone, reasons map[string]string, expected utils.StringSet) []v1alpha1.ProblematicZone {
+	domains := normalizeDomains(result.SpecDomainSel.Include)
+	for _, z := range allzones {
+		for d := range domains {
+			if dnsutils.Match(d, z.Domain()) {
+				expected.Add(z.Id().ID)
+				break
+			}
+		}
+	}
+	var problematic []v1alpha1.ProblematicZone
+	for id := range result.ZoneSel.Exclude {
+		if !expected.Contains(id) {
+			continue
+		}
+		msg := reasons[id]
+		if msg == "" {
+			msg = "excluded by selection"
+		}
+		problematic = append(problematic, v1alpha1.ProblematicZone{
+			ID:      id,
+			Reason:  v1alpha1.ZONE_PROBLEM_EXCLUDED_BY_SELECTOR,
+			Message: msg,
+		})
+	}
+	found := utils.NewStringSet()
+	for _, z := range allzones {
+		found.Add(z.Id().ID)
+	}
+	for id := range result.SpecZoneSel.Include {
+		if !found.Contains(id) {
+			problematic = append(problematic, v1alpha1.ProblematicZone{
+				ID:      id,
+				Reason:  v1alpha1.ZONE_PROBLEM_NOT_FOUND,
+				Message: "zone not found in account",
+			})
+		}
+	}
+	sortProblematicZones(problematic)
+	return problematic
+}
+
+func sortProblematicZones(zones []v1alpha1.ProblematicZone) {
+	sort.Slice(zones, func(i, j int) bool {
+		return zones[i].ID < zones[j].ID
+	})
+}
+
+// calcZoneAndDomainSelection calculates the selection and records the reasons for excluding zones and
+// the zones selected explicitly by the spec.
+func calcZoneAndDomainSelection(spec v1alpha1.DNSProviderSpec, allzones []LightDNSHostedZone, reasons map[string]string, expected utils.StringSet) SelectionResult {
 	this := SelectionResult{
 		SpecDomainSel: PrepareSelection(spec.Domains),
 		SpecZoneSel:   PrepareSelection(spec.Zones),
@@ -65,6 +171,25 @@ func CalcZoneAndDomainSelection(spec v1alpha1.DNSProviderSpec, allzones []LightD
 		return this
 	}
 
+	if spec.RecordTypeSelection != nil {
+		sel, err := calcRecordTypeSelection(spec.RecordTypeSelection)
+		if err != nil {
+			this.Error = err.Error()
+			return this
+		}
+		this.RecordTypeSel = sel
+	}
+
+	var tagSelector labels.Selector
+	if spec.ZoneTagSelector != nil {
+		var err error
+		tagSelector, err = metav1.LabelSelectorAsSelector(spec.ZoneTagSelector)
+		if err != nil {
+			this.Error = fmt.Sprintf("invalid zone tag selector: %s", err)
+			return this
+		}
+	}
+
 	var zones []LightDNSHostedZone
 	for _, z := range allzones {
 		if z.Id().ProviderType == spec.Type {
@@ -72,12 +197,18 @@ func CalcZoneAndDomainSelection(spec v1alpha1.DNSProviderSpec, allzones []LightD
 		}
 	}
 
-	if len(this.SpecZoneSel.Include) > 0 {
+	if len(this.SpecZoneSel.Include) > 0 || tagSelector != nil {
 		for _, z := range zones {
-			if this.SpecZoneSel.Include.Contains(z.Id().ID) {
+			if this.SpecZoneSel.Include.Contains(z.Id().ID) || (tagSelector != nil && tagSelector.Matches(labels.Set(zoneTags(z)))) {
 				this.ZoneSel.Include.Add(z.Id().ID)
+				expected.Add(z.Id().ID)
 			} else {
 				this.ZoneSel.Exclude.Add(z.Id().ID)
+				if this.SpecZoneSel.Exclude.Contains(z.Id().ID) {
+					reasons[z.Id().ID] = "matching zones.exclude"
+				} else {
+					reasons[z.Id().ID] = "not matching zones.include or zones.tagSelector"
+				}
 			}
 		}
 	} else {
@@ -90,6 +221,7 @@ func CalcZoneAndDomainSelection(spec v1alpha1.DNSProviderSpec, allzones []LightD
 			if this.SpecZoneSel.Exclude.Contains(id) {
 				this.ZoneSel.Include.Remove(id)
 				this.ZoneSel.Exclude.Add(id)
+				reasons[id] = "matching zones.exclude"
 			}
 		}
 	}
@@ -124,6 +256,9 @@ func CalcZoneAndDomainSelection(spec v1alpha1.DNSProviderSpec, allzones []LightD
 		}
 	} else {
 		if len(this.DomainSel.Include) == 0 {
+			for id := range this.ZoneSel.Include {
+				reasons[id] = "no domain of domains.include in zone"
+			}
 			this.ZoneSel.Exclude.AddSet(this.ZoneSel.Include)
 			this.ZoneSel.Include = utils.NewStringSet()
 			zoneDomains := []string{}
@@ -161,6 +296,7 @@ outer:
 		}
 		this.ZoneSel.Include.Remove(zone.Id().ID)
 		this.ZoneSel.Exclude.Add(zone.Id().ID)
+		reasons[zone.Id().ID] = "no included domain in zone"
 	}
 
 	for _, z := range allzones {
@@ -189,6 +325,41 @@ func validateDomains(domains utils.StringSet, name string) error {
 	return nil
 }
 
+func calcRecordTypeSelection(spec *v1alpha1.DNSSelection) (SubSelection, error) {
+	specSel := PrepareSelection(spec)
+	for _, set := range []utils.StringSet{specSel.Include, specSel.Exclude} {
+		for rtype := range set {
+			if !dns.SupportedRecordType(strings.ToUpper(rtype)) {
+				return SubSelection{}, fmt.Errorf("unsupported record type %q in record type selection (supported: %s)",
+					rtype, strings.Join(dns.SupportedRecordTypes(), ", "))
+			}
+		}
+	}
+
+	sel := NewSubSelection()
+	for _, rtype := range dns.SupportedRecordTypes() {
+		included := len(specSel.Include) == 0 || containsIgnoreCase(specSel.Include, rtype)
+		if included && !containsIgnoreCase(specSel.Exclude, rtype) {
+			sel.Include.Add(rtype)
+		} else {
+			sel.Exclude.Add(rtype)
+		}
+	}
+	if len(sel.Include) == 0 {
+		return SubSelection{}, fmt.Errorf("record type selection excludes all record types")
+	}
+	return sel, nil
+}
+
+func containsIgnoreCase(set utils.StringSet, value string) bool {
+	for s := range set {
+		if strings.EqualFold(s, value) {
+			return true
+		}
+	}
+	return false
+}
+
 func PrepareSelection(sel *v1alpha1.DNSSelection) SubSelection {
 	subSel := NewSubSelection()
 	if sel != nil {
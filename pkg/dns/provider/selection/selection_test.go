@@ -9,6 +9,8 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/gardener/controller-manager-library/pkg/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	"github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
 	"github.com/gardener/external-dns-management/pkg/dns"
 	. "github.com/gardener/external-dns-management/pkg/dns/provider/selection"
@@ -18,11 +20,13 @@ type lightDNSHostedZone struct {
 	id               dns.ZoneID
 	domain           string
 	forwardedDomains []string
+	tags             map[string]string
 }
 
 func (z *lightDNSHostedZone) Id() dns.ZoneID             { return z.id }
 func (z *lightDNSHostedZone) Domain() string             { return z.domain }
 func (z *lightDNSHostedZone) ForwardedDomains() []string { return z.forwardedDomains }
+func (z *lightDNSHostedZone) Tags() map[string]string    { return z.tags }
 
 var _ = Describe("Selection", func() {
 	zab := &lightDNSHostedZone{
@@ -298,6 +302,9 @@ var _ = Describe("Selection", func() {
 			Warnings: []string{
 				"domain \"d.a.b\" not in hosted domains",
 			},
+			ProblematicZones: []v1alpha1.ProblematicZone{
+				{ID: "ZAB", Reason: v1alpha1.ZONE_PROBLEM_EXCLUDED_BY_SELECTOR, Message: "no included domain in zone"},
+			},
 		}))
 	})
 
@@ -505,6 +512,10 @@ var _ = Describe("Selection", func() {
 				Warnings: []string{
 					"domain \"d.c.a.b\" not in hosted domains",
 				},
+				ProblematicZones: []v1alpha1.ProblematicZone{
+					{ID: "ZB", Reason: v1alpha1.ZONE_PROBLEM_EXCLUDED_BY_SELECTOR, Message: "no domain of domains.include in zone"},
+					{ID: "ZCAB", Reason: v1alpha1.ZONE_PROBLEM_EXCLUDED_BY_SELECTOR, Message: "not matching zones.include or zones.tagSelector"},
+				},
 			}))
 		})
 	})
@@ -544,4 +555,189 @@ var _ = Describe("Selection", func() {
 			}))
 		})
 	})
+
+	Context("zone tag selection", func() {
+		tzab := &lightDNSHostedZone{id: dns.NewZoneID("test", "ZAB"), domain: "a.b", tags: map[string]string{"team": "a", "env": "prod"}}
+		tzcd := &lightDNSHostedZone{id: dns.NewZoneID("test", "ZCD"), domain: "c.d", tags: map[string]string{"team": "b", "env": "prod"}}
+		tzef := &lightDNSHostedZone{id: dns.NewZoneID("test", "ZEF"), domain: "e.f"}
+		tagged := []LightDNSHostedZone{tzab, tzcd, tzef}
+
+		It("selects zones by tags", func() {
+			spec := v1alpha1.DNSProviderSpec{
+				Type:            "test",
+				ZoneTagSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+			}
+			result := CalcZoneAndDomainSelection(spec, tagged)
+			Expect(result.Error).To(BeEmpty())
+			Expect(result.Zones).To(Equal([]LightDNSHostedZone{tzab, tzcd}))
+			Expect(result.ZoneSel).To(Equal(SubSelection{
+				Include: utils.NewStringSet("ZAB", "ZCD"),
+				Exclude: utils.NewStringSet("ZEF"),
+			}))
+		})
+
+		It("combines tag selector with zone inclusion and exclusion", func() {
+			spec := v1alpha1.DNSProviderSpec{
+				Type: "test",
+				Zones: &v1alpha1.DNSSelection{
+					Include: []string{"ZEF"},
+					Exclude: []string{"ZCD"},
+				},
+				ZoneTagSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "team", Operator: metav1.LabelSelectorOpIn, Values: []string{"a", "b"}},
+				}},
+			}
+			result := CalcZoneAndDomainSelection(spec, tagged)
+			Expect(result.Error).To(BeEmpty())
+			Expect(result.ZoneSel).To(Equal(SubSelection{
+				Include: utils.NewStringSet("ZAB", "ZEF"),
+				Exclude: utils.NewStringSet("ZCD"),
+			}))
+		})
+
+		It("fails if no zone matches the tag selector", func() {
+			spec := v1alpha1.DNSProviderSpec{
+				Type:            "test",
+				ZoneTagSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "dev"}},
+			}
+			result := CalcZoneAndDomainSelection(spec, tagged)
+			Expect(result.Error).To(Equal("no zone available in account matches zone filter"))
+		})
+
+		It("rejects invalid tag selectors", func() {
+			spec := v1alpha1.DNSProviderSpec{
+				Type: "test",
+				ZoneTagSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "team", Operator: "Like"},
+				}},
+			}
+			result := CalcZoneAndDomainSelection(spec, tagged)
+			Expect(result.Error).To(HavePrefix("invalid zone tag selector: "))
+		})
+	})
+
+	Context("record type selection", func() {
+		It("includes all record types if no include is given", func() {
+			spec := v1alpha1.DNSProviderSpec{
+				Type: "test",
+				RecordTypeSelection: &v1alpha1.DNSSelection{
+					Exclude: []string{"cname", "TXT"},
+				},
+			}
+			result := CalcZoneAndDomainSelection(spec, allzones)
+			Expect(result.Error).To(BeEmpty())
+			Expect(result.RecordTypeSel).To(Equal(SubSelection{
+				Include: utils.NewStringSet("A", "AAAA", "SRV", "CAA", "MX", "NS", "DS", "PTR"),
+				Exclude: utils.NewStringSet("CNAME", "TXT"),
+			}))
+		})
+
+		It("handles record type inclusion and exclusion", func() {
+			spec := v1alpha1.DNSProviderSpec{
+				Type: "test",
+				RecordTypeSelection: &v1alpha1.DNSSelection{
+					Include: []string{"A", "AAAA", "TXT"},
+					Exclude: []string{"AAAA"},
+				},
+			}
+			result := CalcZoneAndDomainSelection(spec, allzones)
+			Expect(result.Error).To(BeEmpty())
+			Expect(result.RecordTypeSel).To(Equal(SubSelection{
+				Include: utils.NewStringSet("A", "TXT"),
+				Exclude: utils.NewStringSet("AAAA", "CNAME", "SRV", "CAA", "MX", "NS", "DS", "PTR"),
+			}))
+		})
+
+		It("is empty if no record type selection is given", func() {
+			spec := v1alpha1.DNSProviderSpec{
+				Type: "test",
+			}
+			result := CalcZoneAndDomainSelection(spec, allzones)
+			Expect(result.RecordTypeSel.Include).To(BeNil())
+		})
+
+		It("rejects unsupported record types", func() {
+			spec := v1alpha1.DNSProviderSpec{
+				Type: "test",
+				RecordTypeSelection: &v1alpha1.DNSSelection{
+					Include: []string{"SPF"},
+				},
+			}
+			result := CalcZoneAndDomainSelection(spec, allzones)
+			Expect(result.Error).To(Equal("unsupported record type \"SPF\" in record type selection (supported: A, AAAA, CNAME, TXT, SRV, CAA, MX, NS, DS, PTR)"))
+		})
+
+		It("rejects exclusion of all record types", func() {
+			spec := v1alpha1.DNSProviderSpec{
+				Type: "test",
+				RecordTypeSelection: &v1alpha1.DNSSelection{
+					Include: []string{"TXT"},
+					Exclude: []string{"TXT"},
+				},
+			}
+			result := CalcZoneAndDomainSelection(spec, allzones)
+			Expect(result.Error).To(Equal("record type selection excludes all record types"))
+		})
+	})
+
+	Context("problematic zones", func() {
+		It("reports included zones not found in the account", func() {
+			spec := v1alpha1.DNSProviderSpec{
+				Type: "test",
+				Zones: &v1alpha1.DNSSelection{
+					Include: []string{"ZAB", "ZXY"},
+				},
+			}
+			result := CalcZoneAndDomainSelection(spec, allzones)
+			Expect(result.Error).To(BeEmpty())
+			Expect(result.ProblematicZones).To(Equal([]v1alpha1.ProblematicZone{
+				{ID: "ZXY", Reason: v1alpha1.ZONE_PROBLEM_NOT_FOUND, Message: "zone not found in account"},
+			}))
+		})
+
+		It("reports only expected zones excluded by the selection", func() {
+			spec := v1alpha1.DNSProviderSpec{
+				Type: "test",
+				Zones: &v1alpha1.DNSSelection{
+					Include: []string{"ZAB", "ZOP"},
+					Exclude: []string{"ZOP"},
+				},
+			}
+			result := CalcZoneAndDomainSelection(spec, allzones)
+			Expect(result.Error).To(BeEmpty())
+			Expect(result.ProblematicZones).To(Equal([]v1alpha1.ProblematicZone{
+				{ID: "ZOP", Reason: v1alpha1.ZONE_PROBLEM_EXCLUDED_BY_SELECTOR, Message: "matching zones.exclude"},
+			}))
+		})
+
+		It("reports zones containing an included domain as expected", func() {
+			spec := v1alpha1.DNSProviderSpec{
+				Type: "test",
+				Domains: &v1alpha1.DNSSelection{
+					Include: []string{"x.c.a.b"},
+				},
+			}
+			result := CalcZoneAndDomainSelection(spec, allzones)
+			Expect(result.Error).To(BeEmpty())
+			Expect(result.ProblematicZones).To(Equal([]v1alpha1.ProblematicZone{
+				{ID: "ZAB", Reason: v1alpha1.ZONE_PROBLEM_EXCLUDED_BY_SELECTOR, Message: "no included domain in zone"},
+			}))
+		})
+
+		It("reports zones denied by the handler instead of not found", func() {
+			spec := v1alpha1.DNSProviderSpec{
+				Type: "test",
+				Zones: &v1alpha1.DNSSelection{
+					Include: []string{"ZAB", "ZXY"},
+					Exclude: []string{"ZOP"},
+				},
+			}
+			result := CalcZoneAndDomainSelection(spec, []LightDNSHostedZone{zab, zop})
+			result.AddAccessDeniedZones([]string{"ZXY", "ZDENIED"})
+			Expect(result.ProblematicZones).To(Equal([]v1alpha1.ProblematicZone{
+				{ID: "ZDENIED", Reason: v1alpha1.ZONE_PROBLEM_ACCESS_DENIED, Message: "no access to zone with given credentials"},
+				{ID: "ZXY", Reason: v1alpha1.ZONE_PROBLEM_ACCESS_DENIED, Message: "no access to zone with given credentials"},
+			}))
+		})
+	})
 })
@@ -0,0 +1,149 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// split mode of DNS entries
+//
+// An entry listing multiple providers in spec.splitProviders is served by all of
+// them simultaneously. The entry itself is pinned to the first provider, for each
+// further provider a DNS entry is maintained in the namespace of the entry.
+// It references the entry to inherit its targets, is pinned to the provider and
+// is owned by the entry.
+////////////////////////////////////////////////////////////////////////////////
+
+// SplitEntryName returns the name of the DNS entry maintained for a further provider of the given entry.
+func SplitEntryName(entryName string, provider resources.ObjectName) string {
+	return splitEntries.entryName(entryName, provider.String())
+}
+
+// primaryProviderRef returns the reference of the provider the entry is pinned to, if any.
+func primaryProviderRef(spec *api.DNSEntrySpec) *api.ProviderReference {
+	if len(spec.SplitProviders) > 0 {
+		return &spec.SplitProviders[0]
+	}
+	return spec.ProviderRef
+}
+
+// validateSplitProviders checks that the split mode lists at least two distinct providers
+// and is not combined with fields conflicting with it.
+func validateSplitProviders(namespace string, spec *api.DNSEntrySpec) error {
+	if len(spec.SplitProviders) == 0 {
+		return nil
+	}
+	if len(spec.SplitProviders) < 2 {
+		return fmt.Errorf("splitProviders requires at least two providers")
+	}
+	if spec.ProviderRef != nil {
+		return fmt.Errorf("splitProviders cannot be combined with providerRef")
+	}
+	if len(spec.DNSNames) > 0 {
+		return fmt.Errorf("splitProviders cannot be combined with dnsNames")
+	}
+	if spec.PreserveOnDelete != nil && *spec.PreserveOnDelete {
+		return fmt.Errorf("splitProviders cannot be combined with preserveOnDelete")
+	}
+	names := sets.New[resources.ObjectName]()
+	for i := range spec.SplitProviders {
+		ref := &spec.SplitProviders[i]
+		if ref.Name == "" {
+			return fmt.Errorf("provider %d in splitProviders has no name", i+1)
+		}
+		name := providerRefName(namespace, ref)
+		if names.Has(name) {
+			return fmt.Errorf("duplicate provider %s in splitProviders", name)
+		}
+		names.Insert(name)
+	}
+	return nil
+}
+
+// splitEntrySpec returns the desired spec of the DNS entry maintained for a further provider of the given entry.
+func splitEntrySpec(entry *api.DNSEntry, provider *api.ProviderReference) api.DNSEntrySpec {
+	return dependentEntrySpec(entry, entry.Spec.DNSName, provider.DeepCopy())
+}
+
+// splitEntryAnnotations returns the desired annotations of the DNS entry maintained for a further provider of the given entry.
+func splitEntryAnnotations(entry *api.DNSEntry) map[string]string {
+	return dependentEntryAnnotations(entry, splitEntries)
+}
+
+// syncSplitEntries creates, updates, or deletes the DNS entries maintained for the further providers of the entry
+// and aggregates the states of all providers in the status of the entry.
+func (this *state) syncSplitEntries(logger logger.LogContext, object *dnsutils.DNSEntryObject) error {
+	if len(object.Spec().SplitProviders) == 0 && len(object.Status().SplitProviders) == 0 && len(this.dependents.get(object.ObjectName())) == 0 {
+		return nil
+	}
+
+	entry := object.DNSEntry()
+	refs := object.Spec().SplitProviders
+	desired := make([]string, len(refs))
+	for i := 1; i < len(refs); i++ {
+		desired[i] = SplitEntryName(entry.Name, providerRefName(entry.Namespace, &refs[i]))
+	}
+	existing, err := this.getDependentEntries(splitEntries, object, desired...)
+	if err != nil {
+		return err
+	}
+
+	var providers []api.DNSEntrySplitProviderStatus
+	if !object.IsDeleting() {
+		valid := object.Status().State != api.STATE_INVALID
+		for i := range refs {
+			ref := &refs[i]
+			provider := providerRefName(entry.Namespace, ref)
+			status := api.DNSEntrySplitProviderStatus{Provider: provider.String()}
+			if i == 0 {
+				status.Entry = entry.Name
+				status.State = object.Status().State
+				status.Message = object.Status().Message
+				providers = append(providers, status)
+				continue
+			}
+			name := desired[i]
+			status.Entry = name
+			if o := existing[name]; o != nil {
+				delete(existing, name)
+				status.State = o.Status().State
+				status.Message = o.Status().Message
+			}
+			if valid {
+				desc := "provider " + provider.String()
+				if err := this.assureDependentEntry(logger, object.GetResource(), entry, name, desc, splitEntrySpec(entry, ref), splitEntryAnnotations(entry)); err != nil {
+					return err
+				}
+			}
+			providers = append(providers, status)
+		}
+	}
+	if err := deleteDependentEntries(logger, splitEntries, existing); err != nil {
+		return err
+	}
+
+	if object.IsDeleting() {
+		return nil
+	}
+	_, err = object.ModifyStatus(func(data resources.ObjectData) (bool, error) {
+		status := &data.(*api.DNSEntry).Status
+		if reflect.DeepEqual(status.SplitProviders, providers) {
+			return false, nil
+		}
+		status.SplitProviders = providers
+		return true, nil
+	})
+	return err
+}
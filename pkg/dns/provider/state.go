@@ -5,7 +5,9 @@
 package provider
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -17,6 +19,7 @@ import (
 	"k8s.io/client-go/util/flowcontrol"
 
 	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+	"github.com/gardener/controller-manager-library/pkg/ctxutil"
 	"github.com/gardener/controller-manager-library/pkg/logger"
 	"github.com/gardener/controller-manager-library/pkg/resources"
 	"github.com/gardener/controller-manager-library/pkg/resources/access"
@@ -25,6 +28,7 @@ import (
 	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
 	"github.com/gardener/external-dns-management/pkg/dns"
 	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+	"github.com/gardener/external-dns-management/pkg/server/readyz"
 	"github.com/gardener/external-dns-management/pkg/server/remote/embed"
 )
 
@@ -50,6 +54,10 @@ type zoneReconciliation struct {
 	deleting     bool
 	fhandler     FinalizerHandler
 	dnsTicker    *Ticker
+	// recordSetLimit is the maximum number of record sets in the zone (unlimited if 0).
+	recordSetLimit int
+	// migrating contains the entries of migrating providers to be prepared in the zone of the target provider.
+	migrating Entries
 }
 
 type setup struct {
@@ -126,13 +134,28 @@ type state struct {
 	providersecrets map[resources.ObjectName]resources.ObjectName
 	zonePolicies    map[string]*dnsHostedZonePolicy
 	zoneStateTTL    atomic.Value
+	pausedZones     atomic.Value
+	// zoneRecordSetLimits contains the record set limits of zones specified by zone policies.
+	zoneRecordSetLimits atomic.Value
+	// zoneRecordSets contains the number of record sets per zone found on the last zone reconciliation.
+	zoneRecordSets sync.Map
 
 	entries         Entries
 	outdated        *synchronizedEntries
 	blockingEntries map[resources.ObjectName]time.Time
 
 	providerRateLimiter map[resources.ObjectName]*rateLimiterData
-	prlock              sync.RWMutex
+	// zoneRateLimiter contains the rate limiters of zones specified by zone policies.
+	zoneRateLimiter map[dns.ZoneID]*rateLimiterData
+	prlock          sync.RWMutex
+
+	// migrations contains the migrations of source providers to target providers.
+	migrations map[resources.ObjectName]*providerMigration
+	// mirrors contains the mirror providers of providers.
+	mirrors map[resources.ObjectName]resources.ObjectName
+	mlock   sync.Mutex
+	// dependents contains the entries maintained for aliases, split providers and mirror providers of entries.
+	dependents *dependentIndex
 
 	dnsnames   ZonedDNSSetNames
 	references *References
@@ -141,35 +164,111 @@ type state struct {
 
 	dnsTicker *Ticker
 
-	lookupProcessor *lookupProcessor
+	lookupProcessor     *lookupProcessor
+	propagationVerifier *propagationVerifier
+	entryRateLimiter    flowcontrol.RateLimiter
+	statusThrottle      *statusThrottle
+	eventThrottle       *eventThrottle
+	drainer             *drainer
+	zoneCacheReadiness  *zoneCacheReadiness
 
 	providerEventListeners []ProviderEventListener
+
+	// credentialsFiles contains the mounted credentials used by providers instead of secrets.
+	credentialsFiles *credentialsFiles
 }
 
-type rateLimiterData struct {
-	api.RateLimit
+// rateLimiterLane is a token bucket of a rate limit remembering the time of the last accepted request.
+type rateLimiterLane struct {
 	rateLimiter flowcontrol.RateLimiter
 	lastAccept  atomic.Value
 }
 
+type rateLimiterData struct {
+	api.RateLimit
+	rateLimiterLane
+	// priority is the lane of entries with high rate limit priority. It has the same rate limit as the default lane,
+	// so that these entries are not delayed by other entries, but are still rate limited.
+	priority rateLimiterLane
+}
+
 func NewDNSState(pctx ProviderContext, ownerresc, secretresc resources.Interface, classes *controller.Classes, config Config) *state {
 	pctx.Infof("responsible for classes:     %s (%s)", classes, classes.Main())
 	pctx.Infof("availabled providers types   %s", config.Factory.TypeCodes())
 	pctx.Infof("enabled providers types:     %s", config.EnabledTypes)
 	pctx.Infof("using default ttl:           %d", config.TTL)
+	if config.MinTTL > 0 || config.MaxTTL > 0 {
+		pctx.Infof("using ttl range:             %d-%d", config.MinTTL, config.MaxTTL)
+	}
 	pctx.Infof("using identifier:            %s", config.Ident)
 	pctx.Infof("dry run mode:                %t", config.Dryrun)
 	pctx.Infof("reschedule delay:            %v", config.RescheduleDelay)
 	pctx.Infof("zone cache ttl for zones:    %v", config.CacheTTL)
+	pctx.Infof("lookup negative cache ttl:   %v", config.LookupNegativeCacheTTL)
+	pctx.Infof("lookup dedup window:         %v", config.LookupDedupWindow)
+	if len(config.LookupNameservers) > 0 {
+		pctx.Infof("lookup nameservers:          %s", strings.Join(config.LookupNameservers, ", "))
+	}
+	pctx.Infof("provider probe interval:     %v", config.ProviderProbeInterval)
 	pctx.Infof("disable zone state caching:  %t", !config.ZoneStateCaching)
 	pctx.Infof("disable DNS name validation:  %t", config.DisableDNSNameValidation)
+	pctx.Infof("max targets:                 %d", config.MaxTargets)
+	pctx.Infof("max CNAME targets:           %d", config.MaxCNAMETargets)
+	pctx.Infof("zone record set limit:       %d", config.ZoneRecordSetLimit)
+	pctx.Infof("log state transitions:       %t", config.LogStateTransitions)
+	pctx.Infof("zone audit interval:         %v (mode %s)", config.ZoneAuditInterval, config.ZoneAuditMode)
+	pctx.Infof("initial zone cache timeout:  %v", config.InitialZoneCacheTimeout)
+	if config.CredentialsDir != "" {
+		pctx.Infof("provider credentials dir:    %s", config.CredentialsDir)
+	}
+	if config.OwnershipRecordOwnerID != "" {
+		pctx.Infof("ownership record owner id:   %s (prefix %s)", config.OwnershipRecordOwnerID, dns.OwnershipPrefix)
+	}
+	if config.EntryNamespaceFilter != nil {
+		pctx.Infof("entry namespaces:            %s", config.EntryNamespaceFilter)
+	}
+	pctx.Infof("provider tie breaker:        %s", config.ProviderTieBreaker)
+	if config.OwnerConflict != nil {
+		pctx.Infof("owner conflict strategy:     %s", config.OwnerConflict)
+	}
+	pctx.Infof("CNAME lookup interval:       %v (minimum %v, TTL divisor %d)", config.CNameLookupInterval, config.CNameLookupMinInterval, config.CNameLookupTTLDivisor)
+	pctx.Infof("propagation verification:    %t (timeout %v, interval %v)", config.PropagationVerification, config.PropagationTimeout, config.PropagationInterval)
+	if config.EntryReconcileRateLimiter != nil {
+		pctx.Infof("entry reconcile rate limit:  %s", config.EntryReconcileRateLimiter)
+	}
+	if config.CircuitBreakerThreshold > 0 {
+		pctx.Infof("provider circuit breaker:    %d failures (cool-down %v)", config.CircuitBreakerThreshold, config.CircuitBreakerCoolDown)
+	}
+	pctx.Infof("entry reconcile interval:    minimum %v", config.EntryReconcileMinInterval)
+	if config.DrainTimeout > 0 {
+		pctx.Infof("drain timeout on shutdown:   %v", config.DrainTimeout)
+	}
+	if config.EntryStatusMinInterval > 0 {
+		pctx.Infof("entry status min interval:   %v", config.EntryStatusMinInterval)
+	}
+	pctx.Infof("entry event dedup window:    %v", config.EntryEventWindow)
+	for typecode, d := range config.ProviderTypeDefaults {
+		if data, err := json.Marshal(d); err == nil {
+			pctx.Infof("defaults for provider type %s: %s", typecode, data)
+		}
+	}
 	if config.RemoteAccessConfig != nil {
 		pctx.Infof("remote access server port: %d", config.RemoteAccessConfig.Port)
 	}
+	if config.TracingEndpoint != "" {
+		pctx.Infof("tracing endpoint:            %s", config.TracingEndpoint)
+	}
+
+	if config.LogStateTransitions {
+		EnableStateTransitionLog(os.Stdout)
+	}
 
 	realms := access.RealmTypes{"use": access.NewRealmType(dns.REALM_ANNOTATION)}
 
-	return &state{
+	// config has been validated already
+	entryRateLimiter, _ := config.EntryReconcileRateLimiter.NewRateLimiter()
+
+	state := &state{
 		setup:               newSetup(),
 		classes:             classes,
 		context:             pctx,
@@ -187,6 +286,7 @@ func NewDNSState(pctx ProviderContext, ownerresc, secretresc resources.Interface
 		zoneproviders:       map[dns.ZoneID]resources.ObjectNameSet{},
 		providerzones:       map[resources.ObjectName]map[dns.ZoneID]*dnsHostedZone{},
 		providersecrets:     map[resources.ObjectName]resources.ObjectName{},
+		credentialsFiles:    newCredentialsFiles(config.CredentialsDir),
 		zonePolicies:        map[string]*dnsHostedZonePolicy{},
 		entries:             Entries{},
 		outdated:            newSynchronizedEntries(),
@@ -194,10 +294,24 @@ func NewDNSState(pctx ProviderContext, ownerresc, secretresc resources.Interface
 		dnsnames:            map[ZonedDNSSetName]*Entry{},
 		references:          NewReferenceCache(),
 		providerRateLimiter: map[resources.ObjectName]*rateLimiterData{},
+		zoneRateLimiter:     map[dns.ZoneID]*rateLimiterData{},
+		migrations:          map[resources.ObjectName]*providerMigration{},
+		mirrors:             map[resources.ObjectName]resources.ObjectName{},
+		dependents:          newDependentIndex(),
+		entryRateLimiter:    entryRateLimiter,
+		statusThrottle:      newStatusThrottle(config.EntryStatusMinInterval, pctx),
+		eventThrottle:       newEventThrottle(config.EntryEventWindow),
+		drainer:             newDrainer(),
+		zoneCacheReadiness:  newZoneCacheReadiness(config.InitialZoneCacheTimeout > 0),
 	}
+	readyz.Register("zone-cache/"+config.Factory.Name(), state.zoneCacheReadiness.IsReady)
+	return state
 }
 
 func (this *state) IsResponsibleFor(logger logger.LogContext, obj resources.Object) bool {
+	if obj.IsA(&api.DNSEntry{}) && !this.config.EntryNamespaceFilter.Matches(obj.GetNamespace()) {
+		return false
+	}
 	return this.classes.IsResponsibleFor(logger, obj)
 }
 
@@ -221,7 +335,18 @@ func (this *state) Setup() error {
 		15*time.Second,
 		this.context.GetCluster(TARGET_CLUSTER).GetId(),
 		defaultLookupMetrics{},
+		this.config.LookupNegativeCacheTTL,
+		this.config.LookupDedupWindow,
+		this.config.LookupNameservers,
 	)
+	if this.config.PropagationVerification {
+		this.propagationVerifier = newPropagationVerifier(
+			this.context.NewContext("sub", "propagationVerifier"),
+			this.config.PropagationTimeout,
+			this.config.PropagationInterval,
+			this.getZoneDomain,
+		)
+	}
 
 	if this.config.RemoteAccessConfig != nil {
 		secret := &corev1.Secret{}
@@ -235,17 +360,26 @@ func (this *state) Setup() error {
 			return fmt.Errorf("startRemoteAccessServer failed with: %w", err)
 		}
 	}
+	if this.config.TracingEndpoint != "" {
+		if err := this.startTracing(); err != nil {
+			return fmt.Errorf("startTracing failed with: %w", err)
+		}
+	}
 
 	this.context.Infof("using %d parallel workers for initialization", processors)
 	if err := this.setupFor(&api.DNSProvider{}, "providers", func(e resources.Object) error {
 		p := dnsutils.DNSProvider(e)
 		if this.GetHandlerFactory().IsResponsibleFor(p) {
+			if this.config.EnabledTypes.Contains(p.TypeCode()) && !p.IsDeleting() {
+				this.zoneCacheReadiness.AddPending(p.ObjectName())
+			}
 			this.UpdateProvider(this.context.NewContext("provider", p.ObjectName().String()), p)
 		}
 		return nil
 	}, processors); err != nil {
 		return err
 	}
+	this.checkZoneCacheReadiness(this.context)
 	if err := this.setupFor(&api.DNSOwner{}, "owners", func(e resources.Object) error {
 		p := dnsutils.DNSOwner(e)
 		this.UpdateOwner(this.context.NewContext("owner", p.ObjectName().String()), p, true)
@@ -253,6 +387,9 @@ func (this *state) Setup() error {
 	}, processors); err != nil {
 		return err
 	}
+	if err := this.releaseOutOfScopeEntries(); err != nil {
+		return err
+	}
 	if err := this.setupFor(&api.DNSEntry{}, "entries", func(e resources.Object) error {
 		p := dnsutils.DNSEntry(e)
 		this.UpdateEntry(this.context.NewContext("entry", p.ObjectName().String()), p)
@@ -263,10 +400,32 @@ func (this *state) Setup() error {
 
 	this.triggerStatistic()
 	this.initialized = true
+	if this.config.DrainTimeout > 0 {
+		this.drainOnShutdown()
+	}
 	this.context.Infof("setup done - starting reconciliation")
 	return nil
 }
 
+// drainOnShutdown finishes in-flight reconciliations on shutdown of the controller.
+// The shutdown of the process is delayed until draining is done or the drain timeout is reached.
+func (this *state) drainOnShutdown() {
+	ctx := this.context.GetContext()
+	ctxutil.WaitGroupRun(ctx, func() {
+		<-ctx.Done()
+		skipped := this.statusThrottle.Stop()
+		this.context.Infof("draining: waiting up to %v for in-flight reconciliations", this.config.DrainTimeout)
+		if inflight := this.drainer.Drain(this.config.DrainTimeout); inflight > 0 {
+			this.context.Warnf("draining: timeout reached with %d in-flight reconciliations", inflight)
+		} else {
+			this.context.Infof("draining: all in-flight reconciliations finished")
+		}
+		if skipped > 0 {
+			this.context.Infof("draining: %d throttled entry status updates are caught up after restart", skipped)
+		}
+	})
+}
+
 func (this *state) startRemoteAccessServer(secret *corev1.Secret) error {
 	this.context.Infof("starting RemoteAccessServer")
 	server, err := embed.StartDNSHandlerServer(this.context, this.config.RemoteAccessConfig)
@@ -290,7 +449,12 @@ func (this *state) setupFor(obj runtime.Object, msg string, exec func(resources.
 	if err != nil {
 		return err
 	}
-	list, err := res.ListCached(labels.Everything())
+	var list []resources.Object
+	if _, ok := obj.(*api.DNSEntry); ok {
+		list, err = this.config.EntryNamespaceFilter.listEntries(res)
+	} else {
+		list, err = res.ListCached(labels.Everything())
+	}
 	if err != nil {
 		return err
 	}
@@ -303,10 +467,17 @@ func (this *state) setupFor(obj runtime.Object, msg string, exec func(resources.
 }
 
 func (this *state) Start() {
+	this.startupTime = time.Now()
+	if this.config.ZoneAuditInterval > 0 {
+		this.setup.AddCommand(CMD_ZONE_AUDIT)
+	}
 	this.setup.Start(this.context)
 	this.setup = nil
-	this.startupTime = time.Now()
+	this.startZoneCacheReadiness()
 	go this.lookupProcessor.Run(this.context.GetContext())
+	if this.config.CredentialsDir != "" {
+		go this.watchCredentialsFiles()
+	}
 }
 
 func (this *state) HasFinalizer(obj resources.Object) bool {
@@ -407,16 +578,27 @@ func (this *state) LookupProvider(e *EntryVersion) (DNSProvider, error) {
 }
 
 type providerMatch struct {
-	found DNSProvider
+	found *dnsProviderVersion
 	match int
 }
 
+func (this *providerMatch) provider() DNSProvider {
+	if this.found == nil {
+		return nil
+	}
+	return this.found
+}
+
 func (this *state) lookupProvider(e *dnsutils.DNSEntryObject) (DNSProvider, DNSProvider, error) {
+	if ref := primaryProviderRef(e.Spec()); ref != nil {
+		provider, err := this.lookupReferencedProvider(e, ref)
+		return provider, nil, err
+	}
 	handleMatch := func(match *providerMatch, p *dnsProviderVersion, n int, err error) error {
 		if match.match <= n {
 			err2 := access.CheckAccessWithRealms(e, "use", p.Object(), this.realms)
 			if err2 == nil {
-				if match.match < n || (e.Status().Provider != nil && *e.Status().Provider == p.object.ObjectName().String()) {
+				if match.match < n || preferProvider(this.config.ProviderTieBreaker, p.rank(), match.found.rank(), utils.StringValue(e.Status().Provider)) {
 					match.found = p
 					match.match = n
 				}
@@ -433,6 +615,9 @@ func (this *state) lookupProvider(e *dnsutils.DNSEntryObject) (DNSProvider, DNSP
 	errorMatch := &providerMatch{}
 	validMatchFallback := &providerMatch{}
 	for _, p := range this.providers {
+		if this.isMigratedAwayFrom(p, e) {
+			continue
+		}
 		n := p.Match(e.GetDNSName())
 		if n > 0 {
 			if p.IsValid() {
@@ -453,7 +638,51 @@ func (this *state) lookupProvider(e *dnsutils.DNSEntryObject) (DNSProvider, DNSP
 	if errorMatch.found != nil {
 		return errorMatch.found, nil, nil
 	}
-	return nil, validMatchFallback.found, err
+	return nil, validMatchFallback.provider(), err
+}
+
+// lookupReferencedProvider returns the provider referenced by the entry. There is no fallback to
+// another provider, if the referenced one is missing or does not serve the DNS name of the entry.
+// An invalid referenced provider is returned together with an error.
+func (this *state) lookupReferencedProvider(e *dnsutils.DNSEntryObject, ref *api.ProviderReference) (DNSProvider, error) {
+	name := providerRefName(e.GetNamespace(), ref)
+	p, ok := this.providers[name]
+	if !ok {
+		return nil, checkReferencedProvider(name, nil, e.GetDNSName())
+	}
+	if !this.config.EntryNamespaceFilter.Matches(name.Namespace()) {
+		return nil, fmt.Errorf("referenced provider %s is not in the handled namespaces (%s)", name, this.config.EntryNamespaceFilter)
+	}
+	if err := access.CheckAccessWithRealms(e, "use", p.Object(), this.realms); err != nil {
+		return nil, err
+	}
+	if !p.IsValid() {
+		// as for invalid providers matching by domain selection, served entries become stale and keep their records
+		return p, fmt.Errorf("referenced provider %s is not valid", name)
+	}
+	if err := checkReferencedProvider(name, p, e.GetDNSName()); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// providerRefName returns the object name of the referenced provider, defaulting the namespace by the one of the entry.
+func providerRefName(namespace string, ref *api.ProviderReference) resources.ObjectName {
+	if ref.Namespace != "" {
+		namespace = ref.Namespace
+	}
+	return resources.NewObjectName(namespace, ref.Name)
+}
+
+// checkReferencedProvider checks that the referenced provider exists and its domain selection includes the DNS name.
+func checkReferencedProvider(name resources.ObjectName, p DNSProvider, dnsName string) error {
+	if p == nil {
+		return fmt.Errorf("referenced provider %s not found", name)
+	}
+	if p.Match(dnsName) == 0 {
+		return fmt.Errorf("referenced provider %s does not serve domain name %s", name, dnsName)
+	}
+	return nil
 }
 
 func (this *state) GetProvider(name resources.ObjectName) DNSProvider {
@@ -658,6 +887,7 @@ func (this *state) updateZones(logger logger.LogContext, last, new *dnsProviderV
 				zone = newDNSHostedZone(this.config.RescheduleDelay, z)
 				this.zones[z.Id()] = zone
 				logger.Infof("adding hosted zone %q (%s)", z.Id(), z.Domain())
+				this.assignZonePolicy(logger, zone)
 				this.triggerHostedZone(zone.Id())
 				this.triggerAllZonePolicies()
 			}
@@ -710,26 +940,68 @@ func (this *state) RefineLogger(logger logger.LogContext, ptype string) logger.L
 	return logger
 }
 
-func (this *state) tryAcceptProviderRateLimiter(logger logger.LogContext, entry *Entry) (bool, time.Duration) {
-	delay := 0 * time.Second
-	if entry.providername == nil {
-		logger.Infof("missing providername for entry %s", entry.ObjectName())
-		return true, delay
-	}
+// tryAcceptProviderRateLimiter checks the rate limit for a create/update operation of an entry in the given zone.
+// The rate limit of a zone policy takes precedence over the rate limit of the provider.
+// Entries with high rate limit priority use the priority lane of the rate limiter.
+func (this *state) tryAcceptProviderRateLimiter(logger logger.LogContext, zone *dnsHostedZone, entry *Entry) (bool, time.Duration) {
 	this.prlock.Lock()
 	defer this.prlock.Unlock()
 
-	rt := this.providerRateLimiter[entry.providername]
+	rt := this.getRateLimiter(logger, zone, entry.providername)
 	if rt == nil {
+		if entry.providername == nil {
+			logger.Infof("missing providername for entry %s", entry.ObjectName())
+		}
 		// not rate limited
-		return true, delay
+		return true, 0
+	}
+	if entry.HasHighRateLimitPriority() {
+		return rt.priority.tryAccept(rt.RequestsPerDay)
+	}
+	return rt.tryAccept(rt.RequestsPerDay)
+}
+
+// getRateLimiter returns the rate limiter of the zone policy or of the provider (nil if not rate limited).
+// The rate limiter of a zone policy is created lazily if the zone has been discovered
+// after the last update of the zone rate limiters.
+// It must be called with the prlock held.
+func (this *state) getRateLimiter(logger logger.LogContext, zone *dnsHostedZone, providername resources.ObjectName) *rateLimiterData {
+	if rt := this.zoneRateLimiter[zone.Id()]; rt != nil {
+		return rt
+	}
+	if rateLimit := zonePolicyRateLimit(zone); rateLimit != nil {
+		rt := newRateLimiterData(*rateLimit)
+		this.zoneRateLimiter[zone.Id()] = rt
+		logger.Infof("frontend rate limiter of zone %s created: requestsPerDay=%d, burst=%d", zone.Id(), rateLimit.RequestsPerDay, rateLimit.Burst)
+		return rt
 	}
-	accepted := rt.rateLimiter.TryAccept()
+	if providername == nil {
+		return nil
+	}
+	return this.providerRateLimiter[providername]
+}
+
+func newRateLimiterData(rateLimit api.RateLimit) *rateLimiterData {
+	qps := float32(rateLimit.RequestsPerDay) / 86400
+	return &rateLimiterData{
+		RateLimit:       rateLimit,
+		rateLimiterLane: rateLimiterLane{rateLimiter: flowcontrol.NewTokenBucketRateLimiter(qps, rateLimit.Burst)},
+		priority:        rateLimiterLane{rateLimiter: flowcontrol.NewTokenBucketRateLimiter(qps, rateLimit.Burst)},
+	}
+}
+
+func (this *rateLimiterData) matches(rateLimit *api.RateLimit) bool {
+	return this.RequestsPerDay == rateLimit.RequestsPerDay && this.Burst == rateLimit.Burst
+}
+
+func (this *rateLimiterLane) tryAccept(requestsPerDay int) (bool, time.Duration) {
+	delay := 0 * time.Second
+	accepted := this.rateLimiter.TryAccept()
 	if accepted {
-		rt.lastAccept.Store(time.Now())
+		this.lastAccept.Store(time.Now())
 	} else {
-		delay = time.Duration(86400/rt.RequestsPerDay) * time.Second
-		value := rt.lastAccept.Load()
+		delay = time.Duration(86400/requestsPerDay) * time.Second
+		value := this.lastAccept.Load()
 		if value != nil {
 			lastAccept := value.(time.Time)
 			delay -= time.Since(lastAccept)
@@ -0,0 +1,156 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/reconcile"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	corev1 "k8s.io/api/core/v1"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+	"github.com/gardener/external-dns-management/pkg/server/metrics"
+)
+
+const (
+	// ZoneAuditModeReport only reports entries with drifted records.
+	ZoneAuditModeReport = "report"
+	// ZoneAuditModeHeal reports entries with drifted records and triggers the zone reconciliation to reapply them.
+	ZoneAuditModeHeal = "heal"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// state handling for the periodic zone audit
+////////////////////////////////////////////////////////////////////////////////
+
+// AuditZones compares the records of all served hosted zones as read from the DNS backend with the
+// desired state of the ready DNS entries. Entries with drifted records are reported and, in mode
+// ZoneAuditModeHeal, the zone reconciliation is triggered to reapply them.
+func (this *state) AuditZones(logger logger.LogContext) reconcile.Status {
+	interval := this.config.ZoneAuditInterval
+	if interval <= 0 {
+		return reconcile.Succeeded(logger)
+	}
+	if wait := interval - time.Since(this.startupTime); wait > 0 {
+		// give the regular reconciliations a chance to complete after startup
+		return reconcile.Succeeded(logger).RescheduleAfter(wait)
+	}
+
+	zoneids := this.getServedZoneIDs()
+	logger.Infof("zone audit started for %d zones (mode %s)", len(zoneids), this.config.ZoneAuditMode)
+	drifted := 0
+	for _, zoneid := range zoneids {
+		count, err := this.auditZone(logger, zoneid)
+		if err != nil {
+			logger.Warnf("zone audit of %s failed: %s", zoneid, err)
+			continue
+		}
+		drifted += count
+	}
+	logger.Infof("zone audit done: %d drifted entries, next audit in %s", drifted, interval)
+	return reconcile.Succeeded(logger).RescheduleAfter(interval)
+}
+
+// getServedZoneIDs returns the sorted ids of all served hosted zones.
+func (this *state) getServedZoneIDs() []dns.ZoneID {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	zoneids := make([]dns.ZoneID, 0, len(this.zones))
+	for zoneid := range this.zones {
+		zoneids = append(zoneids, zoneid)
+	}
+	sort.Slice(zoneids, func(i, j int) bool {
+		if zoneids[i].ProviderType != zoneids[j].ProviderType {
+			return zoneids[i].ProviderType < zoneids[j].ProviderType
+		}
+		return zoneids[i].ID < zoneids[j].ID
+	})
+	return zoneids
+}
+
+// getZoneAudit returns the zone reconciliation request used for auditing the zone or nil if the zone is not served anymore.
+func (this *state) getZoneAudit(logger logger.LogContext, zoneid dns.ZoneID) *zoneReconciliation {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+
+	zone := this.zones[zoneid]
+	if zone == nil {
+		return nil
+	}
+	req := &zoneReconciliation{
+		fhandler:  this.context,
+		ownership: this.ownerCache,
+		zone:      zone,
+		dnsTicker: this.dnsTicker,
+	}
+	req.entries, req.equivEntries, req.stale, req.deleting = this.addEntriesForZone(logger, nil, nil, zone)
+	req.providers = this.getProvidersForZone(zoneid)
+	return req
+}
+
+// auditZone reads the records of the zone bypassing the zone state cache and returns the number of
+// ready entries whose records deviate from their desired state.
+func (this *state) auditZone(logger logger.LogContext, zoneid dns.ZoneID) (int, error) {
+	if policyName := this.getZonePausedBy(zoneid); policyName != "" {
+		logger.Infof("zone audit of %s skipped: paused by DNSHostedZonePolicy %s", zoneid, policyName)
+		return 0, nil
+	}
+	req := this.getZoneAudit(logger, zoneid)
+	if req == nil || len(req.providers) == 0 {
+		return 0, nil
+	}
+	if source, _ := this.getZoneReadOnlyBy(req.providers); source != nil {
+		logger.Infof("zone audit of %s skipped: read-only during the migration of provider %s", zoneid, source)
+		return 0, nil
+	}
+	if !req.zone.TestAndSetBusy() {
+		logger.Infof("zone audit of %s skipped: zone reconciliation busy", zoneid)
+		return 0, nil
+	}
+	defer req.zone.Release()
+
+	changes := NewChangeModel(logger, req.ownership, req, this.config)
+	if p := changes.getDefaultProvider(); p != nil {
+		// records may have been changed directly in the DNS backend, so the cached zone state cannot be trusted
+		p.ReportZoneStateConflict(req.zone.getZone(), &perrs.StaleZoneState{Reason: "zone audit"})
+	}
+	if err := changes.Setup(); err != nil {
+		return 0, err
+	}
+
+	var drifted []*Entry
+	for _, e := range req.entries {
+		if e.IsDeleting() || e.State() != api.STATE_READY {
+			// not settled yet, handled by the regular reconciliation anyway
+			continue
+		}
+		spec := e.object.GetTargetSpec(e)
+		if result := changes.Check(e.DNSSetName(), e.ObjectName().Namespace(), e.CreatedAt(), nil, spec); result.Modified {
+			drifted = append(drifted, e)
+		}
+	}
+	metrics.ReportZoneDriftedEntries(zoneid, len(drifted))
+	if len(drifted) == 0 {
+		return 0, nil
+	}
+
+	for _, e := range drifted {
+		msg := fmt.Sprintf("records of %s in zone %s deviate from the desired state", e.DNSSetName(), zoneid.ID)
+		if this.config.ZoneAuditMode == ZoneAuditModeHeal {
+			msg += ", reapplying"
+		}
+		logger.Warnf("zone audit: entry %s: %s", e.ObjectName(), msg)
+		e.event(corev1.EventTypeWarning, "drift", msg)
+	}
+	if this.config.ZoneAuditMode == ZoneAuditModeHeal {
+		this.triggerHostedZone(zoneid)
+	}
+	return len(drifted), nil
+}
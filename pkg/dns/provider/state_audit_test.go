@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+)
+
+var _ = ginkgov2.Describe("Zone audit", func() {
+	var (
+		s       *state
+		ctx     *testProviderContext
+		zone    *dnsHostedZone
+		backend *InMemory
+		handler *testZoneHandler
+		entry   *Entry
+	)
+
+	setRecords := func(dnsName string, values ...string) {
+		set := dns.NewDNSSet(dns.DNSSetName{DNSName: dnsName}, nil)
+		set.SetRecordSet(dns.RS_A, 300, values...)
+		Expect(backend.Apply(zone.Id(), NewChangeRequest(R_CREATE, dns.RS_A, nil, set, nil), &NullMetrics{})).To(Succeed())
+	}
+
+	ginkgov2.BeforeEach(func() {
+		zone = newDNSHostedZone(0, NewDNSHostedZone("test", "z1", "example.com", "", false))
+		backend = NewInMemory()
+		backend.AddZone(zone.getZone())
+		handler = &testZoneHandler{inMemory: backend}
+		p := newTestProvider("p1", zone.getZone(), handler)
+		ctx = &testProviderContext{}
+		s = &state{
+			context:       ctx,
+			startupTime:   time.Now().Add(-1 * time.Hour),
+			config:        Config{ZoneAuditInterval: 10 * time.Minute, ZoneAuditMode: ZoneAuditModeReport},
+			zones:         map[dns.ZoneID]*dnsHostedZone{zone.Id(): zone},
+			providers:     map[resources.ObjectName]*dnsProviderVersion{p.ObjectName(): p},
+			zoneproviders: map[dns.ZoneID]resources.ObjectNameSet{zone.Id(): resources.NewObjectNameSet(p.ObjectName())},
+			dnsnames:      ZonedDNSSetNames{},
+		}
+		s.ownerCache = NewOwnerCache(nil, &s.config)
+		entry = newTestEntry(s, "e1", "a.example.com", api.STATE_READY, zone.Id(), "1.1.1.1")
+		s.dnsnames[entry.ZonedDNSName()] = entry
+		setRecords("a.example.com", "1.1.1.1")
+	})
+
+	ginkgov2.It("reports no drift if the records match the entries", func() {
+		count, err := s.auditZone(logger.New(), zone.Id())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(Equal(0))
+		Expect(testEvents(entry)).To(BeEmpty())
+		// the zone state is always read from the backend
+		Expect(handler.conflicts).To(HaveLen(1))
+		Expect(handler.conflicts[0]).To(BeAssignableToTypeOf(&perrs.StaleZoneState{}))
+	})
+
+	ginkgov2.It("reports entries with drifted records", func() {
+		setRecords("a.example.com", "2.2.2.2")
+		count, err := s.auditZone(logger.New(), zone.Id())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(Equal(1))
+		Expect(testEvents(entry)).To(ConsistOf("drift: records of a.example.com in zone z1 deviate from the desired state"))
+		Expect(ctx.commands).To(BeEmpty())
+	})
+
+	ginkgov2.It("reports entries with missing records", func() {
+		Expect(backend.Apply(zone.Id(), NewChangeRequest(R_DELETE, dns.RS_A, newTestDNSSet("a.example.com", "1.1.1.1"), nil, nil), &NullMetrics{})).To(Succeed())
+		count, err := s.auditZone(logger.New(), zone.Id())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(Equal(1))
+	})
+
+	ginkgov2.It("triggers the zone reconciliation for drifted entries in heal mode", func() {
+		s.config.ZoneAuditMode = ZoneAuditModeHeal
+		setRecords("a.example.com", "2.2.2.2")
+		count, err := s.auditZone(logger.New(), zone.Id())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(Equal(1))
+		Expect(testEvents(entry)).To(ConsistOf("drift: records of a.example.com in zone z1 deviate from the desired state, reapplying"))
+		Expect(ctx.commands).To(ConsistOf(CMD_HOSTEDZONE_PREFIX + "test:z1"))
+	})
+
+	ginkgov2.It("ignores entries not ready", func() {
+		entry.status.State = api.STATE_PENDING
+		setRecords("a.example.com", "2.2.2.2")
+		count, err := s.auditZone(logger.New(), zone.Id())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(Equal(0))
+		Expect(testEvents(entry)).To(BeEmpty())
+	})
+
+	ginkgov2.It("skips paused zones", func() {
+		s.pausedZones.Store(map[dns.ZoneID]string{zone.Id(): "policy"})
+		setRecords("a.example.com", "2.2.2.2")
+		count, err := s.auditZone(logger.New(), zone.Id())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(Equal(0))
+		Expect(handler.conflicts).To(BeEmpty())
+	})
+
+	ginkgov2.It("skips zones with running reconciliation", func() {
+		Expect(zone.TestAndSetBusy()).To(BeTrue())
+		defer zone.Release()
+		setRecords("a.example.com", "2.2.2.2")
+		count, err := s.auditZone(logger.New(), zone.Id())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(Equal(0))
+		Expect(handler.conflicts).To(BeEmpty())
+	})
+
+	ginkgov2.It("is disabled without interval", func() {
+		s.config.ZoneAuditInterval = 0
+		setRecords("a.example.com", "2.2.2.2")
+		status := s.AuditZones(logger.New())
+		Expect(status.Interval).To(BeNumerically("<", 0))
+		Expect(testEvents(entry)).To(BeEmpty())
+	})
+
+	ginkgov2.It("waits one interval after startup", func() {
+		s.startupTime = time.Now()
+		setRecords("a.example.com", "2.2.2.2")
+		status := s.AuditZones(logger.New())
+		Expect(status.Interval).To(BeNumerically("~", 10*time.Minute, 1*time.Minute))
+		Expect(testEvents(entry)).To(BeEmpty())
+	})
+
+	ginkgov2.It("audits all zones and reschedules after the interval", func() {
+		setRecords("a.example.com", "2.2.2.2")
+		status := s.AuditZones(logger.New())
+		Expect(status.Interval).To(Equal(10 * time.Minute))
+		Expect(testEvents(entry)).To(HaveLen(1))
+	})
+})
@@ -82,12 +82,46 @@ func (this *state) addBlockingEntries(logger logger.LogContext, entries Entries)
 
 ////////////////////////////////////////////////////////////////////////////////
 
+// TryAcceptEntryReconcile checks whether the entry reconcile rate limiter admits the next reconciliation.
+// If not, it returns the delay after which the reconciliation should be retried, so that no worker is blocked.
+func (this *state) TryAcceptEntryReconcile(logger logger.LogContext) (bool, time.Duration) {
+	if this.entryRateLimiter.TryAccept() {
+		return true, 0
+	}
+	delay := time.Second
+	if qps := this.entryRateLimiter.QPS(); qps > 0 {
+		delay = time.Duration(float64(time.Second) / float64(qps))
+	}
+	logger.Debugf("entry reconciliation throttled by rate limiter, retry in %s", delay)
+	return false, delay
+}
+
 func (this *state) UpdateEntry(logger logger.LogContext, object *dnsutils.DNSEntryObject) reconcile.Status {
-	return this.HandleUpdateEntry(logger, "reconcile", object)
+	status := this.HandleUpdateEntry(logger, "reconcile", object)
+	return this.handleDependentEntries(logger, object, status)
 }
 
 func (this *state) DeleteEntry(logger logger.LogContext, object *dnsutils.DNSEntryObject) reconcile.Status {
-	return this.HandleUpdateEntry(logger, "delete", object)
+	status := this.HandleUpdateEntry(logger, "delete", object)
+	return this.handleDependentEntries(logger, object, status)
+}
+
+func (this *state) handleDependentEntries(logger logger.LogContext, object *dnsutils.DNSEntryObject, status reconcile.Status) reconcile.Status {
+	this.dependents.update(object.ObjectName(), dependentOwner(object))
+	if !status.IsSucceeded() {
+		return status
+	}
+	this.notifyDependentOwner(object)
+	if err := this.syncAliasEntries(logger, object); err != nil {
+		return reconcile.Delay(logger, err)
+	}
+	if err := this.syncSplitEntries(logger, object); err != nil {
+		return reconcile.Delay(logger, err)
+	}
+	if err := this.syncMirrorEntry(logger, object); err != nil {
+		return reconcile.Delay(logger, err)
+	}
+	return status
 }
 
 func (this *state) smartInfof(logger logger.LogContext, format string, args ...interface{}) {
@@ -145,7 +179,7 @@ func (this *state) addEntryVersion(logger logger.LogContext, v *EntryVersion, st
 		return new, reconcile.DelayOnError(logger, err)
 	}
 
-	if new.valid && this.IsManaging(v) {
+	if new.valid && this.IsManaging(v) && !this.config.Dryrun {
 		err := this.SetFinalizer(new.Object())
 		if err != nil {
 			return new, reconcile.DelayOnError(logger, err)
@@ -239,6 +273,10 @@ func (this *state) entryPremise(e *dnsutils.DNSEntryObject) (*EntryPremise, erro
 }
 
 func (this *state) HandleUpdateEntry(logger logger.LogContext, op string, object *dnsutils.DNSEntryObject) reconcile.Status {
+	// entry reconciliations only update the status, the backend writes are done by the zone reconciliation
+	this.drainer.Begin(false)
+	defer this.drainer.End()
+
 	this.lock.Lock()
 	defer this.lock.Unlock()
 
@@ -251,7 +289,8 @@ func (this *state) HandleUpdateEntry(logger logger.LogContext, op string, object
 		defer old.lock.Unlock()
 	}
 
-	if object.GetAnnotations()[constants.GardenerOperation] == constants.GardenerOperationReconcile {
+	immediate := object.GetAnnotations()[constants.GardenerOperation] == constants.GardenerOperationReconcile
+	if immediate {
 		_, err := object.Modify(func(data resources.ObjectData) (bool, error) {
 			annotations := data.GetAnnotations()
 			delete(annotations, constants.GardenerOperation)
@@ -264,14 +303,19 @@ func (this *state) HandleUpdateEntry(logger logger.LogContext, op string, object
 
 	if ignored, annotation := ignoredByAnnotation(object); ignored {
 		var err error
+		var transition *stateTransition
 		if !object.IsDeleting() {
 			_, err = object.ModifyStatus(func(data resources.ObjectData) (bool, error) {
 				status := &data.(*api.DNSEntry).Status
+				transition = newStateTransition(object.ObjectName(), status)
 				mod := utils.ModificationState{}
 				mod.AssureStringValue(&status.State, api.STATE_IGNORED)
 				mod.AssureStringPtrPtr(&status.Message, ptr.To(fmt.Sprintf("entry is ignored as annotated with %s", annotation)))
 				return mod.IsModified(), nil
 			})
+			if err == nil {
+				transition.log()
+			}
 		} else {
 			err = this.RemoveFinalizer(object)
 		}
@@ -282,6 +326,10 @@ func (this *state) HandleUpdateEntry(logger logger.LogContext, op string, object
 	}
 
 	p, err := this.entryPremise(object)
+	if p.provider == nil && p.fallback == nil && this.zoneCacheReadiness.Wait(object.ClusterKey()) {
+		logger.Infof("no matching provider yet, waiting for initial zone cache")
+		return reconcile.RescheduleAfter(logger, zoneCacheReadinessRetryDelay)
+	}
 	if p.provider == nil && err == nil {
 		if p.zoneid != "" {
 			err = fmt.Errorf("no matching provider for zone '%s' found (no provider for this zone includes domain %s)", p.zoneid, object.GetDNSName())
@@ -293,6 +341,8 @@ func (this *state) HandleUpdateEntry(logger logger.LogContext, op string, object
 
 	logger = this.RefineLogger(logger, p.ptype)
 	v := NewEntryVersion(object, old)
+	v.statusThrottle = this.statusThrottle
+	v.eventThrottle = this.eventThrottle
 	if p.fallback != nil {
 		v.obsolete = true
 	}
@@ -300,7 +350,9 @@ func (this *state) HandleUpdateEntry(logger logger.LogContext, op string, object
 	new, status := this.addEntryVersion(logger, v, status)
 
 	if new != nil {
-		if new.IsModified() && !new.ZoneId().IsEmpty() {
+		if immediate && !new.ZoneId().IsEmpty() {
+			this.triggerHostedZoneImmediately(logger, new.ZoneId())
+		} else if new.IsModified() && !new.ZoneId().IsEmpty() {
 			this.smartInfof(logger, "trigger zone %q", new.ZoneId())
 			this.triggerHostedZone(new.ZoneId())
 		} else {
@@ -327,6 +379,9 @@ func (this *state) EntryDeleted(logger logger.LogContext, key resources.ClusterO
 	}()
 
 	delete(this.blockingEntries, key.ObjectName())
+	this.dependents.remove(key.ObjectName())
+	this.statusThrottle.Remove(key.ObjectName())
+	this.eventThrottle.Remove(key.ObjectName())
 
 	old := this.entries[key.ObjectName()]
 	if old != nil {
@@ -349,6 +404,7 @@ func (this *state) cleanupEntry(logger logger.LogContext, e *Entry) {
 	this.smartInfof(logger, "cleanup old entry (duplicate=%t)", e.duplicate)
 	this.entries.Delete(e)
 	this.DeleteLookupJob(e.ObjectName())
+	this.cancelPropagationVerification(e.ObjectName())
 	if this.dnsnames[e.ZonedDNSName()] == e {
 		var found *Entry
 		for _, a := range this.entries {
@@ -388,6 +444,47 @@ func (this *state) UpsertLookupJob(entryName resources.ObjectName, results looku
 	this.lookupProcessor.Upsert(entryName, results, interval)
 }
 
+// VerifyPropagation starts the verification that the records of the entry are resolvable at the
+// authoritative name servers of its zone. The ready state of the entry is not affected, the result
+// is reported in the status field `propagated`.
+func (this *state) VerifyPropagation(logger logger.LogContext, e *Entry) {
+	if this.propagationVerifier == nil || e.IsDeleting() {
+		return
+	}
+	exp := newPropagationExpectation(e.DNSName(), e.activezone, e.Targets(), e.RoutingPolicy())
+	if len(exp.records) == 0 {
+		return
+	}
+	if err := e.UpdatePropagated(logger, nil, ""); err != nil {
+		logger.Warnf("cannot reset propagation status: %s", err)
+	}
+	this.propagationVerifier.Start(this.context.GetContext(), e.ObjectName(), exp, func(propagated bool, err error) {
+		msg := ""
+		if err != nil {
+			msg = fmt.Sprintf("records not propagated to authoritative name servers within %s: %s", this.config.PropagationTimeout, err)
+		}
+		if err := e.UpdatePropagated(logger, &propagated, msg); err != nil {
+			logger.Warnf("cannot update propagation status: %s", err)
+		}
+	})
+}
+
+func (this *state) cancelPropagationVerification(entryName resources.ObjectName) {
+	if this.propagationVerifier != nil {
+		this.propagationVerifier.Cancel(entryName)
+	}
+}
+
+func (this *state) getZoneDomain(zoneid dns.ZoneID) (string, bool) {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	zone := this.zones[zoneid]
+	if zone == nil {
+		return "", false
+	}
+	return zone.Domain(), true
+}
+
 func ignoredByAnnotation(object *dnsutils.DNSEntryObject) (bool, string) {
 	if !object.IsDeleting() && object.GetAnnotations()[dns.AnnotationIgnore] == "true" {
 		return true, dns.AnnotationIgnore
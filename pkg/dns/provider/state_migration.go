@@ -0,0 +1,291 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+// migrationRecheckInterval is the interval for checking the progress of a provider migration.
+const migrationRecheckInterval = 30 * time.Second
+
+////////////////////////////////////////////////////////////////////////////////
+// provider migration
+//
+// A provider migration moves the DNS entries of a source provider to a target
+// provider without removing their records in between:
+//  1. Preparing: the zones of the source provider are read-only. The records of
+//     the entries of the source provider are created in the zones of the target
+//     provider. An entry is prepared if its records are found unchanged on a
+//     later reconciliation of the target zone.
+//  2. Switching: after all entries have been prepared, they are assigned to the
+//     target provider. Their records are already in place, so only the records
+//     in the zones of the source provider are deleted.
+//  3. Completed: no entries are assigned to the source provider anymore, it can
+//     be deleted.
+////////////////////////////////////////////////////////////////////////////////
+
+type providerMigration struct {
+	target resources.ObjectName
+	phase  string
+	// active is true if the target provider is valid. Only active migrations make the source provider read-only.
+	active bool
+	// prepared contains the entries whose records are in place in a zone of the target provider.
+	prepared resources.ObjectNameSet
+}
+
+// nextMigrationPhase returns the phase of a migration following the given phase.
+func nextMigrationPhase(phase string, active bool, pending, prepared int) string {
+	switch phase {
+	case api.MIGRATION_PHASE_PREPARING:
+		if active && prepared >= pending {
+			return api.MIGRATION_PHASE_SWITCHING
+		}
+	case api.MIGRATION_PHASE_SWITCHING:
+		if pending == 0 {
+			return api.MIGRATION_PHASE_COMPLETED
+		}
+	case api.MIGRATION_PHASE_COMPLETED:
+	default:
+		return api.MIGRATION_PHASE_PREPARING
+	}
+	return phase
+}
+
+// getProviderMigration returns a copy of the migration of the given provider or nil.
+func (this *state) getProviderMigration(name resources.ObjectName) *providerMigration {
+	this.mlock.Lock()
+	defer this.mlock.Unlock()
+	m := this.migrations[name]
+	if m == nil {
+		return nil
+	}
+	c := *m
+	return &c
+}
+
+// updateProviderMigration updates the migration of the given provider and returns its status.
+func (this *state) updateProviderMigration(logger logger.LogContext, p *dnsProviderVersion) *api.DNSProviderMigrationStatus {
+	name := p.ObjectName()
+	spec := p.object.Spec().Migration
+	if spec == nil {
+		this.removeProviderMigration(logger, name)
+		return nil
+	}
+
+	target := resources.NewObjectName(name.Namespace(), spec.TargetProvider)
+	var msg string
+	var targetProvider DNSProvider
+	if target == name {
+		msg = "target provider must not be the provider itself"
+	} else if targetProvider = this.GetProvider(target); targetProvider == nil || !targetProvider.IsValid() {
+		msg = fmt.Sprintf("target provider %s not available", target)
+	}
+	active := msg == ""
+
+	phase := ""
+	if status := p.object.Status().Migration; status != nil {
+		phase = status.Phase
+	}
+	this.mlock.Lock()
+	m := this.migrations[name]
+	if m == nil || m.target != target {
+		m = &providerMigration{target: target, phase: phase, prepared: resources.ObjectNameSet{}}
+		this.migrations[name] = m
+		logger.Infof("migration to provider %s", target)
+	}
+	started := active && !m.active
+	m.active = active
+	this.mlock.Unlock()
+
+	pending, prepared := this.countMigrationEntries(name)
+
+	this.mlock.Lock()
+	old := m.phase
+	m.phase = nextMigrationPhase(m.phase, active, pending, prepared)
+	phase = m.phase
+	this.mlock.Unlock()
+
+	if phase != old {
+		logger.Infof("migration to provider %s: phase %s", target, phase)
+	}
+	if started && phase == api.MIGRATION_PHASE_PREPARING {
+		this.triggerProviderZones(targetProvider)
+	}
+	if phase != old && phase == api.MIGRATION_PHASE_SWITCHING {
+		this.triggerProviderEntries(logger, name)
+	}
+	if phase != old && phase == api.MIGRATION_PHASE_COMPLETED {
+		this.triggerProviderZones(p)
+	}
+
+	if msg == "" {
+		switch phase {
+		case api.MIGRATION_PHASE_PREPARING:
+			msg = fmt.Sprintf("provider is read-only, records of %d of %d entries prepared in provider %s", prepared, pending, target)
+		case api.MIGRATION_PHASE_SWITCHING:
+			msg = fmt.Sprintf("switching %d entries to provider %s", pending, target)
+		default:
+			msg = fmt.Sprintf("all entries assigned to provider %s", target)
+		}
+	}
+	return &api.DNSProviderMigrationStatus{
+		Phase:           phase,
+		PendingEntries:  pending,
+		PreparedEntries: prepared,
+		Message:         &msg,
+	}
+}
+
+func (this *state) removeProviderMigration(logger logger.LogContext, name resources.ObjectName) {
+	this.mlock.Lock()
+	defer this.mlock.Unlock()
+	if m := this.migrations[name]; m != nil {
+		logger.Infof("migration to provider %s removed", m.target)
+		delete(this.migrations, name)
+	}
+}
+
+// countMigrationEntries returns the number of entries assigned to the given provider
+// and the number of those entries already prepared in the target provider.
+func (this *state) countMigrationEntries(name resources.ObjectName) (int, int) {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	this.mlock.Lock()
+	defer this.mlock.Unlock()
+
+	m := this.migrations[name]
+	pending, prepared := 0, 0
+	for n, e := range this.entries {
+		if e.ProviderName() == name {
+			pending++
+			if m != nil && m.prepared.Contains(n) {
+				prepared++
+			}
+		}
+	}
+	return pending, prepared
+}
+
+func (this *state) triggerProviderZones(p DNSProvider) {
+	for _, z := range p.GetZones() {
+		if p.IncludesZone(z.Id()) {
+			this.triggerHostedZone(z.Id())
+		}
+	}
+}
+
+// triggerProviderEntries triggers all entries assigned to the given provider.
+func (this *state) triggerProviderEntries(logger logger.LogContext, name resources.ObjectName) {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	for _, e := range this.entries {
+		if e.ProviderName() == name {
+			this.TriggerEntry(logger, e)
+		}
+	}
+}
+
+// isMigratedAwayFrom returns true if the entry must not be assigned to the given provider because of an active
+// migration to a target provider matching the entry. Only entries already assigned to a provider keep it
+// until the migration switches to the target provider.
+// It must be called with the state lock held.
+func (this *state) isMigratedAwayFrom(p *dnsProviderVersion, e *dnsutils.DNSEntryObject) bool {
+	m := this.getProviderMigration(p.ObjectName())
+	if m == nil || !m.active {
+		return false
+	}
+	target := this.providers[m.target]
+	if target == nil || !target.IsValid() || target.Match(e.GetDNSName()) == 0 {
+		return false
+	}
+	return m.phase != api.MIGRATION_PHASE_PREPARING || utils.StringValue(e.Status().Provider) != p.ObjectName().String()
+}
+
+// getZoneReadOnlyBy returns the name of the source provider and the target provider of the migration making the
+// zone read-only. A zone is read-only if it is only served by providers in migration phase Preparing.
+func (this *state) getZoneReadOnlyBy(providers DNSProviders) (resources.ObjectName, resources.ObjectName) {
+	var source, target resources.ObjectName
+	for n := range providers {
+		m := this.getProviderMigration(n)
+		if m == nil || !m.active || m.phase != api.MIGRATION_PHASE_PREPARING {
+			return nil, nil
+		}
+		source, target = n, m.target
+	}
+	return source, target
+}
+
+// getMigratingEntriesForZone returns the entries of providers in migration to a target provider serving the zone.
+// It must be called with the state lock held.
+func (this *state) getMigratingEntriesForZone(zone *dnsHostedZone, entries Entries) Entries {
+	this.mlock.Lock()
+	defer this.mlock.Unlock()
+
+	var result Entries
+	for source, m := range this.migrations {
+		if !m.active || m.phase == api.MIGRATION_PHASE_COMPLETED {
+			continue
+		}
+		target := this.providers[m.target]
+		if target == nil || !target.IncludesZone(zone.Id()) {
+			continue
+		}
+		for n, e := range this.entries {
+			if e.ProviderName() != source || !e.IsValid() || e.IsDeleting() || zone.Match(e.DNSName()) == 0 {
+				continue
+			}
+			if entries[n] != nil {
+				// the records of the entry are already managed in this zone
+				m.prepared.Add(n)
+				continue
+			}
+			if result == nil {
+				result = Entries{}
+			}
+			result[n] = e
+		}
+	}
+	return result
+}
+
+// prepareMigratingEntries creates the records of the migrating entries in the zone of the target provider.
+// Entries whose records are already in place are marked as prepared.
+func (this *state) prepareMigratingEntries(logger logger.LogContext, req *zoneReconciliation, changes *ChangeModel, normalized map[dns.DNSSetName]*dns.RoutingPolicy) {
+	for _, e := range req.migrating {
+		if changes.IsApplied(e.DNSSetName()) {
+			logger.Warnf("migrating entry %q (%s) conflicts with entry of zone %s", e.ObjectName(), e.DNSName(), req.zone.Id())
+			continue
+		}
+		result := changes.Apply(e.DNSSetName(), e.ObjectName().Namespace(), e.CreatedAt(), nil, withNormalizedWeight(e.object.GetTargetSpec(e), e.DNSSetName(), normalized))
+		switch {
+		case result.Error != nil:
+			logger.Warnf("preparing migrating entry %q (%s) failed: %s", e.ObjectName(), e.DNSName(), result.Error)
+		case result.Modified:
+			// verify the records on the next reconciliation
+			if req.zone.nextTrigger == 0 {
+				req.zone.nextTrigger = this.config.Delay
+			}
+		default:
+			this.setMigratingEntryPrepared(e)
+		}
+	}
+}
+
+func (this *state) setMigratingEntryPrepared(e *Entry) {
+	this.mlock.Lock()
+	defer this.mlock.Unlock()
+	if m := this.migrations[e.ProviderName()]; m != nil {
+		m.prepared.Add(e.ObjectName())
+	}
+}
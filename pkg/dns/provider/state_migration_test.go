@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+)
+
+var _ = ginkgov2.Describe("Provider migration", func() {
+	ginkgov2.DescribeTable("calculates the next phase",
+		func(phase string, active bool, pending, prepared int, expected string) {
+			Expect(nextMigrationPhase(phase, active, pending, prepared)).To(Equal(expected))
+		},
+		ginkgov2.Entry("starts with preparing", "", false, 2, 0, api.MIGRATION_PHASE_PREPARING),
+		ginkgov2.Entry("keeps preparing with unprepared entries", api.MIGRATION_PHASE_PREPARING, true, 2, 1, api.MIGRATION_PHASE_PREPARING),
+		ginkgov2.Entry("keeps preparing without target provider", api.MIGRATION_PHASE_PREPARING, false, 2, 2, api.MIGRATION_PHASE_PREPARING),
+		ginkgov2.Entry("switches if all entries are prepared", api.MIGRATION_PHASE_PREPARING, true, 2, 2, api.MIGRATION_PHASE_SWITCHING),
+		ginkgov2.Entry("keeps switching with pending entries", api.MIGRATION_PHASE_SWITCHING, true, 1, 0, api.MIGRATION_PHASE_SWITCHING),
+		ginkgov2.Entry("completes without pending entries", api.MIGRATION_PHASE_SWITCHING, true, 0, 0, api.MIGRATION_PHASE_COMPLETED),
+		ginkgov2.Entry("stays completed", api.MIGRATION_PHASE_COMPLETED, true, 1, 0, api.MIGRATION_PHASE_COMPLETED),
+	)
+
+	ginkgov2.Describe("read-only zones", func() {
+		var (
+			s      *state
+			source resources.ObjectName
+			target resources.ObjectName
+			other  resources.ObjectName
+		)
+
+		ginkgov2.BeforeEach(func() {
+			source = resources.NewObjectName("default", "old")
+			target = resources.NewObjectName("default", "new")
+			other = resources.NewObjectName("default", "other")
+			s = &state{migrations: map[resources.ObjectName]*providerMigration{
+				source: {target: target, phase: api.MIGRATION_PHASE_PREPARING, active: true, prepared: resources.ObjectNameSet{}},
+			}}
+		})
+
+		ginkgov2.It("are served by preparing source providers only", func() {
+			name, targetName := s.getZoneReadOnlyBy(DNSProviders{source: nil})
+			Expect(name).To(Equal(source))
+			Expect(targetName).To(Equal(target))
+		})
+
+		ginkgov2.It("are writable if served by another provider", func() {
+			name, _ := s.getZoneReadOnlyBy(DNSProviders{source: nil, other: nil})
+			Expect(name).To(BeNil())
+		})
+
+		ginkgov2.It("are writable if the migration is inactive", func() {
+			s.migrations[source].active = false
+			name, _ := s.getZoneReadOnlyBy(DNSProviders{source: nil})
+			Expect(name).To(BeNil())
+		})
+
+		ginkgov2.It("are writable after switching", func() {
+			s.migrations[source].phase = api.MIGRATION_PHASE_SWITCHING
+			name, _ := s.getZoneReadOnlyBy(DNSProviders{source: nil})
+			Expect(name).To(BeNil())
+		})
+	})
+})
@@ -97,6 +97,7 @@ func (this *state) UpdateOwnerCounts(log logger.LogContext) {
 	this.UpdateStatistic(statistic)
 	types := this.GetHandlerFactory().TypeCodes()
 	metrics.UpdateOwnerStatistic(statistic, types)
+	metrics.UpdateEntryStateStatistic(statistic.States)
 	changes := this.ownerCache.UpdateCountsWith(statistic.Owners, types)
 	if len(changes) > 0 {
 		log.Infof("found %d changes for owner usages", len(changes))
@@ -15,7 +15,6 @@ import (
 	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
 	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/client-go/util/flowcontrol"
 )
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -65,9 +64,13 @@ func (this *state) _UpdateLocalProvider(logger logger.LogContext, obj *dnsutils.
 	this.lock.Lock()
 	defer this.lock.Unlock()
 	regmod, regerr := this.registerSecret(logger, new.secret, new)
+	this.credentialsFiles.Set(new.ObjectName(), new.credentialsFile)
 
 	this.providers[new.ObjectName()] = new
 	mod := this.updateZones(logger, last, new)
+	if status.IsSucceeded() {
+		this.providerZonesInitialized(logger, new.ObjectName())
+	}
 	if !status.IsSucceeded() {
 		this.informProviderRemoved(logger, new.ObjectName())
 		logger.Infof("errorneous provider: %s", status.Error)
@@ -147,15 +150,13 @@ func (this *state) updateProviderRateLimiter(logger logger.LogContext, obj *dnsu
 	defer this.prlock.Unlock()
 
 	rateLimit := obj.Spec().RateLimit
+	if rateLimit == nil {
+		rateLimit = this.config.ProviderTypeDefaults.Get(obj.TypeCode()).RateLimit
+	}
 	if rateLimit != nil {
 		data, ok := this.providerRateLimiter[obj.ObjectName()]
-		if !ok || data.RateLimit.RequestsPerDay != rateLimit.RequestsPerDay || data.RateLimit.Burst != rateLimit.Burst {
-			qps := float32(rateLimit.RequestsPerDay) / 86400
-			data = &rateLimiterData{
-				RateLimit:   *rateLimit,
-				rateLimiter: flowcontrol.NewTokenBucketRateLimiter(qps, rateLimit.Burst),
-			}
-			this.providerRateLimiter[obj.ObjectName()] = data
+		if !ok || !data.matches(rateLimit) {
+			this.providerRateLimiter[obj.ObjectName()] = newRateLimiterData(*rateLimit)
 			logger.Infof("frontend rate limiter updated: requestsPerDay=%d, burst=%d", rateLimit.RequestsPerDay, rateLimit.Burst)
 		}
 	} else {
@@ -212,11 +213,13 @@ func (this *state) ProviderDeleted(logger logger.LogContext, key resources.Objec
 	defer this.lock.Unlock()
 
 	this.informProviderRemoved(logger, key.ObjectName())
+	this.providerZonesInitialized(logger, key.ObjectName())
 	return this.removeForeignProvider(logger, key.ObjectName())
 }
 
 func (this *state) RemoveProvider(logger logger.LogContext, obj *dnsutils.DNSProviderObject) reconcile.Status {
 	this.informProviderRemoved(logger, obj.ObjectName())
+	this.providerZonesInitialized(logger, obj.ObjectName())
 
 	pname := obj.ObjectName()
 
@@ -240,12 +243,16 @@ func (this *state) removeLocalProvider(logger logger.LogContext, obj *dnsutils.D
 		cur = this.deleting[pname]
 	}
 	if cur != nil {
+		force := obj.GetAnnotations()[AnnotationForceCleanup] == "true"
 		zones := this.providerzones[obj.ObjectName()]
 		logger.Infof("deleting PROVIDER with %d zones", len(zones))
 		for zoneid, z := range zones {
 			if this.isProviderForZone(zoneid, pname) {
 				providers := this.getProvidersForZone(zoneid)
-				if len(providers) == 1 {
+				if len(providers) == 1 && force {
+					logger.Infof("provider is exclusively handling zone %q -> force cleanup without deleting records", zoneid)
+					this.deleteZone(zoneid)
+				} else if len(providers) == 1 {
 					// if this is the last provider for this zone
 					// it must be cleaned up before the provider is gone
 					logger.Infof("provider is exclusively handling zone %q -> cleanup", zoneid)
@@ -267,7 +274,7 @@ func (this *state) removeLocalProvider(logger logger.LogContext, obj *dnsutils.D
 						return reconcile.Delay(logger, fmt.Errorf("zone reconcilation busy -> delay deletion"))
 					}
 					if err != nil {
-						if _, ok := err.(*perrs.NoSuchHostedZone); !ok {
+						if !perrs.IsNoSuchHostedZone(err) {
 							logger.Errorf("zone cleanup failed: %s", err)
 							return reconcile.Delay(logger, fmt.Errorf("zone reconcilation failed -> delay deletion"))
 						}
@@ -284,6 +291,11 @@ func (this *state) removeLocalProvider(logger logger.LogContext, obj *dnsutils.D
 				logger.Infof("not reponsible for zone %q", zoneid)
 			}
 		}
+		if force {
+			if err := this.releaseProviderEntries(logger, pname); err != nil {
+				return reconcile.Delay(logger, fmt.Errorf("releasing entries failed: %w", err))
+			}
+		}
 		logger.Infof("zone cleanup done -> trigger entries")
 		for _, e := range this.entries {
 			if e.providername == pname {
@@ -295,6 +307,9 @@ func (this *state) removeLocalProvider(logger logger.LogContext, obj *dnsutils.D
 		if err != nil {
 			return reconcile.Delay(logger, err)
 		}
+		this.credentialsFiles.Set(cur.ObjectName(), nil)
+		this.removeProviderMigration(logger, cur.ObjectName())
+		this.removeProviderMirror(cur.ObjectName())
 		logger.Infof("releasing account cache")
 		this.accountCache.Release(logger, cur.account, cur.ObjectName())
 		delete(this.deleting, obj.ObjectName())
@@ -304,3 +319,28 @@ func (this *state) removeLocalProvider(logger logger.LogContext, obj *dnsutils.D
 	}
 	return reconcile.Succeeded(logger)
 }
+
+// releaseProviderEntries releases the entries assigned to a force-removed provider without deleting their records.
+// Their finalizers are removed and they are set to state Error.
+// Entries in zones still served by another provider are kept, as they are taken over by this provider.
+// It must be called after the force-removed provider has been removed from its zones.
+func (this *state) releaseProviderEntries(logger logger.LogContext, pname resources.ObjectName) error {
+	msg := fmt.Sprintf("provider %s removed with annotation %s: records have not been deleted", pname, AnnotationForceCleanup)
+	for _, e := range this.entries {
+		if e.providername != pname {
+			continue
+		}
+		if providers := this.getProvidersForZone(e.activezone); len(providers) > 0 {
+			logger.Infof("force cleanup: keeping entry %s, zone %s is still served by %d other provider(s)", e.ObjectName(), e.activezone, len(providers))
+			continue
+		}
+		logger.Infof("force cleanup: releasing entry %s", e.ObjectName())
+		if _, err := e.UpdateStatus(logger, api.STATE_ERROR, msg); err != nil {
+			return err
+		}
+		if err := this.RemoveFinalizer(e.Object()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+var _ = ginkgov2.Describe("Force cleanup of providers", func() {
+	var (
+		s        *state
+		shared   *dnsHostedZone
+		only     *dnsHostedZone
+		removed  *dnsProviderVersion
+		other    *dnsProviderVersion
+		kept     *Entry
+		released *Entry
+	)
+
+	ginkgov2.BeforeEach(func() {
+		shared = newDNSHostedZone(0, NewDNSHostedZone("test", "z1", "example.com", "", false))
+		only = newDNSHostedZone(0, NewDNSHostedZone("test", "z2", "example.org", "", false))
+		removed = newTestProvider("p1", shared.getZone(), nil)
+		other = newTestProvider("p2", shared.getZone(), nil)
+		s = &state{
+			providers: map[resources.ObjectName]*dnsProviderVersion{other.ObjectName(): other},
+			deleting:  map[resources.ObjectName]*dnsProviderVersion{removed.ObjectName(): removed},
+			// the force-removed provider has already been removed from its zones
+			zoneproviders: map[dns.ZoneID]resources.ObjectNameSet{
+				shared.Id(): resources.NewObjectNameSet(other.ObjectName()),
+				only.Id():   resources.NewObjectNameSet(),
+			},
+			entries: Entries{},
+		}
+		kept = newTestEntry(s, "e1", "a.example.com", api.STATE_READY, shared.Id(), "1.1.1.1")
+		released = newTestEntry(s, "e2", "a.example.org", api.STATE_READY, only.Id(), "1.1.1.1")
+		for _, e := range []*Entry{kept, released} {
+			e.providername = removed.ObjectName()
+			s.entries[e.ObjectName()] = e
+		}
+	})
+
+	ginkgov2.It("releases only entries in zones without other provider", func() {
+		Expect(s.releaseProviderEntries(logger.New(), removed.ObjectName())).To(Succeed())
+		Expect(released.Object().Status().State).To(Equal(api.STATE_ERROR))
+		Expect(kept.Object().Status().State).To(Equal(api.STATE_READY))
+		Expect(testStatusModifications(kept)).To(BeZero())
+	})
+})
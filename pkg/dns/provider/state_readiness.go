@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+)
+
+// zoneCacheReadinessRetryDelay is the delay for reconciling an entry again while waiting for the initial zone cache.
+const zoneCacheReadinessRetryDelay = 15 * time.Second
+
+////////////////////////////////////////////////////////////////////////////////
+// readiness of the initial zone cache
+////////////////////////////////////////////////////////////////////////////////
+
+// zoneCacheReadiness tracks the providers which have not yet completed their first
+// zone listing after startup, and the entries waiting for it.
+type zoneCacheReadiness struct {
+	lock    sync.Mutex
+	ready   bool
+	pending resources.ObjectNameSet
+	waiting resources.ClusterObjectKeySet
+}
+
+func newZoneCacheReadiness(enabled bool) *zoneCacheReadiness {
+	return &zoneCacheReadiness{
+		ready:   !enabled,
+		pending: resources.NewObjectNameSet(),
+		waiting: resources.NewClusterObjectKeySet(),
+	}
+}
+
+// IsReady returns true if all providers known at startup have completed their first zone listing.
+func (this *zoneCacheReadiness) IsReady() bool {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return this.ready
+}
+
+// AddPending adds a provider to wait for.
+func (this *zoneCacheReadiness) AddPending(name resources.ObjectName) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if !this.ready {
+		this.pending.Add(name)
+	}
+}
+
+// Done removes a provider to wait for. If no provider is pending anymore, readiness is reached
+// and the waiting entries are returned.
+func (this *zoneCacheReadiness) Done(name resources.ObjectName) resources.ClusterObjectKeySet {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if this.ready {
+		return nil
+	}
+	this.pending.Remove(name)
+	return this.check()
+}
+
+// Check returns the waiting entries if no provider is pending anymore.
+func (this *zoneCacheReadiness) Check() resources.ClusterObjectKeySet {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if this.ready {
+		return nil
+	}
+	return this.check()
+}
+
+func (this *zoneCacheReadiness) check() resources.ClusterObjectKeySet {
+	if len(this.pending) > 0 {
+		return nil
+	}
+	return this.release()
+}
+
+// Release enforces readiness and returns the waiting entries.
+func (this *zoneCacheReadiness) Release() resources.ClusterObjectKeySet {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if this.ready {
+		return nil
+	}
+	return this.release()
+}
+
+func (this *zoneCacheReadiness) release() resources.ClusterObjectKeySet {
+	waiting := this.waiting
+	this.ready = true
+	this.pending = nil
+	this.waiting = nil
+	return waiting
+}
+
+// Wait records an entry to be triggered on readiness. It returns false if readiness is already reached.
+func (this *zoneCacheReadiness) Wait(key resources.ClusterObjectKey) bool {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if this.ready {
+		return false
+	}
+	this.waiting.Add(key)
+	return true
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// startZoneCacheReadiness starts waiting for the first zone listing of all responsible providers.
+// Readiness is enforced after the initial zone cache timeout at the latest.
+func (this *state) startZoneCacheReadiness() {
+	if this.zoneCacheReadiness.IsReady() {
+		return
+	}
+	timeout := this.config.InitialZoneCacheTimeout
+	time.AfterFunc(timeout, func() {
+		if waiting := this.zoneCacheReadiness.Release(); waiting != nil {
+			this.context.Warnf("initial zone cache not completed within %s, continuing without", timeout)
+			this.triggerWaitingEntries(waiting)
+		}
+	})
+}
+
+// checkZoneCacheReadiness reaches readiness if no provider is pending.
+func (this *state) checkZoneCacheReadiness(logger logger.LogContext) {
+	if waiting := this.zoneCacheReadiness.Check(); waiting != nil {
+		logger.Infof("initial zone cache completed, triggering %d waiting entries", len(waiting))
+		this.triggerWaitingEntries(waiting)
+	}
+}
+
+// providerZonesInitialized marks the first zone listing of a provider as completed.
+func (this *state) providerZonesInitialized(logger logger.LogContext, name resources.ObjectName) {
+	if waiting := this.zoneCacheReadiness.Done(name); waiting != nil {
+		logger.Infof("initial zone cache completed, triggering %d waiting entries", len(waiting))
+		this.triggerWaitingEntries(waiting)
+	}
+}
+
+func (this *state) triggerWaitingEntries(waiting resources.ClusterObjectKeySet) {
+	for key := range waiting {
+		this.triggerKey(key)
+	}
+}
@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = ginkgov2.Describe("Zone cache readiness", func() {
+	p1 := resources.NewObjectName("ns", "p1")
+	p2 := resources.NewObjectName("ns", "p2")
+	entry := resources.NewClusterKey("c", entryGroupKind, "ns", "entry")
+
+	ginkgov2.It("is ready if disabled", func() {
+		r := newZoneCacheReadiness(false)
+		Expect(r.IsReady()).To(BeTrue())
+		Expect(r.Wait(entry)).To(BeFalse())
+	})
+
+	ginkgov2.It("is ready if no provider is pending", func() {
+		r := newZoneCacheReadiness(true)
+		Expect(r.IsReady()).To(BeFalse())
+		Expect(r.Check()).To(BeEmpty())
+		Expect(r.IsReady()).To(BeTrue())
+	})
+
+	ginkgov2.It("waits for all pending providers", func() {
+		r := newZoneCacheReadiness(true)
+		r.AddPending(p1)
+		r.AddPending(p2)
+		Expect(r.Check()).To(BeNil())
+		Expect(r.Wait(entry)).To(BeTrue())
+		Expect(r.Done(p1)).To(BeNil())
+		Expect(r.IsReady()).To(BeFalse())
+		Expect(r.Done(p2)).To(Equal(resources.NewClusterObjectKeySet(entry)))
+		Expect(r.IsReady()).To(BeTrue())
+		Expect(r.Wait(entry)).To(BeFalse())
+		Expect(r.Done(p1)).To(BeNil())
+	})
+
+	ginkgov2.It("releases waiting entries on timeout", func() {
+		r := newZoneCacheReadiness(true)
+		r.AddPending(p1)
+		Expect(r.Wait(entry)).To(BeTrue())
+		Expect(r.Release()).To(Equal(resources.NewClusterObjectKeySet(entry)))
+		Expect(r.IsReady()).To(BeTrue())
+		Expect(r.Release()).To(BeNil())
+	})
+})
@@ -6,6 +6,7 @@ package provider
 
 import (
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
@@ -13,9 +14,11 @@ import (
 	"github.com/gardener/controller-manager-library/pkg/ctxutil"
 	"github.com/gardener/controller-manager-library/pkg/logger"
 	"github.com/gardener/controller-manager-library/pkg/utils"
+	"github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
 	"github.com/gardener/external-dns-management/pkg/dns"
 	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
 	"github.com/gardener/external-dns-management/pkg/server/metrics"
@@ -36,6 +39,18 @@ func (this *state) TriggerHostedZonesByChangedOwners(logger logger.LogContext, c
 	}
 }
 
+// triggerHostedZoneImmediately triggers the zone bypassing the delay between two reconciliations if accepted by the zone.
+func (this *state) triggerHostedZoneImmediately(logger logger.LogContext, zoneid dns.ZoneID) {
+	if zone := this.zones[zoneid]; zone != nil {
+		if zone.AcceptImmediateReconciliation(this.config.Delay) {
+			logger.Infof("trigger zone %q immediately as requested by annotation %s", zoneid, constants.GardenerOperation)
+		} else {
+			logger.Infof("trigger zone %q (immediate reconciliation already requested recently or zone throttled)", zoneid)
+		}
+	}
+	this.triggerHostedZone(zoneid)
+}
+
 func (this *state) GetZoneReconcilation(logger logger.LogContext, zoneid dns.ZoneID) (time.Duration, bool, *zoneReconciliation) {
 	req := &zoneReconciliation{
 		fhandler: this.context,
@@ -58,6 +73,7 @@ func (this *state) GetZoneReconcilation(logger logger.LogContext, zoneid dns.Zon
 	}
 	req.entries, req.equivEntries, req.stale, req.deleting = this.addEntriesForZone(logger, nil, nil, zone)
 	req.providers = this.getProvidersForZone(zoneid)
+	req.migrating = this.getMigratingEntriesForZone(zone, req.entries)
 	req.dnsTicker = this.dnsTicker
 	return 0, hasProviders, req
 }
@@ -104,13 +120,32 @@ func (this *state) ReconcileZone(logger logger.LogContext, zoneid dns.ZoneID) re
 	logger.Infof("precondition fulfilled for zone %s", zoneid)
 	if done, err := this.StartZoneReconcilation(logger, req); done {
 		if err != nil {
-			if _, ok := err.(*perrs.NoSuchHostedZone); ok {
+			if perrs.IsNoSuchHostedZone(err) {
 				for _, provider := range req.providers {
 					// trigger provider reconciliation to update its status
 					_ = this.context.Enqueue(provider.Object())
 				}
 				return reconcile.Succeeded(logger)
 			}
+			if perrs.IsThrottlingError(err) {
+				delay, attempts := req.zone.Throttled()
+				logger.Infof("zone reconcilation for %s throttled by provider API (attempt %d): backoff delay %s", req.zone.Id(), attempts, delay)
+				return reconcile.Succeeded(logger).RescheduleAfter(delay)
+			}
+			if cerr, ok := perrs.IsCircuitOpen(err); ok {
+				logger.Infof("zone reconcilation for %s skipped: %s", req.zone.Id(), err)
+				for _, provider := range req.providers {
+					// trigger provider reconciliation to report the open circuit breaker in its status
+					_ = this.context.Enqueue(provider.Object())
+				}
+				// the remaining cool-down may be shorter than the regular retry delay of the zone
+				return reconcile.Succeeded(logger).RescheduleAfter(max(cerr.RetryAfter, req.zone.RateLimit()))
+			}
+			if perrs.IsConditionalUpdateConflictError(err) {
+				// the zone state has already been discarded, the changes are planned again on the fresh state
+				logger.Infof("record sets of zone %s changed concurrently, replanning in %s", req.zone.Id(), conflictRetryDelay)
+				return reconcile.Succeeded(logger).RescheduleAfter(conflictRetryDelay)
+			}
 			logger.Infof("zone reconcilation failed for %s: %s", req.zone.Id(), err)
 			return reconcile.Succeeded(logger).RescheduleAfter(req.zone.RateLimit())
 		}
@@ -127,6 +162,11 @@ func (this *state) StartZoneReconcilation(logger logger.LogContext, req *zoneRec
 	if req.deleting {
 		ctxutil.Tick(this.GetContext().GetContext(), controller.DeletionActivity)
 	}
+	if !this.drainer.Begin(true) {
+		logger.Infof("controller is shutting down, reconcilation of zone %s skipped", req.zone.Id())
+		return true, nil
+	}
+	defer this.drainer.End()
 	if req.zone.TestAndSetBusy() {
 		defer req.zone.Release()
 
@@ -158,37 +198,71 @@ func (this *state) StartZoneReconcilation(logger logger.LogContext, req *zoneRec
 
 func (this *state) reconcileZone(logger logger.LogContext, req *zoneReconciliation) error {
 	zoneid := req.zone.Id()
+	if policyName := this.getZonePausedBy(zoneid); policyName != "" {
+		this.pauseZoneEntries(logger, req, "paused by DNSHostedZonePolicy "+policyName)
+		return nil
+	}
+	if source, target := this.getZoneReadOnlyBy(req.providers); source != nil {
+		this.pauseZoneEntries(logger, req, fmt.Sprintf("read-only during the migration of provider %s to provider %s", source, target))
+		return nil
+	}
 	req.zone.SetNext(time.Now().Add(this.config.Delay))
 	metrics.ReportZoneEntries(zoneid, len(req.entries), len(req.stale))
 	logger.Infof("reconcile ZONE %s (%s) for %d dns entries (%d stale)", req.zone.Id(), req.zone.Domain(), len(req.entries), len(req.stale))
 	logger.Debugf("    ownerids: %s", req.ownership.GetIds())
+	req.recordSetLimit = this.getZoneRecordSetLimit(zoneid)
 	changes := NewChangeModel(logger, req.ownership, req, this.config)
 	err := changes.Setup()
 	if err != nil {
+		if perrs.IsNoSuchHostedZone(err) {
+			this.releaseOutdatedEntries(logger, zoneid, nil)
+		}
 		req.zone.Failed()
 		return err
 	}
+	if this.setZoneRecordSets(zoneid, changes.RecordSets()) {
+		for _, provider := range req.providers {
+			// trigger provider reconciliation to update the record set count in its status
+			_ = this.context.Enqueue(provider.Object())
+		}
+	}
 	req.zone.nextTrigger = 0
 	modified := false
 	var conflictErr error
-	for _, e := range req.entries {
+	// percentage weights are normalized over all sibling entries of the zone on every reconciliation
+	normalized := changes.normalizedWeights(req.entries, req.migrating)
+	invalidFailover := invalidFailoverGroups(req.entries, req.migrating)
+	for _, e := range prioritizedEntries(req.entries) {
 		// TODO: err handling
 		var changeResult ChangeResult
-		spec := e.object.GetTargetSpec(e)
+		spec := withNormalizedWeight(e.object.GetTargetSpec(e), e.DNSSetName(), normalized)
 		statusUpdate := NewStatusUpdate(logger, e, this.GetContext())
-		if e.IsDeleting() {
+		if e.IsDeleting() && e.object.PreserveOnDelete() {
+			logger.Infof("preserving records of deleted entry %s", e.ObjectName())
+			if _, err := e.UpdateState(logger, e.State(), MSG_PRESERVED_ON_DELETE); err != nil {
+				logger.Errorf("cannot update: %s", err)
+			}
+			e.eventf(corev1.EventTypeNormal, EventReasonPreserved, "%s for %s", MSG_PRESERVED_ON_DELETE, e.ZonedDNSName())
+			changeResult = changes.Release(e.DNSSetName(), statusUpdate)
+		} else if e.IsDeleting() {
 			changeResult = changes.Delete(e.DNSSetName(), e.ObjectName().Namespace(), e.CreatedAt(), statusUpdate, spec)
+		} else if err := invalidFailover[e.DNSSetName()]; err != nil {
+			// keep existing record sets of the failover group until it is consistent again
+			logger.Infof("invalid failover group of %s: %s", e.ObjectName(), err)
+			changes.PseudoApply(e.DNSSetName(), spec)
+			statusUpdate.Failed(err)
+			continue
 		} else {
 			if !e.NotRateLimited() {
 				changeResult = changes.Check(e.DNSSetName(), e.ObjectName().Namespace(), e.CreatedAt(), statusUpdate, spec)
 				if changeResult.Modified {
-					if accepted, delay := this.tryAcceptProviderRateLimiter(logger, e); !accepted {
+					if accepted, delay := this.tryAcceptProviderRateLimiter(logger, req.zone, e); !accepted {
 						req.zone.nextTrigger = delay
 						changes.PseudoApply(e.DNSSetName(), spec)
 						logger.Infof("rate limited %s, delay %.1f s", e.ObjectName(), delay.Seconds())
 						statusUpdate.Throttled()
 						if delay.Seconds() > 2 {
-							e.object.Eventf(corev1.EventTypeNormal, "rate limit", "delayed for %1.fs", delay.Seconds())
+							e.eventf(corev1.EventTypeNormal, EventReasonRateLimited, "delayed for %1.fs", delay.Seconds())
 						}
 						continue
 					}
@@ -201,46 +275,129 @@ func (this *state) reconcileZone(logger logger.LogContext, req *zoneReconciliati
 		}
 		modified = modified || changeResult.Modified
 	}
+	if this.config.OwnerConflict != nil {
+		// the leases on the written records must be renewed in time
+		if renew := this.config.OwnerConflict.RenewInterval(); req.zone.nextTrigger == 0 || req.zone.nextTrigger > renew {
+			req.zone.nextTrigger = renew
+		}
+	}
+	this.prepareMigratingEntries(logger, req, changes, normalized)
 	modified = changes.Cleanup(logger) || modified
 	if modified {
 		err = changes.Update(logger)
 	}
 
+	if perrs.IsNoSuchHostedZone(err) {
+		this.releaseOutdatedEntries(logger, zoneid, nil)
+	} else {
+		this.releaseOutdatedEntries(logger, zoneid, changes)
+	}
+	if err == nil {
+		req.zone.Succeeded()
+		req.zone.ResetThrottling()
+		err = conflictErr
+	} else {
+		req.zone.Failed()
+	}
+	return err
+}
+
+// releaseOutdatedEntries removes the finalizers of deleted entries of the zone, if their records have been deleted
+// successfully by the given change model. If no change model is given, the hosted zone does not exist anymore in
+// the DNS backend, so there are no records left to be deleted.
+func (this *state) releaseOutdatedEntries(logger logger.LogContext, zoneid dns.ZoneID, changes *ChangeModel) {
 	outdatedEntries := EntryList{}
 	this.outdated.AddActiveZoneTo(zoneid, &outdatedEntries)
 	for _, e := range outdatedEntries {
-		if changes.IsFailed(e.DNSSetName()) {
+		if changes == nil {
+			logger.Infof("hosted zone %s does not exist anymore -> cleanup outdated entry %q", zoneid, e.ObjectName())
+		} else if changes.IsFailed(e.DNSSetName()) {
 			continue
+		} else {
+			logger.Infof("cleanup outdated entry %q", e.ObjectName())
 		}
-		logger.Infof("cleanup outdated entry %q", e.ObjectName())
 		err := e.RemoveFinalizer()
 		if err == nil || errors.IsNotFound(err) {
 			this.outdated.Delete(e)
 		}
 	}
-	if err == nil {
-		req.zone.Succeeded()
-		err = conflictErr
-	} else {
-		req.zone.Failed()
+}
+
+// pauseZoneEntries keeps the entries of a paused zone in their current state and only sets the paused status message.
+// Entries already showing the paused status message are not updated again.
+func (this *state) pauseZoneEntries(logger logger.LogContext, req *zoneReconciliation, reason string) {
+	logger.Infof("reconciliation of zone %s (%s) is %s", req.zone.Id(), req.zone.Domain(), reason)
+	msg := fmt.Sprintf("%s: reconciliation of zone %s is %s", MSG_PAUSED, req.zone.Id().ID, reason)
+	for _, e := range req.entries {
+		state := e.State()
+		if state == "" {
+			state = api.STATE_PENDING
+		}
+		if state == e.State() && e.Message() == msg {
+			continue
+		}
+		if _, err := e.UpdateState(logger, state, msg); err != nil {
+			logger.Errorf("cannot update: %s", err)
+		}
 	}
-	return err
+}
+
+// setZoneRecordSets stores the number of record sets of the given zone and returns true if it has changed.
+func (this *state) setZoneRecordSets(zoneid dns.ZoneID, count int) bool {
+	old, loaded := this.zoneRecordSets.Swap(zoneid, count)
+	return !loaded || old.(int) != count
+}
+
+// getZoneRecordSetCounts returns the record set counts of the given zones known from their last reconciliation.
+func (this *state) getZoneRecordSetCounts(zones DNSHostedZones) []api.ZoneRecordSetCount {
+	var counts []api.ZoneRecordSetCount
+	for _, zone := range zones {
+		value, ok := this.zoneRecordSets.Load(zone.Id())
+		if !ok {
+			continue
+		}
+		count := api.ZoneRecordSetCount{ZoneID: zone.Id().ID, Count: value.(int)}
+		if limit := this.getZoneRecordSetLimit(zone.Id()); limit > 0 {
+			count.Limit = &limit
+		}
+		counts = append(counts, count)
+	}
+	sort.Slice(counts, func(i, j int) bool { return counts[i].ZoneID < counts[j].ZoneID })
+	return counts
 }
 
 func (this *state) deleteZone(zoneid dns.ZoneID) {
 	metrics.DeleteZone(zoneid)
+	this.zoneRecordSets.Delete(zoneid)
 	delete(this.zones, zoneid)
 	this.triggerAllZonePolicies()
 }
 
 func (this *state) CreateStateTTLGetter(defaultStateTTL time.Duration) StateTTLGetter {
 	return func(zoneid dns.ZoneID) time.Duration {
-		if value := this.zoneStateTTL.Load(); value != nil {
-			stateTTLMap := value.(map[dns.ZoneID]time.Duration)
-			if ttl, ok := stateTTLMap[zoneid]; ok {
-				return ttl
-			}
+		if ttl, ok := this.getZoneStateTTLs()[zoneid]; ok {
+			return ttl
+		}
+		if ttl := this.config.ProviderTypeDefaults.Get(zoneid.ProviderType).ZoneStateCacheTTL; ttl != nil {
+			return ttl.Duration
 		}
 		return defaultStateTTL
 	}
 }
+
+// prioritizedEntries returns the entries with high rate limit priority first,
+// so that they take the available tokens of the provider rate limiter before all other entries.
+func prioritizedEntries(entries Entries) EntryList {
+	list := make(EntryList, 0, len(entries))
+	for _, e := range entries {
+		if e.HasHighRateLimitPriority() {
+			list = append(list, e)
+		}
+	}
+	for _, e := range entries {
+		if !e.HasHighRateLimitPriority() {
+			list = append(list, e)
+		}
+	}
+	return list
+}
@@ -29,7 +29,14 @@ import (
 func (this *state) UpdateZonePolicy(logger logger.LogContext, policy *dnsutils.DNSHostedZonePolicyObject) reconcile.Status {
 	zones, conflicts := this.updateZonePolicyState(logger, policy)
 
-	err := this.updateZonePolicyStatus(policy, zones, conflicts)
+	var rateLimit *api.RateLimit
+	if err := validateZonePolicyRateLimit(policy.Spec().Policy.RateLimit); err != nil {
+		conflicts = append(conflicts, err.Error())
+	} else if len(zones) > 0 {
+		rateLimit = policy.Spec().Policy.RateLimit
+	}
+
+	err := this.updateZonePolicyStatus(policy, zones, conflicts, rateLimit)
 	if err != nil {
 		reconcile.Delay(logger, err)
 	}
@@ -79,11 +86,54 @@ func (this *state) updateZonePolicyState(logger logger.LogContext, policy *dnsut
 			})
 		}
 	}
-	this.updateStateTTLMap()
+	this.updateZonePolicySettings(logger)
 	return zones, conflicts
 }
 
-func (this *state) updateStateTTLMap() {
+// assignZonePolicy assigns the first matching zone policy to a newly discovered zone,
+// so that its settings (e.g. the pause) are already applied on the first reconciliation of the zone.
+// Conflicting policies are reported by the reconciliation of the policies triggered for new zones.
+func (this *state) assignZonePolicy(logger logger.LogContext, zone *dnsHostedZone) {
+	names := make([]string, 0, len(this.zonePolicies))
+	for name := range this.zonePolicies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if pol := this.zonePolicies[name]; matchesPolicySelector(pol, zone) {
+			zone.SetPolicy(pol)
+			pol.zones = append(pol.zones, zone)
+			logger.Infof("added zone %s to policy %s", zone.Id(), name)
+			this.updateZonePolicySettings(logger)
+			return
+		}
+	}
+}
+
+// updateZonePolicySettings updates the settings of all zones specified by the zone policies.
+func (this *state) updateZonePolicySettings(logger logger.LogContext) {
+	this.updateZoneStateTTLs(logger)
+	this.updatePausedZones(logger)
+	this.updateRecordSetLimits()
+	this.updateZoneRateLimiters(logger)
+}
+
+// updateZoneStateTTLs updates the zone state cache TTLs specified by the zone policies.
+// Zones with changed TTL are triggered, so that the accounts serving them re-read the zone state
+// with the new TTL without waiting for the old one to expire.
+func (this *state) updateZoneStateTTLs(logger logger.LogContext) {
+	for _, zoneid := range this.updateStateTTLMap() {
+		if this.zoneStates != nil {
+			logger.Infof("zone state cache TTL of zone %s changed to %s", zoneid, this.zoneStates.stateTTLGetter(zoneid))
+		}
+		this.triggerHostedZone(zoneid)
+	}
+}
+
+// updateStateTTLMap updates the map of zone state cache TTLs specified by the zone policies.
+// It returns the ids of the zones with changed TTL.
+func (this *state) updateStateTTLMap() []dns.ZoneID {
+	old := this.getZoneStateTTLs()
 	new := map[dns.ZoneID]time.Duration{}
 	for _, zone := range this.zones {
 		if zpol := zone.Policy(); zpol != nil {
@@ -93,6 +143,125 @@ func (this *state) updateStateTTLMap() {
 		}
 	}
 	this.zoneStateTTL.Store(new)
+
+	var changed []dns.ZoneID
+	for zoneid := range this.zones {
+		oldTTL, oldFound := old[zoneid]
+		newTTL, newFound := new[zoneid]
+		if oldFound != newFound || oldTTL != newTTL {
+			changed = append(changed, zoneid)
+		}
+	}
+	sort.Slice(changed, func(i, j int) bool { return changed[i].String() < changed[j].String() })
+	return changed
+}
+
+func (this *state) getZoneStateTTLs() map[dns.ZoneID]time.Duration {
+	if value := this.zoneStateTTL.Load(); value != nil {
+		return value.(map[dns.ZoneID]time.Duration)
+	}
+	return nil
+}
+
+// updatePausedZones updates the map of zones paused by their policies.
+// Zones with changed pause state are triggered, so that the entries are resumed or get the paused status message.
+func (this *state) updatePausedZones(logger logger.LogContext) {
+	old := this.getPausedZones()
+	new := map[dns.ZoneID]string{}
+	for _, zone := range this.zones {
+		if zpol := zone.Policy(); zpol != nil && zpol.spec.Policy.Paused {
+			new[zone.Id()] = zpol.name
+		}
+	}
+	this.pausedZones.Store(new)
+	for zoneid := range this.zones {
+		if _, paused := new[zoneid]; paused {
+			if _, ok := old[zoneid]; !ok {
+				logger.Infof("pausing reconciliation of zone %s", zoneid)
+				this.triggerHostedZone(zoneid)
+			}
+		} else if _, ok := old[zoneid]; ok {
+			logger.Infof("resuming reconciliation of zone %s", zoneid)
+			this.triggerHostedZone(zoneid)
+		}
+	}
+}
+
+// updateRecordSetLimits updates the map of record set limits specified by the zone policies.
+func (this *state) updateRecordSetLimits() {
+	new := map[dns.ZoneID]int{}
+	for _, zone := range this.zones {
+		if zpol := zone.Policy(); zpol != nil && zpol.spec.Policy.RecordSetLimit != nil {
+			new[zone.Id()] = *zpol.spec.Policy.RecordSetLimit
+		}
+	}
+	this.zoneRecordSetLimits.Store(new)
+}
+
+// getZoneRecordSetLimit returns the maximum number of record sets of the given zone (unlimited if 0).
+// A limit specified by a zone policy overwrites the limit configured for the controller.
+func (this *state) getZoneRecordSetLimit(zoneid dns.ZoneID) int {
+	if value := this.zoneRecordSetLimits.Load(); value != nil {
+		if limit, ok := value.(map[dns.ZoneID]int)[zoneid]; ok {
+			return limit
+		}
+	}
+	return this.config.ZoneRecordSetLimit
+}
+
+// updateZoneRateLimiters updates the rate limiters of zones specified by the zone policies.
+// Existing rate limiters are kept if the rate limit is unchanged.
+func (this *state) updateZoneRateLimiters(logger logger.LogContext) {
+	this.prlock.Lock()
+	defer this.prlock.Unlock()
+
+	new := map[dns.ZoneID]*rateLimiterData{}
+	for _, zone := range this.zones {
+		rateLimit := zonePolicyRateLimit(zone)
+		if rateLimit == nil {
+			continue
+		}
+		data := this.zoneRateLimiter[zone.Id()]
+		if data == nil || !data.matches(rateLimit) {
+			data = newRateLimiterData(*rateLimit)
+			logger.Infof("frontend rate limiter of zone %s updated: requestsPerDay=%d, burst=%d", zone.Id(), rateLimit.RequestsPerDay, rateLimit.Burst)
+		}
+		new[zone.Id()] = data
+	}
+	for zoneid := range this.zoneRateLimiter {
+		if new[zoneid] == nil {
+			logger.Infof("frontend rate limiter of zone %s deleted", zoneid)
+		}
+	}
+	this.zoneRateLimiter = new
+}
+
+// zonePolicyRateLimit returns the valid rate limit specified by the policy of the zone (nil if none).
+func zonePolicyRateLimit(zone *dnsHostedZone) *api.RateLimit {
+	zpol := zone.Policy()
+	if zpol == nil || zpol.spec.Policy.RateLimit == nil || validateZonePolicyRateLimit(zpol.spec.Policy.RateLimit) != nil {
+		return nil
+	}
+	return zpol.spec.Policy.RateLimit
+}
+
+func validateZonePolicyRateLimit(rateLimit *api.RateLimit) error {
+	if rateLimit != nil && (rateLimit.RequestsPerDay <= 0 || rateLimit.Burst < 0) {
+		return fmt.Errorf("invalid rateLimit: requires positive requestsPerDay and a non-negative burst")
+	}
+	return nil
+}
+
+func (this *state) getPausedZones() map[dns.ZoneID]string {
+	if value := this.pausedZones.Load(); value != nil {
+		return value.(map[dns.ZoneID]string)
+	}
+	return nil
+}
+
+// getZonePausedBy returns the name of the policy pausing the given zone or an empty string if the zone is not paused.
+func (this *state) getZonePausedBy(zoneid dns.ZoneID) string {
+	return this.getPausedZones()[zoneid]
 }
 
 func (this *state) RemoveZonePolicy(logger logger.LogContext, policy *dnsutils.DNSHostedZonePolicyObject) reconcile.Status {
@@ -113,8 +282,8 @@ func (this *state) ZonePolicyDeleted(logger logger.LogContext, key resources.Clu
 			key := this.createZonePolicyClusterKey(zname)
 			this.triggerKey(key)
 		}
-		this.updateStateTTLMap()
 		delete(this.zonePolicies, name)
+		this.updateZonePolicySettings(logger)
 	}
 
 	return reconcile.Succeeded(logger)
@@ -124,6 +293,7 @@ func (this *state) updateZonePolicyStatus(
 	policy *dnsutils.DNSHostedZonePolicyObject,
 	zones []api.ZoneInfo,
 	conflicts []string,
+	rateLimit *api.RateLimit,
 ) error {
 	var pmsg *string
 	if len(conflicts) > 0 {
@@ -146,6 +316,10 @@ func (this *state) updateZonePolicyStatus(
 		status.Count = &n
 		mod.Modify(true)
 	}
+	if !reflect.DeepEqual(status.RateLimit, rateLimit) {
+		status.RateLimit = rateLimit
+		mod.Modify(true)
+	}
 
 	if mod.IsModified() {
 		status.LastStatusUpdateTime = &metav1.Time{Time: time.Now()}
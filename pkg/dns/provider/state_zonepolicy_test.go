@@ -0,0 +1,158 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+var _ = ginkgov2.Describe("Zone state cache TTL of zone policies", func() {
+	var (
+		s          *state
+		zone1      *dnsHostedZone
+		zone2      *dnsHostedZone
+		cache      ZoneCache
+		stateCalls int
+	)
+
+	ginkgov2.BeforeEach(func() {
+		zone1 = newDNSHostedZone(0, NewDNSHostedZone("test", "z1", "example.com", "", false))
+		zone2 = newDNSHostedZone(0, NewDNSHostedZone("test", "z2", "example.org", "", false))
+		s = &state{
+			zones: map[dns.ZoneID]*dnsHostedZone{zone1.Id(): zone1, zone2.Id(): zone2},
+		}
+		s.zoneStates = newZoneStates(s.CreateStateTTLGetter(1 * time.Hour))
+
+		stateCalls = 0
+		factory := ZoneCacheFactory{zonesTTL: 1 * time.Hour, zoneStates: s.zoneStates}
+		zonesUpdater := func(_ context.Context, _ ZoneCache) (DNSHostedZones, error) {
+			return DNSHostedZones{zone1.zone}, nil
+		}
+		stateUpdater := func(_ DNSHostedZone, _ ZoneCache) (DNSZoneState, error) {
+			stateCalls++
+			return NewDNSZoneState(dns.DNSSets{}), nil
+		}
+		var err error
+		cache, err = factory.CreateZoneCache(CacheZoneState, &NullMetrics{}, zonesUpdater, stateUpdater)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	ginkgov2.AfterEach(func() {
+		cache.Release()
+	})
+
+	setTTL := func(zone *dnsHostedZone, ttl *time.Duration) []dns.ZoneID {
+		var policy api.ZonePolicy
+		if ttl != nil {
+			policy.ZoneStateCacheTTL = &metav1.Duration{Duration: *ttl}
+		}
+		zone.SetPolicy(newDNSHostedZonePolicy("policy", &api.DNSHostedZonePolicySpec{Policy: policy}))
+		return s.updateStateTTLMap()
+	}
+
+	ginkgov2.It("reports the zones with changed TTL", func() {
+		ttl := 5 * time.Minute
+		Expect(setTTL(zone1, &ttl)).To(Equal([]dns.ZoneID{zone1.Id()}))
+		Expect(s.zoneStates.stateTTLGetter(zone1.Id())).To(Equal(5 * time.Minute))
+		Expect(s.zoneStates.stateTTLGetter(zone2.Id())).To(Equal(1 * time.Hour))
+
+		Expect(setTTL(zone1, &ttl)).To(BeEmpty())
+		Expect(setTTL(zone2, nil)).To(BeEmpty())
+
+		zone1.SetPolicy(nil)
+		Expect(s.updateStateTTLMap()).To(Equal([]dns.ZoneID{zone1.Id()}))
+		Expect(s.zoneStates.stateTTLGetter(zone1.Id())).To(Equal(1 * time.Hour))
+	})
+
+	ginkgov2.It("applies a changed TTL to cached zone states", func() {
+		_, err := cache.GetZoneState(zone1.zone)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = cache.GetZoneState(zone1.zone)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stateCalls).To(Equal(1))
+
+		ttl := 1 * time.Millisecond
+		Expect(setTTL(zone1, &ttl)).To(Equal([]dns.ZoneID{zone1.Id()}))
+		time.Sleep(5 * time.Millisecond)
+		_, err = cache.GetZoneState(zone1.zone)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stateCalls).To(Equal(2))
+
+		ttl = 1 * time.Hour
+		Expect(setTTL(zone1, &ttl)).To(Equal([]dns.ZoneID{zone1.Id()}))
+		_, err = cache.GetZoneState(zone1.zone)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stateCalls).To(Equal(2))
+	})
+})
+
+var _ = ginkgov2.Describe("Zone pause of zone policies", func() {
+	var (
+		s    *state
+		ctx  *testProviderContext
+		zone *dnsHostedZone
+	)
+
+	ginkgov2.BeforeEach(func() {
+		zone = newDNSHostedZone(0, NewDNSHostedZone("test", "z1", "example.com", "", false))
+		ctx = &testProviderContext{}
+		s = &state{
+			context:       ctx,
+			zones:         map[dns.ZoneID]*dnsHostedZone{},
+			zonePolicies:  map[string]*dnsHostedZonePolicy{},
+			providerzones: map[resources.ObjectName]map[dns.ZoneID]*dnsHostedZone{},
+			zoneproviders: map[dns.ZoneID]resources.ObjectNameSet{},
+		}
+		spec := &api.DNSHostedZonePolicySpec{
+			Selector: api.ZoneSelector{DomainNames: []string{"example.com"}},
+			Policy:   api.ZonePolicy{Paused: true},
+		}
+		s.zonePolicies["pause"] = newDNSHostedZonePolicy("pause", spec)
+	})
+
+	ginkgov2.It("pauses zones discovered after the pause", func() {
+		p := newTestProvider("p1", zone.getZone(), &testZoneHandler{inMemory: NewInMemory()})
+		Expect(s.updateZones(logger.New(), nil, p)).To(BeTrue())
+		Expect(s.getZonePausedBy(zone.Id())).To(Equal("pause"))
+		Expect(s.zones[zone.Id()].Policy()).To(Equal(s.zonePolicies["pause"]))
+		Expect(s.zonePolicies["pause"].zones).To(HaveLen(1))
+		Expect(ctx.commands).To(ContainElement(CMD_HOSTEDZONE_PREFIX + "test:z1"))
+		Expect(ctx.keys).To(HaveLen(1))
+	})
+
+	ginkgov2.It("keeps zones not selected by a policy running", func() {
+		other := NewDNSHostedZone("test", "z2", "example.org", "", false)
+		p := newTestProvider("p1", other, &testZoneHandler{inMemory: NewInMemory()})
+		s.updateZones(logger.New(), nil, p)
+		Expect(s.getZonePausedBy(other.Id())).To(BeEmpty())
+		Expect(s.zones[other.Id()].Policy()).To(BeNil())
+	})
+
+	ginkgov2.It("updates the status of the entries of a paused zone only once", func() {
+		s.zones[zone.Id()] = zone
+		s.pausedZones.Store(map[dns.ZoneID]string{zone.Id(): "pause"})
+		entry := newTestEntry(s, "e1", "a.example.com", api.STATE_READY, zone.Id(), "1.1.1.1")
+		req := &zoneReconciliation{zone: zone, entries: Entries{entry.ObjectName(): entry}}
+
+		Expect(s.reconcileZone(logger.New(), req)).To(Succeed())
+		Expect(entry.State()).To(Equal(api.STATE_READY))
+		Expect(strings.HasPrefix(entry.Message(), MSG_PAUSED)).To(BeTrue())
+		Expect(testStatusModifications(entry)).To(Equal(1))
+
+		Expect(s.reconcileZone(logger.New(), req)).To(Succeed())
+		Expect(testStatusModifications(entry)).To(Equal(1))
+	})
+})
@@ -117,11 +117,28 @@ func (this OwnerStatistic) Walk(state WalkingState, walker OwnerWalker) WalkingS
 
 ////////////////////////////////////////////////////////////////////////////////
 
+// StateKey identifies the entries of a namespace and provider type in a state.
+type StateKey struct {
+	Namespace    string
+	ProviderType string
+	State        string
+}
+
+// StateStatistic counts the entries per namespace, provider type, and state.
+type StateStatistic map[StateKey]int
+
+func (this StateStatistic) Inc(namespace, ptype, state string) {
+	this[StateKey{Namespace: namespace, ProviderType: ptype, State: state}]++
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
 type EntryStatistic struct {
 	Providers ProviderTypeStatistic
 	Owners    OwnerStatistic
+	States    StateStatistic
 }
 
 func NewEntryStatistic() *EntryStatistic {
-	return &EntryStatistic{ProviderTypeStatistic{}, OwnerStatistic{}}
+	return &EntryStatistic{ProviderTypeStatistic{}, OwnerStatistic{}, StateStatistic{}}
 }
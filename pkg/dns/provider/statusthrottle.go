@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+)
+
+// statusThrottle coalesces status writes of DNS entries by enforcing a minimum interval between two status writes
+// of the same entry. A skipped write is caught up by a reconcile scheduled after the remaining interval.
+// State transitions are never throttled.
+type statusThrottle struct {
+	lock        sync.Mutex
+	minInterval time.Duration
+	enqueuer    enqueuer
+	lastWrites  map[resources.ObjectName]time.Time
+	scheduled   map[resources.ObjectName]struct{}
+	now         func() time.Time
+	after       func(d time.Duration, f func())
+	stopped     bool
+}
+
+func newStatusThrottle(minInterval time.Duration, enqueuer enqueuer) *statusThrottle {
+	return &statusThrottle{
+		minInterval: minInterval,
+		enqueuer:    enqueuer,
+		lastWrites:  map[resources.ObjectName]time.Time{},
+		scheduled:   map[resources.ObjectName]struct{}{},
+		now:         time.Now,
+		after: func(d time.Duration, f func()) {
+			time.AfterFunc(d, f)
+		},
+	}
+}
+
+// Allow checks if a status write of the entry is allowed now. If not, a reconcile of the entry is scheduled
+// after the remaining interval.
+func (this *statusThrottle) Allow(key resources.ClusterObjectKey, transition bool) bool {
+	if this == nil || this.minInterval <= 0 || transition {
+		return true
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if this.stopped {
+		return true
+	}
+
+	name := key.ObjectName()
+	remaining := this.minInterval - this.now().Sub(this.lastWrites[name])
+	if remaining <= 0 {
+		return true
+	}
+	if _, ok := this.scheduled[name]; !ok {
+		this.scheduled[name] = struct{}{}
+		this.after(remaining, func() {
+			this.lock.Lock()
+			delete(this.scheduled, name)
+			this.lock.Unlock()
+			_ = this.enqueuer.EnqueueKey(key)
+		})
+	}
+	return false
+}
+
+// Written records a status write of the entry.
+func (this *statusThrottle) Written(name resources.ObjectName) {
+	if this == nil || this.minInterval <= 0 {
+		return
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.lastWrites[name] = this.now()
+}
+
+// Stop disables the throttling on shutdown of the controller, as skipped status writes would not be caught up anymore.
+// It returns the number of entries with skipped status writes.
+func (this *statusThrottle) Stop() int {
+	if this == nil {
+		return 0
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.stopped = true
+	return len(this.scheduled)
+}
+
+// Remove forgets the status writes of a deleted entry.
+func (this *statusThrottle) Remove(name resources.ObjectName) {
+	if this == nil {
+		return
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	delete(this.lastWrites, name)
+}
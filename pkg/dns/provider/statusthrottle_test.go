@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = ginkgov2.Describe("Status throttle", func() {
+	var (
+		now       time.Time
+		scheduled []time.Duration
+		callbacks []func()
+		enq       *testEnqueuer
+		throttle  *statusThrottle
+		key       = resources.NewClusterKey("cluster", entryGroupKind, "ns", "entry")
+	)
+
+	ginkgov2.BeforeEach(func() {
+		now = time.Now()
+		scheduled = nil
+		callbacks = nil
+		enq = &testEnqueuer{enqueuedCount: map[resources.ObjectName]int{}}
+		throttle = newStatusThrottle(10*time.Second, enq)
+		throttle.now = func() time.Time { return now }
+		throttle.after = func(d time.Duration, f func()) {
+			scheduled = append(scheduled, d)
+			callbacks = append(callbacks, f)
+		}
+	})
+
+	ginkgov2.It("allows everything if disabled", func() {
+		var disabled *statusThrottle
+		Expect(disabled.Allow(key, false)).To(BeTrue())
+		disabled.Written(key.ObjectName())
+		throttle.minInterval = 0
+		throttle.Written(key.ObjectName())
+		Expect(throttle.Allow(key, false)).To(BeTrue())
+	})
+
+	ginkgov2.It("throttles status writes within the minimum interval", func() {
+		Expect(throttle.Allow(key, false)).To(BeTrue())
+		throttle.Written(key.ObjectName())
+
+		now = now.Add(4 * time.Second)
+		Expect(throttle.Allow(key, false)).To(BeFalse())
+		Expect(throttle.Allow(key, false)).To(BeFalse())
+		Expect(scheduled).To(Equal([]time.Duration{6 * time.Second}))
+
+		callbacks[0]()
+		Expect(enq.enqueuedCount[key.ObjectName()]).To(Equal(1))
+
+		now = now.Add(6 * time.Second)
+		Expect(throttle.Allow(key, false)).To(BeTrue())
+	})
+
+	ginkgov2.It("never throttles state transitions", func() {
+		throttle.Written(key.ObjectName())
+		Expect(throttle.Allow(key, true)).To(BeTrue())
+		Expect(scheduled).To(BeEmpty())
+	})
+
+	ginkgov2.It("forgets removed entries", func() {
+		throttle.Written(key.ObjectName())
+		throttle.Remove(key.ObjectName())
+		Expect(throttle.Allow(key, false)).To(BeTrue())
+	})
+
+	ginkgov2.It("stops throttling on shutdown", func() {
+		throttle.Written(key.ObjectName())
+		Expect(throttle.Allow(key, false)).To(BeFalse())
+		Expect(throttle.Stop()).To(Equal(1))
+		Expect(throttle.Allow(key, false)).To(BeTrue())
+	})
+})
@@ -5,9 +5,13 @@
 package provider
 
 import (
+	"strings"
+
 	"github.com/gardener/controller-manager-library/pkg/logger"
 	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
 	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 )
 
 type FinalizerHandler interface {
@@ -21,8 +25,16 @@ type StatusUpdate struct {
 	delete   bool
 	done     bool
 	fhandler FinalizerHandler
+	planned  []string
+	written  bool
 }
 
+var (
+	_ DryRunDoneHandler   = &StatusUpdate{}
+	_ WrittenDoneHandler  = &StatusUpdate{}
+	_ DeferredDoneHandler = &StatusUpdate{}
+)
+
 func NewStatusUpdate(logger logger.LogContext, e *Entry, f FinalizerHandler) DoneHandler {
 	// logger.Infof("request update for %s (delete=%t)", e.DNSName(), e.IsDeleting())
 	return &StatusUpdate{Entry: e, logger: logger, delete: e.IsDeleting(), fhandler: f}
@@ -54,6 +66,10 @@ func (this *StatusUpdate) Failed(err error) {
 		} else {
 			newState = api.STATE_STALE
 		}
+		status := this.Entry.object.Status()
+		if status.State != newState || utils.StringValue(status.Message) != err.Error() {
+			this.Entry.event(corev1.EventTypeWarning, EventReasonBackendError, err.Error())
+		}
 		_, err := this.UpdateStatus(this.logger, newState, err.Error())
 		if err != nil {
 			this.logger.Errorf("cannot update: %s", err)
@@ -75,17 +91,55 @@ func (this *StatusUpdate) Succeeded() {
 			if err2 := this.fhandler.SetFinalizer(this.Entry.Object()); err2 != nil {
 				this.logger.Errorf("cannot set finalizer: %s", err2)
 			}
-			_, err := this.UpdateStatus(this.logger, api.STATE_READY, "dns entry active")
+			_, err := this.updateEntryStatus(this.logger, api.STATE_READY, this.activeMessage(), this.written)
 			if err != nil {
 				this.logger.Errorf("cannot update: %s", err)
+			} else {
+				this.Entry.state.VerifyPropagation(this.logger, this.Entry)
 			}
 		}
 	}
 }
 
+// Written records that the changes of the entry have been written to the DNS backend.
+func (this *StatusUpdate) Written() {
+	this.written = true
+}
+
 func (this *StatusUpdate) Throttled() {
+	if utils.StringValue(this.Entry.object.Status().Message) != MSG_THROTTLING {
+		this.Entry.event(corev1.EventTypeNormal, EventReasonRateLimited, MSG_THROTTLING)
+	}
 	_, err := this.UpdateState(this.logger, api.STATE_PENDING, MSG_THROTTLING)
 	if err != nil {
 		this.logger.Errorf("cannot update: %s", err)
 	}
 }
+
+// Deferred reports in the status message that the records are maintained by another controller instance.
+// The entry keeps its state and no event is emitted, as the deferral is not an error.
+func (this *StatusUpdate) Deferred(err error) {
+	if !this.done {
+		this.done = true
+		this.modified = false
+		state := this.Entry.status.State
+		if state == "" {
+			state = api.STATE_PENDING
+		}
+		_, err := this.UpdateState(this.logger, state, err.Error())
+		if err != nil {
+			this.logger.Errorf("cannot update: %s", err)
+		}
+	}
+}
+
+// DryRun reports a planned change in dry run mode as event and in the status message.
+// The entry stays pending and no finalizer is set.
+func (this *StatusUpdate) DryRun(planned string) {
+	this.planned = append(this.planned, planned)
+	this.Entry.event(corev1.EventTypeNormal, EventReasonDryRun, "would "+planned)
+	_, err := this.UpdateState(this.logger, api.STATE_PENDING, "dry run: would "+strings.Join(this.planned, "; "))
+	if err != nil {
+		this.logger.Errorf("cannot update: %s", err)
+	}
+}
@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+// testFinalizerHandler ignores finalizer updates.
+type testFinalizerHandler struct{}
+
+func (testFinalizerHandler) SetFinalizer(_ resources.Object) error    { return nil }
+func (testFinalizerHandler) RemoveFinalizer(_ resources.Object) error { return nil }
+
+var _ = ginkgov2.Describe("Status update", func() {
+	var entry *Entry
+
+	ginkgov2.BeforeEach(func() {
+		entry = newTestEntry(nil, "a", "a.example.com", api.STATE_READY, dns.NewZoneID("test", "z1"), "1.1.1.1")
+	})
+
+	failed := func(msg string) {
+		NewStatusUpdate(logger.New(), entry, testFinalizerHandler{}).Failed(fmt.Errorf("%s", msg))
+	}
+
+	ginkgov2.It("emits the backend error event only if the error changes", func() {
+		failed("backend unavailable")
+		Expect(entry.object.Status().State).To(Equal(api.STATE_STALE))
+		failed("backend unavailable")
+		failed("backend unavailable")
+		Expect(testEvents(entry)).To(Equal([]string{"BackendError: backend unavailable"}))
+
+		failed("access denied")
+		Expect(testEvents(entry)).To(Equal([]string{"BackendError: backend unavailable", "BackendError: access denied"}))
+	})
+})
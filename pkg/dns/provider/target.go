@@ -20,7 +20,8 @@ type (
 func NewHostTargetFromEntryVersion(name string, entry *EntryVersion) (Target, error) {
 	ip := net.ParseIP(name)
 	if ip == nil {
-		return dnsutils.NewTargetWithIPStack(dns.RS_CNAME, name, entry.TTL(), entry.GetAnnotations()[dns.AnnotationIPStack]), nil
+		annotations := entry.GetAnnotations()
+		return dnsutils.NewTargetWithAliasHostedZone(dns.RS_CNAME, name, entry.TTL(), annotations[dns.AnnotationIPStack], annotations[dns.AnnotationAliasHostedZoneID]), nil
 	} else if ip.To4() != nil {
 		return dnsutils.NewTarget(dns.RS_A, name, entry.TTL()), nil
 	} else if ip.To16() != nil {
@@ -0,0 +1,126 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/resources/access"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+const (
+	TARGET_REF_KIND_SERVICE = "Service"
+	TARGET_REF_KIND_INGRESS = "Ingress"
+)
+
+var (
+	serviceGroupKind = resources.NewGroupKind("", TARGET_REF_KIND_SERVICE)
+	ingressGroupKind = resources.NewGroupKind("networking.k8s.io", TARGET_REF_KIND_INGRESS)
+)
+
+func isTargetRefGroupKind(gk schema.GroupKind) bool {
+	return gk == serviceGroupKind || gk == ingressGroupKind
+}
+
+// completeByTargetRef returns the spec of the entry with the targets taken from the load balancer addresses
+// of the referenced Service or Ingress.
+func completeByTargetRef(logger logger.LogContext, state *state, entry *dnsutils.DNSEntryObject, ref *api.TargetReference, prefix string) (*api.DNSEntrySpec, error) {
+	if !state.config.TargetRefs {
+		return nil, fmt.Errorf("%starget references are disabled (option %s)", prefix, OPT_TARGET_REFS)
+	}
+	if entry.GetTargets() != nil || entry.GetText() != nil || entry.GetRecords() != nil {
+		return nil, fmt.Errorf("%stargets, text, or records specified together with target reference", prefix)
+	}
+	var gk schema.GroupKind
+	switch ref.Kind {
+	case TARGET_REF_KIND_SERVICE:
+		gk = serviceGroupKind
+	case TARGET_REF_KIND_INGRESS:
+		gk = ingressGroupKind
+	default:
+		return nil, fmt.Errorf("%sunsupported kind %q of target reference (only %s and %s are supported)", prefix, ref.Kind, TARGET_REF_KIND_SERVICE, TARGET_REF_KIND_INGRESS)
+	}
+	ns := ref.Namespace
+	if ns == "" {
+		ns = entry.GetNamespace()
+	}
+	name := resources.NewObjectName(ns, ref.Name)
+	logger.Infof("completing spec by target reference: %s%s %s", prefix, ref.Kind, name)
+
+	cur := entry.ClusterKey()
+	state.references.AddRef(cur, resources.NewClusterKey(cur.Cluster(), gk, name.Namespace(), name.Name()))
+
+	res, err := entry.GetResource().Resources().GetByGK(gk)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := res.GetCached(name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, &perrs.TargetRefPending{Kind: ref.Kind, Name: name}
+		}
+		return nil, err
+	}
+	if err := access.CheckAccessWithRealms(entry, "use", obj, state.realms); err != nil {
+		return nil, fmt.Errorf("%s%s", prefix, err)
+	}
+
+	var targets []string
+	switch data := obj.Data().(type) {
+	case *corev1.Service:
+		if data.Spec.Type != corev1.ServiceTypeLoadBalancer {
+			return nil, fmt.Errorf("%sreferenced service %s is not of type %s", prefix, name, corev1.ServiceTypeLoadBalancer)
+		}
+		for _, ingress := range data.Status.LoadBalancer.Ingress {
+			targets = appendLoadBalancerTarget(targets, ingress.IP, ingress.Hostname)
+		}
+	case *networkingv1.Ingress:
+		for _, ingress := range data.Status.LoadBalancer.Ingress {
+			targets = appendLoadBalancerTarget(targets, ingress.IP, ingress.Hostname)
+		}
+	default:
+		return nil, fmt.Errorf("%sunexpected type %T of referenced object %s", prefix, data, name)
+	}
+	if len(targets) == 0 {
+		return nil, &perrs.TargetRefPending{Kind: ref.Kind, Name: name}
+	}
+
+	newSpec := entry.Spec().DeepCopy()
+	newSpec.Targets = targets
+	return newSpec, nil
+}
+
+// appendLoadBalancerTarget appends the IP or, if not set, the hostname of a load balancer ingress.
+func appendLoadBalancerTarget(targets []string, ip, hostname string) []string {
+	target := ip
+	if target == "" {
+		target = hostname
+	}
+	if target == "" {
+		return targets
+	}
+	for _, t := range targets {
+		if t == target {
+			return targets
+		}
+	}
+	return append(targets, target)
+}
+
+// TargetRefObjectChanged triggers all entries referencing the given Service or Ingress.
+func (this *state) TargetRefObjectChanged(logger logger.LogContext, key resources.ClusterObjectKey) {
+	logger.Debugf("target reference %s changed", key)
+	this.references.NotifyHolder(this.context, key)
+}
@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+var _ = ginkgov2.Describe("Target references", func() {
+	ginkgov2.It("collects IPs and hostnames of load balancer ingresses", func() {
+		var targets []string
+		targets = appendLoadBalancerTarget(targets, "1.2.3.4", "")
+		targets = appendLoadBalancerTarget(targets, "", "lb.example.com")
+		targets = appendLoadBalancerTarget(targets, "1.2.3.5", "ignored.example.com")
+		targets = appendLoadBalancerTarget(targets, "1.2.3.4", "")
+		targets = appendLoadBalancerTarget(targets, "", "")
+		Expect(targets).To(Equal([]string{"1.2.3.4", "lb.example.com", "1.2.3.5"}))
+	})
+
+	ginkgov2.It("tracks the reference of a holder", func() {
+		refs := NewReferenceCache()
+		holder := resources.NewClusterKey("c", entryGroupKind, "ns", "entry")
+		svc := resources.NewClusterKey("c", serviceGroupKind, "ns", "svc")
+		ing := resources.NewClusterKey("c", ingressGroupKind, "ns", "ing")
+
+		refs.AddRef(holder, svc)
+		Expect(refs.refs).To(HaveKeyWithValue(holder, svc))
+		Expect(refs.usages[svc].Contains(holder)).To(BeTrue())
+
+		refs.AddRef(holder, ing)
+		Expect(refs.refs).To(HaveKeyWithValue(holder, ing))
+		Expect(refs.usages).NotTo(HaveKey(svc))
+		Expect(refs.usages[ing].Contains(holder)).To(BeTrue())
+
+		refs.DelRef(holder)
+		Expect(refs.refs).To(BeEmpty())
+		Expect(refs.usages).To(BeEmpty())
+	})
+
+	ginkgov2.It("rejects target references if not enabled", func() {
+		s := &state{references: NewReferenceCache()}
+		object := &api.DNSEntry{}
+		object.Namespace = "ns"
+		object.Name = "entry"
+		object.Spec.TargetRef = &api.TargetReference{Kind: TARGET_REF_KIND_SERVICE, Name: "svc"}
+		entry := dnsutils.DNSEntry(newTestObject(object, api.DNSEntryKind))
+
+		_, err := completeByTargetRef(logger.New(), s, entry, object.Spec.TargetRef, "")
+		Expect(err).To(MatchError("target references are disabled (option target-refs)"))
+		Expect(s.references.refs).To(BeEmpty())
+	})
+})
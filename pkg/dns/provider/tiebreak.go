@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"time"
+)
+
+const (
+	// ProviderTieBreakerName prefers the provider with the lowest name (namespace/name).
+	ProviderTieBreakerName = "name"
+	// ProviderTieBreakerCreationTime prefers the oldest provider. Providers created at the same time are ordered by name.
+	ProviderTieBreakerCreationTime = "creation-time"
+)
+
+// providerRank contains the properties of a provider relevant for breaking ties between providers
+// matching a DNS entry equally well.
+type providerRank struct {
+	priority int32
+	name     string
+	created  time.Time
+}
+
+func (this *dnsProviderVersion) rank() providerRank {
+	return providerRank{
+		priority: this.Priority(),
+		name:     this.ObjectName().String(),
+		created:  this.Object().GetCreationTimestamp().Time,
+	}
+}
+
+// preferProvider returns true if the provider p is preferred over the provider found so far for a DNS entry
+// matched equally well by both providers.
+// The priority of the providers is the primary criterion. For equal priorities, the current provider of the entry
+// is kept to avoid reassignments. Otherwise, the given tie breaker decides.
+func preferProvider(tieBreaker string, p, found providerRank, current string) bool {
+	if p.priority != found.priority {
+		return p.priority > found.priority
+	}
+	if current != "" {
+		if p.name == current {
+			return true
+		}
+		if found.name == current {
+			return false
+		}
+	}
+	if tieBreaker == ProviderTieBreakerCreationTime && !p.created.Equal(found.created) {
+		return p.created.Before(found.created)
+	}
+	return p.name < found.name
+}
@@ -0,0 +1,48 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"time"
+
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = ginkgov2.Describe("Provider tie breaking", func() {
+	var (
+		now   = time.Now()
+		older = providerRank{name: "default/b", created: now.Add(-time.Hour)}
+		newer = providerRank{name: "default/a", created: now}
+	)
+
+	ginkgov2.It("prefers the provider with higher priority", func() {
+		high := providerRank{priority: 10, name: "default/z", created: now}
+		Expect(preferProvider(ProviderTieBreakerName, high, newer, "")).To(BeTrue())
+		Expect(preferProvider(ProviderTieBreakerName, newer, high, "")).To(BeFalse())
+		Expect(preferProvider(ProviderTieBreakerName, high, newer, newer.name)).To(BeTrue())
+	})
+
+	ginkgov2.It("keeps the current provider for equal priorities", func() {
+		Expect(preferProvider(ProviderTieBreakerName, older, newer, older.name)).To(BeTrue())
+		Expect(preferProvider(ProviderTieBreakerName, newer, older, older.name)).To(BeFalse())
+	})
+
+	ginkgov2.It("prefers the lowest name by default", func() {
+		Expect(preferProvider(ProviderTieBreakerName, newer, older, "")).To(BeTrue())
+		Expect(preferProvider(ProviderTieBreakerName, older, newer, "")).To(BeFalse())
+	})
+
+	ginkgov2.It("prefers the oldest provider for tie breaker creation-time", func() {
+		Expect(preferProvider(ProviderTieBreakerCreationTime, older, newer, "")).To(BeTrue())
+		Expect(preferProvider(ProviderTieBreakerCreationTime, newer, older, "")).To(BeFalse())
+	})
+
+	ginkgov2.It("falls back to the name for providers created at the same time", func() {
+		same := providerRank{name: "default/c", created: now}
+		Expect(preferProvider(ProviderTieBreakerCreationTime, newer, same, "")).To(BeTrue())
+		Expect(preferProvider(ProviderTieBreakerCreationTime, same, newer, "")).To(BeFalse())
+	})
+})
@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+)
+
+const tracerName = "github.com/gardener/external-dns-management/pkg/dns/provider"
+
+var (
+	tracerLock     sync.RWMutex
+	tracerProvider trace.TracerProvider
+)
+
+// SetTracerProvider sets the tracer provider used for the spans of change request executions.
+// If not set, the global OpenTelemetry tracer provider is used, which is a no-op unless configured.
+// It is set by the controller if the option tracing-endpoint is given.
+func SetTracerProvider(tp trace.TracerProvider) {
+	tracerLock.Lock()
+	defer tracerLock.Unlock()
+	tracerProvider = tp
+}
+
+// startTracing sets the tracer provider exporting the spans to the OTLP/HTTP endpoint given by the controller option.
+// Pending spans are flushed when the controller is stopped.
+func (this *state) startTracing() error {
+	ctx := this.context.GetContext()
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(this.config.TracingEndpoint))
+	if err != nil {
+		return err
+	}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", "dns-controller-manager"))),
+	)
+	SetTracerProvider(tp)
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := tp.Shutdown(shutdownCtx); err != nil {
+			this.context.Warnf("shutdown of tracer provider failed: %s", err)
+		}
+	}()
+	return nil
+}
+
+func getTracer() trace.Tracer {
+	tracerLock.RLock()
+	defer tracerLock.RUnlock()
+	if tracerProvider != nil {
+		return tracerProvider.Tracer(tracerName)
+	}
+	return otel.Tracer(tracerName)
+}
+
+// traceExecuteRequests wraps the execution of change requests for a zone in a tracing span.
+func traceExecuteRequests(zone DNSHostedZone, reqs []*ChangeRequest, execute func() error) error {
+	additions, updates, deletions := 0, 0, 0
+	for _, r := range reqs {
+		switch r.Action {
+		case R_CREATE:
+			additions++
+		case R_UPDATE:
+			updates++
+		case R_DELETE:
+			deletions++
+		}
+	}
+	_, span := getTracer().Start(context.Background(), "ExecuteRequests", trace.WithAttributes(
+		attribute.String("dns.zone.id", zone.Id().ID),
+		attribute.String("dns.provider.type", zone.Id().ProviderType),
+		attribute.Int("dns.changes.additions", additions),
+		attribute.Int("dns.changes.updates", updates),
+		attribute.Int("dns.changes.deletions", deletions),
+	))
+	defer span.End()
+
+	err := execute()
+	outcome := "succeeded"
+	if err != nil {
+		outcome = "failed"
+		if perrs.IsThrottlingError(err) {
+			outcome = "throttled"
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.SetAttributes(attribute.String("dns.changes.outcome", outcome))
+	return err
+}
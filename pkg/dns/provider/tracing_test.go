@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+)
+
+type testTracerProvider struct {
+	noop.TracerProvider
+	spans []*testSpan
+}
+
+func (p *testTracerProvider) Tracer(_ string, _ ...trace.TracerOption) trace.Tracer {
+	return &testTracer{provider: p}
+}
+
+type testTracer struct {
+	noop.Tracer
+	provider *testTracerProvider
+}
+
+func (t *testTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	span := &testSpan{name: name, attributes: map[attribute.Key]attribute.Value{}}
+	cfg := trace.NewSpanStartConfig(opts...)
+	span.SetAttributes(cfg.Attributes()...)
+	t.provider.spans = append(t.provider.spans, span)
+	return ctx, span
+}
+
+type testSpan struct {
+	noop.Span
+	name       string
+	attributes map[attribute.Key]attribute.Value
+	status     codes.Code
+	ended      bool
+}
+
+func (s *testSpan) SetAttributes(kv ...attribute.KeyValue) {
+	for _, a := range kv {
+		s.attributes[a.Key] = a.Value
+	}
+}
+
+func (s *testSpan) SetStatus(code codes.Code, _ string) { s.status = code }
+func (s *testSpan) End(_ ...trace.SpanEndOption)        { s.ended = true }
+
+var _ = ginkgov2.Describe("Tracing of change request execution", func() {
+	var tp *testTracerProvider
+
+	ginkgov2.BeforeEach(func() {
+		tp = &testTracerProvider{}
+		SetTracerProvider(tp)
+	})
+
+	ginkgov2.AfterEach(func() {
+		SetTracerProvider(nil)
+	})
+
+	zone := NewDNSHostedZone("test", "z1", "example.com", "", false)
+	set := newTestDNSSet("a.example.com", "1.1.1.1")
+	reqs := []*ChangeRequest{
+		NewChangeRequest(R_CREATE, dns.RS_A, nil, set, nil),
+		NewChangeRequest(R_CREATE, dns.RS_AAAA, nil, set, nil),
+		NewChangeRequest(R_UPDATE, dns.RS_TXT, set, set, nil),
+		NewChangeRequest(R_DELETE, dns.RS_CNAME, set, nil, nil),
+	}
+
+	ginkgov2.It("creates a span with zone and change attributes", func() {
+		Expect(traceExecuteRequests(zone, reqs, func() error { return nil })).To(Succeed())
+		Expect(tp.spans).To(HaveLen(1))
+		span := tp.spans[0]
+		Expect(span.name).To(Equal("ExecuteRequests"))
+		Expect(span.ended).To(BeTrue())
+		Expect(span.status).To(Equal(codes.Unset))
+		Expect(span.attributes).To(Equal(map[attribute.Key]attribute.Value{
+			"dns.zone.id":           attribute.StringValue("z1"),
+			"dns.provider.type":     attribute.StringValue("test"),
+			"dns.changes.additions": attribute.IntValue(2),
+			"dns.changes.updates":   attribute.IntValue(1),
+			"dns.changes.deletions": attribute.IntValue(1),
+			"dns.changes.outcome":   attribute.StringValue("succeeded"),
+		}))
+	})
+
+	ginkgov2.It("records the outcome of failed executions", func() {
+		err := perrs.NewThrottlingError(fmt.Errorf("rate exceeded"))
+		Expect(traceExecuteRequests(zone, reqs, func() error { return err })).To(Equal(err))
+		Expect(tp.spans).To(HaveLen(1))
+		Expect(tp.spans[0].status).To(Equal(codes.Error))
+		Expect(tp.spans[0].attributes["dns.changes.outcome"]).To(Equal(attribute.StringValue("throttled")))
+	})
+})
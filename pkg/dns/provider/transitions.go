@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+)
+
+// StateTransitionLogName is the logger name of the structured log lines for DNS entry state transitions.
+const StateTransitionLogName = "entry-state-transition"
+
+// StateTransition is the structured log record of a state transition of a DNS entry.
+type StateTransition struct {
+	Level    string `json:"level"`
+	Time     string `json:"ts"`
+	Logger   string `json:"logger"`
+	Msg      string `json:"msg"`
+	Entry    string `json:"entry"`
+	OldState string `json:"oldState"`
+	NewState string `json:"newState"`
+	Provider string `json:"provider,omitempty"`
+	Zone     string `json:"zone,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// StateTransitionLog writes DNS entry state transitions as JSON lines, independent of the log level.
+type StateTransitionLog struct {
+	lock sync.Mutex
+	out  io.Writer
+}
+
+var stateTransitionLog atomic.Pointer[StateTransitionLog]
+
+// EnableStateTransitionLog enables writing the state transitions of all DNS entries to the given writer.
+func EnableStateTransitionLog(out io.Writer) {
+	stateTransitionLog.Store(&StateTransitionLog{out: out})
+}
+
+func (this *StateTransitionLog) write(t *StateTransition) {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	_, _ = this.out.Write(append(data, '\n'))
+}
+
+// stateTransition records the state of a DNS entry before a status modification.
+type stateTransition struct {
+	oldState string
+	status   *api.DNSEntryStatus
+	entry    string
+}
+
+// newStateTransition returns a recorder for a state transition of the given entry status,
+// or nil if the state transition log is not enabled.
+func newStateTransition(entry resources.ObjectName, status *api.DNSEntryStatus) *stateTransition {
+	if stateTransitionLog.Load() == nil {
+		return nil
+	}
+	return &stateTransition{oldState: status.State, status: status, entry: entry.String()}
+}
+
+// log writes the state transition if the state has been changed.
+// It must be called after the status has been updated successfully.
+func (this *stateTransition) log() {
+	if this == nil || this.oldState == this.status.State {
+		return
+	}
+	out := stateTransitionLog.Load()
+	if out == nil {
+		return
+	}
+	out.write(&StateTransition{
+		Level:    "info",
+		Time:     time.Now().UTC().Format(time.RFC3339Nano),
+		Logger:   StateTransitionLogName,
+		Msg:      "entry state transition",
+		Entry:    this.entry,
+		OldState: this.oldState,
+		NewState: this.status.State,
+		Provider: utils.StringValue(this.status.Provider),
+		Zone:     utils.StringValue(this.status.Zone),
+		Reason:   utils.StringValue(this.status.Message),
+	})
+}
@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+)
+
+var _ = ginkgov2.Describe("State transition log", func() {
+	var (
+		out  *bytes.Buffer
+		name = resources.NewObjectName("test", "entry")
+	)
+
+	ginkgov2.BeforeEach(func() {
+		out = &bytes.Buffer{}
+		EnableStateTransitionLog(out)
+	})
+
+	ginkgov2.AfterEach(func() {
+		stateTransitionLog.Store(nil)
+	})
+
+	ginkgov2.It("writes a JSON line for a changed state", func() {
+		status := &api.DNSEntryStatus{State: api.STATE_READY}
+		transition := newStateTransition(name, status)
+		status.State = api.STATE_STALE
+		status.Provider = ptr.To("test/provider")
+		status.Zone = ptr.To("Z1")
+		status.Message = ptr.To("provider not valid")
+		transition.log()
+
+		record := &StateTransition{}
+		Expect(json.Unmarshal(out.Bytes(), record)).To(Succeed())
+		Expect(record.Logger).To(Equal(StateTransitionLogName))
+		Expect(record.Entry).To(Equal("test/entry"))
+		Expect(record.OldState).To(Equal(api.STATE_READY))
+		Expect(record.NewState).To(Equal(api.STATE_STALE))
+		Expect(record.Provider).To(Equal("test/provider"))
+		Expect(record.Zone).To(Equal("Z1"))
+		Expect(record.Reason).To(Equal("provider not valid"))
+	})
+
+	ginkgov2.It("writes nothing if the state is unchanged", func() {
+		status := &api.DNSEntryStatus{State: api.STATE_READY}
+		transition := newStateTransition(name, status)
+		status.Message = ptr.To("other message")
+		transition.log()
+		Expect(out.Len()).To(Equal(0))
+	})
+
+	ginkgov2.It("is disabled by default", func() {
+		stateTransitionLog.Store(nil)
+		Expect(newStateTransition(name, &api.DNSEntryStatus{})).To(BeNil())
+	})
+})
@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+// chunkTXTRecords returns the change requests with the TXT record values split into character strings
+// of at most chunkSize bytes. Requests for other record types are kept.
+func chunkTXTRecords(reqs []*ChangeRequest, chunkSize int) []*ChangeRequest {
+	result := make([]*ChangeRequest, len(reqs))
+	for i, r := range reqs {
+		if r.Type != dns.RS_TXT {
+			result[i] = r
+			continue
+		}
+		c := *r
+		c.Addition = mapTXTRecords(r.Addition, func(v string) string { return dns.SplitTXTValue(v, chunkSize) })
+		c.Deletion = mapTXTRecords(r.Deletion, func(v string) string { return dns.SplitTXTValue(v, chunkSize) })
+		c.Done = &applyingDoneHandler{changeRequest: &c, inner: r.Done}
+		result[i] = &c
+	}
+	return result
+}
+
+// joinTXTRecords returns the DNS set with the TXT record values consisting of multiple character strings
+// reassembled to single strings.
+func joinTXTRecords(set *dns.DNSSet) *dns.DNSSet {
+	return mapTXTRecords(set, dns.JoinTXTValue)
+}
+
+// mapTXTRecords returns a copy of the DNS set with mapped TXT record values if any value is changed.
+func mapTXTRecords(set *dns.DNSSet, mapValue func(string) string) *dns.DNSSet {
+	if set == nil || set.Sets[dns.RS_TXT] == nil {
+		return set
+	}
+	var result *dns.DNSSet
+	for i, r := range set.Sets[dns.RS_TXT].Records {
+		value := mapValue(r.Value)
+		if value == r.Value {
+			continue
+		}
+		if result == nil {
+			result = set.Clone()
+		}
+		result.Sets[dns.RS_TXT].Records[i].Value = value
+	}
+	if result == nil {
+		return set
+	}
+	return result
+}
@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"strconv"
+	"strings"
+
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+type txtTestProvider struct {
+	DNSProvider
+	limits dns.TXTRecordLimits
+}
+
+func (p *txtTestProvider) TypeCode() string                     { return "test" }
+func (p *txtTestProvider) TXTRecordLimits() dns.TXTRecordLimits { return p.limits }
+
+func newTestTXTSet(name string, values ...string) *dns.DNSSet {
+	set := dns.NewDNSSet(dns.DNSSetName{DNSName: name}, nil)
+	var records []*dns.Record
+	for _, v := range values {
+		records = append(records, &dns.Record{Value: v})
+	}
+	set.Sets[dns.RS_TXT] = dns.NewRecordSet(dns.RS_TXT, 300, records)
+	return set
+}
+
+var _ = ginkgov2.Describe("TXT record chunking", func() {
+	long := strings.Repeat("x", 300)
+	quoted := strconv.Quote(long)
+	chunked := strconv.Quote(long[:255]) + " " + strconv.Quote(long[255:])
+
+	ginkgov2.It("splits long texts of change requests without modifying the original requests", func() {
+		done := &testBatchDoneHandler{}
+		txt := NewChangeRequest(R_UPDATE, dns.RS_TXT, newTestTXTSet("a.example.com", quoted), newTestTXTSet("a.example.com", `"short"`, quoted), done)
+		a := NewChangeRequest(R_CREATE, dns.RS_A, nil, newTestDNSSet("b.example.com", "1.1.1.1"), done)
+
+		result := chunkTXTRecords([]*ChangeRequest{txt, a}, 255)
+		Expect(result).To(HaveLen(2))
+		Expect(result[1]).To(BeIdenticalTo(a))
+		Expect(result[0]).NotTo(BeIdenticalTo(txt))
+		Expect(result[0].Addition.Sets[dns.RS_TXT].Records[0].Value).To(Equal(`"short"`))
+		Expect(result[0].Addition.Sets[dns.RS_TXT].Records[1].Value).To(Equal(chunked))
+		Expect(result[0].Deletion.Sets[dns.RS_TXT].Records[0].Value).To(Equal(chunked))
+		Expect(txt.Addition.Sets[dns.RS_TXT].Records[1].Value).To(Equal(quoted))
+
+		result[0].Done.Succeeded()
+		Expect(result[0].Applied).To(BeTrue())
+		Expect(done.succeeded).To(Equal(1))
+	})
+
+	ginkgov2.It("reassembles chunked texts read from the provider", func() {
+		set := newTestTXTSet("a.example.com", chunked, `"short"`)
+		joined := joinTXTRecords(set)
+		Expect(joined.Sets[dns.RS_TXT].Records[0].Value).To(Equal(quoted))
+		Expect(joined.Sets[dns.RS_TXT].Records[1].Value).To(Equal(`"short"`))
+		Expect(set.Sets[dns.RS_TXT].Records[0].Value).To(Equal(chunked))
+
+		unchanged := newTestTXTSet("a.example.com", quoted)
+		Expect(joinTXTRecords(unchanged)).To(BeIdenticalTo(unchanged))
+	})
+
+	ginkgov2.It("validates text lengths against the provider maximum", func() {
+		p := &txtTestProvider{limits: dns.TXTRecordLimits{ChunkSize: 255, MaxLength: 300}}
+		Expect(validateTextLengths(p, Targets{dnsutils.NewText(long, 300)})).To(Succeed())
+		Expect(validateTextLengths(p, Targets{dnsutils.NewText(long+"y", 300)})).To(MatchError(ContainSubstring("exceeds maximum length 300")))
+		Expect(validateTextLengths(&txtTestProvider{}, Targets{dnsutils.NewText(long+"y", 300)})).To(Succeed())
+		Expect(validateTextLengths(nil, Targets{dnsutils.NewText(long+"y", 300)})).To(Succeed())
+	})
+})
@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+)
+
+// ProviderTypeDefaults contains the defaults for all DNS providers of a provider type.
+// They are used if the corresponding field is not set in the spec of a DNS provider.
+type ProviderTypeDefaults struct {
+	// DefaultTTL is the default TTL used for DNS entries if not specified explicitly.
+	DefaultTTL *int64 `json:"defaultTTL,omitempty"`
+	// RateLimit is the rate limit for create/update operations on DNS entries assigned to a provider.
+	RateLimit *api.RateLimit `json:"rateLimit,omitempty"`
+	// MaxConcurrentRequests is the maximum number of concurrent requests to the DNS backend of a provider account.
+	MaxConcurrentRequests *int `json:"maxConcurrentRequests,omitempty"`
+	// ZoneStateCacheTTL is the TTL for the zone state cache of the hosted zones of the provider type.
+	// It is overwritten by the zone state cache TTL of a DNSHostedZonePolicy.
+	ZoneStateCacheTTL *metav1.Duration `json:"zoneStateCacheTTL,omitempty"`
+}
+
+// ProviderTypeDefaultsMap maps provider type codes to their defaults.
+type ProviderTypeDefaultsMap map[string]*ProviderTypeDefaults
+
+// Get returns the defaults for the given provider type. The result is never nil.
+func (this ProviderTypeDefaultsMap) Get(typecode string) *ProviderTypeDefaults {
+	if d := this[typecode]; d != nil {
+		return d
+	}
+	return &ProviderTypeDefaults{}
+}
+
+// LoadProviderTypeDefaults reads the provider type defaults from a YAML or JSON file.
+// The keys of the file content are the provider types, which must be contained in the given set of known types.
+func LoadProviderTypeDefaults(filename string, known utils.StringSet) (ProviderTypeDefaultsMap, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	defaults := ProviderTypeDefaultsMap{}
+	if err := yaml.UnmarshalStrict(data, &defaults); err != nil {
+		return nil, fmt.Errorf("invalid provider type defaults in %s: %w", filename, err)
+	}
+	for typecode, d := range defaults {
+		if !known.Contains(typecode) {
+			return nil, fmt.Errorf("invalid provider type defaults in %s: unknown provider type %q", filename, typecode)
+		}
+		if err := d.validate(); err != nil {
+			return nil, fmt.Errorf("invalid provider type defaults for %s in %s: %w", typecode, filename, err)
+		}
+	}
+	return defaults, nil
+}
+
+func (this *ProviderTypeDefaults) validate() error {
+	if this == nil {
+		return nil
+	}
+	if this.DefaultTTL != nil && *this.DefaultTTL <= 0 {
+		return fmt.Errorf("defaultTTL must be positive")
+	}
+	if this.RateLimit != nil && (this.RateLimit.RequestsPerDay <= 0 || this.RateLimit.Burst < 0) {
+		return fmt.Errorf("rateLimit requires positive requestsPerDay and a non-negative burst")
+	}
+	if this.MaxConcurrentRequests != nil && *this.MaxConcurrentRequests <= 0 {
+		return fmt.Errorf("maxConcurrentRequests must be positive")
+	}
+	if this.ZoneStateCacheTTL != nil && this.ZoneStateCacheTTL.Duration <= 0 {
+		return fmt.Errorf("zoneStateCacheTTL must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = ginkgov2.Describe("Provider type defaults", func() {
+	known := utils.NewStringSet("aws-route53", "google-clouddns")
+
+	load := func(content string) (ProviderTypeDefaultsMap, error) {
+		filename := filepath.Join(ginkgov2.GinkgoT().TempDir(), "defaults.yaml")
+		Expect(os.WriteFile(filename, []byte(content), 0o600)).To(Succeed())
+		return LoadProviderTypeDefaults(filename, known)
+	}
+
+	ginkgov2.It("loads defaults per provider type", func() {
+		defaults, err := load(`
+aws-route53:
+  defaultTTL: 600
+  rateLimit:
+    requestsPerDay: 100
+    burst: 5
+  maxConcurrentRequests: 2
+  zoneStateCacheTTL: 5m
+google-clouddns:
+  defaultTTL: 120
+`)
+		Expect(err).NotTo(HaveOccurred())
+		aws := defaults.Get("aws-route53")
+		Expect(*aws.DefaultTTL).To(Equal(int64(600)))
+		Expect(aws.RateLimit.RequestsPerDay).To(Equal(100))
+		Expect(aws.RateLimit.Burst).To(Equal(5))
+		Expect(*aws.MaxConcurrentRequests).To(Equal(2))
+		Expect(aws.ZoneStateCacheTTL.Duration).To(Equal(5 * time.Minute))
+		google := defaults.Get("google-clouddns")
+		Expect(*google.DefaultTTL).To(Equal(int64(120)))
+		Expect(google.RateLimit).To(BeNil())
+	})
+
+	ginkgov2.It("returns empty defaults for unconfigured types", func() {
+		var defaults ProviderTypeDefaultsMap
+		Expect(defaults.Get("aws-route53")).To(Equal(&ProviderTypeDefaults{}))
+	})
+
+	ginkgov2.It("rejects unknown provider types", func() {
+		_, err := load("azure-dns:\n  defaultTTL: 60\n")
+		Expect(err).To(MatchError(ContainSubstring(`unknown provider type "azure-dns"`)))
+	})
+
+	ginkgov2.It("rejects unknown fields", func() {
+		_, err := load("aws-route53:\n  ttl: 60\n")
+		Expect(err).To(HaveOccurred())
+	})
+
+	ginkgov2.It("rejects invalid values", func() {
+		_, err := load("aws-route53:\n  maxConcurrentRequests: 0\n")
+		Expect(err).To(MatchError(ContainSubstring("maxConcurrentRequests must be positive")))
+	})
+})
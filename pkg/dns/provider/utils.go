@@ -49,6 +49,27 @@ func filterZoneByProvider(zones []*dnsHostedZone, provider DNSProvider) *dnsHost
 	return nil
 }
 
+func assureZoneRecordSets(mod *resources.ModificationState, t *[]api.ZoneRecordSetCount, s []api.ZoneRecordSetCount) {
+	if !reflect.DeepEqual(*t, s) {
+		*t = s
+		mod.Modify(true)
+	}
+}
+
+func assureMigrationStatus(mod *resources.ModificationState, t **api.DNSProviderMigrationStatus, s *api.DNSProviderMigrationStatus) {
+	if !reflect.DeepEqual(*t, s) {
+		*t = s
+		mod.Modify(true)
+	}
+}
+
+func assureMirrorStatus(mod *resources.ModificationState, t **api.DNSProviderMirrorStatus, s *api.DNSProviderMirrorStatus) {
+	if !reflect.DeepEqual(*t, s) {
+		*t = s
+		mod.Modify(true)
+	}
+}
+
 func assureRateLimit(mod *resources.ModificationState, t **api.RateLimit, s *api.RateLimit) {
 	if s == nil && *t != nil {
 		*t = nil
@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+// normalizeWeightedPolicies maps routing policies with percentage weights to routing policies with weights
+// in the scale of the provider. The percentages are normalized over all record sets of the same DNS name.
+// Policies without percentage weights are not included in the result.
+func normalizeWeightedPolicies(policies map[dns.DNSSetName]*dns.RoutingPolicy, maxWeight func(dnsName string) int64) map[dns.DNSSetName]*dns.RoutingPolicy {
+	siblings := map[string][]dns.DNSSetName{}
+	percentages := map[dns.DNSSetName]float64{}
+	for name, policy := range policies {
+		if p, ok, err := policy.PercentageWeight(); ok && err == nil {
+			siblings[name.DNSName] = append(siblings[name.DNSName], name)
+			percentages[name] = p
+		}
+	}
+
+	result := map[dns.DNSSetName]*dns.RoutingPolicy{}
+	for dnsName, names := range siblings {
+		sort.Slice(names, func(i, j int) bool { return names[i].SetIdentifier < names[j].SetIdentifier })
+		values := make([]float64, len(names))
+		for i, name := range names {
+			values[i] = percentages[name]
+		}
+		for i, weight := range dns.NormalizeWeights(values, maxWeight(dnsName)) {
+			policy := policies[names[i]].Clone()
+			policy.Parameters[dns.RoutingPolicyParameterWeight] = strconv.FormatInt(weight, 10)
+			result[names[i]] = policy
+		}
+	}
+	return result
+}
+
+// normalizedWeights returns the routing policies with normalized percentage weights of the given entries.
+// Entries being deleted are not considered as siblings.
+func (this *ChangeModel) normalizedWeights(entries ...Entries) map[dns.DNSSetName]*dns.RoutingPolicy {
+	policies := map[dns.DNSSetName]*dns.RoutingPolicy{}
+	for _, list := range entries {
+		for _, e := range list {
+			if !e.IsDeleting() && e.RoutingPolicy() != nil {
+				policies[e.DNSSetName()] = e.RoutingPolicy()
+			}
+		}
+	}
+	return normalizeWeightedPolicies(policies, func(dnsName string) int64 {
+		if p := this.context.providers.LookupFor(dnsName); p != nil {
+			return p.MaxWeight()
+		}
+		return dns.DefaultMaxWeight
+	})
+}
+
+// normalizedWeightSpec is a target spec with a routing policy using a normalized weight.
+type normalizedWeightSpec struct {
+	TargetSpec
+	policy *dns.RoutingPolicy
+}
+
+func (this *normalizedWeightSpec) RoutingPolicy() *dns.RoutingPolicy {
+	return this.policy
+}
+
+// withNormalizedWeight returns the target spec with the normalized routing policy of the DNS set if available.
+func withNormalizedWeight(spec TargetSpec, name dns.DNSSetName, normalized map[dns.DNSSetName]*dns.RoutingPolicy) TargetSpec {
+	if policy := normalized[name]; policy != nil {
+		return &normalizedWeightSpec{TargetSpec: spec, policy: policy}
+	}
+	return spec
+}
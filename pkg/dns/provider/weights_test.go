@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+var _ = ginkgov2.Describe("Weight normalization", func() {
+	setName := func(dnsName, id string) dns.DNSSetName {
+		return dns.DNSSetName{DNSName: dnsName, SetIdentifier: id}
+	}
+	weighted := func(weight string) *dns.RoutingPolicy {
+		return dns.NewRoutingPolicy(dns.RoutingPolicyWeighted, "weight", weight)
+	}
+	scale := func(maxWeight int64) func(string) int64 {
+		return func(string) int64 { return maxWeight }
+	}
+
+	ginkgov2.It("normalizes percentages of siblings to the weight scale of the provider", func() {
+		policies := map[dns.DNSSetName]*dns.RoutingPolicy{
+			setName("a.example.com", "blue"):  weighted("10%"),
+			setName("a.example.com", "green"): weighted("30%"),
+			setName("b.example.com", "blue"):  weighted("100%"),
+		}
+		result := normalizeWeightedPolicies(policies, scale(255))
+		Expect(result).To(HaveLen(3))
+		Expect(result[setName("a.example.com", "blue")].Parameters).To(HaveKeyWithValue("weight", "64"))
+		Expect(result[setName("a.example.com", "green")].Parameters).To(HaveKeyWithValue("weight", "191"))
+		Expect(result[setName("b.example.com", "blue")].Parameters).To(HaveKeyWithValue("weight", "255"))
+		Expect(policies[setName("a.example.com", "blue")].Parameters).To(HaveKeyWithValue("weight", "10%"))
+	})
+
+	ginkgov2.It("keeps absolute weights and other policies", func() {
+		policies := map[dns.DNSSetName]*dns.RoutingPolicy{
+			setName("a.example.com", "blue"):  weighted("50%"),
+			setName("a.example.com", "green"): weighted("20"),
+			setName("b.example.com", "eu"):    dns.NewRoutingPolicy(dns.RoutingPolicyLatency, "region", "eu-west-1"),
+			setName("c.example.com", "blue"):  weighted("invalid%"),
+		}
+		result := normalizeWeightedPolicies(policies, scale(100))
+		Expect(result).To(HaveLen(1))
+		Expect(result[setName("a.example.com", "blue")].Parameters).To(HaveKeyWithValue("weight", "100"))
+	})
+
+	ginkgov2.It("recomputes weights if siblings change", func() {
+		policies := map[dns.DNSSetName]*dns.RoutingPolicy{
+			setName("a.example.com", "blue"):  weighted("50%"),
+			setName("a.example.com", "green"): weighted("50%"),
+		}
+		Expect(normalizeWeightedPolicies(policies, scale(100))[setName("a.example.com", "blue")].Parameters).To(HaveKeyWithValue("weight", "50"))
+		delete(policies, setName("a.example.com", "green"))
+		Expect(normalizeWeightedPolicies(policies, scale(100))[setName("a.example.com", "blue")].Parameters).To(HaveKeyWithValue("weight", "100"))
+	})
+})
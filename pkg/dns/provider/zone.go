@@ -15,6 +15,15 @@ import (
 	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
 )
 
+const (
+	// throttleBackoffMin is the initial delay for reconciling a zone after the provider API has throttled requests.
+	throttleBackoffMin = 5 * time.Second
+	// throttleBackoffMax is the maximum delay for reconciling a zone after the provider API has throttled requests.
+	throttleBackoffMax = 5 * time.Minute
+	// conflictRetryDelay is the delay for reconciling a zone again after a conditional update has been rejected.
+	conflictRetryDelay = 5 * time.Second
+)
+
 type dnsHostedZones map[dns.ZoneID]*dnsHostedZone
 
 type dnsHostedZone struct {
@@ -24,15 +33,19 @@ type dnsHostedZone struct {
 	zone        DNSHostedZone
 	next        time.Time
 	nextTrigger time.Duration
+	immediate   time.Time
 	owners      utils.StringSet
 	policy      *dnsHostedZonePolicy
+
+	throttleBackoff *dnsutils.Backoff
 }
 
 func newDNSHostedZone(min time.Duration, zone DNSHostedZone) *dnsHostedZone {
 	return &dnsHostedZone{
-		zone:        zone,
-		RateLimiter: dnsutils.NewRateLimiter(min, 10*time.Minute),
-		owners:      utils.StringSet{},
+		zone:            zone,
+		RateLimiter:     dnsutils.NewRateLimiter(min, 10*time.Minute),
+		owners:          utils.StringSet{},
+		throttleBackoff: dnsutils.NewBackoff(throttleBackoffMin, throttleBackoffMax),
 	}
 }
 
@@ -100,6 +113,17 @@ func (this *dnsHostedZone) IntersectOwners(owners utils.StringSet) utils.StringS
 	return this.owners.Intersect(owners)
 }
 
+// Throttled increases the throttling backoff of the zone and returns the delay
+// until the next reconciliation and the number of throttled attempts.
+func (this *dnsHostedZone) Throttled() (time.Duration, int) {
+	return this.throttleBackoff.Next()
+}
+
+// ResetThrottling resets the throttling backoff of the zone.
+func (this *dnsHostedZone) ResetThrottling() {
+	this.throttleBackoff.Reset()
+}
+
 func (this *dnsHostedZone) GetNext() time.Time {
 	this.lock.Lock()
 	defer this.lock.Unlock()
@@ -112,6 +136,24 @@ func (this *dnsHostedZone) SetNext(next time.Time) {
 	this.next = next
 }
 
+// AcceptImmediateReconciliation removes the delay until the next reconciliation of the zone.
+// To protect the provider backend, it is only accepted once per given interval and not while
+// the zone is throttled by the provider API.
+func (this *dnsHostedZone) AcceptImmediateReconciliation(interval time.Duration) bool {
+	if this.throttleBackoff.Attempts() > 0 {
+		return false
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	now := time.Now()
+	if now.Before(this.immediate.Add(interval)) {
+		return false
+	}
+	this.immediate = now
+	this.next = now
+	return true
+}
+
 func (this *dnsHostedZone) Policy() *dnsHostedZonePolicy {
 	this.lock.Lock()
 	defer this.lock.Unlock()
@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"time"
+
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = ginkgov2.Describe("Immediate zone reconciliation", func() {
+	ginkgov2.It("is accepted once per interval", func() {
+		zone := newDNSHostedZone(0, NewDNSHostedZone("test", "z1", "example.com", "", false))
+		zone.SetNext(time.Now().Add(time.Hour))
+		Expect(zone.AcceptImmediateReconciliation(time.Minute)).To(BeTrue())
+		Expect(zone.GetNext()).To(BeTemporally("<=", time.Now()))
+		Expect(zone.AcceptImmediateReconciliation(time.Minute)).To(BeFalse())
+	})
+
+	ginkgov2.It("is accepted again after the interval", func() {
+		zone := newDNSHostedZone(0, NewDNSHostedZone("test", "z1", "example.com", "", false))
+		Expect(zone.AcceptImmediateReconciliation(10 * time.Millisecond)).To(BeTrue())
+		time.Sleep(20 * time.Millisecond)
+		Expect(zone.AcceptImmediateReconciliation(10 * time.Millisecond)).To(BeTrue())
+	})
+
+	ginkgov2.It("is not accepted while the zone is throttled", func() {
+		zone := newDNSHostedZone(0, NewDNSHostedZone("test", "z1", "example.com", "", false))
+		zone.Throttled()
+		next := time.Now().Add(time.Hour)
+		zone.SetNext(next)
+		Expect(zone.AcceptImmediateReconciliation(time.Minute)).To(BeFalse())
+		Expect(zone.GetNext()).To(Equal(next))
+	})
+})
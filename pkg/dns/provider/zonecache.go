@@ -22,16 +22,39 @@ import (
 
 type StateTTLGetter func(zoneid dns.ZoneID) time.Duration
 
+// maxIncrementalRefreshes is the number of incremental refreshes of a cached zone state before the zone state
+// is fetched completely again to correct any deviations.
+const maxIncrementalRefreshes = 10
+
 type ZoneCacheFactory struct {
 	context               context.Context
 	logger                logger.LogContext
 	zonesTTL              time.Duration
 	zoneStates            *zoneStates
 	disableZoneStateCache bool
+	// account is informed about the created zone cache to allow probing the zones bypassing the cache
+	account *DNSAccount
 }
 
 func (c ZoneCacheFactory) CreateZoneCache(cacheType ZoneCacheType, metrics Metrics, zonesUpdater ZoneCacheZoneUpdater, stateUpdater ZoneCacheStateUpdater) (ZoneCache, error) {
-	cache := onlyZonesCache{zonesTTL: c.zonesTTL, logger: c.logger, metrics: metrics, zonesUpdater: zonesUpdater, stateUpdater: stateUpdater}
+	cache, err := c.createZoneCache(cacheType, metrics, zonesUpdater, stateUpdater)
+	if err == nil && c.account != nil {
+		c.account.probeZones = func(ctx context.Context) (DNSHostedZones, error) {
+			return zonesUpdater(ctx, cache)
+		}
+	}
+	return cache, err
+}
+
+func (c ZoneCacheFactory) createZoneCache(cacheType ZoneCacheType, metrics Metrics, zonesUpdater ZoneCacheZoneUpdater, stateUpdater ZoneCacheStateUpdater) (ZoneCache, error) {
+	if c.account != nil {
+		stateUpdater = c.account.guardStateUpdater(stateUpdater)
+	}
+	ctx := c.context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	cache := onlyZonesCache{ctx: ctx, zonesTTL: c.zonesTTL, logger: c.logger, metrics: metrics, zonesUpdater: zonesUpdater, stateUpdater: stateUpdater}
 	switch cacheType {
 	case CacheZonesOnly:
 		return &cache, nil
@@ -39,12 +62,28 @@ func (c ZoneCacheFactory) CreateZoneCache(cacheType ZoneCacheType, metrics Metri
 		if c.disableZoneStateCache {
 			return &cache, nil
 		}
-		return &defaultZoneCache{onlyZonesCache: onlyZonesCache{zonesTTL: c.zonesTTL, logger: c.logger, metrics: metrics, zonesUpdater: zonesUpdater, stateUpdater: stateUpdater}, zoneStates: c.zoneStates}, nil
+		return &defaultZoneCache{onlyZonesCache: onlyZonesCache{ctx: ctx, zonesTTL: c.zonesTTL, logger: c.logger, metrics: metrics, zonesUpdater: zonesUpdater, stateUpdater: stateUpdater}, zoneStates: c.zoneStates}, nil
 	default:
 		return nil, fmt.Errorf("unknown zone cache type: %v", cacheType)
 	}
 }
 
+// CreateIncrementalZoneCache creates a zone cache for zone states, which refreshes expired zone states by
+// applying the record sets changed since the last refresh, if the DNS backend offers a change list.
+// The zone state is fetched completely if the changes cannot be determined.
+func (c ZoneCacheFactory) CreateIncrementalZoneCache(metrics Metrics, zonesUpdater ZoneCacheZoneUpdater, stateUpdater ZoneCacheStateUpdater,
+	changesUpdater ZoneCacheStateChangesUpdater,
+) (ZoneCache, error) {
+	cache, err := c.CreateZoneCache(CacheZoneState, metrics, zonesUpdater, stateUpdater)
+	if defaultCache, ok := cache.(*defaultZoneCache); ok {
+		if c.account != nil {
+			changesUpdater = c.account.guardChangesUpdater(changesUpdater)
+		}
+		defaultCache.changesUpdater = changesUpdater
+	}
+	return cache, err
+}
+
 // ZoneCacheType is the zone cache type.
 type ZoneCacheType int
 
@@ -55,6 +94,17 @@ const (
 	CacheZoneState
 )
 
+// NewZonesOnlyCacheFactory returns a zone cache factory which never caches zone states,
+// e.g. for one-shot usage of a DNS handler outside of the controller.
+func NewZonesOnlyCacheFactory(ctx context.Context, logger logger.LogContext, zonesTTL time.Duration) *ZoneCacheFactory {
+	return &ZoneCacheFactory{
+		context:               ctx,
+		logger:                logger,
+		zonesTTL:              zonesTTL,
+		disableZoneStateCache: true,
+	}
+}
+
 func NewTestZoneCacheFactory(zonesTTL, stateTTL time.Duration) *ZoneCacheFactory {
 	return &ZoneCacheFactory{
 		zonesTTL:   zonesTTL,
@@ -62,10 +112,32 @@ func NewTestZoneCacheFactory(zonesTTL, stateTTL time.Duration) *ZoneCacheFactory
 	}
 }
 
-type ZoneCacheZoneUpdater func(cache ZoneCache) (DNSHostedZones, error)
+// ZoneCacheZoneUpdater lists the hosted zones of the account. The context is canceled if the zones are not needed
+// anymore, e.g. if probing the account times out.
+type ZoneCacheZoneUpdater func(ctx context.Context, cache ZoneCache) (DNSHostedZones, error)
 
 type ZoneCacheStateUpdater func(zone DNSHostedZone, cache ZoneCache) (DNSZoneState, error)
 
+// ZoneCacheStateChangesUpdater returns the record sets changed in the hosted zone since the given change marker.
+// For an empty marker, only the marker of the latest change is returned.
+type ZoneCacheStateChangesUpdater func(zone DNSHostedZone, marker string) (*ZoneStateChanges, error)
+
+// ZoneStateChanges are the changes of a zone state since a change marker.
+type ZoneStateChanges struct {
+	// Marker is the change marker of the latest change.
+	Marker string
+	// Changes are the changed record sets in the order of their modification.
+	Changes []ZoneStateChange
+}
+
+// ZoneStateChange replaces the record sets of a record type of a DNS name for all set identifiers.
+type ZoneStateChange struct {
+	DNSName string
+	Type    string
+	// DNSSets contains the current record sets of the record type (empty if deleted).
+	DNSSets dns.DNSSets
+}
+
 type ZoneCache interface {
 	GetZones() (DNSHostedZones, error)
 	GetZoneState(zone DNSHostedZone) (DNSZoneState, error)
@@ -76,6 +148,7 @@ type ZoneCache interface {
 
 type onlyZonesCache struct {
 	lock         sync.Mutex
+	ctx          context.Context
 	logger       logger.LogContext
 	metrics      Metrics
 	zonesTTL     time.Duration
@@ -94,7 +167,7 @@ func (c *onlyZonesCache) GetZones() (DNSHostedZones, error) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 	if time.Now().After(c.zonesNext) {
-		c.zones, c.zonesErr = c.zonesUpdater(c)
+		c.zones, c.zonesErr = c.zonesUpdater(c.ctx, c)
 		updateTime := time.Now()
 		if c.zonesErr != nil {
 			// if getzones fails, don't wait zonesTTL, but use an exponential backoff
@@ -142,7 +215,8 @@ func (c *onlyZonesCache) Release() {
 
 type defaultZoneCache struct {
 	onlyZonesCache
-	zoneStates *zoneStates
+	zoneStates     *zoneStates
+	changesUpdater ZoneCacheStateChangesUpdater
 }
 
 var _ ZoneCache = &defaultZoneCache{}
@@ -194,6 +268,9 @@ type zoneStateProxy struct {
 	lock            sync.Mutex
 	lastUpdateStart time.Time
 	lastUpdateEnd   time.Time
+	// marker is the change marker of the cached zone state for incremental refreshes.
+	marker               string
+	incrementalRefreshes int
 }
 
 type zoneStates struct {
@@ -232,11 +309,21 @@ func (s *zoneStates) GetZoneState(zone DNSHostedZone, cache *defaultZoneCache) (
 	start := time.Now()
 	ttl := s.stateTTLGetter(zone.Id())
 	if start.After(proxy.lastUpdateEnd.Add(ttl)) {
+		if state, ok := s.refreshZoneState(zone, cache, proxy); ok {
+			proxy.lastUpdateStart = start
+			proxy.lastUpdateEnd = time.Now()
+			return state, false, nil
+		}
+		metrics.ReportZoneStateCacheMiss(zone.Id())
+		marker := s.getChangeMarker(zone, cache)
 		state, err := cache.stateUpdater(zone, cache)
 		if err == nil {
 			proxy.lastUpdateStart = start
 			proxy.lastUpdateEnd = time.Now()
+			proxy.marker = marker
+			proxy.incrementalRefreshes = 0
 			s.inMemory.SetZone(zone, state)
+			metrics.ReportZoneStateCacheEntries(zone.Id(), len(state.GetDNSSets()))
 		} else {
 			s.cleanZoneState(zone.Id(), proxy)
 		}
@@ -247,14 +334,62 @@ func (s *zoneStates) GetZoneState(zone DNSHostedZone, cache *defaultZoneCache) (
 	if err != nil {
 		return nil, true, err
 	}
+	metrics.ReportZoneStateCacheHit(zone.Id())
 	return state, true, nil
 }
 
+// getChangeMarker returns the marker of the latest change of the zone, or an empty string if
+// incremental refreshes are not supported. It is determined before fetching the complete zone state,
+// so that changes made in the meantime are applied again on the next incremental refresh.
+func (s *zoneStates) getChangeMarker(zone DNSHostedZone, cache *defaultZoneCache) string {
+	if cache.changesUpdater == nil {
+		return ""
+	}
+	changes, err := cache.changesUpdater(zone, "")
+	if err != nil {
+		logger.Infof("cannot determine change marker of zone %s: %s", zone.Id(), err)
+		return ""
+	}
+	return changes.Marker
+}
+
+// refreshZoneState applies the record sets changed since the last refresh to the cached zone state.
+// It returns false if the zone state must be fetched completely.
+func (s *zoneStates) refreshZoneState(zone DNSHostedZone, cache *defaultZoneCache, proxy *zoneStateProxy) (DNSZoneState, bool) {
+	if cache.changesUpdater == nil || proxy.marker == "" || proxy.incrementalRefreshes >= maxIncrementalRefreshes {
+		return nil, false
+	}
+	if s.inMemory.FindHostedZone(zone.Id()) == nil {
+		return nil, false
+	}
+	changes, err := cache.changesUpdater(zone, proxy.marker)
+	if err != nil {
+		logger.Infof("incremental refresh of zone state %s failed, fetching complete zone state: %s", zone.Id(), err)
+		return nil, false
+	}
+	if err := s.inMemory.ApplyZoneStateChanges(zone.Id(), changes.Changes); err != nil {
+		return nil, false
+	}
+	state, err := s.inMemory.CloneZoneState(zone)
+	if err != nil {
+		return nil, false
+	}
+	proxy.marker = changes.Marker
+	proxy.incrementalRefreshes++
+	metrics.ReportZoneStateCacheIncrementalRefresh(zone.Id())
+	metrics.ReportZoneStateCacheEntries(zone.Id(), len(state.GetDNSSets()))
+	return state, true
+}
+
 func (s *zoneStates) ReportZoneStateConflict(zoneID dns.ZoneID, err error) bool {
 	proxy := s.getProxy(zoneID)
 	proxy.lock.Lock()
 	defer proxy.lock.Unlock()
 
+	if errors.IsStaleZoneState(err) {
+		s.cleanZoneState(zoneID, proxy)
+		return true
+	}
 	if !proxy.lastUpdateStart.IsZero() {
 		ownerConflict, ok := err.(*errors.AlreadyBusyForOwner)
 		if ok {
@@ -279,6 +414,11 @@ func (s *zoneStates) ExecuteRequests(zoneID dns.ZoneID, reqs []*ChangeRequest) {
 	nullMetrics := &NullMetrics{}
 	for _, req := range reqs {
 		if req.Applied {
+			if req.lostETag() {
+				// the cached record set would allow unconditional changes
+				err = fmt.Errorf("new etag of %s record set %s unknown", req.Type, req.Addition.Name)
+				break
+			}
 			err = s.inMemory.Apply(zoneID, req, nullMetrics)
 			if err != nil {
 				break
@@ -288,6 +428,8 @@ func (s *zoneStates) ExecuteRequests(zoneID dns.ZoneID, reqs []*ChangeRequest) {
 
 	if err != nil {
 		s.cleanZoneState(zoneID, proxy)
+	} else {
+		metrics.ReportZoneStateCacheEntries(zoneID, s.inMemory.GetDNSSetCount(zoneID))
 	}
 }
 
@@ -300,11 +442,16 @@ func (s *zoneStates) CleanZoneState(zoneID dns.ZoneID) {
 }
 
 func (s *zoneStates) cleanZoneState(zoneID dns.ZoneID, proxy *zoneStateProxy) {
+	if s.inMemory.FindHostedZone(zoneID) != nil {
+		metrics.ReportZoneStateCacheEviction(zoneID)
+		metrics.ReportZoneStateCacheEntries(zoneID, 0)
+	}
 	s.inMemory.DeleteZone(zoneID)
 	if proxy != nil {
 		var zero time.Time
 		proxy.lastUpdateStart = zero
 		proxy.lastUpdateEnd = zero
+		proxy.marker = ""
 	}
 }
 
@@ -340,6 +487,7 @@ func (s *zoneStates) UpdateUsedZones(cache ZoneCache, zoneids []dns.ZoneID) {
 	for id := range s.proxies {
 		if _, ok := allUsed[id]; !ok {
 			delete(s.proxies, id)
+			metrics.DeleteZoneStateCache(id)
 		}
 	}
 }
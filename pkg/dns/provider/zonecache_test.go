@@ -0,0 +1,263 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+	"github.com/gardener/external-dns-management/pkg/server/metrics"
+)
+
+var _ = ginkgov2.Describe("Zone state cache metrics", func() {
+	var (
+		zone     DNSHostedZone
+		cache    ZoneCache
+		stateErr error
+	)
+
+	ginkgov2.BeforeEach(func() {
+		zone = NewDNSHostedZone("test", "metrics-zone", "example.com", "", false)
+		stateErr = nil
+		factory := NewTestZoneCacheFactory(1*time.Hour, 1*time.Hour)
+		zonesUpdater := func(_ context.Context, _ ZoneCache) (DNSHostedZones, error) {
+			return DNSHostedZones{zone}, nil
+		}
+		stateUpdater := func(_ DNSHostedZone, _ ZoneCache) (DNSZoneState, error) {
+			if stateErr != nil {
+				return nil, stateErr
+			}
+			sets := dns.DNSSets{}
+			sets.AddRecordSet(dns.DNSSetName{DNSName: "a.example.com"}, nil, dns.NewRecordSet(dns.RS_A, 300, []*dns.Record{{Value: "1.1.1.1"}}))
+			sets.AddRecordSet(dns.DNSSetName{DNSName: "b.example.com"}, nil, dns.NewRecordSet(dns.RS_A, 300, []*dns.Record{{Value: "1.1.1.2"}}))
+			return NewDNSZoneState(sets), nil
+		}
+		var err error
+		cache, err = factory.CreateZoneCache(CacheZoneState, &NullMetrics{}, zonesUpdater, stateUpdater)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = cache.GetZones()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	ginkgov2.AfterEach(func() {
+		cache.Release()
+	})
+
+	counter := func(id dns.ZoneID) (float64, float64, float64, float64) {
+		return testutil.ToFloat64(metrics.ZoneStateCacheHits.WithLabelValues(id.ProviderType, id.ID)),
+			testutil.ToFloat64(metrics.ZoneStateCacheMisses.WithLabelValues(id.ProviderType, id.ID)),
+			testutil.ToFloat64(metrics.ZoneStateCacheEvictions.WithLabelValues(id.ProviderType, id.ID)),
+			testutil.ToFloat64(metrics.ZoneStateCacheEntries.WithLabelValues(id.ProviderType, id.ID))
+	}
+
+	ginkgov2.It("reports hits, misses, evictions and entries", func() {
+		_, err := cache.GetZoneState(zone)
+		Expect(err).NotTo(HaveOccurred())
+		hits, misses, evictions, entries := counter(zone.Id())
+		Expect([]float64{hits, misses, evictions, entries}).To(Equal([]float64{0, 1, 0, 2}))
+
+		_, err = cache.GetZoneState(zone)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = cache.GetZoneState(zone)
+		Expect(err).NotTo(HaveOccurred())
+		hits, misses, evictions, entries = counter(zone.Id())
+		Expect([]float64{hits, misses, evictions, entries}).To(Equal([]float64{2, 1, 0, 2}))
+
+		cache.ApplyRequests(nil, nil, zone, []*ChangeRequest{
+			{
+				Action:   R_CREATE,
+				Type:     dns.RS_A,
+				Addition: newTestDNSSet("c.example.com", "1.1.1.3"),
+				Applied:  true,
+			},
+		})
+		_, _, _, entries = counter(zone.Id())
+		Expect(entries).To(Equal(3.0))
+
+		Expect(cache.ReportZoneStateConflict(zone, &errors.AlreadyBusyForOwner{EntryCreatedAt: time.Now()})).To(BeTrue())
+		hits, misses, evictions, entries = counter(zone.Id())
+		Expect([]float64{hits, misses, evictions, entries}).To(Equal([]float64{2, 1, 1, 0}))
+
+		stateErr = fmt.Errorf("failed")
+		_, err = cache.GetZoneState(zone)
+		Expect(err).To(HaveOccurred())
+		hits, misses, evictions, _ = counter(zone.Id())
+		Expect([]float64{hits, misses, evictions}).To(Equal([]float64{2, 2, 1}))
+	})
+
+	ginkgov2.It("discards the cached state if reported as stale", func() {
+		_, err := cache.GetZoneState(zone)
+		Expect(err).NotTo(HaveOccurred())
+		_, missesBefore, _, _ := counter(zone.Id())
+
+		Expect(cache.ReportZoneStateConflict(zone, &errors.StaleZoneState{Reason: "test"})).To(BeTrue())
+		_, err = cache.GetZoneState(zone)
+		Expect(err).NotTo(HaveOccurred())
+		_, misses, _, _ := counter(zone.Id())
+		Expect(misses).To(Equal(missesBefore + 1))
+	})
+
+	ginkgov2.It("keeps the etags of written record sets", func() {
+		state, err := cache.GetZoneState(zone)
+		Expect(err).NotTo(HaveOccurred())
+		old := state.GetDNSSets()[dns.DNSSetName{DNSName: "a.example.com"}]
+		old.Sets[dns.RS_A].ETag = "etag-1"
+
+		create := NewChangeRequest(R_CREATE, dns.RS_A, nil, newTestDNSSet("c.example.com", "1.1.1.3"), nil)
+		create.Applied = true
+		create.SetETag("etag-c")
+		update := NewChangeRequest(R_UPDATE, dns.RS_A, old, newTestDNSSet("a.example.com", "2.2.2.2"), nil)
+		update.Applied = true
+		update.SetETag("etag-2")
+		cache.ApplyRequests(nil, nil, zone, []*ChangeRequest{create, update})
+
+		state, err = cache.GetZoneState(zone)
+		Expect(err).NotTo(HaveOccurred())
+		sets := state.GetDNSSets()
+		Expect(sets[dns.DNSSetName{DNSName: "a.example.com"}].Sets[dns.RS_A].ETag).To(Equal("etag-2"))
+		Expect(sets[dns.DNSSetName{DNSName: "c.example.com"}].Sets[dns.RS_A].ETag).To(Equal("etag-c"))
+	})
+
+	ginkgov2.It("discards the cached state if the new etag of an updated record set is unknown", func() {
+		state, err := cache.GetZoneState(zone)
+		Expect(err).NotTo(HaveOccurred())
+		_, missesBefore, _, _ := counter(zone.Id())
+		old := state.GetDNSSets()[dns.DNSSetName{DNSName: "a.example.com"}]
+		old.Sets[dns.RS_A].ETag = "etag-1"
+
+		update := NewChangeRequest(R_UPDATE, dns.RS_A, old, newTestDNSSet("a.example.com", "2.2.2.2"), nil)
+		update.Applied = true
+		cache.ApplyRequests(nil, nil, zone, []*ChangeRequest{update})
+
+		_, err = cache.GetZoneState(zone)
+		Expect(err).NotTo(HaveOccurred())
+		_, misses, _, _ := counter(zone.Id())
+		Expect(misses).To(Equal(missesBefore + 1))
+	})
+
+	ginkgov2.It("removes metrics on release", func() {
+		_, err := cache.GetZoneState(zone)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(testutil.CollectAndCount(metrics.ZoneStateCacheMisses, "external_dns_management_zone_state_cache_misses")).To(BeNumerically(">", 0))
+		before := testutil.CollectAndCount(metrics.ZoneStateCacheEntries)
+
+		cache.Release()
+		Expect(testutil.CollectAndCount(metrics.ZoneStateCacheEntries)).To(Equal(before - 1))
+	})
+})
+
+func newTestDNSSet(name, value string) *dns.DNSSet {
+	set := dns.NewDNSSet(dns.DNSSetName{DNSName: name}, nil)
+	set.SetRecordSet(dns.RS_A, 300, value)
+	return set
+}
+
+var _ = ginkgov2.Describe("Incremental zone state cache", func() {
+	var (
+		zone        DNSHostedZone
+		cache       ZoneCache
+		fullFetches int
+		changes     []ZoneStateChange
+		markers     []string
+		changesErr  error
+	)
+
+	ginkgov2.BeforeEach(func() {
+		zone = NewDNSHostedZone("test", "incremental-zone", "example.com", "", false)
+		fullFetches = 0
+		changes = nil
+		markers = nil
+		changesErr = nil
+		factory := NewTestZoneCacheFactory(1*time.Hour, 1*time.Nanosecond)
+		zonesUpdater := func(_ context.Context, _ ZoneCache) (DNSHostedZones, error) {
+			return DNSHostedZones{zone}, nil
+		}
+		stateUpdater := func(_ DNSHostedZone, _ ZoneCache) (DNSZoneState, error) {
+			fullFetches++
+			sets := dns.DNSSets{}
+			sets.AddRecordSet(dns.DNSSetName{DNSName: "a.example.com"}, nil, dns.NewRecordSet(dns.RS_A, 300, []*dns.Record{{Value: "1.1.1.1"}}))
+			sets.AddRecordSet(dns.DNSSetName{DNSName: "b.example.com"}, nil, dns.NewRecordSet(dns.RS_A, 300, []*dns.Record{{Value: "1.1.1.2"}}))
+			return NewDNSZoneState(sets), nil
+		}
+		changesUpdater := func(_ DNSHostedZone, marker string) (*ZoneStateChanges, error) {
+			markers = append(markers, marker)
+			if changesErr != nil {
+				return nil, changesErr
+			}
+			if marker == "" {
+				return &ZoneStateChanges{Marker: "1"}, nil
+			}
+			result := &ZoneStateChanges{Marker: fmt.Sprintf("%d", len(markers)), Changes: changes}
+			changes = nil
+			return result, nil
+		}
+		var err error
+		cache, err = factory.CreateIncrementalZoneCache(&NullMetrics{}, zonesUpdater, stateUpdater, changesUpdater)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = cache.GetZones()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	ginkgov2.AfterEach(func() {
+		cache.Release()
+	})
+
+	ginkgov2.It("applies the changed record sets to the cached zone state", func() {
+		_, err := cache.GetZoneState(zone)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fullFetches).To(Equal(1))
+		Expect(markers).To(Equal([]string{""}))
+
+		changed := dns.DNSSets{}
+		changed.AddRecordSet(dns.DNSSetName{DNSName: "c.example.com"}, nil, dns.NewRecordSet(dns.RS_A, 300, []*dns.Record{{Value: "1.1.1.3"}}))
+		changes = []ZoneStateChange{
+			{DNSName: "a.example.com", Type: dns.RS_A},
+			{DNSName: "c.example.com", Type: dns.RS_A, DNSSets: changed},
+		}
+		time.Sleep(1 * time.Millisecond)
+		state, err := cache.GetZoneState(zone)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fullFetches).To(Equal(1))
+		Expect(markers).To(Equal([]string{"", "1"}))
+		sets := state.GetDNSSets()
+		Expect(sets).To(HaveLen(2))
+		Expect(sets).NotTo(HaveKey(dns.DNSSetName{DNSName: "a.example.com"}))
+		Expect(sets[dns.DNSSetName{DNSName: "c.example.com"}].Sets[dns.RS_A].Records[0].Value).To(Equal("1.1.1.3"))
+
+		time.Sleep(1 * time.Millisecond)
+		_, err = cache.GetZoneState(zone)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(markers).To(Equal([]string{"", "1", "2"}))
+	})
+
+	ginkgov2.It("fetches the complete zone state if the changes cannot be determined", func() {
+		_, err := cache.GetZoneState(zone)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fullFetches).To(Equal(1))
+
+		changesErr = fmt.Errorf("failed")
+		time.Sleep(1 * time.Millisecond)
+		state, err := cache.GetZoneState(zone)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fullFetches).To(Equal(2))
+		Expect(state.GetDNSSets()).To(HaveLen(2))
+	})
+
+	ginkgov2.It("fetches the complete zone state after the maximum number of incremental refreshes", func() {
+		for i := 0; i <= maxIncrementalRefreshes+1; i++ {
+			time.Sleep(1 * time.Millisecond)
+			_, err := cache.GetZoneState(zone)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(fullFetches).To(Equal(2))
+	})
+})
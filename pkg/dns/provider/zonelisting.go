@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ZoneListingConfig contains the tuning of the zone listing for accounts with a large number of hosted zones.
+// It is part of the provider config of providers supporting it.
+type ZoneListingConfig struct {
+	// ZoneListPageSize is the number of hosted zones requested per page (provider default if 0).
+	ZoneListPageSize int32 `json:"zoneListPageSize,omitempty"`
+	// ZoneListMaxZones is the maximum number of hosted zones listed (unlimited if 0).
+	ZoneListMaxZones int `json:"zoneListMaxZones,omitempty"`
+	// ZoneListTimeout is the timeout of the paginated zone listing (unlimited if not set).
+	// If it is reached, the zones listed so far are served.
+	ZoneListTimeout *metav1.Duration `json:"zoneListTimeout,omitempty"`
+}
+
+// Validate checks the zone listing config. The page size is limited by the given maximum page size of the provider.
+func (c ZoneListingConfig) Validate(maxPageSize int32) error {
+	if c.ZoneListPageSize < 0 || c.ZoneListPageSize > maxPageSize {
+		return fmt.Errorf("invalid zoneListPageSize %d: must be between 0 and %d", c.ZoneListPageSize, maxPageSize)
+	}
+	if c.ZoneListMaxZones < 0 {
+		return fmt.Errorf("invalid zoneListMaxZones %d: must not be negative", c.ZoneListMaxZones)
+	}
+	if c.ZoneListTimeout != nil && c.ZoneListTimeout.Duration <= 0 {
+		return fmt.Errorf("invalid zoneListTimeout %s: must be positive", c.ZoneListTimeout.Duration)
+	}
+	return nil
+}
+
+// Context returns the context for the zone listing restricted by the zone list timeout.
+func (c ZoneListingConfig) Context(parent context.Context) (context.Context, context.CancelFunc) {
+	if c.ZoneListTimeout == nil {
+		return context.WithCancel(parent)
+	}
+	return context.WithTimeout(parent, c.ZoneListTimeout.Duration)
+}
+
+// MaxZonesReached returns true if the number of listed zones has reached the maximum number of zones.
+func (c ZoneListingConfig) MaxZonesReached(count int) bool {
+	return c.ZoneListMaxZones > 0 && count >= c.ZoneListMaxZones
+}
+
+// IsPartialResult returns true if the zone listing has been stopped by the zone list timeout after some zones
+// have already been listed. In this case, the listed zones are served instead of failing.
+func (c ZoneListingConfig) IsPartialResult(ctx context.Context, err error, count int) bool {
+	return count > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) && err != nil
+}
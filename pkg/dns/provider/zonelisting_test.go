@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = ginkgov2.Describe("Zone listing config", func() {
+	ginkgov2.It("is parsed from the provider config", func() {
+		cfg := ZoneListingConfig{}
+		Expect(json.Unmarshal([]byte(`{"zoneListPageSize": 50, "zoneListMaxZones": 6000, "zoneListTimeout": "2m"}`), &cfg)).To(Succeed())
+		Expect(cfg).To(Equal(ZoneListingConfig{
+			ZoneListPageSize: 50,
+			ZoneListMaxZones: 6000,
+			ZoneListTimeout:  &metav1.Duration{Duration: 2 * time.Minute},
+		}))
+		Expect(cfg.Validate(100)).To(Succeed())
+	})
+
+	ginkgov2.It("validates the values", func() {
+		Expect(ZoneListingConfig{ZoneListPageSize: 101}.Validate(100)).To(MatchError("invalid zoneListPageSize 101: must be between 0 and 100"))
+		Expect(ZoneListingConfig{ZoneListMaxZones: -1}.Validate(100)).To(MatchError("invalid zoneListMaxZones -1: must not be negative"))
+		Expect(ZoneListingConfig{ZoneListTimeout: &metav1.Duration{}}.Validate(100)).To(MatchError("invalid zoneListTimeout 0s: must be positive"))
+	})
+
+	ginkgov2.It("limits the number of zones", func() {
+		Expect(ZoneListingConfig{}.MaxZonesReached(10000)).To(BeFalse())
+		Expect(ZoneListingConfig{ZoneListMaxZones: 2}.MaxZonesReached(1)).To(BeFalse())
+		Expect(ZoneListingConfig{ZoneListMaxZones: 2}.MaxZonesReached(2)).To(BeTrue())
+	})
+
+	ginkgov2.It("serves partial results only after the timeout", func() {
+		cfg := ZoneListingConfig{ZoneListTimeout: &metav1.Duration{Duration: time.Millisecond}}
+		ctx, cancel := cfg.Context(context.Background())
+		defer cancel()
+		err := fmt.Errorf("request failed")
+		Expect(cfg.IsPartialResult(ctx, err, 5)).To(BeFalse())
+		<-ctx.Done()
+		Expect(cfg.IsPartialResult(ctx, err, 5)).To(BeTrue())
+		Expect(cfg.IsPartialResult(ctx, err, 0)).To(BeFalse())
+	})
+})
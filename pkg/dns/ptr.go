@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dns
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+const (
+	// ReverseDomainIPv4 is the domain of reverse DNS names of IPv4 addresses.
+	ReverseDomainIPv4 = "in-addr.arpa"
+	// ReverseDomainIPv6 is the domain of reverse DNS names of IPv6 addresses.
+	ReverseDomainIPv6 = "ip6.arpa"
+)
+
+// ParseReverseName returns the IP address of a reverse DNS name, e.g. `4.3.2.1.in-addr.arpa` for `1.2.3.4`.
+// IPv6 addresses are given as 32 nibbles in reverse order below `ip6.arpa`.
+func ParseReverseName(name string) (net.IP, error) {
+	check := strings.ToLower(NormalizeHostname(name))
+	if prefix, ok := strings.CutSuffix(check, "."+ReverseDomainIPv4); ok {
+		labels := strings.Split(prefix, ".")
+		if len(labels) != net.IPv4len {
+			return nil, fmt.Errorf("invalid reverse name %q: expected %d labels below %s", name, net.IPv4len, ReverseDomainIPv4)
+		}
+		ip := make(net.IP, net.IPv4len)
+		for i, label := range labels {
+			v, err := strconv.ParseUint(label, 10, 8)
+			if err != nil || (len(label) > 1 && label[0] == '0') {
+				return nil, fmt.Errorf("invalid reverse name %q: label %q is no decimal octet", name, label)
+			}
+			ip[net.IPv4len-1-i] = byte(v)
+		}
+		return ip, nil
+	}
+	if prefix, ok := strings.CutSuffix(check, "."+ReverseDomainIPv6); ok {
+		labels := strings.Split(prefix, ".")
+		if len(labels) != 2*net.IPv6len {
+			return nil, fmt.Errorf("invalid reverse name %q: expected %d labels below %s", name, 2*net.IPv6len, ReverseDomainIPv6)
+		}
+		ip := make(net.IP, net.IPv6len)
+		for i, label := range labels {
+			v, err := strconv.ParseUint(label, 16, 4)
+			if err != nil || len(label) != 1 {
+				return nil, fmt.Errorf("invalid reverse name %q: label %q is no hexadecimal nibble", name, label)
+			}
+			pos := 2*net.IPv6len - 1 - i
+			ip[pos/2] |= byte(v) << (4 * (1 - pos%2))
+		}
+		return ip, nil
+	}
+	return nil, fmt.Errorf("invalid reverse name %q: expected domain %s or %s", name, ReverseDomainIPv4, ReverseDomainIPv6)
+}
+
+// IsReverseDomain returns true if the domain is a reverse DNS domain below `in-addr.arpa` or `ip6.arpa`.
+func IsReverseDomain(domain string) bool {
+	check := strings.ToLower(NormalizeHostname(domain))
+	for _, reverse := range []string{ReverseDomainIPv4, ReverseDomainIPv6} {
+		if check == reverse || strings.HasSuffix(check, "."+reverse) {
+			return true
+		}
+	}
+	return false
+}
+
+// ReverseDomainForCIDR returns the reverse DNS domain of a network, e.g. `2.1.in-addr.arpa` for `1.2.0.0/16`.
+// Only prefix lengths at octet boundaries (IPv4) or nibble boundaries (IPv6) are supported.
+func ReverseDomainForCIDR(cidr *net.IPNet) (string, error) {
+	ones, bits := cidr.Mask.Size()
+	var labels []string
+	switch {
+	case bits == 8*net.IPv4len && ones%8 == 0:
+		ip := cidr.IP.To4()
+		for i := ones/8 - 1; i >= 0; i-- {
+			labels = append(labels, strconv.Itoa(int(ip[i])))
+		}
+		labels = append(labels, ReverseDomainIPv4)
+	case bits == 8*net.IPv6len && ones%4 == 0:
+		ip := cidr.IP.To16()
+		for i := ones/4 - 1; i >= 0; i-- {
+			labels = append(labels, strconv.FormatUint(uint64(ip[i/2]>>(4*(1-i%2))&0xf), 16))
+		}
+		labels = append(labels, ReverseDomainIPv6)
+	default:
+		return "", fmt.Errorf("unsupported prefix length %d of network %s for reverse domain", ones, cidr)
+	}
+	return strings.Join(labels, "."), nil
+}
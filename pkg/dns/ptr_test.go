@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dns
+
+import (
+	"net"
+	"testing"
+)
+
+func TestParseReverseName(t *testing.T) {
+	table := []struct {
+		input string
+		ok    bool
+		ip    string
+	}{
+		{"4.3.2.1.in-addr.arpa", true, "1.2.3.4"},
+		{"4.3.2.1.in-addr.arpa.", true, "1.2.3.4"},
+		{"0.0.168.192.IN-ADDR.ARPA", true, "192.168.0.0"},
+		{"b.a.9.8.7.6.5.0.4.0.0.0.3.0.0.0.2.0.0.0.1.0.0.0.0.0.0.0.1.2.3.4.ip6.arpa", true, "4321:0:1:2:3:4:567:89ab"},
+		{"3.2.1.in-addr.arpa", false, ""},
+		{"256.3.2.1.in-addr.arpa", false, ""},
+		{"04.3.2.1.in-addr.arpa", false, ""},
+		{"x.3.2.1.in-addr.arpa", false, ""},
+		{"1.0.ip6.arpa", false, ""},
+		{"b.a.9.8.7.6.5.0.4.0.0.0.3.0.0.0.2.0.0.0.1.0.0.0.0.0.0.0.1.2.3.g.ip6.arpa", false, ""},
+		{"www.example.com", false, ""},
+		{"in-addr.arpa", false, ""},
+	}
+	for _, entry := range table {
+		ip, err := ParseReverseName(entry.input)
+		if entry.ok && err != nil {
+			t.Errorf("%q should be ok, but got error %s", entry.input, err)
+			continue
+		} else if !entry.ok {
+			if err == nil {
+				t.Errorf("%q should not be ok, but got no error", entry.input)
+			}
+			continue
+		}
+		if ip.String() != entry.ip {
+			t.Errorf("%q: expected %s, but got %s", entry.input, entry.ip, ip)
+		}
+	}
+}
+
+func TestReverseDomainForCIDR(t *testing.T) {
+	table := []struct {
+		input    string
+		ok       bool
+		expected string
+	}{
+		{"192.168.1.0/24", true, "1.168.192.in-addr.arpa"},
+		{"10.0.0.0/8", true, "10.in-addr.arpa"},
+		{"2001:db8::/32", true, "8.b.d.0.1.0.0.2.ip6.arpa"},
+		{"2001:db8:1234::/44", true, "3.2.1.8.b.d.0.1.0.0.2.ip6.arpa"},
+		{"192.168.1.0/25", false, ""},
+		{"2001:db8::/30", false, ""},
+	}
+	for _, entry := range table {
+		_, cidr, err := net.ParseCIDR(entry.input)
+		if err != nil {
+			t.Fatalf("%q: %s", entry.input, err)
+		}
+		domain, err := ReverseDomainForCIDR(cidr)
+		if entry.ok && err != nil {
+			t.Errorf("%q should be ok, but got error %s", entry.input, err)
+		} else if !entry.ok && err == nil {
+			t.Errorf("%q should not be ok, but got no error", entry.input)
+		}
+		if domain != entry.expected {
+			t.Errorf("%q: expected %q, but got %q", entry.input, entry.expected, domain)
+		}
+	}
+}
+
+func TestIsReverseDomain(t *testing.T) {
+	table := []struct {
+		input    string
+		expected bool
+	}{
+		{"1.168.192.in-addr.arpa", true},
+		{"in-addr.arpa", true},
+		{"8.b.d.0.1.0.0.2.IP6.ARPA.", true},
+		{"example.com", false},
+		{"example-in-addr.arpa", false},
+	}
+	for _, entry := range table {
+		if result := IsReverseDomain(entry.input); result != entry.expected {
+			t.Errorf("%q: expected %t, but got %t", entry.input, entry.expected, result)
+		}
+	}
+}
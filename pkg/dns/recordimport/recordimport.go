@@ -0,0 +1,248 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package recordimport
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+const (
+	rsSOA = "SOA"
+
+	maxNameLength = 253
+)
+
+// Options are the options for creating DNS entries from record sets.
+type Options struct {
+	// Namespace is the namespace of the DNS entries.
+	Namespace string
+	// NamePrefix is an optional prefix for the names of the DNS entries.
+	NamePrefix string
+	// Class is the optional DNS class of the DNS entries.
+	Class string
+}
+
+// Skipped describes a record set which has not been imported.
+type Skipped struct {
+	Name   dns.DNSSetName
+	Type   string
+	Reason string
+}
+
+func (s Skipped) String() string {
+	return fmt.Sprintf("%s %s: %s", s.Name, s.Type, s.Reason)
+}
+
+// Entries creates DNS entries for the record sets of a zone with the given domain.
+// Record sets which cannot be managed by DNS entries are skipped, i.e. the SOA and NS records of the zone apex,
+// the meta data records, record sets already owned by a DNS controller, and unsupported record types.
+// The entries are annotated with dns.AnnotationImported and sorted by name.
+func Entries(domain string, dnssets dns.DNSSets, opts Options) ([]*api.DNSEntry, []Skipped) {
+	domain = dns.NormalizeHostname(domain)
+	names := make([]dns.DNSSetName, 0, len(dnssets))
+	for name := range dnssets {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if names[i].DNSName != names[j].DNSName {
+			return names[i].DNSName < names[j].DNSName
+		}
+		return names[i].SetIdentifier < names[j].SetIdentifier
+	})
+
+	b := &builder{opts: opts, used: map[string]struct{}{}}
+	for _, name := range names {
+		b.add(domain, name, dnssets[name])
+	}
+	sort.Slice(b.entries, func(i, j int) bool { return b.entries[i].Name < b.entries[j].Name })
+	return b.entries, b.skipped
+}
+
+type builder struct {
+	opts    Options
+	used    map[string]struct{}
+	entries []*api.DNSEntry
+	skipped []Skipped
+}
+
+func (this *builder) skip(name dns.DNSSetName, rtype, reason string) {
+	this.skipped = append(this.skipped, Skipped{Name: name, Type: rtype, Reason: reason})
+}
+
+func (this *builder) add(domain string, name dns.DNSSetName, dnsset *dns.DNSSet) {
+	dnsName := dns.NormalizeHostname(name.DNSName)
+	rtypes := make([]string, 0, len(dnsset.Sets))
+	for rtype := range dnsset.Sets {
+		rtypes = append(rtypes, rtype)
+	}
+	sort.Strings(rtypes)
+
+	owner := dnsset.GetOwner()
+	if owner == "" {
+		owner = dnsset.GetOwnershipRecordOwner()
+	}
+	if owner != "" {
+		for _, rtype := range rtypes {
+			if rtype != dns.RS_META && rtype != dns.RS_OWNERSHIP {
+				this.skip(name, rtype, fmt.Sprintf("already managed by owner %s", owner))
+			}
+		}
+		return
+	}
+
+	var targets *api.DNSEntry
+	for _, rtype := range rtypes {
+		rs := dnsset.Sets[rtype]
+		switch rtype {
+		case dns.RS_META, dns.RS_OWNERSHIP:
+		case rsSOA:
+			this.skip(name, rtype, "not managed by DNS entries")
+		case dns.RS_NS:
+			if dnsName == domain {
+				this.skip(name, rtype, "zone apex")
+				continue
+			}
+			this.addRecords(name, dnsset, rs, dns.NormalizeHostname)
+		case dns.RS_PTR:
+			this.addRecords(name, dnsset, rs, dns.NormalizeHostname)
+		case dns.RS_SRV, dns.RS_CAA, dns.RS_MX, dns.RS_DS:
+			this.addRecords(name, dnsset, rs, nil)
+		case dns.RS_TXT:
+			entry := this.newEntry(name, dnsset, rs, "txt")
+			for _, r := range rs.Records {
+				value := r.Value
+				if unquoted, err := strconv.Unquote(value); err == nil {
+					value = unquoted
+				}
+				entry.Spec.Text = append(entry.Spec.Text, value)
+			}
+		case dns.RS_A, dns.RS_AAAA, dns.RS_CNAME, dns.RS_ALIAS_A, dns.RS_ALIAS_AAAA:
+			if targets == nil {
+				targets = this.newEntry(name, dnsset, rs, "")
+			}
+			addTargets(targets, rs)
+		default:
+			this.skip(name, rtype, "unsupported record type")
+		}
+	}
+}
+
+func addTargets(entry *api.DNSEntry, rs *dns.RecordSet) {
+	for _, r := range rs.Records {
+		value := r.Value
+		switch rs.Type {
+		case dns.RS_CNAME:
+			value = dns.NormalizeHostname(value)
+		case dns.RS_ALIAS_A, dns.RS_ALIAS_AAAA:
+			var hostedZone string
+			value, hostedZone = dns.SplitAliasTargetValue(value)
+			value = dns.NormalizeHostname(value)
+			if hostedZone != "" {
+				entry.Annotations[dns.AnnotationAliasHostedZoneID] = hostedZone
+			}
+			entry.Annotations[dns.AnnotationIPStack] = aliasIPStack(entry.Annotations[dns.AnnotationIPStack], rs.Type)
+		}
+		if !slices.Contains(entry.Spec.Targets, value) {
+			entry.Spec.Targets = append(entry.Spec.Targets, value)
+		}
+	}
+}
+
+// aliasIPStack returns the IP stack annotation value for alias target records of the given type,
+// taking into account the value for the alias records of the other type.
+func aliasIPStack(old, rtype string) string {
+	stack := dns.AnnotationValueIPStackIPv4
+	if rtype == dns.RS_ALIAS_AAAA {
+		stack = dns.AnnotationValueIPStackIPv6
+	}
+	if old != "" && old != stack {
+		return dns.AnnotationValueIPStackIPDualStack
+	}
+	return stack
+}
+
+func (this *builder) addRecords(name dns.DNSSetName, dnsset *dns.DNSSet, rs *dns.RecordSet, normalize func(string) string) {
+	entry := this.newEntry(name, dnsset, rs, strings.ToLower(rs.Type))
+	entry.Spec.RecordType = rs.Type
+	for _, r := range rs.Records {
+		value := r.Value
+		if normalize != nil {
+			value = normalize(value)
+		}
+		entry.Spec.Records = append(entry.Spec.Records, value)
+	}
+}
+
+func (this *builder) newEntry(name dns.DNSSetName, dnsset *dns.DNSSet, rs *dns.RecordSet, suffix string) *api.DNSEntry {
+	ttl := rs.TTL
+	entry := &api.DNSEntry{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: api.SchemeGroupVersion.String(),
+			Kind:       api.DNSEntryKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   this.opts.Namespace,
+			Name:        this.entryName(name, suffix),
+			Annotations: map[string]string{dns.AnnotationImported: "true"},
+		},
+		Spec: api.DNSEntrySpec{
+			DNSName: dns.NormalizeHostname(name.DNSName),
+			TTL:     &ttl,
+		},
+	}
+	if this.opts.Class != "" && this.opts.Class != dns.DEFAULT_CLASS {
+		entry.Annotations[dns.CLASS_ANNOTATION] = this.opts.Class
+	}
+	if policy := dnsset.RoutingPolicy; policy != nil {
+		entry.Spec.RoutingPolicy = &api.RoutingPolicy{
+			Type:          policy.Type,
+			SetIdentifier: name.SetIdentifier,
+			Parameters:    policy.Parameters,
+		}
+	}
+	this.entries = append(this.entries, entry)
+	return entry
+}
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// entryName derives a unique object name from the DNS name, the set identifier, and the given suffix.
+func (this *builder) entryName(name dns.DNSSetName, suffix string) string {
+	parts := []string{this.opts.NamePrefix + strings.Replace(dns.NormalizeHostname(name.DNSName), "*", "star", 1)}
+	if name.SetIdentifier != "" {
+		parts = append(parts, name.SetIdentifier)
+	}
+	if suffix != "" {
+		parts = append(parts, suffix)
+	}
+	base := strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(strings.Join(parts, "-")), "-"), "-")
+	result := truncate(base, maxNameLength)
+	for i := 2; ; i++ {
+		if _, ok := this.used[result]; !ok {
+			break
+		}
+		n := fmt.Sprintf("-%d", i)
+		result = truncate(base, maxNameLength-len(n)) + n
+	}
+	this.used[result] = struct{}{}
+	return result
+}
+
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return strings.TrimRight(s[:n], "-")
+	}
+	return s
+}
@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package recordimport
+
+import (
+	"reflect"
+	"testing"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+func addRecordSet(dnssets dns.DNSSets, name dns.DNSSetName, policy *dns.RoutingPolicy, rtype string, ttl int64, values ...string) {
+	records := make([]*dns.Record, len(values))
+	for i, v := range values {
+		records[i] = &dns.Record{Value: v}
+	}
+	dnssets.AddRecordSet(name, policy, dns.NewRecordSet(rtype, ttl, records))
+}
+
+func TestEntries(t *testing.T) {
+	dnssets := dns.DNSSets{}
+	apex := dns.DNSSetName{DNSName: "example.com"}
+	addRecordSet(dnssets, apex, nil, "SOA", 900, "ns1.example.net. admin.example.com. 1 7200 900 1209600 86400")
+	addRecordSet(dnssets, apex, nil, dns.RS_NS, 3600, "ns1.example.net.")
+	addRecordSet(dnssets, apex, nil, dns.RS_A, 300, "1.1.1.1", "1.1.1.2")
+	addRecordSet(dnssets, apex, nil, dns.RS_AAAA, 300, "::1")
+	addRecordSet(dnssets, apex, nil, dns.RS_MX, 300, "10 mail.example.com.")
+	addRecordSet(dnssets, apex, nil, "HINFO", 300, `"x86" "linux"`)
+	addRecordSet(dnssets, dns.DNSSetName{DNSName: "www.example.com"}, nil, dns.RS_CNAME, 600, "example.com.")
+	addRecordSet(dnssets, dns.DNSSetName{DNSName: "*.example.com"}, nil, dns.RS_TXT, 120, `"hello world"`)
+	addRecordSet(dnssets, dns.DNSSetName{DNSName: "sub.example.com"}, nil, dns.RS_NS, 3600, "ns.sub.example.com.")
+	addRecordSet(dnssets, dns.DNSSetName{DNSName: "lb.example.com"}, nil, dns.RS_ALIAS_A, 60, "my-lb.elb.amazonaws.com")
+	addRecordSet(dnssets, dns.DNSSetName{DNSName: "lb.example.com"}, nil, dns.RS_ALIAS_AAAA, 60, "my-lb.elb.amazonaws.com")
+	weighted := dns.DNSSetName{DNSName: "w.example.com", SetIdentifier: "blue"}
+	addRecordSet(dnssets, weighted, dns.NewRoutingPolicy("weighted", "weight", "10"), dns.RS_A, 300, "2.2.2.2")
+	owned := dns.DNSSetName{DNSName: "owned.example.com"}
+	addRecordSet(dnssets, owned, nil, dns.RS_A, 300, "3.3.3.3")
+	dnssets[owned].SetOwner("other")
+
+	entries, skipped := Entries("example.com", dnssets, Options{Namespace: "ns", Class: "custom"})
+
+	specs := map[string]api.DNSEntrySpec{}
+	for _, e := range entries {
+		if e.Namespace != "ns" || e.Annotations[dns.AnnotationImported] != "true" || e.Annotations[dns.CLASS_ANNOTATION] != "custom" {
+			t.Errorf("unexpected metadata of entry %s: %v", e.Name, e.ObjectMeta)
+		}
+		specs[e.Name] = e.Spec
+	}
+	ttl := func(v int64) *int64 { return &v }
+	expected := map[string]api.DNSEntrySpec{
+		"example-com":          {DNSName: "example.com", TTL: ttl(300), Targets: []string{"1.1.1.1", "1.1.1.2", "::1"}},
+		"example-com-mx":       {DNSName: "example.com", TTL: ttl(300), RecordType: dns.RS_MX, Records: []string{"10 mail.example.com"}},
+		"www-example-com":      {DNSName: "www.example.com", TTL: ttl(600), Targets: []string{"example.com"}},
+		"star-example-com-txt": {DNSName: "*.example.com", TTL: ttl(120), Text: []string{"hello world"}},
+		"sub-example-com-ns":   {DNSName: "sub.example.com", TTL: ttl(3600), RecordType: dns.RS_NS, Records: []string{"ns.sub.example.com"}},
+		"lb-example-com":       {DNSName: "lb.example.com", TTL: ttl(60), Targets: []string{"my-lb.elb.amazonaws.com"}},
+		"w-example-com-blue": {
+			DNSName:       "w.example.com",
+			TTL:           ttl(300),
+			Targets:       []string{"2.2.2.2"},
+			RoutingPolicy: &api.RoutingPolicy{Type: "weighted", SetIdentifier: "blue", Parameters: map[string]string{"weight": "10"}},
+		},
+	}
+	if !reflect.DeepEqual(specs, expected) {
+		t.Errorf("unexpected entries:\n%v\nexpected:\n%v", specs, expected)
+	}
+	for _, e := range entries {
+		if e.Name == "lb-example-com" && e.Annotations[dns.AnnotationIPStack] != dns.AnnotationValueIPStackIPDualStack {
+			t.Errorf("expected dual-stack annotation for alias entry, got %q", e.Annotations[dns.AnnotationIPStack])
+		}
+	}
+
+	var skippedTypes []string
+	for _, s := range skipped {
+		skippedTypes = append(skippedTypes, s.Name.DNSName+" "+s.Type)
+	}
+	expectedSkipped := []string{"example.com HINFO", "example.com NS", "example.com SOA", "owned.example.com A"}
+	if !reflect.DeepEqual(skippedTypes, expectedSkipped) {
+		t.Errorf("unexpected skipped record sets %v, expected %v", skippedTypes, expectedSkipped)
+	}
+}
+
+func TestEntryNames(t *testing.T) {
+	b := &builder{opts: Options{NamePrefix: "imported-"}, used: map[string]struct{}{}}
+	table := []struct {
+		name     dns.DNSSetName
+		suffix   string
+		expected string
+	}{
+		{dns.DNSSetName{DNSName: "a.b.example.com"}, "", "imported-a-b-example-com"},
+		{dns.DNSSetName{DNSName: "a-b.example.com"}, "", "imported-a-b-example-com-2"},
+		{dns.DNSSetName{DNSName: "_sip._tcp.example.com"}, "srv", "imported-sip-tcp-example-com-srv"},
+		{dns.DNSSetName{DNSName: "x.example.com", SetIdentifier: "EU_West"}, "", "imported-x-example-com-eu-west"},
+	}
+	for _, e := range table {
+		if result := b.entryName(e.name, e.suffix); result != e.expected {
+			t.Errorf("%s: expected %q, got %q", e.name, e.expected, result)
+		}
+	}
+}
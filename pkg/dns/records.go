@@ -7,6 +7,7 @@ package dns
 import (
 	"bytes"
 	"fmt"
+	"maps"
 	"strings"
 )
 
@@ -21,10 +22,36 @@ const (
 	RS_CNAME = "CNAME"
 	RS_A     = "A"
 	RS_AAAA  = "AAAA"
+	RS_SRV   = "SRV"
+	RS_CAA   = "CAA"
+	RS_MX    = "MX"
+	RS_DS    = "DS"
 )
 
 const RS_NS = "NS"
 
+// RS_PTR is the record type of reverse DNS records mapping an IP address to a hostname.
+const RS_PTR = "PTR"
+
+// aliasHostedZoneSeparator separates the hostname and an explicit hosted zone in the value of an alias record.
+const aliasHostedZoneSeparator = "@"
+
+// AliasTargetValue returns the record value of an ALIAS_A or ALIAS_AAAA record.
+// The hosted zone is only included if not empty.
+func AliasTargetValue(hostname, hostedZone string) string {
+	if hostedZone == "" {
+		return hostname
+	}
+	return hostname + aliasHostedZoneSeparator + hostedZone
+}
+
+// SplitAliasTargetValue splits the record value of an ALIAS_A or ALIAS_AAAA record into hostname and hosted zone.
+// The hosted zone is empty if not included in the value.
+func SplitAliasTargetValue(value string) (hostname, hostedZone string) {
+	hostname, hostedZone, _ = strings.Cut(value, aliasHostedZoneSeparator)
+	return
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 // Record Sets
 ////////////////////////////////////////////////////////////////////////////////
@@ -63,7 +90,15 @@ type RecordSet struct {
 	Type      string
 	TTL       int64
 	IgnoreTTL bool
-	Records   Records
+	// Comment is the comment of the record set. It is nil if comments are not supported by the provider.
+	Comment *string
+	// ETag is the version of the record set as read from the provider. It is empty if the provider
+	// does not support conditional updates or if the record set has not been read from the provider.
+	ETag string
+	// ProviderAttributes are provider specific attributes of the record set. It is nil if not requested
+	// or if attributes are not supported by the provider.
+	ProviderAttributes map[string]string
+	Records            Records
 }
 
 func NewRecordSet(rtype string, ttl int64, records []*Record) *RecordSet {
@@ -71,7 +106,10 @@ func NewRecordSet(rtype string, ttl int64, records []*Record) *RecordSet {
 }
 
 func (rs *RecordSet) Clone() *RecordSet {
-	set := &RecordSet{Type: rs.Type, TTL: rs.TTL, IgnoreTTL: rs.IgnoreTTL}
+	set := &RecordSet{Type: rs.Type, TTL: rs.TTL, IgnoreTTL: rs.IgnoreTTL, Comment: rs.Comment, ETag: rs.ETag}
+	if rs.ProviderAttributes != nil {
+		set.ProviderAttributes = maps.Clone(rs.ProviderAttributes)
+	}
 	for _, r := range rs.Records {
 		set.Records = append(set.Records, r.Clone())
 	}
@@ -105,6 +143,8 @@ func (rs *RecordSet) RecordString() string {
 	return "[" + line + "]"
 }
 
+// Match checks if the record set matches the given one. Provider attributes are only compared
+// if both record sets have them.
 func (rs *RecordSet) Match(set *RecordSet) bool {
 	if len(rs.Records) != len(set.Records) {
 		return false
@@ -114,10 +154,18 @@ func (rs *RecordSet) Match(set *RecordSet) bool {
 		return false
 	}
 
+	if rs.Comment != nil && set.Comment != nil && !CommentsEqual(*rs.Comment, *set.Comment) {
+		return false
+	}
+
+	if rs.ProviderAttributes != nil && set.ProviderAttributes != nil && !maps.Equal(rs.ProviderAttributes, set.ProviderAttributes) {
+		return false
+	}
+
 	for _, r := range rs.Records {
 		found := false
 		for _, t := range set.Records {
-			if t.Value == r.Value {
+			if valuesEqual(rs.Type, r.Value, t.Value) {
 				found = true
 				break
 			}
@@ -129,6 +177,20 @@ func (rs *RecordSet) Match(set *RecordSet) bool {
 	return true
 }
 
+// valuesEqual compares two record values of the given type. Host names are compared in canonical form,
+// so that values only differing in the trailing dot or case are considered equal.
+func valuesEqual(rtype, a, b string) bool {
+	switch rtype {
+	case RS_CNAME, RS_NS, RS_PTR:
+		return CanonicalHostname(a) == CanonicalHostname(b)
+	case RS_MX, RS_SRV:
+		// the target host name is the last field of the value
+		return strings.EqualFold(strings.TrimSuffix(a, "."), strings.TrimSuffix(b, "."))
+	default:
+		return a == b
+	}
+}
+
 func (rs *RecordSet) GetAttr(name string) string {
 	if rs.Type == RS_TXT || rs.Type == RS_META {
 		prefix := newAttrKeyPrefix(name)
@@ -218,3 +280,9 @@ func newAttrRecordSet(ty string, name, value string) *RecordSet {
 	records := []*Record{newAttrRecord(name, value)}
 	return &RecordSet{Type: ty, TTL: 600, IgnoreTTL: false, Records: records}
 }
+
+// CommentsEqual compares record comments semantically. Providers may normalize comments on write,
+// therefore leading, trailing, and repeated whitespace is ignored.
+func CommentsEqual(a, b string) bool {
+	return strings.Join(strings.Fields(a), " ") == strings.Join(strings.Fields(b), " ")
+}
@@ -3,6 +3,8 @@ package dns
 import (
 	"encoding/json"
 	"testing"
+
+	"k8s.io/utils/ptr"
 )
 
 func TestMatch(t *testing.T) {
@@ -26,6 +28,29 @@ func TestMatch(t *testing.T) {
 		{RecordSet{Type: RS_META, TTL: 600, Records: []*Record{{"\"owner=test\""}}}, RecordSet{Type: RS_TXT, TTL: 800, Records: []*Record{{"\"owner=test\""}}}, false},
 		// different amount of records = not equal
 		{RecordSet{Type: RS_META, TTL: 600, Records: []*Record{{"\"owner=test\""}}}, RecordSet{Type: RS_TXT, TTL: 600, Records: []*Record{{"\"owner=test\""}, {"\"owner=test\""}}}, false},
+		// different comments = not equal
+		{RecordSet{Type: RS_A, TTL: 600, Comment: ptr.To("team-a"), Records: []*Record{{"1.1.1.1"}}}, RecordSet{Type: RS_A, TTL: 600, Comment: ptr.To("team-b"), Records: []*Record{{"1.1.1.1"}}}, false},
+		// comments differing only in whitespace = equal
+		{RecordSet{Type: RS_A, TTL: 600, Comment: ptr.To("team a"), Records: []*Record{{"1.1.1.1"}}}, RecordSet{Type: RS_A, TTL: 600, Comment: ptr.To(" team  a\n"), Records: []*Record{{"1.1.1.1"}}}, true},
+		// comment not supported by provider = equal
+		{RecordSet{Type: RS_A, TTL: 600, Comment: ptr.To("team-a"), Records: []*Record{{"1.1.1.1"}}}, RecordSet{Type: RS_A, TTL: 600, Records: []*Record{{"1.1.1.1"}}}, true},
+		// equal provider attributes = equal
+		{RecordSet{Type: RS_A, TTL: 600, ProviderAttributes: map[string]string{"Site": "berlin", "Tenant": "x"}, Records: []*Record{{"1.1.1.1"}}}, RecordSet{Type: RS_A, TTL: 600, ProviderAttributes: map[string]string{"Site": "berlin", "Tenant": "x"}, Records: []*Record{{"1.1.1.1"}}}, true},
+		// additional current provider attributes = not equal
+		{RecordSet{Type: RS_A, TTL: 600, ProviderAttributes: map[string]string{"Site": "berlin", "Tenant": "x"}, Records: []*Record{{"1.1.1.1"}}}, RecordSet{Type: RS_A, TTL: 600, ProviderAttributes: map[string]string{"Site": "berlin"}, Records: []*Record{{"1.1.1.1"}}}, false},
+		// requested provider attribute with different value = not equal
+		{RecordSet{Type: RS_A, TTL: 600, ProviderAttributes: map[string]string{"Site": "berlin"}, Records: []*Record{{"1.1.1.1"}}}, RecordSet{Type: RS_A, TTL: 600, ProviderAttributes: map[string]string{"Site": "paris"}, Records: []*Record{{"1.1.1.1"}}}, false},
+		// requested provider attribute missing = not equal
+		{RecordSet{Type: RS_A, TTL: 600, ProviderAttributes: map[string]string{}, Records: []*Record{{"1.1.1.1"}}}, RecordSet{Type: RS_A, TTL: 600, ProviderAttributes: map[string]string{"Site": "berlin"}, Records: []*Record{{"1.1.1.1"}}}, false},
+		// provider attributes not supported by provider = equal
+		{RecordSet{Type: RS_A, TTL: 600, Records: []*Record{{"1.1.1.1"}}}, RecordSet{Type: RS_A, TTL: 600, ProviderAttributes: map[string]string{"Site": "berlin"}, Records: []*Record{{"1.1.1.1"}}}, true},
+		// host names differing only in trailing dot or case = equal
+		{RecordSet{Type: RS_CNAME, TTL: 600, Records: []*Record{{"a.example.com"}}}, RecordSet{Type: RS_CNAME, TTL: 600, Records: []*Record{{"A.Example.com."}}}, true},
+		{RecordSet{Type: RS_MX, TTL: 600, Records: []*Record{{"10 mail.example.com"}}}, RecordSet{Type: RS_MX, TTL: 600, Records: []*Record{{"10 Mail.example.com"}}}, true},
+		{RecordSet{Type: RS_MX, TTL: 600, Records: []*Record{{"10 mail.example.com"}}}, RecordSet{Type: RS_MX, TTL: 600, Records: []*Record{{"10 Mail.example.com."}}}, true},
+		{RecordSet{Type: RS_SRV, TTL: 600, Records: []*Record{{"10 5 443 svc.example.com"}}}, RecordSet{Type: RS_SRV, TTL: 600, Records: []*Record{{"10 5 443 svc.example.com."}}}, true},
+		// texts differing in case = not equal
+		{RecordSet{Type: RS_TXT, TTL: 600, Records: []*Record{{"\"abc\""}}}, RecordSet{Type: RS_TXT, TTL: 600, Records: []*Record{{"\"ABC\""}}}, false},
 	}
 
 	for _, entry := range table {
@@ -38,3 +63,25 @@ func TestMatch(t *testing.T) {
 		}
 	}
 }
+
+func TestAliasTargetValue(t *testing.T) {
+	table := []struct {
+		hostname   string
+		hostedZone string
+		value      string
+	}{
+		{"my-lb.eu-west-1.elb.amazonaws.com", "", "my-lb.eu-west-1.elb.amazonaws.com"},
+		{"d111111abcdef8.example.com", "Z123456", "d111111abcdef8.example.com@Z123456"},
+	}
+
+	for _, entry := range table {
+		value := AliasTargetValue(entry.hostname, entry.hostedZone)
+		if value != entry.value {
+			t.Errorf("expected value %q, got %q", entry.value, value)
+		}
+		hostname, hostedZone := SplitAliasTargetValue(value)
+		if hostname != entry.hostname || hostedZone != entry.hostedZone {
+			t.Errorf("expected %q/%q, got %q/%q", entry.hostname, entry.hostedZone, hostname, hostedZone)
+		}
+	}
+}
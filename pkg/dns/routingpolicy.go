@@ -6,10 +6,14 @@ package dns
 
 import (
 	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
 )
 
 const (
-	// RoutingPolicyWeighted is a weighted routing policy (supported for AWS Route 53 and Google CloudDNS)
+	// RoutingPolicyWeighted is a weighted routing policy (supported for AWS Route 53, Google CloudDNS, and Cloudflare)
 	RoutingPolicyWeighted = "weighted"
 	// RoutingPolicyLatency is a latency based routing policy (supported for AWS Route 53)
 	RoutingPolicyLatency = "latency"
@@ -21,6 +25,28 @@ const (
 	RoutingPolicyFailover = "failover"
 )
 
+const (
+	// RoutingPolicyParameterWeight is the parameter key of the weight of a weighted routing policy.
+	RoutingPolicyParameterWeight = "weight"
+	// WeightPercentageSuffix marks the weight of a weighted routing policy as relative percentage, e.g. `25%`.
+	// The percentages of all record sets of a DNS name are normalized to the weight scale of the provider.
+	WeightPercentageSuffix = "%"
+	// DefaultMaxWeight is the weight scale of providers without an explicit maximum weight.
+	DefaultMaxWeight int64 = 100
+
+	// RoutingPolicyParameterFailoverRecordType is the parameter key of the record type of a failover routing policy.
+	RoutingPolicyParameterFailoverRecordType = "failoverRecordType"
+	// RoutingPolicyParameterHealthCheckID is the parameter key of the health check associated with a record set.
+	RoutingPolicyParameterHealthCheckID = "healthCheckID"
+	// FailoverRecordTypePrimary marks the record set of a failover routing policy used while it is healthy.
+	FailoverRecordTypePrimary = "PRIMARY"
+	// FailoverRecordTypeSecondary marks the record set of a failover routing policy used if the primary is unhealthy.
+	FailoverRecordTypeSecondary = "SECONDARY"
+)
+
+// healthCheckIDPattern matches health check IDs, which are UUIDs like `abcdef11-2222-3333-4444-555555fedcba`.
+var healthCheckIDPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
 type RoutingPolicy struct {
 	Type       string
 	Parameters map[string]string
@@ -69,3 +95,94 @@ func (p *RoutingPolicy) CheckParameterKeys(keys, optionalKeys []string) error {
 	}
 	return nil
 }
+
+// PercentageWeight returns the relative percentage of a weighted routing policy.
+// The second return value is false if the policy has no percentage weight.
+func (p *RoutingPolicy) PercentageWeight() (float64, bool, error) {
+	if p == nil || p.Type != RoutingPolicyWeighted {
+		return 0, false, nil
+	}
+	return ParsePercentageWeight(p.Parameters[RoutingPolicyParameterWeight])
+}
+
+// ParsePercentageWeight parses a weight given as relative percentage in the range [0%, 100%].
+// The second return value is false if the value is no percentage.
+func ParsePercentageWeight(value string) (float64, bool, error) {
+	s, ok := strings.CutSuffix(strings.TrimSpace(value), WeightPercentageSuffix)
+	if !ok {
+		return 0, false, nil
+	}
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil || math.IsNaN(f) || f < 0 || f > 100 {
+		return 0, true, fmt.Errorf("invalid percentage weight %q (only numbers between 0%% and 100%% are allowed)", value)
+	}
+	return f, true, nil
+}
+
+// FailoverRecordType returns the upper case record type (PRIMARY or SECONDARY) of a failover routing policy.
+// The second return value is false if the policy is no failover routing policy.
+func (p *RoutingPolicy) FailoverRecordType() (string, bool, error) {
+	if p == nil || p.Type != RoutingPolicyFailover {
+		return "", false, nil
+	}
+	value := p.Parameters[RoutingPolicyParameterFailoverRecordType]
+	switch typ := strings.ToUpper(value); typ {
+	case FailoverRecordTypePrimary, FailoverRecordTypeSecondary:
+		return typ, true, nil
+	default:
+		return "", true, fmt.Errorf("invalid %s value %q (allowed values: %s, %s)",
+			RoutingPolicyParameterFailoverRecordType, value, FailoverRecordTypePrimary, FailoverRecordTypeSecondary)
+	}
+}
+
+// NormalizeFailoverRecordType returns the routing policy with the upper case failover record type.
+// Policies of other types or with invalid record types are returned unchanged.
+func (p *RoutingPolicy) NormalizeFailoverRecordType() *RoutingPolicy {
+	typ, ok, err := p.FailoverRecordType()
+	if !ok || err != nil || typ == p.Parameters[RoutingPolicyParameterFailoverRecordType] {
+		return p
+	}
+	normalized := p.Clone()
+	normalized.Parameters[RoutingPolicyParameterFailoverRecordType] = typ
+	return normalized
+}
+
+// ValidateHealthCheckID checks the format of the health check ID parameter of the routing policy if set.
+func (p *RoutingPolicy) ValidateHealthCheckID() error {
+	if p == nil {
+		return nil
+	}
+	if id, ok := p.Parameters[RoutingPolicyParameterHealthCheckID]; ok {
+		return ValidateHealthCheckID(id)
+	}
+	return nil
+}
+
+// ValidateHealthCheckID checks if the health check ID has the UUID format used by Route53.
+func ValidateHealthCheckID(id string) error {
+	if !healthCheckIDPattern.MatchString(id) {
+		return fmt.Errorf("invalid %s value %q (expected UUID format)", RoutingPolicyParameterHealthCheckID, id)
+	}
+	return nil
+}
+
+// NormalizeWeights scales the relative percentages of sibling record sets to weights in the range [0, maxWeight].
+// The percentages are related to their sum, a non-zero percentage always results in a weight of at least 1.
+// If all percentages are zero, all weights are zero.
+func NormalizeWeights(percentages []float64, maxWeight int64) []int64 {
+	sum := 0.0
+	for _, p := range percentages {
+		sum += p
+	}
+	weights := make([]int64, len(percentages))
+	if sum == 0 {
+		return weights
+	}
+	for i, p := range percentages {
+		if p == 0 {
+			continue
+		}
+		weights[i] = max(1, int64(math.Round(p/sum*float64(maxWeight))))
+	}
+	return weights
+}
@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dns
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParsePercentageWeight(t *testing.T) {
+	table := []struct {
+		input      string
+		percentage bool
+		ok         bool
+		value      float64
+	}{
+		{"50%", true, true, 50},
+		{" 12.5 % ", true, true, 12.5},
+		{"0%", true, true, 0},
+		{"100%", true, true, 100},
+		{"50", false, true, 0},
+		{"", false, true, 0},
+		{"101%", true, false, 0},
+		{"-1%", true, false, 0},
+		{"x%", true, false, 0},
+		{"%", true, false, 0},
+	}
+	for _, entry := range table {
+		value, percentage, err := ParsePercentageWeight(entry.input)
+		if percentage != entry.percentage {
+			t.Errorf("%q: expected percentage %t, but got %t", entry.input, entry.percentage, percentage)
+		}
+		if entry.ok && err != nil {
+			t.Errorf("%q should be ok, but got error %s", entry.input, err)
+		} else if !entry.ok && err == nil {
+			t.Errorf("%q should not be ok, but got no error", entry.input)
+		}
+		if value != entry.value {
+			t.Errorf("%q: expected value %f, but got %f", entry.input, entry.value, value)
+		}
+	}
+}
+
+func TestPercentageWeight(t *testing.T) {
+	if _, ok, _ := NewRoutingPolicy(RoutingPolicyLatency, "weight", "50%").PercentageWeight(); ok {
+		t.Errorf("latency policy should have no percentage weight")
+	}
+	if _, ok, _ := (*RoutingPolicy)(nil).PercentageWeight(); ok {
+		t.Errorf("nil policy should have no percentage weight")
+	}
+	if v, ok, err := NewRoutingPolicy(RoutingPolicyWeighted, "weight", "30%").PercentageWeight(); !ok || err != nil || v != 30 {
+		t.Errorf("expected percentage weight 30, but got %f, %t, %v", v, ok, err)
+	}
+}
+
+func TestNormalizeWeights(t *testing.T) {
+	table := []struct {
+		percentages []float64
+		maxWeight   int64
+		expected    []int64
+	}{
+		{[]float64{50, 50}, 100, []int64{50, 50}},
+		{[]float64{10, 30}, 100, []int64{25, 75}},
+		{[]float64{90, 10}, 255, []int64{230, 26}},
+		{[]float64{100}, 255, []int64{255}},
+		{[]float64{99.9, 0.1, 0}, 100, []int64{100, 1, 0}},
+		{[]float64{0, 0}, 100, []int64{0, 0}},
+		{nil, 100, []int64{}},
+	}
+	for _, entry := range table {
+		weights := NormalizeWeights(entry.percentages, entry.maxWeight)
+		if !reflect.DeepEqual(weights, entry.expected) {
+			t.Errorf("%v (max %d): expected %v, but got %v", entry.percentages, entry.maxWeight, entry.expected, weights)
+		}
+	}
+}
+
+func TestFailoverRecordType(t *testing.T) {
+	table := []struct {
+		policy   *RoutingPolicy
+		typ      string
+		failover bool
+		err      bool
+	}{
+		{nil, "", false, false},
+		{NewRoutingPolicy(RoutingPolicyWeighted, "weight", "1"), "", false, false},
+		{NewRoutingPolicy(RoutingPolicyFailover, "failoverRecordType", "primary"), "PRIMARY", true, false},
+		{NewRoutingPolicy(RoutingPolicyFailover, "failoverRecordType", "SECONDARY"), "SECONDARY", true, false},
+		{NewRoutingPolicy(RoutingPolicyFailover, "failoverRecordType", "tertiary"), "", true, true},
+		{NewRoutingPolicy(RoutingPolicyFailover), "", true, true},
+	}
+	for _, entry := range table {
+		typ, failover, err := entry.policy.FailoverRecordType()
+		if typ != entry.typ || failover != entry.failover || (err != nil) != entry.err {
+			t.Errorf("%v: expected %q, %t, err=%t, but got %q, %t, %v", entry.policy, entry.typ, entry.failover, entry.err, typ, failover, err)
+		}
+	}
+}
+
+func TestValidateHealthCheckID(t *testing.T) {
+	table := []struct {
+		id    string
+		valid bool
+	}{
+		{"abcdef11-2222-3333-4444-555555fedcba", true},
+		{"ABCDEF11-2222-3333-4444-555555FEDCBA", true},
+		{"abcdef11-2222-3333-4444-555555fedcb", false},
+		{"abcdef11222233334444555555fedcba", false},
+		{"my-health-check", false},
+		{"", false},
+	}
+	for _, entry := range table {
+		if err := ValidateHealthCheckID(entry.id); (err == nil) != entry.valid {
+			t.Errorf("%q: expected valid=%t, but got %v", entry.id, entry.valid, err)
+		}
+	}
+	if err := NewRoutingPolicy(RoutingPolicyFailover, "failoverRecordType", "primary").ValidateHealthCheckID(); err != nil {
+		t.Errorf("missing health check ID should be valid, but got %s", err)
+	}
+}
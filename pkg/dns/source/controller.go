@@ -35,6 +35,9 @@ const (
 	TTL_ANNOTATION            = dns.ANNOTATION_GROUP + "/ttl"
 	PERIOD_ANNOTATION         = dns.ANNOTATION_GROUP + "/cname-lookup-interval"
 	ROUTING_POLICY_ANNOTATION = dns.ANNOTATION_GROUP + "/routing-policy"
+	// SET_IDENTIFIER_ANNOTATION is the annotation key for source objects to set the set identifier of the routing policy.
+	// It overwrites the set identifier given in the routing policy annotation.
+	SET_IDENTIFIER_ANNOTATION = dns.ANNOTATION_GROUP + "/set-identifier"
 	CLASS_ANNOTATION          = dns.CLASS_ANNOTATION
 	OWNER_ID_ANNOTATION       = dns.ANNOTATION_GROUP + "/owner-id"
 	// RESOLVE_TARGETS_TO_ADDRS_ANNOTATION is the annotation key for source objects to set the `.spec.resolveTargetsToAddresses` in the DNSEntry.
@@ -54,6 +57,7 @@ const (
 	OPT_TARGET_OWNER_OBJECT        = "target-owner-object"
 	OPT_TARGET_SET_IGNORE_OWNERS   = "target-set-ignore-owners"
 	OPT_TARGET_REALMS              = "target-realms"
+	OPT_CLUSTER_NAME               = "cluster-name"
 )
 
 var (
@@ -86,6 +90,7 @@ func DNSSourceController(source DNSSourceType, reconcilerType controller.Reconci
 		StringOption(OPT_TARGET_OWNER_OBJECT, "owner object to use for generated DNS entries").
 		BoolOption(OPT_TARGET_SET_IGNORE_OWNERS, "mark generated DNS entries to omit owner based access control").
 		StringOption(OPT_TARGET_REALMS, "realm(s) to use for generated DNS entries").
+		StringOption(OPT_CLUSTER_NAME, "cluster name available as {{.Cluster}} in DNS name templates of the dns annotation").
 		FinalizerDomain(api.GroupName).
 		Reconciler(SourceReconciler(source, reconcilerType)).
 		Cluster(cluster.DEFAULT). // first one used as MAIN cluster
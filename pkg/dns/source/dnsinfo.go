@@ -45,14 +45,17 @@ func (this *sourceReconciler) getDNSInfo(logger logger.LogContext, obj resources
 	annos := obj.GetAnnotations()
 	current.AnnotatedNames = utils.StringSet{}
 	current.AnnotatedNames.AddAllSplittedSelected(annos[DNS_ANNOTATION], utils.StandardNonEmptyStringElement)
+	names, err := renderAnnotatedNames(current.AnnotatedNames, newDNSNameTemplateData(obj, this.clusterName))
+	if err != nil {
+		return nil, true, fmt.Errorf("invalid annotation %s: %w", DNS_ANNOTATION, err)
+	}
+	current.AnnotatedNames = names
 	current.AnnotatedRoutingPolicy = nil
-	if a := annos[ROUTING_POLICY_ANNOTATION]; a != "" {
-		policy := &v1alpha1.RoutingPolicy{}
-		if err := json.Unmarshal([]byte(a), policy); err != nil {
-			return nil, true, err
-		}
-		current.AnnotatedRoutingPolicy = policy
+	policy, err := getAnnotatedRoutingPolicy(annos)
+	if err != nil {
+		return nil, true, err
 	}
+	current.AnnotatedRoutingPolicy = policy
 
 	info, err := s.GetDNSInfo(logger, obj.Data(), current)
 	if info != nil && info.Names != nil {
@@ -104,6 +107,33 @@ func (this *sourceReconciler) getDNSInfo(logger logger.LogContext, obj resources
 	return info, true, nil
 }
 
+// getAnnotatedRoutingPolicy returns the routing policy given by the routing policy and set identifier annotations.
+func getAnnotatedRoutingPolicy(annos map[string]string) (*v1alpha1.RoutingPolicy, error) {
+	var policy *v1alpha1.RoutingPolicy
+	if a := annos[ROUTING_POLICY_ANNOTATION]; a != "" {
+		policy = &v1alpha1.RoutingPolicy{}
+		if err := json.Unmarshal([]byte(a), policy); err != nil {
+			return nil, fmt.Errorf("invalid annotation %s: %w", ROUTING_POLICY_ANNOTATION, err)
+		}
+	}
+	if id := strings.TrimSpace(annos[SET_IDENTIFIER_ANNOTATION]); id != "" {
+		if policy == nil {
+			return nil, fmt.Errorf("annotation %s requires annotation %s", SET_IDENTIFIER_ANNOTATION, ROUTING_POLICY_ANNOTATION)
+		}
+		policy.SetIdentifier = id
+	}
+	if policy != nil {
+		if policy.Type == "" {
+			return nil, fmt.Errorf("missing type in annotation %s", ROUTING_POLICY_ANNOTATION)
+		}
+		if policy.SetIdentifier == "" {
+			return nil, fmt.Errorf("routing policy of type %s requires a set identifier (given by annotation %s or by field setIdentifier of annotation %s)",
+				policy.Type, SET_IDENTIFIER_ANNOTATION, ROUTING_POLICY_ANNOTATION)
+		}
+	}
+	return policy, nil
+}
+
 func (this *sourceReconciler) enrichAnnotations(logger logger.LogContext, obj resources.Object) resources.Object {
 	addons := this.annotations.GetInfoFor(obj.ClusterKey())
 	if len(addons) > 0 {
@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package source
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+)
+
+func TestGetAnnotatedRoutingPolicy(t *testing.T) {
+	weighted := `{"type": "weighted", "setIdentifier": "blue", "parameters": {"weight": "10"}}`
+	withoutID := `{"type": "weighted", "parameters": {"weight": "10"}}`
+
+	table := []struct {
+		name     string
+		annos    map[string]string
+		expected *v1alpha1.RoutingPolicy
+		err      bool
+	}{
+		{"no annotations", nil, nil, false},
+		{"routing policy", map[string]string{ROUTING_POLICY_ANNOTATION: weighted},
+			&v1alpha1.RoutingPolicy{Type: "weighted", SetIdentifier: "blue", Parameters: map[string]string{"weight": "10"}}, false},
+		{"set identifier annotation", map[string]string{ROUTING_POLICY_ANNOTATION: withoutID, SET_IDENTIFIER_ANNOTATION: "green"},
+			&v1alpha1.RoutingPolicy{Type: "weighted", SetIdentifier: "green", Parameters: map[string]string{"weight": "10"}}, false},
+		{"set identifier annotation overwrites", map[string]string{ROUTING_POLICY_ANNOTATION: weighted, SET_IDENTIFIER_ANNOTATION: "green"},
+			&v1alpha1.RoutingPolicy{Type: "weighted", SetIdentifier: "green", Parameters: map[string]string{"weight": "10"}}, false},
+		{"missing set identifier", map[string]string{ROUTING_POLICY_ANNOTATION: withoutID}, nil, true},
+		{"missing type", map[string]string{ROUTING_POLICY_ANNOTATION: `{"setIdentifier": "blue"}`}, nil, true},
+		{"set identifier without routing policy", map[string]string{SET_IDENTIFIER_ANNOTATION: "green"}, nil, true},
+		{"invalid JSON", map[string]string{ROUTING_POLICY_ANNOTATION: "{"}, nil, true},
+	}
+	for _, e := range table {
+		policy, err := getAnnotatedRoutingPolicy(e.annos)
+		if (err != nil) != e.err {
+			t.Errorf("%s: unexpected error %v", e.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(policy, e.expected) {
+			t.Errorf("%s: expected %v, got %v", e.name, e.expected, policy)
+		}
+	}
+}
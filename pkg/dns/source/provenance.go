@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package source
+
+import (
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+// injectedTriggerSuffix marks a trigger annotation injected by a DNSAnnotation object.
+const injectedTriggerSuffix = " (injected by DNSAnnotation)"
+
+// provenanceAnnotations returns the annotations recording the source object of a generated DNS entry.
+func (this *sourceReconciler) provenanceAnnotations(obj resources.Object) map[string]string {
+	annotated := obj.GetAnnotations()[DNS_ANNOTATION] != ""
+	injected := this.annotations.GetInfoFor(obj.ClusterKey())[DNS_ANNOTATION] != ""
+	return provenanceAnnotations(obj.GroupKind(), obj.GetNamespace(), obj.GetName(), annotated, injected)
+}
+
+func provenanceAnnotations(gk schema.GroupKind, namespace, name string, annotated, injected bool) map[string]string {
+	annotations := map[string]string{
+		dns.AnnotationSourceKind: gk.String(),
+		dns.AnnotationSourceName: name,
+	}
+	if namespace != "" {
+		annotations[dns.AnnotationSourceNamespace] = namespace
+	}
+	switch {
+	case annotated:
+		annotations[dns.AnnotationSourceTrigger] = DNS_ANNOTATION
+	case injected:
+		annotations[dns.AnnotationSourceTrigger] = DNS_ANNOTATION + injectedTriggerSuffix
+	}
+	return annotations
+}
+
+// assureProvenanceAnnotations sets the given provenance annotations and removes obsolete ones.
+func assureProvenanceAnnotations(o resources.ObjectData, annotations map[string]string) bool {
+	modified := false
+	for _, key := range []string{dns.AnnotationSourceKind, dns.AnnotationSourceNamespace, dns.AnnotationSourceName, dns.AnnotationSourceTrigger} {
+		if value, ok := annotations[key]; ok {
+			modified = resources.SetAnnotation(o, key, value) || modified
+		} else {
+			modified = resources.RemoveAnnotation(o, key) || modified
+		}
+	}
+	return modified
+}
@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package source
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+func TestProvenanceAnnotations(t *testing.T) {
+	ingress := resources.NewGroupKind("networking.k8s.io", "Ingress")
+
+	table := []struct {
+		name      string
+		namespace string
+		annotated bool
+		injected  bool
+		expected  map[string]string
+	}{
+		{"annotated", "default", true, false, map[string]string{
+			dns.AnnotationSourceKind:      "Ingress.networking.k8s.io",
+			dns.AnnotationSourceNamespace: "default",
+			dns.AnnotationSourceName:      "test",
+			dns.AnnotationSourceTrigger:   DNS_ANNOTATION,
+		}},
+		{"annotated and injected", "default", true, true, map[string]string{
+			dns.AnnotationSourceKind:      "Ingress.networking.k8s.io",
+			dns.AnnotationSourceNamespace: "default",
+			dns.AnnotationSourceName:      "test",
+			dns.AnnotationSourceTrigger:   DNS_ANNOTATION,
+		}},
+		{"injected", "default", false, true, map[string]string{
+			dns.AnnotationSourceKind:      "Ingress.networking.k8s.io",
+			dns.AnnotationSourceNamespace: "default",
+			dns.AnnotationSourceName:      "test",
+			dns.AnnotationSourceTrigger:   DNS_ANNOTATION + " (injected by DNSAnnotation)",
+		}},
+		{"cluster scoped without trigger", "", false, false, map[string]string{
+			dns.AnnotationSourceKind: "Ingress.networking.k8s.io",
+			dns.AnnotationSourceName: "test",
+		}},
+	}
+	for _, entry := range table {
+		actual := provenanceAnnotations(ingress, entry.namespace, "test", entry.annotated, entry.injected)
+		if !reflect.DeepEqual(actual, entry.expected) {
+			t.Errorf("%s: expected %v, but got %v", entry.name, entry.expected, actual)
+		}
+	}
+}
+
+func TestAssureProvenanceAnnotations(t *testing.T) {
+	entry := &api.DNSEntry{}
+	entry.Annotations = map[string]string{dns.AnnotationSourceTrigger: DNS_ANNOTATION, "other": "x"}
+	annotations := map[string]string{dns.AnnotationSourceKind: "Service", dns.AnnotationSourceName: "test"}
+
+	if !assureProvenanceAnnotations(entry, annotations) {
+		t.Errorf("expected modification")
+	}
+	expected := map[string]string{dns.AnnotationSourceKind: "Service", dns.AnnotationSourceName: "test", "other": "x"}
+	if !reflect.DeepEqual(entry.Annotations, expected) {
+		t.Errorf("expected %v, but got %v", expected, entry.Annotations)
+	}
+	if assureProvenanceAnnotations(entry, annotations) {
+		t.Errorf("expected no modification")
+	}
+}
@@ -79,6 +79,7 @@ func SourceReconciler(sourceType DNSSourceType, rtype controller.ReconcilerType)
 		reconciler.creatorLabelName, _ = c.GetStringOption(OPT_TARGET_CREATOR_LABEL_NAME)
 		reconciler.creatorLabelValue, _ = c.GetStringOption(OPT_TARGET_CREATOR_LABEL_VALUE)
 		reconciler.setIgnoreOwners, _ = c.GetBoolOption(OPT_TARGET_SET_IGNORE_OWNERS)
+		reconciler.clusterName, _ = c.GetStringOption(OPT_CLUSTER_NAME)
 
 		excluded, _ := c.GetStringArrayOption(OPT_EXCLUDE)
 		reconciler.excluded = utils.NewStringSetByArray(excluded)
@@ -110,6 +111,7 @@ type sourceReconciler struct {
 	creatorLabelName  string
 	creatorLabelValue string
 	setIgnoreOwners   bool
+	clusterName       string
 
 	state       *state
 	annotations *annotations.State
@@ -494,6 +496,7 @@ func (this *sourceReconciler) createEntryFor(logger logger.LogContext, obj resou
 	} else {
 		resources.RemoveAnnotation(entry, dns.AnnotationIgnore)
 	}
+	assureProvenanceAnnotations(entry, this.provenanceAnnotations(obj))
 
 	e, _ := this.SlaveResoures()[0].Wrap(entry)
 
@@ -601,6 +604,7 @@ func (this *sourceReconciler) updateEntryFor(logger logger.LogContext, obj resou
 		} else {
 			mod.Modify(resources.RemoveAnnotation(o, dns.AnnotationIgnore))
 		}
+		mod.Modify(assureProvenanceAnnotations(o, this.provenanceAnnotations(obj)))
 
 		if mod.IsModified() {
 			logger.Infof("update entry %s", slave.ObjectName())
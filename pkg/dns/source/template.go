@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package source
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+// dnsNameTemplateData is the data available in DNS name templates of the dns annotation,
+// e.g. `{{.Namespace}}.{{.Cluster}}.example.com`.
+type dnsNameTemplateData struct {
+	Name        string
+	Namespace   string
+	Cluster     string
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+func newDNSNameTemplateData(obj resources.Object, cluster string) *dnsNameTemplateData {
+	return &dnsNameTemplateData{
+		Name:        obj.GetName(),
+		Namespace:   obj.GetNamespace(),
+		Cluster:     cluster,
+		Labels:      getSafeMap(obj.GetLabels()),
+		Annotations: getSafeMap(obj.GetAnnotations()),
+	}
+}
+
+// isDNSNameTemplate returns true if the annotated DNS name contains template actions.
+func isDNSNameTemplate(name string) bool {
+	return strings.Contains(name, "{{")
+}
+
+// renderDNSNameTemplate renders a DNS name template against the object metadata and validates the resulting DNS name.
+func renderDNSNameTemplate(text string, data *dnsNameTemplateData) (string, error) {
+	tmpl, err := template.New("dnsName").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid DNS name template %q: %w", text, err)
+	}
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("cannot render DNS name template %q: %w", text, err)
+	}
+	name := strings.TrimSpace(sb.String())
+	if err := dns.ValidateDomainName(name); err != nil {
+		return "", fmt.Errorf("invalid DNS name rendered from template %q: %w", text, err)
+	}
+	return name, nil
+}
+
+// renderAnnotatedNames replaces the DNS name templates of the annotated DNS names by the rendered DNS names.
+func renderAnnotatedNames(names utils.StringSet, data *dnsNameTemplateData) (utils.StringSet, error) {
+	rendered := utils.StringSet{}
+	for name := range names {
+		if !isDNSNameTemplate(name) {
+			rendered.Add(name)
+			continue
+		}
+		result, err := renderDNSNameTemplate(name, data)
+		if err != nil {
+			return nil, err
+		}
+		rendered.Add(result)
+	}
+	return rendered, nil
+}
@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package source
+
+import (
+	"testing"
+
+	"github.com/gardener/controller-manager-library/pkg/utils"
+)
+
+func TestRenderDNSNameTemplate(t *testing.T) {
+	data := &dnsNameTemplateData{
+		Name:      "web",
+		Namespace: "shop",
+		Cluster:   "eu1",
+		Labels:    map[string]string{"team": "blue"},
+	}
+
+	table := []struct {
+		template string
+		expected string
+		err      bool
+	}{
+		{"{{.Namespace}}.{{.Cluster}}.example.com", "shop.eu1.example.com", false},
+		{"{{ .Name }}-{{ index .Labels \"team\" }}.example.com", "web-blue.example.com", false},
+		{"{{.Unknown}}.example.com", "", true},
+		{"{{.Namespace.example.com", "", true},
+		{"{{index .Labels \"missing\"}}.example.com", "", true},
+		{"{{.Name}}_{{.Namespace}}.example.com", "", true},
+	}
+	for _, e := range table {
+		result, err := renderDNSNameTemplate(e.template, data)
+		if (err != nil) != e.err {
+			t.Errorf("%s: unexpected error %v", e.template, err)
+			continue
+		}
+		if result != e.expected {
+			t.Errorf("%s: expected %q, but got %q", e.template, e.expected, result)
+		}
+	}
+}
+
+func TestRenderAnnotatedNames(t *testing.T) {
+	data := &dnsNameTemplateData{Name: "web", Namespace: "shop", Cluster: "eu1"}
+	names := utils.NewStringSet("a.example.com", "*", "{{.Namespace}}.example.com")
+
+	rendered, err := renderAnnotatedNames(names, data)
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if !rendered.Equals(utils.NewStringSet("a.example.com", "*", "shop.example.com")) {
+		t.Errorf("unexpected rendered names %v", rendered)
+	}
+}
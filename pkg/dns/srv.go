@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dns
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// SRVRecord is the parsed value of a SRV record in the format `priority weight port target`.
+type SRVRecord struct {
+	Priority uint16
+	Weight   uint16
+	Port     uint16
+	Target   string
+}
+
+// ParseSRVRecord parses and validates a SRV record value in the format `priority weight port target`.
+// The target is normalized (i.e. without trailing dot).
+func ParseSRVRecord(value string) (*SRVRecord, error) {
+	fields := strings.Fields(value)
+	if len(fields) != 4 {
+		return nil, fmt.Errorf("invalid SRV record %q: expected format 'priority weight port target'", value)
+	}
+	var numbers [3]uint16
+	for i, name := range []string{"priority", "weight", "port"} {
+		n, err := strconv.ParseUint(fields[i], 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SRV record %q: %s must be a number between 0 and 65535", value, name)
+		}
+		numbers[i] = uint16(n)
+	}
+	target := fields[3]
+	if target != "." {
+		target = NormalizeHostname(target)
+		if errs := validation.IsDNS1123Subdomain(strings.ToLower(target)); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid SRV record %q: target %q is no valid dns name (%v)", value, fields[3], errs)
+		}
+	}
+	return &SRVRecord{Priority: numbers[0], Weight: numbers[1], Port: numbers[2], Target: target}, nil
+}
+
+// String returns the normalized record value.
+func (r *SRVRecord) String() string {
+	return fmt.Sprintf("%d %d %d %s", r.Priority, r.Weight, r.Port, r.Target)
+}
+
+// AlignedString returns the record value with a fully qualified target (i.e. with trailing dot).
+func (r *SRVRecord) AlignedString() string {
+	return fmt.Sprintf("%d %d %d %s", r.Priority, r.Weight, r.Port, AlignHostname(r.Target))
+}
+
+// NormalizeSRVValue returns the normalized SRV record value, or the unchanged value if it cannot be parsed.
+func NormalizeSRVValue(value string) string {
+	if r, err := ParseSRVRecord(value); err == nil {
+		return r.String()
+	}
+	return value
+}
+
+// AlignSRVValue returns the SRV record value with fully qualified target, or the unchanged value if it cannot be parsed.
+func AlignSRVValue(value string) string {
+	if r, err := ParseSRVRecord(value); err == nil {
+		return r.AlignedString()
+	}
+	return value
+}
@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dns
+
+import (
+	"testing"
+)
+
+func TestParseSRVRecord(t *testing.T) {
+	table := []struct {
+		input      string
+		ok         bool
+		normalized string
+		aligned    string
+	}{
+		{"10 5 5060 sip.example.com", true, "10 5 5060 sip.example.com", "10 5 5060 sip.example.com."},
+		{"10 5 5060 sip.example.com.", true, "10 5 5060 sip.example.com", "10 5 5060 sip.example.com."},
+		{"  0   0  443   a.b ", true, "0 0 443 a.b", "0 0 443 a.b."},
+		{"0 0 0 .", true, "0 0 0 .", "0 0 0 ."},
+		{"65535 65535 65535 a.b", true, "65535 65535 65535 a.b", "65535 65535 65535 a.b."},
+		{"10 5 5060", false, "", ""},
+		{"10 5 5060 sip.example.com extra", false, "", ""},
+		{"-1 5 5060 sip.example.com", false, "", ""},
+		{"10 x 5060 sip.example.com", false, "", ""},
+		{"10 5 65536 sip.example.com", false, "", ""},
+		{"10 5 5060 sip_example.com", false, "", ""},
+		{"", false, "", ""},
+	}
+	for _, entry := range table {
+		r, err := ParseSRVRecord(entry.input)
+		if entry.ok && err != nil {
+			t.Errorf("%q should be ok, but got error %s", entry.input, err)
+			continue
+		} else if !entry.ok {
+			if err == nil {
+				t.Errorf("%q should not be ok, but got no error", entry.input)
+			}
+			continue
+		}
+		if r.String() != entry.normalized {
+			t.Errorf("%q: expected normalized %q, but got %q", entry.input, entry.normalized, r.String())
+		}
+		if r.AlignedString() != entry.aligned {
+			t.Errorf("%q: expected aligned %q, but got %q", entry.input, entry.aligned, r.AlignedString())
+		}
+	}
+}
@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dns
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxTXTCharacterStringLength is the maximum length of a single character string of a TXT record (RFC 1035).
+const MaxTXTCharacterStringLength = 255
+
+// TXTRecordLimits describes the restrictions of a DNS provider for TXT record values.
+type TXTRecordLimits struct {
+	// ChunkSize is the maximum length of a single character string.
+	// If set, longer texts are split into multiple character strings.
+	ChunkSize int
+	// MaxLength is the maximum total length of a text (unlimited if zero).
+	MaxLength int
+}
+
+// SplitTXTValue splits the text of a quoted TXT record value into quoted character strings
+// of at most chunkSize bytes separated by blanks, e.g. `"abc" "def"`.
+// Values already consisting of multiple character strings are rechunked.
+// Multi-byte characters are never split.
+func SplitTXTValue(value string, chunkSize int) string {
+	if chunkSize <= 0 {
+		return value
+	}
+	text, ok := unquoteTXTValue(value)
+	if !ok || len(text) <= chunkSize {
+		return JoinTXTValue(value)
+	}
+	var chunks []string
+	for len(text) > chunkSize {
+		n := chunkSize
+		for n > 0 && !utf8.RuneStart(text[n]) {
+			n--
+		}
+		if n == 0 {
+			n = chunkSize
+		}
+		chunks = append(chunks, strconv.Quote(text[:n]))
+		text = text[n:]
+	}
+	chunks = append(chunks, strconv.Quote(text))
+	return strings.Join(chunks, " ")
+}
+
+// JoinTXTValue reassembles a TXT record value consisting of multiple quoted character strings
+// into a single quoted string. Other values are returned unchanged.
+func JoinTXTValue(value string) string {
+	chunks, ok := splitQuotedStrings(value)
+	if !ok || len(chunks) < 2 {
+		return value
+	}
+	return strconv.Quote(strings.Join(chunks, ""))
+}
+
+// TXTTextLength returns the length of the unquoted text of a TXT record value.
+func TXTTextLength(value string) int {
+	if text, ok := unquoteTXTValue(value); ok {
+		return len(text)
+	}
+	return len(value)
+}
+
+func unquoteTXTValue(value string) (string, bool) {
+	chunks, ok := splitQuotedStrings(value)
+	if !ok {
+		return "", false
+	}
+	return strings.Join(chunks, ""), true
+}
+
+// splitQuotedStrings returns the unquoted strings of a sequence of quoted strings separated by blanks.
+func splitQuotedStrings(value string) ([]string, bool) {
+	var result []string
+	rest := strings.TrimSpace(value)
+	for rest != "" {
+		quoted, err := strconv.QuotedPrefix(rest)
+		if err != nil || !strings.HasPrefix(quoted, `"`) {
+			return nil, false
+		}
+		s, err := strconv.Unquote(quoted)
+		if err != nil {
+			return nil, false
+		}
+		result = append(result, s)
+		rest = strings.TrimLeft(rest[len(quoted):], " ")
+	}
+	return result, len(result) > 0
+}
@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dns
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestSplitTXTValue(t *testing.T) {
+	long := strings.Repeat("a", 255) + strings.Repeat("b", 255) + "c"
+	table := []struct {
+		input     string
+		chunkSize int
+		expected  string
+	}{
+		{`"short"`, 255, `"short"`},
+		{`"short"`, 0, `"short"`},
+		{`"abcdef"`, 3, `"abc" "def"`},
+		{`"abcdefg"`, 3, `"abc" "def" "g"`},
+		{`"ab" "cdefg"`, 3, `"abc" "def" "g"`},
+		{`"ab" "cd"`, 255, `"abcd"`},
+		{`"a\"bcd"`, 2, `"a\"" "bc" "d"`},
+		{`"aäb"`, 2, `"a" "ä" "b"`},
+		{`not quoted`, 3, `not quoted`},
+		{strconv.Quote(long), 255, strconv.Quote(long[:255]) + " " + strconv.Quote(long[255:510]) + ` "c"`},
+	}
+	for _, entry := range table {
+		result := SplitTXTValue(entry.input, entry.chunkSize)
+		if result != entry.expected {
+			t.Errorf("%q with chunk size %d: expected %q, but got %q", entry.input, entry.chunkSize, entry.expected, result)
+		}
+	}
+}
+
+func TestJoinTXTValue(t *testing.T) {
+	table := []struct {
+		input    string
+		expected string
+	}{
+		{`"abc"`, `"abc"`},
+		{`"abc" "def"`, `"abcdef"`},
+		{`"abc"  "d\"ef" "g"`, `"abcd\"efg"`},
+		{`"abc" def`, `"abc" def`},
+		{`abc`, `abc`},
+		{``, ``},
+	}
+	for _, entry := range table {
+		result := JoinTXTValue(entry.input)
+		if result != entry.expected {
+			t.Errorf("%q: expected %q, but got %q", entry.input, entry.expected, result)
+		}
+	}
+}
+
+func TestTXTTextLength(t *testing.T) {
+	if l := TXTTextLength(`"abc" "d\"e"`); l != 6 {
+		t.Errorf("expected length 6, but got %d", l)
+	}
+}
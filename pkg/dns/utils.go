@@ -4,10 +4,18 @@
 
 package dns
 
+import (
+	"slices"
+)
+
+// supportedRecordTypes contains all record types which can be specified by DNS entries.
+var supportedRecordTypes = []string{RS_A, RS_AAAA, RS_CNAME, RS_TXT, RS_SRV, RS_CAA, RS_MX, RS_NS, RS_DS, RS_PTR}
+
 func SupportedRecordType(t string) bool {
-	switch t {
-	case RS_CNAME, RS_A, RS_AAAA, RS_TXT:
-		return true
-	}
-	return false
+	return slices.Contains(supportedRecordTypes, t)
+}
+
+// SupportedRecordTypes returns all record types which can be specified by DNS entries.
+func SupportedRecordTypes() []string {
+	return slices.Clone(supportedRecordTypes)
 }
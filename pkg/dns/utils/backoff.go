@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// Backoff calculates exponentially growing delays with jitter.
+type Backoff struct {
+	lock     sync.Mutex
+	min      time.Duration
+	max      time.Duration
+	attempts int
+}
+
+// NewBackoff creates a backoff starting with delay min, doubling the delay on every attempt up to max.
+func NewBackoff(min, max time.Duration) *Backoff {
+	if min <= 0 {
+		min = time.Second
+	}
+	if max < min {
+		max = min
+	}
+	return &Backoff{
+		min: min,
+		max: max,
+	}
+}
+
+// Next increments the number of attempts and returns the delay to wait before the next attempt.
+// The delay is randomized in the range [d/2, d] with d being the exponential delay for the attempt.
+func (this *Backoff) Next() (time.Duration, int) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	delay := this.min
+	for i := 0; i < this.attempts && delay < this.max; i++ {
+		delay *= 2
+	}
+	if delay > this.max {
+		delay = this.max
+	}
+	this.attempts++
+	half := delay / 2
+	return half + rand.N(delay-half+1), this.attempts
+}
+
+// Attempts returns the number of attempts since the last reset.
+func (this *Backoff) Attempts() int {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return this.attempts
+}
+
+// Reset resets the backoff to its initial delay.
+func (this *Backoff) Reset() {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.attempts = 0
+}
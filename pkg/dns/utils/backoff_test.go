@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Backoff", func() {
+	It("increases the delay exponentially with jitter up to the maximum", func() {
+		backoff := NewBackoff(10*time.Second, 60*time.Second)
+		for i, expected := range []time.Duration{10, 20, 40, 60, 60} {
+			expected *= time.Second
+			delay, attempts := backoff.Next()
+			Expect(attempts).To(Equal(i + 1))
+			Expect(delay).To(BeNumerically(">=", expected/2))
+			Expect(delay).To(BeNumerically("<=", expected))
+		}
+	})
+
+	It("starts again with the minimum delay after reset", func() {
+		backoff := NewBackoff(10*time.Second, 60*time.Second)
+		backoff.Next()
+		backoff.Next()
+		backoff.Reset()
+		Expect(backoff.Attempts()).To(Equal(0))
+		delay, attempts := backoff.Next()
+		Expect(attempts).To(Equal(1))
+		Expect(delay).To(BeNumerically("<=", 10*time.Second))
+	})
+})
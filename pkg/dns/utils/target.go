@@ -20,7 +20,8 @@ func (this Targets) Has(target Target) bool {
 	for _, t := range this {
 		if t.GetRecordType() == target.GetRecordType() &&
 			t.GetHostName() == target.GetHostName() &&
-			t.GetIPStack() == target.GetIPStack() {
+			t.GetIPStack() == target.GetIPStack() &&
+			t.GetAliasHostedZone() == target.GetAliasHostedZone() {
 			return true
 		}
 	}
@@ -45,13 +46,16 @@ type Target interface {
 	GetTTL() int64
 	AsRecord() *dns.Record
 	GetIPStack() string
+	// GetAliasHostedZone returns the explicitly requested hosted zone of an alias target (provider specific).
+	GetAliasHostedZone() string
 }
 
 type target struct {
-	rtype   string
-	host    string
-	ttl     int64
-	ipstack string
+	rtype           string
+	host            string
+	ttl             int64
+	ipstack         string
+	aliasHostedZone string
 }
 
 func NewText(t string, ttl int64) Target {
@@ -66,10 +70,15 @@ func NewTargetWithIPStack(ty string, ta string, ttl int64, ipstack string) Targe
 	return &target{rtype: ty, host: ta, ttl: ttl, ipstack: ipstack}
 }
 
-func (t *target) GetTTL() int64         { return t.ttl }
-func (t *target) GetHostName() string   { return t.host }
-func (t *target) GetRecordType() string { return t.rtype }
-func (t *target) GetIPStack() string    { return t.ipstack }
+func NewTargetWithAliasHostedZone(ty string, ta string, ttl int64, ipstack, aliasHostedZone string) Target {
+	return &target{rtype: ty, host: ta, ttl: ttl, ipstack: ipstack, aliasHostedZone: aliasHostedZone}
+}
+
+func (t *target) GetTTL() int64              { return t.ttl }
+func (t *target) GetHostName() string        { return t.host }
+func (t *target) GetRecordType() string      { return t.rtype }
+func (t *target) GetIPStack() string         { return t.ipstack }
+func (t *target) GetAliasHostedZone() string { return t.aliasHostedZone }
 
 func (t *target) AsRecord() *dns.Record {
 	return &dns.Record{Value: t.host}
@@ -88,6 +97,8 @@ type TargetSpec interface {
 	OwnerId() string
 	Targets() []Target
 	RoutingPolicy() *dns.RoutingPolicy
+	Comment() string
+	ProviderAttributes() map[string]string
 	Responsible(set *dns.DNSSet, ownership dns.Ownership) bool
 }
 
@@ -96,6 +107,8 @@ type targetSpec struct {
 	ownerId       string
 	targets       []Target
 	routingPolicy *dns.RoutingPolicy
+	comment       string
+	attributes    map[string]string
 }
 
 func BaseTargetSpec(entry *DNSEntryObject, p TargetProvider) TargetSpec {
@@ -104,6 +117,8 @@ func BaseTargetSpec(entry *DNSEntryObject, p TargetProvider) TargetSpec {
 		ownerId:       p.OwnerId(),
 		targets:       p.Targets(),
 		routingPolicy: p.RoutingPolicy(),
+		comment:       p.Comment(),
+		attributes:    p.ProviderAttributes(),
 	}
 	return spec
 }
@@ -127,3 +142,11 @@ func (this *targetSpec) Responsible(set *dns.DNSSet, ownership dns.Ownership) bo
 func (this *targetSpec) RoutingPolicy() *dns.RoutingPolicy {
 	return this.routingPolicy
 }
+
+func (this *targetSpec) Comment() string {
+	return this.comment
+}
+
+func (this *targetSpec) ProviderAttributes() map[string]string {
+	return this.attributes
+}
@@ -13,6 +13,8 @@ type TargetProvider interface {
 	TTL() int64
 	OwnerId() string
 	RoutingPolicy() *dns.RoutingPolicy
+	Comment() string
+	ProviderAttributes() map[string]string
 }
 
 // TTLToUint32 converts a TTL value to an uint32 value.
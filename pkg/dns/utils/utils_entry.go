@@ -6,6 +6,7 @@ package utils
 
 import (
 	"reflect"
+	"strings"
 
 	"github.com/gardener/controller-manager-library/pkg/resources"
 	"github.com/gardener/controller-manager-library/pkg/utils"
@@ -66,6 +67,14 @@ func (this *DNSEntryObject) GetText() []string {
 	return this.DNSEntry().Spec.Text
 }
 
+func (this *DNSEntryObject) GetRecordType() string {
+	return this.DNSEntry().Spec.RecordType
+}
+
+func (this *DNSEntryObject) GetRecords() []string {
+	return this.DNSEntry().Spec.Records
+}
+
 func (this *DNSEntryObject) GetOwnerId() *string {
 	return this.DNSEntry().Spec.OwnerId
 }
@@ -82,14 +91,49 @@ func (this *DNSEntryObject) ResolveTargetsToAddresses() *bool {
 	return this.DNSEntry().Spec.ResolveTargetsToAddresses
 }
 
+// PreserveOnDelete returns true if the records of the entry are preserved on its deletion.
+func (this *DNSEntryObject) PreserveOnDelete() bool {
+	preserve := this.DNSEntry().Spec.PreserveOnDelete
+	return preserve != nil && *preserve
+}
+
+func (this *DNSEntryObject) ResolveTargetsExclusions() []string {
+	return this.DNSEntry().Spec.ResolveTargetsExclusions
+}
+
 func (this *DNSEntryObject) GetReference() *api.EntryReference {
 	return this.DNSEntry().Spec.Reference
 }
 
+func (this *DNSEntryObject) GetTargetRef() *api.TargetReference {
+	return this.DNSEntry().Spec.TargetRef
+}
+
 func (this *DNSEntryObject) GetRoutingPolicy() *dns.RoutingPolicy {
 	return ToDNSRoutingPolicy(this.DNSEntry().Spec.RoutingPolicy)
 }
 
+// GetProviderAttributes returns the provider specific record attributes from the annotations
+// with prefix dns.AnnotationProviderAttributePrefix (nil if there are none).
+func (this *DNSEntryObject) GetProviderAttributes() map[string]string {
+	return ProviderAttributesFromAnnotations(this.GetAnnotations())
+}
+
+// ProviderAttributesFromAnnotations extracts the provider specific record attributes from annotations
+// with prefix dns.AnnotationProviderAttributePrefix (nil if there are none).
+func ProviderAttributesFromAnnotations(annotations map[string]string) map[string]string {
+	var attrs map[string]string
+	for k, v := range annotations {
+		if name, ok := strings.CutPrefix(k, dns.AnnotationProviderAttributePrefix); ok && name != "" {
+			if attrs == nil {
+				attrs = map[string]string{}
+			}
+			attrs[name] = v
+		}
+	}
+	return attrs
+}
+
 func (this *DNSEntryObject) AcknowledgeTargets(targets []string) bool {
 	s := this.Status()
 	if !reflect.DeepEqual(s.Targets, targets) {
@@ -6,6 +6,7 @@ package utils
 
 import (
 	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
 	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
 )
 
@@ -43,6 +44,15 @@ func (this *DNSOwnerObject) IsActive() bool {
 	return this.IsEnabled()
 }
 
+// GetNamespaces returns the namespaces the owner id is restricted to or nil if it is valid for all namespaces.
+func (this *DNSOwnerObject) GetNamespaces() utils.StringSet {
+	selector := this.DNSOwner().Spec.NamespaceSelector
+	if selector == nil {
+		return nil
+	}
+	return utils.NewStringSet(selector.MatchNames...)
+}
+
 func (this *DNSOwnerObject) GetCounts() map[string]int {
 	return this.DNSOwner().Status.Entries.ByType
 }
@@ -5,6 +5,7 @@
 package utils
 
 import (
+	"reflect"
 	"strings"
 
 	"golang.org/x/xerrors"
@@ -109,6 +110,19 @@ func (this *DNSProviderObject) SetSelection(included, excluded utils.StringSet,
 	return modified
 }
 
+// SetProblematicZones sets the zones not served together with the reason.
+func (this *DNSProviderObject) SetProblematicZones(problematic []api.ProblematicZone) bool {
+	status := this.Status()
+	if len(problematic) == 0 && len(status.ProblematicZones) == 0 {
+		return false
+	}
+	if reflect.DeepEqual(problematic, status.ProblematicZones) {
+		return false
+	}
+	status.ProblematicZones = problematic
+	return true
+}
+
 func DNSProvider(o resources.Object) *DNSProviderObject {
 	if o.IsA(DNSProviderType) {
 		return &DNSProviderObject{o}
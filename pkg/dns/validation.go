@@ -13,10 +13,15 @@ import (
 
 func ValidateDomainName(name string) error {
 	check := NormalizeHostname(name)
-	if strings.HasPrefix(check, "_") {
-		// allow "_" prefix, as it is used for DNS challenges of Let's encrypt
-		check = "x" + check[1:]
+	// allow "_" prefix of labels, as it is used for DNS challenges of Let's encrypt
+	// and for service and protocol labels of SRV records (e.g. `_sip._tcp.example.com`)
+	checkLabels := strings.Split(check, ".")
+	for i, label := range checkLabels {
+		if strings.HasPrefix(label, "_") {
+			checkLabels[i] = "x" + label[1:]
+		}
 	}
+	check = strings.Join(checkLabels, ".")
 
 	var errs []string
 	if strings.HasPrefix(check, "*.") {
@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package zonefile
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+// Write writes the record sets of a zone in BIND zone file format.
+// Record sets with routing policy cannot be represented in a zone file. They are written as comments
+// together with the routing policy and the set identifier.
+func Write(w io.Writer, origin string, dnssets dns.DNSSets) error {
+	origin = dns.NormalizeHostname(origin)
+	out := &writer{w: w, origin: origin}
+	out.printf("$ORIGIN %s\n", dns.AlignHostname(origin))
+
+	names := make([]dns.DNSSetName, 0, len(dnssets))
+	for name := range dnssets {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if names[i].DNSName != names[j].DNSName {
+			return names[i].DNSName < names[j].DNSName
+		}
+		return names[i].SetIdentifier < names[j].SetIdentifier
+	})
+
+	for _, name := range names {
+		dnsset := dnssets[name].Clone()
+		rtypes := make([]string, 0, len(dnsset.Sets))
+		for rtype := range dnsset.Sets {
+			rtypes = append(rtypes, rtype)
+		}
+		sort.Strings(rtypes)
+
+		prefix := ""
+		if dnsset.RoutingPolicy != nil || name.SetIdentifier != "" {
+			prefix = "; "
+			out.printf("; routing policy %s\n", describeRoutingPolicy(name, dnsset.RoutingPolicy))
+		}
+		for _, rtype := range rtypes {
+			setName, rs := dns.MapToProvider(rtype, dnsset, origin)
+			for _, r := range rs.Records {
+				out.printf("%s%s\t%d\tIN\t%s\t%s\n", prefix, out.relativeName(setName.DNSName), rs.TTL, rs.Type, formatValue(rs.Type, r.Value))
+			}
+		}
+	}
+	return out.err
+}
+
+type writer struct {
+	w      io.Writer
+	origin string
+	err    error
+}
+
+func (this *writer) printf(format string, args ...any) {
+	if this.err == nil {
+		_, this.err = fmt.Fprintf(this.w, format, args...)
+	}
+}
+
+// relativeName returns the name relative to the origin, or `@` for the origin itself.
+func (this *writer) relativeName(name string) string {
+	name = dns.NormalizeHostname(name)
+	if name == this.origin {
+		return "@"
+	}
+	if strings.HasSuffix(name, "."+this.origin) {
+		return strings.TrimSuffix(name, "."+this.origin)
+	}
+	return dns.AlignHostname(name)
+}
+
+func formatValue(rtype, value string) string {
+	switch rtype {
+	case dns.RS_CNAME, dns.RS_NS, dns.RS_PTR:
+		return dns.AlignHostname(value)
+	case dns.RS_SRV:
+		if fields := strings.Fields(value); len(fields) == 4 {
+			fields[3] = dns.AlignHostname(fields[3])
+			return strings.Join(fields, " ")
+		}
+	case dns.RS_MX:
+		return dns.AlignMXValue(value)
+	case dns.RS_TXT:
+		if !strings.HasPrefix(value, "\"") {
+			return fmt.Sprintf("%q", value)
+		}
+	}
+	return value
+}
+
+func describeRoutingPolicy(name dns.DNSSetName, policy *dns.RoutingPolicy) string {
+	if policy == nil {
+		return fmt.Sprintf("<unknown> (set identifier %s)", name.SetIdentifier)
+	}
+	keys := make([]string, 0, len(policy.Parameters))
+	for k := range policy.Parameters {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	params := make([]string, len(keys))
+	for i, k := range keys {
+		params[i] = fmt.Sprintf("%s=%s", k, policy.Parameters[k])
+	}
+	return fmt.Sprintf("%s (set identifier %s): %s", policy.Type, name.SetIdentifier, strings.Join(params, ", "))
+}
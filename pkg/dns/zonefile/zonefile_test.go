@@ -0,0 +1,39 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package zonefile
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+func TestWrite(t *testing.T) {
+	dnssets := dns.DNSSets{}
+	dnssets.AddRecordSetFromProvider("example.com", dns.NewRecordSet(dns.RS_A, 300, []*dns.Record{{Value: "1.2.3.4"}}))
+	dnssets.AddRecordSetFromProvider("www.example.com", dns.NewRecordSet(dns.RS_CNAME, 600, []*dns.Record{{Value: "example.org"}}))
+	dnssets.AddRecordSetFromProvider("txt.example.com", dns.NewRecordSet(dns.RS_TXT, 60, []*dns.Record{{Value: "\"foo bar\""}}))
+	dnssets.AddRecordSetFromProvider("_sip._tcp.example.com", dns.NewRecordSet(dns.RS_SRV, 60, []*dns.Record{{Value: "0 5 5060 sip.example.com"}}))
+	dnssets.AddRecordSetFromProviderEx(dns.DNSSetName{DNSName: "weighted.example.com", SetIdentifier: "b"},
+		dns.NewRoutingPolicy(dns.RoutingPolicyWeighted, "weight", "10"),
+		dns.NewRecordSet(dns.RS_A, 120, []*dns.Record{{Value: "5.6.7.8"}}))
+
+	buf := &bytes.Buffer{}
+	if err := Write(buf, "example.com.", dnssets); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := `$ORIGIN example.com.
+_sip._tcp	60	IN	SRV	0 5 5060 sip.example.com.
+@	300	IN	A	1.2.3.4
+txt	60	IN	TXT	"foo bar"
+; routing policy weighted (set identifier b): weight=10
+; weighted	120	IN	A	5.6.7.8
+www	600	IN	CNAME	example.org.
+`
+	if buf.String() != expected {
+		t.Errorf("unexpected zone file:\n%s\nexpected:\n%s", buf.String(), expected)
+	}
+}
@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package admission
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// StartServer starts the HTTPS server for the admission webhooks.
+// The server certificate is read from the files `tls.crt` and `tls.key` in the certificate directory.
+// It is reloaded if the files are modified, e.g. on certificate rotation.
+func StartServer(ctx context.Context, logctx logger.LogContext, port int, certDir string, validator *EntryValidator) error {
+	certs := &certificateLoader{
+		certFile: filepath.Join(certDir, corev1.TLSCertKey),
+		keyFile:  filepath.Join(certDir, corev1.TLSPrivateKeyKey),
+	}
+	if _, err := certs.getCertificate(nil); err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(PathValidateDNSEntry, validator)
+	server := &http.Server{
+		Addr:              fmt.Sprintf(":%d", port),
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		TLSConfig: &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: certs.getCertificate,
+		},
+	}
+
+	go func() {
+		logctx.Infof("starting admission webhook server on port %d", port)
+		if err := server.ListenAndServeTLS("", ""); err != nil && err != http.ErrServerClosed {
+			logctx.Errorf("admission webhook server failed: %s", err)
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+	return nil
+}
+
+// certificateLoader loads the server certificate and reloads it if the certificate file has been modified.
+type certificateLoader struct {
+	lock     sync.Mutex
+	certFile string
+	keyFile  string
+	modTime  time.Time
+	cert     *tls.Certificate
+}
+
+func (this *certificateLoader) getCertificate(_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	info, err := os.Stat(this.certFile)
+	if err != nil {
+		if this.cert != nil {
+			return this.cert, nil
+		}
+		return nil, fmt.Errorf("cannot read admission webhook certificate: %w", err)
+	}
+	if this.cert != nil && info.ModTime().Equal(this.modTime) {
+		return this.cert, nil
+	}
+	cert, err := tls.LoadX509KeyPair(this.certFile, this.keyFile)
+	if err != nil {
+		if this.cert != nil {
+			return this.cert, nil
+		}
+		return nil, fmt.Errorf("cannot load admission webhook certificate: %w", err)
+	}
+	this.cert = &cert
+	this.modTime = info.ModTime()
+	return this.cert, nil
+}
@@ -0,0 +1,163 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package admission
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+// PathValidateDNSEntry is the path of the validating webhook for DNS entries.
+const PathValidateDNSEntry = "/validate-dnsentry"
+
+// maxRequestSize limits the size of an admission review request body.
+const maxRequestSize = 3 * 1024 * 1024
+
+// EntryLister lists the DNS entries of a namespace.
+type EntryLister func(namespace string) ([]*api.DNSEntry, error)
+
+// EntryValidator is a validating admission webhook for DNS entries.
+// It rejects entries whose DNS set name (DNS name and set identifier) is already used by another
+// entry of the same DNS class in the same namespace. As a DNS entry manages all record types of its
+// DNS set name, the record type is implicitly part of the check.
+type EntryValidator struct {
+	logger logger.LogContext
+	lister EntryLister
+	// responsible reports if the controller is responsible for the DNS class of the entry
+	responsible func(class string) bool
+}
+
+var _ http.Handler = &EntryValidator{}
+
+// NewEntryValidator creates a validator for DNS entries.
+func NewEntryValidator(logger logger.LogContext, lister EntryLister, responsible func(class string) bool) *EntryValidator {
+	return &EntryValidator{
+		logger:      logger,
+		lister:      lister,
+		responsible: responsible,
+	}
+}
+
+func (this *EntryValidator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestSize))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("cannot read request: %s", err), http.StatusBadRequest)
+		return
+	}
+	review := &admissionv1.AdmissionReview{}
+	if err := json.Unmarshal(body, review); err != nil || review.Request == nil {
+		http.Error(w, "invalid admission review", http.StatusBadRequest)
+		return
+	}
+
+	response := this.Validate(review.Request)
+	response.UID = review.Request.UID
+	review.Response = response
+	review.Request = nil
+	data, err := json.Marshal(review)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("cannot marshal response: %s", err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(data); err != nil {
+		this.logger.Warnf("cannot write admission response: %s", err)
+	}
+}
+
+// Validate validates an admission request for a DNS entry.
+func (this *EntryValidator) Validate(req *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return allowed()
+	}
+	entry := &api.DNSEntry{}
+	if err := json.Unmarshal(req.Object.Raw, entry); err != nil {
+		return denied(http.StatusBadRequest, fmt.Sprintf("cannot decode DNS entry: %s", err))
+	}
+	if entry.Namespace == "" {
+		entry.Namespace = req.Namespace
+	}
+	if entry.DeletionTimestamp != nil || !this.responsible(entryClass(entry)) {
+		return allowed()
+	}
+	if req.Operation == admissionv1.Update {
+		old := &api.DNSEntry{}
+		if err := json.Unmarshal(req.OldObject.Raw, old); err == nil && dnsutils.DNSSetName(old) == dnsutils.DNSSetName(entry) {
+			// only changes of the DNS set name can introduce new conflicts
+			return allowed()
+		}
+	}
+
+	conflict, err := this.findConflict(entry)
+	if err != nil {
+		this.logger.Warnf("cannot check conflicts of DNS entry %s/%s: %s", entry.Namespace, entry.Name, err)
+		return denied(http.StatusInternalServerError, fmt.Sprintf("cannot check conflicts: %s", err))
+	}
+	if conflict != nil {
+		name := dnsutils.DNSSetName(entry)
+		this.logger.Infof("rejecting DNS entry %s/%s: %s already used by entry %s", entry.Namespace, entry.Name, name, conflict.Name)
+		return denied(http.StatusConflict, fmt.Sprintf("DNS name %s is already used by DNS entry %s/%s", name, conflict.Namespace, conflict.Name))
+	}
+	return allowed()
+}
+
+// findConflict returns an existing entry with the same DNS set name and DNS class.
+// Entries being deleted are ignored.
+func (this *EntryValidator) findConflict(entry *api.DNSEntry) (*api.DNSEntry, error) {
+	name := dnsutils.DNSSetName(entry)
+	if name.DNSName == "" {
+		return nil, nil
+	}
+	entries, err := this.lister(entry.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	class := entryClass(entry)
+	for _, other := range entries {
+		if other.Name == entry.Name || other.DeletionTimestamp != nil || entryClass(other) != class {
+			continue
+		}
+		if dnsutils.DNSSetName(other) == name {
+			return other, nil
+		}
+	}
+	return nil, nil
+}
+
+func entryClass(entry *api.DNSEntry) string {
+	if class := entry.Annotations[dns.CLASS_ANNOTATION]; class != "" {
+		return class
+	}
+	return dns.DEFAULT_CLASS
+}
+
+func allowed() *admissionv1.AdmissionResponse {
+	return &admissionv1.AdmissionResponse{Allowed: true}
+}
+
+func denied(code int32, msg string) *admissionv1.AdmissionResponse {
+	return &admissionv1.AdmissionResponse{
+		Allowed: false,
+		Result: &metav1.Status{
+			Status:  metav1.StatusFailure,
+			Code:    code,
+			Message: msg,
+		},
+	}
+}
@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package admission
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+func newEntry(name, dnsName, setIdentifier string) *api.DNSEntry {
+	entry := &api.DNSEntry{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: name},
+		Spec:       api.DNSEntrySpec{DNSName: dnsName, Targets: []string{"1.1.1.1"}},
+	}
+	if setIdentifier != "" {
+		entry.Spec.RoutingPolicy = &api.RoutingPolicy{Type: "weighted", SetIdentifier: setIdentifier}
+	}
+	return entry
+}
+
+func newValidator(existing ...*api.DNSEntry) *EntryValidator {
+	lister := func(namespace string) ([]*api.DNSEntry, error) {
+		var result []*api.DNSEntry
+		for _, e := range existing {
+			if e.Namespace == namespace {
+				result = append(result, e)
+			}
+		}
+		return result, nil
+	}
+	return NewEntryValidator(logger.New(), lister, func(class string) bool { return class == dns.DEFAULT_CLASS })
+}
+
+func newRequest(t *testing.T, op admissionv1.Operation, entry, old *api.DNSEntry) *admissionv1.AdmissionRequest {
+	req := &admissionv1.AdmissionRequest{
+		UID:       types.UID("uid"),
+		Operation: op,
+		Namespace: entry.Namespace,
+		Name:      entry.Name,
+		Object:    runtime.RawExtension{Raw: marshal(t, entry)},
+	}
+	if old != nil {
+		req.OldObject = runtime.RawExtension{Raw: marshal(t, old)}
+	}
+	return req
+}
+
+func marshal(t *testing.T, obj any) []byte {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestValidate(t *testing.T) {
+	deleting := newEntry("deleting", "c.example.com", "")
+	deleting.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	otherClass := newEntry("other-class", "d.example.com", "")
+	otherClass.Annotations = map[string]string{dns.CLASS_ANNOTATION: "other"}
+	otherNamespace := newEntry("other-ns", "e.example.com", "")
+	otherNamespace.Namespace = "other"
+	validator := newValidator(
+		newEntry("a", "a.example.com", ""),
+		newEntry("b1", "b.example.com", "id1"),
+		deleting,
+		otherClass,
+		otherNamespace,
+	)
+
+	table := []struct {
+		name    string
+		entry   *api.DNSEntry
+		allowed bool
+	}{
+		{"new name", newEntry("x", "x.example.com", ""), true},
+		{"same name", newEntry("x", "a.example.com", ""), false},
+		{"same name normalized", newEntry("x", "a.example.com.", ""), false},
+		{"same name other set identifier", newEntry("x", "b.example.com", "id2"), true},
+		{"same name same set identifier", newEntry("x", "b.example.com", "id1"), false},
+		{"existing entry being deleted", newEntry("x", "c.example.com", ""), true},
+		{"existing entry of other class", newEntry("x", "d.example.com", ""), true},
+		{"existing entry in other namespace", newEntry("x", "e.example.com", ""), true},
+	}
+	for _, e := range table {
+		resp := validator.Validate(newRequest(t, admissionv1.Create, e.entry, nil))
+		if resp.Allowed != e.allowed {
+			t.Errorf("%s: expected allowed=%t, got %t (%v)", e.name, e.allowed, resp.Allowed, resp.Result)
+		}
+	}
+}
+
+func TestValidateUpdate(t *testing.T) {
+	validator := newValidator(newEntry("a", "a.example.com", ""), newEntry("b", "b.example.com", ""))
+
+	old := newEntry("a", "a.example.com", "")
+	updated := newEntry("a", "a.example.com", "")
+	updated.Spec.Targets = []string{"2.2.2.2"}
+	if resp := validator.Validate(newRequest(t, admissionv1.Update, updated, old)); !resp.Allowed {
+		t.Errorf("update without name change: expected allowed, got %v", resp.Result)
+	}
+
+	renamed := newEntry("a", "b.example.com", "")
+	if resp := validator.Validate(newRequest(t, admissionv1.Update, renamed, old)); resp.Allowed {
+		t.Errorf("update to conflicting name: expected denied")
+	}
+
+	renamed.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	if resp := validator.Validate(newRequest(t, admissionv1.Update, renamed, old)); !resp.Allowed {
+		t.Errorf("update of entry being deleted: expected allowed, got %v", resp.Result)
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	validator := newValidator(newEntry("a", "a.example.com", ""))
+	review := &admissionv1.AdmissionReview{
+		TypeMeta: metav1.TypeMeta{APIVersion: "admission.k8s.io/v1", Kind: "AdmissionReview"},
+		Request:  newRequest(t, admissionv1.Create, newEntry("x", "a.example.com", ""), nil),
+	}
+	rec := httptest.NewRecorder()
+	validator.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, PathValidateDNSEntry, bytes.NewReader(marshal(t, review))))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unexpected status code %d: %s", rec.Code, rec.Body.String())
+	}
+	result := &admissionv1.AdmissionReview{}
+	if err := json.Unmarshal(rec.Body.Bytes(), result); err != nil {
+		t.Fatal(err)
+	}
+	if result.Response == nil || result.Response.UID != "uid" || result.Response.Allowed {
+		t.Fatalf("unexpected response %v", result.Response)
+	}
+	if msg := result.Response.Result.Message; msg != "DNS name a.example.com is already used by DNS entry test/a" {
+		t.Errorf("unexpected message %q", msg)
+	}
+}
@@ -24,9 +24,16 @@ func init() {
 	prometheus.MustRegister(Requests)
 	prometheus.MustRegister(ZoneRequests)
 	prometheus.MustRegister(ZoneCacheDiscardings)
+	prometheus.MustRegister(ZoneStateCacheHits)
+	prometheus.MustRegister(ZoneStateCacheMisses)
+	prometheus.MustRegister(ZoneStateCacheEvictions)
+	prometheus.MustRegister(ZoneStateCacheEntries)
+	prometheus.MustRegister(ZoneStateCacheIncrementalRefreshes)
 	prometheus.MustRegister(Accounts)
 	prometheus.MustRegister(Entries)
 	prometheus.MustRegister(StaleEntries)
+	prometheus.MustRegister(DriftedEntries)
+	prometheus.MustRegister(EntriesByState)
 	prometheus.MustRegister(Owners)
 	prometheus.MustRegister(RemoteAccessLogins)
 	prometheus.MustRegister(RemoteAccessRequests)
@@ -68,6 +75,46 @@ var (
 		[]string{"providertype", "zone"},
 	)
 
+	ZoneStateCacheHits = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "external_dns_management_zone_state_cache_hits",
+			Help: "Zone state requests served from the zone state cache per provider type and zone",
+		},
+		[]string{"providertype", "zone"},
+	)
+
+	ZoneStateCacheMisses = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "external_dns_management_zone_state_cache_misses",
+			Help: "Zone state requests not served from the zone state cache per provider type and zone",
+		},
+		[]string{"providertype", "zone"},
+	)
+
+	ZoneStateCacheEvictions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "external_dns_management_zone_state_cache_evictions",
+			Help: "Evictions of cached zone states per provider type and zone",
+		},
+		[]string{"providertype", "zone"},
+	)
+
+	ZoneStateCacheEntries = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "external_dns_management_zone_state_cache_entries",
+			Help: "Number of cached DNS sets per provider type and zone",
+		},
+		[]string{"providertype", "zone"},
+	)
+
+	ZoneStateCacheIncrementalRefreshes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "external_dns_management_zone_state_cache_incremental_refreshes",
+			Help: "Refreshes of cached zone states by fetching only the changed record sets per provider type and zone",
+		},
+		[]string{"providertype", "zone"},
+	)
+
 	Accounts = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "external_dns_management_account_providers",
@@ -92,6 +139,22 @@ var (
 		[]string{"providertype", "zone"},
 	)
 
+	DriftedEntries = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "external_dns_management_dns_entries_drifted",
+			Help: "Number of dns entries per hosted zone with records deviating from the desired state found by the last zone audit",
+		},
+		[]string{"providertype", "zone"},
+	)
+
+	EntriesByState = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "external_dns_management_dns_entries_by_state",
+			Help: "Total number of dns entries per namespace, provider type, and state",
+		},
+		[]string{"namespace", "providertype", "state"},
+	)
+
 	Owners = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "external_dns_management_dns_owners",
@@ -247,6 +310,35 @@ func AddZoneCacheDiscarding(id dns.ZoneID) {
 	ZoneCacheDiscardings.WithLabelValues(id.ProviderType, id.ID).Add(float64(1))
 }
 
+func ReportZoneStateCacheHit(id dns.ZoneID) {
+	ZoneStateCacheHits.WithLabelValues(id.ProviderType, id.ID).Inc()
+}
+
+func ReportZoneStateCacheMiss(id dns.ZoneID) {
+	ZoneStateCacheMisses.WithLabelValues(id.ProviderType, id.ID).Inc()
+}
+
+func ReportZoneStateCacheEviction(id dns.ZoneID) {
+	ZoneStateCacheEvictions.WithLabelValues(id.ProviderType, id.ID).Inc()
+}
+
+func ReportZoneStateCacheEntries(id dns.ZoneID, amount int) {
+	ZoneStateCacheEntries.WithLabelValues(id.ProviderType, id.ID).Set(float64(amount))
+}
+
+func ReportZoneStateCacheIncrementalRefresh(id dns.ZoneID) {
+	ZoneStateCacheIncrementalRefreshes.WithLabelValues(id.ProviderType, id.ID).Inc()
+}
+
+// DeleteZoneStateCache removes all zone state cache metrics of the given zone.
+func DeleteZoneStateCache(id dns.ZoneID) {
+	ZoneStateCacheHits.DeleteLabelValues(id.ProviderType, id.ID)
+	ZoneStateCacheMisses.DeleteLabelValues(id.ProviderType, id.ID)
+	ZoneStateCacheEvictions.DeleteLabelValues(id.ProviderType, id.ID)
+	ZoneStateCacheEntries.DeleteLabelValues(id.ProviderType, id.ID)
+	ZoneStateCacheIncrementalRefreshes.DeleteLabelValues(id.ProviderType, id.ID)
+}
+
 type ZoneProviderTypes struct {
 	lock      sync.Mutex
 	providers map[dns.ZoneID]struct{}
@@ -272,6 +364,10 @@ func ReportZoneEntries(zoneid dns.ZoneID, amount int, stale int) {
 	zoneProviders.Add(zoneid)
 }
 
+func ReportZoneDriftedEntries(zoneid dns.ZoneID, amount int) {
+	DriftedEntries.WithLabelValues(zoneid.ProviderType, zoneid.ID).Set(float64(amount))
+}
+
 func ReportRemoteAccessLogins(namespace, client string, success bool) {
 	RemoteAccessLogins.WithLabelValues(namespace, client, strconv.FormatBool(success)).Add(float64(1))
 }
@@ -291,6 +387,7 @@ func ReportRemoteAccessCertificates(count int) {
 func DeleteZone(zoneid dns.ZoneID) {
 	zoneProviders.Remove(zoneid)
 	Entries.DeleteLabelValues(zoneid.ProviderType, zoneid.ID)
+	DriftedEntries.DeleteLabelValues(zoneid.ProviderType, zoneid.ID)
 }
 
 var (
@@ -298,6 +395,23 @@ var (
 	lock             sync.Mutex
 )
 
+// UpdateEntryStateStatistic sets the number of entries per namespace, provider type, and state.
+// Label combinations without entries anymore are deleted.
+func UpdateEntryStateStatistic(states statistic.StateStatistic) {
+	lock.Lock()
+	defer lock.Unlock()
+
+	for key, count := range states {
+		EntriesByState.WithLabelValues(key.Namespace, key.ProviderType, key.State).Set(float64(count))
+	}
+	for key := range currentStatistic.States {
+		if _, ok := states[key]; !ok {
+			EntriesByState.DeleteLabelValues(key.Namespace, key.ProviderType, key.State)
+		}
+	}
+	currentStatistic.States = states
+}
+
 func deleteOwnerStatistic(state statistic.WalkingState, owner, ptype string, pname resources.ObjectName, _ int) statistic.WalkingState {
 	types := state.(utils.StringSet)
 	if types.Contains(ptype) {
@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package readyz
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gardener/controller-manager-library/pkg/server"
+)
+
+// Check returns true if the checked component is ready.
+type Check func() bool
+
+var (
+	lock   sync.Mutex
+	checks = map[string]Check{}
+)
+
+func init() {
+	server.Register("/readyz", Readyz)
+}
+
+// Register adds a readiness check with the given key. An existing check with the same key is replaced.
+func Register(key string, check Check) {
+	lock.Lock()
+	defer lock.Unlock()
+	checks[key] = check
+}
+
+// Unregister removes the readiness check with the given key.
+func Unregister(key string) {
+	lock.Lock()
+	defer lock.Unlock()
+	delete(checks, key)
+}
+
+// ReadyInfo returns true if all registered checks are ready and a description of the pending checks.
+func ReadyInfo() (bool, string) {
+	lock.Lock()
+	defer lock.Unlock()
+
+	var pending []string
+	for key, check := range checks {
+		if !check() {
+			pending = append(pending, key)
+		}
+	}
+	if len(pending) == 0 {
+		return true, "ok"
+	}
+	sort.Strings(pending)
+	return false, fmt.Sprintf("not ready: %s", strings.Join(pending, ", "))
+}
+
+// Readyz is a HTTP handler for the /readyz endpoint which responses with 200 OK status code
+// if all registered checks are ready; and with 503 Service Unavailable status code otherwise.
+func Readyz(w http.ResponseWriter, _ *http.Request) {
+	ok, info := ReadyInfo()
+	if ok {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_, _ = io.WriteString(w, info)
+}
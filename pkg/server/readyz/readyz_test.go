@@ -0,0 +1,35 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package readyz
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestReadyz(t *testing.T) {
+	ready := false
+	Register("b", func() bool { return ready })
+	Register("a", func() bool { return true })
+	defer Unregister("a")
+	defer Unregister("b")
+
+	rec := httptest.NewRecorder()
+	Readyz(rec, nil)
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("unexpected status code %d", rec.Code)
+	}
+	if body := rec.Body.String(); body != "not ready: b" {
+		t.Errorf("unexpected body %q", body)
+	}
+
+	ready = true
+	rec = httptest.NewRecorder()
+	Readyz(rec, nil)
+	if rec.Code != http.StatusOK {
+		t.Errorf("unexpected status code %d", rec.Code)
+	}
+}
@@ -0,0 +1,358 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package client provides a typed client for the remote access server of the DNS controller manager.
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/server/remote/common"
+	"github.com/gardener/external-dns-management/pkg/server/remote/conversion"
+)
+
+// DefaultTimeout is the timeout for calls with a context without deadline.
+const DefaultTimeout = 60 * time.Second
+
+// Config is the configuration of a client for the remote access server.
+type Config struct {
+	// Endpoint is the address of the remote access server as `host:port`.
+	Endpoint string
+	// Namespace is the namespace of the DNS providers to access.
+	Namespace string
+	// ClientID identifies the client in the logs of the server.
+	ClientID string
+	// ServerCA is the PEM encoded certificate of the CA who signed the server certificate.
+	// If not set, the system certificate pool is used.
+	ServerCA []byte
+	// ClientCert is the PEM encoded client certificate.
+	ClientCert []byte
+	// ClientKey is the PEM encoded private key of the client certificate.
+	ClientKey []byte
+	// OverrideServerName overrides the server name used to verify the server certificate.
+	OverrideServerName string
+	// Timeout is the timeout for calls with a context without deadline (DefaultTimeout if not set).
+	Timeout time.Duration
+}
+
+// ConfigFromCertData creates a client configuration from a client certificate created for remote access.
+func ConfigFromCertData(endpoint, namespace, clientID string, data *common.CertData) Config {
+	return Config{
+		Endpoint:   endpoint,
+		Namespace:  namespace,
+		ClientID:   clientID,
+		ServerCA:   data.CACrt,
+		ClientCert: data.TLSCrt,
+		ClientKey:  data.TLSKey,
+	}
+}
+
+// ConfigFromSecretData creates a client configuration from the data of a secret of a DNS provider of type `remote`.
+func ConfigFromSecretData(clientID string, data map[string][]byte) (Config, error) {
+	get := func(keys ...string) string {
+		for _, key := range keys {
+			if value, ok := data[key]; ok {
+				return strings.TrimSpace(string(value))
+			}
+		}
+		return ""
+	}
+	config := Config{
+		Endpoint:           get("REMOTE_ENDPOINT", "remoteEndpoint"),
+		Namespace:          get("NAMESPACE", "namespace"),
+		ClientID:           clientID,
+		ServerCA:           []byte(get("SERVER_CA_CERT", "ca.crt")),
+		ClientCert:         []byte(get("CLIENT_CERT", corev1.TLSCertKey)),
+		ClientKey:          []byte(get("CLIENT_KEY", corev1.TLSPrivateKeyKey)),
+		OverrideServerName: get("OVERRIDE_SERVER_NAME", "overrideServerName"),
+	}
+	return config, config.Validate()
+}
+
+// Validate checks that all required fields are set.
+func (c Config) Validate() error {
+	var missing []string
+	if c.Endpoint == "" {
+		missing = append(missing, "endpoint")
+	}
+	if c.Namespace == "" {
+		missing = append(missing, "namespace")
+	}
+	if len(c.ClientCert) == 0 {
+		missing = append(missing, "client certificate")
+	}
+	if len(c.ClientKey) == 0 {
+		missing = append(missing, "client key")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing %s in remote client config", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// LoadTLSCredentials creates the transport credentials for the given PEM encoded client certificate and key.
+// serverCA is the optional certificate of the CA who signed the server certificate.
+func LoadTLSCredentials(serverCA, clientCert, clientKey []byte) (credentials.TransportCredentials, error) {
+	cert, err := tls.X509KeyPair(clientCert, clientKey)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+	}
+
+	if len(serverCA) > 0 {
+		certPool := x509.NewCertPool()
+		if !certPool.AppendCertsFromPEM(serverCA) {
+			return nil, fmt.Errorf("failed to add server CA's certificate")
+		}
+		config.RootCAs = certPool
+	}
+
+	return credentials.NewTLS(config), nil
+}
+
+// Client is a client for the remote access server.
+// It logs in on demand and renews its token if it has become invalid. It is safe for concurrent use.
+type Client struct {
+	config Config
+	conn   *grpc.ClientConn
+	rpc    common.RemoteProviderClient
+
+	lock                  sync.Mutex
+	token                 string
+	serverProtocolVersion int32
+}
+
+// New creates a client for the remote access server. The connection is established on the first call.
+func New(config Config) (*Client, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	creds, err := LoadTLSCredentials(config.ServerCA, config.ClientCert, config.ClientKey)
+	if err != nil {
+		return nil, err
+	}
+	if config.OverrideServerName != "" {
+		if err := creds.OverrideServerName(config.OverrideServerName); err != nil {
+			return nil, err
+		}
+	}
+	conn, err := grpc.Dial(config.Endpoint, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, err
+	}
+	return newClient(config, conn), nil
+}
+
+func newClient(config Config, conn *grpc.ClientConn) *Client {
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultTimeout
+	}
+	return &Client{
+		config: config,
+		conn:   conn,
+		rpc:    common.NewRemoteProviderClient(conn),
+	}
+}
+
+// Close closes the connection of the client.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// Namespace returns the namespace of the DNS providers accessed by the client.
+func (c *Client) Namespace() string {
+	return c.config.Namespace
+}
+
+// ServerProtocolVersion returns the protocol version of the server known from the last login.
+func (c *Client) ServerProtocolVersion() int32 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.serverProtocolVersion
+}
+
+// Login requests a new token from the server.
+func (c *Client) Login(ctx context.Context) error {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	_, err := c.login(ctx)
+	return err
+}
+
+func (c *Client) login(ctx context.Context) (string, error) {
+	response, err := c.rpc.Login(ctx, &common.LoginRequest{
+		Namespace:             c.config.Namespace,
+		CliendID:              c.config.ClientID,
+		ClientProtocolVersion: common.ProtocolVersion1,
+	})
+	if err != nil {
+		if s, ok := status.FromError(err); ok && s.Code() == codes.Unavailable && s.Message() == "connection closed before server preface received" {
+			return "", status.Error(s.Code(), s.Message()+" (hint: certificate not valid?)")
+		}
+		return "", err
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.token = response.Token
+	c.serverProtocolVersion = response.ServerProtocolVersion
+	return response.Token, nil
+}
+
+// withToken calls f with the current token and retries once with a new token if the token is invalid.
+func (c *Client) withToken(ctx context.Context, f func(token string) error) error {
+	c.lock.Lock()
+	token := c.token
+	c.lock.Unlock()
+
+	var err error
+	if token != "" {
+		err = f(token)
+	} else {
+		err = fmt.Errorf("%s", common.InvalidToken)
+	}
+	if err != nil && strings.Contains(err.Error(), common.InvalidToken) {
+		if token, err = c.login(ctx); err != nil {
+			return err
+		}
+		err = f(token)
+	}
+	return err
+}
+
+func (c *Client) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, c.config.Timeout)
+}
+
+// ListZones returns the hosted zones of all DNS providers in the namespace.
+func (c *Client) ListZones(ctx context.Context) ([]*common.Zone, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	var zones *common.Zones
+	err := c.withToken(ctx, func(token string) error {
+		var err error
+		zones, err = c.rpc.GetZones(ctx, &common.GetZonesRequest{Token: token})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return zones.Zone, nil
+}
+
+// GetZoneState returns the record sets of a hosted zone.
+func (c *Client) GetZoneState(ctx context.Context, zoneID string) (dns.DNSSets, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	var state *common.ZoneState
+	err := c.withToken(ctx, func(token string) error {
+		var err error
+		state, err = c.rpc.GetZoneState(ctx, &common.GetZoneStateRequest{Token: token, Zoneid: zoneID})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return conversion.UnmarshalDNSSets(state.DnsSets), nil
+}
+
+// Execute executes the change requests for a hosted zone.
+// The states of the single changes are reported in the change responses.
+func (c *Client) Execute(ctx context.Context, zoneID string, changes []*common.ChangeRequest) (*common.ExecuteResponse, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	var response *common.ExecuteResponse
+	err := c.withToken(ctx, func(token string) error {
+		var err error
+		response, err = c.rpc.Execute(ctx, &common.ExecuteRequest{Token: token, Zoneid: zoneID, ChangeRequest: changes})
+		return err
+	})
+	return response, err
+}
+
+// CreateEntry creates the record sets of the DNS set in a hosted zone.
+func (c *Client) CreateEntry(ctx context.Context, zoneID string, dnsset *dns.DNSSet) error {
+	return c.executeDNSSet(ctx, zoneID, common.ChangeRequest_CREATE, dnsset)
+}
+
+// UpdateEntry updates the existing record sets of the DNS set in a hosted zone.
+func (c *Client) UpdateEntry(ctx context.Context, zoneID string, dnsset *dns.DNSSet) error {
+	return c.executeDNSSet(ctx, zoneID, common.ChangeRequest_UPDATE, dnsset)
+}
+
+// DeleteEntry deletes the record sets of the DNS set from a hosted zone.
+func (c *Client) DeleteEntry(ctx context.Context, zoneID string, dnsset *dns.DNSSet) error {
+	return c.executeDNSSet(ctx, zoneID, common.ChangeRequest_DELETE, dnsset)
+}
+
+func (c *Client) executeDNSSet(ctx context.Context, zoneID string, action common.ChangeRequest_ActionType, dnsset *dns.DNSSet) error {
+	if len(dnsset.Sets) == 0 {
+		return fmt.Errorf("no record sets given for %s", dnsset.Name)
+	}
+	if dnsset.RoutingPolicy != nil {
+		if !c.hasToken() {
+			if err := c.Login(ctx); err != nil {
+				return err
+			}
+		}
+		if c.ServerProtocolVersion() < common.ProtocolVersion1 {
+			return fmt.Errorf("routing policy not supported by remote server version")
+		}
+	}
+	rtypes := make([]string, 0, len(dnsset.Sets))
+	for rtype := range dnsset.Sets {
+		rtypes = append(rtypes, rtype)
+	}
+	sort.Strings(rtypes)
+	changes := make([]*common.ChangeRequest, 0, len(rtypes))
+	for _, rtype := range rtypes {
+		changes = append(changes, &common.ChangeRequest{Action: action, Change: conversion.MarshalPartialDNSSet(dnsset, rtype)})
+	}
+
+	response, err := c.Execute(ctx, zoneID, changes)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for i, rtype := range rtypes {
+		if i >= len(response.ChangeResponse) {
+			errs = append(errs, fmt.Errorf("%s %s record set %s: no response", action, rtype, dnsset.Name))
+			continue
+		}
+		if r := response.ChangeResponse[i]; r.State != common.ChangeResponse_SUCCEEDED {
+			errs = append(errs, fmt.Errorf("%s %s record set %s: %s %s", action, rtype, dnsset.Name, r.State, r.ErrorMessage))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (c *Client) hasToken() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.token != ""
+}
@@ -0,0 +1,219 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/server/remote/common"
+)
+
+type fakeServer struct {
+	common.UnimplementedRemoteProviderServer
+	protocolVersion int32
+	validToken      string
+	logins          int
+	failRecordType  string
+	executed        []*common.ChangeRequest
+}
+
+func (s *fakeServer) Login(_ context.Context, request *common.LoginRequest) (*common.LoginResponse, error) {
+	s.logins++
+	s.validToken = fmt.Sprintf("%s-%d", request.Namespace, s.logins)
+	return &common.LoginResponse{Token: s.validToken, ServerProtocolVersion: s.protocolVersion}, nil
+}
+
+func (s *fakeServer) GetZones(_ context.Context, request *common.GetZonesRequest) (*common.Zones, error) {
+	if request.Token != s.validToken {
+		return nil, fmt.Errorf("%s", common.InvalidToken)
+	}
+	return &common.Zones{Zone: []*common.Zone{{Id: "z1", Domain: "example.com"}}}, nil
+}
+
+func (s *fakeServer) Execute(_ context.Context, request *common.ExecuteRequest) (*common.ExecuteResponse, error) {
+	if request.Token != s.validToken {
+		return nil, fmt.Errorf("%s", common.InvalidToken)
+	}
+	response := &common.ExecuteResponse{}
+	for _, change := range request.ChangeRequest {
+		s.executed = append(s.executed, change)
+		state := common.ChangeResponse_SUCCEEDED
+		msg := ""
+		if change.Change.RecordSet.Type == s.failRecordType {
+			state = common.ChangeResponse_FAILED
+			msg = "boom"
+		}
+		response.ChangeResponse = append(response.ChangeResponse, &common.ChangeResponse{State: state, ErrorMessage: msg})
+	}
+	return response, nil
+}
+
+func newTestClient(t *testing.T, srv *fakeServer) *Client {
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	common.RegisterRemoteProviderServer(s, srv)
+	go func() { _ = s.Serve(lis) }()
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("dial failed: %s", err)
+	}
+	c := newClient(Config{Namespace: "test"}, conn)
+	t.Cleanup(func() { _ = c.Close() })
+	return c
+}
+
+func newTestDNSSet(rtypes ...string) *dns.DNSSet {
+	set := dns.NewDNSSet(dns.DNSSetName{DNSName: "a.example.com"}, nil)
+	for _, rtype := range rtypes {
+		set.SetRecordSet(rtype, 300, "1.2.3.4")
+	}
+	return set
+}
+
+func TestListZonesRenewsToken(t *testing.T) {
+	srv := &fakeServer{protocolVersion: common.ProtocolVersion1}
+	c := newTestClient(t, srv)
+
+	zones, err := c.ListZones(context.Background())
+	if err != nil {
+		t.Fatalf("ListZones failed: %s", err)
+	}
+	if len(zones) != 1 || zones[0].Id != "z1" {
+		t.Errorf("unexpected zones: %v", zones)
+	}
+	if srv.logins != 1 {
+		t.Errorf("expected 1 login, got %d", srv.logins)
+	}
+
+	// invalidate token on server side
+	srv.validToken = "other"
+	if _, err := c.ListZones(context.Background()); err != nil {
+		t.Fatalf("ListZones with expired token failed: %s", err)
+	}
+	if srv.logins != 2 {
+		t.Errorf("expected 2 logins, got %d", srv.logins)
+	}
+	if c.ServerProtocolVersion() != common.ProtocolVersion1 {
+		t.Errorf("unexpected server protocol version %d", c.ServerProtocolVersion())
+	}
+}
+
+func TestCreateAndDeleteEntry(t *testing.T) {
+	srv := &fakeServer{protocolVersion: common.ProtocolVersion1}
+	c := newTestClient(t, srv)
+
+	if err := c.CreateEntry(context.Background(), "z1", newTestDNSSet(dns.RS_A, dns.RS_TXT)); err != nil {
+		t.Fatalf("CreateEntry failed: %s", err)
+	}
+	if err := c.DeleteEntry(context.Background(), "z1", newTestDNSSet(dns.RS_A)); err != nil {
+		t.Fatalf("DeleteEntry failed: %s", err)
+	}
+	if len(srv.executed) != 3 {
+		t.Fatalf("expected 3 executed changes, got %d", len(srv.executed))
+	}
+	expected := []common.ChangeRequest_ActionType{common.ChangeRequest_CREATE, common.ChangeRequest_CREATE, common.ChangeRequest_DELETE}
+	for i, change := range srv.executed {
+		if change.Action != expected[i] {
+			t.Errorf("change %d: expected action %s, got %s", i, expected[i], change.Action)
+		}
+	}
+}
+
+func TestCreateEntryReportsFailedRecordSets(t *testing.T) {
+	srv := &fakeServer{protocolVersion: common.ProtocolVersion1, failRecordType: dns.RS_TXT}
+	c := newTestClient(t, srv)
+
+	err := c.CreateEntry(context.Background(), "z1", newTestDNSSet(dns.RS_A, dns.RS_TXT))
+	if err == nil || !strings.Contains(err.Error(), "TXT record set a.example.com: FAILED boom") {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := c.CreateEntry(context.Background(), "z1", newTestDNSSet()); err == nil {
+		t.Errorf("expected error for empty DNS set")
+	}
+}
+
+func TestRoutingPolicyNeedsProtocolVersion1(t *testing.T) {
+	srv := &fakeServer{protocolVersion: common.ProtocolVersion0}
+	c := newTestClient(t, srv)
+
+	set := newTestDNSSet(dns.RS_A)
+	set.RoutingPolicy = &dns.RoutingPolicy{Type: "weighted", Parameters: map[string]string{"weight": "1"}}
+	err := c.CreateEntry(context.Background(), "z1", set)
+	if err == nil || !strings.Contains(err.Error(), "routing policy not supported") {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if len(srv.executed) != 0 {
+		t.Errorf("expected no executed changes, got %d", len(srv.executed))
+	}
+}
+
+func TestConfigFromSecretData(t *testing.T) {
+	config, err := ConfigFromSecretData("id", map[string][]byte{
+		"remoteEndpoint": []byte("server:7777\n"),
+		"NAMESPACE":      []byte("ns"),
+		"tls.crt":        []byte("cert"),
+		"tls.key":        []byte("key"),
+	})
+	if err != nil {
+		t.Fatalf("ConfigFromSecretData failed: %s", err)
+	}
+	if config.Endpoint != "server:7777" || config.Namespace != "ns" || config.ClientID != "id" || len(config.ServerCA) != 0 {
+		t.Errorf("unexpected config: %+v", config)
+	}
+
+	_, err = ConfigFromSecretData("id", map[string][]byte{"NAMESPACE": []byte("ns")})
+	if err == nil || err.Error() != "missing endpoint, client certificate, client key in remote client config" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestPoolSharesClients(t *testing.T) {
+	pool := NewPool()
+	c1 := newTestClient(t, &fakeServer{})
+	c2 := newTestClient(t, &fakeServer{})
+	key1 := poolKey(Config{Namespace: "a"})
+	pool.clients[key1] = &pooledClient{client: c1, refs: 1}
+	pool.keys[c1] = key1
+	key2 := poolKey(Config{Namespace: "b"})
+	pool.clients[key2] = &pooledClient{client: c2, refs: 1}
+	pool.keys[c2] = key2
+
+	got, err := pool.Get(Config{Namespace: "a"})
+	if err != nil || got != c1 {
+		t.Fatalf("expected pooled client, got %v (%v)", got, err)
+	}
+	if err := pool.Release(c1); err != nil {
+		t.Fatalf("Release failed: %s", err)
+	}
+	if pool.Size() != 2 {
+		t.Errorf("expected 2 clients, got %d", pool.Size())
+	}
+	_ = pool.Release(c1)
+	if pool.Size() != 1 {
+		t.Errorf("expected 1 client, got %d", pool.Size())
+	}
+	if err := pool.Release(c1); err == nil {
+		t.Errorf("expected error on releasing unknown client")
+	}
+	if err := pool.Close(); err != nil {
+		t.Errorf("Close failed: %s", err)
+	}
+	if pool.Size() != 0 {
+		t.Errorf("expected empty pool, got %d", pool.Size())
+	}
+}
@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Pool shares clients with the same configuration.
+// Clients are reference counted and the connection is closed if the last user has released its client.
+type Pool struct {
+	lock    sync.Mutex
+	clients map[string]*pooledClient
+	keys    map[*Client]string
+}
+
+type pooledClient struct {
+	client *Client
+	refs   int
+}
+
+// NewPool creates an empty client pool.
+func NewPool() *Pool {
+	return &Pool{
+		clients: map[string]*pooledClient{},
+		keys:    map[*Client]string{},
+	}
+}
+
+// Get returns a client for the given configuration. It must be released with Release if it is not needed anymore.
+func (p *Pool) Get(config Config) (*Client, error) {
+	key := poolKey(config)
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if pc := p.clients[key]; pc != nil {
+		pc.refs++
+		return pc.client, nil
+	}
+	client, err := New(config)
+	if err != nil {
+		return nil, err
+	}
+	p.clients[key] = &pooledClient{client: client, refs: 1}
+	p.keys[client] = key
+	return client, nil
+}
+
+// Release releases a client obtained by Get.
+func (p *Pool) Release(client *Client) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	key, ok := p.keys[client]
+	if !ok {
+		return fmt.Errorf("client not managed by pool")
+	}
+	pc := p.clients[key]
+	pc.refs--
+	if pc.refs > 0 {
+		return nil
+	}
+	delete(p.clients, key)
+	delete(p.keys, client)
+	return client.Close()
+}
+
+// Size returns the number of open clients.
+func (p *Pool) Size() int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return len(p.clients)
+}
+
+// Close closes all clients of the pool regardless of their reference counts.
+func (p *Pool) Close() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	var errs []error
+	for key, pc := range p.clients {
+		if err := pc.client.Close(); err != nil {
+			errs = append(errs, err)
+		}
+		delete(p.clients, key)
+		delete(p.keys, pc.client)
+	}
+	return errors.Join(errs...)
+}
+
+func poolKey(config Config) string {
+	h := sha256.New()
+	for _, field := range [][]byte{
+		[]byte(config.Endpoint),
+		[]byte(config.Namespace),
+		[]byte(config.ClientID),
+		config.ServerCA,
+		config.ClientCert,
+		config.ClientKey,
+		[]byte(config.OverrideServerName),
+		[]byte(config.Timeout.String()),
+	} {
+		h.Write(field)
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -1161,6 +1161,234 @@ func (x *ChangeResponse) GetErrorMessage() string {
 	return ""
 }
 
+type ExecuteBatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Token              string                `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	ZoneChangeRequests []*ZoneChangeRequests `protobuf:"bytes,2,rep,name=zone_change_requests,json=zoneChangeRequests,proto3" json:"zone_change_requests,omitempty"`
+}
+
+func (x *ExecuteBatchRequest) Reset() {
+	*x = ExecuteBatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_server_remote_common_remote_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExecuteBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecuteBatchRequest) ProtoMessage() {}
+
+func (x *ExecuteBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_server_remote_common_remote_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecuteBatchRequest.ProtoReflect.Descriptor instead.
+func (*ExecuteBatchRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_server_remote_common_remote_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ExecuteBatchRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ExecuteBatchRequest) GetZoneChangeRequests() []*ZoneChangeRequests {
+	if x != nil {
+		return x.ZoneChangeRequests
+	}
+	return nil
+}
+
+type ZoneChangeRequests struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Zoneid        string           `protobuf:"bytes,1,opt,name=zoneid,proto3" json:"zoneid,omitempty"`
+	ChangeRequest []*ChangeRequest `protobuf:"bytes,2,rep,name=change_request,json=changeRequest,proto3" json:"change_request,omitempty"`
+}
+
+func (x *ZoneChangeRequests) Reset() {
+	*x = ZoneChangeRequests{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_server_remote_common_remote_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ZoneChangeRequests) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZoneChangeRequests) ProtoMessage() {}
+
+func (x *ZoneChangeRequests) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_server_remote_common_remote_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZoneChangeRequests.ProtoReflect.Descriptor instead.
+func (*ZoneChangeRequests) Descriptor() ([]byte, []int) {
+	return file_pkg_server_remote_common_remote_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ZoneChangeRequests) GetZoneid() string {
+	if x != nil {
+		return x.Zoneid
+	}
+	return ""
+}
+
+func (x *ZoneChangeRequests) GetChangeRequest() []*ChangeRequest {
+	if x != nil {
+		return x.ChangeRequest
+	}
+	return nil
+}
+
+type ExecuteBatchResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ZoneChangeResponses []*ZoneChangeResponses `protobuf:"bytes,1,rep,name=zone_change_responses,json=zoneChangeResponses,proto3" json:"zone_change_responses,omitempty"`
+	LogMessage          []*LogEntry            `protobuf:"bytes,2,rep,name=log_message,json=logMessage,proto3" json:"log_message,omitempty"`
+}
+
+func (x *ExecuteBatchResponse) Reset() {
+	*x = ExecuteBatchResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_server_remote_common_remote_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExecuteBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecuteBatchResponse) ProtoMessage() {}
+
+func (x *ExecuteBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_server_remote_common_remote_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecuteBatchResponse.ProtoReflect.Descriptor instead.
+func (*ExecuteBatchResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_server_remote_common_remote_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ExecuteBatchResponse) GetZoneChangeResponses() []*ZoneChangeResponses {
+	if x != nil {
+		return x.ZoneChangeResponses
+	}
+	return nil
+}
+
+func (x *ExecuteBatchResponse) GetLogMessage() []*LogEntry {
+	if x != nil {
+		return x.LogMessage
+	}
+	return nil
+}
+
+type ZoneChangeResponses struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Zoneid         string            `protobuf:"bytes,1,opt,name=zoneid,proto3" json:"zoneid,omitempty"`
+	ChangeResponse []*ChangeResponse `protobuf:"bytes,2,rep,name=change_response,json=changeResponse,proto3" json:"change_response,omitempty"`
+	ErrorMessage   string            `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+}
+
+func (x *ZoneChangeResponses) Reset() {
+	*x = ZoneChangeResponses{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_server_remote_common_remote_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ZoneChangeResponses) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZoneChangeResponses) ProtoMessage() {}
+
+func (x *ZoneChangeResponses) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_server_remote_common_remote_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZoneChangeResponses.ProtoReflect.Descriptor instead.
+func (*ZoneChangeResponses) Descriptor() ([]byte, []int) {
+	return file_pkg_server_remote_common_remote_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ZoneChangeResponses) GetZoneid() string {
+	if x != nil {
+		return x.Zoneid
+	}
+	return ""
+}
+
+func (x *ZoneChangeResponses) GetChangeResponse() []*ChangeResponse {
+	if x != nil {
+		return x.ChangeResponse
+	}
+	return nil
+}
+
+func (x *ZoneChangeResponses) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
 type RecordSet_Record struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1172,7 +1400,7 @@ type RecordSet_Record struct {
 func (x *RecordSet_Record) Reset() {
 	*x = RecordSet_Record{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_server_remote_common_remote_proto_msgTypes[16]
+		mi := &file_pkg_server_remote_common_remote_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1185,7 +1413,7 @@ func (x *RecordSet_Record) String() string {
 func (*RecordSet_Record) ProtoMessage() {}
 
 func (x *RecordSet_Record) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_server_remote_common_remote_proto_msgTypes[16]
+	mi := &file_pkg_server_remote_common_remote_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1363,28 +1591,66 @@ var file_pkg_server_remote_common_remote_proto_rawDesc = []byte{
 	0x00, 0x12, 0x0d, 0x0a, 0x09, 0x53, 0x55, 0x43, 0x43, 0x45, 0x45, 0x44, 0x45, 0x44, 0x10, 0x01,
 	0x12, 0x0b, 0x0a, 0x07, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x10, 0x02, 0x12, 0x0a, 0x0a,
 	0x06, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x03, 0x12, 0x0d, 0x0a, 0x09, 0x54, 0x48, 0x52,
-	0x4f, 0x54, 0x54, 0x4c, 0x45, 0x44, 0x10, 0x04, 0x32, 0xfe, 0x01, 0x0a, 0x0e, 0x52, 0x65, 0x6d,
-	0x6f, 0x74, 0x65, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x12, 0x36, 0x0a, 0x05, 0x4c,
-	0x6f, 0x67, 0x69, 0x6e, 0x12, 0x14, 0x2e, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x2e, 0x4c, 0x6f,
-	0x67, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x72, 0x65, 0x6d,
-	0x6f, 0x74, 0x65, 0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x00, 0x12, 0x34, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x5a, 0x6f, 0x6e, 0x65, 0x73, 0x12,
-	0x17, 0x2e, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x5a, 0x6f, 0x6e, 0x65,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x72, 0x65, 0x6d, 0x6f, 0x74,
-	0x65, 0x2e, 0x5a, 0x6f, 0x6e, 0x65, 0x73, 0x22, 0x00, 0x12, 0x40, 0x0a, 0x0c, 0x47, 0x65, 0x74,
-	0x5a, 0x6f, 0x6e, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1b, 0x2e, 0x72, 0x65, 0x6d, 0x6f,
-	0x74, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x5a, 0x6f, 0x6e, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x2e,
-	0x5a, 0x6f, 0x6e, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x07, 0x45,
-	0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x12, 0x16, 0x2e, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x2e,
-	0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17,
-	0x2e, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x2e, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x46, 0x5a, 0x44, 0x67, 0x69, 0x74,
-	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x61, 0x72, 0x64, 0x65, 0x6e, 0x65, 0x72,
-	0x2f, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2d, 0x64, 0x6e, 0x73, 0x2d, 0x6d, 0x61,
-	0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x73, 0x65, 0x72,
-	0x76, 0x65, 0x72, 0x2f, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
-	0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x4f, 0x54, 0x54, 0x4c, 0x45, 0x44, 0x10, 0x04, 0x22, 0x79, 0x0a, 0x13, 0x45, 0x78, 0x65, 0x63,
+	0x75, 0x74, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x4c, 0x0a, 0x14, 0x7a, 0x6f, 0x6e, 0x65, 0x5f, 0x63, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x2e, 0x5a, 0x6f, 0x6e,
+	0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x52,
+	0x12, 0x7a, 0x6f, 0x6e, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x73, 0x22, 0x6a, 0x0a, 0x12, 0x5a, 0x6f, 0x6e, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x7a, 0x6f, 0x6e,
+	0x65, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x7a, 0x6f, 0x6e, 0x65, 0x69,
+	0x64, 0x12, 0x3c, 0x0a, 0x0e, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x72, 0x65, 0x6d, 0x6f,
+	0x74, 0x65, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x52, 0x0d, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22,
+	0x9a, 0x01, 0x0a, 0x14, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4f, 0x0a, 0x15, 0x7a, 0x6f, 0x6e, 0x65,
+	0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65,
+	0x2e, 0x5a, 0x6f, 0x6e, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x73, 0x52, 0x13, 0x7a, 0x6f, 0x6e, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x12, 0x31, 0x0a, 0x0b, 0x6c, 0x6f, 0x67,
+	0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10,
+	0x2e, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x2e, 0x4c, 0x6f, 0x67, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x0a, 0x6c, 0x6f, 0x67, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x93, 0x01, 0x0a,
+	0x13, 0x5a, 0x6f, 0x6e, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x7a, 0x6f, 0x6e, 0x65, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x7a, 0x6f, 0x6e, 0x65, 0x69, 0x64, 0x12, 0x3f, 0x0a, 0x0f,
+	0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x2e, 0x43,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x0e, 0x63,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23, 0x0a,
+	0x0d, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x32, 0xcb, 0x02, 0x0a, 0x0e, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x50, 0x72, 0x6f,
+	0x76, 0x69, 0x64, 0x65, 0x72, 0x12, 0x36, 0x0a, 0x05, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x12, 0x14,
+	0x2e, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x2e, 0x4c, 0x6f,
+	0x67, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x34, 0x0a,
+	0x08, 0x47, 0x65, 0x74, 0x5a, 0x6f, 0x6e, 0x65, 0x73, 0x12, 0x17, 0x2e, 0x72, 0x65, 0x6d, 0x6f,
+	0x74, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x5a, 0x6f, 0x6e, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x2e, 0x5a, 0x6f, 0x6e, 0x65,
+	0x73, 0x22, 0x00, 0x12, 0x40, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x5a, 0x6f, 0x6e, 0x65, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x12, 0x1b, 0x2e, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x2e, 0x47, 0x65, 0x74,
+	0x5a, 0x6f, 0x6e, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x11, 0x2e, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x2e, 0x5a, 0x6f, 0x6e, 0x65, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x07, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65,
+	0x12, 0x16, 0x2e, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x2e, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x72, 0x65, 0x6d, 0x6f, 0x74,
+	0x65, 0x2e, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x0c, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x12, 0x1b, 0x2e, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x2e, 0x45, 0x78, 0x65,
+	0x63, 0x75, 0x74, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1c, 0x2e, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x2e, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74,
+	0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x42, 0x46, 0x5a, 0x44, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67,
+	0x61, 0x72, 0x64, 0x65, 0x6e, 0x65, 0x72, 0x2f, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c,
+	0x2d, 0x64, 0x6e, 0x73, 0x2d, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2f,
+	0x70, 0x6b, 0x67, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x72, 0x65, 0x6d, 0x6f, 0x74,
+	0x65, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1400,7 +1666,7 @@ func file_pkg_server_remote_common_remote_proto_rawDescGZIP() []byte {
 }
 
 var file_pkg_server_remote_common_remote_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_pkg_server_remote_common_remote_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_pkg_server_remote_common_remote_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
 var file_pkg_server_remote_common_remote_proto_goTypes = []interface{}{
 	(ChangeRequest_ActionType)(0), // 0: remote.ChangeRequest.ActionType
 	(LogEntry_Level)(0),           // 1: remote.LogEntry.Level
@@ -1421,20 +1687,24 @@ var file_pkg_server_remote_common_remote_proto_goTypes = []interface{}{
 	(*LogEntry)(nil),              // 16: remote.LogEntry
 	(*ExecuteResponse)(nil),       // 17: remote.ExecuteResponse
 	(*ChangeResponse)(nil),        // 18: remote.ChangeResponse
-	(*RecordSet_Record)(nil),      // 19: remote.RecordSet.Record
-	nil,                           // 20: remote.RoutingPolicy.ParametersEntry
-	nil,                           // 21: remote.DNSSet.RecordsEntry
-	nil,                           // 22: remote.ZoneState.DnsSetsEntry
+	(*ExecuteBatchRequest)(nil),   // 19: remote.ExecuteBatchRequest
+	(*ZoneChangeRequests)(nil),    // 20: remote.ZoneChangeRequests
+	(*ExecuteBatchResponse)(nil),  // 21: remote.ExecuteBatchResponse
+	(*ZoneChangeResponses)(nil),   // 22: remote.ZoneChangeResponses
+	(*RecordSet_Record)(nil),      // 23: remote.RecordSet.Record
+	nil,                           // 24: remote.RoutingPolicy.ParametersEntry
+	nil,                           // 25: remote.DNSSet.RecordsEntry
+	nil,                           // 26: remote.ZoneState.DnsSetsEntry
 }
 var file_pkg_server_remote_common_remote_proto_depIdxs = []int32{
 	7,  // 0: remote.Zones.zone:type_name -> remote.Zone
-	19, // 1: remote.RecordSet.record:type_name -> remote.RecordSet.Record
-	20, // 2: remote.RoutingPolicy.parameters:type_name -> remote.RoutingPolicy.ParametersEntry
-	21, // 3: remote.DNSSet.records:type_name -> remote.DNSSet.RecordsEntry
+	23, // 1: remote.RecordSet.record:type_name -> remote.RecordSet.Record
+	24, // 2: remote.RoutingPolicy.parameters:type_name -> remote.RoutingPolicy.ParametersEntry
+	25, // 3: remote.DNSSet.records:type_name -> remote.DNSSet.RecordsEntry
 	10, // 4: remote.DNSSet.routing_policy:type_name -> remote.RoutingPolicy
 	9,  // 5: remote.PartialDNSSet.record_set:type_name -> remote.RecordSet
 	10, // 6: remote.PartialDNSSet.routing_policy:type_name -> remote.RoutingPolicy
-	22, // 7: remote.ZoneState.dns_sets:type_name -> remote.ZoneState.DnsSetsEntry
+	26, // 7: remote.ZoneState.dns_sets:type_name -> remote.ZoneState.DnsSetsEntry
 	15, // 8: remote.ExecuteRequest.change_request:type_name -> remote.ChangeRequest
 	0,  // 9: remote.ChangeRequest.action:type_name -> remote.ChangeRequest.ActionType
 	12, // 10: remote.ChangeRequest.change:type_name -> remote.PartialDNSSet
@@ -1442,21 +1712,28 @@ var file_pkg_server_remote_common_remote_proto_depIdxs = []int32{
 	18, // 12: remote.ExecuteResponse.change_response:type_name -> remote.ChangeResponse
 	16, // 13: remote.ExecuteResponse.log_message:type_name -> remote.LogEntry
 	2,  // 14: remote.ChangeResponse.state:type_name -> remote.ChangeResponse.State
-	9,  // 15: remote.DNSSet.RecordsEntry.value:type_name -> remote.RecordSet
-	11, // 16: remote.ZoneState.DnsSetsEntry.value:type_name -> remote.DNSSet
-	3,  // 17: remote.RemoteProvider.Login:input_type -> remote.LoginRequest
-	5,  // 18: remote.RemoteProvider.GetZones:input_type -> remote.GetZonesRequest
-	8,  // 19: remote.RemoteProvider.GetZoneState:input_type -> remote.GetZoneStateRequest
-	14, // 20: remote.RemoteProvider.Execute:input_type -> remote.ExecuteRequest
-	4,  // 21: remote.RemoteProvider.Login:output_type -> remote.LoginResponse
-	6,  // 22: remote.RemoteProvider.GetZones:output_type -> remote.Zones
-	13, // 23: remote.RemoteProvider.GetZoneState:output_type -> remote.ZoneState
-	17, // 24: remote.RemoteProvider.Execute:output_type -> remote.ExecuteResponse
-	21, // [21:25] is the sub-list for method output_type
-	17, // [17:21] is the sub-list for method input_type
-	17, // [17:17] is the sub-list for extension type_name
-	17, // [17:17] is the sub-list for extension extendee
-	0,  // [0:17] is the sub-list for field type_name
+	20, // 15: remote.ExecuteBatchRequest.zone_change_requests:type_name -> remote.ZoneChangeRequests
+	15, // 16: remote.ZoneChangeRequests.change_request:type_name -> remote.ChangeRequest
+	22, // 17: remote.ExecuteBatchResponse.zone_change_responses:type_name -> remote.ZoneChangeResponses
+	16, // 18: remote.ExecuteBatchResponse.log_message:type_name -> remote.LogEntry
+	18, // 19: remote.ZoneChangeResponses.change_response:type_name -> remote.ChangeResponse
+	9,  // 20: remote.DNSSet.RecordsEntry.value:type_name -> remote.RecordSet
+	11, // 21: remote.ZoneState.DnsSetsEntry.value:type_name -> remote.DNSSet
+	3,  // 22: remote.RemoteProvider.Login:input_type -> remote.LoginRequest
+	5,  // 23: remote.RemoteProvider.GetZones:input_type -> remote.GetZonesRequest
+	8,  // 24: remote.RemoteProvider.GetZoneState:input_type -> remote.GetZoneStateRequest
+	14, // 25: remote.RemoteProvider.Execute:input_type -> remote.ExecuteRequest
+	19, // 26: remote.RemoteProvider.ExecuteBatch:input_type -> remote.ExecuteBatchRequest
+	4,  // 27: remote.RemoteProvider.Login:output_type -> remote.LoginResponse
+	6,  // 28: remote.RemoteProvider.GetZones:output_type -> remote.Zones
+	13, // 29: remote.RemoteProvider.GetZoneState:output_type -> remote.ZoneState
+	17, // 30: remote.RemoteProvider.Execute:output_type -> remote.ExecuteResponse
+	21, // 31: remote.RemoteProvider.ExecuteBatch:output_type -> remote.ExecuteBatchResponse
+	27, // [27:32] is the sub-list for method output_type
+	22, // [22:27] is the sub-list for method input_type
+	22, // [22:22] is the sub-list for extension type_name
+	22, // [22:22] is the sub-list for extension extendee
+	0,  // [0:22] is the sub-list for field type_name
 }
 
 func init() { file_pkg_server_remote_common_remote_proto_init() }
@@ -1658,6 +1935,54 @@ func file_pkg_server_remote_common_remote_proto_init() {
 			}
 		}
 		file_pkg_server_remote_common_remote_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExecuteBatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_server_remote_common_remote_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ZoneChangeRequests); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_server_remote_common_remote_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExecuteBatchResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_server_remote_common_remote_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ZoneChangeResponses); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_server_remote_common_remote_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RecordSet_Record); i {
 			case 0:
 				return &v.state
@@ -1676,7 +2001,7 @@ func file_pkg_server_remote_common_remote_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_pkg_server_remote_common_remote_proto_rawDesc,
 			NumEnums:      3,
-			NumMessages:   20,
+			NumMessages:   24,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -23,6 +23,7 @@ type RemoteProviderClient interface {
 	GetZones(ctx context.Context, in *GetZonesRequest, opts ...grpc.CallOption) (*Zones, error)
 	GetZoneState(ctx context.Context, in *GetZoneStateRequest, opts ...grpc.CallOption) (*ZoneState, error)
 	Execute(ctx context.Context, in *ExecuteRequest, opts ...grpc.CallOption) (*ExecuteResponse, error)
+	ExecuteBatch(ctx context.Context, in *ExecuteBatchRequest, opts ...grpc.CallOption) (*ExecuteBatchResponse, error)
 }
 
 type remoteProviderClient struct {
@@ -69,6 +70,15 @@ func (c *remoteProviderClient) Execute(ctx context.Context, in *ExecuteRequest,
 	return out, nil
 }
 
+func (c *remoteProviderClient) ExecuteBatch(ctx context.Context, in *ExecuteBatchRequest, opts ...grpc.CallOption) (*ExecuteBatchResponse, error) {
+	out := new(ExecuteBatchResponse)
+	err := c.cc.Invoke(ctx, "/remote.RemoteProvider/ExecuteBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RemoteProviderServer is the server API for RemoteProvider service.
 // All implementations must embed UnimplementedRemoteProviderServer
 // for forward compatibility
@@ -77,6 +87,7 @@ type RemoteProviderServer interface {
 	GetZones(context.Context, *GetZonesRequest) (*Zones, error)
 	GetZoneState(context.Context, *GetZoneStateRequest) (*ZoneState, error)
 	Execute(context.Context, *ExecuteRequest) (*ExecuteResponse, error)
+	ExecuteBatch(context.Context, *ExecuteBatchRequest) (*ExecuteBatchResponse, error)
 	mustEmbedUnimplementedRemoteProviderServer()
 }
 
@@ -96,6 +107,9 @@ func (UnimplementedRemoteProviderServer) GetZoneState(context.Context, *GetZoneS
 func (UnimplementedRemoteProviderServer) Execute(context.Context, *ExecuteRequest) (*ExecuteResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Execute not implemented")
 }
+func (UnimplementedRemoteProviderServer) ExecuteBatch(context.Context, *ExecuteBatchRequest) (*ExecuteBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExecuteBatch not implemented")
+}
 func (UnimplementedRemoteProviderServer) mustEmbedUnimplementedRemoteProviderServer() {}
 
 // UnsafeRemoteProviderServer may be embedded to opt out of forward compatibility for this service.
@@ -181,6 +195,24 @@ func _RemoteProvider_Execute_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RemoteProvider_ExecuteBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecuteBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteProviderServer).ExecuteBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remote.RemoteProvider/ExecuteBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteProviderServer).ExecuteBatch(ctx, req.(*ExecuteBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // RemoteProvider_ServiceDesc is the grpc.ServiceDesc for RemoteProvider service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -204,6 +236,10 @@ var RemoteProvider_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Execute",
 			Handler:    _RemoteProvider_Execute_Handler,
 		},
+		{
+			MethodName: "ExecuteBatch",
+			Handler:    _RemoteProvider_ExecuteBatch_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/server/remote/common/remote.proto",
@@ -4,6 +4,8 @@
 
 package common
 
+import "crypto/x509"
+
 const InvalidToken = "[invalid token]"
 
 const (
@@ -13,4 +15,15 @@ const (
 	ProtocolVersion1 = 1
 )
 
+// MaxBatchChangeRequests is the maximum number of change requests accepted by a single ExecuteBatch call.
+const MaxBatchChangeRequests = 1000
+
 type DNSSets map[string]*DNSSet
+
+// CertData contains a certificate created for remote access.
+type CertData struct {
+	Certificate *x509.Certificate
+	CACrt       []byte
+	TLSKey      []byte
+	TLSCrt      []byte
+}
@@ -33,6 +33,7 @@ type server struct {
 
 	tokenTTL           time.Duration
 	tokenCleanupTicker *time.Ticker
+	maxBatchSize       int
 
 	common.UnimplementedRemoteProviderServer
 }
@@ -52,6 +53,7 @@ func newServer(logctx logger.LogContext) (*server, error) {
 		logctx:          logctx,
 		namespaceStates: map[string]*namespaceState{},
 		tokenTTL:        2 * time.Hour,
+		maxBatchSize:    common.MaxBatchChangeRequests,
 	}
 
 	s.tokenCleanupTicker = time.NewTicker(s.tokenTTL)
@@ -293,6 +295,18 @@ func (s *server) Execute(_ context.Context, request *common.ExecuteRequest) (*co
 }
 
 func (s *server) execute(nsState *namespaceState, logctx logger.LogContext, zoneid string, changeRequests []*common.ChangeRequest) (*common.ExecuteResponse, error) {
+	memLogger := newMemoryLogger(logctx)
+	responses, err := s.executeZone(nsState, logctx, memLogger, zoneid, changeRequests)
+	if responses == nil && err != nil {
+		return nil, err
+	}
+	return &common.ExecuteResponse{
+		ChangeResponse: responses,
+		LogMessage:     memLogger.entries,
+	}, err
+}
+
+func (s *server) executeZone(nsState *namespaceState, logctx logger.LogContext, memLogger *memoryLogger, zoneid string, changeRequests []*common.ChangeRequest) ([]*common.ChangeResponse, error) {
 	hstate, zone, err := nsState.lockupZone(s.spinning, zoneid)
 	if err != nil {
 		return nil, err
@@ -309,7 +323,6 @@ func (s *server) execute(nsState *namespaceState, logctx logger.LogContext, zone
 		return nil, err
 	}
 
-	memLogger := newMemoryLogger(logctx)
 	var requests []*provider.ChangeRequest
 	var responses []*common.ChangeResponse
 	for _, request := range changeRequests {
@@ -323,10 +336,52 @@ func (s *server) execute(nsState *namespaceState, logctx logger.LogContext, zone
 		requests = append(requests, req)
 	}
 	err = hstate.handler.ExecuteRequests(memLogger, zone, state, requests)
-	return &common.ExecuteResponse{
-		ChangeResponse: responses,
-		LogMessage:     memLogger.entries,
-	}, err
+	return responses, err
+}
+
+func (s *server) ExecuteBatch(_ context.Context, request *common.ExecuteBatchRequest) (*common.ExecuteBatchResponse, error) {
+	nsState, logctx, report, _, err := s.checkAuth(request.Token, "ExecuteBatch", "")
+	if err != nil {
+		logctx.Warn(err)
+		return nil, err
+	}
+	count := 0
+	for _, zoneRequests := range request.ZoneChangeRequests {
+		count += len(zoneRequests.ChangeRequest)
+	}
+	logctx.Infof("ExecuteBatch: %d changes for %d zones", count, len(request.ZoneChangeRequests))
+	if count > s.maxBatchSize {
+		err = status.Errorf(codes.InvalidArgument, "batch size %d exceeds maximum of %d change requests", count, s.maxBatchSize)
+		logctx.Warn(err)
+		report(err)
+		return nil, err
+	}
+
+	res := s.executeBatch(nsState, logctx, request.ZoneChangeRequests)
+	report(nil)
+	return res, nil
+}
+
+// executeBatch executes the change requests zone by zone. Errors are reported per zone,
+// so that a failing zone does not prevent the execution of the remaining ones.
+func (s *server) executeBatch(nsState *namespaceState, logctx logger.LogContext, zoneChangeRequests []*common.ZoneChangeRequests) *common.ExecuteBatchResponse {
+	memLogger := newMemoryLogger(logctx)
+	result := &common.ExecuteBatchResponse{}
+	for _, zoneRequests := range zoneChangeRequests {
+		zoneLogctx := logctx.NewContext("zoneid", zoneRequests.Zoneid)
+		responses, err := s.executeZone(nsState, zoneLogctx, memLogger, zoneRequests.Zoneid, zoneRequests.ChangeRequest)
+		zoneResponses := &common.ZoneChangeResponses{
+			Zoneid:         zoneRequests.Zoneid,
+			ChangeResponse: responses,
+		}
+		if err != nil {
+			zoneLogctx.Warnf("execution failed: %s", err)
+			zoneResponses.ErrorMessage = err.Error()
+		}
+		result.ZoneChangeResponses = append(result.ZoneChangeResponses, zoneResponses)
+	}
+	result.LogMessage = memLogger.entries
+	return result
 }
 
 func newDoneHandler(response *common.ChangeResponse) provider.DoneHandler {
@@ -0,0 +1,182 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package remote
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	"github.com/gardener/external-dns-management/pkg/server/remote/common"
+	"github.com/gardener/external-dns-management/pkg/server/remote/conversion"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type testHandler struct {
+	zones    provider.DNSHostedZones
+	failZone string
+	executed map[string]int
+}
+
+var _ provider.LightDNSHandler = &testHandler{}
+
+func (h *testHandler) ProviderType() string {
+	return "test"
+}
+
+func (h *testHandler) GetZones() (provider.DNSHostedZones, error) {
+	return h.zones, nil
+}
+
+func (h *testHandler) GetZoneState(_ provider.DNSHostedZone) (provider.DNSZoneState, error) {
+	return provider.NewDNSZoneState(dns.DNSSets{}), nil
+}
+
+func (h *testHandler) ExecuteRequests(logger logger.LogContext, zone provider.DNSHostedZone, _ provider.DNSZoneState, reqs []*provider.ChangeRequest) error {
+	if zone.Id().ID == h.failZone {
+		for _, req := range reqs {
+			req.Done.Failed(fmt.Errorf("zone failed"))
+		}
+		return fmt.Errorf("zone %s failed", zone.Id().ID)
+	}
+	logger.Infof("executing %d requests", len(reqs))
+	h.executed[zone.Id().ID] += len(reqs)
+	for _, req := range reqs {
+		req.Done.Succeeded()
+	}
+	return nil
+}
+
+func newTestServer(t *testing.T, handler *testHandler) (*server, string) {
+	s, err := newServer(logger.New())
+	if err != nil {
+		t.Fatalf("newServer failed: %s", err)
+	}
+	s.tokenCleanupTicker.Stop()
+	nsState := s.getNamespaceState("test", true)
+	nsState.updateHandler(s.logctx, "provider", handler)
+	token := nsState.generateAndAddToken(s.tokenTTL, "rnd", "client", s.serverID, common.ProtocolVersion1)
+	return s, token
+}
+
+func newTestChangeRequests(t *testing.T, count int) []*common.ChangeRequest {
+	var result []*common.ChangeRequest
+	for i := 0; i < count; i++ {
+		set := dns.NewDNSSet(dns.DNSSetName{DNSName: fmt.Sprintf("e%d.example.com", i)}, nil)
+		set.SetRecordSet(dns.RS_A, 300, "1.2.3.4")
+		change, err := conversion.MarshalChangeRequest(provider.NewChangeRequest(provider.R_CREATE, dns.RS_A, nil, set, nil))
+		if err != nil {
+			t.Fatalf("marshal failed: %s", err)
+		}
+		result = append(result, change)
+	}
+	return result
+}
+
+func TestExecuteBatch(t *testing.T) {
+	handler := &testHandler{
+		zones: provider.DNSHostedZones{
+			provider.NewDNSHostedZone("test", "z1", "example.com", "", false),
+			provider.NewDNSHostedZone("test", "z2", "example.org", "", false),
+			provider.NewDNSHostedZone("test", "z3", "example.net", "", false),
+		},
+		failZone: "z2",
+		executed: map[string]int{},
+	}
+	s, token := newTestServer(t, handler)
+
+	response, err := s.ExecuteBatch(context.Background(), &common.ExecuteBatchRequest{
+		Token: token,
+		ZoneChangeRequests: []*common.ZoneChangeRequests{
+			{Zoneid: "z1", ChangeRequest: newTestChangeRequests(t, 3)},
+			{Zoneid: "z2", ChangeRequest: newTestChangeRequests(t, 1)},
+			{Zoneid: "z3", ChangeRequest: newTestChangeRequests(t, 2)},
+			{Zoneid: "unknown", ChangeRequest: newTestChangeRequests(t, 1)},
+		},
+	})
+	if err != nil {
+		t.Fatalf("ExecuteBatch failed: %s", err)
+	}
+	if len(response.ZoneChangeResponses) != 4 {
+		t.Fatalf("expected 4 zone responses, got %d", len(response.ZoneChangeResponses))
+	}
+	if handler.executed["z1"] != 3 || handler.executed["z3"] != 2 {
+		t.Errorf("unexpected executions: %v", handler.executed)
+	}
+
+	expected := []struct {
+		zoneid    string
+		responses int
+		state     common.ChangeResponse_State
+		failed    bool
+	}{
+		{"z1", 3, common.ChangeResponse_SUCCEEDED, false},
+		{"z2", 1, common.ChangeResponse_FAILED, true},
+		{"z3", 2, common.ChangeResponse_SUCCEEDED, false},
+		{"unknown", 0, common.ChangeResponse_NOT_PROCESSED, true},
+	}
+	for i, exp := range expected {
+		zr := response.ZoneChangeResponses[i]
+		if zr.Zoneid != exp.zoneid {
+			t.Errorf("%d: expected zone %s, got %s", i, exp.zoneid, zr.Zoneid)
+		}
+		if (zr.ErrorMessage != "") != exp.failed {
+			t.Errorf("%s: unexpected error message %q", exp.zoneid, zr.ErrorMessage)
+		}
+		if len(zr.ChangeResponse) != exp.responses {
+			t.Errorf("%s: expected %d change responses, got %d", exp.zoneid, exp.responses, len(zr.ChangeResponse))
+		}
+		for _, cr := range zr.ChangeResponse {
+			if cr.State != exp.state {
+				t.Errorf("%s: expected state %s, got %s", exp.zoneid, exp.state, cr.State)
+			}
+		}
+	}
+	if len(response.LogMessage) == 0 {
+		t.Errorf("expected log messages")
+	}
+}
+
+func TestExecuteBatchSizeLimit(t *testing.T) {
+	handler := &testHandler{
+		zones: provider.DNSHostedZones{
+			provider.NewDNSHostedZone("test", "z1", "example.com", "", false),
+		},
+		executed: map[string]int{},
+	}
+	s, token := newTestServer(t, handler)
+	s.maxBatchSize = 5
+
+	_, err := s.ExecuteBatch(context.Background(), &common.ExecuteBatchRequest{
+		Token: token,
+		ZoneChangeRequests: []*common.ZoneChangeRequests{
+			{Zoneid: "z1", ChangeRequest: newTestChangeRequests(t, 3)},
+			{Zoneid: "z1", ChangeRequest: newTestChangeRequests(t, 3)},
+		},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected invalid argument error, got %v", err)
+	}
+	if len(handler.executed) != 0 {
+		t.Errorf("expected no executions: %v", handler.executed)
+	}
+
+	response, err := s.ExecuteBatch(context.Background(), &common.ExecuteBatchRequest{
+		Token: token,
+		ZoneChangeRequests: []*common.ZoneChangeRequests{
+			{Zoneid: "z1", ChangeRequest: newTestChangeRequests(t, 5)},
+		},
+	})
+	if err != nil {
+		t.Fatalf("ExecuteBatch failed: %s", err)
+	}
+	if len(response.ZoneChangeResponses) != 1 || handler.executed["z1"] != 5 {
+		t.Errorf("unexpected result: %v, executed: %v", response.ZoneChangeResponses, handler.executed)
+	}
+}
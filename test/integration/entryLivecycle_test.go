@@ -11,6 +11,7 @@ import (
 
 	"github.com/gardener/controller-manager-library/pkg/utils"
 	"github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"k8s.io/utils/ptr"
@@ -104,6 +105,85 @@ var _ = Describe("EntryLivecycle", func() {
 		Ω(err).ShouldNot(HaveOccurred())
 	})
 
+	It("has correct life cycle with provider for SRV record", func() {
+		pr, domain, _, err := testEnv.CreateSecretAndProvider("inmemory.mock", 0)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		defer testEnv.DeleteProviderAndSecret(pr)
+
+		ttl := int64(120)
+		setSpec := func(e *v1alpha1.DNSEntry) {
+			e.Spec.TTL = &ttl
+			e.Spec.DNSName = "_sip._tcp." + domain
+			e.Spec.RecordType = "SRV"
+			e.Spec.Records = []string{"10 5 5060 sip." + domain + "."}
+		}
+		e, err := testEnv.CreateEntryGeneric(0, setSpec)
+		Ω(err).ShouldNot(HaveOccurred())
+		defer testEnv.DeleteEntryAndWait(e)
+
+		checkProvider(pr)
+
+		checkEntry(e, pr)
+
+		dnsSet, err := testEnv.MockInMemoryGetDNSSet("_sip._tcp." + domain)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(dnsSet).ShouldNot(BeNil())
+		Ω(dnsSet.Sets[dns.RS_SRV].Records).Should(ConsistOf(&dns.Record{Value: "10 5 5060 sip." + domain}))
+
+		setSpec = func(e *v1alpha1.DNSEntry) {
+			e.Spec.Records = []string{"10 5 sip." + domain}
+		}
+		e, err = testEnv.CreateEntryGeneric(0, setSpec)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		err = testEnv.AwaitEntryInvalid(e.GetName())
+		Ω(err).ShouldNot(HaveOccurred())
+
+		err = testEnv.DeleteEntryAndWait(e)
+		Ω(err).ShouldNot(HaveOccurred())
+	})
+
+	It("has correct life cycle with provider for CAA record", func() {
+		pr, domain, _, err := testEnv.CreateSecretAndProvider("inmemory.mock", 0)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		defer testEnv.DeleteProviderAndSecret(pr)
+
+		setSpec := func(e *v1alpha1.DNSEntry) {
+			e.Spec.DNSName = domain
+			e.Spec.RecordType = "CAA"
+			e.Spec.Records = []string{`0 issue "letsencrypt.org"`, "0 iodef mailto:security@" + domain}
+		}
+		e, err := testEnv.CreateEntryGeneric(0, setSpec)
+		Ω(err).ShouldNot(HaveOccurred())
+		defer testEnv.DeleteEntryAndWait(e)
+
+		checkProvider(pr)
+
+		checkEntry(e, pr)
+
+		dnsSet, err := testEnv.MockInMemoryGetDNSSet(domain)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(dnsSet).ShouldNot(BeNil())
+		Ω(dnsSet.Sets[dns.RS_CAA].Records).Should(ConsistOf(
+			&dns.Record{Value: `0 issue "letsencrypt.org"`},
+			&dns.Record{Value: `0 iodef "mailto:security@` + domain + `"`},
+		))
+
+		setSpec = func(e *v1alpha1.DNSEntry) {
+			e.Spec.Records = []string{"0 letsencrypt.org"}
+		}
+		e, err = testEnv.CreateEntryGeneric(0, setSpec)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		err = testEnv.AwaitEntryInvalid(e.GetName())
+		Ω(err).ShouldNot(HaveOccurred())
+
+		err = testEnv.DeleteEntryAndWait(e)
+		Ω(err).ShouldNot(HaveOccurred())
+	})
+
 	It("is handled only by owner", func() {
 		pr, domain, _, err := testEnv.CreateSecretAndProvider("inmemory.mock", 0)
 		Ω(err).ShouldNot(HaveOccurred())
@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package integration
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+const weightedEntryCount = 30
+
+var _ = Describe("ManyWeightedEntriesOneName", func() {
+	It("provisions all weighted records of a name created concurrently", func() {
+		oldTimeout := testEnv.defaultTimeout
+		testEnv.defaultTimeout = oldTimeout * time.Duration(int64(math.Sqrt(weightedEntryCount)))
+		defer func() { testEnv.defaultTimeout = oldTimeout }()
+
+		pr, domain, _, err := testEnv.CreateSecretAndProvider("inmemory.mock", 0)
+		Ω(err).ShouldNot(HaveOccurred())
+		defer testEnv.DeleteProviderAndSecret(pr)
+
+		checkProvider(pr)
+
+		dnsName := "weighted." + domain
+		entries := make([]resources.Object, weightedEntryCount)
+		var wg sync.WaitGroup
+		for i := 0; i < weightedEntryCount; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer GinkgoRecover()
+				setSpec := func(e *v1alpha1.DNSEntry) {
+					e.Spec.DNSName = dnsName
+					e.Spec.Targets = []string{fmt.Sprintf("1.1.1.%d", i)}
+					e.Spec.RoutingPolicy = &v1alpha1.RoutingPolicy{
+						Type:          "weighted",
+						SetIdentifier: fmt.Sprintf("id%d", i),
+						Parameters:    map[string]string{"weight": fmt.Sprintf("%d", i+1)},
+					}
+				}
+				e, err := testEnv.CreateEntryGeneric(i, setSpec)
+				Ω(err).ShouldNot(HaveOccurred())
+				entries[i] = e
+			}()
+		}
+		wg.Wait()
+
+		for i, entry := range entries {
+			checkEntry(entry, pr)
+
+			setName := dns.DNSSetName{DNSName: dnsName, SetIdentifier: fmt.Sprintf("id%d", i)}
+			set, err := testEnv.MockInMemoryGetDNSSetByName(testEnv.Namespace, testEnv.ZonePrefix, setName)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(set).ShouldNot(BeNil(), "missing weighted record set %s", setName)
+			Ω(set.Sets[dns.RS_A].Records).Should(HaveLen(1))
+			Ω(set.Sets[dns.RS_A].Records[0].Value).Should(Equal(fmt.Sprintf("1.1.1.%d", i)))
+			Ω(set.RoutingPolicy).ShouldNot(BeNil())
+			Ω(set.RoutingPolicy.Parameters["weight"]).Should(Equal(fmt.Sprintf("%d", i+1)))
+		}
+
+		err = testEnv.DeleteEntriesAndWait(entries...)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		for i := range entries {
+			setName := dns.DNSSetName{DNSName: dnsName, SetIdentifier: fmt.Sprintf("id%d", i)}
+			set, err := testEnv.MockInMemoryGetDNSSetByName(testEnv.Namespace, testEnv.ZonePrefix, setName)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(set).Should(BeNil())
+		}
+	})
+})
@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package integration
+
+import (
+	"fmt"
+
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/controller/provider/mock"
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+var _ = Describe("Migration", func() {
+	It("moves the entries to the target provider without removing their records in between", func() {
+		pr, domain, _, err := testEnv.CreateSecretAndProvider("migration.inmemory.mock", 0)
+		Ω(err).ShouldNot(HaveOccurred())
+		defer func() { _ = testEnv.DeleteProviderAndSecret(pr) }()
+		checkProvider(pr)
+
+		e, err := testEnv.CreateEntry(0, domain)
+		Ω(err).ShouldNot(HaveOccurred())
+		defer func() { _ = testEnv.DeleteEntryAndWait(e) }()
+		checkEntry(e, pr)
+		dnsName := UnwrapEntry(e).Spec.DNSName
+
+		hasRecords := func(mockName, zonePrefix string) (bool, error) {
+			set, err := testEnv.MockInMemoryGetDNSSetEx(mockName, zonePrefix, dnsName)
+			return set != nil && set.Sets[dns.RS_A] != nil, err
+		}
+		awaitRecords := func(mockName, zonePrefix string, expected bool) {
+			msg := fmt.Sprintf("records of %s in mock %s: %t", dnsName, mockName, expected)
+			err := testEnv.Await(msg, func() (bool, error) {
+				found, err := hasRecords(mockName, zonePrefix)
+				return found == expected, err
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+		}
+		awaitRecords(testEnv.Namespace, testEnv.ZonePrefix, true)
+
+		// the migration is inactive until the target provider is available
+		pr, err = testEnv.UpdateProviderSpec(pr, func(spec *v1alpha1.DNSProviderSpec) error {
+			spec.Migration = &v1alpha1.DNSProviderMigration{TargetProvider: "mock-provider-1"}
+			return nil
+		})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		targetMock := testEnv.Namespace + "-target"
+		targetPrefix := testEnv.ZonePrefix + "target:"
+		secret, err := testEnv.CreateSecret(1)
+		Ω(err).ShouldNot(HaveOccurred())
+		tpr, err := testEnv.CreateProviderEx(1, func(p *v1alpha1.DNSProvider) {
+			p.Spec.Type = "mock-inmemory"
+			p.Spec.Domains = &v1alpha1.DNSSelection{Include: []string{domain}}
+			p.Spec.ProviderConfig = testEnv.BuildProviderConfigEx(mock.MockConfig{
+				Name:  targetMock,
+				Zones: []mock.MockZone{{ZonePrefix: targetPrefix, DNSName: domain}},
+			})
+			p.Spec.SecretRef = &corev1.SecretReference{Name: secret.GetName(), Namespace: testEnv.Namespace}
+		})
+		Ω(err).ShouldNot(HaveOccurred())
+		defer func() { _ = testEnv.DeleteProviderAndSecret(tpr) }()
+		checkProvider(tpr)
+
+		err = testEnv.Await("migration completed", func() (bool, error) {
+			_, provider, err := testEnv.GetProvider(pr.GetName())
+			if err != nil {
+				return false, err
+			}
+			migration := provider.Status.Migration
+			return migration != nil && migration.Phase == v1alpha1.MIGRATION_PHASE_COMPLETED, nil
+		})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		// the entry is assigned to the target provider and its records are only kept in the target zone
+		err = testEnv.Await("entry assigned to target provider", func() (bool, error) {
+			obj, err := testEnv.GetEntry(e.GetName())
+			if err != nil {
+				return false, err
+			}
+			status := UnwrapEntry(obj).Status
+			return status.State == v1alpha1.STATE_READY && utils.StringValue(status.Provider) == tpr.ObjectName().String(), nil
+		})
+		Ω(err).ShouldNot(HaveOccurred())
+		awaitRecords(targetMock, targetPrefix, true)
+		awaitRecords(testEnv.Namespace, testEnv.ZonePrefix, false)
+
+		// the source provider can be deleted without affecting the entry
+		err = testEnv.DeleteProviderAndSecret(pr)
+		Ω(err).ShouldNot(HaveOccurred())
+		err = testEnv.AwaitProviderDeletion(pr.GetName())
+		Ω(err).ShouldNot(HaveOccurred())
+		err = testEnv.AwaitEntryReady(e.GetName())
+		Ω(err).ShouldNot(HaveOccurred())
+		awaitRecords(targetMock, targetPrefix, true)
+
+		err = testEnv.DeleteEntryAndWait(e)
+		Ω(err).ShouldNot(HaveOccurred())
+		awaitRecords(targetMock, targetPrefix, false)
+	})
+})
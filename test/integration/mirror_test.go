@@ -0,0 +1,123 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package integration
+
+import (
+	"fmt"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/controller/provider/mock"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsprovider "github.com/gardener/external-dns-management/pkg/dns/provider"
+)
+
+var _ = Describe("Mirror", func() {
+	It("keeps serving the primary provider while the mirror is down and updates the mirror in lockstep", func() {
+		pr, domain, _, err := testEnv.CreateSecretAndProvider("mirror.inmemory.mock", 0)
+		Ω(err).ShouldNot(HaveOccurred())
+		defer func() { _ = testEnv.DeleteProviderAndSecret(pr) }()
+
+		mirrorMock := testEnv.Namespace + "-mirror"
+		mirrorPrefix := testEnv.ZonePrefix + "mirror:"
+		mirrorConfig := func(options ...ProviderTestOption) *runtime.RawExtension {
+			return testEnv.BuildProviderConfigEx(mock.MockConfig{
+				Name:  mirrorMock,
+				Zones: []mock.MockZone{{ZonePrefix: mirrorPrefix, DNSName: domain}},
+			}, options...)
+		}
+		secret, err := testEnv.CreateSecret(1)
+		Ω(err).ShouldNot(HaveOccurred())
+		mpr, err := testEnv.CreateProviderEx(1, func(p *v1alpha1.DNSProvider) {
+			p.Spec.Type = "mock-inmemory"
+			p.Spec.Domains = &v1alpha1.DNSSelection{Include: []string{domain}}
+			p.Spec.ProviderConfig = mirrorConfig(FailGetZones)
+			p.Spec.SecretRef = &corev1.SecretReference{Name: secret.GetName(), Namespace: testEnv.Namespace}
+		})
+		Ω(err).ShouldNot(HaveOccurred())
+		defer func() { _ = testEnv.DeleteProviderAndSecret(mpr) }()
+
+		checkProvider(pr)
+		err = testEnv.AwaitProviderState(mpr.GetName(), v1alpha1.STATE_ERROR)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		pr, err = testEnv.UpdateProviderSpec(pr, func(spec *v1alpha1.DNSProviderSpec) error {
+			spec.Mirror = &v1alpha1.DNSProviderMirror{TargetProvider: mpr.GetName()}
+			return nil
+		})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		e, err := testEnv.CreateEntryGeneric(0, func(e *v1alpha1.DNSEntry) {
+			e.Spec.DNSName = "e0." + domain
+			e.Spec.Targets = []string{"1.1.0.0"}
+			e.Spec.ProviderRef = &v1alpha1.ProviderReference{Name: pr.GetName()}
+		})
+		Ω(err).ShouldNot(HaveOccurred())
+		mirrorEntry := dnsprovider.MirrorEntryName(e.GetName(), resources.NewObjectName(testEnv.Namespace, mpr.GetName()))
+
+		awaitMirrorState := func(ready bool) {
+			msg := fmt.Sprintf("mirror of entry %s ready=%t", e.GetName(), ready)
+			err := testEnv.Await(msg, func() (bool, error) {
+				obj, err := testEnv.GetEntry(e.GetName())
+				if err != nil {
+					return false, err
+				}
+				status := UnwrapEntry(obj).Status
+				if status.State != v1alpha1.STATE_READY || status.Mirror == nil || status.Mirror.Entry != mirrorEntry {
+					return false, nil
+				}
+				return (status.Mirror.State == v1alpha1.STATE_READY) == ready, nil
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+		}
+		awaitTargets := func(mockName, zonePrefix, target string) {
+			msg := fmt.Sprintf("%s has target %s in mock %s", e.GetName(), target, mockName)
+			err := testEnv.Await(msg, func() (bool, error) {
+				set, err := testEnv.MockInMemoryGetDNSSetEx(mockName, zonePrefix, "e0."+domain)
+				if err != nil || set == nil || set.Sets[dns.RS_A] == nil {
+					return false, err
+				}
+				records := set.Sets[dns.RS_A].Records
+				return len(records) == 1 && records[0].Value == target, nil
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+		}
+
+		// the primary provider keeps serving while the mirror provider is down
+		err = testEnv.AwaitEntryReady(e.GetName())
+		Ω(err).ShouldNot(HaveOccurred())
+		awaitTargets(testEnv.Namespace, testEnv.ZonePrefix, "1.1.0.0")
+		awaitMirrorState(false)
+
+		mpr, err = testEnv.UpdateProviderSpec(mpr, func(spec *v1alpha1.DNSProviderSpec) error {
+			spec.ProviderConfig = mirrorConfig()
+			return nil
+		})
+		Ω(err).ShouldNot(HaveOccurred())
+		checkProvider(mpr)
+		err = testEnv.AwaitEntryReady(mirrorEntry)
+		Ω(err).ShouldNot(HaveOccurred())
+		awaitTargets(mirrorMock, mirrorPrefix, "1.1.0.0")
+		awaitMirrorState(true)
+
+		// the mirror provider follows any change of the primary entry
+		e, err = testEnv.UpdateEntryTargets(e, "1.1.0.1")
+		Ω(err).ShouldNot(HaveOccurred())
+		awaitTargets(testEnv.Namespace, testEnv.ZonePrefix, "1.1.0.1")
+		awaitTargets(mirrorMock, mirrorPrefix, "1.1.0.1")
+
+		err = testEnv.DeleteEntryAndWait(e)
+		Ω(err).ShouldNot(HaveOccurred())
+		err = testEnv.AwaitEntryDeletion(mirrorEntry)
+		Ω(err).ShouldNot(HaveOccurred())
+		err = testEnv.MockInMemoryHasNotEntryEx(mirrorMock, mirrorPrefix, e)
+		Ω(err).ShouldNot(HaveOccurred())
+	})
+})
@@ -7,8 +7,11 @@ package integration
 import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
 
 	"github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsprovider "github.com/gardener/external-dns-management/pkg/dns/provider"
 )
 
 var _ = Describe("SingleEntryOneProvider", func() {
@@ -128,4 +131,90 @@ var _ = Describe("SingleEntryOneProvider", func() {
 		err = testEnv.DeleteProviderAndSecret(pr)
 		Ω(err).ShouldNot(HaveOccurred())
 	})
+	It("should release entries without deleting records on force cleanup", func() {
+		pr, domain, _, err := testEnv.CreateSecretAndProvider("pr-1.inmemory.mock", 0, FailDeleteEntry)
+		Ω(err).ShouldNot(HaveOccurred())
+		defer testEnv.DeleteProviderAndSecret(pr)
+
+		checkProvider(pr)
+
+		e, err := testEnv.CreateEntry(0, domain)
+		Ω(err).ShouldNot(HaveOccurred())
+		defer testEnv.DeleteEntryAndWait(e)
+		checkEntry(e, pr)
+
+		err = testEnv.AwaitEntryReady(e.GetName())
+		Ω(err).ShouldNot(HaveOccurred())
+
+		err = testEnv.AnnotateObject(pr, dnsprovider.AnnotationForceCleanup, "true")
+		Ω(err).ShouldNot(HaveOccurred())
+
+		err = pr.Delete()
+		Ω(err).ShouldNot(HaveOccurred())
+
+		err = testEnv.AwaitProviderDeletion(pr.GetName())
+		Ω(err).ShouldNot(HaveOccurred())
+
+		err = testEnv.AwaitEntryError(e.GetName())
+		Ω(err).ShouldNot(HaveOccurred())
+
+		err = testEnv.AwaitFinalizers(e)
+		Ω(err).ShouldNot(HaveOccurred())
+	})
+	It("should clamp the TTL to the minimum TTL of the provider", func() {
+		pr, domain, _, err := testEnv.CreateSecretAndProvider("pr-1.inmemory.mock", 0, MinTTL120)
+		Ω(err).ShouldNot(HaveOccurred())
+		defer testEnv.DeleteProviderAndSecret(pr)
+
+		checkProvider(pr)
+
+		e, err := testEnv.CreateEntryGeneric(0, func(e *v1alpha1.DNSEntry) {
+			e.Spec.TTL = ptr.To[int64](60)
+			e.Spec.DNSName = "e0." + domain
+			e.Spec.Targets = []string{"1.1.0.0"}
+		})
+		Ω(err).ShouldNot(HaveOccurred())
+		defer testEnv.DeleteEntryAndWait(e)
+
+		err = testEnv.AwaitEntryReady(e.GetName())
+		Ω(err).ShouldNot(HaveOccurred())
+
+		e, err = testEnv.GetEntry(e.GetName())
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(UnwrapEntry(e).Status.TTL).Should(Equal(ptr.To[int64](120)))
+
+		set, err := testEnv.MockInMemoryGetDNSSet("e0." + domain)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(set).ShouldNot(BeNil())
+		Ω(set.Sets[dns.RS_A].TTL).Should(Equal(int64(120)))
+	})
+	It("should preserve the records on deletion if requested", func() {
+		pr, domain, _, err := testEnv.CreateSecretAndProvider("pr-1.inmemory.mock", 0)
+		Ω(err).ShouldNot(HaveOccurred())
+		defer testEnv.DeleteProviderAndSecret(pr)
+
+		checkProvider(pr)
+
+		e, err := testEnv.CreateEntryGeneric(0, func(e *v1alpha1.DNSEntry) {
+			e.Spec.DNSName = "e0." + domain
+			e.Spec.Targets = []string{"1.1.0.0"}
+			e.Spec.PreserveOnDelete = ptr.To(true)
+		})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		err = testEnv.AwaitEntryReady(e.GetName())
+		Ω(err).ShouldNot(HaveOccurred())
+
+		err = e.Delete()
+		Ω(err).ShouldNot(HaveOccurred())
+
+		err = testEnv.AwaitEntryDeletion(e.GetName())
+		Ω(err).ShouldNot(HaveOccurred())
+
+		set, err := testEnv.MockInMemoryGetDNSSet("e0." + domain)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(set).ShouldNot(BeNil())
+		Ω(set.Sets[dns.RS_A]).ShouldNot(BeNil())
+		Ω(set.GetOwner()).Should(BeEmpty())
+	})
 })
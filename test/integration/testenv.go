@@ -57,6 +57,7 @@ const (
 	PrivateZones
 	Quotas4PerMin
 	RemoveAccess
+	MinTTL120
 )
 
 type TestEnv struct {
@@ -292,6 +293,8 @@ func (te *TestEnv) BuildProviderConfigEx(input mock.MockConfig, failOptions ...P
 			input.FailGetZones = true
 		case FailDeleteEntry:
 			input.FailDeleteEntry = true
+		case MinTTL120:
+			input.MinTTL = 120
 		case FailSecondZoneWithSameBaseDomain:
 			input.Zones = append(input.Zones, mock.MockZone{
 				ZonePrefix: te.ZonePrefix + ":second",
@@ -1290,6 +1293,11 @@ func (te *TestEnv) MockInMemoryGetDNSSet(dnsName string) (*dns.DNSSet, error) {
 }
 
 func (te *TestEnv) MockInMemoryGetDNSSetEx(name, zonePrefix, dnsName string) (*dns.DNSSet, error) {
+	return te.MockInMemoryGetDNSSetByName(name, zonePrefix, dns.DNSSetName{DNSName: dnsName})
+}
+
+func (te *TestEnv) MockInMemoryGetDNSSetByName(name, zonePrefix string, setName dns.DNSSetName) (*dns.DNSSet, error) {
+	dnsName := setName.DNSName
 	testMock := mock.TestMock[name]
 	if testMock == nil {
 		return nil, nil
@@ -1300,7 +1308,7 @@ func (te *TestEnv) MockInMemoryGetDNSSetEx(name, zonePrefix, dnsName string) (*d
 			if err != nil {
 				return nil, err
 			}
-			if set := state.GetDNSSets()[dns.DNSSetName{DNSName: dnsName}]; set != nil {
+			if set := state.GetDNSSets()[setName]; set != nil {
 				return set, nil
 			}
 		}